@@ -0,0 +1,122 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterPushSecretSpec defines the desired state of ClusterPushSecret.
+type ClusterPushSecretSpec struct {
+	// The spec for the PushSecrets to be created.
+	// The remote keys of the data are rendered as Go templates for every namespace,
+	// `{{ .namespace }}` and `{{ .secretName }}` refer to the namespace and the name of the source Secret.
+	PushSecretSpec PushSecretSpec `json:"pushSecretSpec"`
+
+	// The name of the push secrets to be created defaults to the name of the ClusterPushSecret
+	// +optional
+	PushSecretName string `json:"pushSecretName,omitempty"`
+
+	// The metadata of the push secrets to be created
+	// +optional
+	PushSecretMetadata PushSecretMetadata `json:"pushSecretMetadata,omitempty"`
+
+	// A list of labels to select by to find the Namespaces of the source Secrets to create the PushSecrets in. The selectors are ORed.
+	// +optional
+	NamespaceSelectors []*metav1.LabelSelector `json:"namespaceSelectors,omitempty"`
+
+	// Choose namespaces by name. This field is ORed with anything that NamespaceSelectors ends up choosing.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// The time in which the controller should reconcile its objects and recheck namespaces for labels.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshTime,omitempty"`
+}
+
+// PushSecretMetadata defines metadata fields for the PushSecret generated by the ClusterPushSecret.
+type PushSecretMetadata struct {
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type ClusterPushSecretConditionType string
+
+const ClusterPushSecretReady ClusterPushSecretConditionType = "Ready"
+
+type ClusterPushSecretStatusCondition struct {
+	Type   ClusterPushSecretConditionType `json:"type"`
+	Status corev1.ConditionStatus         `json:"status"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterPushSecretNamespaceFailure represents a failed namespace deployment and its reason.
+type ClusterPushSecretNamespaceFailure struct {
+	// Namespace is the namespace that failed when trying to apply a PushSecret
+	Namespace string `json:"namespace"`
+
+	// Reason is why the PushSecret failed to apply to the namespace
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClusterPushSecretStatus defines the observed state of ClusterPushSecret.
+type ClusterPushSecretStatus struct {
+	// PushSecretName is the name of the PushSecrets created by the ClusterPushSecret
+	PushSecretName string `json:"pushSecretName,omitempty"`
+
+	// Failed namespaces are the namespaces that failed to apply a PushSecret
+	// +optional
+	FailedNamespaces []ClusterPushSecretNamespaceFailure `json:"failedNamespaces,omitempty"`
+
+	// ProvisionedNamespaces are the namespaces where the ClusterPushSecret has PushSecrets
+	// +optional
+	ProvisionedNamespaces []string `json:"provisionedNamespaces,omitempty"`
+
+	// +optional
+	Conditions []ClusterPushSecretStatusCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,categories={pushsecrets},shortName=cps
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.spec.refreshTime`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// ClusterPushSecret is the Schema for the clusterpushsecrets API.
+type ClusterPushSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterPushSecretSpec   `json:"spec,omitempty"`
+	Status ClusterPushSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterPushSecretList contains a list of ClusterPushSecret.
+type ClusterPushSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPushSecret `json:"items"`
+}
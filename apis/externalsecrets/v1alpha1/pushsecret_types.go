@@ -23,8 +23,10 @@ import (
 )
 
 const (
-	ReasonSynced  = "Synced"
-	ReasonErrored = "Errored"
+	ReasonSynced         = "Synced"
+	ReasonErrored        = "Errored"
+	ReasonPending        = "Pending"
+	ReasonSecretExpiring = "SecretExpiring"
 )
 
 type PushSecretStoreRef struct {
@@ -92,14 +94,26 @@ type PushSecretSecret struct {
 	Name string `json:"name"`
 }
 
+// PushSecretSelector selects the source of the pushed data.
+// Exactly one of secret or generatorRef must be set.
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
 type PushSecretSelector struct {
 	// Select a Secret to Push.
-	Secret PushSecretSecret `json:"secret"`
+	// +optional
+	Secret *PushSecretSecret `json:"secret,omitempty"`
+	// GeneratorRef points to a generator custom resource whose output is pushed.
+	// The generated values are not stored in a Kubernetes Secret,
+	// a new value is generated and pushed on every refresh.
+	// +optional
+	GeneratorRef *esv1beta1.GeneratorRef `json:"generatorRef,omitempty"`
 }
 
 type PushSecretRemoteRef struct {
 	// Name of the resulting provider secret.
-	RemoteKey string `json:"remoteKey"`
+	// Not used with secretKeyRegex, the remote keys are derived from the matching secret keys.
+	// +optional
+	RemoteKey string `json:"remoteKey,omitempty"`
 
 	// Name of the property in the resulting secret
 	// +optional
@@ -118,6 +132,10 @@ type PushSecretMatch struct {
 	// Secret Key to be pushed
 	// +optional
 	SecretKey string `json:"secretKey,omitempty"`
+	// SecretKeyRegex pushes every Secret Key matching the regular expression.
+	// Each key is pushed to a provider secret of the same name, after applying the rewrite operations.
+	// +optional
+	SecretKeyRegex string `json:"secretKeyRegex,omitempty"`
 	// Remote Refs to push to providers.
 	RemoteRef PushSecretRemoteRef `json:"remoteRef"`
 }
@@ -133,6 +151,10 @@ type PushSecretData struct {
 	// Used to define a conversion Strategy for the secret keys
 	// +kubebuilder:default="None"
 	ConversionStrategy PushSecretConversionStrategy `json:"conversionStrategy,omitempty"`
+	// Used to rewrite the keys matched by secretKeyRegex to the names of the provider secrets.
+	// Multiple Rewrite operations can be provided. They are applied in a layered order (first to last)
+	// +optional
+	Rewrite []esv1beta1.ExternalSecretRewrite `json:"rewrite,omitempty"`
 }
 
 func (d PushSecretData) GetMetadata() *apiextensionsv1.JSON {
@@ -156,6 +178,9 @@ type PushSecretConditionType string
 
 const (
 	PushSecretReady PushSecretConditionType = "Ready"
+	// PushSecretExpiring indicates that a pushed secret expires soon,
+	// as reported by providers that support expiry reporting.
+	PushSecretExpiring PushSecretConditionType = "Expiring"
 )
 
 // PushSecretStatusCondition indicates the status of the PushSecret.
@@ -67,9 +67,27 @@ var (
 	PushSecretGroupVersionKind = SchemeGroupVersion.WithKind(PushSecretKind)
 )
 
+// ClusterPushSecret type metadata.
+var (
+	ClusterPushSecretKind             = reflect.TypeOf(ClusterPushSecret{}).Name()
+	ClusterPushSecretGroupKind        = schema.GroupKind{Group: Group, Kind: ClusterPushSecretKind}.String()
+	ClusterPushSecretKindAPIVersion   = ClusterPushSecretKind + "." + SchemeGroupVersion.String()
+	ClusterPushSecretGroupVersionKind = SchemeGroupVersion.WithKind(ClusterPushSecretKind)
+)
+
+// SecretRotation type metadata.
+var (
+	SecretRotationKind             = reflect.TypeOf(SecretRotation{}).Name()
+	SecretRotationGroupKind        = schema.GroupKind{Group: Group, Kind: SecretRotationKind}.String()
+	SecretRotationKindAPIVersion   = SecretRotationKind + "." + SchemeGroupVersion.String()
+	SecretRotationGroupVersionKind = SchemeGroupVersion.WithKind(SecretRotationKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ExternalSecret{}, &ExternalSecretList{})
 	SchemeBuilder.Register(&SecretStore{}, &SecretStoreList{})
 	SchemeBuilder.Register(&ClusterSecretStore{}, &ClusterSecretStoreList{})
 	SchemeBuilder.Register(&PushSecret{}, &PushSecretList{})
+	SchemeBuilder.Register(&ClusterPushSecret{}, &ClusterPushSecretList{})
+	SchemeBuilder.Register(&SecretRotation{}, &SecretRotationList{})
 }
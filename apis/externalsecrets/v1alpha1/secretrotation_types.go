@@ -0,0 +1,165 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	// AnnotationRotate triggers a rotation of a SecretRotation whenever its value changes.
+	AnnotationRotate = "external-secrets.io/rotate"
+	// AnnotationRotationID is set by a SecretRotation on the Secrets, PushSecret and ExternalSecrets
+	// of a rotation, the value is the id of the rotation.
+	AnnotationRotationID = "external-secrets.io/rotation-id"
+)
+
+// SecretRotationSpec defines the desired state of SecretRotation.
+type SecretRotationSpec struct {
+	// GeneratorRef points to the generator of the new credential.
+	// If the generator supports it, the previous credential is revoked once the consumers picked up the new one.
+	GeneratorRef esv1beta1.GeneratorRef `json:"generatorRef"`
+
+	// PushSecretName is the name of the PushSecret that pushes the credential to the providers.
+	// Its selector must reference a Secret, the new credential is written to that Secret.
+	PushSecretName string `json:"pushSecretName"`
+
+	// RotationInterval rotates the credential periodically, measured from the last completed rotation.
+	// Without an interval the credential is only rotated on creation and whenever
+	// the external-secrets.io/rotate annotation changes.
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+
+	// Verification configures how the rotation checks that the consumers picked up the new credential.
+	// +optional
+	Verification *SecretRotationVerification `json:"verification,omitempty"`
+}
+
+// SecretRotationVerification configures the check that the consumers picked up the new credential,
+// the previous credential is revoked only once the check passed.
+type SecretRotationVerification struct {
+	// ExternalSecretNames are the ExternalSecrets in the namespace of the SecretRotation that read the credential
+	// from the providers. They are refreshed once the credential was pushed and must be ready afterwards.
+	// +optional
+	ExternalSecretNames []string `json:"externalSecretNames,omitempty"`
+
+	// GracePeriod is waited once the ExternalSecrets synced, e.g. for the workloads to reload the credential.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// Timeout fails the rotation if the credential is not pushed and picked up in time,
+	// the previous credential is not revoked.
+	// Defaults to 1h
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// SecretRotationPhase is the phase of a rotation.
+type SecretRotationPhase string
+
+const (
+	// SecretRotationPhaseGenerating generates the new credential and writes it to the Secret of the PushSecret.
+	SecretRotationPhaseGenerating SecretRotationPhase = "Generating"
+	// SecretRotationPhasePushing waits for the PushSecret to push the new credential.
+	SecretRotationPhasePushing SecretRotationPhase = "Pushing"
+	// SecretRotationPhaseVerifying waits for the consumers to pick up the new credential.
+	SecretRotationPhaseVerifying SecretRotationPhase = "Verifying"
+	// SecretRotationPhaseRevoking revokes the previous credential.
+	SecretRotationPhaseRevoking SecretRotationPhase = "Revoking"
+	// SecretRotationPhaseCompleted is set once the rotation completed.
+	SecretRotationPhaseCompleted SecretRotationPhase = "Completed"
+	// SecretRotationPhaseFailed is set if the rotation failed, the previous credential is kept.
+	SecretRotationPhaseFailed SecretRotationPhase = "Failed"
+)
+
+type SecretRotationConditionType string
+
+const SecretRotationReady SecretRotationConditionType = "Ready"
+
+type SecretRotationStatusCondition struct {
+	Type   SecretRotationConditionType `json:"type"`
+	Status corev1.ConditionStatus      `json:"status"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// SecretRotationStatus defines the observed state of SecretRotation.
+type SecretRotationStatus struct {
+	// RotationID identifies the current or last rotation.
+	// +optional
+	RotationID string `json:"rotationID,omitempty"`
+
+	// Phase of the current or last rotation.
+	// +optional
+	Phase SecretRotationPhase `json:"phase,omitempty"`
+
+	// Trigger is the value of the external-secrets.io/rotate annotation of the current or last rotation.
+	// +optional
+	Trigger string `json:"trigger,omitempty"`
+
+	// StartTime is the time the current or last rotation started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// PushTime is the time the PushSecret pushed the new credential.
+	// +optional
+	PushTime *metav1.Time `json:"pushTime,omitempty"`
+
+	// LastRotationTime is the time the last rotation completed.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// +optional
+	Conditions []SecretRotationStatusCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Namespaced,categories={pushsecrets}
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Last Rotation",type="date",JSONPath=`.status.lastRotationTime`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// SecretRotation rotates a credential in a handshake: a generator creates the new credential,
+// a PushSecret pushes it to the providers, the consumers are verified to have picked it up
+// and the previous credential is revoked.
+type SecretRotation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretRotationSpec   `json:"spec,omitempty"`
+	Status SecretRotationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretRotationList contains a list of SecretRotation.
+type SecretRotationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretRotation `json:"items"`
+}
@@ -30,6 +30,19 @@ type SecretStoreRef struct {
 	Kind string `json:"kind,omitempty"`
 }
 
+// ExternalSecretTargetKind defines the kind of the resource managed by the ExternalSecret.
+// +kubebuilder:validation:Enum=Secret;ConfigMap
+type ExternalSecretTargetKind string
+
+const (
+	// TargetKindSecret writes the data to a Secret.
+	TargetKindSecret ExternalSecretTargetKind = "Secret"
+
+	// TargetKindConfigMap writes the data to a ConfigMap.
+	// Values that are not valid UTF-8 are stored in .binaryData.
+	TargetKindConfigMap ExternalSecretTargetKind = "ConfigMap"
+)
+
 // ExternalSecretCreationPolicy defines rules on how to create the resulting Secret.
 // +kubebuilder:validation:Enum=Owner;Orphan;Merge;None
 type ExternalSecretCreationPolicy string
@@ -50,7 +63,7 @@ const (
 )
 
 // ExternalSecretDeletionPolicy defines rules on how to delete the resulting Secret.
-// +kubebuilder:validation:Enum=Delete;Merge;Retain
+// +kubebuilder:validation:Enum=Delete;Merge;Retain;Archive
 type ExternalSecretDeletionPolicy string
 
 const (
@@ -70,8 +83,24 @@ const (
 	// If a provider secret does not exist the ExternalSecret gets into the
 	// SecretSyncedError status.
 	DeletionPolicyRetain ExternalSecretDeletionPolicy = "Retain"
+
+	// Archive removes keys in the secret like Merge, but moves the
+	// last values of the removed keys to a timestamped archive Secret first.
+	// The number of archive Secrets is limited by target.archive.retention.
+	DeletionPolicyArchive ExternalSecretDeletionPolicy = "Archive"
 )
 
+// ExternalSecretArchive configures the archive Secrets of deletionPolicy=Archive.
+type ExternalSecretArchive struct {
+	// Retention is the number of archive Secrets to keep,
+	// the oldest archive Secrets are deleted first.
+	// Defaults to 5
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	Retention int `json:"retention,omitempty"`
+}
+
 // ExternalSecretTemplateMetadata defines metadata fields for the Secret blueprint.
 type ExternalSecretTemplateMetadata struct {
 	// +optional
@@ -166,6 +195,13 @@ type ExternalSecretTarget struct {
 	// +optional
 	Name string `json:"name,omitempty"`
 
+	// Kind defines the kind of the resource to be managed, either Secret or ConfigMap.
+	// Use ConfigMap for non-sensitive data, e.g. feature flags or public keys.
+	// Defaults to 'Secret'
+	// +optional
+	// +kubebuilder:default="Secret"
+	Kind ExternalSecretTargetKind `json:"kind,omitempty"`
+
 	// CreationPolicy defines rules on how to create the resulting Secret
 	// Defaults to 'Owner'
 	// +optional
@@ -176,6 +212,9 @@ type ExternalSecretTarget struct {
 	// +optional
 	// +kubebuilder:default="Retain"
 	DeletionPolicy ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Archive configures the archive Secrets when using deletionPolicy=Archive
+	// +optional
+	Archive *ExternalSecretArchive `json:"archive,omitempty"`
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
 	Template *ExternalSecretTemplate `json:"template,omitempty"`
@@ -183,6 +222,120 @@ type ExternalSecretTarget struct {
 	// Immutable defines if the final secret will be immutable
 	// +optional
 	Immutable bool `json:"immutable,omitempty"`
+
+	// Rotation defines how an immutable Secret is replaced when its data changes.
+	// Requires immutable to be true.
+	// +optional
+	Rotation *ExternalSecretRotation `json:"rotation,omitempty"`
+
+	// Encryption seals the values of the Secret with a public key,
+	// for clusters that require encryption of secret values at the application layer.
+	// The workloads decrypt the values with the private key, e.g. in an init container.
+	// Requires target.kind=Secret and creationPolicy=Owner or creationPolicy=Orphan.
+	// +optional
+	Encryption *ExternalSecretEncryption `json:"encryption,omitempty"`
+
+	// Validation checks the rendered values before they are written, to catch values
+	// corrupted by the provider: truncated PEM blocks, invalid JSON in .dockerconfigjson
+	// or .dockercfg keys and passwords with suspiciously low entropy.
+	// +optional
+	Validation *ExternalSecretValidation `json:"validation,omitempty"`
+
+	// Chunking splits values that exceed the maximum size of a Secret across chunk Secrets,
+	// e.g. large binary files. The target Secret holds an index of the chunks.
+	// Requires target.kind=Secret and creationPolicy=Owner or creationPolicy=Orphan.
+	// +optional
+	Chunking *ExternalSecretChunking `json:"chunking,omitempty"`
+}
+
+// ExternalSecretChunking configures the chunking of large values.
+// Once the values exceed the maximum size of a Secret, the largest values are moved
+// to chunk Secrets named <target>-chunk-<n>, each holding a part of one value
+// under the key of the value. The index annotation of the target Secret lists the
+// chunk Secrets of every moved key in order, their concatenated parts are the value.
+type ExternalSecretChunking struct {
+	// ChunkSize is the maximum size of a chunk in bytes.
+	// Defaults to the maximum Secret size of the controller, 1MiB unless configured otherwise.
+	// +optional
+	// +kubebuilder:validation:Minimum=1024
+	ChunkSize *int32 `json:"chunkSize,omitempty"`
+
+	// MaxChunks is the maximum number of chunk Secrets, larger values fail the sync.
+	// Defaults to 16
+	// +optional
+	// +kubebuilder:default=16
+	// +kubebuilder:validation:Minimum=1
+	MaxChunks *int32 `json:"maxChunks,omitempty"`
+}
+
+// ExternalSecretValidationAction defines the action taken on invalid values.
+// +kubebuilder:validation:Enum=Fail;Warn
+type ExternalSecretValidationAction string
+
+const (
+	// ValidationActionFail fails the sync and keeps the existing target.
+	ValidationActionFail ExternalSecretValidationAction = "Fail"
+	// ValidationActionWarn records a warning event and writes the target.
+	ValidationActionWarn ExternalSecretValidationAction = "Warn"
+)
+
+// ExternalSecretValidation configures the validation of the rendered values.
+type ExternalSecretValidation struct {
+	// Action taken if a value is invalid, either Fail or Warn.
+	// Defaults to 'Fail'
+	// +optional
+	// +kubebuilder:default="Fail"
+	Action ExternalSecretValidationAction `json:"action,omitempty"`
+
+	// PasswordKeys are regexes matching the keys holding passwords,
+	// whose values are checked for low entropy.
+	// Defaults to keys containing password, passwd or pwd, case-insensitive.
+	// +optional
+	PasswordKeys []string `json:"passwordKeys,omitempty"`
+
+	// MinPasswordEntropy is the minimum Shannon entropy of the password values in bits.
+	// Defaults to 32, 0 disables the entropy check.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinPasswordEntropy *int32 `json:"minPasswordEntropy,omitempty"`
+}
+
+// ExternalSecretEncryption configures the encryption of the values of the target Secret.
+// Every value is sealed as JWE compact serialization, with RSA-OAEP-256 for RSA keys
+// or ECDH-ES+A256KW for EC keys, and A256GCM content encryption.
+type ExternalSecretEncryption struct {
+	// PublicKey is the PEM encoded RSA or EC public key the values are sealed with,
+	// e.g. the public key of an asymmetric KMS key.
+	PublicKey string `json:"publicKey"`
+
+	// KeyID is set as kid header of the sealed values, e.g. to select the decryption key.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// ExternalSecretRotationStrategy defines how immutable Secrets are rotated.
+// +kubebuilder:validation:Enum=Suffix
+type ExternalSecretRotationStrategy string
+
+const (
+	// RotationStrategySuffix creates a new Secret named <name>-<hash of the data>
+	// whenever the data changes.
+	RotationStrategySuffix ExternalSecretRotationStrategy = "Suffix"
+)
+
+// ExternalSecretRotation configures the rotation of immutable Secrets.
+type ExternalSecretRotation struct {
+	// Strategy used to rotate the Secret.
+	// +kubebuilder:default="Suffix"
+	Strategy ExternalSecretRotationStrategy `json:"strategy,omitempty"`
+
+	// Generations is the number of previous Secrets to keep
+	// after a new Secret has been created, older Secrets are deleted.
+	// Defaults to 2
+	// +optional
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=0
+	Generations *int `json:"generations,omitempty"`
 }
 
 // ExternalSecretData defines the connection between the Kubernetes Secret key (spec.data.<key>) and the Provider data.
@@ -198,6 +351,12 @@ type ExternalSecretData struct {
 	// SourceRef allows you to override the source
 	// from which the value will pulled from.
 	SourceRef *StoreSourceRef `json:"sourceRef,omitempty"`
+
+	// IgnoreChangesRegex lists regular expressions matching the parts of the value
+	// that change on every read, e.g. timestamps. When the value only changed
+	// within the matches, the value of the target is kept as-is.
+	// +optional
+	IgnoreChangesRegex []string `json:"ignoreChangesRegex,omitempty"`
 }
 
 // ExternalSecretDataRemoteRef defines Provider data location.
@@ -227,6 +386,16 @@ type ExternalSecretDataRemoteRef struct {
 	// Used to define a decoding Strategy
 	// +kubebuilder:default="None"
 	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+
+	// +optional
+	// Used to normalize line endings and whitespace of the value after decoding
+	Normalize *ExternalSecretNormalization `json:"normalize,omitempty"`
+
+	// +optional
+	// FilterPath is a JMESPath expression applied to the extracted map before the rewrite rules,
+	// it must evaluate to an object. Values holding JSON objects or arrays can be queried.
+	// Only supported in dataFrom.extract.
+	FilterPath string `json:"filterPath,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=None;Fetch
@@ -245,6 +414,41 @@ const (
 	ExternalSecretConversionUnicode ExternalSecretConversionStrategy = "Unicode"
 )
 
+// ExternalSecretNormalization defines how line endings and whitespace
+// of the values returned by the provider are normalized.
+// Line endings are converted before the value is trimmed.
+type ExternalSecretNormalization struct {
+	// LineEndings defines how line endings are converted.
+	// LF replaces CRLF and CR line endings with LF.
+	// +optional
+	// +kubebuilder:default="Preserve"
+	LineEndings ExternalSecretLineEndings `json:"lineEndings,omitempty"`
+
+	// Trim defines which whitespace is removed from the value.
+	// TrailingNewline removes trailing CR and LF characters,
+	// Whitespace removes all leading and trailing whitespace.
+	// +optional
+	// +kubebuilder:default="None"
+	Trim ExternalSecretTrimPolicy `json:"trim,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Preserve;LF
+type ExternalSecretLineEndings string
+
+const (
+	ExternalSecretLineEndingsPreserve ExternalSecretLineEndings = "Preserve"
+	ExternalSecretLineEndingsLF       ExternalSecretLineEndings = "LF"
+)
+
+// +kubebuilder:validation:Enum=None;TrailingNewline;Whitespace
+type ExternalSecretTrimPolicy string
+
+const (
+	ExternalSecretTrimNone            ExternalSecretTrimPolicy = "None"
+	ExternalSecretTrimTrailingNewline ExternalSecretTrimPolicy = "TrailingNewline"
+	ExternalSecretTrimWhitespace      ExternalSecretTrimPolicy = "Whitespace"
+)
+
 // +kubebuilder:validation:Enum=Auto;Base64;Base64URL;None
 type ExternalSecretDecodingStrategy string
 
@@ -277,6 +481,24 @@ type ExternalSecretDataFromRemoteRef struct {
 	// When sourceRef points to a generator Extract or Find is not supported.
 	// The generator returns a static map of values
 	SourceRef *StoreGeneratorSourceRef `json:"sourceRef,omitempty"`
+
+	// Bundle aggregates the certificates found by Find into a single PEM bundle
+	// instead of writing a key per secret found.
+	// Note: Bundle is only supported with Find.
+	// +optional
+	Bundle *ExternalSecretCertificateBundle `json:"bundle,omitempty"`
+}
+
+// ExternalSecretCertificateBundle aggregates certificates into a single PEM bundle, e.g. a CA bundle.
+// The values may hold PEM or DER encoded certificates, other PEM blocks are ignored.
+// The certificates are deduplicated and sorted by subject, so the bundle only changes with its certificates.
+type ExternalSecretCertificateBundle struct {
+	// Key is the key of the bundle in the target.
+	Key string `json:"key"`
+
+	// KeepExpired keeps expired certificates in the bundle, they are dropped by default.
+	// +optional
+	KeepExpired bool `json:"keepExpired,omitempty"`
 }
 
 type ExternalSecretRewrite struct {
@@ -289,6 +511,11 @@ type ExternalSecretRewrite struct {
 	// The resulting key will be the output of the template applied by the operation.
 	// +optional
 	Transform *ExternalSecretRewriteTransform `json:"transform,omitempty"`
+
+	// Used to apply a predefined operation on the secret keys.
+	// The resulting key will be the output of the operation.
+	// +optional
+	Operation *ExternalSecretRewriteOperation `json:"operation,omitempty"`
 }
 
 type ExternalSecretRewriteRegexp struct {
@@ -304,6 +531,32 @@ type ExternalSecretRewriteTransform struct {
 	Template string `json:"template"`
 }
 
+// +kubebuilder:validation:Enum=ToUpper;ToLower;KebabCase;SnakeCase;TrimPrefix;TrimSuffix;Base64Encode;Base64Decode;SHA256
+type ExternalSecretRewriteOperationType string
+
+const (
+	RewriteOperationToUpper      ExternalSecretRewriteOperationType = "ToUpper"
+	RewriteOperationToLower      ExternalSecretRewriteOperationType = "ToLower"
+	RewriteOperationKebabCase    ExternalSecretRewriteOperationType = "KebabCase"
+	RewriteOperationSnakeCase    ExternalSecretRewriteOperationType = "SnakeCase"
+	RewriteOperationTrimPrefix   ExternalSecretRewriteOperationType = "TrimPrefix"
+	RewriteOperationTrimSuffix   ExternalSecretRewriteOperationType = "TrimSuffix"
+	RewriteOperationBase64Encode ExternalSecretRewriteOperationType = "Base64Encode"
+	RewriteOperationBase64Decode ExternalSecretRewriteOperationType = "Base64Decode"
+	RewriteOperationSHA256       ExternalSecretRewriteOperationType = "SHA256"
+)
+
+type ExternalSecretRewriteOperation struct {
+	// Used to define the operation applied to the secret keys.
+	// Base64Encode uses the URL-safe alphabet without padding, so the result is a valid secret key.
+	// SHA256 replaces the key with its hex encoded digest.
+	Type ExternalSecretRewriteOperationType `json:"type"`
+
+	// Used to define the prefix or suffix removed by TrimPrefix and TrimSuffix.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
 type ExternalSecretFind struct {
 	// A root path to start the find operations.
 	// +optional
@@ -326,6 +579,10 @@ type ExternalSecretFind struct {
 	// Used to define a decoding Strategy
 	// +kubebuilder:default="None"
 	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+
+	// +optional
+	// Used to normalize line endings and whitespace of the value after decoding
+	Normalize *ExternalSecretNormalization `json:"normalize,omitempty"`
 }
 
 type FindName struct {
@@ -338,6 +595,12 @@ type FindName struct {
 type ExternalSecretSpec struct {
 	// +optional
 	SecretStoreRef SecretStoreRef `json:"secretStoreRef,omitempty"`
+
+	// SecretStoreRefs fetches the data from multiple stores, as an alternative to secretStoreRef.
+	// Data and dataFrom entries without sourceRef are read from each store in the specified order,
+	// values of later stores take precedence. Stores that do not have a secret are skipped.
+	// +optional
+	SecretStoreRefs []SecretStoreRef `json:"secretStoreRefs,omitempty"`
 	// +kubebuilder:default={creationPolicy:Owner,deletionPolicy:Retain}
 	// +optional
 	Target ExternalSecretTarget `json:"target,omitempty"`
@@ -348,6 +611,14 @@ type ExternalSecretSpec struct {
 	// +kubebuilder:default="1h"
 	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 
+	// DriftCheckInterval is the amount of time before the target Secret is checked for local changes.
+	// Changed data is reverted to the values of the last sync without reading the provider again.
+	// Only Secret targets with creationPolicy Owner or Orphan are reverted, other targets and targets
+	// the controller has not synced since its start are refreshed from the provider instead.
+	// Defaults to 0, changed targets are refreshed from the provider.
+	// +optional
+	DriftCheckInterval *metav1.Duration `json:"driftCheckInterval,omitempty"`
+
 	// Data defines the connection between the Kubernetes Secret keys and the Provider data
 	// +optional
 	Data []ExternalSecretData `json:"data,omitempty"`
@@ -356,6 +627,45 @@ type ExternalSecretSpec struct {
 	// If multiple entries are specified, the Secret keys are merged in the specified order
 	// +optional
 	DataFrom []ExternalSecretDataFromRemoteRef `json:"dataFrom,omitempty"`
+
+	// DataFromMergePolicy defines the value of a key returned with different values by multiple dataFrom entries:
+	// Last takes the value of the last entry, First the value of the first entry and Error fails the sync.
+	// Conflicting keys are reported in the DataFromConflict condition. Defaults to Last.
+	// +kubebuilder:validation:Enum=Error;First;Last
+	// +kubebuilder:default="Last"
+	// +optional
+	DataFromMergePolicy ExternalSecretDataFromMergePolicy `json:"dataFromMergePolicy,omitempty"`
+
+	// RetryPolicy enables an exponential backoff after failed syncs.
+	// Without it, failed syncs are retried by the rate limiter of the controller.
+	// +optional
+	RetryPolicy *ExternalSecretRetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// ExternalSecretDataFromMergePolicy defines the value of a key returned with different values by multiple dataFrom entries.
+type ExternalSecretDataFromMergePolicy string
+
+const (
+	// DataFromMergePolicyLast takes the value of the last dataFrom entry returning the key.
+	DataFromMergePolicyLast ExternalSecretDataFromMergePolicy = "Last"
+	// DataFromMergePolicyFirst takes the value of the first dataFrom entry returning the key.
+	DataFromMergePolicyFirst ExternalSecretDataFromMergePolicy = "First"
+	// DataFromMergePolicyError fails the sync.
+	DataFromMergePolicyError ExternalSecretDataFromMergePolicy = "Error"
+)
+
+// ExternalSecretRetryPolicy configures the exponential backoff after consecutive failed syncs.
+// The interval starts at initialInterval and is doubled after every failed sync up to maxInterval.
+type ExternalSecretRetryPolicy struct {
+	// InitialInterval is the interval after the first failed sync.
+	// Defaults to the retry initial interval of the controller.
+	// +optional
+	InitialInterval *metav1.Duration `json:"initialInterval,omitempty"`
+
+	// MaxInterval is the longest interval between attempts.
+	// Defaults to the retry max interval of the controller.
+	// +optional
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
 }
 
 // StoreSourceRef allows you to override the SecretStore source
@@ -402,6 +712,21 @@ type ExternalSecretConditionType string
 const (
 	ExternalSecretReady   ExternalSecretConditionType = "Ready"
 	ExternalSecretDeleted ExternalSecretConditionType = "Deleted"
+	// ExternalSecretProtectionChanged indicates that the protection flags of a
+	// source secret changed since the last sync.
+	ExternalSecretProtectionChanged ExternalSecretConditionType = "ProtectionChanged"
+	// ExternalSecretAdopted indicates that the target was taken over
+	// from the resource that controlled it before.
+	ExternalSecretAdopted ExternalSecretConditionType = "Adopted"
+	// ExternalSecretExpiring indicates that a source secret expires soon,
+	// as reported by providers that support expiry reporting.
+	ExternalSecretExpiring ExternalSecretConditionType = "Expiring"
+	// ExternalSecretThrottled indicates that the provider throttles the requests
+	// and the sync is paused until the provider accepts requests again.
+	ExternalSecretThrottled ExternalSecretConditionType = "Throttled"
+	// ExternalSecretDataFromConflict indicates that multiple dataFrom entries
+	// returned different values for the same keys.
+	ExternalSecretDataFromConflict ExternalSecretConditionType = "DataFromConflict"
 )
 
 type ExternalSecretStatusCondition struct {
@@ -425,12 +750,37 @@ const (
 	ConditionReasonSecretSyncedError = "SecretSyncedError"
 	// ConditionReasonSecretDeleted indicates that the secret has been deleted.
 	ConditionReasonSecretDeleted = "SecretDeleted"
+	// ConditionReasonProtectionChanged indicates that the protection flags of a source secret changed.
+	ConditionReasonProtectionChanged = "ProtectionFlagsChanged"
+	// ConditionReasonProtectionUnchanged indicates that the protection flags of the source secrets are unchanged.
+	ConditionReasonProtectionUnchanged = "ProtectionFlagsUnchanged"
+	// ConditionReasonTargetAdopted indicates that the target was adopted from another controller.
+	ConditionReasonTargetAdopted = "TargetAdopted"
+	// ConditionReasonSecretExpiring indicates that a source secret expires soon.
+	ConditionReasonSecretExpiring = "SecretExpiring"
+	// ConditionReasonSecretDryRun indicates that the target was rendered without writing it.
+	ConditionReasonSecretDryRun = "SecretDryRun"
+	// ConditionReasonSecretLimitExceeded indicates that the rendered data exceeds the size or key count limits.
+	ConditionReasonSecretLimitExceeded = "SecretLimitExceeded"
+	// ConditionReasonProviderThrottled indicates that the provider throttles the requests.
+	ConditionReasonProviderThrottled = "ProviderThrottled"
+	// ConditionReasonSecretValidationFailed indicates that the rendered values failed the validation.
+	ConditionReasonSecretValidationFailed = "SecretValidationFailed"
+	// ConditionReasonDataFromKeyConflict indicates that multiple dataFrom entries returned different values for the same keys.
+	ConditionReasonDataFromKeyConflict = "DataFromKeyConflict"
 
 	ReasonUpdateFailed = "UpdateFailed"
 	ReasonDeprecated   = "ParameterDeprecated"
 	ReasonCreated      = "Created"
 	ReasonUpdated      = "Updated"
 	ReasonDeleted      = "Deleted"
+	// ReasonDriftRepaired indicates that local changes of the target were reverted to the last synced values.
+	ReasonDriftRepaired = "DriftRepaired"
+	// ReasonTargetRenamed indicates that the previous Secret of a renamed target was released.
+	ReasonTargetRenamed = "TargetRenamed"
+	// ReasonClusterSecretStoreDisabled indicates that the controller ignores an ExternalSecret
+	// using a ClusterSecretStore, as it does not process ClusterSecretStores.
+	ReasonClusterSecretStoreDisabled = "ClusterSecretStoreDisabled"
 )
 
 type ExternalSecretStatus struct {
@@ -447,6 +797,71 @@ type ExternalSecretStatus struct {
 
 	// Binding represents a servicebinding.io Provisioned Service reference to the secret
 	Binding corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	// SecretProtections records the protection flags of the source secrets,
+	// as reported by providers that support protection verification.
+	// +optional
+	SecretProtections []SecretProtectionStatus `json:"secretProtections,omitempty"`
+
+	// RenewalTime is the time the earliest expiring generated value, e.g. a certificate,
+	// is renewed. The ExternalSecret is refreshed at that time regardless of the refresh interval.
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// DryRun holds the result of the last dry run, it is removed once the target is synced.
+	// +optional
+	DryRun *ExternalSecretDryRunStatus `json:"dryRun,omitempty"`
+
+	// Failures is the number of consecutive failed syncs, it is reset by a successful sync.
+	// +optional
+	Failures int32 `json:"failures,omitempty"`
+
+	// NextRetryTime is the time of the next attempt after a failed sync.
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+}
+
+// ExternalSecretDryRunStatus describes the target rendered by a dry run
+// and how it differs from the existing target.
+type ExternalSecretDryRunStatus struct {
+	// RenderTime is the time the target was rendered.
+	RenderTime metav1.Time `json:"renderTime"`
+
+	// Keys of the rendered target.
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+
+	// Size of the rendered data in bytes.
+	Size int `json:"size"`
+
+	// AddedKeys are rendered keys that do not exist in the target.
+	// +optional
+	AddedKeys []string `json:"addedKeys,omitempty"`
+
+	// ChangedKeys are rendered keys whose value differs from the target.
+	// +optional
+	ChangedKeys []string `json:"changedKeys,omitempty"`
+
+	// RemovedKeys are keys the ExternalSecret wrote to the target that are not rendered anymore.
+	// +optional
+	RemovedKeys []string `json:"removedKeys,omitempty"`
+
+	// ConflictingKeys are rendered keys that exist in the target
+	// but have been written by another owner, they would be overwritten.
+	// +optional
+	ConflictingKeys []string `json:"conflictingKeys,omitempty"`
+}
+
+// SecretProtectionStatus holds the protection flags of a source secret.
+type SecretProtectionStatus struct {
+	// Key is the key of the secret in the provider.
+	Key string `json:"key"`
+
+	// Masked indicates whether the secret is masked in the provider.
+	Masked bool `json:"masked"`
+
+	// Protected indicates whether the secret is protected in the provider.
+	Protected bool `json:"protected"`
 }
 
 // +kubebuilder:object:root=true
@@ -473,6 +888,38 @@ const (
 	// LabelOwner points to the owning ExternalSecret resource
 	//  and is used to manage the lifecycle of a Secret
 	LabelOwner = "reconcile.external-secrets.io/created-by"
+	// AnnotationAllowAdoption can be set to "true" on an existing Secret
+	// to allow an ExternalSecret with creationPolicy=Owner to take it over
+	// from the resource that currently controls it.
+	AnnotationAllowAdoption = "reconcile.external-secrets.io/allow-adoption"
+	// LabelArchiveOf marks the archive Secrets of an ExternalSecret
+	// with deletionPolicy=Archive, the value is the hash of its namespace/name.
+	LabelArchiveOf = "reconcile.external-secrets.io/archive-of"
+	// AnnotationArchivedFrom holds the name of the Secret the archived keys were removed from.
+	AnnotationArchivedFrom = "reconcile.external-secrets.io/archived-from"
+	// LabelRotationOf marks the Secrets rotated by an ExternalSecret
+	// with target.rotation, the value is the hash of its namespace/name.
+	LabelRotationOf = "reconcile.external-secrets.io/rotation-of"
+	// LabelRotationCurrent is set to "true" on the current generation of a rotated Secret.
+	LabelRotationCurrent = "reconcile.external-secrets.io/rotation-current"
+	// AnnotationRotationAlias holds the stable name (target.name) of a rotated Secret.
+	AnnotationRotationAlias = "reconcile.external-secrets.io/alias"
+	// AnnotationDryRun can be set to "true" on an ExternalSecret to render and validate
+	// its target without writing it, the result is reported in status.dryRun.
+	AnnotationDryRun = "reconcile.external-secrets.io/dry-run"
+	// AnnotationManagedKeys lists the keys written by an ExternalSecret with creationPolicy=Merge,
+	// so keys removed from the ExternalSecret can be deleted while the other keys are preserved.
+	AnnotationManagedKeys = "reconcile.external-secrets.io/managed-keys"
+	// AnnotationEncryption is set on Secrets whose values are sealed, its value is the format of the sealed values.
+	AnnotationEncryption = "reconcile.external-secrets.io/encryption"
+	// AnnotationChunks is set on Secrets whose values are chunked, its value is a JSON index
+	// mapping every chunked key to its size, sha256 hash and the names of its chunk Secrets.
+	AnnotationChunks = "reconcile.external-secrets.io/chunks"
+	// LabelChunkOf marks the chunk Secrets of an ExternalSecret, the value is the hash of its namespace/name.
+	LabelChunkOf = "reconcile.external-secrets.io/chunk-of"
+	// AnnotationManager holds the identity of the controller that writes the target, set with --manager-id.
+	// Controllers with another identity refuse to update the target.
+	AnnotationManager = "reconcile.external-secrets.io/manager"
 )
 
 // +kubebuilder:object:root=true
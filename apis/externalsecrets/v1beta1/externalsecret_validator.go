@@ -16,21 +16,233 @@ package v1beta1
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 
+	"github.com/jmespath/go-jmespath"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-type ExternalSecretValidator struct{}
+// ExternalSecretValidator validates ExternalSecrets. If a Reader is set, the refresh interval
+// is checked against the minimum recommended by the providers of the referenced stores,
+// and the remote refs against the features the providers support.
+// +kubebuilder:object:generate=false
+type ExternalSecretValidator struct {
+	Reader client.Reader
+	// RejectShortRefreshInterval rejects refresh intervals below the recommended minimum instead of warning.
+	RejectShortRefreshInterval bool
+	// RejectUnsupportedRemoteRefFeatures rejects remote refs using features the provider doesn't support instead of warning.
+	RejectUnsupportedRemoteRefFeatures bool
+}
+
+func (esv *ExternalSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return esv.validate(ctx, obj)
+}
+
+func (esv *ExternalSecretValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return esv.validate(ctx, newObj)
+}
+
+func (esv *ExternalSecretValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	warnings, err := validateExternalSecret(obj)
+	if err != nil || esv.Reader == nil {
+		return warnings, err
+	}
+	es := obj.(*ExternalSecret)
+	refreshWarnings, refreshErr := esv.validateRefreshInterval(ctx, es)
+	featureWarnings, featureErr := esv.validateRemoteRefFeatures(ctx, es)
+	warnings = append(warnings, refreshWarnings...)
+	warnings = append(warnings, featureWarnings...)
+	return warnings, errors.Join(refreshErr, featureErr)
+}
+
+// validateRefreshInterval warns about refresh intervals shorter than recommended by the providers of the referenced stores.
+// Stores that can not be read are skipped, the ExternalSecret reports them once it is reconciled.
+func (esv *ExternalSecretValidator) validateRefreshInterval(ctx context.Context, es *ExternalSecret) (admission.Warnings, error) {
+	if es.Spec.RefreshInterval == nil || es.Spec.RefreshInterval.Duration <= 0 {
+		return nil, nil
+	}
+	interval := es.Spec.RefreshInterval.Duration
+
+	var (
+		warnings admission.Warnings
+		errs     error
+	)
+	for _, ref := range referencedStores(es) {
+		store, err := esv.getStore(ctx, ref, es.Namespace)
+		if err != nil {
+			continue
+		}
+		provider, err := GetProvider(store)
+		if err != nil {
+			continue
+		}
+		recommender, ok := provider.(RefreshIntervalRecommender)
+		if !ok || interval >= recommender.RecommendedMinRefreshInterval() {
+			continue
+		}
+		msg := fmt.Sprintf("refreshInterval %s is shorter than the minimum of %s recommended for %s %s",
+			interval, recommender.RecommendedMinRefreshInterval(), store.GetKind(), store.GetName())
+		if esv.RejectShortRefreshInterval {
+			errs = errors.Join(errs, errors.New(msg))
+			continue
+		}
+		warnings = append(warnings, msg)
+	}
+	return warnings, errs
+}
+
+// validateRemoteRefFeatures reports remote refs using features that the providers of their stores don't support.
+// Stores that can not be read are skipped, like for the refresh interval.
+func (esv *ExternalSecretValidator) validateRemoteRefFeatures(ctx context.Context, es *ExternalSecret) (admission.Warnings, error) {
+	unsupported := make(map[SecretStoreRef][]RemoteRefFeature)
+	stores := make(map[SecretStoreRef]GenericStore)
+	for _, ref := range referencedStores(es) {
+		store, err := esv.getStore(ctx, ref, es.Namespace)
+		if err != nil {
+			continue
+		}
+		provider, err := GetProvider(store)
+		if err != nil {
+			continue
+		}
+		if validator, ok := provider.(RemoteRefFeatureValidator); ok {
+			unsupported[ref] = validator.UnsupportedRemoteRefFeatures(store)
+			stores[ref] = store
+		}
+	}
+	if len(unsupported) == 0 {
+		return nil, nil
+	}
+
+	var (
+		warnings admission.Warnings
+		errs     error
+	)
+	check := func(field string, refs []SecretStoreRef, features []RemoteRefFeature) {
+		for _, ref := range refs {
+			for _, feature := range features {
+				if !slices.Contains(unsupported[ref], feature) {
+					continue
+				}
+				store := stores[ref]
+				msg := fmt.Sprintf("%s: %s is not supported by the provider of %s %s", field, feature, store.GetKind(), store.GetName())
+				if esv.RejectUnsupportedRemoteRefFeatures {
+					errs = errors.Join(errs, errors.New(msg))
+					continue
+				}
+				warnings = append(warnings, msg)
+			}
+		}
+	}
+	for i, data := range es.Spec.Data {
+		var refs []SecretStoreRef
+		if data.SourceRef != nil && data.SourceRef.SecretStoreRef.Name != "" {
+			refs = []SecretStoreRef{storeRefWithKind(data.SourceRef.SecretStoreRef)}
+		} else if data.SourceRef == nil || data.SourceRef.GeneratorRef == nil {
+			refs = defaultStores(es)
+		}
+		check(fmt.Sprintf("data[%d].remoteRef", i), refs, usedRemoteRefFeatures(data.RemoteRef.Version, data.RemoteRef.MetadataPolicy))
+	}
+	for i, data := range es.Spec.DataFrom {
+		var refs []SecretStoreRef
+		if data.SourceRef != nil && data.SourceRef.SecretStoreRef != nil {
+			refs = []SecretStoreRef{storeRefWithKind(*data.SourceRef.SecretStoreRef)}
+		} else if data.SourceRef == nil || data.SourceRef.GeneratorRef == nil {
+			refs = defaultStores(es)
+		}
+		if data.Extract != nil {
+			check(fmt.Sprintf("dataFrom[%d].extract", i), refs, usedRemoteRefFeatures(data.Extract.Version, data.Extract.MetadataPolicy))
+		}
+		if data.Find != nil && len(data.Find.Tags) > 0 {
+			check(fmt.Sprintf("dataFrom[%d]", i), refs, []RemoteRefFeature{RemoteRefFeatureFindTags})
+		}
+	}
+	return warnings, errs
+}
+
+func usedRemoteRefFeatures(version string, policy ExternalSecretMetadataPolicy) []RemoteRefFeature {
+	var features []RemoteRefFeature
+	if version != "" {
+		features = append(features, RemoteRefFeatureVersion)
+	}
+	if policy == ExternalSecretMetadataPolicyFetch {
+		features = append(features, RemoteRefFeatureMetadataPolicyFetch)
+	}
+	return features
+}
+
+// defaultStores returns the stores of the ExternalSecret that data without a sourceRef is read from.
+func defaultStores(es *ExternalSecret) []SecretStoreRef {
+	if len(es.Spec.SecretStoreRefs) > 0 {
+		refs := make([]SecretStoreRef, 0, len(es.Spec.SecretStoreRefs))
+		for _, ref := range es.Spec.SecretStoreRefs {
+			refs = append(refs, storeRefWithKind(ref))
+		}
+		return refs
+	}
+	if es.Spec.SecretStoreRef.Name == "" {
+		return nil
+	}
+	return []SecretStoreRef{storeRefWithKind(es.Spec.SecretStoreRef)}
+}
 
-func (esv *ExternalSecretValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(obj)
+func storeRefWithKind(ref SecretStoreRef) SecretStoreRef {
+	if ref.Kind == "" {
+		ref.Kind = SecretStoreKind
+	}
+	return ref
 }
 
-func (esv *ExternalSecretValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
-	return validateExternalSecret(newObj)
+func (esv *ExternalSecretValidator) getStore(ctx context.Context, ref SecretStoreRef, namespace string) (GenericStore, error) {
+	if ref.Kind == ClusterSecretStoreKind {
+		var store ClusterSecretStore
+		err := esv.Reader.Get(ctx, client.ObjectKey{Name: ref.Name}, &store)
+		return &store, err
+	}
+	var store SecretStore
+	err := esv.Reader.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, &store)
+	return &store, err
+}
+
+// referencedStores returns the distinct stores referenced by the ExternalSecret.
+func referencedStores(es *ExternalSecret) []SecretStoreRef {
+	refs := make([]SecretStoreRef, 0, 1)
+	seen := make(map[SecretStoreRef]struct{})
+	add := func(ref SecretStoreRef) {
+		if ref.Name == "" {
+			return
+		}
+		ref = storeRefWithKind(ref)
+		if _, ok := seen[ref]; ok {
+			return
+		}
+		seen[ref] = struct{}{}
+		refs = append(refs, ref)
+	}
+	add(es.Spec.SecretStoreRef)
+	for _, ref := range es.Spec.SecretStoreRefs {
+		add(ref)
+	}
+	for _, data := range es.Spec.Data {
+		if data.SourceRef != nil {
+			add(data.SourceRef.SecretStoreRef)
+		}
+	}
+	for _, data := range es.Spec.DataFrom {
+		if data.SourceRef != nil && data.SourceRef.SecretStoreRef != nil {
+			add(*data.SourceRef.SecretStoreRef)
+		}
+	}
+	return refs
 }
 
 func (esv *ExternalSecretValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
@@ -49,10 +261,66 @@ func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Delete must not be used when the controller doesn't own the secret. Please set creationPolicy=Owner"))
 	}
 
+	if es.Spec.SecretStoreRef.Name != "" && len(es.Spec.SecretStoreRefs) > 0 {
+		errs = errors.Join(errs, fmt.Errorf("secretStoreRef and secretStoreRefs must not be used together"))
+	}
+
 	if es.Spec.Target.DeletionPolicy == DeletionPolicyMerge && es.Spec.Target.CreationPolicy == CreatePolicyNone {
 		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with"))
 	}
 
+	if es.Spec.Target.DeletionPolicy == DeletionPolicyArchive && es.Spec.Target.CreationPolicy == CreatePolicyNone {
+		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Archive must not be used with creationPolicy=None. There is no Secret to archive keys from"))
+	}
+
+	if es.Spec.Target.DeletionPolicy == DeletionPolicyArchive && es.Spec.Target.Kind == TargetKindConfigMap {
+		errs = errors.Join(errs, fmt.Errorf("deletionPolicy=Archive is not supported with target.kind=ConfigMap"))
+	}
+
+	if es.Spec.Target.Kind == TargetKindConfigMap && es.Spec.Target.Template != nil && es.Spec.Target.Template.Type != "" {
+		errs = errors.Join(errs, fmt.Errorf("template.type must not be set when target.kind=ConfigMap"))
+	}
+
+	if es.Spec.Target.Rotation != nil {
+		if !es.Spec.Target.Immutable {
+			errs = errors.Join(errs, fmt.Errorf("target.rotation requires target.immutable=true"))
+		}
+		if es.Spec.Target.Kind == TargetKindConfigMap {
+			errs = errors.Join(errs, fmt.Errorf("target.rotation is not supported with target.kind=ConfigMap"))
+		}
+		if es.Spec.Target.CreationPolicy == CreatePolicyMerge || es.Spec.Target.CreationPolicy == CreatePolicyNone {
+			errs = errors.Join(errs, fmt.Errorf("target.rotation requires creationPolicy=Owner or creationPolicy=Orphan"))
+		}
+	}
+
+	if es.Spec.Target.Encryption != nil {
+		errs = validateEncryption(es, errs)
+	}
+
+	if es.Spec.Target.Chunking != nil {
+		if es.Spec.Target.Kind == TargetKindConfigMap {
+			errs = errors.Join(errs, fmt.Errorf("target.chunking is not supported with target.kind=ConfigMap"))
+		}
+		if es.Spec.Target.CreationPolicy == CreatePolicyMerge || es.Spec.Target.CreationPolicy == CreatePolicyNone {
+			errs = errors.Join(errs, fmt.Errorf("target.chunking requires creationPolicy=Owner or creationPolicy=Orphan"))
+		}
+		if es.Spec.Target.Rotation != nil {
+			errs = errors.Join(errs, fmt.Errorf("target.chunking must not be used with target.rotation"))
+		}
+	}
+
+	if es.Spec.Target.Validation != nil {
+		for _, exp := range es.Spec.Target.Validation.PasswordKeys {
+			if _, err := regexp.Compile(exp); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid target.validation.passwordKeys regex %q: %w", exp, err))
+			}
+		}
+	}
+
+	if es.Spec.RetryPolicy != nil {
+		errs = validateRetryPolicy(es.Spec.RetryPolicy, errs)
+	}
+
 	if len(es.Spec.Data) == 0 && len(es.Spec.DataFrom) == 0 {
 		errs = errors.Join(errs, fmt.Errorf("either data or dataFrom should be specified"))
 	}
@@ -70,12 +338,87 @@ func validateExternalSecret(obj runtime.Object) (admission.Warnings, error) {
 		if ref.SourceRef != nil && ref.SourceRef.GeneratorRef == nil && ref.SourceRef.SecretStoreRef == nil {
 			errs = errors.Join(errs, fmt.Errorf("generatorRef or storeRef must be set when using sourceRef in dataFrom"))
 		}
+
+		if ref.Bundle != nil {
+			if ref.Find == nil {
+				errs = errors.Join(errs, fmt.Errorf("bundle is only supported with dataFrom.find"))
+			}
+			if ref.Bundle.Key == "" {
+				errs = errors.Join(errs, fmt.Errorf("bundle.key must be set"))
+			}
+		}
+
+		if ref.Extract != nil && ref.Extract.FilterPath != "" {
+			if _, err := jmespath.Compile(ref.Extract.FilterPath); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid extract.filterPath %q: %w", ref.Extract.FilterPath, err))
+			}
+		}
+
+		for _, rw := range ref.Rewrite {
+			if rw.Operation == nil {
+				continue
+			}
+			trim := rw.Operation.Type == RewriteOperationTrimPrefix || rw.Operation.Type == RewriteOperationTrimSuffix
+			if trim && rw.Operation.Value == "" {
+				errs = errors.Join(errs, fmt.Errorf("rewrite operation %s requires a value", rw.Operation.Type))
+			}
+		}
+	}
+
+	for _, data := range es.Spec.Data {
+		if data.RemoteRef.FilterPath != "" {
+			errs = errors.Join(errs, fmt.Errorf("filterPath is only supported in dataFrom.extract"))
+		}
+		for _, exp := range data.IgnoreChangesRegex {
+			if _, err := regexp.Compile(exp); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid ignoreChangesRegex %q: %w", exp, err))
+			}
+		}
 	}
 
 	errs = validateDuplicateKeys(es, errs)
 	return nil, errs
 }
 
+func validateEncryption(es *ExternalSecret, errs error) error {
+	if es.Spec.Target.Kind == TargetKindConfigMap {
+		errs = errors.Join(errs, fmt.Errorf("target.encryption is not supported with target.kind=ConfigMap"))
+	}
+	if es.Spec.Target.CreationPolicy == CreatePolicyMerge || es.Spec.Target.CreationPolicy == CreatePolicyNone {
+		errs = errors.Join(errs, fmt.Errorf("target.encryption requires creationPolicy=Owner or creationPolicy=Orphan"))
+	}
+	if es.Spec.Target.Rotation != nil {
+		errs = errors.Join(errs, fmt.Errorf("target.encryption must not be used with target.rotation"))
+	}
+	block, _ := pem.Decode([]byte(es.Spec.Target.Encryption.PublicKey))
+	if block == nil {
+		return errors.Join(errs, fmt.Errorf("target.encryption.publicKey must be a PEM encoded public key"))
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errors.Join(errs, fmt.Errorf("invalid target.encryption.publicKey: %w", err))
+	}
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		errs = errors.Join(errs, fmt.Errorf("target.encryption.publicKey must be an RSA or EC key"))
+	}
+	return errs
+}
+
+func validateRetryPolicy(policy *ExternalSecretRetryPolicy, errs error) error {
+	if policy.InitialInterval != nil && policy.InitialInterval.Duration <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("retryPolicy.initialInterval must be greater than 0"))
+	}
+	if policy.MaxInterval != nil && policy.MaxInterval.Duration <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("retryPolicy.maxInterval must be greater than 0"))
+	}
+	if policy.InitialInterval != nil && policy.MaxInterval != nil && policy.MaxInterval.Duration < policy.InitialInterval.Duration {
+		errs = errors.Join(errs, fmt.Errorf("retryPolicy.maxInterval must not be less than retryPolicy.initialInterval"))
+	}
+	return errs
+}
+
 func validateDuplicateKeys(es *ExternalSecret, errs error) error {
 	if es.Spec.Target.DeletionPolicy == DeletionPolicyRetain {
 		seenKeys := make(map[string]struct{})
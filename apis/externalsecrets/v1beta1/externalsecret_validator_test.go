@@ -15,11 +15,23 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+const testEncryptionKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEArwqWu5cs9Uczbz6RKT1gx9SGsLK
+ruG+6yCNo3ZSoqCkmEaLCdX/C9dTw7n8aBGEcn3mB2a+vWPk0Enpan5FAA==
+-----END PUBLIC KEY-----`
+
 func TestValidateExternalSecret(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -61,6 +73,235 @@ func TestValidateExternalSecret(t *testing.T) {
 			},
 			expectedErr: "deletionPolicy=Merge must not be used with creationPolicy=None. There is no Secret to merge with",
 		},
+		{
+			name: "deletion policy archive",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						DeletionPolicy: DeletionPolicyArchive,
+						CreationPolicy: CreatePolicyNone,
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "deletionPolicy=Archive must not be used with creationPolicy=None. There is no Secret to archive keys from",
+		},
+		{
+			name: "invalid extract filterPath",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							Extract: &ExternalSecretDataRemoteRef{Key: "key", FilterPath: "database.["},
+						},
+					},
+				},
+			},
+			expectedErr: `invalid extract.filterPath "database.[": SyntaxError: Incomplete expression`,
+		},
+		{
+			name: "trim rewrite operation without value",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							Find: &ExternalSecretFind{},
+							Rewrite: []ExternalSecretRewrite{
+								{Operation: &ExternalSecretRewriteOperation{Type: RewriteOperationTrimPrefix}},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "rewrite operation TrimPrefix requires a value",
+		},
+		{
+			name: "filterPath in data",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{RemoteRef: ExternalSecretDataRemoteRef{Key: "key", FilterPath: "database"}},
+					},
+				},
+			},
+			expectedErr: "filterPath is only supported in dataFrom.extract",
+		},
+		{
+			name: "bundle without find",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					DataFrom: []ExternalSecretDataFromRemoteRef{
+						{
+							Extract: &ExternalSecretDataRemoteRef{Key: "ca"},
+							Bundle:  &ExternalSecretCertificateBundle{Key: "ca.crt"},
+						},
+					},
+				},
+			},
+			expectedErr: "bundle is only supported with dataFrom.find",
+		},
+		{
+			name: "invalid ignoreChangesRegex",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Data: []ExternalSecretData{
+						{
+							SecretKey:          "key",
+							IgnoreChangesRegex: []string{"updated_at: [0-9"},
+						},
+					},
+				},
+			},
+			expectedErr: "invalid ignoreChangesRegex \"updated_at: [0-9\": error parsing regexp: missing closing ]: `[0-9`",
+		},
+		{
+			name: "invalid validation passwordKeys",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Validation: &ExternalSecretValidation{PasswordKeys: []string{"pass[word"}},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "invalid target.validation.passwordKeys regex \"pass[word\": error parsing regexp: missing closing ]: `[word`",
+		},
+		{
+			name: "rotation of mutable secret",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Rotation: &ExternalSecretRotation{Strategy: RotationStrategySuffix},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "target.rotation requires target.immutable=true",
+		},
+		{
+			name: "configmap target with secret type",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Kind: TargetKindConfigMap,
+						Template: &ExternalSecretTemplate{
+							Type: "kubernetes.io/tls",
+						},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "template.type must not be set when target.kind=ConfigMap",
+		},
+		{
+			name: "retry policy with max interval below initial interval",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					RetryPolicy: &ExternalSecretRetryPolicy{
+						InitialInterval: &metav1.Duration{Duration: time.Minute},
+						MaxInterval:     &metav1.Duration{Duration: time.Second},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "retryPolicy.maxInterval must not be less than retryPolicy.initialInterval",
+		},
+		{
+			name: "encryption with merge policy",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						CreationPolicy: CreatePolicyMerge,
+						Encryption:     &ExternalSecretEncryption{PublicKey: testEncryptionKey},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "target.encryption requires creationPolicy=Owner or creationPolicy=Orphan",
+		},
+		{
+			name: "encryption without PEM key",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Encryption: &ExternalSecretEncryption{PublicKey: "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p"},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "target.encryption.publicKey must be a PEM encoded public key",
+		},
+		{
+			name: "encryption",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Encryption: &ExternalSecretEncryption{PublicKey: testEncryptionKey},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+		},
+		{
+			name: "chunking with ConfigMap target",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Kind:     TargetKindConfigMap,
+						Chunking: &ExternalSecretChunking{},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "target.chunking is not supported with target.kind=ConfigMap",
+		},
+		{
+			name: "chunking with rotation",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					Target: ExternalSecretTarget{
+						Immutable: true,
+						Rotation:  &ExternalSecretRotation{Strategy: RotationStrategySuffix},
+						Chunking:  &ExternalSecretChunking{},
+					},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "target.chunking must not be used with target.rotation",
+		},
+		{
+			name: "secretStoreRef with secretStoreRefs",
+			obj: &ExternalSecret{
+				Spec: ExternalSecretSpec{
+					SecretStoreRef:  SecretStoreRef{Name: "base"},
+					SecretStoreRefs: []SecretStoreRef{{Name: "base"}, {Name: "override"}},
+					Data: []ExternalSecretData{
+						{},
+					},
+				},
+			},
+			expectedErr: "secretStoreRef and secretStoreRefs must not be used together",
+		},
 		{
 			name: "both data and data_from are empty",
 			obj: &ExternalSecret{
@@ -218,3 +459,186 @@ either data or dataFrom should be specified`,
 		})
 	}
 }
+
+// refreshIntervalProvider recommends a minimum refresh interval of one minute.
+type refreshIntervalProvider struct {
+	Provider
+}
+
+func (p *refreshIntervalProvider) RecommendedMinRefreshInterval() time.Duration {
+	return time.Minute
+}
+
+func TestValidateRefreshInterval(t *testing.T) {
+	ForceRegister(&refreshIntervalProvider{}, &SecretStoreProvider{Gitlab: &GitlabProvider{}})
+	ForceRegister(&ValidationProvider{}, &SecretStoreProvider{Fake: &FakeProvider{}})
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&SecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "gitlab", Namespace: "default"},
+			Spec:       SecretStoreSpec{Provider: &SecretStoreProvider{Gitlab: &GitlabProvider{}}},
+		},
+		&ClusterSecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+			Spec:       SecretStoreSpec{Provider: &SecretStoreProvider{Fake: &FakeProvider{}}},
+		},
+	).Build()
+
+	newES := func(interval time.Duration, ref SecretStoreRef) *ExternalSecret {
+		return &ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+			Spec: ExternalSecretSpec{
+				RefreshInterval: &metav1.Duration{Duration: interval},
+				SecretStoreRef:  ref,
+				Data:            []ExternalSecretData{{SecretKey: "foo"}},
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		es           *ExternalSecret
+		reject       bool
+		wantWarnings admission.Warnings
+		wantErr      string
+	}{
+		{
+			name:         "warns below recommended interval",
+			es:           newES(time.Second, SecretStoreRef{Name: "gitlab"}),
+			wantWarnings: admission.Warnings{"refreshInterval 1s is shorter than the minimum of 1m0s recommended for SecretStore gitlab"},
+		},
+		{
+			name:    "rejects below recommended interval",
+			es:      newES(time.Second, SecretStoreRef{Name: "gitlab"}),
+			reject:  true,
+			wantErr: "refreshInterval 1s is shorter than the minimum of 1m0s recommended for SecretStore gitlab",
+		},
+		{
+			name: "accepts recommended interval",
+			es:   newES(time.Minute, SecretStoreRef{Name: "gitlab"}),
+		},
+		{
+			name: "refresh disabled",
+			es:   newES(0, SecretStoreRef{Name: "gitlab"}),
+		},
+		{
+			name: "provider without recommendation",
+			es:   newES(time.Second, SecretStoreRef{Name: "fake", Kind: ClusterSecretStoreKind}),
+		},
+		{
+			name: "missing store",
+			es:   newES(time.Second, SecretStoreRef{Name: "missing"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &ExternalSecretValidator{Reader: kube, RejectShortRefreshInterval: tt.reject}
+			warnings, err := v.ValidateCreate(context.Background(), tt.es)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantWarnings, warnings)
+		})
+	}
+}
+
+// remoteRefFeatureProvider supports neither versions nor find by tags.
+type remoteRefFeatureProvider struct {
+	Provider
+}
+
+func (p *remoteRefFeatureProvider) UnsupportedRemoteRefFeatures(_ GenericStore) []RemoteRefFeature {
+	return []RemoteRefFeature{RemoteRefFeatureVersion, RemoteRefFeatureFindTags}
+}
+
+func TestValidateRemoteRefFeatures(t *testing.T) {
+	ForceRegister(&remoteRefFeatureProvider{}, &SecretStoreProvider{Webhook: &WebhookProvider{}})
+	ForceRegister(&ValidationProvider{}, &SecretStoreProvider{Fake: &FakeProvider{}})
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&SecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: "default"},
+			Spec:       SecretStoreSpec{Provider: &SecretStoreProvider{Webhook: &WebhookProvider{}}},
+		},
+		&ClusterSecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "fake"},
+			Spec:       SecretStoreSpec{Provider: &SecretStoreProvider{Fake: &FakeProvider{}}},
+		},
+	).Build()
+
+	newES := func(ref SecretStoreRef, data []ExternalSecretData, dataFrom []ExternalSecretDataFromRemoteRef) *ExternalSecret {
+		return &ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+			Spec: ExternalSecretSpec{
+				SecretStoreRef: ref,
+				Data:           data,
+				DataFrom:       dataFrom,
+			},
+		}
+	}
+	versioned := []ExternalSecretData{{SecretKey: "foo", RemoteRef: ExternalSecretDataRemoteRef{Key: "foo", Version: "1"}}}
+	tests := []struct {
+		name         string
+		es           *ExternalSecret
+		reject       bool
+		wantWarnings admission.Warnings
+		wantErr      string
+	}{
+		{
+			name:         "warns about version",
+			es:           newES(SecretStoreRef{Name: "webhook"}, versioned, nil),
+			wantWarnings: admission.Warnings{"data[0].remoteRef: version is not supported by the provider of SecretStore webhook"},
+		},
+		{
+			name:    "rejects version",
+			es:      newES(SecretStoreRef{Name: "webhook"}, versioned, nil),
+			reject:  true,
+			wantErr: "data[0].remoteRef: version is not supported by the provider of SecretStore webhook",
+		},
+		{
+			name: "warns about find.tags",
+			es: newES(SecretStoreRef{Name: "webhook"}, nil, []ExternalSecretDataFromRemoteRef{
+				{Find: &ExternalSecretFind{Tags: map[string]string{"env": "prod"}}},
+			}),
+			wantWarnings: admission.Warnings{"dataFrom[0]: find.tags is not supported by the provider of SecretStore webhook"},
+		},
+		{
+			name: "checks the store of the sourceRef",
+			es: newES(SecretStoreRef{Name: "fake", Kind: ClusterSecretStoreKind}, []ExternalSecretData{{
+				SecretKey: "foo",
+				RemoteRef: ExternalSecretDataRemoteRef{Key: "foo", Version: "1"},
+				SourceRef: &StoreSourceRef{SecretStoreRef: SecretStoreRef{Name: "webhook"}},
+			}}, nil),
+			wantWarnings: admission.Warnings{"data[0].remoteRef: version is not supported by the provider of SecretStore webhook"},
+		},
+		{
+			name: "accepts supported features",
+			es:   newES(SecretStoreRef{Name: "webhook"}, []ExternalSecretData{{SecretKey: "foo", RemoteRef: ExternalSecretDataRemoteRef{Key: "foo"}}}, nil),
+		},
+		{
+			name: "provider supporting all features",
+			es:   newES(SecretStoreRef{Name: "fake", Kind: ClusterSecretStoreKind}, versioned, nil),
+		},
+		{
+			name: "missing store",
+			es:   newES(SecretStoreRef{Name: "missing"}, versioned, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &ExternalSecretValidator{Reader: kube, RejectUnsupportedRemoteRefFeatures: tt.reject}
+			warnings, err := v.ValidateCreate(context.Background(), tt.es)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantWarnings, warnings)
+		})
+	}
+}
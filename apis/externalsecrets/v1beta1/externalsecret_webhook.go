@@ -18,9 +18,22 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func (r *ExternalSecret) SetupWebhookWithManager(mgr ctrl.Manager) error {
+// ExternalSecretWebhookOptions configures the validation of ExternalSecrets.
+// +kubebuilder:object:generate=false
+type ExternalSecretWebhookOptions struct {
+	// RejectShortRefreshInterval rejects refresh intervals below the minimum recommended by the provider instead of warning.
+	RejectShortRefreshInterval bool
+	// RejectUnsupportedRemoteRefFeatures rejects remote refs using features the provider doesn't support instead of warning.
+	RejectUnsupportedRemoteRefFeatures bool
+}
+
+func (r *ExternalSecret) SetupWebhookWithManager(mgr ctrl.Manager, opts ExternalSecretWebhookOptions) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
-		WithValidator(&ExternalSecretValidator{}).
+		WithValidator(&ExternalSecretValidator{
+			Reader:                             mgr.GetAPIReader(),
+			RejectShortRefreshInterval:         opts.RejectShortRefreshInterval,
+			RejectUnsupportedRemoteRefFeatures: opts.RejectUnsupportedRemoteRefFeatures,
+		}).
 		Complete()
 }
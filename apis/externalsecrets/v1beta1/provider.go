@@ -16,6 +16,8 @@ package v1beta1
 
 import (
 	"context"
+	"io"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -96,6 +98,146 @@ type SecretsClient interface {
 	Close(ctx context.Context) error
 }
 
+// SecretProtectionReporter may be implemented by a SecretsClient that is able
+// to report the protection flags of the secrets it has read.
+// +kubebuilder:object:generate=false
+type SecretProtectionReporter interface {
+	// SecretProtections returns the protection flags of the secrets read by the client.
+	SecretProtections() []SecretProtectionStatus
+}
+
+// ProviderVersionReporter may be implemented by a SecretsClient that is able
+// to tell the version of the backend it is connected to.
+// +kubebuilder:object:generate=false
+type ProviderVersionReporter interface {
+	// ProviderVersion returns the version of the backend.
+	ProviderVersion(ctx context.Context) (string, error)
+}
+
+// SecretExpiryReporter may be implemented by a SecretsClient that is able
+// to tell when the secrets it read or pushed expire.
+// +kubebuilder:object:generate=false
+type SecretExpiryReporter interface {
+	// SecretExpirations returns the secrets read or pushed by the client that expire soon.
+	SecretExpirations() []SecretExpiration
+}
+
+// SecretExpiration is the upcoming expiry of a secret in the provider.
+// +kubebuilder:object:generate=false
+type SecretExpiration struct {
+	// Key is the key of the secret in the provider.
+	Key string
+	// Expires is the time the secret expires.
+	Expires time.Time
+}
+
+// SecretLifetimeReporter may be implemented by a SecretsClient that is able
+// to tell when the secrets it read were created and when they expire.
+// +kubebuilder:object:generate=false
+type SecretLifetimeReporter interface {
+	// SecretLifetimes returns the creation and expiry times of the secrets read by the client.
+	SecretLifetimes() []SecretLifetime
+}
+
+// SecretLifetime holds the creation and expiry time of a secret in the provider.
+// A zero time means the provider does not know the time.
+// +kubebuilder:object:generate=false
+type SecretLifetime struct {
+	// Key is the key of the secret in the provider.
+	Key string
+	// Created is the time the secret was created.
+	Created time.Time
+	// Expires is the time the secret expires.
+	Expires time.Time
+}
+
+// SecretMetadataReporter may be implemented by a SecretsClient that is able
+// to tell the version, modification time or tags of the secrets it read.
+// +kubebuilder:object:generate=false
+type SecretMetadataReporter interface {
+	// SecretMetadata returns the metadata of the secrets read by the client.
+	SecretMetadata() []SecretMetadata
+}
+
+// SecretMetadata holds the metadata of a secret in the provider,
+// it can be used in the label and annotation templates of the target.
+// Empty fields are not known by the provider.
+// +kubebuilder:object:generate=false
+type SecretMetadata struct {
+	// Key is the key of the secret in the provider.
+	Key string
+	// Version is the version id of the secret.
+	Version string
+	// LastModified is the time the secret was last modified.
+	LastModified time.Time
+	// Tags are the tags or custom metadata of the secret.
+	Tags map[string]string
+}
+
+// ManagedSecretOwnerTag is the tag of a provider secret created by a PushSecret
+// holding the UID of the SecretStore or ClusterSecretStore it was pushed through.
+const ManagedSecretOwnerTag = "external-secrets-owner"
+
+// ManagedSecretLister may be implemented by a SecretsClient that is able to list
+// the provider secrets created by PushSecrets, e.g. using the managed-by tag.
+// +kubebuilder:object:generate=false
+type ManagedSecretLister interface {
+	// ListManagedSecrets returns the keys of the provider secrets managed by external-secrets
+	// whose ManagedSecretOwnerTag matches the store of the client.
+	ListManagedSecrets(ctx context.Context) ([]string, error)
+	// ManagedSecretKey returns the key of ListManagedSecrets the remote key of a PushSecret refers to.
+	ManagedSecretKey(remoteKey string) string
+}
+
+// RefreshIntervalRecommender may be implemented by a Provider whose backend
+// is rate limited, so that short refresh intervals quickly exhaust the API quota.
+// +kubebuilder:object:generate=false
+type RefreshIntervalRecommender interface {
+	// RecommendedMinRefreshInterval returns the shortest refresh interval recommended for the provider.
+	RecommendedMinRefreshInterval() time.Duration
+}
+
+// RemoteRefFeature is a feature of the remote refs of an ExternalSecret that not all providers support.
+type RemoteRefFeature string
+
+const (
+	// RemoteRefFeatureVersion is the version of data[].remoteRef and dataFrom[].extract.
+	RemoteRefFeatureVersion RemoteRefFeature = "version"
+	// RemoteRefFeatureFindTags is dataFrom[].find.tags.
+	RemoteRefFeatureFindTags RemoteRefFeature = "find.tags"
+	// RemoteRefFeatureMetadataPolicyFetch is metadataPolicy=Fetch of data[].remoteRef and dataFrom[].extract.
+	RemoteRefFeatureMetadataPolicyFetch RemoteRefFeature = "metadataPolicy=Fetch"
+	// RemoteRefFeaturePushWholeSecret is pushing the whole Secret with PushSecret data that has no secretKey.
+	RemoteRefFeaturePushWholeSecret RemoteRefFeature = "push.wholeSecret"
+)
+
+// RemoteRefFeatures are all remote ref features, in the order they are reported.
+var RemoteRefFeatures = []RemoteRefFeature{
+	RemoteRefFeatureVersion,
+	RemoteRefFeatureFindTags,
+	RemoteRefFeatureMetadataPolicyFetch,
+	RemoteRefFeaturePushWholeSecret,
+}
+
+// RemoteRefFeatureValidator may be implemented by a Provider that does not support all features of the remote refs,
+// so ExternalSecrets using them are reported at admission instead of failing at runtime.
+// +kubebuilder:object:generate=false
+type RemoteRefFeatureValidator interface {
+	// UnsupportedRemoteRefFeatures returns the features the store does not support.
+	// For the capability matrix the store has an empty provider configuration,
+	// features that depend on the configuration should then be reported as unsupported.
+	UnsupportedRemoteRefFeatures(store GenericStore) []RemoteRefFeature
+}
+
+// SecretStreamer may be implemented by a SecretsClient that can stream the value of a secret,
+// e.g. a large binary file, so the controller can bound the bytes it reads.
+// +kubebuilder:object:generate=false
+type SecretStreamer interface {
+	// GetSecretStream returns a reader of the value GetSecret would return for the ref.
+	// The caller must close the reader.
+	GetSecretStream(ctx context.Context, ref ExternalSecretDataRemoteRef) (io.ReadCloser, error)
+}
+
 var NoSecretErr = NoSecretError{}
 
 // NoSecretError shall be returned when a GetSecret can not find the
@@ -105,3 +247,30 @@ type NoSecretError struct{}
 func (NoSecretError) Error() string {
 	return "Secret does not exist"
 }
+
+// PushSecretPendingError shall be returned by PushSecret when the provider
+// can not write the secret yet because of a transient condition on the provider
+// side, e.g. a previous version of the secret is still being deleted.
+// The PushSecret is marked as pending and retried after RetryAfter.
+// +kubebuilder:object:generate=false
+type PushSecretPendingError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e PushSecretPendingError) Error() string {
+	return e.Message
+}
+
+// ThrottledError shall be returned when the provider throttles the requests,
+// e.g. after repeated 429 responses, and asks to wait before sending new ones.
+// The sync is paused and retried after RetryAfter.
+// +kubebuilder:object:generate=false
+type ThrottledError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e ThrottledError) Error() string {
+	return e.Message
+}
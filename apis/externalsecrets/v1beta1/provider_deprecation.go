@@ -0,0 +1,73 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const warnUnmaintainedProvider = "the %s provider is unmaintained: %s"
+
+var (
+	unmaintained     = make(map[string]string)
+	unmaintainedLock sync.RWMutex
+)
+
+// MarkUnmaintained marks the provider of the store spec as unmaintained.
+// Stores using the provider get a warning with the notice, e.g. naming a replacement.
+func MarkUnmaintained(storeSpec *SecretStoreProvider, notice string) {
+	storeName, err := getProviderName(storeSpec)
+	if err != nil {
+		panic(fmt.Sprintf("store error marking provider unmaintained: %s", err.Error()))
+	}
+	unmaintainedLock.Lock()
+	defer unmaintainedLock.Unlock()
+	unmaintained[storeName] = notice
+}
+
+// StoreWarnings returns the deprecation and migration warnings of a store:
+// the warnings of the provider validation and the notice of an unmaintained provider.
+// Unlike the admission warnings they are also available for stores applied before
+// the deprecation, so the controllers can surface them as events.
+func StoreWarnings(store GenericStore) admission.Warnings {
+	provider, err := GetProvider(store)
+	if err != nil || provider == nil {
+		return nil
+	}
+	// validation errors are reported by the store controller
+	warnings, _ := provider.ValidateStore(store)
+	if warning, ok := unmaintainedWarning(store.GetSpec().Provider); ok {
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// unmaintainedWarning returns the warning for a store spec using an unmaintained provider.
+func unmaintainedWarning(storeSpec *SecretStoreProvider) (string, bool) {
+	storeName, err := getProviderName(storeSpec)
+	if err != nil {
+		return "", false
+	}
+	unmaintainedLock.RLock()
+	notice, ok := unmaintained[storeName]
+	unmaintainedLock.RUnlock()
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf(warnUnmaintainedProvider, storeName, notice), true
+}
@@ -0,0 +1,49 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreWarnings(t *testing.T) {
+	store := &SecretStore{
+		Spec: SecretStoreSpec{
+			Provider: &SecretStoreProvider{
+				Doppler: &DopplerProvider{},
+			},
+		},
+	}
+	assert.Empty(t, StoreWarnings(store), "unregistered provider")
+
+	ForceRegister(&PP{}, store.Spec.Provider)
+	assert.Empty(t, StoreWarnings(store))
+
+	MarkUnmaintained(store.Spec.Provider, "use the webhook provider instead")
+	defer func() {
+		unmaintainedLock.Lock()
+		delete(unmaintained, "doppler")
+		unmaintainedLock.Unlock()
+	}()
+	want := "the doppler provider is unmaintained: use the webhook provider instead"
+	assert.Equal(t, []string{want}, []string(StoreWarnings(store)))
+
+	warnings, err := (&GenericStoreValidator{}).ValidateCreate(context.Background(), store)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{want}, []string(warnings))
+}
@@ -17,14 +17,21 @@ package v1beta1
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 )
 
 var builder map[string]Provider
+var builderSpecs map[string]*SecretStoreProvider
+var disabled map[string]bool
 var buildlock sync.RWMutex
 
 func init() {
 	builder = make(map[string]Provider)
+	builderSpecs = make(map[string]*SecretStoreProvider)
+	disabled = make(map[string]bool)
 }
 
 // Register a store backend type. Register panics if a
@@ -43,6 +50,7 @@ func Register(s Provider, storeSpec *SecretStoreProvider) {
 	}
 
 	builder[storeName] = s
+	builderSpecs[storeName] = storeSpec
 }
 
 // ForceRegister adds to store schema, overwriting a store if
@@ -55,9 +63,38 @@ func ForceRegister(s Provider, storeSpec *SecretStoreProvider) {
 
 	buildlock.Lock()
 	builder[storeName] = s
+	builderSpecs[storeName] = storeSpec
 	buildlock.Unlock()
 }
 
+// FilterProviders disables the providers that are not allowed or that are denied,
+// an empty allow list allows all providers. Stores using a disabled provider are rejected.
+// It must be called once the providers are registered and returns an error for unknown provider names.
+func FilterProviders(allowed, denied []string) error {
+	buildlock.Lock()
+	defer buildlock.Unlock()
+	for _, name := range append(slices.Clone(allowed), denied...) {
+		if _, ok := builder[name]; !ok && !disabled[name] {
+			known := make([]string, 0, len(builder)+len(disabled))
+			for k := range builder {
+				known = append(known, k)
+			}
+			for k := range disabled {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("unknown provider %q, known providers: %s", name, strings.Join(known, ", "))
+		}
+	}
+	for name := range builder {
+		if (len(allowed) > 0 && !slices.Contains(allowed, name)) || slices.Contains(denied, name) {
+			delete(builder, name)
+			disabled[name] = true
+		}
+	}
+	return nil
+}
+
 // GetProviderByName returns the provider implementation by name.
 func GetProviderByName(name string) (Provider, bool) {
 	buildlock.RLock()
@@ -66,6 +103,48 @@ func GetProviderByName(name string) (Provider, bool) {
 	return f, ok
 }
 
+// ProviderCapabilities declares the operations a provider supports.
+// +kubebuilder:object:generate=false
+type ProviderCapabilities struct {
+	// Capabilities tells whether the provider can read, write or both.
+	Capabilities SecretStoreCapabilities `json:"capabilities"`
+	// SupportedFeatures are the remote ref features the provider supports.
+	SupportedFeatures []RemoteRefFeature `json:"supportedFeatures"`
+	// UnsupportedFeatures are the remote ref features the provider rejects or ignores.
+	UnsupportedFeatures []RemoteRefFeature `json:"unsupportedFeatures,omitempty"`
+}
+
+// GetProviderCapabilities returns the capabilities of the enabled providers by name.
+// A feature that is only supported by some configurations of a provider is reported as unsupported,
+// push features are not reported for read only providers.
+func GetProviderCapabilities() map[string]ProviderCapabilities {
+	buildlock.RLock()
+	defer buildlock.RUnlock()
+	matrix := make(map[string]ProviderCapabilities, len(builder))
+	for name, provider := range builder {
+		var unsupported []RemoteRefFeature
+		if validator, ok := provider.(RemoteRefFeatureValidator); ok {
+			store := &SecretStore{Spec: SecretStoreSpec{Provider: builderSpecs[name]}}
+			unsupported = validator.UnsupportedRemoteRefFeatures(store)
+		}
+		capabilities := ProviderCapabilities{
+			Capabilities:        provider.Capabilities(),
+			SupportedFeatures:   []RemoteRefFeature{},
+			UnsupportedFeatures: unsupported,
+		}
+		for _, feature := range RemoteRefFeatures {
+			if feature == RemoteRefFeaturePushWholeSecret && capabilities.Capabilities == SecretStoreReadOnly {
+				continue
+			}
+			if !slices.Contains(unsupported, feature) {
+				capabilities.SupportedFeatures = append(capabilities.SupportedFeatures, feature)
+			}
+		}
+		matrix[name] = capabilities
+	}
+	return matrix
+}
+
 // GetProvider returns the provider from the generic store.
 func GetProvider(s GenericStore) (Provider, error) {
 	if s == nil {
@@ -85,9 +164,13 @@ func GetProvider(s GenericStore) (Provider, error) {
 
 	buildlock.RLock()
 	f, ok := builder[storeName]
+	off := disabled[storeName]
 	buildlock.RUnlock()
 
 	if !ok {
+		if off {
+			return nil, fmt.Errorf("store backend %s is disabled in this cluster, name: %s", storeName, s.GetName())
+		}
 		return nil, fmt.Errorf("failed to find registered store backend for type: %s, name: %s", storeName, s.GetName())
 	}
 
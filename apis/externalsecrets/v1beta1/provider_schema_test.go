@@ -204,3 +204,75 @@ func TestRegisterGCP(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, testProvider, p2)
 }
+
+func TestFilterProviders(t *testing.T) {
+	buildlock.Lock()
+	oldBuilder, oldDisabled := builder, disabled
+	builder, disabled = make(map[string]Provider), make(map[string]bool)
+	buildlock.Unlock()
+	defer func() {
+		buildlock.Lock()
+		builder, disabled = oldBuilder, oldDisabled
+		buildlock.Unlock()
+	}()
+
+	webhookStore := &SecretStore{Spec: SecretStoreSpec{Provider: &SecretStoreProvider{Webhook: &WebhookProvider{}}}}
+	fakeStore := &SecretStore{Spec: SecretStoreSpec{Provider: &SecretStoreProvider{Fake: &FakeProvider{}}}}
+	ForceRegister(&PP{}, webhookStore.Spec.Provider)
+	ForceRegister(&PP{}, fakeStore.Spec.Provider)
+
+	err := FilterProviders(nil, []string{"vault"})
+	assert.EqualError(t, err, `unknown provider "vault", known providers: fake, webhook`)
+
+	assert.NoError(t, FilterProviders(nil, []string{"webhook"}))
+	_, err = GetProvider(webhookStore)
+	assert.ErrorContains(t, err, "store backend webhook is disabled")
+	_, err = validateStore(webhookStore)
+	assert.ErrorContains(t, err, "store backend webhook is disabled")
+	_, err = GetProvider(fakeStore)
+	assert.NoError(t, err)
+
+	// disabled providers are known to later filters
+	assert.NoError(t, FilterProviders([]string{"webhook"}, nil))
+	_, err = GetProvider(fakeStore)
+	assert.ErrorContains(t, err, "store backend fake is disabled")
+}
+
+// featurePP supports neither versions nor find by tags when configured for webhook.
+type featurePP struct {
+	PP
+}
+
+func (p *featurePP) UnsupportedRemoteRefFeatures(store GenericStore) []RemoteRefFeature {
+	if store.GetSpec().Provider.Webhook == nil {
+		return nil
+	}
+	return []RemoteRefFeature{RemoteRefFeatureVersion, RemoteRefFeatureFindTags}
+}
+
+func TestGetProviderCapabilities(t *testing.T) {
+	buildlock.Lock()
+	oldBuilder, oldSpecs := builder, builderSpecs
+	builder, builderSpecs = make(map[string]Provider), make(map[string]*SecretStoreProvider)
+	buildlock.Unlock()
+	defer func() {
+		buildlock.Lock()
+		builder, builderSpecs = oldBuilder, oldSpecs
+		buildlock.Unlock()
+	}()
+
+	ForceRegister(&featurePP{}, &SecretStoreProvider{Webhook: &WebhookProvider{}})
+	ForceRegister(&PP{}, &SecretStoreProvider{Fake: &FakeProvider{}})
+
+	assert.Equal(t, map[string]ProviderCapabilities{
+		"webhook": {
+			Capabilities:        SecretStoreReadOnly,
+			SupportedFeatures:   []RemoteRefFeature{RemoteRefFeatureMetadataPolicyFetch},
+			UnsupportedFeatures: []RemoteRefFeature{RemoteRefFeatureVersion, RemoteRefFeatureFindTags},
+		},
+		"fake": {
+			Capabilities:      SecretStoreReadOnly,
+			SupportedFeatures: []RemoteRefFeature{RemoteRefFeatureVersion, RemoteRefFeatureFindTags, RemoteRefFeatureMetadataPolicyFetch},
+		},
+	}, GetProviderCapabilities())
+}
@@ -50,7 +50,7 @@ type AWSJWTAuth struct {
 }
 
 // AWSServiceType is a enum that defines the service/API that is used to fetch the secrets.
-// +kubebuilder:validation:Enum=SecretsManager;ParameterStore
+// +kubebuilder:validation:Enum=SecretsManager;ParameterStore;AppConfig
 type AWSServiceType string
 
 const (
@@ -60,6 +60,9 @@ const (
 	// AWSServiceParameterStore is the AWS SystemsManager ParameterStore service.
 	// see: https://docs.aws.amazon.com/systems-manager/latest/userguide/systems-manager-parameter-store.html
 	AWSServiceParameterStore AWSServiceType = "ParameterStore"
+	// AWSServiceAppConfig is the hosted configuration store of AWS AppConfig, it is read-only.
+	// see: https://docs.aws.amazon.com/appconfig/latest/userguide/appconfig-free-form-configurations-creating.html
+	AWSServiceAppConfig AWSServiceType = "AppConfig"
 )
 
 // SecretsManager defines how the provider behaves when interacting with AWS
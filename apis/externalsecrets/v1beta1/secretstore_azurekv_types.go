@@ -101,6 +101,11 @@ type AzureKVAuth struct {
 	ClientSecret *smmeta.SecretKeySelector `json:"clientSecret,omitempty"`
 
 	// The Azure ClientCertificate of the service principle used for authentication.
+	// Either a PEM bundle containing the certificate and private key, or a PKCS#12 (pfx) archive.
 	// +optional
 	ClientCertificate *smmeta.SecretKeySelector `json:"clientCertificate,omitempty"`
+
+	// The password used to decrypt the PKCS#12 (pfx) archive referenced in ClientCertificate.
+	// +optional
+	ClientCertificatePassword *smmeta.SecretKeySelector `json:"clientCertificatePassword,omitempty"`
 }
@@ -0,0 +1,33 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// BundleProvider reads secrets from an encrypted and signed snapshot bundle
+// that is mounted into the controller. The bundle is created with `external-secrets bundle export`
+// against the real store, so air-gapped clusters can use the same ExternalSecrets without network access to the provider.
+type BundleProvider struct {
+	// Path of the bundle file in the controller container.
+	Path string `json:"path"`
+
+	// DecryptionKeySecretRef references the base64 encoded AES-256 key the bundle is encrypted with.
+	DecryptionKeySecretRef esmeta.SecretKeySelector `json:"decryptionKeySecretRef"`
+
+	// VerificationKeySecretRef references the PEM encoded Ed25519 public key the signature of the bundle is verified with.
+	VerificationKeySecretRef esmeta.SecretKeySelector `json:"verificationKeySecretRef"`
+}
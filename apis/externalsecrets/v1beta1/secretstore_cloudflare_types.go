@@ -0,0 +1,50 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// CloudflareProvider configures a store to sync secrets with a Workers KV namespace
+// or a Secrets Store of a Cloudflare account.
+// Exactly one of kvNamespaceID and secretsStoreID must be set.
+type CloudflareProvider struct {
+	// AccountID is the ID of the Cloudflare account owning the namespace or store.
+	AccountID string `json:"accountID"`
+
+	// KVNamespaceID is the ID of the Workers KV namespace to read and write the keys of.
+	// +optional
+	KVNamespaceID string `json:"kvNamespaceID,omitempty"`
+
+	// SecretsStoreID is the ID of the Secrets Store to push secrets to.
+	// The Secrets Store does not expose the values of its secrets, so they can't be read.
+	// +optional
+	SecretsStoreID string `json:"secretsStoreID,omitempty"`
+
+	// Auth configures the API token used to authenticate with Cloudflare.
+	Auth CloudflareAuth `json:"auth"`
+
+	// URL of the Cloudflare API, defaults to https://api.cloudflare.com/client/v4.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// CloudflareAuth configures the authentication with the Cloudflare API.
+type CloudflareAuth struct {
+	// APITokenSecretRef references an API token with the Workers KV Storage
+	// or Secrets Store permissions of the account.
+	APITokenSecretRef esmeta.SecretKeySelector `json:"apiTokenSecretRef"`
+}
@@ -54,4 +54,24 @@ type DopplerProvider struct {
 	// +kubebuilder:validation:Enum=json;dotnet-json;env;yaml;docker
 	// +optional
 	Format string `json:"format,omitempty"`
+
+	// Configs lists additional Doppler configs to pull secrets from when using dataFrom.find.
+	// Their secrets are merged in order on top of the secrets of Project and Config.
+	// Cannot be used with Format.
+	// +optional
+	Configs []DopplerConfig `json:"configs,omitempty"`
+}
+
+// DopplerConfig references an additional Doppler config to pull secrets from.
+type DopplerConfig struct {
+	// Doppler project, defaults to the project of the store
+	// +optional
+	Project string `json:"project,omitempty"`
+
+	// Doppler config
+	Config string `json:"config"`
+
+	// Prefix is prepended to the names of the secrets of this config
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
 }
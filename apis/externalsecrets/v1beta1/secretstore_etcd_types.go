@@ -0,0 +1,54 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// EtcdProvider configures a store to read secrets from the keys of an etcd v3 cluster.
+type EtcdProvider struct {
+	// Endpoints of the etcd cluster, e.g. `https://etcd-0.example.com:2379`.
+	// The endpoints are tried in order until one of them responds.
+	// +kubebuilder:validation:MinItems=1
+	Endpoints []string `json:"endpoints"`
+
+	// Prefix is prepended to the keys of the references, e.g. `/platform/credentials/`.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// PEM encoded CA bundle used to verify the certificates of the etcd endpoints.
+	// If not set, the system root certificates are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Auth configures the client certificate used to authenticate with etcd.
+	Auth EtcdAuth `json:"auth"`
+
+	// Timeout of a request to etcd, defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// EtcdAuth configures the TLS client certificate authentication with etcd.
+type EtcdAuth struct {
+	// CertSecretRef references the PEM encoded client certificate.
+	CertSecretRef esmeta.SecretKeySelector `json:"certSecretRef"`
+
+	// KeySecretRef references the PEM encoded private key of the client certificate.
+	KeySecretRef esmeta.SecretKeySelector `json:"keySecretRef"`
+}
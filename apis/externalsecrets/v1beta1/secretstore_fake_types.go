@@ -14,14 +14,32 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // FakeProvider configures a fake provider that returns static values.
 type FakeProvider struct {
 	Data []FakeProviderData `json:"data"`
+
+	// Latency delays every call to the provider by the given duration.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+
+	// ErrorRate is the percentage of calls to the provider that fail with an injected error.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ErrorRate int `json:"errorRate,omitempty"`
 }
 
 type FakeProviderData struct {
 	Key   string `json:"key"`
 	Value string `json:"value,omitempty"`
+	// Values is a sequence of values returned by subsequent reads of the key.
+	// Each read returns the next value, the last value is returned once the sequence is exhausted.
+	// +optional
+	Values []string `json:"values,omitempty"`
 	// Deprecated: ValueMap is deprecated and is intended to be removed in the future, use the `value` field instead.
 	ValueMap map[string]string `json:"valueMap,omitempty"`
 	Version  string            `json:"version,omitempty"`
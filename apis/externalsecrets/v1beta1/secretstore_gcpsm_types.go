@@ -15,6 +15,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
@@ -23,6 +25,27 @@ type GCPSMAuth struct {
 	SecretRef *GCPSMAuthSecretRef `json:"secretRef,omitempty"`
 	// +optional
 	WorkloadIdentity *GCPWorkloadIdentity `json:"workloadIdentity,omitempty"`
+	// ServiceAccountImpersonation uses the credentials of the auth method
+	// to impersonate a service account, optionally through a chain of intermediate service accounts.
+	// +optional
+	ServiceAccountImpersonation *GCPServiceAccountImpersonation `json:"serviceAccountImpersonation,omitempty"`
+}
+
+type GCPServiceAccountImpersonation struct {
+	// TargetServiceAccount is the email of the service account to impersonate.
+	TargetServiceAccount string `json:"targetServiceAccount"`
+	// Delegates is the chain of intermediate service accounts, in order, between the
+	// authenticated identity and the target service account. Each service account must be granted
+	// roles/iam.serviceAccountTokenCreator on the next one in the chain.
+	// +optional
+	Delegates []string `json:"delegates,omitempty"`
+	// Scopes of the access token, defaults to the Secret Manager scopes.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Lifetime of the access token, defaults to 1h. A lifetime of up to 12h requires
+	// the constraints/iam.allowServiceAccountCredentialLifetimeExtension organization policy.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
 }
 
 type GCPSMAuthSecretRef struct {
@@ -37,6 +37,11 @@ type GitlabProvider struct {
 
 	// Environment environment_scope of gitlab CI/CD variables (Please see https://docs.gitlab.com/ee/ci/environments/#create-a-static-environment on how to create environments)
 	Environment string `json:"environment,omitempty"`
+
+	// VerifyProtection records whether the variables are masked and protected in the ExternalSecret status
+	// and raises the ProtectionChanged condition when these flags change between two syncs.
+	// +optional
+	VerifyProtection bool `json:"verifyProtection,omitempty"`
 }
 
 type GitlabAuth struct {
@@ -46,6 +46,12 @@ type KubernetesProvider struct {
 	// +kubebuilder:default= default
 	// +optional
 	RemoteNamespace string `json:"remoteNamespace,omitempty"`
+
+	// ServerSideApply pushes secrets with server-side apply instead of updating them.
+	// Every pushed property is owned by a field manager of its own, so values written by others
+	// are kept and keys removed from a pushed secret are removed from the remote secret.
+	// +optional
+	ServerSideApply bool `json:"serverSideApply,omitempty"`
 }
 
 // +kubebuilder:validation:MinProperties=1
@@ -62,6 +68,17 @@ type KubernetesAuth struct {
 	// points to a service account that should be used for authentication
 	// +optional
 	ServiceAccount *esmeta.ServiceAccountSelector `json:"serviceAccount,omitempty"`
+
+	// uses a kubeconfig to authenticate with a remote cluster
+	// +optional
+	Kubeconfig *KubeconfigAuth `json:"kubeconfig,omitempty"`
+}
+
+type KubeconfigAuth struct {
+	// SecretRef references the kubeconfig, the server and credentials of its current context are used.
+	// The caBundle or caProvider of the server replace the certificate authority of the kubeconfig.
+	// Kubeconfigs that reference files or use exec or auth provider plugins are rejected.
+	SecretRef esmeta.SecretKeySelector `json:"secretRef"`
 }
 
 type CertAuth struct {
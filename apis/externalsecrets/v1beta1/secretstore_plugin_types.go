@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// PluginProvider configures a store to sync secrets with an out-of-tree provider over gRPC.
+type PluginProvider struct {
+	// Endpoint of the gRPC server of the plugin, either a unix socket shared with a sidecar,
+	// e.g. `unix:///var/run/plugin/provider.sock`, or the `host:port` of a remote plugin.
+	Endpoint string `json:"endpoint"`
+
+	// PEM encoded CA bundle used to verify the certificate of the plugin.
+	// It is required for a `host:port` endpoint unless insecure is set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Insecure allows an unencrypted connection to a plugin at a `host:port` endpoint,
+	// the secrets of the store are sent to it in plaintext. Not allowed for ClusterSecretStores.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Config is the provider specific configuration, it is passed to the plugin with every call.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// Secrets are resolved by the controller and passed to the plugin with every call, keyed by name.
+	// +optional
+	Secrets []PluginSecret `json:"secrets,omitempty"`
+
+	// Timeout of a call to the plugin, defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type PluginSecret struct {
+	// Name of the secret passed to the plugin.
+	Name string `json:"name"`
+
+	// SecretRef references the value of the secret.
+	SecretRef esmeta.SecretKeySelector `json:"secretRef"`
+}
@@ -37,13 +37,50 @@ type SecretStoreSpec struct {
 	// +optional
 	RefreshInterval int `json:"refreshInterval,omitempty"`
 
+	// Used to configure the interval the provider configuration is validated again,
+	// e.g. to detect expired credentials. Takes precedence over refreshInterval.
+	// Empty or 0 will default to refreshInterval.
+	// +optional
+	ValidationInterval *metav1.Duration `json:"validationInterval,omitempty"`
+
 	// Used to constraint a ClusterSecretStore to specific namespaces. Relevant only to ClusterSecretStore
 	// +optional
 	Conditions []ClusterSecretStoreCondition `json:"conditions,omitempty"`
+
+	// Used to restrict the remote keys that can be read or pushed through the store.
+	// A remote key must match at least one of the regular expressions. All keys are allowed if empty.
+	// +optional
+	AllowedKeys []string `json:"allowedKeys,omitempty"`
+
+	// Used to clean up provider secrets that were created by PushSecrets which no longer exist.
+	// Only supported by providers that can list the secrets managed by external-secrets.
+	// +optional
+	OrphanCleanup *SecretStoreOrphanCleanup `json:"orphanCleanup,omitempty"`
+}
+
+type OrphanCleanupMode string
+
+const (
+	// OrphanCleanupModeReport lists the orphaned secrets in the store status without deleting them.
+	OrphanCleanupModeReport OrphanCleanupMode = "Report"
+	// OrphanCleanupModeDelete deletes the orphaned secrets from the provider.
+	OrphanCleanupModeDelete OrphanCleanupMode = "Delete"
+)
+
+// SecretStoreOrphanCleanup configures the cleanup of orphaned provider secrets.
+// A provider secret managed by external-secrets is orphaned if no PushSecret using the store pushes it.
+type SecretStoreOrphanCleanup struct {
+	// Report only lists the orphaned secrets in the store status, Delete deletes them from the provider.
+	// +kubebuilder:validation:Enum=Report;Delete
+	// +kubebuilder:default=Report
+	// +optional
+	Mode OrphanCleanupMode `json:"mode,omitempty"`
 }
 
 // ClusterSecretStoreCondition describes a condition by which to choose namespaces to process ExternalSecrets in
 // for a ClusterSecretStore instance.
+// The ExternalSecrets in these namespaces can be further restricted
+// by their labels and the name of their target.
 type ClusterSecretStoreCondition struct {
 	// Choose namespace using a labelSelector
 	// +optional
@@ -56,6 +93,17 @@ type ClusterSecretStoreCondition struct {
 	// Choose namespaces by using regex matching
 	// +optional
 	NamespaceRegexes []string `json:"namespaceRegexes,omitempty"`
+
+	// Choose ExternalSecrets using a labelSelector.
+	// Requests not made by an ExternalSecret, e.g. by a PushSecret, don't match.
+	// +optional
+	ExternalSecretSelector *metav1.LabelSelector `json:"externalSecretSelector,omitempty"`
+
+	// Choose ExternalSecrets by using regex matching on the name of their target,
+	// which defaults to the name of the ExternalSecret.
+	// Requests not made by an ExternalSecret, e.g. by a PushSecret, don't match.
+	// +optional
+	TargetNameRegexes []string `json:"targetNameRegexes,omitempty"`
 }
 
 // SecretStoreProvider contains the provider-specific configuration.
@@ -176,6 +224,22 @@ type SecretStoreProvider struct {
 	// Infisical configures this store to sync secrets using the Infisical provider
 	// +optional
 	Infisical *InfisicalProvider `json:"infisical,omitempty"`
+
+	// Bundle configures this store to sync secrets from an offline snapshot bundle
+	// +optional
+	Bundle *BundleProvider `json:"bundle,omitempty"`
+
+	// Plugin configures this store to sync secrets using an out-of-tree provider over gRPC
+	// +optional
+	Plugin *PluginProvider `json:"plugin,omitempty"`
+
+	// Etcd configures this store to sync secrets from the keys of an etcd cluster
+	// +optional
+	Etcd *EtcdProvider `json:"etcd,omitempty"`
+
+	// Cloudflare configures this store to sync secrets with Workers KV or the Secrets Store of Cloudflare
+	// +optional
+	Cloudflare *CloudflareProvider `json:"cloudflare,omitempty"`
 }
 
 type CAProviderType string
@@ -220,6 +284,9 @@ const (
 	ReasonInvalidProviderConfig = "InvalidProviderConfig"
 	ReasonValidationFailed      = "ValidationFailed"
 	ReasonStoreValid            = "Valid"
+	ReasonValidationLost        = "ValidationLost"
+	ReasonOrphanedSecrets       = "OrphanedSecrets"
+	ReasonOrphanDeleted         = "OrphanDeleted"
 )
 
 type SecretStoreStatusCondition struct {
@@ -251,6 +318,17 @@ type SecretStoreStatus struct {
 	Conditions []SecretStoreStatusCondition `json:"conditions,omitempty"`
 	// +optional
 	Capabilities SecretStoreCapabilities `json:"capabilities,omitempty"`
+	// Version of the backend as reported by the provider, e.g. the Vault server version.
+	// Only set if the provider is able to report it.
+	// +optional
+	ProviderVersion string `json:"providerVersion,omitempty"`
+	// Time of the last successful validation of the store.
+	// +optional
+	LastValidated *metav1.Time `json:"lastValidated,omitempty"`
+	// Keys of the provider secrets managed by external-secrets that are not pushed by any PushSecret.
+	// Only set if orphanCleanup is configured.
+	// +optional
+	OrphanedSecrets []string `json:"orphanedSecrets,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -261,6 +339,8 @@ type SecretStoreStatus struct {
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:printcolumn:name="Capabilities",type=string,JSONPath=`.status.capabilities`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.providerVersion`,priority=1
+// +kubebuilder:printcolumn:name="Last Validated",type="date",JSONPath=".status.lastValidated",priority=1
 // +kubebuilder:subresource:status
 // +kubebuilder:metadata:labels="external-secrets.io/component=controller"
 // +kubebuilder:resource:scope=Namespaced,categories={externalsecrets},shortName=ss
@@ -289,6 +369,8 @@ type SecretStoreList struct {
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:printcolumn:name="Capabilities",type=string,JSONPath=`.status.capabilities`
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.providerVersion`,priority=1
+// +kubebuilder:printcolumn:name="Last Validated",type="date",JSONPath=".status.lastValidated",priority=1
 // +kubebuilder:subresource:status
 // +kubebuilder:metadata:labels="external-secrets.io/component=controller"
 // +kubebuilder:resource:scope=Cluster,categories={externalsecrets},shortName=css
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"regexp"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -59,13 +60,23 @@ func validateStore(store GenericStore) (admission.Warnings, error) {
 	if err := validateConditions(store); err != nil {
 		return nil, err
 	}
+	if err := validateAllowedKeys(store); err != nil {
+		return nil, err
+	}
 
 	provider, err := GetProvider(store)
 	if err != nil {
 		return nil, err
 	}
 
-	return provider.ValidateStore(store)
+	warnings, err := provider.ValidateStore(store)
+	if err != nil {
+		return warnings, err
+	}
+	if warning, ok := unmaintainedWarning(store.GetSpec().Provider); ok {
+		warnings = append(warnings, warning)
+	}
+	return warnings, nil
 }
 
 func validateConditions(store GenericStore) error {
@@ -76,6 +87,27 @@ func validateConditions(store GenericStore) error {
 				errs = errors.Join(errs, fmt.Errorf("failed to compile %dth namespace regex in %dth condition: %w", ri, ci, err))
 			}
 		}
+		for ri, r := range condition.TargetNameRegexes {
+			if _, err := regexp.Compile(r); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to compile %dth target name regex in %dth condition: %w", ri, ci, err))
+			}
+		}
+		if condition.ExternalSecretSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(condition.ExternalSecretSelector); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid externalSecretSelector in %dth condition: %w", ci, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateAllowedKeys(store GenericStore) error {
+	var errs error
+	for i, r := range store.GetSpec().AllowedKeys {
+		if _, err := regexp.Compile(r); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to compile %dth allowed key regex: %w", i, err))
+		}
 	}
 
 	return errs
@@ -84,6 +84,29 @@ func TestValidateSecretStore(t *testing.T) {
 				assert.EqualError(t, err, "failed to compile 0th namespace regex in 0th condition: error parsing regexp: invalid escape sequence: `\\1`")
 			},
 		},
+		{
+			name: "invalid target name regex",
+			obj: &SecretStore{
+				Spec: SecretStoreSpec{
+					Conditions: []ClusterSecretStoreCondition{
+						{
+							TargetNameRegexes: []string{`\1`},
+						},
+					},
+					Provider: &SecretStoreProvider{
+						AWS: &AWSProvider{},
+					},
+				},
+			},
+			mock: func() {
+				ForceRegister(&ValidationProvider{}, &SecretStoreProvider{
+					AWS: &AWSProvider{},
+				})
+			},
+			assertErr: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "failed to compile 0th target name regex in 0th condition: error parsing regexp: invalid escape sequence: `\\1`")
+			},
+		},
 		{
 			name: "multiple errors",
 			obj: &SecretStore{
@@ -107,6 +130,25 @@ func TestValidateSecretStore(t *testing.T) {
 				assert.EqualError(t, err, "failed to compile 0th namespace regex in 0th condition: error parsing regexp: invalid escape sequence: `\\1`\nfailed to compile 1th namespace regex in 0th condition: error parsing regexp: invalid escape sequence: `\\2`")
 			},
 		},
+		{
+			name: "invalid allowed key regex",
+			obj: &SecretStore{
+				Spec: SecretStoreSpec{
+					AllowedKeys: []string{`^app/`, `(`},
+					Provider: &SecretStoreProvider{
+						Gitlab: &GitlabProvider{},
+					},
+				},
+			},
+			mock: func() {
+				ForceRegister(&ValidationProvider{}, &SecretStoreProvider{
+					Gitlab: &GitlabProvider{},
+				})
+			},
+			assertErr: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "failed to compile 1th allowed key regex: error parsing regexp: missing closing ): `(`")
+			},
+		},
 		{
 			name: "secret store must have only a single backend",
 			obj: &SecretStore{
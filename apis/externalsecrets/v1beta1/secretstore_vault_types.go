@@ -86,6 +86,48 @@ type VaultProvider struct {
 	// https://www.vaultproject.io/docs/configuration/replication#allow_forwarding_via_header
 	// +optional
 	ForwardInconsistent bool `json:"forwardInconsistent,omitempty"`
+
+	// FindConcurrency is the number of secrets read in parallel when finding secrets
+	// with dataFrom.find. Defaults to 1, reading the matching secrets one after another.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FindConcurrency int `json:"findConcurrency,omitempty"`
+
+	// Transit passes the fetched values through the transit secrets engine, so they are
+	// stored encrypted in the target Secret and decrypted by the consumer, or decrypted
+	// if Vault holds transit ciphertext.
+	// +optional
+	Transit *VaultTransit `json:"transit,omitempty"`
+}
+
+type VaultTransitOperation string
+
+const (
+	// VaultTransitEncrypt encrypts the fetched values, the target holds the ciphertext.
+	VaultTransitEncrypt VaultTransitOperation = "Encrypt"
+	// VaultTransitDecrypt decrypts fetched ciphertext, the target holds the plaintext.
+	VaultTransitDecrypt VaultTransitOperation = "Decrypt"
+)
+
+// VaultTransit configures the transit secrets engine the fetched values are passed through.
+type VaultTransit struct {
+	// Path is the mount path of the transit secrets engine, e.g: "transit".
+	// +kubebuilder:default:="transit"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Key is the name of the transit encryption key.
+	Key string `json:"key"`
+
+	// Operation applied to the fetched values, either "Encrypt" or "Decrypt".
+	// +kubebuilder:validation:Enum="Encrypt";"Decrypt"
+	// +kubebuilder:default:="Encrypt"
+	// +optional
+	Operation VaultTransitOperation `json:"operation,omitempty"`
+
+	// Context is the base64 encoded context for key derivation, required by derived keys.
+	// +optional
+	Context string `json:"context,omitempty"`
 }
 
 // VaultClientTLS is the configuration used for client side related TLS communication,
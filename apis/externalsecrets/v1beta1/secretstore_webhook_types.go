@@ -44,6 +44,11 @@ type WebhookProvider struct {
 	// Result formatting
 	Result WebhookResult `json:"result"`
 
+	// MaxResponseBytes limits the size of the response body, larger responses are rejected.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxResponseBytes *int64 `json:"maxResponseBytes,omitempty"`
+
 	// Secrets to fill in templates
 	// These secrets will be passed to the templating function as key value pairs under the given name
 	// +optional
@@ -59,6 +64,129 @@ type WebhookProvider struct {
 	// The provider for the CA bundle to use to validate webhook server certificate.
 	// +optional
 	CAProvider *WebhookCAProvider `json:"caProvider,omitempty"`
+
+	// Auth configures how the webhook authenticates with the endpoint.
+	// +optional
+	Auth *WebhookAuth `json:"auth,omitempty"`
+
+	// HealthCheck configures the request used to validate the store.
+	// The request uses the headers, secrets and auth of the webhook, so the store readiness reflects the authorization.
+	// If not set, only the reachability of the url is checked.
+	// +optional
+	HealthCheck *WebhookHealthCheck `json:"healthCheck,omitempty"`
+
+	// Pagination configures how dataFrom.find follows the pages of a list response.
+	// The secret maps of all pages are merged.
+	// +optional
+	Pagination *WebhookPagination `json:"pagination,omitempty"`
+
+	// ConnectionPool configures the connections to the webhook.
+	// The connections are pooled per store and reused across reconciles.
+	// +optional
+	ConnectionPool *WebhookConnectionPool `json:"connectionPool,omitempty"`
+}
+
+type WebhookConnectionPool struct {
+	// MaxIdleConns limits the number of idle connections of the store to all hosts.
+	// Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConns int `json:"maxIdleConns,omitempty"`
+
+	// MaxIdleConnsPerHost limits the number of idle connections of the store per host.
+	// Defaults to 2, raise it for stores with many concurrent requests to reuse their connections.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost,omitempty"`
+
+	// MaxConnsPerHost limits the number of connections of the store per host,
+	// requests wait for a connection once the limit is reached.
+	// Not limited if not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConnsPerHost int `json:"maxConnsPerHost,omitempty"`
+
+	// IdleConnTimeout is the time an idle connection is kept open.
+	// Defaults to 90s.
+	// +optional
+	IdleConnTimeout *metav1.Duration `json:"idleConnTimeout,omitempty"`
+
+	// KeepAlive is the interval of the TCP keep-alive probes of the connections.
+	// Defaults to 30s.
+	// +optional
+	KeepAlive *metav1.Duration `json:"keepAlive,omitempty"`
+}
+
+type WebhookPagination struct {
+	// CursorJSONPath is the json path of the cursor of the next page in the response.
+	// The cursor is available in templates as {{ .pagination.cursor }}, it is empty for the first page,
+	// and the last page is reached when the cursor is missing or empty.
+	// If not set, the next page is taken from the RFC 5988 Link header with rel="next".
+	// +optional
+	CursorJSONPath string `json:"cursorJSONPath,omitempty"`
+
+	// MaxPages limits the number of requested pages, responses with more pages are rejected.
+	// Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPages int `json:"maxPages,omitempty"`
+}
+
+type WebhookHealthCheck struct {
+	// Method of the health request, defaults to GET.
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Path of the health request, resolved against the webhook url:
+	// an absolute path replaces the path of the url, e.g. `/healthz`.
+	// The secrets of the webhook can be used as template values.
+	Path string `json:"path"`
+
+	// ExpectedStatus is the status code of a healthy response, any 2xx status is accepted if not set.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	// +optional
+	ExpectedStatus int `json:"expectedStatus,omitempty"`
+
+	// Timeout of the health request, defaults to 15s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type WebhookAuth struct {
+	// PrivateKeyJWT renders a JWT signed with a private key for every request (private_key_jwt).
+	// The JWT is sent as bearer token unless an Authorization header is configured,
+	// and is available in templates as {{ .auth.jwt }}.
+	// +optional
+	PrivateKeyJWT *WebhookPrivateKeyJWT `json:"privateKeyJWT,omitempty"`
+}
+
+type WebhookPrivateKeyJWT struct {
+	// PrivateKeySecretRef references the PEM encoded private key used to sign the JWT.
+	PrivateKeySecretRef esmeta.SecretKeySelector `json:"privateKeySecretRef"`
+
+	// Algorithm used to sign the JWT, defaults to RS256.
+	// +kubebuilder:validation:Enum=RS256;RS384;RS512;PS256;PS384;PS512;ES256;ES384;ES512
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Issuer is set as iss claim.
+	Issuer string `json:"issuer"`
+
+	// Subject is set as sub claim, defaults to the issuer.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Audience is set as aud claim.
+	Audience string `json:"audience"`
+
+	// KeyID is set as kid header.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Expiry is the lifetime of the JWT, defaults to 5m.
+	// +optional
+	Expiry *metav1.Duration `json:"expiry,omitempty"`
 }
 
 type WebhookCAProviderType string
@@ -90,8 +218,30 @@ type WebhookResult struct {
 	// Json path of return value
 	// +optional
 	JSONPath string `json:"jsonPath,omitempty"`
+
+	// Format of the response body: json, yaml or raw.
+	// If not set, yaml is used for responses with a YAML content type and json otherwise.
+	// In raw mode the body is returned as is, e.g. for DER encoded certificates or binary keys,
+	// and jsonPath must not be set.
+	// +kubebuilder:validation:Enum=json;yaml;raw
+	// +optional
+	Format WebhookResultFormat `json:"format,omitempty"`
+
+	// Keys maps the keys of a secret map to the json path of their value in the response.
+	// If set, dataFrom.extract returns these keys instead of the object at jsonPath.
+	// The paths are evaluated against the whole response, jsonPath is only used for data entries.
+	// +optional
+	Keys map[string]string `json:"keys,omitempty"`
 }
 
+type WebhookResultFormat string
+
+const (
+	WebhookResultFormatJSON WebhookResultFormat = "json"
+	WebhookResultFormatYAML WebhookResultFormat = "yaml"
+	WebhookResultFormatRaw  WebhookResultFormat = "raw"
+)
+
 type WebhookSecret struct {
 	// Name of this secret in templates
 	Name string `json:"name"`
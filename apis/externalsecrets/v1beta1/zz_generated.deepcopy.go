@@ -20,6 +20,7 @@ package v1beta1
 
 import (
 	metav1 "github.com/external-secrets/external-secrets/apis/meta/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -334,6 +335,11 @@ func (in *AzureKVAuth) DeepCopyInto(out *AzureKVAuth) {
 		*out = new(metav1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClientCertificatePassword != nil {
+		in, out := &in.ClientCertificatePassword, &out.ClientCertificatePassword
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKVAuth.
@@ -391,6 +397,23 @@ func (in *AzureKVProvider) DeepCopy() *AzureKVProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleProvider) DeepCopyInto(out *BundleProvider) {
+	*out = *in
+	in.DecryptionKeySecretRef.DeepCopyInto(&out.DecryptionKeySecretRef)
+	in.VerificationKeySecretRef.DeepCopyInto(&out.VerificationKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleProvider.
+func (in *BundleProvider) DeepCopy() *BundleProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CAProvider) DeepCopyInto(out *CAProvider) {
 	*out = *in
@@ -480,6 +503,38 @@ func (in *ChefProvider) DeepCopy() *ChefProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudflareAuth) DeepCopyInto(out *CloudflareAuth) {
+	*out = *in
+	in.APITokenSecretRef.DeepCopyInto(&out.APITokenSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudflareAuth.
+func (in *CloudflareAuth) DeepCopy() *CloudflareAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudflareAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudflareProvider) DeepCopyInto(out *CloudflareProvider) {
+	*out = *in
+	in.Auth.DeepCopyInto(&out.Auth)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudflareProvider.
+func (in *CloudflareProvider) DeepCopy() *CloudflareProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudflareProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterExternalSecret) DeepCopyInto(out *ClusterExternalSecret) {
 	*out = *in
@@ -687,6 +742,16 @@ func (in *ClusterSecretStoreCondition) DeepCopyInto(out *ClusterSecretStoreCondi
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExternalSecretSelector != nil {
+		in, out := &in.ExternalSecretSelector, &out.ExternalSecretSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetNameRegexes != nil {
+		in, out := &in.TargetNameRegexes, &out.TargetNameRegexes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSecretStoreCondition.
@@ -952,6 +1017,21 @@ func (in *DopplerAuthSecretRef) DeepCopy() *DopplerAuthSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DopplerConfig) DeepCopyInto(out *DopplerConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DopplerConfig.
+func (in *DopplerConfig) DeepCopy() *DopplerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DopplerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DopplerProvider) DeepCopyInto(out *DopplerProvider) {
 	*out = *in
@@ -960,6 +1040,11 @@ func (in *DopplerProvider) DeepCopyInto(out *DopplerProvider) {
 		*out = new(DopplerAuth)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Configs != nil {
+		in, out := &in.Configs, &out.Configs
+		*out = make([]DopplerConfig, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DopplerProvider.
@@ -972,6 +1057,54 @@ func (in *DopplerProvider) DeepCopy() *DopplerProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdAuth) DeepCopyInto(out *EtcdAuth) {
+	*out = *in
+	in.CertSecretRef.DeepCopyInto(&out.CertSecretRef)
+	in.KeySecretRef.DeepCopyInto(&out.KeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdAuth.
+func (in *EtcdAuth) DeepCopy() *EtcdAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdProvider) DeepCopyInto(out *EtcdProvider) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	in.Auth.DeepCopyInto(&out.Auth)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdProvider.
+func (in *EtcdProvider) DeepCopy() *EtcdProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecret) DeepCopyInto(out *ExternalSecret) {
 	*out = *in
@@ -999,15 +1132,75 @@ func (in *ExternalSecret) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretArchive) DeepCopyInto(out *ExternalSecretArchive) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretArchive.
+func (in *ExternalSecretArchive) DeepCopy() *ExternalSecretArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretCertificateBundle) DeepCopyInto(out *ExternalSecretCertificateBundle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretCertificateBundle.
+func (in *ExternalSecretCertificateBundle) DeepCopy() *ExternalSecretCertificateBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretCertificateBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretChunking) DeepCopyInto(out *ExternalSecretChunking) {
+	*out = *in
+	if in.ChunkSize != nil {
+		in, out := &in.ChunkSize, &out.ChunkSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxChunks != nil {
+		in, out := &in.MaxChunks, &out.MaxChunks
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretChunking.
+func (in *ExternalSecretChunking) DeepCopy() *ExternalSecretChunking {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretChunking)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretData) DeepCopyInto(out *ExternalSecretData) {
 	*out = *in
-	out.RemoteRef = in.RemoteRef
+	in.RemoteRef.DeepCopyInto(&out.RemoteRef)
 	if in.SourceRef != nil {
 		in, out := &in.SourceRef, &out.SourceRef
 		*out = new(StoreSourceRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IgnoreChangesRegex != nil {
+		in, out := &in.IgnoreChangesRegex, &out.IgnoreChangesRegex
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretData.
@@ -1026,7 +1219,7 @@ func (in *ExternalSecretDataFromRemoteRef) DeepCopyInto(out *ExternalSecretDataF
 	if in.Extract != nil {
 		in, out := &in.Extract, &out.Extract
 		*out = new(ExternalSecretDataRemoteRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Find != nil {
 		in, out := &in.Find, &out.Find
@@ -1045,6 +1238,11 @@ func (in *ExternalSecretDataFromRemoteRef) DeepCopyInto(out *ExternalSecretDataF
 		*out = new(StoreGeneratorSourceRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Bundle != nil {
+		in, out := &in.Bundle, &out.Bundle
+		*out = new(ExternalSecretCertificateBundle)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretDataFromRemoteRef.
@@ -1060,6 +1258,11 @@ func (in *ExternalSecretDataFromRemoteRef) DeepCopy() *ExternalSecretDataFromRem
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretDataRemoteRef) DeepCopyInto(out *ExternalSecretDataRemoteRef) {
 	*out = *in
+	if in.Normalize != nil {
+		in, out := &in.Normalize, &out.Normalize
+		*out = new(ExternalSecretNormalization)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretDataRemoteRef.
@@ -1072,6 +1275,62 @@ func (in *ExternalSecretDataRemoteRef) DeepCopy() *ExternalSecretDataRemoteRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretDryRunStatus) DeepCopyInto(out *ExternalSecretDryRunStatus) {
+	*out = *in
+	in.RenderTime.DeepCopyInto(&out.RenderTime)
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddedKeys != nil {
+		in, out := &in.AddedKeys, &out.AddedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedKeys != nil {
+		in, out := &in.ChangedKeys, &out.ChangedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovedKeys != nil {
+		in, out := &in.RemovedKeys, &out.RemovedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConflictingKeys != nil {
+		in, out := &in.ConflictingKeys, &out.ConflictingKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretDryRunStatus.
+func (in *ExternalSecretDryRunStatus) DeepCopy() *ExternalSecretDryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretDryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretEncryption) DeepCopyInto(out *ExternalSecretEncryption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretEncryption.
+func (in *ExternalSecretEncryption) DeepCopy() *ExternalSecretEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretFind) DeepCopyInto(out *ExternalSecretFind) {
 	*out = *in
@@ -1092,6 +1351,11 @@ func (in *ExternalSecretFind) DeepCopyInto(out *ExternalSecretFind) {
 			(*out)[key] = val
 		}
 	}
+	if in.Normalize != nil {
+		in, out := &in.Normalize, &out.Normalize
+		*out = new(ExternalSecretNormalization)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretFind.
@@ -1165,6 +1429,46 @@ func (in *ExternalSecretMetadata) DeepCopy() *ExternalSecretMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretNormalization) DeepCopyInto(out *ExternalSecretNormalization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretNormalization.
+func (in *ExternalSecretNormalization) DeepCopy() *ExternalSecretNormalization {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretNormalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretRetryPolicy) DeepCopyInto(out *ExternalSecretRetryPolicy) {
+	*out = *in
+	if in.InitialInterval != nil {
+		in, out := &in.InitialInterval, &out.InitialInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInterval != nil {
+		in, out := &in.MaxInterval, &out.MaxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretRetryPolicy.
+func (in *ExternalSecretRetryPolicy) DeepCopy() *ExternalSecretRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretRewrite) DeepCopyInto(out *ExternalSecretRewrite) {
 	*out = *in
@@ -1178,6 +1482,11 @@ func (in *ExternalSecretRewrite) DeepCopyInto(out *ExternalSecretRewrite) {
 		*out = new(ExternalSecretRewriteTransform)
 		**out = **in
 	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(ExternalSecretRewriteOperation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretRewrite.
@@ -1190,6 +1499,21 @@ func (in *ExternalSecretRewrite) DeepCopy() *ExternalSecretRewrite {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretRewriteOperation) DeepCopyInto(out *ExternalSecretRewriteOperation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretRewriteOperation.
+func (in *ExternalSecretRewriteOperation) DeepCopy() *ExternalSecretRewriteOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretRewriteOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretRewriteRegexp) DeepCopyInto(out *ExternalSecretRewriteRegexp) {
 	*out = *in
@@ -1220,16 +1544,46 @@ func (in *ExternalSecretRewriteTransform) DeepCopy() *ExternalSecretRewriteTrans
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretRotation) DeepCopyInto(out *ExternalSecretRotation) {
+	*out = *in
+	if in.Generations != nil {
+		in, out := &in.Generations, &out.Generations
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretRotation.
+func (in *ExternalSecretRotation) DeepCopy() *ExternalSecretRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretSpec) DeepCopyInto(out *ExternalSecretSpec) {
 	*out = *in
 	out.SecretStoreRef = in.SecretStoreRef
+	if in.SecretStoreRefs != nil {
+		in, out := &in.SecretStoreRefs, &out.SecretStoreRefs
+		*out = make([]SecretStoreRef, len(*in))
+		copy(*out, *in)
+	}
 	in.Target.DeepCopyInto(&out.Target)
 	if in.RefreshInterval != nil {
 		in, out := &in.RefreshInterval, &out.RefreshInterval
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.DriftCheckInterval != nil {
+		in, out := &in.DriftCheckInterval, &out.DriftCheckInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Data != nil {
 		in, out := &in.Data, &out.Data
 		*out = make([]ExternalSecretData, len(*in))
@@ -1244,6 +1598,11 @@ func (in *ExternalSecretSpec) DeepCopyInto(out *ExternalSecretSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(ExternalSecretRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretSpec.
@@ -1268,6 +1627,24 @@ func (in *ExternalSecretStatus) DeepCopyInto(out *ExternalSecretStatus) {
 		}
 	}
 	out.Binding = in.Binding
+	if in.SecretProtections != nil {
+		in, out := &in.SecretProtections, &out.SecretProtections
+		*out = make([]SecretProtectionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(ExternalSecretDryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretStatus.
@@ -1299,11 +1676,36 @@ func (in *ExternalSecretStatusCondition) DeepCopy() *ExternalSecretStatusConditi
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretTarget) DeepCopyInto(out *ExternalSecretTarget) {
 	*out = *in
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ExternalSecretArchive)
+		**out = **in
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(ExternalSecretTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(ExternalSecretRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ExternalSecretEncryption)
+		**out = **in
+	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(ExternalSecretValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Chunking != nil {
+		in, out := &in.Chunking, &out.Chunking
+		*out = new(ExternalSecretChunking)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretTarget.
@@ -1376,16 +1778,26 @@ func (in *ExternalSecretTemplateMetadata) DeepCopy() *ExternalSecretTemplateMeta
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalSecretValidator) DeepCopyInto(out *ExternalSecretValidator) {
+func (in *ExternalSecretValidation) DeepCopyInto(out *ExternalSecretValidation) {
 	*out = *in
+	if in.PasswordKeys != nil {
+		in, out := &in.PasswordKeys, &out.PasswordKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinPasswordEntropy != nil {
+		in, out := &in.MinPasswordEntropy, &out.MinPasswordEntropy
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretValidator.
-func (in *ExternalSecretValidator) DeepCopy() *ExternalSecretValidator {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretValidation.
+func (in *ExternalSecretValidation) DeepCopy() *ExternalSecretValidation {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalSecretValidator)
+	out := new(ExternalSecretValidation)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1400,6 +1812,11 @@ func (in *FakeProvider) DeepCopyInto(out *FakeProvider) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FakeProvider.
@@ -1415,6 +1832,11 @@ func (in *FakeProvider) DeepCopy() *FakeProvider {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FakeProviderData) DeepCopyInto(out *FakeProviderData) {
 	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ValueMap != nil {
 		in, out := &in.ValueMap, &out.ValueMap
 		*out = make(map[string]string, len(*in))
@@ -1502,6 +1924,11 @@ func (in *GCPSMAuth) DeepCopyInto(out *GCPSMAuth) {
 		*out = new(GCPWorkloadIdentity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ServiceAccountImpersonation != nil {
+		in, out := &in.ServiceAccountImpersonation, &out.ServiceAccountImpersonation
+		*out = new(GCPServiceAccountImpersonation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSMAuth.
@@ -1546,6 +1973,36 @@ func (in *GCPSMProvider) DeepCopy() *GCPSMProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPServiceAccountImpersonation) DeepCopyInto(out *GCPServiceAccountImpersonation) {
+	*out = *in
+	if in.Delegates != nil {
+		in, out := &in.Delegates, &out.Delegates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPServiceAccountImpersonation.
+func (in *GCPServiceAccountImpersonation) DeepCopy() *GCPServiceAccountImpersonation {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPServiceAccountImpersonation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPWorkloadIdentity) DeepCopyInto(out *GCPWorkloadIdentity) {
 	*out = *in
@@ -1775,6 +2232,22 @@ func (in *KeeperSecurityProvider) DeepCopy() *KeeperSecurityProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigAuth) DeepCopyInto(out *KubeconfigAuth) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigAuth.
+func (in *KubeconfigAuth) DeepCopy() *KubeconfigAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesAuth) DeepCopyInto(out *KubernetesAuth) {
 	*out = *in
@@ -1793,6 +2266,11 @@ func (in *KubernetesAuth) DeepCopyInto(out *KubernetesAuth) {
 		*out = new(metav1.ServiceAccountSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Kubeconfig != nil {
+		in, out := &in.Kubeconfig, &out.Kubeconfig
+		*out = new(KubeconfigAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesAuth.
@@ -1938,6 +2416,11 @@ func (in *OnePasswordAuth) DeepCopy() *OnePasswordAuth {
 func (in *OnePasswordAuthSecretRef) DeepCopyInto(out *OnePasswordAuthSecretRef) {
 	*out = *in
 	in.ConnectToken.DeepCopyInto(&out.ConnectToken)
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnePasswordAuthSecretRef.
@@ -2128,6 +2611,59 @@ func (in *PasswordDepotSecretRef) DeepCopy() *PasswordDepotSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginProvider) DeepCopyInto(out *PluginProvider) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]PluginSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginProvider.
+func (in *PluginProvider) DeepCopy() *PluginProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSecret) DeepCopyInto(out *PluginSecret) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSecret.
+func (in *PluginSecret) DeepCopy() *PluginSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PulumiProvider) DeepCopyInto(out *PulumiProvider) {
 	*out = *in
@@ -2213,6 +2749,21 @@ func (in *ScalewayProviderSecretRef) DeepCopy() *ScalewayProviderSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProtectionStatus) DeepCopyInto(out *SecretProtectionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProtectionStatus.
+func (in *SecretProtectionStatus) DeepCopy() *SecretProtectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProtectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretStore) DeepCopyInto(out *SecretStore) {
 	*out = *in
@@ -2272,6 +2823,21 @@ func (in *SecretStoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStoreOrphanCleanup) DeepCopyInto(out *SecretStoreOrphanCleanup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreOrphanCleanup.
+func (in *SecretStoreOrphanCleanup) DeepCopy() *SecretStoreOrphanCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStoreOrphanCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretStoreProvider) DeepCopyInto(out *SecretStoreProvider) {
 	*out = *in
@@ -2420,6 +2986,26 @@ func (in *SecretStoreProvider) DeepCopyInto(out *SecretStoreProvider) {
 		*out = new(InfisicalProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Bundle != nil {
+		in, out := &in.Bundle, &out.Bundle
+		*out = new(BundleProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(EtcdProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cloudflare != nil {
+		in, out := &in.Cloudflare, &out.Cloudflare
+		*out = new(CloudflareProvider)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreProvider.
@@ -2485,6 +3071,11 @@ func (in *SecretStoreSpec) DeepCopyInto(out *SecretStoreSpec) {
 		*out = new(SecretStoreRetrySettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValidationInterval != nil {
+		in, out := &in.ValidationInterval, &out.ValidationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]ClusterSecretStoreCondition, len(*in))
@@ -2492,6 +3083,16 @@ func (in *SecretStoreSpec) DeepCopyInto(out *SecretStoreSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AllowedKeys != nil {
+		in, out := &in.AllowedKeys, &out.AllowedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrphanCleanup != nil {
+		in, out := &in.OrphanCleanup, &out.OrphanCleanup
+		*out = new(SecretStoreOrphanCleanup)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreSpec.
@@ -2514,6 +3115,15 @@ func (in *SecretStoreStatus) DeepCopyInto(out *SecretStoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastValidated != nil {
+		in, out := &in.LastValidated, &out.LastValidated
+		*out = (*in).DeepCopy()
+	}
+	if in.OrphanedSecrets != nil {
+		in, out := &in.OrphanedSecrets, &out.OrphanedSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStoreStatus.
@@ -3084,6 +3694,11 @@ func (in *VaultProvider) DeepCopyInto(out *VaultProvider) {
 		*out = new(CAProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Transit != nil {
+		in, out := &in.Transit, &out.Transit
+		*out = new(VaultTransit)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultProvider.
@@ -3096,6 +3711,21 @@ func (in *VaultProvider) DeepCopy() *VaultProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultTransit) DeepCopyInto(out *VaultTransit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultTransit.
+func (in *VaultTransit) DeepCopy() *VaultTransit {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultTransit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VaultUserPassAuth) DeepCopyInto(out *VaultUserPassAuth) {
 	*out = *in
@@ -3112,6 +3742,26 @@ func (in *VaultUserPassAuth) DeepCopy() *VaultUserPassAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAuth) DeepCopyInto(out *WebhookAuth) {
+	*out = *in
+	if in.PrivateKeyJWT != nil {
+		in, out := &in.PrivateKeyJWT, &out.PrivateKeyJWT
+		*out = new(WebhookPrivateKeyJWT)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAuth.
+func (in *WebhookAuth) DeepCopy() *WebhookAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookCAProvider) DeepCopyInto(out *WebhookCAProvider) {
 	*out = *in
@@ -3132,6 +3782,87 @@ func (in *WebhookCAProvider) DeepCopy() *WebhookCAProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookConnectionPool) DeepCopyInto(out *WebhookConnectionPool) {
+	*out = *in
+	if in.IdleConnTimeout != nil {
+		in, out := &in.IdleConnTimeout, &out.IdleConnTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepAlive != nil {
+		in, out := &in.KeepAlive, &out.KeepAlive
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookConnectionPool.
+func (in *WebhookConnectionPool) DeepCopy() *WebhookConnectionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookConnectionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookHealthCheck) DeepCopyInto(out *WebhookHealthCheck) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookHealthCheck.
+func (in *WebhookHealthCheck) DeepCopy() *WebhookHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookPagination) DeepCopyInto(out *WebhookPagination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookPagination.
+func (in *WebhookPagination) DeepCopy() *WebhookPagination {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookPagination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookPrivateKeyJWT) DeepCopyInto(out *WebhookPrivateKeyJWT) {
+	*out = *in
+	in.PrivateKeySecretRef.DeepCopyInto(&out.PrivateKeySecretRef)
+	if in.Expiry != nil {
+		in, out := &in.Expiry, &out.Expiry
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookPrivateKeyJWT.
+func (in *WebhookPrivateKeyJWT) DeepCopy() *WebhookPrivateKeyJWT {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookPrivateKeyJWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookProvider) DeepCopyInto(out *WebhookProvider) {
 	*out = *in
@@ -3147,7 +3878,12 @@ func (in *WebhookProvider) DeepCopyInto(out *WebhookProvider) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
-	out.Result = in.Result
+	in.Result.DeepCopyInto(&out.Result)
+	if in.MaxResponseBytes != nil {
+		in, out := &in.MaxResponseBytes, &out.MaxResponseBytes
+		*out = new(int64)
+		**out = **in
+	}
 	if in.Secrets != nil {
 		in, out := &in.Secrets, &out.Secrets
 		*out = make([]WebhookSecret, len(*in))
@@ -3165,6 +3901,26 @@ func (in *WebhookProvider) DeepCopyInto(out *WebhookProvider) {
 		*out = new(WebhookCAProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(WebhookAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(WebhookHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pagination != nil {
+		in, out := &in.Pagination, &out.Pagination
+		*out = new(WebhookPagination)
+		**out = **in
+	}
+	if in.ConnectionPool != nil {
+		in, out := &in.ConnectionPool, &out.ConnectionPool
+		*out = new(WebhookConnectionPool)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookProvider.
@@ -3180,6 +3936,13 @@ func (in *WebhookProvider) DeepCopy() *WebhookProvider {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookResult) DeepCopyInto(out *WebhookResult) {
 	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookResult.
@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"context"
+	"time"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,3 +34,37 @@ type Generator interface {
 		namespace string,
 	) (map[string][]byte, error)
 }
+
+// Renewer is implemented by generators whose generated values expire, e.g. certificates.
+// RenewalTime returns the time the data generated from the spec must be renewed,
+// the ExternalSecret is refreshed at that time regardless of its refresh interval.
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+type Renewer interface {
+	RenewalTime(obj *apiextensions.JSON, data map[string][]byte) (time.Time, error)
+}
+
+// Cleaner is implemented by generators whose generated values can be revoked, e.g. tokens.
+// Cleanup revokes the data generated from the spec once it is no longer used,
+// e.g. after a SecretRotation replaced it.
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+type Cleaner interface {
+	Cleanup(ctx context.Context, obj *apiextensions.JSON, data map[string][]byte, kube client.Client, namespace string) error
+}
+
+// RotationOnly is implemented by generators whose generated values must be revoked with Cleanup
+// once they are replaced, e.g. credentials that accumulate in the backend until they expire.
+// They can only be used by a SecretRotation, ExternalSecrets and PushSecrets reject them.
+// +kubebuilder:object:root=false
+// +kubebuilder:object:generate:false
+// +k8s:deepcopy-gen:interfaces=nil
+// +k8s:deepcopy-gen=nil
+type RotationOnly interface {
+	Cleaner
+	RotationOnly()
+}
@@ -45,6 +45,12 @@ type ACRAccessTokenSpec struct {
 	// +optional
 	Scope string `json:"scope,omitempty"`
 
+	// ScopeMapToken generates a password for an ACR token that is bound to a scope map,
+	// e.g. to grant pull-only access to selected repositories, instead of an AAD based token.
+	// The identity needs the permission to generate credentials of the token in the registry.
+	// +optional
+	ScopeMapToken *ACRScopeMapToken `json:"scopeMapToken,omitempty"`
+
 	// EnvironmentType specifies the Azure cloud environment endpoints to use for
 	// connecting and authenticating with Azure. By default it points to the public cloud AAD endpoint.
 	// The following endpoints are available, also see here: https://github.com/Azure/go-autorest/blob/main/autorest/azure/environments.go#L152
@@ -53,6 +59,29 @@ type ACRAccessTokenSpec struct {
 	EnvironmentType v1beta1.AzureEnvironmentType `json:"environmentType,omitempty"`
 }
 
+// ACRScopeMapToken references an ACR token resource bound to a scope map.
+// See docs: https://learn.microsoft.com/en-us/azure/container-registry/container-registry-repository-scoped-permissions
+type ACRScopeMapToken struct {
+	// SubscriptionID of the registry.
+	SubscriptionID string `json:"subscriptionId"`
+
+	// ResourceGroup of the registry.
+	ResourceGroup string `json:"resourceGroup"`
+
+	// TokenName is the name of the token resource in the registry.
+	TokenName string `json:"tokenName"`
+
+	// PasswordName selects the password of the token that is regenerated.
+	// +kubebuilder:validation:Enum=password1;password2
+	// +kubebuilder:default=password1
+	// +optional
+	PasswordName string `json:"passwordName,omitempty"`
+
+	// Expiry of the generated password, the password does not expire if not set.
+	// +optional
+	Expiry *metav1.Duration `json:"expiry,omitempty"`
+}
+
 type ACRAuth struct {
 	// ServicePrincipal uses Azure Service Principal credentials to authenticate with Azure.
 	// +optional
@@ -98,6 +127,7 @@ type AzureACRServicePrincipalAuthSecretRef struct {
 // (depending on the identity).
 // This can be scoped down to the repository level using .spec.scope.
 // In case scope is defined it will return an ACR Access Token.
+// With .spec.scopeMapToken it returns a password of an ACR token bound to a scope map.
 //
 // See docs: https://github.com/Azure/acr/blob/main/docs/AAD-OAuth.md
 //
@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+type AzureWorkloadTokenSpec struct {
+	// Scopes of the access token, e.g. api://my-api/.default.
+	// +kubebuilder:validation:MinItems=1
+	Scopes []string `json:"scopes"`
+
+	// ServiceAccountRef specifies the service account whose token is exchanged for the access token.
+	// The client and tenant ID are read from its azure.workload.identity annotations.
+	// If not set, the token of the controller projected by the azure workload identity webhook is used.
+	// +optional
+	ServiceAccountRef *smmeta.ServiceAccountSelector `json:"serviceAccountRef,omitempty"`
+
+	// ClientID of the app registration or managed identity with the federated credential.
+	// Defaults to the client ID of the service account or the controller.
+	// +optional
+	ClientID string `json:"clientId,omitempty"`
+
+	// TenantID of the app registration or managed identity.
+	// Defaults to the tenant ID of the service account or the controller.
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+
+	// RenewBefore is how long before the expiry the token is renewed.
+	// Defaults to 15 minutes.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// EnvironmentType specifies the Azure cloud environment endpoints to use for
+	// connecting and authenticating with Azure. By default it points to the public cloud AAD endpoint.
+	// The following endpoints are available, also see here: https://github.com/Azure/go-autorest/blob/main/autorest/azure/environments.go#L152
+	// PublicCloud, USGovernmentCloud, ChinaCloud, GermanCloud
+	// +kubebuilder:default=PublicCloud
+	EnvironmentType v1beta1.AzureEnvironmentType `json:"environmentType,omitempty"`
+}
+
+// AzureWorkloadToken exchanges a Kubernetes service account token for a short-lived
+// Microsoft Entra ID access token with arbitrary scopes using workload identity federation.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={azureworkloadtoken},shortName=azureworkloadtoken
+type AzureWorkloadToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AzureWorkloadTokenSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AzureWorkloadTokenList contains a list of AzureWorkloadToken resources.
+type AzureWorkloadTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AzureWorkloadToken `json:"items"`
+}
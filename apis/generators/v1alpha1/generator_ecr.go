@@ -24,6 +24,20 @@ type ECRAuthorizationTokenSpec struct {
 	// Region specifies the region to operate in.
 	Region string `json:"region"`
 
+	// Regions to generate authorization tokens for in addition to region.
+	// The tokens of all regions are output as JSON map keyed by region in `regions`,
+	// dockerconfigjson and cred_helpers hold the registries of all regions.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+
+	// UseFIPSEndpoint resolves the FIPS endpoints of ECR.
+	// +optional
+	UseFIPSEndpoint bool `json:"useFIPSEndpoint,omitempty"`
+
+	// UseDualStackEndpoint resolves the dualstack (IPv4 and IPv6) endpoints of ECR.
+	// +optional
+	UseDualStackEndpoint bool `json:"useDualStackEndpoint,omitempty"`
+
 	// Auth defines how to authenticate with AWS
 	// +optional
 	Auth AWSAuth `json:"auth,omitempty"`
@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type EntraClientSecretSpec struct {
+	// Auth configures how the generator authenticates with Microsoft Graph.
+	// The identity needs the permission to update the app registration,
+	// e.g. the Application.ReadWrite.OwnedBy role and ownership of the app registration.
+	Auth ACRAuth `json:"auth"`
+
+	// TenantID configures the Azure Tenant to send requests to. Required for ServicePrincipal auth type.
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+
+	// ApplicationID is the application (client) ID of the app registration
+	// the client secret is added to.
+	ApplicationID string `json:"applicationId"`
+
+	// DisplayName of the client secret in the app registration.
+	// Defaults to external-secrets.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Validity of the client secret. Defaults to 90 days.
+	// +optional
+	Validity *metav1.Duration `json:"validity,omitempty"`
+
+	// RenewBefore is how long before the expiry the client secret is renewed.
+	// Defaults to a third of the validity of the client secret.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// EnvironmentType specifies the Azure cloud environment endpoints to use for
+	// connecting and authenticating with Azure. By default it points to the public cloud AAD endpoint.
+	// The following endpoints are available, also see here: https://github.com/Azure/go-autorest/blob/main/autorest/azure/environments.go#L152
+	// PublicCloud, USGovernmentCloud, ChinaCloud
+	// +kubebuilder:default=PublicCloud
+	EnvironmentType v1beta1.AzureEnvironmentType `json:"environmentType,omitempty"`
+}
+
+// EntraClientSecret adds a client secret to a Microsoft Entra ID app registration
+// with Microsoft Graph. Used with a SecretRotation the previous client secret
+// is removed from the app registration once it has been replaced.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={entraclientsecret},shortName=entraclientsecret
+type EntraClientSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EntraClientSecretSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EntraClientSecretList contains a list of EntraClientSecret resources.
+type EntraClientSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EntraClientSecret `json:"items"`
+}
@@ -44,6 +44,11 @@ type PasswordSpec struct {
 	// set AllowRepeat to true to allow repeating characters.
 	// +kubebuilder:default=false
 	AllowRepeat bool `json:"allowRepeat"`
+
+	// Policy is the name of the ClusterPasswordPolicy the generated passwords must satisfy.
+	// If omitted the ClusterPasswordPolicy marked as default is used, if any.
+	// +optional
+	Policy string `json:"policy,omitempty"`
 }
 
 // Password generates a random password based on the
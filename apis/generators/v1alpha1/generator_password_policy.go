@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterPasswordPolicySpec defines the minimum requirements of the passwords
+// generated by the Password generators referencing the policy.
+type ClusterPasswordPolicySpec struct {
+	// Default applies the policy to all Password generators that do not reference a policy.
+	// Only one policy should be marked as default.
+	// +optional
+	Default bool `json:"default,omitempty"`
+
+	// Length is the minimum length of the generated passwords.
+	// +kubebuilder:validation:Minimum=1
+	Length int `json:"length"`
+
+	// Digits is the minimum number of digits in the generated passwords.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Digits int `json:"digits,omitempty"`
+
+	// Symbols is the minimum number of symbol characters in the generated passwords.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Symbols int `json:"symbols,omitempty"`
+
+	// SymbolCharacters are the symbol characters the generators may use.
+	// Generators without symbolCharacters use all of them.
+	// +optional
+	SymbolCharacters *string `json:"symbolCharacters,omitempty"`
+
+	// ExcludedCharacters are never used in the generated passwords, e.g. ambiguous characters like "lI1O0".
+	// +optional
+	ExcludedCharacters string `json:"excludedCharacters,omitempty"`
+
+	// AllowNoUpper allows generators to disable uppercase characters.
+	// +optional
+	AllowNoUpper bool `json:"allowNoUpper,omitempty"`
+
+	// AllowRepeat allows generators to use repeating characters.
+	// +optional
+	AllowRepeat bool `json:"allowRepeat,omitempty"`
+}
+
+// ClusterPasswordPolicy is a named password profile defined by cluster admins.
+// Password generators reference it by name and are rejected at admission
+// and generation if they would generate passwords weaker than the policy.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Cluster,categories={password},shortName=cpp
+// +kubebuilder:printcolumn:name="Length",type=integer,JSONPath=`.spec.length`
+// +kubebuilder:printcolumn:name="Default",type=boolean,JSONPath=`.spec.default`
+type ClusterPasswordPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterPasswordPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterPasswordPolicyList contains a list of ClusterPasswordPolicy resources.
+type ClusterPasswordPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPasswordPolicy `json:"items"`
+}
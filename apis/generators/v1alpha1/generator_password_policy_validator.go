@@ -0,0 +1,129 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// PasswordValidator rejects Password generators that do not satisfy their ClusterPasswordPolicy.
+// +kubebuilder:object:generate=false
+type PasswordValidator struct {
+	Reader client.Reader
+}
+
+func (v *PasswordValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validatePassword(ctx, obj)
+}
+
+func (v *PasswordValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validatePassword(ctx, newObj)
+}
+
+func (v *PasswordValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *PasswordValidator) validatePassword(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	p, ok := obj.(*Password)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type")
+	}
+	policy, err := ResolvePasswordPolicy(ctx, v.Reader, &p.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, nil
+	}
+	if err := policy.Spec.Check(&p.Spec); err != nil {
+		return nil, fmt.Errorf("password does not satisfy ClusterPasswordPolicy %s: %w", policy.Name, err)
+	}
+	return nil, nil
+}
+
+// ResolvePasswordPolicy returns the ClusterPasswordPolicy referenced by the spec
+// or the default policy if the spec does not reference one.
+// It returns nil if there is no default policy, or if the policies are not installed or can not be listed.
+func ResolvePasswordPolicy(ctx context.Context, reader client.Reader, spec *PasswordSpec) (*ClusterPasswordPolicy, error) {
+	if spec.Policy != "" {
+		var policy ClusterPasswordPolicy
+		if err := reader.Get(ctx, client.ObjectKey{Name: spec.Policy}, &policy); err != nil {
+			return nil, fmt.Errorf("could not get ClusterPasswordPolicy %s: %w", spec.Policy, err)
+		}
+		return &policy, nil
+	}
+
+	var list ClusterPasswordPolicyList
+	if err := reader.List(ctx, &list); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not list ClusterPasswordPolicies: %w", err)
+	}
+	var defaults []string
+	var policy *ClusterPasswordPolicy
+	for i := range list.Items {
+		if list.Items[i].Spec.Default {
+			defaults = append(defaults, list.Items[i].Name)
+			policy = &list.Items[i]
+		}
+	}
+	if len(defaults) > 1 {
+		return nil, fmt.Errorf("multiple ClusterPasswordPolicies are marked as default: %s", strings.Join(defaults, ", "))
+	}
+	return policy, nil
+}
+
+// Check returns an error if the passwords generated with the spec would be weaker than the policy.
+// Digits and symbols that are not set in the spec are raised to the minimum of the policy by the generator.
+func (p *ClusterPasswordPolicySpec) Check(spec *PasswordSpec) error {
+	var errs error
+	if spec.Length < p.Length {
+		errs = errors.Join(errs, fmt.Errorf("length %d is shorter than the minimum length %d", spec.Length, p.Length))
+	}
+	if spec.Digits != nil && *spec.Digits < p.Digits {
+		errs = errors.Join(errs, fmt.Errorf("digits %d is less than the minimum of %d digits", *spec.Digits, p.Digits))
+	}
+	if spec.Symbols != nil && *spec.Symbols < p.Symbols {
+		errs = errors.Join(errs, fmt.Errorf("symbols %d is less than the minimum of %d symbols", *spec.Symbols, p.Symbols))
+	}
+	if spec.SymbolCharacters != nil {
+		for _, c := range *spec.SymbolCharacters {
+			if p.SymbolCharacters != nil && !strings.ContainsRune(*p.SymbolCharacters, c) {
+				errs = errors.Join(errs, fmt.Errorf("symbol character %q is not allowed", c))
+			}
+			if strings.ContainsRune(p.ExcludedCharacters, c) {
+				errs = errors.Join(errs, fmt.Errorf("symbol character %q is excluded", c))
+			}
+		}
+	}
+	if spec.NoUpper && !p.AllowNoUpper {
+		errs = errors.Join(errs, fmt.Errorf("uppercase characters can not be disabled"))
+	}
+	if spec.AllowRepeat && !p.AllowRepeat {
+		errs = errors.Join(errs, fmt.Errorf("repeating characters are not allowed"))
+	}
+	return errs
+}
@@ -0,0 +1,109 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidatePassword(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = AddToScheme(scheme)
+
+	digits := 2
+	symbols := "#%"
+	policy := func(name string, isDefault bool, length int) client.Object {
+		return &ClusterPasswordPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: ClusterPasswordPolicySpec{
+				Default:            isDefault,
+				Length:             length,
+				Digits:             4,
+				ExcludedCharacters: "%",
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		policies    []client.Object
+		spec        PasswordSpec
+		expectedErr string
+	}{
+		{
+			name: "no policy",
+			spec: PasswordSpec{Length: 8},
+		},
+		{
+			name:     "default policy",
+			policies: []client.Object{policy("strong", true, 32)},
+			spec:     PasswordSpec{Length: 24},
+			expectedErr: "password does not satisfy ClusterPasswordPolicy strong: " +
+				"length 24 is shorter than the minimum length 32",
+		},
+		{
+			name:     "referenced policy",
+			policies: []client.Object{policy("strong", true, 32), policy("legacy", false, 16)},
+			spec:     PasswordSpec{Length: 24, Policy: "legacy"},
+		},
+		{
+			name:        "missing policy",
+			spec:        PasswordSpec{Length: 24, Policy: "legacy"},
+			expectedErr: `could not get ClusterPasswordPolicy legacy: clusterpasswordpolicies.generators.external-secrets.io "legacy" not found`,
+		},
+		{
+			name:        "multiple default policies",
+			policies:    []client.Object{policy("a", true, 32), policy("b", true, 16)},
+			spec:        PasswordSpec{Length: 32},
+			expectedErr: "multiple ClusterPasswordPolicies are marked as default: a, b",
+		},
+		{
+			name:     "weaker than policy",
+			policies: []client.Object{policy("strong", true, 16)},
+			spec:     PasswordSpec{Length: 16, Digits: &digits, SymbolCharacters: &symbols, NoUpper: true, AllowRepeat: true},
+			expectedErr: "password does not satisfy ClusterPasswordPolicy strong: " +
+				"digits 2 is less than the minimum of 4 digits\n" +
+				"symbol character '%' is excluded\n" +
+				"uppercase characters can not be disabled\n" +
+				"repeating characters are not allowed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &PasswordValidator{
+				Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.policies...).Build(),
+			}
+			_, err := v.ValidateCreate(context.Background(), &Password{Spec: tt.spec})
+			if err != nil {
+				if tt.expectedErr == "" {
+					t.Fatalf("ValidateCreate() returned an unexpected error: %v", err)
+				}
+				if err.Error() != tt.expectedErr {
+					t.Fatalf("ValidateCreate() returned an unexpected error: got: %v, expected: %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if tt.expectedErr != "" {
+				t.Errorf("ValidateCreate() should have returned an error but got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RandomValueFormat is the format of a generated random value.
+type RandomValueFormat string
+
+const (
+	RandomValueFormatUUIDv4 RandomValueFormat = "UUIDv4"
+	RandomValueFormatUUIDv7 RandomValueFormat = "UUIDv7"
+	RandomValueFormatHex    RandomValueFormat = "Hex"
+	RandomValueFormatBytes  RandomValueFormat = "Bytes"
+)
+
+// RandomValueRegeneratePolicy controls when a new random value is generated.
+type RandomValueRegeneratePolicy string
+
+const (
+	// RandomValueRegenerateAlways generates a new value every time the generator is called.
+	RandomValueRegenerateAlways RandomValueRegeneratePolicy = "Always"
+	// RandomValueRegenerateOnSpecChange keeps the generated value until the spec of the generator changes.
+	RandomValueRegenerateOnSpecChange RandomValueRegeneratePolicy = "OnSpecChange"
+)
+
+// RandomValueSpec controls the behavior of the random value generator.
+type RandomValueSpec struct {
+	// Format of the generated value: a UUID, random bytes or their hex encoding.
+	// +kubebuilder:validation:Enum=UUIDv4;UUIDv7;Hex;Bytes
+	// +kubebuilder:default=UUIDv4
+	// +optional
+	Format RandomValueFormat `json:"format,omitempty"`
+
+	// Length is the number of random bytes of the Hex and Bytes formats.
+	// Defaults to 32
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4096
+	// +optional
+	Length int `json:"length,omitempty"`
+
+	// Regenerate controls when a new value is generated. With OnSpecChange the value is
+	// stored in the Secret random-value-<name> next to the generator and only regenerated
+	// when the spec changes, all ExternalSecrets using the generator get the same value.
+	// +kubebuilder:validation:Enum=Always;OnSpecChange
+	// +kubebuilder:default=Always
+	// +optional
+	Regenerate RandomValueRegeneratePolicy `json:"regenerate,omitempty"`
+}
+
+// RandomValue generates a random UUID, random bytes or their hex encoding,
+// e.g. for instance IDs or cookie-signing keys.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={randomvalue},shortName=randomvalue
+type RandomValue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RandomValueSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RandomValueList contains a list of RandomValue resources.
+type RandomValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RandomValue `json:"items"`
+}
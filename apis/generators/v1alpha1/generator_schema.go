@@ -16,6 +16,9 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -24,10 +27,12 @@ import (
 )
 
 var builder map[string]Generator
+var disabled map[string]bool
 var buildlock sync.RWMutex
 
 func init() {
 	builder = make(map[string]Generator)
+	disabled = make(map[string]bool)
 }
 
 // Register a generator type. Register panics if a
@@ -51,6 +56,34 @@ func ForceRegister(kind string, g Generator) {
 	buildlock.Unlock()
 }
 
+// FilterGenerators disables the generator kinds that are not allowed or that are denied,
+// an empty allow list allows all generators.
+// It must be called once the generators are registered and returns an error for unknown kinds.
+func FilterGenerators(allowed, denied []string) error {
+	buildlock.Lock()
+	defer buildlock.Unlock()
+	for _, kind := range append(slices.Clone(allowed), denied...) {
+		if _, ok := builder[kind]; !ok && !disabled[kind] {
+			known := make([]string, 0, len(builder)+len(disabled))
+			for k := range builder {
+				known = append(known, k)
+			}
+			for k := range disabled {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("unknown generator %q, known generators: %s", kind, strings.Join(known, ", "))
+		}
+	}
+	for kind := range builder {
+		if (len(allowed) > 0 && !slices.Contains(allowed, kind)) || slices.Contains(denied, kind) {
+			delete(builder, kind)
+			disabled[kind] = true
+		}
+	}
+	return nil
+}
+
 // GetGeneratorByName returns the provider implementation by name.
 func GetGeneratorByName(kind string) (Generator, bool) {
 	buildlock.RLock()
@@ -74,6 +107,9 @@ func GetGenerator(obj *apiextensions.JSON) (Generator, error) {
 	buildlock.RLock()
 	defer buildlock.RUnlock()
 	gen, ok := builder[res.Kind]
+	if !ok && disabled[res.Kind] {
+		return nil, fmt.Errorf("generator %s is disabled in this cluster", res.Kind)
+	}
 	if !ok {
 		return nil, fmt.Errorf("failed to find registered generator for: %s", string(obj.Raw))
 	}
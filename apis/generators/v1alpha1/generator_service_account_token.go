@@ -0,0 +1,81 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type ServiceAccountTokenSpec struct {
+	// Server configures the Kubernetes API server of the remote cluster,
+	// the server of the current context is used with auth.kubeconfig.
+	// +optional
+	Server esv1beta1.KubernetesServer `json:"server,omitempty"`
+
+	// Auth configures how the generator authenticates with the remote cluster,
+	// the credentials need the permission to create tokens of the service account.
+	Auth esv1beta1.KubernetesAuth `json:"auth"`
+
+	// ServiceAccount on the remote cluster the token is requested for.
+	ServiceAccount RemoteServiceAccount `json:"serviceAccount"`
+
+	// Audiences of the token. Defaults to the audience of the remote API server.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// ExpirationSeconds is the requested lifetime of the token,
+	// the API server may issue a token with a shorter lifetime.
+	// Defaults to 3600
+	// +optional
+	// +kubebuilder:validation:Minimum=600
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+
+	// RenewBefore is how long before the expiry the token is renewed.
+	// Defaults to a third of the lifetime of the token.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+type RemoteServiceAccount struct {
+	// Name of the service account.
+	Name string `json:"name"`
+	// Namespace of the service account on the remote cluster.
+	Namespace string `json:"namespace"`
+}
+
+// ServiceAccountToken requests a short-lived token of a service account
+// on a remote cluster with the TokenRequest API.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={serviceaccounttoken},shortName=serviceaccounttoken
+type ServiceAccountToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceAccountTokenSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceAccountTokenList contains a list of ServiceAccountToken resources.
+type ServiceAccountTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceAccountToken `json:"items"`
+}
@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+type SlackAccessTokenSpec struct {
+	// URL configures the Slack Web API URL. Defaults to https://slack.com/api.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// ClientID of the Slack app with token rotation enabled.
+	ClientID string `json:"clientID"`
+	// Auth configures the credentials used to rotate the tokens of the Slack app.
+	Auth SlackAuth `json:"auth"`
+}
+
+type SlackAuth struct {
+	// ClientSecret of the Slack app.
+	ClientSecret SlackSecretRef `json:"clientSecret"`
+	// RefreshToken references the current refresh token of the app installation.
+	// Slack issues a new refresh token on every rotation, it is written back to
+	// the referenced key, so the secret must not be managed by another controller.
+	RefreshToken SlackSecretRef `json:"refreshToken"`
+}
+
+type SlackSecretRef struct {
+	SecretRef esmeta.SecretKeySelector `json:"secretRef"`
+}
+
+// SlackAccessToken exchanges the refresh token of a Slack app with token rotation
+// enabled for a short-lived bot or user access token.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={slackaccesstoken},shortName=slackaccesstoken
+type SlackAccessToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SlackAccessTokenSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlackAccessTokenList contains a list of SlackAccessToken resources.
+type SlackAccessTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SlackAccessToken `json:"items"`
+}
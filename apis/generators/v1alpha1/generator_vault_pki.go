@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type VaultPKICertificateSpec struct {
+	// Used to select the correct ESO controller (think: ingress.ingressClassName)
+	// The ESO controller is instantiated with a specific controller name and filters VDS based on this property
+	// +optional
+	Controller string `json:"controller,omitempty"`
+
+	// Vault provider common spec
+	Provider *esv1beta1.VaultProvider `json:"provider"`
+
+	// Mount path of the PKI secrets engine. Defaults to pki.
+	// +optional
+	Mount string `json:"mount,omitempty"`
+
+	// Role of the PKI secrets engine the certificate is issued for.
+	Role string `json:"role"`
+
+	// CommonName of the certificate.
+	CommonName string `json:"commonName"`
+
+	// AltNames are the DNS names and email addresses added as subject alternative names.
+	// +optional
+	AltNames []string `json:"altNames,omitempty"`
+
+	// IPSANs are the IP addresses added as subject alternative names.
+	// +optional
+	IPSANs []string `json:"ipSANs,omitempty"`
+
+	// URISANs are the URIs added as subject alternative names.
+	// +optional
+	URISANs []string `json:"uriSANs,omitempty"`
+
+	// TTL of the certificate, capped by the max TTL of the role.
+	// Defaults to the TTL of the role.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// RenewBefore is how long before the expiry the certificate is renewed.
+	// Defaults to a third of the lifetime of the certificate.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// VaultPKICertificate issues a certificate and private key from a role of the Vault PKI secrets engine.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:metadata:labels="external-secrets.io/component=controller"
+// +kubebuilder:resource:scope=Namespaced,categories={vaultpkicertificate},shortName=vaultpkicertificate
+type VaultPKICertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VaultPKICertificateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VaultPKICertificateList contains a list of VaultPKICertificate resources.
+type VaultPKICertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VaultPKICertificate `json:"items"`
+}
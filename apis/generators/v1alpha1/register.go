@@ -60,6 +60,22 @@ var (
 	ACRAccessTokenGroupVersionKind = SchemeGroupVersion.WithKind(ACRAccessTokenKind)
 )
 
+// AzureWorkloadToken type metadata.
+var (
+	AzureWorkloadTokenKind             = reflect.TypeOf(AzureWorkloadToken{}).Name()
+	AzureWorkloadTokenGroupKind        = schema.GroupKind{Group: Group, Kind: AzureWorkloadTokenKind}.String()
+	AzureWorkloadTokenKindAPIVersion   = AzureWorkloadTokenKind + "." + SchemeGroupVersion.String()
+	AzureWorkloadTokenGroupVersionKind = SchemeGroupVersion.WithKind(AzureWorkloadTokenKind)
+)
+
+// EntraClientSecret type metadata.
+var (
+	EntraClientSecretKind             = reflect.TypeOf(EntraClientSecret{}).Name()
+	EntraClientSecretGroupKind        = schema.GroupKind{Group: Group, Kind: EntraClientSecretKind}.String()
+	EntraClientSecretKindAPIVersion   = EntraClientSecretKind + "." + SchemeGroupVersion.String()
+	EntraClientSecretGroupVersionKind = SchemeGroupVersion.WithKind(EntraClientSecretKind)
+)
+
 // Password type metadata.
 var (
 	PasswordKind             = reflect.TypeOf(Password{}).Name()
@@ -68,6 +84,30 @@ var (
 	PasswordGroupVersionKind = SchemeGroupVersion.WithKind(PasswordKind)
 )
 
+// RandomValue type metadata.
+var (
+	RandomValueKind             = reflect.TypeOf(RandomValue{}).Name()
+	RandomValueGroupKind        = schema.GroupKind{Group: Group, Kind: RandomValueKind}.String()
+	RandomValueKindAPIVersion   = RandomValueKind + "." + SchemeGroupVersion.String()
+	RandomValueGroupVersionKind = SchemeGroupVersion.WithKind(RandomValueKind)
+)
+
+// ServiceAccountToken type metadata.
+var (
+	ServiceAccountTokenKind             = reflect.TypeOf(ServiceAccountToken{}).Name()
+	ServiceAccountTokenGroupKind        = schema.GroupKind{Group: Group, Kind: ServiceAccountTokenKind}.String()
+	ServiceAccountTokenKindAPIVersion   = ServiceAccountTokenKind + "." + SchemeGroupVersion.String()
+	ServiceAccountTokenGroupVersionKind = SchemeGroupVersion.WithKind(ServiceAccountTokenKind)
+)
+
+// SlackAccessToken type metadata.
+var (
+	SlackAccessTokenKind             = reflect.TypeOf(SlackAccessToken{}).Name()
+	SlackAccessTokenGroupKind        = schema.GroupKind{Group: Group, Kind: SlackAccessTokenKind}.String()
+	SlackAccessTokenKindAPIVersion   = SlackAccessTokenKind + "." + SchemeGroupVersion.String()
+	SlackAccessTokenGroupVersionKind = SchemeGroupVersion.WithKind(SlackAccessTokenKind)
+)
+
 // Webhook type metadata.
 var (
 	WebhookKind             = reflect.TypeOf(Webhook{}).Name()
@@ -92,6 +132,14 @@ var (
 	VaultDynamicSecretGroupVersionKind = SchemeGroupVersion.WithKind(VaultDynamicSecretKind)
 )
 
+// VaultPKICertificate type metadata.
+var (
+	VaultPKICertificateKind             = reflect.TypeOf(VaultPKICertificate{}).Name()
+	VaultPKICertificateGroupKind        = schema.GroupKind{Group: Group, Kind: VaultPKICertificateKind}.String()
+	VaultPKICertificateKindAPIVersion   = VaultPKICertificateKind + "." + SchemeGroupVersion.String()
+	VaultPKICertificateGroupVersionKind = SchemeGroupVersion.WithKind(VaultPKICertificateKind)
+)
+
 // GithubAccessToken type metadata.
 var (
 	GithubAccessTokenKind             = reflect.TypeOf(GithubAccessToken{}).Name()
@@ -100,6 +148,14 @@ var (
 	GithubAccessTokenGroupVersionKind = SchemeGroupVersion.WithKind(GithubAccessTokenKind)
 )
 
+// ClusterPasswordPolicy type metadata.
+var (
+	ClusterPasswordPolicyKind             = reflect.TypeOf(ClusterPasswordPolicy{}).Name()
+	ClusterPasswordPolicyGroupKind        = schema.GroupKind{Group: Group, Kind: ClusterPasswordPolicyKind}.String()
+	ClusterPasswordPolicyKindAPIVersion   = ClusterPasswordPolicyKind + "." + SchemeGroupVersion.String()
+	ClusterPasswordPolicyGroupVersionKind = SchemeGroupVersion.WithKind(ClusterPasswordPolicyKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ECRAuthorizationToken{}, &ECRAuthorizationToken{})
 	SchemeBuilder.Register(&GCRAccessToken{}, &GCRAccessTokenList{})
@@ -107,6 +163,13 @@ func init() {
 	SchemeBuilder.Register(&ACRAccessToken{}, &ACRAccessTokenList{})
 	SchemeBuilder.Register(&Fake{}, &FakeList{})
 	SchemeBuilder.Register(&VaultDynamicSecret{}, &VaultDynamicSecretList{})
+	SchemeBuilder.Register(&VaultPKICertificate{}, &VaultPKICertificateList{})
+	SchemeBuilder.Register(&AzureWorkloadToken{}, &AzureWorkloadTokenList{})
+	SchemeBuilder.Register(&EntraClientSecret{}, &EntraClientSecretList{})
 	SchemeBuilder.Register(&Password{}, &PasswordList{})
+	SchemeBuilder.Register(&RandomValue{}, &RandomValueList{})
+	SchemeBuilder.Register(&ServiceAccountToken{}, &ServiceAccountTokenList{})
+	SchemeBuilder.Register(&SlackAccessToken{}, &SlackAccessTokenList{})
+	SchemeBuilder.Register(&ClusterPasswordPolicy{}, &ClusterPasswordPolicyList{})
 	SchemeBuilder.Register(&Webhook{}, &WebhookList{})
 }
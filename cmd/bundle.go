@@ -0,0 +1,224 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/bundle"
+	_ "github.com/external-secrets/external-secrets/pkg/provider/register" // Loading registered providers.
+)
+
+const (
+	bundleKeyFile          = "bundle.key"
+	bundleSigningKeyFile   = "signing.pem"
+	bundleVerifyingKeyFile = "verification.pem"
+	bundleFindAll          = ".*"
+)
+
+var (
+	bundleOutput        string
+	bundleStoreName     string
+	bundleStoreKind     string
+	bundleNamespace     string
+	bundleFindRegex     string
+	bundleFindPath      string
+	bundleKeys          []string
+	bundleEncryptionKey string
+	bundleSigningKey    string
+	bundleKeygenDir     string
+	bundleExportTimeout time.Duration
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Create offline snapshot bundles for air-gapped clusters",
+	Long: `Create encrypted and signed snapshot bundles of a SecretStore or ClusterSecretStore.
+	The bundle is mounted into the controller of an air-gapped cluster and consumed with the bundle provider.
+	For more information visit https://external-secrets.io`,
+}
+
+var bundleKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate the encryption key and the signing key pair of a bundle",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := generateBundleKeys(bundleKeygenDir); err != nil {
+			setupLog.Error(err, "unable to generate bundle keys")
+			os.Exit(1)
+		}
+	},
+}
+
+var bundleExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the secrets of a store into a bundle",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), bundleExportTimeout)
+		defer cancel()
+		if err := exportBundle(ctx); err != nil {
+			setupLog.Error(err, "unable to export bundle")
+			os.Exit(1)
+		}
+	},
+}
+
+// generateBundleKeys writes a base64 encoded AES-256 key and a PEM encoded Ed25519 key pair to the directory.
+func generateBundleKeys(dir string) error {
+	key := make([]byte, bundle.KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		bundleKeyFile:          []byte(base64.StdEncoding.EncodeToString(key)),
+		bundleSigningKeyFile:   pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}),
+		bundleVerifyingKeyFile: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportBundle reads the secrets of the store and writes them into an encrypted and signed bundle.
+func exportBundle(ctx context.Context) error {
+	encodedKey, err := os.ReadFile(bundleEncryptionKey)
+	if err != nil {
+		return err
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedKey)))
+	if err != nil {
+		return fmt.Errorf("unable to decode encryption key: %w", err)
+	}
+	pemKey, err := os.ReadFile(bundleSigningKey)
+	if err != nil {
+		return err
+	}
+	signingKey, err := bundle.ParsePrivateKey(pemKey)
+	if err != nil {
+		return fmt.Errorf("unable to parse signing key: %w", err)
+	}
+
+	kube, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+	var store esv1beta1.GenericStore
+	if bundleStoreKind == esv1beta1.ClusterSecretStoreKind {
+		store = &esv1beta1.ClusterSecretStore{}
+		err = kube.Get(ctx, client.ObjectKey{Name: bundleStoreName}, store)
+	} else {
+		store = &esv1beta1.SecretStore{}
+		err = kube.Get(ctx, client.ObjectKey{Name: bundleStoreName, Namespace: bundleNamespace}, store)
+	}
+	if err != nil {
+		return err
+	}
+	provider, err := esv1beta1.GetProvider(store)
+	if err != nil {
+		return err
+	}
+	secretsClient, err := provider.NewClient(ctx, store, kube, bundleNamespace)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = secretsClient.Close(ctx)
+	}()
+
+	// without any keys, all secrets of the store are exported
+	secrets := make(map[string][]byte)
+	if bundleFindRegex != "" || bundleFindPath != "" || len(bundleKeys) == 0 {
+		ref := esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: bundleFindAll}}
+		if bundleFindRegex != "" {
+			ref.Name.RegExp = bundleFindRegex
+		}
+		if bundleFindPath != "" {
+			ref.Path = &bundleFindPath
+		}
+		secrets, err = secretsClient.GetAllSecrets(ctx, ref)
+		if err != nil {
+			return err
+		}
+	}
+	for _, k := range bundleKeys {
+		value, err := secretsClient.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: k})
+		if err != nil {
+			return fmt.Errorf("unable to get secret %s: %w", k, err)
+		}
+		secrets[k] = value
+	}
+
+	data, err := bundle.Seal(&bundle.Snapshot{
+		CreatedAt: time.Now().UTC(),
+		Source:    fmt.Sprintf("%s %s/%s", store.GetKind(), store.GetNamespace(), store.GetName()),
+		Secrets:   secrets,
+	}, key, signingKey)
+	if err != nil {
+		return err
+	}
+	setupLog.Info("exported bundle", "path", bundleOutput, "secrets", len(secrets))
+	return os.WriteFile(bundleOutput, data, 0o600)
+}
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(bundleKeygenCmd)
+	bundleCmd.AddCommand(bundleExportCmd)
+
+	bundleKeygenCmd.Flags().StringVar(&bundleKeygenDir, "output-dir", ".", "Directory the bundle.key, signing.pem and verification.pem files are written to.")
+
+	bundleExportCmd.Flags().StringVar(&bundleStoreName, "store", "", "Name of the store to export.")
+	bundleExportCmd.Flags().StringVar(&bundleStoreKind, "store-kind", esv1beta1.SecretStoreKind, "Kind of the store to export, SecretStore or ClusterSecretStore.")
+	bundleExportCmd.Flags().StringVar(&bundleNamespace, "namespace", "default", "Namespace of the SecretStore, or the namespace secrets referenced by a ClusterSecretStore are resolved in.")
+	bundleExportCmd.Flags().StringVar(&bundleFindRegex, "find-regex", "", "Export the secrets with names matching the regular expression.")
+	bundleExportCmd.Flags().StringVar(&bundleFindPath, "find-path", "", "Export the secrets below the path.")
+	bundleExportCmd.Flags().StringSliceVar(&bundleKeys, "key", nil, "Remote key to export, can be repeated. Use for providers that can not find secrets.")
+	bundleExportCmd.Flags().StringVar(&bundleEncryptionKey, "encryption-key", bundleKeyFile, "File containing the base64 encoded AES-256 key the bundle is encrypted with.")
+	bundleExportCmd.Flags().StringVar(&bundleSigningKey, "signing-key", bundleSigningKeyFile, "File containing the PEM encoded Ed25519 private key the bundle is signed with.")
+	bundleExportCmd.Flags().StringVarP(&bundleOutput, "output", "o", "bundle.json", "File the bundle is written to.")
+	bundleExportCmd.Flags().DurationVar(&bundleExportTimeout, "timeout", 5*time.Minute, "Timeout of the export.")
+	_ = bundleExportCmd.MarkFlagRequired("store")
+}
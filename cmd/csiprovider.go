@@ -0,0 +1,114 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap/zapcore"
+	v1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/csi"
+)
+
+var csiSocketPath string
+
+var csiProviderCmd = &cobra.Command{
+	Use:   "csi-provider",
+	Short: "Provider for the secrets-store CSI driver to mount ExternalSecrets as files",
+	Long: `Provider for the secrets-store CSI driver to mount ExternalSecrets as files
+	without creating a Secret. For more information visit https://external-secrets.io`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var lvl zapcore.Level
+		var enc zapcore.TimeEncoder
+		lvlErr := lvl.UnmarshalText([]byte(loglevel))
+		if lvlErr != nil {
+			setupLog.Error(lvlErr, "error unmarshalling loglevel")
+			os.Exit(1)
+		}
+		encErr := enc.UnmarshalText([]byte(zapTimeEncoding))
+		if encErr != nil {
+			setupLog.Error(encErr, "error unmarshalling timeEncoding")
+			os.Exit(1)
+		}
+		opts := zap.Options{
+			Level:       lvl,
+			TimeEncoder: enc,
+		}
+		logger := zap.New(zap.UseFlagOptions(&opts))
+		ctrl.SetLogger(logger)
+		ctrlmetrics.SetUpLabelNames(false)
+		esmetrics.SetUpMetrics()
+
+		config := ctrl.GetConfigOrDie()
+		mgr, err := ctrl.NewManager(config, ctrl.Options{
+			Scheme: scheme,
+			Metrics: server.Options{
+				BindAddress: metricsAddr,
+			},
+			HealthProbeBindAddress: healthzAddr,
+			Client: client.Options{
+				Cache: &client.CacheOptions{
+					// provider data is read from the stores,
+					// avoid caching all secrets of the cluster in memory.
+					DisableFor: []client.Object{&v1.Secret{}, &v1.ConfigMap{}},
+				},
+			},
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to start manager")
+			os.Exit(1)
+		}
+
+		renderer := externalsecret.NewRenderer(mgr.GetClient(), config, mgr.GetEventRecorderFor("external-secrets-csi"), controllerClass, enableFloodGate)
+		srv := csi.NewServer(mgr.GetClient(), renderer)
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			return srv.Serve(ctx, csiSocketPath)
+		})); err != nil {
+			setupLog.Error(err, "unable to add csi provider server")
+			os.Exit(1)
+		}
+
+		setupLog.Info("starting manager")
+		if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+			setupLog.Error(err, "problem running manager")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(csiProviderCmd)
+
+	csiProviderCmd.Flags().StringVar(&csiSocketPath, "socket-path", "/var/run/secrets-store-csi-providers/external-secrets.sock", "The unix socket the provider listens on for requests of the secrets-store CSI driver.")
+	csiProviderCmd.Flags().StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	csiProviderCmd.Flags().StringVar(&healthzAddr, "healthz-addr", ":8081", "The address the health endpoint binds to.")
+	csiProviderCmd.Flags().StringVar(&controllerClass, "controller-class", "default", "The provider only serves ExternalSecrets using stores of this controller class")
+	csiProviderCmd.Flags().BoolVar(&enableFloodGate, "enable-flood-gate", true, "Enable flood gate. ExternalSecrets are only mounted if the ClusterStore or Store have an healthy or unknown state.")
+	csiProviderCmd.Flags().StringVar(&loglevel, "loglevel", "info", "loglevel to use, one of: debug, info, warn, error, dpanic, panic, fatal")
+	csiProviderCmd.Flags().StringVar(&zapTimeEncoding, "zap-time-encoding", "epoch", "Zap time encoding (one of 'epoch', 'millis', 'nano', 'iso8601', 'rfc3339' or 'rfc3339nano')")
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"net/http"
 	"os"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -39,11 +42,14 @@ import (
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret/cesmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret/cpsmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretrotation"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/cssmetrics"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/ssmetrics"
@@ -60,6 +66,7 @@ var (
 	certDir                               string
 	metricsAddr                           string
 	healthzAddr                           string
+	controllerHealthzAddr                 string
 	controllerClass                       string
 	enableLeaderElection                  bool
 	enableSecretsCache                    bool
@@ -72,9 +79,23 @@ var (
 	loglevel                              string
 	zapTimeEncoding                       string
 	namespace                             string
+	namespaces                            []string
 	enableClusterStoreReconciler          bool
 	enableClusterExternalSecretReconciler bool
+	enableClusterPushSecretReconciler     bool
+	maxSecretSize                         int
+	maxSecretKeys                         int
+	retryInitialInterval                  time.Duration
+	retryMaxInterval                      time.Duration
+	eventDedupWindow                      time.Duration
+	managerID                             string
+	forceOwnership                        bool
 	enablePushSecretReconciler            bool
+	enableSecretRotationReconciler        bool
+	allowedProviders                      []string
+	deniedProviders                       []string
+	allowedGenerators                     []string
+	deniedGenerators                      []string
 	enableFloodGate                       bool
 	enableExtendedMetricLabels            bool
 	storeRequeueInterval                  time.Duration
@@ -86,10 +107,17 @@ var (
 	certLookaheadInterval                 time.Duration
 	tlsCiphers                            string
 	tlsMinVersion                         string
+	rejectShortRefreshInterval            bool
+	rejectUnsupportedRemoteRefFeatures    bool
+	providerDefaultsConfigMap             string
+	providerCapabilitiesConfigMap         string
+	validateStoresOnStartup               bool
+	validateStoresConcurrency             int
 )
 
 const (
 	errCreateController = "unable to create controller"
+	errFilterProviders  = "invalid provider or generator list"
 )
 
 func init() {
@@ -136,6 +164,14 @@ var rootCmd = &cobra.Command{
 		}
 		logger := zap.New(zap.UseFlagOptions(&opts))
 		ctrl.SetLogger(logger)
+		if err := esv1beta1.FilterProviders(allowedProviders, deniedProviders); err != nil {
+			setupLog.Error(err, errFilterProviders)
+			os.Exit(1)
+		}
+		if err := genv1alpha1.FilterGenerators(allowedGenerators, deniedGenerators); err != nil {
+			setupLog.Error(err, errFilterProviders)
+			os.Exit(1)
+		}
 		ctrlmetrics.SetUpLabelNames(enableExtendedMetricLabels)
 		esmetrics.SetUpMetrics()
 		config := ctrl.GetConfigOrDie()
@@ -145,6 +181,9 @@ var rootCmd = &cobra.Command{
 			Scheme: scheme,
 			Metrics: server.Options{
 				BindAddress: metricsAddr,
+				ExtraHandlers: map[string]http.Handler{
+					ctrlmetrics.OpenMetricsPath: ctrlmetrics.OpenMetricsHandler(),
+				},
 			},
 			WebhookServer: webhook.NewServer(webhook.Options{
 				Port: 9443,
@@ -154,12 +193,16 @@ var rootCmd = &cobra.Command{
 					DisableFor: cacheList,
 				},
 			},
-			LeaderElection:   enableLeaderElection,
-			LeaderElectionID: "external-secrets-controller",
+			HealthProbeBindAddress: controllerHealthzAddr,
+			LeaderElection:         enableLeaderElection,
+			LeaderElectionID:       "external-secrets-controller",
 		}
-		if namespace != "" {
-			ctrlOpts.Cache.DefaultNamespaces = map[string]cache.Config{
-				namespace: {},
+		if namespace != "" || len(namespaces) > 0 {
+			ctrlOpts.Cache.DefaultNamespaces = make(map[string]cache.Config)
+			for _, ns := range append(namespaces, namespace) {
+				if ns != "" {
+					ctrlOpts.Cache.DefaultNamespaces[ns] = cache.Config{}
+				}
 			}
 		}
 		mgr, err := ctrl.NewManager(config, ctrlOpts)
@@ -167,10 +210,15 @@ var rootCmd = &cobra.Command{
 			setupLog.Error(err, "unable to start manager")
 			os.Exit(1)
 		}
+		if err := secretstore.SetProviderDefaultsConfigMap(providerDefaultsConfigMap, mgr.GetAPIReader()); err != nil {
+			setupLog.Error(err, "invalid provider defaults ConfigMap")
+			os.Exit(1)
+		}
 
 		ssmetrics.SetUpMetrics()
 		if err = (&secretstore.StoreReconciler{
 			Client:          mgr.GetClient(),
+			APIReader:       mgr.GetAPIReader(),
 			Log:             ctrl.Log.WithName("controllers").WithName("SecretStore"),
 			Scheme:          mgr.GetScheme(),
 			ControllerClass: controllerClass,
@@ -185,6 +233,7 @@ var rootCmd = &cobra.Command{
 			cssmetrics.SetUpMetrics()
 			if err = (&secretstore.ClusterStoreReconciler{
 				Client:          mgr.GetClient(),
+				APIReader:       mgr.GetAPIReader(),
 				Log:             ctrl.Log.WithName("controllers").WithName("ClusterSecretStore"),
 				Scheme:          mgr.GetScheme(),
 				ControllerClass: controllerClass,
@@ -203,6 +252,13 @@ var rootCmd = &cobra.Command{
 			RequeueInterval:           time.Hour,
 			ClusterSecretStoreEnabled: enableClusterStoreReconciler,
 			EnableFloodGate:           enableFloodGate,
+			MaxSecretSize:             maxSecretSize,
+			MaxSecretKeys:             maxSecretKeys,
+			RetryInitialInterval:      retryInitialInterval,
+			RetryMaxInterval:          retryMaxInterval,
+			EventDedupWindow:          eventDedupWindow,
+			ManagerID:                 managerID,
+			ForceOwnership:            forceOwnership,
 		}).SetupWithManager(mgr, controller.Options{
 			MaxConcurrentReconciles: concurrent,
 		}); err != nil {
@@ -212,11 +268,12 @@ var rootCmd = &cobra.Command{
 		if enablePushSecretReconciler {
 			psmetrics.SetUpMetrics()
 			if err = (&pushsecret.Reconciler{
-				Client:          mgr.GetClient(),
-				Log:             ctrl.Log.WithName("controllers").WithName("PushSecret"),
-				Scheme:          mgr.GetScheme(),
-				ControllerClass: controllerClass,
-				RequeueInterval: time.Hour,
+				Client:                    mgr.GetClient(),
+				Log:                       ctrl.Log.WithName("controllers").WithName("PushSecret"),
+				Scheme:                    mgr.GetScheme(),
+				ControllerClass:           controllerClass,
+				RequeueInterval:           time.Hour,
+				ClusterSecretStoreEnabled: enableClusterStoreReconciler,
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, errCreateController, "controller", "PushSecret")
 				os.Exit(1)
@@ -237,6 +294,64 @@ var rootCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
+		if enablePushSecretReconciler && enableClusterPushSecretReconciler {
+			cpsmetrics.SetUpMetrics()
+
+			if err = (&clusterpushsecret.Reconciler{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("controllers").WithName("ClusterPushSecret"),
+				Scheme:          mgr.GetScheme(),
+				RequeueInterval: time.Hour,
+			}).SetupWithManager(mgr, controller.Options{
+				MaxConcurrentReconciles: concurrent,
+			}); err != nil {
+				setupLog.Error(err, errCreateController, "controller", "ClusterPushSecret")
+				os.Exit(1)
+			}
+		}
+		if enablePushSecretReconciler && enableSecretRotationReconciler {
+			if err = (&secretrotation.Reconciler{
+				Client: mgr.GetClient(),
+				Log:    ctrl.Log.WithName("controllers").WithName("SecretRotation"),
+				Scheme: mgr.GetScheme(),
+			}).SetupWithManager(mgr, controller.Options{
+				MaxConcurrentReconciles: concurrent,
+			}); err != nil {
+				setupLog.Error(err, errCreateController, "controller", "SecretRotation")
+				os.Exit(1)
+			}
+		}
+
+		if providerCapabilitiesConfigMap != "" {
+			// published once by the leader, the providers can't change while running.
+			if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+				if err := secretstore.PublishProviderCapabilities(ctx, mgr.GetClient(), providerCapabilitiesConfigMap); err != nil {
+					setupLog.Error(err, "unable to publish provider capabilities")
+				}
+				return nil
+			})); err != nil {
+				setupLog.Error(err, "unable to add provider capabilities publisher")
+				os.Exit(1)
+			}
+		}
+
+		if validateStoresOnStartup {
+			validator := &secretstore.StartupValidator{
+				Client:          mgr.GetClient(),
+				Log:             ctrl.Log.WithName("startup-validation"),
+				ControllerClass: controllerClass,
+				ClusterStores:   enableClusterStoreReconciler,
+				Concurrency:     validateStoresConcurrency,
+			}
+			if err := mgr.Add(validator); err != nil {
+				setupLog.Error(err, "unable to add store startup validation")
+				os.Exit(1)
+			}
+			if err := mgr.AddReadyzCheck("stores", validator.Check); err != nil {
+				setupLog.Error(err, "unable to add store readiness check")
+				os.Exit(1)
+			}
+		}
 
 		fs := feature.Features()
 		for _, f := range fs {
@@ -259,6 +374,7 @@ func Execute() {
 
 func init() {
 	rootCmd.Flags().StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	rootCmd.Flags().StringVar(&controllerHealthzAddr, "healthz-addr", "0", "The address the health endpoint binds to, 0 disables it.")
 	rootCmd.Flags().StringVar(&controllerClass, "controller-class", "default", "The controller is instantiated with a specific controller name and filters ES based on this property")
 	rootCmd.Flags().BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
@@ -269,14 +385,32 @@ func init() {
 	rootCmd.Flags().StringVar(&loglevel, "loglevel", "info", "loglevel to use, one of: debug, info, warn, error, dpanic, panic, fatal")
 	rootCmd.Flags().StringVar(&zapTimeEncoding, "zap-time-encoding", "epoch", "Zap time encoding (one of 'epoch', 'millis', 'nano', 'iso8601', 'rfc3339' or 'rfc3339nano')")
 	rootCmd.Flags().StringVar(&namespace, "namespace", "", "watch external secrets scoped in the provided namespace only. ClusterSecretStore can be used but only work if it doesn't reference resources from other namespaces")
+	rootCmd.Flags().StringSliceVar(&namespaces, "namespaces", nil, "watch external secrets scoped in the provided comma separated list of namespaces only, in addition to --namespace. ClusterSecretStore can be used but only work if it doesn't reference resources from other namespaces")
 	rootCmd.Flags().BoolVar(&enableClusterStoreReconciler, "enable-cluster-store-reconciler", true, "Enable cluster store reconciler.")
 	rootCmd.Flags().BoolVar(&enableClusterExternalSecretReconciler, "enable-cluster-external-secret-reconciler", true, "Enable cluster external secret reconciler.")
+	rootCmd.Flags().BoolVar(&enableClusterPushSecretReconciler, "enable-cluster-push-secret-reconciler", true, "Enable cluster push secret reconciler.")
 	rootCmd.Flags().BoolVar(&enablePushSecretReconciler, "enable-push-secret-reconciler", true, "Enable push secret reconciler.")
+	rootCmd.Flags().BoolVar(&enableSecretRotationReconciler, "enable-secret-rotation-reconciler", true, "Enable secret rotation reconciler, requires the push secret reconciler.")
 	rootCmd.Flags().BoolVar(&enableSecretsCache, "enable-secrets-caching", false, "Enable secrets caching for external-secrets pod.")
 	rootCmd.Flags().BoolVar(&enableConfigMapsCache, "enable-configmaps-caching", false, "Enable secrets caching for external-secrets pod.")
 	rootCmd.Flags().DurationVar(&storeRequeueInterval, "store-requeue-interval", time.Minute*5, "Default Time duration between reconciling (Cluster)SecretStores")
 	rootCmd.Flags().BoolVar(&enableFloodGate, "enable-flood-gate", true, "Enable flood gate. External secret will be reconciled only if the ClusterStore or Store have an healthy or unknown state.")
+	rootCmd.Flags().IntVar(&maxSecretSize, "max-secret-size", v1.MaxSecretSize, "Maximum size in bytes of the data of a Secret or ConfigMap written by an ExternalSecret.")
+	rootCmd.Flags().IntVar(&maxSecretKeys, "max-secret-keys", 0, "Maximum number of keys of a Secret or ConfigMap written by an ExternalSecret, 0 means no limit.")
+	rootCmd.Flags().DurationVar(&retryInitialInterval, "retry-initial-interval", time.Second*10, "Default interval before retrying a failed sync of an ExternalSecret with a retryPolicy, doubled after every consecutive failure.")
+	rootCmd.Flags().DurationVar(&retryMaxInterval, "retry-max-interval", time.Minute*10, "Default maximum interval before retrying a failed sync of an ExternalSecret with a retryPolicy.")
+	rootCmd.Flags().DurationVar(&eventDedupWindow, "event-dedup-window", time.Minute*5, "Window in which identical events of an ExternalSecret are recorded at most once, repetitions are summarized once it has passed. 0 records every event.")
+	rootCmd.Flags().StringVar(&managerID, "manager-id", "", "Identity the Secrets and ConfigMaps written by the controller are stamped with, e.g. the name of the installation. Targets stamped by a controller with another identity are not updated.")
+	rootCmd.Flags().BoolVar(&forceOwnership, "force-ownership", false, "Update targets stamped by a controller with another --manager-id and stamp them with the own identity.")
+	rootCmd.Flags().StringVar(&providerDefaultsConfigMap, "provider-defaults-configmap", "", "ConfigMap given as namespace/name holding provider configuration defaults that are merged under the configuration of every store.")
+	rootCmd.Flags().StringVar(&providerCapabilitiesConfigMap, "provider-capabilities-configmap", "", "ConfigMap given as namespace/name the capabilities of the enabled providers are written to.")
+	rootCmd.Flags().BoolVar(&validateStoresOnStartup, "validate-stores-on-startup", false, "Validate the stores at startup and report the controller ready on the health endpoint once all of them are valid.")
+	rootCmd.Flags().IntVar(&validateStoresConcurrency, "validate-stores-concurrency", 5, "The number of stores validated in parallel at startup.")
 	rootCmd.Flags().BoolVar(&enableExtendedMetricLabels, "enable-extended-metric-labels", false, "Enable recommended kubernetes annotations as labels in metrics.")
+	rootCmd.Flags().StringSliceVar(&allowedProviders, "allowed-providers", nil, "Comma separated list of the providers stores may use, e.g. 'vault,aws'. All providers are allowed if not set.")
+	rootCmd.Flags().StringSliceVar(&deniedProviders, "denied-providers", nil, "Comma separated list of the providers stores must not use, e.g. 'webhook'.")
+	rootCmd.Flags().StringSliceVar(&allowedGenerators, "allowed-generators", nil, "Comma separated list of the generator kinds that may be used, e.g. 'Password,ECRAuthorizationToken'. All generators are allowed if not set.")
+	rootCmd.Flags().StringSliceVar(&deniedGenerators, "denied-generators", nil, "Comma separated list of the generator kinds that must not be used, e.g. 'Webhook'.")
 	fs := feature.Features()
 	for _, f := range fs {
 		rootCmd.Flags().AddFlagSet(f.Flags)
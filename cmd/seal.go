@@ -0,0 +1,170 @@
+/*
+Copyright © 2022 ESO Maintainer team
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/external-secrets/external-secrets/pkg/seal"
+)
+
+const (
+	sealPrivateKeyFile = "seal.pem"
+	sealPublicKeyFile  = "seal.pub.pem"
+	sealKeyTypeRSA     = "rsa"
+	sealKeyTypeEC      = "ec"
+	sealRSAKeySize     = 4096
+)
+
+var (
+	sealKeygenDir  string
+	sealKeyType    string
+	sealPrivateKey string
+	sealSourceDir  string
+	sealTargetDir  string
+)
+
+var sealCmd = &cobra.Command{
+	Use:   "seal",
+	Short: "Manage the keys and values of sealed Secrets",
+	Long: `Manage the keys of ExternalSecrets with target.encryption and open their sealed values.
+	The controller seals the values with the public key, the workloads open them with the private key,
+	e.g. in an init container that writes the values to an in-memory volume.
+	For more information visit https://external-secrets.io`,
+}
+
+var sealKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate a key pair to seal the values of Secrets",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := generateSealKeys(sealKeygenDir, sealKeyType); err != nil {
+			setupLog.Error(err, "unable to generate seal keys")
+			os.Exit(1)
+		}
+	},
+}
+
+var sealOpenCmd = &cobra.Command{
+	Use:   "open",
+	Short: "Open the sealed values of a mounted Secret",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := openSealedDir(sealPrivateKey, sealSourceDir, sealTargetDir); err != nil {
+			setupLog.Error(err, "unable to open sealed values")
+			os.Exit(1)
+		}
+	},
+}
+
+// generateSealKeys writes a PEM encoded private key and its public key to the directory.
+func generateSealKeys(dir, keyType string) error {
+	var key crypto.Signer
+	var err error
+	switch keyType {
+	case sealKeyTypeRSA:
+		key, err = rsa.GenerateKey(rand.Reader, sealRSAKeySize)
+	case sealKeyTypeEC:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return fmt.Errorf("unknown key type %q, use %s or %s", keyType, sealKeyTypeRSA, sealKeyTypeEC)
+	}
+	if err != nil {
+		return err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		sealPrivateKeyFile: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}),
+		sealPublicKeyFile:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openSealedDir opens the sealed values in the files of the source directory and writes them
+// to files with the same name in the target directory. Hidden files, like the ..data link of
+// a mounted Secret, and directories are skipped.
+func openSealedDir(keyFile, source, target string) error {
+	pemKey, err := os.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+	key, err := seal.ParsePrivateKey(pemKey)
+	if err != nil {
+		return fmt.Errorf("unable to parse private key: %w", err)
+	}
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return err
+	}
+	opened := 0
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		sealed, err := os.ReadFile(filepath.Join(source, entry.Name()))
+		if err != nil {
+			return err
+		}
+		value, err := seal.Open(sealed, key)
+		if err != nil {
+			return fmt.Errorf("unable to open %s: %w", entry.Name(), err)
+		}
+		if err := os.WriteFile(filepath.Join(target, entry.Name()), value, 0o600); err != nil {
+			return err
+		}
+		opened++
+	}
+	setupLog.Info("opened sealed values", "source", source, "target", target, "files", opened)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(sealCmd)
+	sealCmd.AddCommand(sealKeygenCmd)
+	sealCmd.AddCommand(sealOpenCmd)
+
+	sealKeygenCmd.Flags().StringVar(&sealKeygenDir, "output-dir", ".", "Directory the seal.pem and seal.pub.pem files are written to.")
+	sealKeygenCmd.Flags().StringVar(&sealKeyType, "type", sealKeyTypeRSA, "Type of the key, rsa or ec.")
+
+	sealOpenCmd.Flags().StringVar(&sealPrivateKey, "private-key", sealPrivateKeyFile, "File containing the PEM encoded RSA or EC private key the values are opened with.")
+	sealOpenCmd.Flags().StringVar(&sealSourceDir, "source", "", "Directory of the mounted sealed Secret.")
+	sealOpenCmd.Flags().StringVar(&sealTargetDir, "target", "", "Directory the opened values are written to, e.g. an in-memory emptyDir volume.")
+	_ = sealOpenCmd.MarkFlagRequired("source")
+	_ = sealOpenCmd.MarkFlagRequired("target")
+}
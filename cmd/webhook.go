@@ -37,6 +37,7 @@ import (
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/crds"
 )
 
@@ -48,6 +49,7 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = esv1beta1.AddToScheme(scheme)
 	_ = esv1alpha1.AddToScheme(scheme)
+	_ = genv1alpha1.AddToScheme(scheme)
 }
 
 var webhookCmd = &cobra.Command{
@@ -80,6 +82,10 @@ var webhookCmd = &cobra.Command{
 		}
 		logger := zap.New(zap.UseFlagOptions(&opts))
 		ctrl.SetLogger(logger)
+		if err := esv1beta1.FilterProviders(allowedProviders, deniedProviders); err != nil {
+			setupLog.Error(err, errFilterProviders)
+			os.Exit(1)
+		}
 
 		err := waitForCerts(c, time.Minute*2)
 		if err != nil {
@@ -138,7 +144,10 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, "unable to start manager")
 			os.Exit(1)
 		}
-		if err = (&esv1beta1.ExternalSecret{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&esv1beta1.ExternalSecret{}).SetupWebhookWithManager(mgr, esv1beta1.ExternalSecretWebhookOptions{
+			RejectShortRefreshInterval:         rejectShortRefreshInterval,
+			RejectUnsupportedRemoteRefFeatures: rejectUnsupportedRemoteRefFeatures,
+		}); err != nil {
 			setupLog.Error(err, errCreateWebhook, "webhook", "ExternalSecret-v1beta1")
 			os.Exit(1)
 		}
@@ -162,6 +171,10 @@ var webhookCmd = &cobra.Command{
 			setupLog.Error(err, errCreateWebhook, "webhook", "ClusterSecretStore-v1alpha1")
 			os.Exit(1)
 		}
+		if err = (&genv1alpha1.Password{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, errCreateWebhook, "webhook", "Password-v1alpha1")
+			os.Exit(1)
+		}
 
 		err = mgr.AddReadyzCheck("certs", func(_ *http.Request) error {
 			return crds.CheckCerts(c, dnsName, time.Now().Add(time.Hour))
@@ -259,4 +272,8 @@ func init() {
 		" Full lists of available ciphers can be found at https://pkg.go.dev/crypto/tls#pkg-constants."+
 		" E.g. 'TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256'")
 	webhookCmd.Flags().StringVar(&tlsMinVersion, "tls-min-version", "1.2", "minimum version of TLS supported.")
+	webhookCmd.Flags().BoolVar(&rejectShortRefreshInterval, "reject-short-refresh-interval", false, "Reject ExternalSecrets with a refreshInterval below the minimum recommended by the provider instead of warning.")
+	webhookCmd.Flags().BoolVar(&rejectUnsupportedRemoteRefFeatures, "reject-unsupported-remote-ref-features", false, "Reject ExternalSecrets using remote ref features (version, find.tags, metadataPolicy Fetch) the provider doesn't support instead of warning.")
+	webhookCmd.Flags().StringSliceVar(&allowedProviders, "allowed-providers", nil, "Comma separated list of the providers stores may use, stores using other providers are rejected. All providers are allowed if not set.")
+	webhookCmd.Flags().StringSliceVar(&deniedProviders, "denied-providers", nil, "Comma separated list of the providers stores must not use, stores using them are rejected.")
 }
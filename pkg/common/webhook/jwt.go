@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultJWTAlgorithm = "RS256"
+	defaultJWTExpiry    = 5 * time.Minute
+
+	// authTemplateKey is the key the rendered credentials are available under in templates.
+	authTemplateKey = "auth"
+)
+
+// getPrivateKeyJWT renders a JWT signed with the referenced private key
+// for private_key_jwt client authentication.
+func (w *Webhook) getPrivateKeyJWT(ctx context.Context, auth *PrivateKeyJWT) (string, error) {
+	secret, err := w.getStoreSecret(ctx, auth.PrivateKeySecretRef)
+	if err != nil {
+		return "", err
+	}
+	keyPEM, ok := secret.Data[auth.PrivateKeySecretRef.Key]
+	if !ok {
+		return "", fmt.Errorf("private key %q not found in secret %s", auth.PrivateKeySecretRef.Key, auth.PrivateKeySecretRef.Name)
+	}
+
+	algorithm := auth.Algorithm
+	if algorithm == "" {
+		algorithm = defaultJWTAlgorithm
+	}
+	method := jwt.GetSigningMethod(algorithm)
+	if method == nil {
+		return "", fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	var key any
+	switch {
+	case strings.HasPrefix(algorithm, "ES"):
+		key, err = jwt.ParseECPrivateKeyFromPEM(keyPEM)
+	case strings.HasPrefix(algorithm, "RS"), strings.HasPrefix(algorithm, "PS"):
+		key, err = jwt.ParseRSAPrivateKeyFromPEM(keyPEM)
+	default:
+		return "", fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	subject := auth.Subject
+	if subject == "" {
+		subject = auth.Issuer
+	}
+	expiry := defaultJWTExpiry
+	if auth.Expiry != nil {
+		expiry = auth.Expiry.Duration
+	}
+	now := time.Now()
+	token := jwt.NewWithClaims(method, jwt.RegisteredClaims{
+		Issuer:    auth.Issuer,
+		Subject:   subject,
+		Audience:  jwt.ClaimStrings{auth.Audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+		ID:        uuid.NewString(),
+	})
+	if auth.KeyID != "" {
+		token.Header["kid"] = auth.KeyID
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign jwt: %w", err)
+	}
+	return signed, nil
+}
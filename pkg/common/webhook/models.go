@@ -41,6 +41,10 @@ type Spec struct {
 	// Result formatting
 	Result Result `json:"result"`
 
+	// MaxResponseBytes limits the size of the response body, larger responses are rejected.
+	// +optional
+	MaxResponseBytes *int64 `json:"maxResponseBytes,omitempty"`
+
 	// Secrets to fill in templates
 	// These secrets will be passed to the templating function as key value pairs under the given name
 	// +optional
@@ -56,6 +60,105 @@ type Spec struct {
 	// The provider for the CA bundle to use to validate webhook server certificate.
 	// +optional
 	CAProvider *CAProvider `json:"caProvider,omitempty"`
+
+	// Auth configures how the webhook authenticates with the endpoint.
+	// +optional
+	Auth *Auth `json:"auth,omitempty"`
+
+	// HealthCheck configures the request used to validate the webhook.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+
+	// Pagination configures how the pages of a list response are followed.
+	// +optional
+	Pagination *Pagination `json:"pagination,omitempty"`
+
+	// ConnectionPool configures the connections to the webhook.
+	// +optional
+	ConnectionPool *ConnectionPool `json:"connectionPool,omitempty"`
+}
+
+type ConnectionPool struct {
+	// MaxIdleConns limits the number of idle connections to all hosts, defaults to 100.
+	// +optional
+	MaxIdleConns int `json:"maxIdleConns,omitempty"`
+
+	// MaxIdleConnsPerHost limits the number of idle connections per host, defaults to 2.
+	// +optional
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost,omitempty"`
+
+	// MaxConnsPerHost limits the number of connections per host, not limited if not set.
+	// +optional
+	MaxConnsPerHost int `json:"maxConnsPerHost,omitempty"`
+
+	// IdleConnTimeout is the time an idle connection is kept open, defaults to 90s.
+	// +optional
+	IdleConnTimeout *metav1.Duration `json:"idleConnTimeout,omitempty"`
+
+	// KeepAlive is the interval of the TCP keep-alive probes, defaults to 30s.
+	// +optional
+	KeepAlive *metav1.Duration `json:"keepAlive,omitempty"`
+}
+
+type Pagination struct {
+	// CursorJSONPath is the json path of the cursor of the next page in the response.
+	// If not set, the next page is taken from the Link header.
+	// +optional
+	CursorJSONPath string `json:"cursorJSONPath,omitempty"`
+
+	// MaxPages limits the number of requested pages, defaults to 100.
+	// +optional
+	MaxPages int `json:"maxPages,omitempty"`
+}
+
+type HealthCheck struct {
+	// Method of the health request, defaults to GET.
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Path of the health request, resolved against the webhook url.
+	Path string `json:"path"`
+
+	// ExpectedStatus is the status code of a healthy response, any 2xx status if not set.
+	// +optional
+	ExpectedStatus int `json:"expectedStatus,omitempty"`
+
+	// Timeout of the health request, defaults to 15s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type Auth struct {
+	// PrivateKeyJWT renders a JWT signed with a private key for every request (private_key_jwt).
+	// +optional
+	PrivateKeyJWT *PrivateKeyJWT `json:"privateKeyJWT,omitempty"`
+}
+
+type PrivateKeyJWT struct {
+	// PrivateKeySecretRef references the PEM encoded private key used to sign the JWT.
+	PrivateKeySecretRef SecretKeySelector `json:"privateKeySecretRef"`
+
+	// Algorithm used to sign the JWT, defaults to RS256.
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Issuer is set as iss claim.
+	Issuer string `json:"issuer"`
+
+	// Subject is set as sub claim, defaults to the issuer.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Audience is set as aud claim.
+	Audience string `json:"audience"`
+
+	// KeyID is set as kid header.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Expiry is the lifetime of the JWT, defaults to 5m.
+	// +optional
+	Expiry *metav1.Duration `json:"expiry,omitempty"`
 }
 type CAProviderType string
 
@@ -86,8 +189,24 @@ type Result struct {
 	// Json path of return value
 	// +optional
 	JSONPath string `json:"jsonPath,omitempty"`
+
+	// Format of the response body: json, yaml or raw.
+	// +optional
+	Format ResultFormat `json:"format,omitempty"`
+
+	// Keys maps the keys of a secret map to the json path of their value in the response.
+	// +optional
+	Keys map[string]string `json:"keys,omitempty"`
 }
 
+type ResultFormat string
+
+const (
+	ResultFormatJSON ResultFormat = "json"
+	ResultFormatYAML ResultFormat = "yaml"
+	ResultFormatRaw  ResultFormat = "raw"
+)
+
 type Secret struct {
 	// Name of this secret in templates
 	Name string `json:"name"`
@@ -0,0 +1,170 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	defaultMaxPages = 100
+
+	// paginationTemplateKey is the template data key of the pagination values, e.g. {{ .pagination.cursor }}.
+	paginationTemplateKey = "pagination"
+
+	errMaxPages    = "response has more than maxPages of %d pages"
+	errPageCursor  = "failed to get cursor from response path %s: %w"
+	errPageLink    = "failed to parse next page link %q: %w"
+	errPageRequest = "failed to get page %d: %w"
+)
+
+// GetPaginatedSecretMap calls the webhook and follows the next pages of the response,
+// either through the cursor at pagination.cursorJSONPath or the RFC 5988 Link header with rel="next".
+// The secret maps of all pages are merged, keys of later pages take precedence.
+func (w *Webhook) GetPaginatedSecretMap(ctx context.Context, provider *Spec, ref *esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if w.HTTP == nil {
+		return nil, fmt.Errorf("http client not initialized")
+	}
+	data, bearer, err := w.getRequestData(ctx, provider, ref)
+	if err != nil {
+		return nil, err
+	}
+	maxPages := defaultMaxPages
+	var cursorPath string
+	if provider.Pagination != nil {
+		cursorPath = provider.Pagination.CursorJSONPath
+		if provider.Pagination.MaxPages > 0 {
+			maxPages = provider.Pagination.MaxPages
+		}
+	}
+	data[paginationTemplateKey] = map[string]string{"cursor": ""}
+	nextURL, err := ExecuteTemplateString(provider.URL, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	values := make(map[string][]byte)
+	for page := 1; ; page++ {
+		if page > maxPages {
+			return nil, fmt.Errorf(errMaxPages, maxPages)
+		}
+		resp, err := w.sendRequest(ctx, provider, nextURL, data, bearer)
+		if err != nil {
+			return nil, fmt.Errorf(errPageRequest, page, err)
+		}
+		jsondata, err := parseSecretMapResponse(provider, resp)
+		if err != nil {
+			return nil, err
+		}
+		pageValues, err := getSecretMap(provider, jsondata)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range pageValues {
+			values[k] = v
+		}
+
+		if cursorPath != "" {
+			cursor, err := getPageCursor(cursorPath, jsondata)
+			if err != nil {
+				return nil, err
+			}
+			if cursor == "" {
+				return values, nil
+			}
+			data[paginationTemplateKey] = map[string]string{"cursor": url.QueryEscape(cursor)}
+			nextURL, err = ExecuteTemplateString(provider.URL, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse url: %w", err)
+			}
+			continue
+		}
+		next, err := nextPageLink(resp)
+		if err != nil {
+			return nil, err
+		}
+		if next == "" {
+			return values, nil
+		}
+		nextURL = next
+	}
+}
+
+// getPageCursor returns the cursor of the next page, an empty string if the response is the last page.
+func getPageCursor(path string, jsondata any) (string, error) {
+	value, err := jsonpath.Get(path, jsondata)
+	if err != nil {
+		// a missing cursor marks the last page
+		if strings.Contains(err.Error(), "unknown key") {
+			return "", nil
+		}
+		return "", fmt.Errorf(errPageCursor, path, err)
+	}
+	cursor, err := ExtractSecretData(value)
+	if err != nil {
+		return "", fmt.Errorf(errPageCursor, path, err)
+	}
+	return string(cursor), nil
+}
+
+// nextPageLink returns the url of the rel="next" link of the response resolved against the request url,
+// an empty string if there is none.
+func nextPageLink(resp *Response) (string, error) {
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			target = strings.TrimSpace(target)
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			if !isNextLink(params) {
+				continue
+			}
+			target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+			next, err := url.Parse(target)
+			if err != nil {
+				return "", fmt.Errorf(errPageLink, target, err)
+			}
+			if resp.URL != nil {
+				next = resp.URL.ResolveReference(next)
+			}
+			return next.String(), nil
+		}
+	}
+	return "", nil
+}
+
+// isNextLink checks whether the link parameters contain the relation type next.
+func isNextLink(params string) bool {
+	for _, param := range strings.Split(params, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+			continue
+		}
+		for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+			if strings.EqualFold(rel, "next") {
+				return true
+			}
+		}
+	}
+	return false
+}
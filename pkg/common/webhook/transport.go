@@ -0,0 +1,158 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	defaultDialTimeout = 30 * time.Second
+	defaultKeepAlive   = 30 * time.Second
+	// transportTTL is the time the transport of a store is kept without being used,
+	// a little longer than the default refresh interval of an hour.
+	transportTTL = 2 * time.Hour
+)
+
+// StoreRef identifies the store of a webhook.
+type StoreRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// transportSettings are the settings a transport is built from.
+type transportSettings struct {
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	maxConnsPerHost     int
+	idleConnTimeout     time.Duration
+	keepAlive           time.Duration
+	rootCAs             *x509.CertPool
+}
+
+func (s *transportSettings) equal(o *transportSettings) bool {
+	return s.maxIdleConns == o.maxIdleConns &&
+		s.maxIdleConnsPerHost == o.maxIdleConnsPerHost &&
+		s.maxConnsPerHost == o.maxConnsPerHost &&
+		s.idleConnTimeout == o.idleConnTimeout &&
+		s.keepAlive == o.keepAlive &&
+		s.rootCAs.Equal(o.rootCAs)
+}
+
+func newTransportSettings(pool *ConnectionPool, rootCAs *x509.CertPool) *transportSettings {
+	s := &transportSettings{keepAlive: defaultKeepAlive, rootCAs: rootCAs}
+	if pool == nil {
+		return s
+	}
+	s.maxIdleConns = pool.MaxIdleConns
+	s.maxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+	s.maxConnsPerHost = pool.MaxConnsPerHost
+	if pool.IdleConnTimeout != nil {
+		s.idleConnTimeout = pool.IdleConnTimeout.Duration
+	}
+	if pool.KeepAlive != nil {
+		s.keepAlive = pool.KeepAlive.Duration
+	}
+	return s
+}
+
+// newTransport returns a transport with the defaults of http.DefaultTransport, overridden by the settings.
+func newTransport(s *transportSettings) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.DialContext = (&net.Dialer{
+		Timeout:   defaultDialTimeout,
+		KeepAlive: s.keepAlive,
+	}).DialContext
+	if s.maxIdleConns > 0 {
+		t.MaxIdleConns = s.maxIdleConns
+	}
+	if s.maxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = s.maxIdleConnsPerHost
+	}
+	if s.maxConnsPerHost > 0 {
+		t.MaxConnsPerHost = s.maxConnsPerHost
+	}
+	if s.idleConnTimeout > 0 {
+		t.IdleConnTimeout = s.idleConnTimeout
+	}
+	if s.rootCAs != nil {
+		t.TLSClientConfig = &tls.Config{
+			RootCAs:       s.rootCAs,
+			MinVersion:    tls.VersionTLS12,
+			Renegotiation: tls.RenegotiateOnceAsClient,
+		}
+	}
+	return t
+}
+
+// transportCache holds a transport per store, so the clients created for the reconciles
+// of a store share its connection pool instead of opening new connections.
+type transportCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[StoreRef]*cachedTransport
+}
+
+type cachedTransport struct {
+	settings  *transportSettings
+	transport *http.Transport
+	lastUsed  time.Time
+}
+
+var transports = newTransportCache(transportTTL)
+
+func newTransportCache(ttl time.Duration) *transportCache {
+	return &transportCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[StoreRef]*cachedTransport),
+	}
+}
+
+// get returns the transport of the store, it is replaced once the settings of the store changed.
+// The transports of stores that have not been used within the ttl, e.g. deleted stores, are evicted
+// together with their in-flight requests series.
+func (c *transportCache) get(store StoreRef, s *transportSettings) *http.Transport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for ref, entry := range c.entries {
+		if ref != store && now.Sub(entry.lastUsed) > c.ttl {
+			entry.transport.CloseIdleConnections()
+			metrics.DeleteInFlightRequests(constants.ProviderWebhook, ref.Kind, ref.Namespace, ref.Name)
+			delete(c.entries, ref)
+		}
+	}
+	if entry, ok := c.entries[store]; ok {
+		if entry.settings.equal(s) {
+			entry.lastUsed = now
+			return entry.transport
+		}
+		entry.transport.CloseIdleConnections()
+	}
+	t := newTransport(s)
+	c.entries[store] = &cachedTransport{settings: s, transport: t, lastUsed: now}
+	return t
+}
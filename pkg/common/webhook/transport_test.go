@@ -0,0 +1,48 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransportCache(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := newTransportCache(time.Hour)
+	c.now = func() time.Time { return now }
+	idle := StoreRef{Kind: "SecretStore", Namespace: "default", Name: "idle"}
+	used := StoreRef{Kind: "SecretStore", Namespace: "default", Name: "used"}
+	settings := newTransportSettings(nil, nil)
+
+	idleTransport := c.get(idle, settings)
+	usedTransport := c.get(used, settings)
+	assert.Same(t, usedTransport, c.get(used, newTransportSettings(nil, nil)))
+
+	// changed settings replace the transport
+	changed := newTransportSettings(&ConnectionPool{MaxConnsPerHost: 5}, nil)
+	usedTransport = c.get(used, changed)
+	assert.Equal(t, 5, usedTransport.MaxConnsPerHost)
+
+	now = now.Add(40 * time.Minute)
+	assert.Same(t, usedTransport, c.get(used, changed))
+	now = now.Add(40 * time.Minute)
+	assert.Same(t, usedTransport, c.get(used, changed))
+
+	assert.Len(t, c.entries, 1)
+	assert.NotSame(t, idleTransport, c.get(idle, settings))
+}
@@ -17,18 +17,21 @@ package webhook
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
 	tpl "text/template"
+	"time"
 
 	"github.com/PaesslerAG/jsonpath"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -38,6 +41,21 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
+const defaultHealthCheckTimeout = 15 * time.Second
+
+const (
+	errParseResponse     = "failed to parse response %s: %w"
+	errRawSecretMap      = "result format raw can not be used to get a map of secret values"
+	errMaxResponseBytes  = "response exceeds maxResponseBytes of %d bytes"
+	errUnknownFormat     = "unknown result format %q"
+	errHealthCheckPath   = "failed to parse health check path: %w"
+	errHealthCheckStatus = "health check failed with status %s"
+	errResultKey         = "failed to get key %s from response path %s: %w"
+	yamlMediaType        = "application/yaml"
+	yamlMediaTypeX       = "application/x-yaml"
+	yamlMediaTypeText    = "text/yaml"
+)
+
 type Webhook struct {
 	Kube          client.Client
 	Namespace     string
@@ -45,6 +63,8 @@ type Webhook struct {
 	HTTP          *http.Client
 	EnforceLabels bool
 	ClusterScoped bool
+	// Store is the store the webhook belongs to, if any.
+	Store *StoreRef
 }
 
 func (w *Webhook) getStoreSecret(ctx context.Context, ref SecretKeySelector) (*corev1.Secret, error) {
@@ -74,14 +94,32 @@ func (w *Webhook) getStoreSecret(ctx context.Context, ref SecretKeySelector) (*c
 	return secret, nil
 }
 func (w *Webhook) GetSecretMap(ctx context.Context, provider *Spec, ref *esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
-	result, err := w.GetWebhookData(ctx, provider, ref)
+	resp, err := w.GetWebhookResponse(ctx, provider, ref)
+	if err != nil {
+		return nil, err
+	}
+	jsondata, err := parseSecretMapResponse(provider, resp)
 	if err != nil {
 		return nil, err
 	}
-	// We always want json here, so just parse it out
-	jsondata := any(nil)
-	if err := json.Unmarshal(result, &jsondata); err != nil {
-		return nil, fmt.Errorf("failed to parse response json: %w", err)
+	return getSecretMap(provider, jsondata)
+}
+
+// parseSecretMapResponse parses the response into generic json data, raw responses are rejected.
+func parseSecretMapResponse(provider *Spec, resp *Response) (any, error) {
+	format := resp.Format(provider.Result)
+	if format == ResultFormatRaw {
+		return nil, fmt.Errorf(errRawSecretMap)
+	}
+	// We always want structured data here, so just parse it out
+	return resp.Parse(format)
+}
+
+// getSecretMap returns the secret map of the result configuration from the parsed response.
+func getSecretMap(provider *Spec, jsondata any) (map[string][]byte, error) {
+	var err error
+	if len(provider.Result.Keys) > 0 {
+		return getResultKeys(provider.Result.Keys, jsondata)
 	}
 	// Get subdata via jsonpath, if given
 	if provider.Result.JSONPath != "" {
@@ -116,6 +154,57 @@ func (w *Webhook) GetSecretMap(ctx context.Context, provider *Spec, ref *esv1bet
 	return values, nil
 }
 
+// getResultKeys evaluates the jsonPath of each key against the response.
+func getResultKeys(keys map[string]string, jsondata any) (map[string][]byte, error) {
+	values := make(map[string][]byte, len(keys))
+	for key, path := range keys {
+		value, err := jsonpath.Get(path, jsondata)
+		if err != nil {
+			return nil, fmt.Errorf(errResultKey, key, path, err)
+		}
+		values[key], err = ExtractSecretData(value)
+		if err != nil {
+			return nil, fmt.Errorf(errResultKey, key, path, err)
+		}
+	}
+	return values, nil
+}
+
+// ExtractSecretData tries to extract data from an any,
+// it is supposed to return a single value.
+func ExtractSecretData(jsondata any) ([]byte, error) {
+	switch val := jsondata.(type) {
+	case bool:
+		return []byte(strconv.FormatBool(val)), nil
+	case nil:
+		return []byte{}, nil
+	case int:
+		return []byte(strconv.Itoa(val)), nil
+	case float64:
+		return []byte(strconv.FormatFloat(val, 'f', 0, 64)), nil
+	case []byte:
+		return val, nil
+	case string:
+		return []byte(val), nil
+
+	// due to backwards compatibility we must keep this!
+	// in case we see a []something we pick the first element and return it
+	case []any:
+		if len(val) == 0 {
+			return nil, fmt.Errorf("filter worked but didn't get any result")
+		}
+		return ExtractSecretData(val[0])
+
+	// in case we encounter a map we serialize it instead of erroring out
+	// The user should use that data from within a template and figure
+	// out how to deal with it.
+	case map[string]any:
+		return json.Marshal(val)
+	default:
+		return nil, fmt.Errorf("failed to get response (wrong type: %T)", jsondata)
+	}
+}
+
 func (w *Webhook) GetTemplateData(ctx context.Context, ref *esv1beta1.ExternalSecretDataRemoteRef, secrets []Secret) (map[string]map[string]string, error) {
 	data := map[string]map[string]string{}
 	if ref != nil {
@@ -140,22 +229,145 @@ func (w *Webhook) GetTemplateData(ctx context.Context, ref *esv1beta1.ExternalSe
 	return data, nil
 }
 
+// Response is the body of a successful webhook call.
+type Response struct {
+	Body        []byte
+	ContentType string
+	// Header of the response and URL of the request, used to follow pagination links.
+	Header http.Header
+	URL    *url.URL
+}
+
+// Format returns the configured result format or derives it from the content type of the response.
+func (r *Response) Format(result Result) ResultFormat {
+	if result.Format != "" {
+		return result.Format
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.ContentType)
+	switch mediaType {
+	case yamlMediaType, yamlMediaTypeX, yamlMediaTypeText:
+		return ResultFormatYAML
+	default:
+		return ResultFormatJSON
+	}
+}
+
+// Parse decodes the body in the given format into generic json data.
+func (r *Response) Parse(format ResultFormat) (any, error) {
+	data := any(nil)
+	switch format {
+	case ResultFormatJSON:
+		if err := json.Unmarshal(r.Body, &data); err != nil {
+			return nil, fmt.Errorf(errParseResponse, format, err)
+		}
+	case ResultFormatYAML:
+		if err := yaml.Unmarshal(r.Body, &data); err != nil {
+			return nil, fmt.Errorf(errParseResponse, format, err)
+		}
+	default:
+		return nil, fmt.Errorf(errUnknownFormat, format)
+	}
+	return data, nil
+}
+
 func (w *Webhook) GetWebhookData(ctx context.Context, provider *Spec, ref *esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	resp, err := w.GetWebhookResponse(ctx, provider, ref)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// GetWebhookResponse calls the webhook and returns the body and content type of the response.
+func (w *Webhook) GetWebhookResponse(ctx context.Context, provider *Spec, ref *esv1beta1.ExternalSecretDataRemoteRef) (*Response, error) {
 	if w.HTTP == nil {
 		return nil, fmt.Errorf("http client not initialized")
 	}
-	data, err := w.GetTemplateData(ctx, ref, provider.Secrets)
+	data, bearer, err := w.getRequestData(ctx, provider, ref)
 	if err != nil {
 		return nil, err
 	}
-	method := provider.Method
-	if method == "" {
-		method = http.MethodGet
+	url, err := ExecuteTemplateString(provider.URL, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+	return w.sendRequest(ctx, provider, url, data, bearer)
+}
+
+// GetWebhookStream calls the webhook and returns the body of the response without reading it.
+// Reading more than maxResponseBytes of the provider fails. The caller must close the body.
+func (w *Webhook) GetWebhookStream(ctx context.Context, provider *Spec, ref *esv1beta1.ExternalSecretDataRemoteRef) (io.ReadCloser, error) {
+	if w.HTTP == nil {
+		return nil, fmt.Errorf("http client not initialized")
+	}
+	data, bearer, err := w.getRequestData(ctx, provider, ref)
+	if err != nil {
+		return nil, err
 	}
 	url, err := ExecuteTemplateString(provider.URL, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse url: %w", err)
 	}
+	resp, err := w.doRequest(ctx, provider, url, data, bearer)
+	if err != nil {
+		return nil, err
+	}
+	if provider.MaxResponseBytes == nil {
+		return resp.Body, nil
+	}
+	return &limitedBody{ReadCloser: resp.Body, limit: *provider.MaxResponseBytes}, nil
+}
+
+// limitedBody fails the read once more than limit bytes were read, instead of truncating the body.
+type limitedBody struct {
+	io.ReadCloser
+	limit int64
+	read  int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.read > b.limit {
+		return n, fmt.Errorf(errMaxResponseBytes, b.limit)
+	}
+	return n, err
+}
+
+// sendRequest calls the url with the method, body and headers of the provider and reads the response.
+func (w *Webhook) sendRequest(ctx context.Context, provider *Spec, url string, data map[string]map[string]string, bearer string) (*Response, error) {
+	resp, err := w.doRequest(ctx, provider, url, data, bearer)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var reader io.Reader = resp.Body
+	if provider.MaxResponseBytes != nil {
+		// read one byte more than allowed to detect larger responses
+		reader = io.LimitReader(resp.Body, *provider.MaxResponseBytes+1)
+	}
+	respBody, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if provider.MaxResponseBytes != nil && int64(len(respBody)) > *provider.MaxResponseBytes {
+		return nil, fmt.Errorf(errMaxResponseBytes, *provider.MaxResponseBytes)
+	}
+	return &Response{
+		Body:        respBody,
+		ContentType: resp.Header.Get("Content-Type"),
+		Header:      resp.Header,
+		URL:         resp.Request.URL,
+	}, nil
+}
+
+// doRequest calls the url with the method, body and headers of the provider.
+// The body of a successful response must be closed by the caller.
+func (w *Webhook) doRequest(ctx context.Context, provider *Spec, url string, data map[string]map[string]string, bearer string) (*http.Response, error) {
+	method := provider.Method
+	if method == "" {
+		method = http.MethodGet
+	}
 	body, err := ExecuteTemplate(provider.Body, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse body: %w", err)
@@ -165,12 +377,8 @@ func (w *Webhook) GetWebhookData(ctx context.Context, provider *Spec, ref *esv1b
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	for hKey, hValueTpl := range provider.Headers {
-		hValue, err := ExecuteTemplateString(hValueTpl, data)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse header %s: %w", hKey, err)
-		}
-		req.Header.Add(hKey, hValue)
+	if err := setHeaders(req, provider, data, bearer); err != nil {
+		return nil, err
 	}
 
 	resp, err := w.HTTP.Do(req)
@@ -178,36 +386,137 @@ func (w *Webhook) GetWebhookData(ctx context.Context, provider *Spec, ref *esv1b
 	if err != nil {
 		return nil, fmt.Errorf("failed to call endpoint: %w", err)
 	}
-	defer resp.Body.Close()
 	if resp.StatusCode == 404 {
+		resp.Body.Close()
 		return nil, esv1beta1.NoSecretError{}
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		resp.Body.Close()
 		return nil, fmt.Errorf("endpoint gave error %s", resp.Status)
 	}
-	return io.ReadAll(resp.Body)
+	return resp, nil
 }
 
+// CheckHealth sends the health request of the provider and checks the status of the response.
+// The request uses the headers, secrets and auth of the provider, but not its body.
+func (w *Webhook) CheckHealth(ctx context.Context, provider *Spec) error {
+	if w.HTTP == nil {
+		return fmt.Errorf("http client not initialized")
+	}
+	check := provider.HealthCheck
+	if check == nil {
+		return nil
+	}
+	timeout := defaultHealthCheckTimeout
+	if check.Timeout != nil {
+		timeout = check.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	data, bearer, err := w.getRequestData(ctx, provider, nil)
+	if err != nil {
+		return err
+	}
+	path, err := ExecuteTemplateString(check.Path, data)
+	if err != nil {
+		return fmt.Errorf(errHealthCheckPath, err)
+	}
+	base, err := url.Parse(provider.URL)
+	if err != nil {
+		return fmt.Errorf(errHealthCheckPath, err)
+	}
+	ref, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf(errHealthCheckPath, err)
+	}
+	method := check.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, base.ResolveReference(ref).String(), http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := setHeaders(req, provider, data, bearer); err != nil {
+		return err
+	}
+
+	resp, err := w.HTTP.Do(req)
+	metrics.ObserveAPICall(constants.ProviderWebhook, constants.CallWebhookHTTPReq, err)
+	if err != nil {
+		return fmt.Errorf("failed to call endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	healthy := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if check.ExpectedStatus != 0 {
+		healthy = resp.StatusCode == check.ExpectedStatus
+	}
+	if !healthy {
+		return fmt.Errorf(errHealthCheckStatus, resp.Status)
+	}
+	return nil
+}
+
+// getRequestData returns the template data of a request and the bearer token rendered by the auth of the provider.
+func (w *Webhook) getRequestData(ctx context.Context, provider *Spec, ref *esv1beta1.ExternalSecretDataRemoteRef) (map[string]map[string]string, string, error) {
+	data, err := w.GetTemplateData(ctx, ref, provider.Secrets)
+	if err != nil {
+		return nil, "", err
+	}
+	var bearer string
+	if provider.Auth != nil && provider.Auth.PrivateKeyJWT != nil {
+		bearer, err = w.getPrivateKeyJWT(ctx, provider.Auth.PrivateKeyJWT)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to render private key jwt: %w", err)
+		}
+		data[authTemplateKey] = map[string]string{"jwt": bearer}
+	}
+	return data, bearer, nil
+}
+
+func setHeaders(req *http.Request, provider *Spec, data map[string]map[string]string, bearer string) error {
+	for hKey, hValueTpl := range provider.Headers {
+		hValue, err := ExecuteTemplateString(hValueTpl, data)
+		if err != nil {
+			return fmt.Errorf("failed to parse header %s: %w", hKey, err)
+		}
+		req.Header.Add(hKey, hValue)
+	}
+	if bearer != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	return nil
+}
+
+// GetHTTPClient returns the client to call the webhook with.
+// Clients of a store share the connection pool of the store and count its in-flight requests,
+// other clients get a connection pool of their own.
 func (w *Webhook) GetHTTPClient(provider *Spec) (*http.Client, error) {
 	client := &http.Client{}
 	if provider.Timeout != nil {
 		client.Timeout = provider.Timeout.Duration
 	}
-	if len(provider.CABundle) == 0 && provider.CAProvider == nil {
-		// No need to process ca stuff if it is not there
-		return client, nil
-	}
-	caCertPool, err := w.GetCACertPool(provider)
-	if err != nil {
-		return nil, err
+	var caCertPool *x509.CertPool
+	if len(provider.CABundle) > 0 || provider.CAProvider != nil {
+		var err error
+		caCertPool, err = w.GetCACertPool(provider)
+		if err != nil {
+			return nil, err
+		}
 	}
-
-	tlsConf := &tls.Config{
-		RootCAs:       caCertPool,
-		MinVersion:    tls.VersionTLS12,
-		Renegotiation: tls.RenegotiateOnceAsClient,
+	settings := newTransportSettings(provider.ConnectionPool, caCertPool)
+	if w.Store == nil {
+		if caCertPool == nil && provider.ConnectionPool == nil {
+			// No need for a transport of our own, use the default transport
+			return client, nil
+		}
+		client.Transport = newTransport(settings)
+		return client, nil
 	}
-	client.Transport = &http.Transport{TLSClientConfig: tlsConf}
+	transport := transports.get(*w.Store, settings)
+	client.Transport = metrics.InFlightRoundTripper(constants.ProviderWebhook, w.Store.Kind, w.Store.Namespace, w.Store.Name, transport)
 	return client, nil
 }
 
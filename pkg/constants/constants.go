@@ -23,6 +23,7 @@ const (
 	CallAWSSMCreateSecret        = "CreateSecret"
 	CallAWSSMPutSecretValue      = "PutSecretValue"
 	CallAWSSMListSecrets         = "ListSecrets"
+	CallAWSSMTagResource         = "TagResource"
 
 	ProviderAWSPS                = "AWS/ParameterStore"
 	CallAWSPSGetParameter        = "GetParameter"
@@ -31,6 +32,10 @@ const (
 	CallAWSPSDescribeParameter   = "DescribeParameter"
 	CallAWSPSListTagsForResource = "ListTagsForResource"
 
+	ProviderAWSAC                            = "AWS/AppConfig"
+	CallAWSACGetHostedConfigurationVersion   = "GetHostedConfigurationVersion"
+	CallAWSACListHostedConfigurationVersions = "ListHostedConfigurationVersions"
+
 	ProviderAzureKV              = "Azure/KeyVault"
 	CallAzureKVGetKey            = "GetKey"
 	CallAzureKVDeleteKey         = "DeleteKey"
@@ -41,6 +46,11 @@ const (
 	CallAzureKVGetCertificate    = "GetCertificate"
 	CallAzureKVDeleteCertificate = "DeleteCertificate"
 	CallAzureKVImportCertificate = "ImportCertificate"
+	CallAzureKVGetCertificates   = "GetCertificates"
+	CallAzureKVGetKeys           = "GetKeys"
+
+	CallAzureKVGetKeyRotationPolicy    = "GetKeyRotationPolicy"
+	CallAzureKVUpdateKeyRotationPolicy = "UpdateKeyRotationPolicy"
 
 	ProviderGCPSM                = "GCP/SecretManager"
 	CallGCPSMGetSecret           = "GetSecret"
@@ -62,6 +72,8 @@ const (
 	CallHCVaultWriteSecretData = "WriteSecretData"
 	CallHCVaultDeleteSecret    = "DeleteSecret"
 	CallHCVaultListSecrets     = "ListSecrets"
+	CallHCVaultTransitEncrypt  = "TransitEncrypt"
+	CallHCVaultTransitDecrypt  = "TransitDecrypt"
 
 	ProviderKubernetes                         = "Kubernetes"
 	CallKubernetesGetSecret                    = "GetSecret"
@@ -69,6 +81,7 @@ const (
 	CallKubernetesCreateSecret                 = "CreateSecret"
 	CallKubernetesDeleteSecret                 = "DeleteSecret"
 	CallKubernetesUpdateSecret                 = "UpdateSecret"
+	CallKubernetesApplySecret                  = "ApplySecret"
 	CallKubernetesCreateSelfSubjectRulesReview = "CreateSelfSubjectRulesReview"
 
 	ProviderIBMSM                = "IBM/SecretsManager"
@@ -79,6 +92,29 @@ const (
 	ProviderWebhook    = "Webhook"
 	CallWebhookHTTPReq = "HTTPRequest"
 
+	ProviderPlugin          = "Plugin"
+	CallPluginGetSecret     = "GetSecret"
+	CallPluginGetSecretMap  = "GetSecretMap"
+	CallPluginGetAllSecrets = "GetAllSecrets"
+	CallPluginPushSecret    = "PushSecret"
+	CallPluginDeleteSecret  = "DeleteSecret"
+	CallPluginSecretExists  = "SecretExists"
+	CallPluginValidate      = "Validate"
+
+	ProviderEtcd   = "etcd"
+	CallEtcdRange  = "Range"
+	CallEtcdStatus = "Status"
+
+	ProviderCloudflare               = "Cloudflare"
+	CallCloudflareKVGetValue         = "KVGetValue"
+	CallCloudflareKVListKeys         = "KVListKeys"
+	CallCloudflareKVPutValue         = "KVPutValue"
+	CallCloudflareKVDeleteValue      = "KVDeleteValue"
+	CallCloudflareSecretsStoreList   = "SecretsStoreListSecrets"
+	CallCloudflareSecretsStoreCreate = "SecretsStoreCreateSecret"
+	CallCloudflareSecretsStoreUpdate = "SecretsStoreUpdateSecret"
+	CallCloudflareSecretsStoreDelete = "SecretsStoreDeleteSecret"
+
 	ProviderGitLab                 = "GitLab"
 	CallGitLabListProjectsGroups   = "ListProjectsGroups"
 	CallGitLabProjectVariableGet   = "ProjectVariableGet"
@@ -95,6 +131,8 @@ const (
 	CallAKEYLESSSMGetCertificateValue   = "GetCertificateValue"
 	CallAKEYLESSSMGetDynamicSecretValue = "GetDynamicSecretsValue"
 
+	GeneratorGithub = "Github"
+
 	StatusError   = "error"
 	StatusSuccess = "success"
 
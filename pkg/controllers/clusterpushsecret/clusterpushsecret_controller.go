@@ -0,0 +1,408 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpushsecret
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret/cpsmetrics"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+)
+
+// Reconciler reconciles a ClusterPushSecret object.
+type Reconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	RequeueInterval time.Duration
+}
+
+const (
+	errGetCPS               = "could not get ClusterPushSecret"
+	errPatchStatus          = "unable to patch status"
+	errConvertLabelSelector = "unable to convert labelselector"
+	errGetExistingPS        = "could not get existing PushSecret"
+	errNamespacesFailed     = "one or more namespaces failed"
+	errRenderRemoteKey      = "could not render remote key %q: %w"
+)
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("ClusterPushSecret", req.NamespacedName)
+
+	resourceLabels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": req.Name, "namespace": req.Namespace})
+	start := time.Now()
+
+	pushSecretReconcileDuration := cpsmetrics.GetGaugeVec(cpsmetrics.ClusterPushSecretReconcileDurationKey)
+	defer func() { pushSecretReconcileDuration.With(resourceLabels).Set(float64(time.Since(start))) }()
+
+	var clusterPushSecret esv1alpha1.ClusterPushSecret
+	err := r.Get(ctx, req.NamespacedName, &clusterPushSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			cpsmetrics.RemoveMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, errGetCPS)
+		return ctrl.Result{}, err
+	}
+
+	// skip reconciliation if deletion timestamp is set on cluster push secret
+	if clusterPushSecret.DeletionTimestamp != nil {
+		log.Info("skipping as it is in deletion")
+		return ctrl.Result{}, nil
+	}
+
+	p := client.MergeFrom(clusterPushSecret.DeepCopy())
+	defer r.deferPatch(ctx, log, &clusterPushSecret, p)
+
+	refreshInt := r.RequeueInterval
+	if clusterPushSecret.Spec.RefreshInterval != nil {
+		refreshInt = clusterPushSecret.Spec.RefreshInterval.Duration
+	}
+
+	psName := clusterPushSecret.Spec.PushSecretName
+	if psName == "" {
+		psName = clusterPushSecret.ObjectMeta.Name
+	}
+	if prevName := clusterPushSecret.Status.PushSecretName; prevName != psName {
+		// PushSecretName has changed, so remove the old ones
+		for _, ns := range clusterPushSecret.Status.ProvisionedNamespaces {
+			if err := r.deletePushSecret(ctx, prevName, clusterPushSecret.Name, ns); err != nil {
+				log.Error(err, "could not delete PushSecret")
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	clusterPushSecret.Status.PushSecretName = psName
+
+	namespaces, err := r.getTargetNamespaces(ctx, &clusterPushSecret)
+	if err != nil {
+		log.Error(err, "failed to get target Namespaces")
+		return ctrl.Result{}, err
+	}
+
+	failedNamespaces := r.deleteOutdatedPushSecrets(ctx, namespaces, psName, clusterPushSecret.Name, clusterPushSecret.Status.ProvisionedNamespaces)
+
+	provisionedNamespaces := []string{}
+	for _, namespace := range namespaces {
+		var existingPS esv1alpha1.PushSecret
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      psName,
+			Namespace: namespace.Name,
+		}, &existingPS)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, errGetExistingPS)
+			failedNamespaces[namespace.Name] = err
+			continue
+		}
+
+		if err == nil && !isPushSecretOwnedBy(&existingPS, clusterPushSecret.Name) {
+			failedNamespaces[namespace.Name] = fmt.Errorf("push secret already exists in namespace")
+			continue
+		}
+
+		if err := r.createOrUpdatePushSecret(ctx, &clusterPushSecret, namespace, psName, clusterPushSecret.Spec.PushSecretMetadata); err != nil {
+			log.Error(err, "failed to create or update push secret")
+			failedNamespaces[namespace.Name] = err
+			continue
+		}
+
+		provisionedNamespaces = append(provisionedNamespaces, namespace.Name)
+	}
+
+	condition := NewClusterPushSecretCondition(failedNamespaces)
+	SetClusterPushSecretCondition(&clusterPushSecret, *condition)
+
+	clusterPushSecret.Status.FailedNamespaces = toNamespaceFailures(failedNamespaces)
+	sort.Strings(provisionedNamespaces)
+	clusterPushSecret.Status.ProvisionedNamespaces = provisionedNamespaces
+
+	return ctrl.Result{RequeueAfter: refreshInt}, nil
+}
+
+func (r *Reconciler) getTargetNamespaces(ctx context.Context, cps *esv1alpha1.ClusterPushSecret) ([]v1.Namespace, error) {
+	selectors := []*metav1.LabelSelector{}
+	for _, ns := range cps.Spec.Namespaces {
+		selectors = append(selectors, &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"kubernetes.io/metadata.name": ns,
+			},
+		})
+	}
+	selectors = append(selectors, cps.Spec.NamespaceSelectors...)
+
+	var namespaces []v1.Namespace
+	namespaceSet := make(map[string]struct{})
+	for _, selector := range selectors {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert label selector %s: %w", selector, err)
+		}
+
+		var nl v1.NamespaceList
+		err = r.List(ctx, &nl, &client.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces by label selector %s: %w", selector, err)
+		}
+
+		for _, n := range nl.Items {
+			if _, exist := namespaceSet[n.Name]; exist {
+				continue
+			}
+			namespaceSet[n.Name] = struct{}{}
+			namespaces = append(namespaces, n)
+		}
+	}
+
+	return namespaces, nil
+}
+
+func (r *Reconciler) createOrUpdatePushSecret(ctx context.Context, clusterPushSecret *esv1alpha1.ClusterPushSecret, namespace v1.Namespace, psName string, psMetadata esv1alpha1.PushSecretMetadata) error {
+	spec, err := renderPushSecretSpec(clusterPushSecret.Spec.PushSecretSpec, namespace.Name)
+	if err != nil {
+		return err
+	}
+
+	pushSecret := &esv1alpha1.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace.Name,
+			Name:      psName,
+		},
+	}
+
+	mutateFunc := func() error {
+		pushSecret.Labels = psMetadata.Labels
+		pushSecret.Annotations = psMetadata.Annotations
+		pushSecret.Spec = spec
+
+		if err := controllerutil.SetControllerReference(clusterPushSecret, pushSecret, r.Scheme); err != nil {
+			return fmt.Errorf("could not set the controller owner reference %w", err)
+		}
+
+		return nil
+	}
+
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, pushSecret, mutateFunc); err != nil {
+		return fmt.Errorf("could not create or update PushSecret: %w", err)
+	}
+
+	return nil
+}
+
+// renderPushSecretSpec renders the remote keys of the PushSecret data for the namespace,
+// so the source Secrets of all namespaces can be pushed to the same store without overwriting each other.
+func renderPushSecretSpec(spec esv1alpha1.PushSecretSpec, namespace string) (esv1alpha1.PushSecretSpec, error) {
+	rendered := *spec.DeepCopy()
+	values := map[string]string{
+		"namespace": namespace,
+	}
+	// generated data has no source Secret
+	if spec.Selector.Secret != nil {
+		values["secretName"] = spec.Selector.Secret.Name
+	}
+	for i := range rendered.Data {
+		remoteRef := &rendered.Data[i].Match.RemoteRef
+		if !strings.Contains(remoteRef.RemoteKey, "{{") {
+			continue
+		}
+		tpl, err := template.New("remoteKey").Option("missingkey=error").Parse(remoteRef.RemoteKey)
+		if err != nil {
+			return rendered, fmt.Errorf(errRenderRemoteKey, remoteRef.RemoteKey, err)
+		}
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, values); err != nil {
+			return rendered, fmt.Errorf(errRenderRemoteKey, remoteRef.RemoteKey, err)
+		}
+		remoteRef.RemoteKey = buf.String()
+	}
+	return rendered, nil
+}
+
+func (r *Reconciler) deletePushSecret(ctx context.Context, psName, cpsName, namespace string) error {
+	var existingPS esv1alpha1.PushSecret
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      psName,
+		Namespace: namespace,
+	}, &existingPS)
+	if err != nil {
+		// If we can't find it then just leave
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !isPushSecretOwnedBy(&existingPS, cpsName) {
+		return nil
+	}
+
+	err = r.Delete(ctx, &existingPS, &client.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("push secret in non matching namespace could not be deleted: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deferPatch(ctx context.Context, log logr.Logger, clusterPushSecret *esv1alpha1.ClusterPushSecret, p client.Patch) {
+	if err := r.Status().Patch(ctx, clusterPushSecret, p); err != nil {
+		log.Error(err, errPatchStatus)
+	}
+}
+
+func (r *Reconciler) deleteOutdatedPushSecrets(ctx context.Context, namespaces []v1.Namespace, psName, cpsName string, provisionedNamespaces []string) map[string]error {
+	failedNamespaces := map[string]error{}
+	// Loop through existing namespaces first to make sure they still have our labels
+	for _, namespace := range getRemovedNamespaces(namespaces, provisionedNamespaces) {
+		err := r.deletePushSecret(ctx, psName, cpsName, namespace)
+		if err != nil {
+			r.Log.Error(err, "unable to delete push secret")
+			failedNamespaces[namespace] = err
+		}
+	}
+
+	return failedNamespaces
+}
+
+func isPushSecretOwnedBy(ps *esv1alpha1.PushSecret, cpsName string) bool {
+	owner := metav1.GetControllerOf(ps)
+	return owner != nil && owner.APIVersion == esv1alpha1.SchemeGroupVersion.String() && owner.Kind == esv1alpha1.ClusterPushSecretKind && owner.Name == cpsName
+}
+
+func getRemovedNamespaces(currentNSs []v1.Namespace, provisionedNSs []string) []string {
+	currentNSSet := map[string]struct{}{}
+	for _, currentNs := range currentNSs {
+		currentNSSet[currentNs.Name] = struct{}{}
+	}
+
+	var removedNSs []string
+	for _, ns := range provisionedNSs {
+		if _, ok := currentNSSet[ns]; !ok {
+			removedNSs = append(removedNSs, ns)
+		}
+	}
+
+	return removedNSs
+}
+
+func toNamespaceFailures(failedNamespaces map[string]error) []esv1alpha1.ClusterPushSecretNamespaceFailure {
+	namespaceFailures := make([]esv1alpha1.ClusterPushSecretNamespaceFailure, 0, len(failedNamespaces))
+	for namespace, err := range failedNamespaces {
+		namespaceFailures = append(namespaceFailures, esv1alpha1.ClusterPushSecretNamespaceFailure{
+			Namespace: namespace,
+			Reason:    err.Error(),
+		})
+	}
+	sort.Slice(namespaceFailures, func(i, j int) bool { return namespaceFailures[i].Namespace < namespaceFailures[j].Namespace })
+	return namespaceFailures
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(opts).
+		For(&esv1alpha1.ClusterPushSecret{}).
+		Owns(&esv1alpha1.PushSecret{}).
+		Watches(
+			&v1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForNamespace),
+			builder.WithPredicates(namespacePredicate()),
+		).
+		Complete(r)
+}
+
+func (r *Reconciler) findObjectsForNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	var clusterPushSecrets esv1alpha1.ClusterPushSecretList
+	if err := r.List(ctx, &clusterPushSecrets); err != nil {
+		r.Log.Error(err, errGetCPS)
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range clusterPushSecrets.Items {
+		clusterPushSecret := &clusterPushSecrets.Items[i]
+		if selectsNamespace(r.Log, clusterPushSecret, namespace) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      clusterPushSecret.GetName(),
+					Namespace: clusterPushSecret.GetNamespace(),
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+func selectsNamespace(log logr.Logger, cps *esv1alpha1.ClusterPushSecret, namespace client.Object) bool {
+	if slices.Contains(cps.Spec.Namespaces, namespace.GetName()) {
+		return true
+	}
+	for _, selector := range cps.Spec.NamespaceSelectors {
+		labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			log.Error(err, errConvertLabelSelector)
+			continue
+		}
+		if labelSelector.Matches(labels.Set(namespace.GetLabels())) {
+			return true
+		}
+	}
+	return false
+}
+
+func namespacePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
+			return true
+		},
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpushsecret
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret/cpsmetrics"
+)
+
+func init() {
+	cpsmetrics.SetUpMetrics()
+}
+
+func TestRenderPushSecretSpec(t *testing.T) {
+	spec := esv1alpha1.PushSecretSpec{
+		Selector: esv1alpha1.PushSecretSelector{
+			Secret: &esv1alpha1.PushSecretSecret{Name: "db"},
+		},
+		Data: []esv1alpha1.PushSecretData{
+			{Match: esv1alpha1.PushSecretMatch{SecretKey: "a", RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "{{ .namespace }}/{{ .secretName }}"}}},
+			{Match: esv1alpha1.PushSecretMatch{SecretKey: "b", RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "static"}}},
+		},
+	}
+	got, err := renderPushSecretSpec(spec, "team-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key := got.Data[0].Match.RemoteRef.RemoteKey; key != "team-a/db" {
+		t.Errorf("unexpected remote key: %q", key)
+	}
+	if key := got.Data[1].Match.RemoteRef.RemoteKey; key != "static" {
+		t.Errorf("unexpected remote key: %q", key)
+	}
+	if key := spec.Data[0].Match.RemoteRef.RemoteKey; key != "{{ .namespace }}/{{ .secretName }}" {
+		t.Errorf("spec of the ClusterPushSecret was modified: %q", key)
+	}
+
+	spec.Data[0].Match.RemoteRef.RemoteKey = "{{ .unknown }}"
+	if _, err := renderPushSecretSpec(spec, "team-a"); err == nil {
+		t.Errorf("expected an error for an unknown template value")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1alpha1.AddToScheme(scheme)
+
+	namespace := func(name string, lbls map[string]string) *v1.Namespace {
+		l := map[string]string{"kubernetes.io/metadata.name": name}
+		for k, v := range lbls {
+			l[k] = v
+		}
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: l}}
+	}
+	cps := &esv1alpha1.ClusterPushSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cps"},
+		Spec: esv1alpha1.ClusterPushSecretSpec{
+			PushSecretName: "push-db",
+			NamespaceSelectors: []*metav1.LabelSelector{
+				{MatchLabels: map[string]string{"team": "x"}},
+			},
+			Namespaces: []string{"taken"},
+			PushSecretSpec: esv1alpha1.PushSecretSpec{
+				SecretStoreRefs: []esv1alpha1.PushSecretStoreRef{{Name: "store", Kind: "ClusterSecretStore"}},
+				Selector: esv1alpha1.PushSecretSelector{
+					Secret: &esv1alpha1.PushSecretSecret{Name: "db"},
+				},
+				Data: []esv1alpha1.PushSecretData{
+					{Match: esv1alpha1.PushSecretMatch{SecretKey: "password", RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "{{ .namespace }}-db"}}},
+				},
+			},
+		},
+	}
+	unowned := &esv1alpha1.PushSecret{ObjectMeta: metav1.ObjectMeta{Name: "push-db", Namespace: "taken"}}
+
+	c := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cps, unowned,
+			namespace("team-a", map[string]string{"team": "x"}),
+			namespace("team-b", map[string]string{"team": "x"}),
+			namespace("taken", nil),
+			namespace("other", nil)).
+		WithStatusSubresource(&esv1alpha1.ClusterPushSecret{}).
+		Build()
+	r := &Reconciler{Client: c, Log: ctrl.Log, Scheme: scheme}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: cps.Name}}
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, ns := range []string{"team-a", "team-b"} {
+		var ps esv1alpha1.PushSecret
+		if err := c.Get(ctx, types.NamespacedName{Name: "push-db", Namespace: ns}, &ps); err != nil {
+			t.Fatalf("expected PushSecret in %s: %v", ns, err)
+		}
+		if !isPushSecretOwnedBy(&ps, cps.Name) {
+			t.Errorf("PushSecret in %s is not owned by the ClusterPushSecret", ns)
+		}
+		if key := ps.Spec.Data[0].Match.RemoteRef.RemoteKey; key != ns+"-db" {
+			t.Errorf("unexpected remote key in %s: %q", ns, key)
+		}
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "push-db", Namespace: "other"}, &esv1alpha1.PushSecret{}); err == nil {
+		t.Errorf("unexpected PushSecret in unselected namespace")
+	}
+
+	var got esv1alpha1.ClusterPushSecret
+	if err := c.Get(ctx, req.NamespacedName, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Status.ProvisionedNamespaces) != 2 || got.Status.ProvisionedNamespaces[0] != "team-a" || got.Status.ProvisionedNamespaces[1] != "team-b" {
+		t.Errorf("unexpected provisioned namespaces: %v", got.Status.ProvisionedNamespaces)
+	}
+	if len(got.Status.FailedNamespaces) != 1 || got.Status.FailedNamespaces[0].Namespace != "taken" {
+		t.Errorf("unexpected failed namespaces: %v", got.Status.FailedNamespaces)
+	}
+	if len(got.Status.Conditions) != 1 || got.Status.Conditions[0].Status != v1.ConditionFalse {
+		t.Errorf("unexpected conditions: %v", got.Status.Conditions)
+	}
+
+	// deselecting a namespace removes its PushSecret
+	var nsB v1.Namespace
+	if err := c.Get(ctx, types.NamespacedName{Name: "team-b"}, &nsB); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	delete(nsB.Labels, "team")
+	if err := c.Update(ctx, &nsB); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "push-db", Namespace: "team-b"}, &esv1alpha1.PushSecret{}); err == nil {
+		t.Errorf("expected PushSecret in deselected namespace to be deleted")
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(unowned), &esv1alpha1.PushSecret{}); err != nil {
+		t.Errorf("expected unowned PushSecret to be kept: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterpushsecret
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterpushsecret/cpsmetrics"
+)
+
+func NewClusterPushSecretCondition(failedNamespaces map[string]error) *esv1alpha1.ClusterPushSecretStatusCondition {
+	if len(failedNamespaces) == 0 {
+		return &esv1alpha1.ClusterPushSecretStatusCondition{
+			Type:   esv1alpha1.ClusterPushSecretReady,
+			Status: v1.ConditionTrue,
+		}
+	}
+
+	condition := &esv1alpha1.ClusterPushSecretStatusCondition{
+		Type:    esv1alpha1.ClusterPushSecretReady,
+		Status:  v1.ConditionFalse,
+		Message: errNamespacesFailed,
+	}
+
+	return condition
+}
+
+func SetClusterPushSecretCondition(cps *esv1alpha1.ClusterPushSecret, condition esv1alpha1.ClusterPushSecretStatusCondition) {
+	cps.Status.Conditions = append(filterOutCondition(cps.Status.Conditions, condition.Type), condition)
+	cpsmetrics.UpdateClusterPushSecretCondition(cps, &condition)
+}
+
+// filterOutCondition returns an empty set of conditions with the provided type.
+func filterOutCondition(conditions []esv1alpha1.ClusterPushSecretStatusCondition, condType esv1alpha1.ClusterPushSecretConditionType) []esv1alpha1.ClusterPushSecretStatusCondition {
+	newConditions := make([]esv1alpha1.ClusterPushSecretStatusCondition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Type == condType {
+			continue
+		}
+		newConditions = append(newConditions, c)
+	}
+	return newConditions
+}
@@ -29,6 +29,8 @@ const (
 	SyncCallsErrorKey                  = "sync_calls_error"
 	ExternalSecretStatusConditionKey   = "status_condition"
 	ExternalSecretReconcileDurationKey = "reconcile_duration"
+	SourceSecretCreatedKey             = "source_secret_created_timestamp_seconds"
+	SourceSecretExpiresKey             = "source_secret_expiry_timestamp_seconds"
 )
 
 var counterVecMetrics = map[string]*prometheus.CounterVec{}
@@ -63,7 +65,21 @@ func SetUpMetrics() {
 		Help:      "The duration time to reconcile the External Secret",
 	}, ctrlmetrics.NonConditionMetricLabelNames)
 
-	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition, externalSecretReconcileDuration)
+	sourceSecretLabelNames := append(append([]string{}, ctrlmetrics.NonConditionMetricLabelNames...), "key")
+	sourceSecretCreated := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      SourceSecretCreatedKey,
+		Help:      "The creation time of a provider secret fetched by the External Secret in seconds since the epoch",
+	}, sourceSecretLabelNames)
+
+	sourceSecretExpires := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      SourceSecretExpiresKey,
+		Help:      "The expiry time of a provider secret fetched by the External Secret in seconds since the epoch",
+	}, sourceSecretLabelNames)
+
+	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition, externalSecretReconcileDuration,
+		sourceSecretCreated, sourceSecretExpires)
 
 	counterVecMetrics = map[string]*prometheus.CounterVec{
 		SyncCallsKey:      syncCallsTotal,
@@ -73,6 +89,8 @@ func SetUpMetrics() {
 	gaugeVecMetrics = map[string]*prometheus.GaugeVec{
 		ExternalSecretStatusConditionKey:   externalSecretCondition,
 		ExternalSecretReconcileDurationKey: externalSecretReconcileDuration,
+		SourceSecretCreatedKey:             sourceSecretCreated,
+		SourceSecretExpiresKey:             sourceSecretExpires,
 	}
 }
 
@@ -146,6 +164,39 @@ func UpdateExternalSecretCondition(es *esv1beta1.ExternalSecret, condition *esv1
 		})).Set(value)
 }
 
+// UpdateSourceSecretLifetimes replaces the creation and expiry times of the provider secrets
+// published for the External Secret. Unknown times are not published.
+func UpdateSourceSecretLifetimes(es *esv1beta1.ExternalSecret, lifetimes []esv1beta1.SecretLifetime) {
+	RemoveSourceSecretLifetimes(es.Namespace, es.Name)
+
+	esInfo := make(map[string]string)
+	for k, v := range es.Labels {
+		esInfo[k] = v
+	}
+	esInfo["name"] = es.Name
+	esInfo["namespace"] = es.Namespace
+	for _, lifetime := range lifetimes {
+		labels := ctrlmetrics.RefineNonConditionMetricLabels(esInfo)
+		labels["key"] = lifetime.Key
+		if !lifetime.Created.IsZero() {
+			GetGaugeVec(SourceSecretCreatedKey).With(labels).Set(float64(lifetime.Created.Unix()))
+		}
+		if !lifetime.Expires.IsZero() {
+			GetGaugeVec(SourceSecretExpiresKey).With(labels).Set(float64(lifetime.Expires.Unix()))
+		}
+	}
+}
+
+// RemoveSourceSecretLifetimes deletes the creation and expiry times published for the External Secret.
+func RemoveSourceSecretLifetimes(namespace, name string) {
+	for _, key := range []string{SourceSecretCreatedKey, SourceSecretExpiresKey} {
+		GetGaugeVec(key).DeletePartialMatch(map[string]string{
+			"namespace": namespace,
+			"name":      name,
+		})
+	}
+}
+
 func GetCounterVec(key string) *prometheus.CounterVec {
 	return counterVecMetrics[key]
 }
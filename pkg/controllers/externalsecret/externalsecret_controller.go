@@ -17,8 +17,11 @@ package externalsecret
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -26,6 +29,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -44,6 +48,7 @@ import (
 	// Metrics.
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 
 	// Loading registered generators.
@@ -55,9 +60,19 @@ import (
 const (
 	fieldOwnerTemplate      = "externalsecrets.external-secrets.io/%v"
 	errGetES                = "could not get ExternalSecret"
+	errControlledByOther    = "target %s is controlled by %s %s, set the %s=true annotation on it to allow the ExternalSecret to adopt it"
+	msgAdopted              = "took over %s from %s"
+	msgSyncFailed           = "%s (sync id: %s)"
+	msgProtectionChanged    = "protection flags changed for keys: %s"
+	msgSecretExpiring       = "source secrets expire soon: %s"
+	msgThrottled            = "provider throttles requests, sync paused for %s: %s"
+	msgClusterStoreDisabled = "ClusterSecretStore %s is ignored, the controller does not process ClusterSecretStores"
 	errConvert              = "could not apply conversion strategy to keys: %v"
 	errDecode               = "could not apply decoding strategy to %v[%d]: %v"
+	errNormalize            = "could not apply normalization to %v[%d]: %v"
+	errFilter               = "could not filter spec.dataFrom[%d].extract: %w"
 	errGenerate             = "could not generate [%d]: %w"
+	errRotationOnly         = "could not generate [%d]: generator %s can only be used by a SecretRotation, it does not revoke the values it replaces"
 	errRewrite              = "could not rewrite spec.dataFrom[%d]: %v"
 	errInvalidKeys          = "secret keys from spec.dataFrom.%v[%d] can only have alphanumeric,'-', '_' or '.' characters. Convert them using rewrite (https://external-secrets.io/latest/guides-datafrom-rewrite)"
 	errUpdateSecret         = "could not update Secret"
@@ -76,7 +91,10 @@ const (
 	errPolicyMergePatch     = "unable to patch secret %s: %w"
 )
 
-const externalSecretSecretNameKey = ".spec.target.name"
+const (
+	externalSecretSecretNameKey = ".spec.target.name"
+	externalSecretStoreRefKey   = ".spec.secretStoreRef"
+)
 
 // Reconciler reconciles a ExternalSecret object.
 type Reconciler struct {
@@ -88,14 +106,45 @@ type Reconciler struct {
 	RequeueInterval           time.Duration
 	ClusterSecretStoreEnabled bool
 	EnableFloodGate           bool
-	recorder                  record.EventRecorder
+	// MaxSecretSize is the maximum size of the rendered data in bytes, defaults to the limit of the API server.
+	MaxSecretSize int
+	// MaxSecretKeys is the maximum number of keys of the rendered data, zero means no limit.
+	MaxSecretKeys int
+	// RetryInitialInterval is the interval after the first failed sync of an ExternalSecret with spec.retryPolicy,
+	// it is doubled after every consecutive failure up to RetryMaxInterval. Both default the intervals the policy doesn't set.
+	RetryInitialInterval time.Duration
+	RetryMaxInterval     time.Duration
+	// EventDedupWindow is the window identical events of an ExternalSecret are recorded at most once in,
+	// zero records every event.
+	EventDedupWindow time.Duration
+	// ManagerID is the identity the targets are stamped with, targets stamped with another identity
+	// are not updated unless ForceOwnership is set. An empty ManagerID disables the stamp and the check.
+	ManagerID      string
+	ForceOwnership bool
+	recorder       record.EventRecorder
+
+	// refreshRequests holds the ExternalSecrets that must be refreshed
+	// regardless of their refresh interval, e.g. because the credentials of their store have changed.
+	refreshRequests sync.Map
+
+	// sealedValues holds the sealed data of the ExternalSecrets with target.encryption,
+	// so unchanged values are not sealed again on every refresh.
+	sealedValues sync.Map
+
+	// failedVersions holds the resource versions of the ExternalSecrets whose last sync failed.
+	failedVersions sync.Map
+
+	// syncedValues holds the data written to the targets of the ExternalSecrets with driftCheckInterval,
+	// so local changes of the targets are reverted without reading the provider again.
+	syncedValues sync.Map
 }
 
 // Reconcile implements the main reconciliation loop
 // for watched objects (ExternalSecret, ClusterSecretStore and SecretStore),
 // and updates/creates a Kubernetes secret based on them.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	log := r.Log.WithValues("ExternalSecret", req.NamespacedName)
+	syncID := syncIDFromContext(ctx)
+	log := r.Log.WithValues("ExternalSecret", req.NamespacedName, "syncID", syncID)
 
 	resourceLabels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": req.Name, "namespace": req.Namespace})
 	start := time.Now()
@@ -105,7 +154,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	// use closures to dynamically update resourceLabels
 	defer func() {
 		esmetrics.GetGaugeVec(esmetrics.ExternalSecretReconcileDurationKey).With(resourceLabels).Set(float64(time.Since(start)))
-		esmetrics.GetCounterVec(esmetrics.SyncCallsKey).With(resourceLabels).Inc()
+		ctrlmetrics.IncWithSyncID(esmetrics.GetCounterVec(esmetrics.SyncCallsKey).With(resourceLabels), syncID)
 	}()
 
 	var externalSecret esv1beta1.ExternalSecret
@@ -120,12 +169,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 					Namespace: req.Namespace,
 				},
 			}, *conditionSynced)
+			esmetrics.RemoveSourceSecretLifetimes(req.Namespace, req.Name)
+			r.sealedValues.Delete(req.NamespacedName)
+			r.failedVersions.Delete(req.NamespacedName)
+			r.syncedValues.Delete(req.NamespacedName)
 
 			return ctrl.Result{}, nil
 		}
 
 		log.Error(err, errGetES)
-		syncCallsError.With(resourceLabels).Inc()
+		ctrlmetrics.IncWithSyncID(syncCallsError.With(resourceLabels), syncID)
 
 		return ctrl.Result{}, err
 	}
@@ -156,6 +209,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	if shouldSkipClusterSecretStore(r, externalSecret) {
 		log.Info("skipping cluster secret store as it is disabled")
+		r.recordClusterSecretStoreDisabled(&externalSecret)
 		return ctrl.Result{}, nil
 	}
 
@@ -179,21 +233,59 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	// fetch external secret, we need to ensure that it exists, and it's hashmap corresponds
 	var existingSecret v1.Secret
-	err = r.Get(ctx, types.NamespacedName{
-		Name:      secretName,
-		Namespace: externalSecret.Namespace,
-	}, &existingSecret)
-	if err != nil && !apierrors.IsNotFound(err) {
-		log.Error(err, errGetExistingSecret)
-		return ctrl.Result{}, err
+	var existingConfigMap v1.ConfigMap
+	var targetValid bool
+	if isConfigMapTarget(&externalSecret) {
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      secretName,
+			Namespace: externalSecret.Namespace,
+		}, &existingConfigMap)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, errGetExistingConfigMap)
+			return ctrl.Result{}, err
+		}
+		targetValid = isConfigMapValid(existingConfigMap)
+	} else {
+		// a rotated secret is stored in the current generation
+		existingName := secretName
+		if isRotationTarget(&externalSecret) && externalSecret.Status.Binding.Name != "" {
+			existingName = externalSecret.Status.Binding.Name
+		}
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      existingName,
+			Namespace: externalSecret.Namespace,
+		}, &existingSecret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, errGetExistingSecret)
+			return ctrl.Result{}, err
+		}
+		targetValid = isSecretValid(existingSecret)
+	}
+
+	// back off after failed syncs
+	if retryIn := r.retryBackoff(req.NamespacedName, &externalSecret); retryIn > 0 {
+		log.V(1).Info("backing off after failed sync", "failures", externalSecret.Status.Failures, "retryIn", retryIn)
+		return ctrl.Result{RequeueAfter: retryIn}, nil
+	}
+
+	// a target changed locally is reverted to the data of the last sync, unless a refresh is due anyway
+	if !targetValid && !shouldRefresh(externalSecret) {
+		repaired, err := r.repairDrift(ctx, &externalSecret, &existingSecret)
+		if err != nil {
+			log.Error(err, errRepairDrift)
+		}
+		targetValid = repaired
 	}
 
 	// refresh should be skipped if
 	// 1. resource generation hasn't changed
 	// 2. refresh interval is 0
 	// 3. if we're still within refresh-interval
-	if !shouldRefresh(externalSecret) && isSecretValid(existingSecret) {
+	// 4. the credentials of the store haven't changed
+	// a dry run does not write the target, so its validity is not checked
+	if !r.refreshRequested(req.NamespacedName, externalSecret) && !shouldRefresh(externalSecret) && (targetValid || isDryRun(&externalSecret)) {
 		refreshInt = (externalSecret.Spec.RefreshInterval.Duration - timeSinceLastRefresh) + 5*time.Second
+		refreshInt = requeueAfter(&externalSecret, refreshInt)
 		log.V(1).Info("skipping refresh", "rv", getResourceVersion(externalSecret), "nr", refreshInt.Seconds())
 		return ctrl.Result{RequeueAfter: refreshInt}, nil
 	}
@@ -220,10 +312,44 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		Data:      make(map[string][]byte),
 	}
 
-	dataMap, err := r.getProviderSecretData(ctx, &externalSecret)
+	var existingTarget metav1.Object = &existingSecret
+	if isConfigMapTarget(&externalSecret) {
+		existingTarget = &existingConfigMap
+	}
+	if err := r.checkManager(existingTarget); err != nil {
+		r.markAsFailed(log, syncID, errCheckManager, err, &externalSecret, syncCallsError.With(resourceLabels))
+		return failedResult(&externalSecret, err)
+	}
+
+	dataMap, metadata, err := r.getProviderSecretData(ctx, &externalSecret)
+	var throttledErr esv1beta1.ThrottledError
+	if errors.As(err, &throttledErr) {
+		// the target is still valid, retrying now would only extend the throttling
+		r.markAsThrottled(log, &externalSecret, throttledErr)
+		return ctrl.Result{RequeueAfter: throttledErr.RetryAfter}, nil
+	}
 	if err != nil {
-		r.markAsFailed(log, errGetSecretData, err, &externalSecret, syncCallsError.With(resourceLabels))
-		return ctrl.Result{}, err
+		r.markAsFailed(log, syncID, errGetSecretData, err, &externalSecret, syncCallsError.With(resourceLabels))
+		return failedResult(&externalSecret, err)
+	}
+
+	existingData := existingSecret.Data
+	if isConfigMapTarget(&externalSecret) {
+		existingData = configMapData(&existingConfigMap)
+	}
+	if err := keepUnchangedValues(&externalSecret, dataMap, existingData); err != nil {
+		r.markAsFailed(log, syncID, errGetSecretData, err, &externalSecret, syncCallsError.With(resourceLabels))
+		return failedResult(&externalSecret, err)
+	}
+
+	if isDryRun(&externalSecret) {
+		if err := r.dryRun(ctx, &externalSecret, secret, dataMap, metadata, &existingSecret, &existingConfigMap); err != nil {
+			r.markAsFailed(log, syncID, errDryRun, err, &externalSecret, syncCallsError.With(resourceLabels))
+			return failedResult(&externalSecret, err)
+		}
+		externalSecret.Status.RefreshTime = metav1.NewTime(start)
+		externalSecret.Status.SyncedResourceVersion = getResourceVersion(externalSecret)
+		return ctrl.Result{RequeueAfter: refreshInt}, nil
 	}
 
 	// if no data was found we can delete the secret if needed.
@@ -235,13 +361,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			// this is also implemented in the es validation webhook
 			if externalSecret.Spec.Target.CreationPolicy != esv1beta1.CreatePolicyOwner {
 				err := fmt.Errorf(errInvalidCreatePolicy, externalSecret.Spec.Target.CreationPolicy)
-				r.markAsFailed(log, errDeleteSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
-				return ctrl.Result{}, err
+				r.markAsFailed(log, syncID, errDeleteSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
+				return failedResult(&externalSecret, err)
 			}
 
-			if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
-				r.markAsFailed(log, errDeleteSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
-				return ctrl.Result{}, err
+			var target client.Object = secret
+			if isConfigMapTarget(&externalSecret) {
+				target = &v1.ConfigMap{ObjectMeta: secret.ObjectMeta}
+			}
+			if isRotationTarget(&externalSecret) && externalSecret.Status.Binding.Name != "" {
+				target.SetName(externalSecret.Status.Binding.Name)
+			}
+			if err := r.Delete(ctx, target); err != nil && !apierrors.IsNotFound(err) {
+				r.markAsFailed(log, syncID, errDeleteSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
+				return failedResult(&externalSecret, err)
 			}
 
 			conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonSecretDeleted, "secret deleted due to DeletionPolicy")
@@ -252,12 +385,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			r.markAsDone(&externalSecret, start, log)
 			return ctrl.Result{RequeueAfter: refreshInt}, nil
 		// noop, handled below
-		case esv1beta1.DeletionPolicyMerge:
+		case esv1beta1.DeletionPolicyMerge, esv1beta1.DeletionPolicyArchive:
 		}
 	}
 
+	var adoptedFrom string
+	var staleKeys map[string][]byte
+	var chunks []string
 	mutationFunc := func() error {
 		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+			adoptedFrom, err = adoptTarget(secret, &externalSecret)
+			if err != nil {
+				return err
+			}
 			err = controllerutil.SetControllerReference(&externalSecret, &secret.ObjectMeta, r.Scheme)
 			if err != nil {
 				return fmt.Errorf(errSetCtrlReference, err)
@@ -267,7 +407,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			secret.Data = make(map[string][]byte)
 		}
 		// diff existing keys
-		keys, err := getManagedDataKeys(&existingSecret, externalSecret.Name)
+		keys, err := managedKeys(&existingSecret, externalSecret.Name)
 		if err != nil {
 			return err
 		}
@@ -279,23 +419,82 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 				delete(secret.Data, key)
 			}
 		}
-		err = r.applyTemplate(ctx, &externalSecret, secret, dataMap)
+		// a sealed secret only holds the sealed rendered values, the sealed existing values must not be merged
+		if isSealedTarget(&externalSecret) {
+			secret.Data = make(map[string][]byte)
+		}
+		err = r.applyTemplate(ctx, &externalSecret, secret, dataMap, metadata)
 		if err != nil {
 			return fmt.Errorf(errApplyTemplate, err)
 		}
+		if err := r.validateSecretValues(&externalSecret, secret.Data); err != nil {
+			return err
+		}
+		if isSealedTarget(&externalSecret) {
+			if err := r.sealSecretData(&externalSecret, secret, &existingSecret); err != nil {
+				return err
+			}
+		}
+		delete(secret.Annotations, esv1beta1.AnnotationChunks)
+		if isChunkedTarget(&externalSecret) {
+			chunks, err = r.chunkSecretData(ctx, &externalSecret, secret)
+			if err != nil {
+				return err
+			}
+		}
+		if err := r.validateSecretLimits(secret.Data); err != nil {
+			return err
+		}
 		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
 			lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", externalSecret.Namespace, externalSecret.Name))
 			secret.Labels[esv1beta1.LabelOwner] = lblValue
 		}
+		// archive the removed keys before the secret is updated, so their values can't get lost
+		if externalSecret.Spec.Target.DeletionPolicy == esv1beta1.DeletionPolicyArchive {
+			err = r.archiveKeys(ctx, &externalSecret, secret.Name, removedKeys(&existingSecret, keys, secret))
+			if err != nil {
+				return err
+			}
+		}
 
-		secret.Annotations[esv1beta1.AnnotationDataHash] = r.computeDataHashAnnotation(&existingSecret, secret)
+		if externalSecret.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyMerge {
+			staleKeys = removedKeys(&existingSecret, keys, secret)
+			secret.Annotations[esv1beta1.AnnotationManagedKeys] = managedKeysAnnotation(secret.Data)
+		}
+
+		secret.Annotations[esv1beta1.AnnotationDataHash] = r.computeDataHashAnnotation(&existingSecret, secret, staleKeys)
+		r.setManager(secret)
 
 		return nil
 	}
 
+	if isConfigMapTarget(&externalSecret) {
+		if err := r.syncConfigMap(ctx, &externalSecret, secret, dataMap, metadata); err != nil {
+			r.markAsFailed(log, syncID, errUpdateConfigMap, err, &externalSecret, syncCallsError.With(resourceLabels))
+			return failedResult(&externalSecret, err)
+		}
+		r.markAsDone(&externalSecret, start, log)
+		return ctrl.Result{RequeueAfter: refreshInt}, nil
+	}
+
+	if isRotationTarget(&externalSecret) {
+		if err := r.rotateSecret(ctx, &externalSecret, secret, dataMap, metadata); err != nil {
+			r.markAsFailed(log, syncID, errRotateSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
+			return failedResult(&externalSecret, err)
+		}
+		r.markAsDone(&externalSecret, start, log)
+		return ctrl.Result{RequeueAfter: requeueAfter(&externalSecret, refreshInt)}, nil
+	}
+
 	switch externalSecret.Spec.Target.CreationPolicy { //nolint:exhaustive
 	case esv1beta1.CreatePolicyMerge:
 		err = r.patchSecret(ctx, secret, mutationFunc, &externalSecret)
+		if err == nil {
+			err = r.removeSecretKeys(ctx, secret, staleKeys)
+		}
+		if err == nil {
+			err = r.releasePreviousTarget(ctx, &externalSecret, secret.Name)
+		}
 		if err == nil {
 			externalSecret.Status.Binding = v1.LocalObjectReference{Name: secret.Name}
 		}
@@ -305,38 +504,65 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	default:
 		var created bool
 		created, err = r.createOrUpdateSecret(ctx, secret, mutationFunc, &externalSecret)
+		// the chunks of the previous index are pruned once the secret no longer refers to them
+		if err == nil && (isChunkedTarget(&externalSecret) || existingSecret.Annotations[esv1beta1.AnnotationChunks] != "") {
+			err = r.pruneChunks(ctx, &externalSecret, chunks)
+		}
+		if err == nil {
+			err = r.releasePreviousTarget(ctx, &externalSecret, secret.Name)
+		}
 		if err == nil {
 			externalSecret.Status.Binding = v1.LocalObjectReference{Name: secret.Name}
+			r.markAsAdopted(&externalSecret, "Secret "+secret.Name, adoptedFrom)
+			r.recordSyncedData(&externalSecret, secret)
 		}
 		// cleanup orphaned secrets
 		if created {
-			delErr := deleteOrphanedSecrets(ctx, r.Client, &externalSecret)
+			delErr := deleteOrphanedObjects(ctx, r.Client, &externalSecret, &v1.SecretList{})
 			if delErr != nil {
 				msg := fmt.Sprintf("failed to clean up orphaned secrets: %v", delErr)
-				r.markAsFailed(log, msg, delErr, &externalSecret, syncCallsError.With(resourceLabels))
-				return ctrl.Result{}, delErr
+				r.markAsFailed(log, syncID, msg, delErr, &externalSecret, syncCallsError.With(resourceLabels))
+				return failedResult(&externalSecret, delErr)
 			}
 		}
 	}
 
 	if err != nil {
-		r.markAsFailed(log, errUpdateSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
-		return ctrl.Result{}, err
+		r.markAsFailed(log, syncID, errUpdateSecret, err, &externalSecret, syncCallsError.With(resourceLabels))
+		return failedResult(&externalSecret, err)
 	}
 
 	r.markAsDone(&externalSecret, start, log)
 
 	return ctrl.Result{
-		RequeueAfter: refreshInt,
+		RequeueAfter: requeueAfter(&externalSecret, refreshInt),
 	}, nil
 }
 
+// requeueAfter shortens the requeue interval to the drift check interval and the renewal time of generated values.
+func requeueAfter(es *esv1beta1.ExternalSecret, interval time.Duration) time.Duration {
+	if drift := driftCheckInterval(es); drift > 0 && (interval <= 0 || drift < interval) {
+		interval = drift
+	}
+	if es.Status.RenewalTime == nil {
+		return interval
+	}
+	untilRenewal := max(time.Until(es.Status.RenewalTime.Time), time.Second)
+	if interval <= 0 || untilRenewal < interval {
+		return untilRenewal
+	}
+	return interval
+}
+
 func (r *Reconciler) markAsDone(externalSecret *esv1beta1.ExternalSecret, start time.Time, log logr.Logger) {
 	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonSecretSynced, "Secret was synced")
 	currCond := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretReady)
 	SetExternalSecretCondition(externalSecret, *conditionSynced)
 	externalSecret.Status.RefreshTime = metav1.NewTime(start)
 	externalSecret.Status.SyncedResourceVersion = getResourceVersion(*externalSecret)
+	externalSecret.Status.DryRun = nil
+	externalSecret.Status.Conditions = filterOutCondition(externalSecret.Status.Conditions, esv1beta1.ExternalSecretThrottled)
+	r.resetRetries(externalSecret)
 	if currCond == nil || currCond.Status != conditionSynced.Status {
 		log.Info("reconciled secret") // Log once if on success in any verbosity
 	} else {
@@ -344,16 +570,95 @@ func (r *Reconciler) markAsDone(externalSecret *esv1beta1.ExternalSecret, start
 	}
 }
 
-func (r *Reconciler) markAsFailed(log logr.Logger, msg string, err error, externalSecret *esv1beta1.ExternalSecret, counter prometheus.Counter) {
+// markAsAdopted records the takeover of the target from its previous controller.
+func (r *Reconciler) markAsAdopted(externalSecret *esv1beta1.ExternalSecret, target, previous string) {
+	if previous == "" {
+		return
+	}
+	msg := fmt.Sprintf(msgAdopted, target, previous)
+	r.recorder.Event(externalSecret, v1.EventTypeNormal, esv1beta1.ConditionReasonTargetAdopted, msg)
+	condition := NewExternalSecretCondition(esv1beta1.ExternalSecretAdopted, v1.ConditionTrue, esv1beta1.ConditionReasonTargetAdopted, msg)
+	SetExternalSecretCondition(externalSecret, *condition)
+}
+
+// markAsThrottled raises the Throttled condition, the Ready condition is kept
+// as the target still holds the values of the last sync.
+func (r *Reconciler) markAsThrottled(log logr.Logger, externalSecret *esv1beta1.ExternalSecret, err esv1beta1.ThrottledError) {
+	msg := fmt.Sprintf(msgThrottled, err.RetryAfter.Round(time.Second), err.Message)
+	log.Info("provider throttles requests", "retryAfter", err.RetryAfter, "reason", err.Message)
+	current := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretThrottled)
+	if current == nil {
+		r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ConditionReasonProviderThrottled, msg)
+	}
+	condition := NewExternalSecretCondition(esv1beta1.ExternalSecretThrottled, v1.ConditionTrue, esv1beta1.ConditionReasonProviderThrottled, msg)
+	SetExternalSecretCondition(externalSecret, *condition)
+}
+
+// markAsFailed sets the Ready condition to false, the message contains the sync ID
+// to correlate the condition with the logs and the exemplar of the error counter.
+// The next attempt is scheduled with the backoff of the consecutive failures.
+func (r *Reconciler) markAsFailed(log logr.Logger, syncID, msg string, err error, externalSecret *esv1beta1.ExternalSecret, counter prometheus.Counter) {
 	log.Error(err, msg)
 	r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, err.Error())
-	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, esv1beta1.ConditionReasonSecretSyncedError, msg)
+	reason := esv1beta1.ConditionReasonSecretSyncedError
+	// the limit and validation errors are precise enough to be shown in the status
+	var limitErr *secretLimitError
+	if errors.As(err, &limitErr) {
+		reason, msg = esv1beta1.ConditionReasonSecretLimitExceeded, limitErr.Error()
+	}
+	var validationErr *secretValidationError
+	if errors.As(err, &validationErr) {
+		reason, msg = esv1beta1.ConditionReasonSecretValidationFailed, validationErr.Error()
+	}
+	conditionSynced := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionFalse, reason, fmt.Sprintf(msgSyncFailed, msg, syncID))
 	SetExternalSecretCondition(externalSecret, *conditionSynced)
-	counter.Inc()
+	r.scheduleRetry(externalSecret)
+	ctrlmetrics.IncWithSyncID(counter, syncID)
+}
+
+// updateSecretProtections records the protection flags reported by the providers
+// and raises the ProtectionChanged condition if they differ from the previous sync.
+func (r *Reconciler) updateSecretProtections(externalSecret *esv1beta1.ExternalSecret, protections []esv1beta1.SecretProtectionStatus) {
+	if len(protections) == 0 {
+		externalSecret.Status.SecretProtections = nil
+		externalSecret.Status.Conditions = filterOutCondition(externalSecret.Status.Conditions, esv1beta1.ExternalSecretProtectionChanged)
+		return
+	}
+	sort.Slice(protections, func(i, j int) bool {
+		return protections[i].Key < protections[j].Key
+	})
+	changed := changedProtectionKeys(externalSecret.Status.SecretProtections, protections)
+	externalSecret.Status.SecretProtections = protections
+	if len(changed) == 0 {
+		condition := NewExternalSecretCondition(esv1beta1.ExternalSecretProtectionChanged, v1.ConditionFalse, esv1beta1.ConditionReasonProtectionUnchanged, "protection flags are unchanged")
+		SetExternalSecretCondition(externalSecret, *condition)
+		return
+	}
+	msg := fmt.Sprintf(msgProtectionChanged, strings.Join(changed, ", "))
+	r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ConditionReasonProtectionChanged, msg)
+	condition := NewExternalSecretCondition(esv1beta1.ExternalSecretProtectionChanged, v1.ConditionTrue, esv1beta1.ConditionReasonProtectionChanged, msg)
+	SetExternalSecretCondition(externalSecret, *condition)
 }
 
-func deleteOrphanedSecrets(ctx context.Context, cl client.Client, externalSecret *esv1beta1.ExternalSecret) error {
-	secretList := v1.SecretList{}
+// updateSecretExpirations raises the Expiring condition if the providers report
+// that source secrets expire soon, a warning event is emitted when the expirations change.
+func (r *Reconciler) updateSecretExpirations(externalSecret *esv1beta1.ExternalSecret, expirations []esv1beta1.SecretExpiration) {
+	if len(expirations) == 0 {
+		externalSecret.Status.Conditions = filterOutCondition(externalSecret.Status.Conditions, esv1beta1.ExternalSecretExpiring)
+		return
+	}
+	msg := fmt.Sprintf(msgSecretExpiring, secretstore.DescribeExpirations(expirations))
+	current := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretExpiring)
+	if current == nil || current.Message != msg {
+		r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ConditionReasonSecretExpiring, msg)
+	}
+	condition := NewExternalSecretCondition(esv1beta1.ExternalSecretExpiring, v1.ConditionTrue, esv1beta1.ConditionReasonSecretExpiring, msg)
+	SetExternalSecretCondition(externalSecret, *condition)
+}
+
+// deleteOrphanedObjects deletes the objects of the list type that are owned by the ExternalSecret
+// but do not match the target name anymore.
+func deleteOrphanedObjects(ctx context.Context, cl client.Client, externalSecret *esv1beta1.ExternalSecret, list client.ObjectList) error {
 	lblValue := utils.ObjectHash(fmt.Sprintf("%v/%v", externalSecret.Namespace, externalSecret.Name))
 	ls := &metav1.LabelSelector{
 		MatchLabels: map[string]string{
@@ -364,13 +669,21 @@ func deleteOrphanedSecrets(ctx context.Context, cl client.Client, externalSecret
 	if err != nil {
 		return err
 	}
-	err = cl.List(ctx, &secretList, &client.ListOptions{LabelSelector: labelSelector})
+	err = cl.List(ctx, list, &client.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
 	if err != nil {
 		return err
 	}
-	for key, secret := range secretList.Items {
-		if externalSecret.Spec.Target.Name != "" && secret.Name != externalSecret.Spec.Target.Name {
-			err = cl.Delete(ctx, &secretList.Items[key])
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		if externalSecret.Spec.Target.Name != "" && obj.GetName() != externalSecret.Spec.Target.Name {
+			err = cl.Delete(ctx, obj)
 			if err != nil {
 				return err
 			}
@@ -474,13 +787,13 @@ func getManagedDataKeys(secret *v1.Secret, fieldOwner string) ([]string, error)
 }
 
 func getManagedFieldKeys(
-	secret *v1.Secret,
+	obj metav1.Object,
 	fieldOwner string,
 	process func(fields map[string]any) []string,
 ) ([]string, error) {
 	fqdn := fmt.Sprintf(fieldOwnerTemplate, fieldOwner)
 	var keys []string
-	for _, v := range secret.ObjectMeta.ManagedFields {
+	for _, v := range obj.GetManagedFields() {
 		if v.Manager != fqdn {
 			continue
 		}
@@ -515,7 +828,39 @@ func hashMeta(m metav1.ObjectMeta) string {
 }
 
 func shouldSkipClusterSecretStore(r *Reconciler, es esv1beta1.ExternalSecret) bool {
-	return !r.ClusterSecretStoreEnabled && es.Spec.SecretStoreRef.Kind == esv1beta1.ClusterSecretStoreKind
+	return !r.ClusterSecretStoreEnabled && clusterSecretStoreRef(es) != ""
+}
+
+// clusterSecretStoreRef returns the name of the first ClusterSecretStore referenced by the ExternalSecret,
+// including the store references of the data and dataFrom entries, or an empty string if there is none.
+func clusterSecretStoreRef(es esv1beta1.ExternalSecret) string {
+	refs := append([]esv1beta1.SecretStoreRef{es.Spec.SecretStoreRef}, es.Spec.SecretStoreRefs...)
+	for _, data := range es.Spec.Data {
+		if data.SourceRef != nil {
+			refs = append(refs, data.SourceRef.SecretStoreRef)
+		}
+	}
+	for _, dataFrom := range es.Spec.DataFrom {
+		if dataFrom.SourceRef != nil && dataFrom.SourceRef.SecretStoreRef != nil {
+			refs = append(refs, *dataFrom.SourceRef.SecretStoreRef)
+		}
+	}
+	for _, ref := range refs {
+		if ref.Kind == esv1beta1.ClusterSecretStoreKind {
+			return ref.Name
+		}
+	}
+	return ""
+}
+
+// recordClusterSecretStoreDisabled tells why an ExternalSecret using a ClusterSecretStore is not synced.
+// ExternalSecrets with a Ready condition are synced by another controller, e.g. one processing
+// the ClusterSecretStores of the cluster, the event is only recorded for ExternalSecrets without it.
+func (r *Reconciler) recordClusterSecretStoreDisabled(es *esv1beta1.ExternalSecret) {
+	if GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady) != nil {
+		return
+	}
+	r.recorder.Eventf(es, v1.EventTypeWarning, esv1beta1.ReasonClusterSecretStoreDisabled, msgClusterStoreDisabled, clusterSecretStoreRef(*es))
 }
 
 // shouldSkipUnmanagedStore iterates over all secretStore references in the externalSecret spec,
@@ -527,6 +872,7 @@ func shouldSkipUnmanagedStore(ctx context.Context, namespace string, r *Reconcil
 	if es.Spec.SecretStoreRef.Name != "" {
 		storeList = append(storeList, es.Spec.SecretStoreRef)
 	}
+	storeList = append(storeList, es.Spec.SecretStoreRefs...)
 
 	for _, ref := range es.Spec.Data {
 		if ref.SourceRef != nil {
@@ -587,6 +933,11 @@ func shouldRefresh(es esv1beta1.ExternalSecret) bool {
 		return true
 	}
 
+	// renew generated values before they expire
+	if es.Status.RenewalTime != nil && !es.Status.RenewalTime.After(time.Now()) {
+		return true
+	}
+
 	// skip refresh if refresh interval is 0
 	if es.Spec.RefreshInterval.Duration == 0 && es.Status.SyncedResourceVersion != "" {
 		return false
@@ -598,7 +949,8 @@ func shouldRefresh(es esv1beta1.ExternalSecret) bool {
 }
 
 func shouldReconcile(es esv1beta1.ExternalSecret) bool {
-	if es.Spec.Target.Immutable && hasSyncedCondition(es) {
+	// immutable secrets with a rotation are replaced instead of updated
+	if es.Spec.Target.Immutable && es.Spec.Target.Rotation == nil && hasSyncedCondition(es) {
 		return false
 	}
 	return true
@@ -628,9 +980,13 @@ func isSecretValid(existingSecret v1.Secret) bool {
 }
 
 // computeDataHashAnnotation generate a hash of the secret data combining the old key with the new keys to add or override.
-func (r *Reconciler) computeDataHashAnnotation(existing, secret *v1.Secret) string {
+// The removed keys are not part of the hash.
+func (r *Reconciler) computeDataHashAnnotation(existing, secret *v1.Secret, removed map[string][]byte) string {
 	data := make(map[string][]byte)
 	for k, v := range existing.Data {
+		if _, ok := removed[k]; ok {
+			continue
+		}
 		data[k] = v
 	}
 	for k, v := range secret.Data {
@@ -642,6 +998,13 @@ func (r *Reconciler) computeDataHashAnnotation(existing, secret *v1.Secret) stri
 // SetupWithManager returns a new controller builder that will be started by the provided Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
 	r.recorder = mgr.GetEventRecorderFor("external-secrets")
+	if r.EventDedupWindow > 0 {
+		aggregator := newEventAggregator(r.recorder, r.EventDedupWindow)
+		if err := mgr.Add(aggregator); err != nil {
+			return err
+		}
+		r.recorder = aggregator
+	}
 
 	// Index .Spec.Target.Name to reconcile ExternalSecrets effectively when secrets have changed
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &esv1beta1.ExternalSecret{}, externalSecretSecretNameKey, func(obj client.Object) []string {
@@ -655,6 +1018,22 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options)
 		return err
 	}
 
+	// Index the store references and the secrets referenced by the stores
+	// to refresh ExternalSecrets when the credentials of their store have changed
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &esv1beta1.ExternalSecret{}, externalSecretStoreRefKey, func(obj client.Object) []string {
+		return storeRefIndexValues(obj.(*esv1beta1.ExternalSecret))
+	}); err != nil {
+		return err
+	}
+	if err := secretstore.IndexReferencedSecrets(context.Background(), mgr.GetFieldIndexer(), &esv1beta1.SecretStore{}); err != nil {
+		return err
+	}
+	if r.ClusterSecretStoreEnabled {
+		if err := secretstore.IndexReferencedSecrets(context.Background(), mgr.GetFieldIndexer(), &esv1beta1.ClusterSecretStore{}); err != nil {
+			return err
+		}
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&esv1beta1.ExternalSecret{}).
@@ -665,6 +1044,12 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options)
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 			builder.OnlyMetadata,
 		).
+		Watches(
+			&v1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForConfigMap),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+			builder.OnlyMetadata,
+		).
 		Complete(r)
 }
 
@@ -689,5 +1074,49 @@ func (r *Reconciler) findObjectsForSecret(ctx context.Context, secret client.Obj
 			},
 		}
 	}
+	return append(requests, r.findObjectsForStoreSecret(ctx, secret)...)
+}
+
+// findObjectsForStoreSecret returns the ExternalSecrets using a store that references the secret,
+// e.g. as credentials, and marks them to be refreshed.
+func (r *Reconciler) findObjectsForStoreSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	kinds := []string{esv1beta1.SecretStoreKind}
+	if r.ClusterSecretStoreEnabled {
+		kinds = append(kinds, esv1beta1.ClusterSecretStoreKind)
+	}
+
+	var requests []reconcile.Request
+	for _, kind := range kinds {
+		stores, err := secretstore.FindStoresForSecret(ctx, r.Client, secret, kind)
+		if err != nil {
+			r.Log.Error(err, "unable to find stores for secret", "secret", client.ObjectKeyFromObject(secret))
+			continue
+		}
+		for _, store := range stores {
+			opts := []client.ListOption{client.MatchingFields{externalSecretStoreRefKey: kind + "/" + store.GetName()}}
+			if kind == esv1beta1.SecretStoreKind {
+				opts = append(opts, client.InNamespace(store.GetNamespace()))
+			}
+			var externalSecrets esv1beta1.ExternalSecretList
+			if err := r.List(ctx, &externalSecrets, opts...); err != nil {
+				r.Log.Error(err, "unable to list ExternalSecrets for store", "store", store.GetName(), "kind", kind)
+				continue
+			}
+			for i := range externalSecrets.Items {
+				name := client.ObjectKeyFromObject(&externalSecrets.Items[i])
+				r.refreshRequests.Store(name, struct{}{})
+				requests = append(requests, reconcile.Request{NamespacedName: name})
+			}
+		}
+	}
 	return requests
 }
+
+// refreshRequested returns true once after the credentials of the store of the ExternalSecret have changed.
+// ExternalSecrets with a refresh interval of 0 are not refreshed.
+func (r *Reconciler) refreshRequested(name types.NamespacedName, es esv1beta1.ExternalSecret) bool {
+	if _, ok := r.refreshRequests.LoadAndDelete(name); !ok {
+		return false
+	}
+	return es.Spec.RefreshInterval == nil || es.Spec.RefreshInterval.Duration != 0
+}
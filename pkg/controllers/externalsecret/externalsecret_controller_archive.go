@@ -0,0 +1,130 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	defaultArchiveRetention = 5
+	archiveNameTemplate     = "%s-archive-%s"
+	archiveTimeFormat       = "20060102-150405"
+
+	errCreateArchive = "could not create archive secret %s: %w"
+	errPruneArchive  = "could not prune archive secrets: %w"
+	msgArchived      = "archived removed keys %v to secret %s"
+)
+
+// removedKeys returns the keys the ExternalSecret wrote to the existing secret
+// that are not part of the updated secret anymore.
+func removedKeys(existing *v1.Secret, managedKeys []string, updated *v1.Secret) map[string][]byte {
+	removed := make(map[string][]byte)
+	for _, key := range managedKeys {
+		if _, ok := updated.Data[key]; ok {
+			continue
+		}
+		if val, ok := existing.Data[key]; ok {
+			removed[key] = val
+		}
+	}
+	return removed
+}
+
+// archiveKeys moves the removed keys to a new archive secret and prunes
+// the archive secrets exceeding the retention of the ExternalSecret.
+func (r *Reconciler) archiveKeys(ctx context.Context, es *esv1beta1.ExternalSecret, target string, removed map[string][]byte) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	immutable := true
+	archive := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(archiveNameTemplate, target, time.Now().UTC().Format(archiveTimeFormat)),
+			Namespace: es.Namespace,
+			Labels: map[string]string{
+				esv1beta1.LabelArchiveOf: archiveLabelValue(es),
+			},
+			Annotations: map[string]string{
+				esv1beta1.AnnotationArchivedFrom: target,
+			},
+		},
+		Immutable: &immutable,
+		Data:      removed,
+	}
+	if err := r.Create(ctx, archive); err != nil {
+		return fmt.Errorf(errCreateArchive, archive.Name, err)
+	}
+
+	keys := make([]string, 0, len(removed))
+	for k := range removed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonDeleted, fmt.Sprintf(msgArchived, keys, archive.Name))
+
+	if err := r.pruneArchives(ctx, es); err != nil {
+		return fmt.Errorf(errPruneArchive, err)
+	}
+	return nil
+}
+
+// pruneArchives deletes the oldest archive secrets exceeding the retention.
+func (r *Reconciler) pruneArchives(ctx context.Context, es *esv1beta1.ExternalSecret) error {
+	retention := defaultArchiveRetention
+	if es.Spec.Target.Archive != nil && es.Spec.Target.Archive.Retention > 0 {
+		retention = es.Spec.Target.Archive.Retention
+	}
+
+	var archives v1.SecretList
+	err := r.List(ctx, &archives, client.InNamespace(es.Namespace), client.MatchingLabels{
+		esv1beta1.LabelArchiveOf: archiveLabelValue(es),
+	})
+	if err != nil {
+		return err
+	}
+	if len(archives.Items) <= retention {
+		return nil
+	}
+
+	sort.Slice(archives.Items, func(i, j int) bool {
+		ti, tj := archives.Items[i].CreationTimestamp, archives.Items[j].CreationTimestamp
+		if ti.Equal(&tj) {
+			return archives.Items[i].Name < archives.Items[j].Name
+		}
+		return ti.Before(&tj)
+	})
+	for i := range archives.Items[:len(archives.Items)-retention] {
+		if err := r.Delete(ctx, &archives.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func archiveLabelValue(es *esv1beta1.ExternalSecret) string {
+	return utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name))
+}
@@ -0,0 +1,121 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestRemovedKeys(t *testing.T) {
+	existing := &v1.Secret{Data: map[string][]byte{
+		"kept":      []byte("1"),
+		"removed":   []byte("2"),
+		"unmanaged": []byte("3"),
+	}}
+	updated := &v1.Secret{Data: map[string][]byte{
+		"kept":      []byte("1"),
+		"unmanaged": []byte("3"),
+	}}
+
+	got := removedKeys(existing, []string{"kept", "removed", "unknown"}, updated)
+	want := map[string][]byte{"removed": []byte("2")}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("removedKeys() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestArchiveKeys(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				DeletionPolicy: esv1beta1.DeletionPolicyArchive,
+				Archive:        &esv1beta1.ExternalSecretArchive{Retention: 2},
+			},
+		},
+	}
+	now := time.Now()
+	archive := func(name string, age time.Duration) client.Object {
+		return &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			Labels:            map[string]string{esv1beta1.LabelArchiveOf: archiveLabelValue(es)},
+		}}
+	}
+	r := newFakeReconciler(
+		archive("target-archive-oldest", 3*time.Hour),
+		archive("target-archive-older", 2*time.Hour),
+		archive("target-archive-old", time.Hour),
+		// archives of other ExternalSecrets are not pruned
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:              "other-archive",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(now.Add(-4 * time.Hour)),
+			Labels:            map[string]string{esv1beta1.LabelArchiveOf: "other"},
+		}},
+	)
+	cl := r.Client
+
+	// the fake client does not set the creation timestamp,
+	// prune the existing archives first to verify the order
+	if err := r.pruneArchives(context.Background(), es); err != nil {
+		t.Fatalf("pruneArchives() error = %v", err)
+	}
+	var list v1.SecretList
+	if err := cl.List(context.Background(), &list); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range list.Items {
+		names = append(names, s.Name)
+	}
+	if diff := cmp.Diff([]string{"other-archive", "target-archive-old", "target-archive-older"}, names); diff != "" {
+		t.Errorf("unexpected secrets after prune (-want +got):\n%s", diff)
+	}
+
+	es.Spec.Target.Archive.Retention = 5
+	if err := r.archiveKeys(context.Background(), es, "target", map[string][]byte{"removed": []byte("value")}); err != nil {
+		t.Fatalf("archiveKeys() error = %v", err)
+	}
+	list = v1.SecretList{}
+	if err := cl.List(context.Background(), &list, client.HasLabels{esv1beta1.LabelArchiveOf}); err != nil {
+		t.Fatal(err)
+	}
+	var created *v1.Secret
+	for i := range list.Items {
+		if list.Items[i].Annotations[esv1beta1.AnnotationArchivedFrom] == "target" {
+			created = &list.Items[i]
+		}
+	}
+	if created == nil {
+		t.Fatalf("archive secret was not created")
+	}
+	if diff := cmp.Diff(map[string][]byte{"removed": []byte("value")}, created.Data); diff != "" {
+		t.Errorf("unexpected archive data (-want +got):\n%s", diff)
+	}
+	if created.Immutable == nil || !*created.Immutable {
+		t.Errorf("archive secret should be immutable")
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errBundleParse    = "could not bundle spec.dataFrom[%d]: %s holds no certificate: %w"
+	errBundleNoCerts  = "could not bundle spec.dataFrom[%d]: no valid certificate found"
+	errBundleNotCerts = "neither PEM nor DER data"
+)
+
+// certificateBundle aggregates the certificates of the secrets found into a single PEM bundle.
+// Duplicates and, unless kept, expired certificates are dropped. The certificates are sorted
+// by subject and serial number, so the bundle does not depend on the order the provider returns them in.
+func certificateBundle(bundle *esv1beta1.ExternalSecretCertificateBundle, secretMap map[string][]byte, i int, now time.Time) (map[string][]byte, error) {
+	seen := make(map[[sha256.Size]byte]struct{})
+	var certs []*x509.Certificate
+	for key, value := range secretMap {
+		found, err := parseCertificates(value)
+		if err != nil {
+			return nil, fmt.Errorf(errBundleParse, i, key, err)
+		}
+		for _, cert := range found {
+			if !bundle.KeepExpired && now.After(cert.NotAfter) {
+				continue
+			}
+			sum := sha256.Sum256(cert.Raw)
+			if _, ok := seen[sum]; ok {
+				continue
+			}
+			seen[sum] = struct{}{}
+			certs = append(certs, cert)
+		}
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf(errBundleNoCerts, i)
+	}
+	sort.Slice(certs, func(a, b int) bool {
+		subjectA, subjectB := certs[a].Subject.String(), certs[b].Subject.String()
+		if subjectA != subjectB {
+			return subjectA < subjectB
+		}
+		if c := certs[a].SerialNumber.Cmp(certs[b].SerialNumber); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(certs[a].Raw, certs[b].Raw) < 0
+	})
+
+	var out bytes.Buffer
+	for _, cert := range certs {
+		if err := pem.Encode(&out, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return nil, err
+		}
+	}
+	return map[string][]byte{bundle.Key: out.Bytes()}, nil
+}
+
+// parseCertificates returns the certificates of PEM data, other PEM blocks like private keys are skipped,
+// or of DER data holding one or more certificates. Data that is neither is an error.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) > 0 || bytes.Contains(data, []byte("-----BEGIN")) {
+		return certs, nil
+	}
+	certs, err := x509.ParseCertificates(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.New(errBundleNotCerts)
+	}
+	return certs, nil
+}
@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func newTestCertificate(t *testing.T, name string, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return der
+}
+
+func toPEM(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func TestCertificateBundle(t *testing.T) {
+	now := time.Now()
+	rootA := newTestCertificate(t, "root-a", now.Add(time.Hour))
+	rootB := newTestCertificate(t, "root-b", now.Add(time.Hour))
+	expired := newTestCertificate(t, "expired", now.Add(-time.Hour))
+	bundle := &esv1beta1.ExternalSecretCertificateBundle{Key: "ca.crt"}
+
+	secretMap := map[string][]byte{
+		// DER as returned for Azure certificates
+		"b": rootB,
+		// chain holding a duplicate and an expired certificate
+		"chain": append(append(toPEM("CERTIFICATE", rootA), toPEM("CERTIFICATE", rootB)...), toPEM("CERTIFICATE", expired)...),
+		// private keys are skipped
+		"tls": toPEM("PRIVATE KEY", []byte("key")),
+	}
+	got, err := certificateBundle(bundle, secretMap, 0, now)
+	require.NoError(t, err)
+	want := append(toPEM("CERTIFICATE", rootA), toPEM("CERTIFICATE", rootB)...)
+	assert.Equal(t, map[string][]byte{"ca.crt": want}, got)
+
+	got, err = certificateBundle(&esv1beta1.ExternalSecretCertificateBundle{Key: "ca.crt", KeepExpired: true}, secretMap, 0, now)
+	require.NoError(t, err)
+	assert.Equal(t, append(toPEM("CERTIFICATE", expired), want...), got["ca.crt"])
+
+	_, err = certificateBundle(bundle, map[string][]byte{"expired": expired}, 1, now)
+	assert.EqualError(t, err, "could not bundle spec.dataFrom[1]: no valid certificate found")
+
+	_, err = certificateBundle(bundle, map[string][]byte{"password": []byte("secret")}, 1, now)
+	assert.ErrorContains(t, err, "could not bundle spec.dataFrom[1]: password holds no certificate")
+}
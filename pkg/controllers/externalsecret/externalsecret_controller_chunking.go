@@ -0,0 +1,233 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	defaultMaxChunks  = 16
+	chunkNameTemplate = "%s-chunk-%d"
+
+	errTooManyChunks   = "chunked data needs %d chunks, exceeding maxChunks of %d"
+	errStreamTooLarge  = "value exceeds the chunking capacity of %d bytes"
+	errEncodeChunks    = "could not encode chunk index: %w"
+	errWriteChunk      = "could not write chunk secret %s: %w"
+	errChunkNotManaged = "chunk secret %s exists and is not managed by this ExternalSecret"
+	errPruneChunks     = "could not prune chunk secrets: %w"
+)
+
+// chunkIndexEntry describes a chunked value in the index annotation of the target Secret.
+type chunkIndexEntry struct {
+	Size   int      `json:"size"`
+	SHA256 string   `json:"sha256"`
+	Chunks []string `json:"chunks"`
+}
+
+func isChunkedTarget(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.Target.Chunking != nil
+}
+
+// chunkLimits returns the size of a chunk and the maximum number of chunks of the ExternalSecret.
+// A chunk is never larger than the maximum size of a Secret.
+func (r *Reconciler) chunkLimits(es *esv1beta1.ExternalSecret) (int, int) {
+	chunking := es.Spec.Target.Chunking
+	size := r.maxSecretSize()
+	if chunking.ChunkSize != nil && int(*chunking.ChunkSize) > 0 && int(*chunking.ChunkSize) < size {
+		size = int(*chunking.ChunkSize)
+	}
+	maxChunks := defaultMaxChunks
+	if chunking.MaxChunks != nil && *chunking.MaxChunks > 0 {
+		maxChunks = int(*chunking.MaxChunks)
+	}
+	return size, maxChunks
+}
+
+// chunkedCapacity returns the maximum size of a value of a chunked target, zero if the target is not chunked.
+func (r *Reconciler) chunkedCapacity(es *esv1beta1.ExternalSecret) int64 {
+	if !isChunkedTarget(es) {
+		return 0
+	}
+	size, maxChunks := r.chunkLimits(es)
+	return int64(size) * int64(maxChunks)
+}
+
+// readSecretStream reads the streamed value of the ref, failing once it is larger than limit
+// so a large value is never read completely into memory.
+func readSecretStream(ctx context.Context, streamer esv1beta1.SecretStreamer, ref esv1beta1.ExternalSecretDataRemoteRef, limit int64) ([]byte, error) {
+	stream, err := streamer.GetSecretStream(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	// read one byte more than allowed to detect larger values
+	value, err := io.ReadAll(io.LimitReader(stream, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(value)) > limit {
+		return nil, fmt.Errorf(errStreamTooLarge, limit)
+	}
+	return value, nil
+}
+
+// chunkSecretData moves the largest values of the secret to chunk secrets until the remaining
+// values fit into the maximum size of a Secret, and records the chunks in the index annotation.
+// The chunk secrets are written before the secret, so the index never refers to missing chunks.
+// It returns the names of the chunk secrets, the others are pruned once the secret is written.
+func (r *Reconciler) chunkSecretData(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret) ([]string, error) {
+	maxSize := r.maxSecretSize()
+	size := 0
+	for _, val := range secret.Data {
+		size += len(val)
+	}
+	if size <= maxSize {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(secret.Data[keys[i]]) == len(secret.Data[keys[j]]) {
+			return keys[i] < keys[j]
+		}
+		return len(secret.Data[keys[i]]) > len(secret.Data[keys[j]])
+	})
+
+	chunkSize, maxChunks := r.chunkLimits(es)
+	index := make(map[string]chunkIndexEntry)
+	var chunks []*v1.Secret
+	for _, key := range keys {
+		if size <= maxSize {
+			break
+		}
+		val := secret.Data[key]
+		hash := sha256.Sum256(val)
+		entry := chunkIndexEntry{Size: len(val), SHA256: hex.EncodeToString(hash[:])}
+		for off := 0; off < len(val); off += chunkSize {
+			name := fmt.Sprintf(chunkNameTemplate, secret.Name, len(chunks))
+			chunks = append(chunks, newChunkSecret(es, name, key, val[off:min(off+chunkSize, len(val))]))
+			entry.Chunks = append(entry.Chunks, name)
+		}
+		index[key] = entry
+		delete(secret.Data, key)
+		size -= len(val)
+	}
+	if len(chunks) > maxChunks {
+		return nil, &secretLimitError{msg: fmt.Sprintf(errTooManyChunks, len(chunks), maxChunks)}
+	}
+	encoded, err := json.Marshal(index)
+	if err != nil {
+		return nil, fmt.Errorf(errEncodeChunks, err)
+	}
+
+	names := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		if err := r.writeChunk(ctx, es, chunk); err != nil {
+			return nil, fmt.Errorf(errWriteChunk, chunk.Name, err)
+		}
+		names[i] = chunk.Name
+	}
+	secret.Annotations[esv1beta1.AnnotationChunks] = string(encoded)
+	return names, nil
+}
+
+func newChunkSecret(es *esv1beta1.ExternalSecret, name, key string, part []byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: es.Namespace,
+			Labels: map[string]string{
+				esv1beta1.LabelChunkOf: chunkLabelValue(es),
+			},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: map[string][]byte{key: part},
+	}
+}
+
+// writeChunk creates or updates the chunk secret, chunk secrets of creationPolicy=Owner
+// are owned by the ExternalSecret. Secrets not created as chunk of the ExternalSecret are never overwritten.
+func (r *Reconciler) writeChunk(ctx context.Context, es *esv1beta1.ExternalSecret, chunk *v1.Secret) error {
+	if es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+		if err := controllerutil.SetControllerReference(es, chunk, r.Scheme); err != nil {
+			return fmt.Errorf(errSetCtrlReference, err)
+		}
+	}
+	var existing v1.Secret
+	err := r.Get(ctx, client.ObjectKeyFromObject(chunk), &existing)
+	if apierrors.IsNotFound(err) {
+		return r.Create(ctx, chunk)
+	}
+	if err != nil {
+		return err
+	}
+	if existing.Labels[esv1beta1.LabelChunkOf] != chunkLabelValue(es) {
+		return fmt.Errorf(errChunkNotManaged, chunk.Name)
+	}
+	if equality.Semantic.DeepEqual(existing.Data, chunk.Data) && equality.Semantic.DeepEqual(existing.OwnerReferences, chunk.OwnerReferences) {
+		return nil
+	}
+	existing.Data = chunk.Data
+	existing.OwnerReferences = chunk.OwnerReferences
+	return r.Update(ctx, &existing)
+}
+
+// pruneChunks deletes the chunk secrets of the ExternalSecret that are not part of the current index.
+func (r *Reconciler) pruneChunks(ctx context.Context, es *esv1beta1.ExternalSecret, keep []string) error {
+	var chunks v1.SecretList
+	err := r.List(ctx, &chunks, client.InNamespace(es.Namespace), client.MatchingLabels{
+		esv1beta1.LabelChunkOf: chunkLabelValue(es),
+	})
+	if err != nil {
+		return fmt.Errorf(errPruneChunks, err)
+	}
+	kept := make(map[string]bool, len(keep))
+	for _, name := range keep {
+		kept[name] = true
+	}
+	for i := range chunks.Items {
+		if kept[chunks.Items[i].Name] {
+			continue
+		}
+		if err := r.Delete(ctx, &chunks.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf(errPruneChunks, err)
+		}
+	}
+	return nil
+}
+
+func chunkLabelValue(es *esv1beta1.ExternalSecret) string {
+	return utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name))
+}
@@ -0,0 +1,173 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestChunkSecretData(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default", UID: "uid"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				CreationPolicy: esv1beta1.CreatePolicyOwner,
+				Chunking:       &esv1beta1.ExternalSecretChunking{ChunkSize: ptr.To[int32](40)},
+			},
+		},
+	}
+	r := newFakeReconciler(
+		// a stale chunk of a previous, larger value
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:      "target-chunk-3",
+			Namespace: "default",
+			Labels:    map[string]string{esv1beta1.LabelChunkOf: chunkLabelValue(es)},
+		}},
+	)
+	r.MaxSecretSize = 100
+	cl := r.Client
+	ctx := context.Background()
+
+	big := []byte(strings.Repeat("0123456789", 10))
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default", Annotations: map[string]string{}},
+		Data:       map[string][]byte{"big": big, "small": []byte("small")},
+	}
+	chunks, err := r.chunkSecretData(ctx, es, secret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"target-chunk-0", "target-chunk-1", "target-chunk-2"}
+	if strings.Join(chunks, ",") != strings.Join(want, ",") {
+		t.Fatalf("chunks = %v, want %v", chunks, want)
+	}
+	if _, ok := secret.Data["big"]; ok || string(secret.Data["small"]) != "small" {
+		t.Errorf("unexpected data after chunking: %v", secret.Data)
+	}
+
+	var index map[string]chunkIndexEntry
+	if err := json.Unmarshal([]byte(secret.Annotations[esv1beta1.AnnotationChunks]), &index); err != nil {
+		t.Fatalf("invalid index annotation: %v", err)
+	}
+	if index["big"].Size != len(big) || len(index["big"].SHA256) != 64 {
+		t.Errorf("unexpected index entry: %+v", index["big"])
+	}
+	var joined []byte
+	for _, name := range index["big"].Chunks {
+		var chunk v1.Secret
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &chunk); err != nil {
+			t.Fatalf("chunk %s: %v", name, err)
+		}
+		if len(chunk.OwnerReferences) != 1 || chunk.OwnerReferences[0].UID != es.UID {
+			t.Errorf("chunk %s is not owned by the ExternalSecret", name)
+		}
+		joined = append(joined, chunk.Data["big"]...)
+	}
+	if !bytes.Equal(joined, big) {
+		t.Errorf("joined chunks = %q, want %q", joined, big)
+	}
+
+	if err := r.pruneChunks(ctx, es, chunks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var list v1.SecretList
+	if err := cl.List(ctx, &list, client.MatchingLabels{esv1beta1.LabelChunkOf: chunkLabelValue(es)}); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != len(want) {
+		t.Errorf("%d chunk secrets after pruning, want %d", len(list.Items), len(want))
+	}
+
+	// data within the limit is not chunked
+	secret.Data = map[string][]byte{"small": []byte("small")}
+	chunks, err = r.chunkSecretData(ctx, es, secret)
+	if err != nil || chunks != nil {
+		t.Errorf("chunkSecretData() = %v, %v, want no chunks", chunks, err)
+	}
+}
+
+func TestChunkSecretDataLimits(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				CreationPolicy: esv1beta1.CreatePolicyOrphan,
+				Chunking: &esv1beta1.ExternalSecretChunking{
+					ChunkSize: ptr.To[int32](40),
+					MaxChunks: ptr.To[int32](2),
+				},
+			},
+		},
+	}
+	r := newFakeReconciler(
+		// a secret that happens to have the name of a chunk
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-chunk-0", Namespace: "default"}},
+	)
+	r.MaxSecretSize = 50
+	ctx := context.Background()
+
+	if got := r.chunkedCapacity(es); got != 80 {
+		t.Errorf("chunkedCapacity() = %d, want 80", got)
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default", Annotations: map[string]string{}},
+		Data:       map[string][]byte{"big": bytes.Repeat([]byte("x"), 81)},
+	}
+	_, err := r.chunkSecretData(ctx, es, secret)
+	if err == nil || err.Error() != "chunked data needs 3 chunks, exceeding maxChunks of 2" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	secret = &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Annotations: map[string]string{}},
+		Data:       map[string][]byte{"big": bytes.Repeat([]byte("x"), 60)},
+	}
+	_, err = r.chunkSecretData(ctx, es, secret)
+	if err == nil || !strings.Contains(err.Error(), "chunk secret other-chunk-0 exists and is not managed by this ExternalSecret") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+type fakeStreamer struct {
+	value []byte
+}
+
+func (f *fakeStreamer) GetSecretStream(_ context.Context, _ esv1beta1.ExternalSecretDataRemoteRef) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(f.value)), nil
+}
+
+func TestReadSecretStream(t *testing.T) {
+	streamer := &fakeStreamer{value: bytes.Repeat([]byte{0xff}, 100)}
+	got, err := readSecretStream(context.Background(), streamer, esv1beta1.ExternalSecretDataRemoteRef{}, 100)
+	if err != nil || !bytes.Equal(got, streamer.value) {
+		t.Errorf("readSecretStream() = %d bytes, %v", len(got), err)
+	}
+	_, err = readSecretStream(context.Background(), streamer, esv1beta1.ExternalSecretDataRemoteRef{}, 99)
+	if err == nil || err.Error() != "value exceeds the chunking capacity of 99 bytes" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errGetExistingConfigMap = "could not get existing configmap: %w"
+	errUpdateConfigMap      = "could not update ConfigMap"
+	errApplyConfigMap       = "could not apply configmap %s: %w"
+)
+
+func isConfigMapTarget(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.Target.Kind == esv1beta1.TargetKindConfigMap
+}
+
+// isConfigMapValid checks if the ConfigMap exists and its data has not been changed since the last sync.
+func isConfigMapValid(existing v1.ConfigMap) bool {
+	if existing.UID == "" || existing.Annotations == nil {
+		return false
+	}
+	return existing.Annotations[esv1beta1.AnnotationDataHash] == utils.ObjectHash(configMapData(&existing))
+}
+
+// syncConfigMap renders the data into the in-memory secret and writes the result to a ConfigMap.
+// The ConfigMap is applied server-side, so keys that were previously written
+// by the ExternalSecret but are not part of the data anymore are removed.
+func (r *Reconciler) syncConfigMap(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret, dataMap map[string][]byte, metadata []esv1beta1.SecretMetadata) error {
+	if es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyNone {
+		return nil
+	}
+	fqdn := fmt.Sprintf(fieldOwnerTemplate, es.Name)
+
+	var existing v1.ConfigMap
+	err := r.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, &existing)
+	notFound := apierrors.IsNotFound(err)
+	if err != nil && !notFound {
+		return fmt.Errorf(errGetExistingConfigMap, err)
+	}
+	if notFound && es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyMerge {
+		return fmt.Errorf(errPolicyMergeNotFound, secret.Name)
+	}
+
+	var adoptedFrom string
+	if !notFound && es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+		adoptedFrom, err = adoptTarget(&existing, es)
+		if err != nil {
+			return err
+		}
+		// the controller reference of the previous owner can not be removed with an apply
+		if adoptedFrom != "" {
+			if err := r.Update(ctx, &existing); err != nil {
+				return fmt.Errorf(errApplyConfigMap, existing.Name, err)
+			}
+		}
+	}
+
+	if err := r.applyTemplate(ctx, es, secret, dataMap, metadata); err != nil {
+		return fmt.Errorf(errApplyTemplate, err)
+	}
+	if err := r.validateSecretLimits(secret.Data); err != nil {
+		return err
+	}
+	if err := r.validateSecretValues(es, secret.Data); err != nil {
+		return err
+	}
+
+	cm := &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+		},
+		Immutable: secret.Immutable,
+	}
+	setConfigMapData(cm, secret.Data)
+
+	if es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+		if err := controllerutil.SetControllerReference(es, cm, r.Scheme); err != nil {
+			return fmt.Errorf(errSetCtrlReference, err)
+		}
+		cm.Labels[esv1beta1.LabelOwner] = utils.ObjectHash(fmt.Sprintf("%v/%v", es.Namespace, es.Name))
+	}
+
+	hash, err := computeConfigMapDataHash(&existing, cm, es.Name)
+	if err != nil {
+		return err
+	}
+	cm.Annotations[esv1beta1.AnnotationDataHash] = hash
+	r.setManager(cm)
+
+	if err := r.Patch(ctx, cm, client.Apply, client.FieldOwner(fqdn), client.ForceOwnership); err != nil {
+		return fmt.Errorf(errApplyConfigMap, cm.Name, err)
+	}
+
+	es.Status.Binding = v1.LocalObjectReference{Name: cm.Name}
+	r.markAsAdopted(es, "ConfigMap "+cm.Name, adoptedFrom)
+	switch {
+	case notFound:
+		r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonCreated, "Created ConfigMap")
+		if es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+			return deleteOrphanedObjects(ctx, r.Client, es, &v1.ConfigMapList{})
+		}
+	case cm.ResourceVersion != existing.ResourceVersion:
+		r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonUpdated, "Updated ConfigMap")
+	}
+	return nil
+}
+
+// setConfigMapData stores valid UTF-8 values in .data and all other values in .binaryData.
+func setConfigMapData(cm *v1.ConfigMap, data map[string][]byte) {
+	for k, v := range data {
+		if utf8.Valid(v) {
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data[k] = string(v)
+			continue
+		}
+		if cm.BinaryData == nil {
+			cm.BinaryData = make(map[string][]byte)
+		}
+		cm.BinaryData[k] = v
+	}
+}
+
+// configMapData returns .data and .binaryData of the ConfigMap as a single map.
+func configMapData(cm *v1.ConfigMap) map[string][]byte {
+	data := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for k, v := range cm.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range cm.BinaryData {
+		data[k] = v
+	}
+	return data
+}
+
+// computeConfigMapDataHash generates a hash of the ConfigMap data after the apply:
+// the keys of other field managers are kept, the keys previously written by the ExternalSecret are replaced.
+func computeConfigMapDataHash(existing, cm *v1.ConfigMap, fieldOwner string) (string, error) {
+	data := configMapData(existing)
+	keys, err := getManagedConfigMapKeys(existing, fieldOwner)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		delete(data, key)
+	}
+	for k, v := range configMapData(cm) {
+		data[k] = v
+	}
+	return utils.ObjectHash(data), nil
+}
+
+func getManagedConfigMapKeys(cm *v1.ConfigMap, fieldOwner string) ([]string, error) {
+	return getManagedFieldKeys(cm, fieldOwner, func(fields map[string]any) []string {
+		var keys []string
+		for _, field := range []string{"f:data", "f:binaryData"} {
+			df, ok := fields[field].(map[string]any)
+			if !ok {
+				continue
+			}
+			for k := range df {
+				keys = append(keys, k)
+			}
+		}
+		return keys
+	})
+}
+
+func (r *Reconciler) findObjectsForConfigMap(ctx context.Context, cm client.Object) []reconcile.Request {
+	var externalSecrets esv1beta1.ExternalSecretList
+	err := r.List(
+		ctx,
+		&externalSecrets,
+		client.InNamespace(cm.GetNamespace()),
+		client.MatchingFields{externalSecretSecretNameKey: cm.GetName()},
+	)
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(externalSecrets.Items))
+	for i := range externalSecrets.Items {
+		if !isConfigMapTarget(&externalSecrets.Items[i]) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&externalSecrets.Items[i]),
+		})
+	}
+	return requests
+}
@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestSetConfigMapData(t *testing.T) {
+	data := map[string][]byte{
+		"flag": []byte("true"),
+		"key":  []byte("-----BEGIN PUBLIC KEY-----"),
+		"bin":  {0xff, 0xfe, 0x00},
+	}
+	cm := &v1.ConfigMap{}
+	setConfigMapData(cm, data)
+
+	wantData := map[string]string{
+		"flag": "true",
+		"key":  "-----BEGIN PUBLIC KEY-----",
+	}
+	if diff := cmp.Diff(wantData, cm.Data); diff != "" {
+		t.Errorf("unexpected data (-want +got):\n%s", diff)
+	}
+	wantBinary := map[string][]byte{
+		"bin": {0xff, 0xfe, 0x00},
+	}
+	if diff := cmp.Diff(wantBinary, cm.BinaryData); diff != "" {
+		t.Errorf("unexpected binaryData (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(data, configMapData(cm)); diff != "" {
+		t.Errorf("unexpected combined data (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsConfigMapValid(t *testing.T) {
+	data := map[string]string{"flag": "true"}
+	tests := []struct {
+		name string
+		cm   v1.ConfigMap
+		want bool
+	}{
+		{
+			name: "missing uid",
+			cm:   v1.ConfigMap{},
+			want: false,
+		},
+		{
+			name: "missing hash",
+			cm: v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{UID: "xxx", Annotations: map[string]string{}},
+				Data:       data,
+			},
+			want: false,
+		},
+		{
+			name: "matching hash",
+			cm: v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{UID: "xxx", Annotations: map[string]string{
+					esv1beta1.AnnotationDataHash: utils.ObjectHash(map[string][]byte{"flag": []byte("true")}),
+				}},
+				Data: data,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConfigMapValid(tt.cm); got != tt.want {
+				t.Errorf("isConfigMapValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
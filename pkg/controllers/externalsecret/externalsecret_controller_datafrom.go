@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errDataFromConflict = "%s (dataFromMergePolicy: Error)"
+	msgDataFromConflict = "keys returned with different values by multiple dataFrom entries: %s"
+)
+
+// dataFromMerger merges the secret maps of the dataFrom entries according to the merge policy
+// and keeps track of the keys returned with different values by multiple entries.
+type dataFromMerger struct {
+	policy      esv1beta1.ExternalSecretDataFromMergePolicy
+	data        map[string][]byte
+	entries     map[string][]int
+	conflicting map[string]bool
+}
+
+func newDataFromMerger(policy esv1beta1.ExternalSecretDataFromMergePolicy) *dataFromMerger {
+	return &dataFromMerger{
+		policy:      policy,
+		data:        make(map[string][]byte),
+		entries:     make(map[string][]int),
+		conflicting: make(map[string]bool),
+	}
+}
+
+// merge adds the secret map of dataFrom[i].
+func (m *dataFromMerger) merge(i int, secretMap map[string][]byte) {
+	for key, value := range secretMap {
+		m.entries[key] = append(m.entries[key], i)
+		current, exists := m.data[key]
+		if !exists {
+			m.data[key] = value
+			continue
+		}
+		if !bytes.Equal(current, value) {
+			m.conflicting[key] = true
+		}
+		if m.policy != esv1beta1.DataFromMergePolicyFirst {
+			m.data[key] = value
+		}
+	}
+}
+
+// conflicts describes the conflicting keys along with the entries returning them, sorted by key.
+// It returns an empty string if there are no conflicts.
+func (m *dataFromMerger) conflicts() string {
+	keys := make([]string, 0, len(m.conflicting))
+	for key := range m.conflicting {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	described := make([]string, len(keys))
+	for i, key := range keys {
+		entries := make([]string, len(m.entries[key]))
+		for j, entry := range m.entries[key] {
+			entries[j] = fmt.Sprintf("dataFrom[%d]", entry)
+		}
+		described[i] = fmt.Sprintf("%s (%s)", key, strings.Join(entries, ", "))
+	}
+	return strings.Join(described, ", ")
+}
+
+// updateDataFromConflicts sets the DataFromConflict condition if multiple dataFrom entries
+// returned different values for the same keys and removes it otherwise.
+// A warning event is recorded whenever the conflicting keys change.
+func (r *Reconciler) updateDataFromConflicts(externalSecret *esv1beta1.ExternalSecret, conflicts string) {
+	if conflicts == "" {
+		externalSecret.Status.Conditions = filterOutCondition(externalSecret.Status.Conditions, esv1beta1.ExternalSecretDataFromConflict)
+		return
+	}
+	msg := fmt.Sprintf(msgDataFromConflict, conflicts)
+	current := GetExternalSecretCondition(externalSecret.Status, esv1beta1.ExternalSecretDataFromConflict)
+	if current == nil || current.Message != msg {
+		r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ConditionReasonDataFromKeyConflict, msg)
+	}
+	condition := NewExternalSecretCondition(esv1beta1.ExternalSecretDataFromConflict, v1.ConditionTrue, esv1beta1.ConditionReasonDataFromKeyConflict, msg)
+	SetExternalSecretCondition(externalSecret, *condition)
+}
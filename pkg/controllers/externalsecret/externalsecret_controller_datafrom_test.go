@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestDataFromMerger(t *testing.T) {
+	entries := []map[string][]byte{
+		{"user": []byte("admin"), "password": []byte("first")},
+		{"url": []byte("https://example.com"), "user": []byte("admin")},
+		{"password": []byte("last")},
+	}
+	tests := []struct {
+		name   string
+		policy esv1beta1.ExternalSecretDataFromMergePolicy
+		want   string
+	}{
+		{name: "unset policy takes the last value", want: "last"},
+		{name: "last", policy: esv1beta1.DataFromMergePolicyLast, want: "last"},
+		{name: "first", policy: esv1beta1.DataFromMergePolicyFirst, want: "first"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			merger := newDataFromMerger(tc.policy)
+			for i, entry := range entries {
+				merger.merge(i, entry)
+			}
+			assert.Equal(t, map[string][]byte{
+				"user":     []byte("admin"),
+				"url":      []byte("https://example.com"),
+				"password": []byte(tc.want),
+			}, merger.data)
+			// equal values of the same key are no conflict
+			assert.Equal(t, "password (dataFrom[0], dataFrom[2])", merger.conflicts())
+		})
+	}
+}
+
+func TestUpdateDataFromConflicts(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder}
+	es := &esv1beta1.ExternalSecret{}
+
+	r.updateDataFromConflicts(es, "password (dataFrom[0], dataFrom[2])")
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretDataFromConflict)
+	require.NotNil(t, cond)
+	assert.Equal(t, v1.ConditionTrue, cond.Status)
+	assert.Equal(t, "keys returned with different values by multiple dataFrom entries: password (dataFrom[0], dataFrom[2])", cond.Message)
+	assert.Len(t, recorder.Events, 1)
+
+	// unchanged conflicts are not recorded again
+	r.updateDataFromConflicts(es, "password (dataFrom[0], dataFrom[2])")
+	assert.Len(t, recorder.Events, 1)
+
+	r.updateDataFromConflicts(es, "")
+	assert.Nil(t, GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretDataFromConflict))
+}
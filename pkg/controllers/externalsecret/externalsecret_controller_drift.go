@@ -0,0 +1,110 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errRepairDrift   = "could not repair drift of target"
+	msgDriftRepaired = "reverted local changes of Secret %s"
+)
+
+// syncedData holds the data of a target Secret as written by the last sync.
+type syncedData struct {
+	target string
+	hash   string
+	data   map[string][]byte
+}
+
+// driftCheckInterval returns the drift check interval of the ExternalSecret,
+// zero if drift is not checked or its target can't be repaired.
+func driftCheckInterval(es *esv1beta1.ExternalSecret) time.Duration {
+	if es.Spec.DriftCheckInterval == nil || !isDriftRepairable(es) {
+		return 0
+	}
+	return es.Spec.DriftCheckInterval.Duration
+}
+
+// isDriftRepairable returns true if the target is a Secret the controller writes as a whole,
+// other targets are refreshed from the provider when they have been changed.
+func isDriftRepairable(es *esv1beta1.ExternalSecret) bool {
+	switch es.Spec.Target.CreationPolicy {
+	case esv1beta1.CreatePolicyMerge, esv1beta1.CreatePolicyNone:
+		return false
+	}
+	return !isConfigMapTarget(es) && !isRotationTarget(es) && !isDryRun(es) && !es.Spec.Target.Immutable
+}
+
+// recordSyncedData keeps the data written to the target, so local changes can be reverted without a refresh.
+func (r *Reconciler) recordSyncedData(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+	name := types.NamespacedName{Namespace: es.Namespace, Name: es.Name}
+	if driftCheckInterval(es) <= 0 {
+		r.syncedValues.Delete(name)
+		return
+	}
+	data := make(map[string][]byte, len(secret.Data))
+	for k, v := range secret.Data {
+		data[k] = v
+	}
+	r.syncedValues.Store(name, syncedData{
+		target: secret.Name,
+		hash:   secret.Annotations[esv1beta1.AnnotationDataHash],
+		data:   data,
+	})
+}
+
+// repairDrift reverts the data of a changed target to the data of the last sync.
+// It returns false if the data of the last sync is unknown, e.g. after a restart,
+// or the target has been replaced, then the target must be refreshed from the provider.
+func (r *Reconciler) repairDrift(ctx context.Context, es *esv1beta1.ExternalSecret, existing *v1.Secret) (bool, error) {
+	if driftCheckInterval(es) <= 0 || existing.UID == "" {
+		return false, nil
+	}
+	cached, ok := r.syncedValues.Load(types.NamespacedName{Namespace: es.Namespace, Name: es.Name})
+	if !ok {
+		return false, nil
+	}
+	synced := cached.(syncedData)
+	// the hash annotation is only written by the controller, a different hash means it wasn't the last writer
+	if synced.target != existing.Name || synced.hash != existing.Annotations[esv1beta1.AnnotationDataHash] || synced.hash != utils.ObjectHash(synced.data) {
+		return false, nil
+	}
+	if err := r.checkManager(existing); err != nil {
+		return false, err
+	}
+
+	repaired := existing.DeepCopy()
+	repaired.Data = make(map[string][]byte, len(synced.data))
+	for k, v := range synced.data {
+		repaired.Data[k] = v
+	}
+	if err := r.Update(ctx, repaired, client.FieldOwner(fmt.Sprintf(fieldOwnerTemplate, es.Name))); err != nil {
+		return false, err
+	}
+	*existing = *repaired
+	r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonDriftRepaired, fmt.Sprintf(msgDriftRepaired, existing.Name))
+	return true, nil
+}
@@ -0,0 +1,124 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+func TestRepairDrift(t *testing.T) {
+	synced := map[string][]byte{"password": []byte("s3cr3t")}
+	newES := func(mutate func(es *esv1beta1.ExternalSecret)) *esv1beta1.ExternalSecret {
+		es := &esv1beta1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+			Spec: esv1beta1.ExternalSecretSpec{
+				DriftCheckInterval: &metav1.Duration{Duration: time.Minute},
+				Target:             esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyOwner},
+			},
+		}
+		if mutate != nil {
+			mutate(es)
+		}
+		return es
+	}
+	tests := []struct {
+		name         string
+		es           *esv1beta1.ExternalSecret
+		record       bool
+		annotation   string
+		wantRepaired bool
+	}{
+		{name: "reverts local changes", es: newES(nil), record: true, annotation: utils.ObjectHash(synced), wantRepaired: true},
+		{name: "data of the last sync is unknown", es: newES(nil), annotation: utils.ObjectHash(synced)},
+		{name: "hash annotation changed", es: newES(nil), record: true, annotation: "changed"},
+		{
+			name:       "drift check disabled",
+			es:         newES(func(es *esv1beta1.ExternalSecret) { es.Spec.DriftCheckInterval = nil }),
+			record:     true,
+			annotation: utils.ObjectHash(synced),
+		},
+		{
+			name:       "merged target",
+			es:         newES(func(es *esv1beta1.ExternalSecret) { es.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyMerge }),
+			record:     true,
+			annotation: utils.ObjectHash(synced),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "target",
+					Namespace:   "default",
+					UID:         "uid",
+					Annotations: map[string]string{esv1beta1.AnnotationDataHash: tt.annotation},
+				},
+				Data: map[string][]byte{"password": []byte("changed")},
+			}
+			r := newFakeReconciler(tampered)
+			cl, recorder := r.Client, r.recorder.(*record.FakeRecorder)
+			if tt.record {
+				// the drift check is enabled when the data is recorded, a later change of the spec disables it
+				r.recordSyncedData(newES(nil), &v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "target", Annotations: map[string]string{esv1beta1.AnnotationDataHash: utils.ObjectHash(synced)}},
+					Data:       synced,
+				})
+			}
+
+			var existing v1.Secret
+			require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(tampered), &existing))
+			repaired, err := r.repairDrift(context.Background(), tt.es, &existing)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRepaired, repaired)
+
+			var got v1.Secret
+			require.NoError(t, cl.Get(context.Background(), client.ObjectKeyFromObject(tampered), &got))
+			if !tt.wantRepaired {
+				assert.Equal(t, tampered.Data, got.Data)
+				assert.Empty(t, recorder.Events)
+				return
+			}
+			assert.Equal(t, synced, got.Data)
+			assert.True(t, isSecretValid(got))
+			assert.Equal(t, "Normal DriftRepaired reverted local changes of Secret target", <-recorder.Events)
+		})
+	}
+}
+
+func TestDriftCheckInterval(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{Spec: esv1beta1.ExternalSecretSpec{
+		DriftCheckInterval: &metav1.Duration{Duration: time.Minute},
+	}}
+	assert.Equal(t, time.Minute, driftCheckInterval(es))
+	assert.Equal(t, time.Minute, requeueAfter(es, time.Hour))
+	assert.Equal(t, time.Minute, requeueAfter(es, 0))
+	assert.Equal(t, 30*time.Second, requeueAfter(es, 30*time.Second))
+
+	es.Spec.Target.Immutable = true
+	assert.Zero(t, driftCheckInterval(es))
+	assert.Equal(t, time.Hour, requeueAfter(es, time.Hour))
+}
@@ -0,0 +1,112 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errDryRun        = "dry run failed"
+	msgDryRun        = "rendered %d keys without writing the target (dry run)"
+	msgDryRunChanges = "%s: added %v, changed %v, removed %v"
+)
+
+func isDryRun(es *esv1beta1.ExternalSecret) bool {
+	return es.Annotations[esv1beta1.AnnotationDryRun] == "true"
+}
+
+// dryRun renders the target like a sync would and validates it against the limits and target.validation,
+// the result is recorded in status.dryRun instead of writing the target.
+func (r *Reconciler) dryRun(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret, dataMap map[string][]byte, metadata []esv1beta1.SecretMetadata, existingSecret *v1.Secret, existingConfigMap *v1.ConfigMap) error {
+	if err := r.applyTemplate(ctx, es, secret, dataMap, metadata); err != nil {
+		return fmt.Errorf(errApplyTemplate, err)
+	}
+
+	existing := existingSecret.Data
+	managed, err := getManagedDataKeys(existingSecret, es.Name)
+	exists := existingSecret.UID != ""
+	if isConfigMapTarget(es) {
+		existing = configMapData(existingConfigMap)
+		managed, err = getManagedConfigMapKeys(existingConfigMap, es.Name)
+		exists = existingConfigMap.UID != ""
+	}
+	if err != nil {
+		return err
+	}
+
+	status := diffTarget(existing, managed, exists, secret.Data)
+	status.RenderTime = metav1.NewTime(time.Now())
+	if err := r.validateSecretLimits(secret.Data); err != nil {
+		return err
+	}
+	if err := r.validateSecretValues(es, secret.Data); err != nil {
+		return err
+	}
+	es.Status.DryRun = status
+
+	msg := fmt.Sprintf(msgDryRun, len(status.Keys))
+	if len(status.ConflictingKeys) > 0 {
+		msg = fmt.Sprintf("%s, overwrites keys of another owner: %s", msg, strings.Join(status.ConflictingKeys, ", "))
+	}
+	r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ConditionReasonSecretDryRun,
+		fmt.Sprintf(msgDryRunChanges, msg, status.AddedKeys, status.ChangedKeys, status.RemovedKeys))
+	condition := NewExternalSecretCondition(esv1beta1.ExternalSecretReady, v1.ConditionTrue, esv1beta1.ConditionReasonSecretDryRun, msg)
+	SetExternalSecretCondition(es, *condition)
+	return nil
+}
+
+// diffTarget compares the rendered data with the data of the existing target.
+// managed are the keys the ExternalSecret wrote to the existing target.
+func diffTarget(existing map[string][]byte, managed []string, exists bool, rendered map[string][]byte) *esv1beta1.ExternalSecretDryRunStatus {
+	status := &esv1beta1.ExternalSecretDryRunStatus{}
+	isManaged := make(map[string]bool, len(managed))
+	for _, key := range managed {
+		isManaged[key] = true
+	}
+	for key, val := range rendered {
+		status.Keys = append(status.Keys, key)
+		status.Size += len(key) + len(val)
+		old, ok := existing[key]
+		switch {
+		case !ok:
+			status.AddedKeys = append(status.AddedKeys, key)
+		case !bytes.Equal(old, val):
+			status.ChangedKeys = append(status.ChangedKeys, key)
+		}
+		if ok && exists && !isManaged[key] {
+			status.ConflictingKeys = append(status.ConflictingKeys, key)
+		}
+	}
+	for _, key := range managed {
+		if _, ok := rendered[key]; !ok {
+			status.RemovedKeys = append(status.RemovedKeys, key)
+		}
+	}
+	for _, keys := range [][]string{status.Keys, status.AddedKeys, status.ChangedKeys, status.RemovedKeys, status.ConflictingKeys} {
+		sort.Strings(keys)
+	}
+	return status
+}
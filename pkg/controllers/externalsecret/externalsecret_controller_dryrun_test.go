@@ -0,0 +1,108 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestDiffTarget(t *testing.T) {
+	existing := map[string][]byte{
+		"kept":      []byte("1"),
+		"changed":   []byte("2"),
+		"removed":   []byte("3"),
+		"unmanaged": []byte("4"),
+	}
+	rendered := map[string][]byte{
+		"kept":      []byte("1"),
+		"changed":   []byte("new"),
+		"added":     []byte("5"),
+		"unmanaged": []byte("6"),
+	}
+
+	got := diffTarget(existing, []string{"kept", "changed", "removed"}, true, rendered)
+	want := &esv1beta1.ExternalSecretDryRunStatus{
+		Keys:            []string{"added", "changed", "kept", "unmanaged"},
+		Size:            len("kept1changednewadded5unmanaged6"),
+		AddedKeys:       []string{"added"},
+		ChangedKeys:     []string{"changed", "unmanaged"},
+		RemovedKeys:     []string{"removed"},
+		ConflictingKeys: []string{"unmanaged"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("diffTarget() mismatch (-want +got):\n%s", diff)
+	}
+
+	// all keys are added to a target that does not exist
+	got = diffTarget(nil, nil, false, rendered)
+	if diff := cmp.Diff([]string{"added", "changed", "kept", "unmanaged"}, got.AddedKeys); diff != "" {
+		t.Errorf("diffTarget() added keys mismatch (-want +got):\n%s", diff)
+	}
+	if len(got.ConflictingKeys) != 0 {
+		t.Errorf("diffTarget() unexpected conflicts: %v", got.ConflictingKeys)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	r := newFakeReconciler()
+	cl := r.Client
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "es",
+			Namespace:   "default",
+			Annotations: map[string]string{esv1beta1.AnnotationDryRun: "true"},
+		},
+	}
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"}}
+
+	err := r.dryRun(context.Background(), es, secret, map[string][]byte{"key": []byte("value")}, nil, &v1.Secret{}, &v1.ConfigMap{})
+	if err != nil {
+		t.Fatalf("dryRun() error = %v", err)
+	}
+	want := &esv1beta1.ExternalSecretDryRunStatus{
+		Keys:      []string{"key"},
+		Size:      len("keyvalue"),
+		AddedKeys: []string{"key"},
+	}
+	if diff := cmp.Diff(want, es.Status.DryRun, cmpopts.IgnoreFields(esv1beta1.ExternalSecretDryRunStatus{}, "RenderTime")); diff != "" {
+		t.Errorf("dryRun() status mismatch (-want +got):\n%s", diff)
+	}
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Reason != esv1beta1.ConditionReasonSecretDryRun {
+		t.Errorf("dryRun() unexpected condition: %v", cond)
+	}
+	// the target is not written
+	if err := cl.Get(context.Background(), types.NamespacedName{Name: "target", Namespace: "default"}, &v1.Secret{}); err == nil {
+		t.Errorf("dryRun() created the target")
+	}
+
+	// rendered data exceeding the maximum size of a secret
+	large := map[string][]byte{"key": []byte(strings.Repeat("x", v1.MaxSecretSize+1))}
+	secret = &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"}}
+	err = r.dryRun(context.Background(), es, secret, large, nil, &v1.Secret{}, &v1.ConfigMap{})
+	if err == nil || !strings.Contains(err.Error(), "exceeds the maximum size") {
+		t.Errorf("dryRun() expected size error, got %v", err)
+	}
+}
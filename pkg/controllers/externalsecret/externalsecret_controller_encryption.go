@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/seal"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const errSealSecret = "could not seal secret data: %w"
+
+// sealedData holds the sealed values of a Secret and the hash of the values they were sealed from.
+type sealedData struct {
+	hash string
+	data map[string][]byte
+}
+
+func isSealedTarget(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.Target.Encryption != nil
+}
+
+// sealSecretData replaces the rendered values of the secret with their sealed values.
+// Sealing is not deterministic, so the sealed values of the existing secret are kept as long as
+// the rendered values and the key are unchanged. Only a hash of the rendered values is kept in memory,
+// nothing derived from them is written to the secret; after a restart the values are sealed again once.
+func (r *Reconciler) sealSecretData(es *esv1beta1.ExternalSecret, secret, existing *v1.Secret) error {
+	enc := es.Spec.Target.Encryption
+	name := types.NamespacedName{Namespace: es.Namespace, Name: es.Name}
+	hash := utils.ObjectHash(map[string]any{"name": secret.Name, "data": secret.Data, "encryption": *enc})
+	secret.Annotations[esv1beta1.AnnotationEncryption] = seal.Format
+
+	if cached, ok := r.sealedValues.Load(name); ok {
+		sealed := cached.(sealedData)
+		if sealed.hash == hash && utils.ObjectHash(existing.Data) == utils.ObjectHash(sealed.data) {
+			secret.Data = make(map[string][]byte, len(sealed.data))
+			for k, v := range sealed.data {
+				secret.Data[k] = v
+			}
+			return nil
+		}
+	}
+
+	sealer, err := seal.NewSealer([]byte(enc.PublicKey), enc.KeyID)
+	if err != nil {
+		return fmt.Errorf(errSealSecret, err)
+	}
+	data, err := sealer.SealMap(secret.Data)
+	if err != nil {
+		return fmt.Errorf(errSealSecret, err)
+	}
+	r.sealedValues.Store(name, sealedData{hash: hash, data: data})
+	secret.Data = make(map[string][]byte, len(data))
+	for k, v := range data {
+		secret.Data[k] = v
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/seal"
+)
+
+func TestSealSecretData(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				Encryption: &esv1beta1.ExternalSecretEncryption{
+					PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})),
+				},
+			},
+		},
+	}
+	newSecret := func(value string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "target", Annotations: map[string]string{}},
+			Data:       map[string][]byte{"password": []byte(value)},
+		}
+	}
+	r := &Reconciler{}
+
+	secret := newSecret("s3cr3t")
+	require.NoError(t, r.sealSecretData(es, secret, &v1.Secret{}))
+	assert.Equal(t, seal.Format, secret.Annotations[esv1beta1.AnnotationEncryption])
+	assert.NotEqual(t, "s3cr3t", string(secret.Data["password"]))
+	value, err := seal.Open(secret.Data["password"], key)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(value))
+
+	// unchanged values keep their sealed values
+	existing := secret
+	secret = newSecret("s3cr3t")
+	require.NoError(t, r.sealSecretData(es, secret, existing))
+	assert.Equal(t, existing.Data, secret.Data)
+
+	// changed values are sealed again
+	secret = newSecret("changed")
+	require.NoError(t, r.sealSecretData(es, secret, existing))
+	assert.NotEqual(t, existing.Data, secret.Data)
+	value, err = seal.Open(secret.Data["password"], key)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(value))
+
+	// values of a modified secret are sealed again
+	modified := &v1.Secret{Data: map[string][]byte{"password": []byte("tampered")}}
+	secret = newSecret("changed")
+	require.NoError(t, r.sealSecretData(es, secret, modified))
+	value, err = seal.Open(secret.Data["password"], key)
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(value))
+}
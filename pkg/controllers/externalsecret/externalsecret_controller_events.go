@@ -0,0 +1,126 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+const msgEventRepeated = "%s (repeated %d times in the last %s)"
+
+// eventAggregator is an EventRecorder recording identical events of an object,
+// with the same type, reason and message, at most once per window.
+// The repetitions within the window are recorded as a single summary event once the window has passed.
+type eventAggregator struct {
+	record.EventRecorder
+	window time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	events map[eventKey]*aggregatedEvent
+}
+
+type eventKey struct {
+	uid       types.UID
+	eventtype string
+	reason    string
+	message   string
+}
+
+type aggregatedEvent struct {
+	object   runtime.Object
+	recorded time.Time
+	// repeated counts the events suppressed since the event was recorded.
+	repeated int
+}
+
+func newEventAggregator(recorder record.EventRecorder, window time.Duration) *eventAggregator {
+	return &eventAggregator{
+		EventRecorder: recorder,
+		window:        window,
+		now:           time.Now,
+		events:        make(map[eventKey]*aggregatedEvent),
+	}
+}
+
+// Event records the event unless an identical event of the object was recorded within the window.
+func (a *eventAggregator) Event(object runtime.Object, eventtype, reason, message string) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		a.EventRecorder.Event(object, eventtype, reason, message)
+		return
+	}
+	key := eventKey{uid: accessor.GetUID(), eventtype: eventtype, reason: reason, message: message}
+	now := a.now()
+
+	a.mu.Lock()
+	previous, exists := a.events[key]
+	if exists && now.Sub(previous.recorded) < a.window {
+		previous.repeated++
+		a.mu.Unlock()
+		return
+	}
+	a.events[key] = &aggregatedEvent{object: object.DeepCopyObject(), recorded: now}
+	a.mu.Unlock()
+
+	if exists && previous.repeated > 0 {
+		message = fmt.Sprintf(msgEventRepeated, message, previous.repeated+1, now.Sub(previous.recorded).Round(time.Second))
+	}
+	a.EventRecorder.Event(object, eventtype, reason, message)
+}
+
+// Eventf is just like Event, but with Sprintf for the message field.
+func (a *eventAggregator) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	a.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// Start records the summaries of the events whose window has passed until the context is done.
+func (a *eventAggregator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(a.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			a.flush()
+		}
+	}
+}
+
+// flush records a summary for the events repeated within their passed window and forgets them.
+func (a *eventAggregator) flush() {
+	now := a.now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for key, event := range a.events {
+		elapsed := now.Sub(event.recorded)
+		if elapsed < a.window {
+			continue
+		}
+		if event.repeated > 0 {
+			a.EventRecorder.Event(event.object, key.eventtype, key.reason, fmt.Sprintf(msgEventRepeated, key.message, event.repeated, elapsed.Round(time.Second)))
+		}
+		delete(a.events, key)
+	}
+}
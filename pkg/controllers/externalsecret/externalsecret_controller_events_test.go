@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestEventAggregator(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	aggregator := newEventAggregator(recorder, time.Minute)
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	aggregator.now = func() time.Time { return now }
+
+	es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "es", UID: "1"}}
+	other := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "other", UID: "2"}}
+
+	aggregator.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "provider unavailable")
+	aggregator.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "provider unavailable")
+	aggregator.Eventf(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "provider %s", "unavailable")
+	// events of other objects or with other messages are recorded
+	aggregator.Event(other, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "provider unavailable")
+	aggregator.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "invalid credentials")
+	assert.Equal(t, []string{
+		"Warning UpdateFailed provider unavailable",
+		"Warning UpdateFailed provider unavailable",
+		"Warning UpdateFailed invalid credentials",
+	}, drainEvents(recorder))
+
+	// the first event after the window carries the number of repetitions
+	now = now.Add(time.Minute)
+	aggregator.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "provider unavailable")
+	assert.Equal(t, []string{"Warning UpdateFailed provider unavailable (repeated 3 times in the last 1m0s)"}, drainEvents(recorder))
+
+	// repetitions without a following event are summarized by flush
+	aggregator.Event(es, v1.EventTypeWarning, esv1beta1.ReasonUpdateFailed, "provider unavailable")
+	aggregator.flush()
+	assert.Empty(t, drainEvents(recorder))
+	now = now.Add(time.Minute * 2)
+	aggregator.flush()
+	assert.Equal(t, []string{"Warning UpdateFailed provider unavailable (repeated 1 times in the last 2m0s)"}, drainEvents(recorder))
+	assert.Empty(t, aggregator.events)
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errIgnoreChangesRegex = "invalid ignoreChangesRegex %q: %w"
+
+// keepUnchangedValues replaces the provider values of data entries with ignoreChangesRegex
+// by the value of the existing target, if they only differ within the matches of the expressions.
+// The data hash stays the same, so noisy provider fields don't update the target.
+func keepUnchangedValues(es *esv1beta1.ExternalSecret, dataMap, existing map[string][]byte) error {
+	for _, data := range es.Spec.Data {
+		if len(data.IgnoreChangesRegex) == 0 {
+			continue
+		}
+		val, ok := dataMap[data.SecretKey]
+		if !ok {
+			continue
+		}
+		current, ok := existing[data.SecretKey]
+		if !ok || bytes.Equal(val, current) {
+			continue
+		}
+		exps := make([]*regexp.Regexp, len(data.IgnoreChangesRegex))
+		for i, exp := range data.IgnoreChangesRegex {
+			re, err := regexp.Compile(exp)
+			if err != nil {
+				return fmt.Errorf(errIgnoreChangesRegex, exp, err)
+			}
+			exps[i] = re
+		}
+		if bytes.Equal(maskValue(val, exps), maskValue(current, exps)) {
+			dataMap[data.SecretKey] = current
+		}
+	}
+	return nil
+}
+
+func maskValue(val []byte, exps []*regexp.Regexp) []byte {
+	for _, re := range exps {
+		val = re.ReplaceAll(val, nil)
+	}
+	return val
+}
@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestKeepUnchangedValues(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "config", IgnoreChangesRegex: []string{`"updatedAt":"[^"]*"`}},
+				{SecretKey: "plain"},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		data     map[string][]byte
+		existing map[string][]byte
+		want     map[string]string
+	}{
+		{
+			name:     "change within the match keeps the existing value",
+			data:     map[string][]byte{"config": []byte(`{"user":"foo","updatedAt":"2024-02-01"}`)},
+			existing: map[string][]byte{"config": []byte(`{"user":"foo","updatedAt":"2024-01-01"}`)},
+			want:     map[string]string{"config": `{"user":"foo","updatedAt":"2024-01-01"}`},
+		},
+		{
+			name:     "change outside the match updates the value",
+			data:     map[string][]byte{"config": []byte(`{"user":"bar","updatedAt":"2024-02-01"}`)},
+			existing: map[string][]byte{"config": []byte(`{"user":"foo","updatedAt":"2024-01-01"}`)},
+			want:     map[string]string{"config": `{"user":"bar","updatedAt":"2024-02-01"}`},
+		},
+		{
+			name:     "keys without expressions are updated",
+			data:     map[string][]byte{"plain": []byte(`"updatedAt":"2024-02-01"`)},
+			existing: map[string][]byte{"plain": []byte(`"updatedAt":"2024-01-01"`)},
+			want:     map[string]string{"plain": `"updatedAt":"2024-02-01"`},
+		},
+		{
+			name: "missing target value",
+			data: map[string][]byte{"config": []byte(`{"updatedAt":"2024-02-01"}`)},
+			want: map[string]string{"config": `{"updatedAt":"2024-02-01"}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := keepUnchangedValues(es, tt.data, tt.existing); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for k, v := range tt.want {
+				if got := string(tt.data[k]); got != v {
+					t.Errorf("key %s: got %s, want %s", k, got, v)
+				}
+			}
+		})
+	}
+
+	invalid := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			Data: []esv1beta1.ExternalSecretData{{SecretKey: "config", IgnoreChangesRegex: []string{"["}}},
+		},
+	}
+	err := keepUnchangedValues(invalid, map[string][]byte{"config": []byte("a")}, map[string][]byte{"config": []byte("b")})
+	if err == nil {
+		t.Error("expected an error for an invalid expression")
+	}
+}
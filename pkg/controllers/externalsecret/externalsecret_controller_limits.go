@@ -0,0 +1,88 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	errSecretTooLarge = "rendered data of %d bytes exceeds the maximum size of %d bytes, largest keys: %s"
+	errTooManyKeys    = "rendered data has %d keys, exceeding the maximum of %d keys"
+
+	largestKeysReported = 3
+)
+
+// secretLimitError is returned if the rendered data exceeds the size or key count limits,
+// its message is used as status message so the failing keys can be spotted.
+type secretLimitError struct {
+	msg string
+}
+
+func (e *secretLimitError) Error() string {
+	return e.msg
+}
+
+// validateSecretLimits checks the rendered data against the limits of the controller
+// before it is written, the API server would reject it only after the provider data was fetched.
+// The size is the sum of the values, like the API server computes it.
+func (r *Reconciler) validateSecretLimits(data map[string][]byte) error {
+	if r.MaxSecretKeys > 0 && len(data) > r.MaxSecretKeys {
+		return &secretLimitError{msg: fmt.Sprintf(errTooManyKeys, len(data), r.MaxSecretKeys)}
+	}
+	maxSize := r.maxSecretSize()
+	size := 0
+	for _, val := range data {
+		size += len(val)
+	}
+	if size <= maxSize {
+		return nil
+	}
+	return &secretLimitError{msg: fmt.Sprintf(errSecretTooLarge, size, maxSize, largestKeys(data))}
+}
+
+// maxSecretSize returns the maximum size of the rendered data in bytes.
+func (r *Reconciler) maxSecretSize() int {
+	if r.MaxSecretSize <= 0 {
+		return v1.MaxSecretSize
+	}
+	return r.MaxSecretSize
+}
+
+// largestKeys describes the keys with the largest values.
+func largestKeys(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(data[keys[i]]) == len(data[keys[j]]) {
+			return keys[i] < keys[j]
+		}
+		return len(data[keys[i]]) > len(data[keys[j]])
+	})
+	if len(keys) > largestKeysReported {
+		keys = keys[:largestKeysReported]
+	}
+	described := make([]string, len(keys))
+	for i, key := range keys {
+		described[i] = fmt.Sprintf("%s (%d bytes)", key, len(data[key]))
+	}
+	return strings.Join(described, ", ")
+}
@@ -0,0 +1,105 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestValidateSecretLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *Reconciler
+		data    map[string][]byte
+		wantErr string
+	}{
+		{
+			name: "within the default size",
+			r:    &Reconciler{},
+			data: map[string][]byte{"key": []byte(strings.Repeat("x", v1.MaxSecretSize))},
+		},
+		{
+			name:    "exceeds the default size",
+			r:       &Reconciler{},
+			data:    map[string][]byte{"key": []byte(strings.Repeat("x", v1.MaxSecretSize+1))},
+			wantErr: fmt.Sprintf("rendered data of %d bytes exceeds the maximum size of %d bytes, largest keys: key (%d bytes)", v1.MaxSecretSize+1, v1.MaxSecretSize, v1.MaxSecretSize+1),
+		},
+		{
+			name: "exceeds a custom size",
+			r:    &Reconciler{MaxSecretSize: 10},
+			data: map[string][]byte{
+				"a": []byte("1234"),
+				"b": []byte("12"),
+				"c": []byte("1234"),
+				"d": []byte("123"),
+			},
+			wantErr: "rendered data of 13 bytes exceeds the maximum size of 10 bytes, largest keys: a (4 bytes), c (4 bytes), d (3 bytes)",
+		},
+		{
+			name:    "exceeds the key count",
+			r:       &Reconciler{MaxSecretKeys: 1},
+			data:    map[string][]byte{"a": nil, "b": nil},
+			wantErr: "rendered data has 2 keys, exceeding the maximum of 1 keys",
+		},
+		{
+			name: "no key limit by default",
+			r:    &Reconciler{},
+			data: map[string][]byte{"a": nil, "b": nil},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.r.validateSecretLimits(tc.data)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateSecretLimits() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("validateSecretLimits() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMarkAsFailedLimitExceeded(t *testing.T) {
+	r := &Reconciler{recorder: record.NewFakeRecorder(10)}
+	es := &esv1beta1.ExternalSecret{}
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test"})
+
+	limitErr := fmt.Errorf("could not update secret: %w", &secretLimitError{msg: "rendered data has 2 keys, exceeding the maximum of 1 keys"})
+	r.markAsFailed(logr.Discard(), "sync-id", errUpdateSecret, limitErr, es, counter)
+	cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Reason != esv1beta1.ConditionReasonSecretLimitExceeded || cond.Message != "rendered data has 2 keys, exceeding the maximum of 1 keys (sync id: sync-id)" {
+		t.Errorf("markAsFailed() unexpected condition: %v", cond)
+	}
+
+	r.markAsFailed(logr.Discard(), "sync-id", errUpdateSecret, errors.New("boom"), es, counter)
+	cond = GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
+	if cond == nil || cond.Reason != esv1beta1.ConditionReasonSecretSyncedError || cond.Message != errUpdateSecret+" (sync id: sync-id)" {
+		t.Errorf("markAsFailed() unexpected condition: %v", cond)
+	}
+}
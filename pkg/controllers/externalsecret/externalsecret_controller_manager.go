@@ -0,0 +1,54 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errCheckManager   = "target is managed by another controller"
+	errManagedByOther = "target %s is managed by %s, start the controller with --force-ownership to take it over"
+)
+
+// checkManager returns an error if the existing target was written by a controller with another identity,
+// so two installations in one cluster don't fight over the same target. ForceOwnership disables the check.
+func (r *Reconciler) checkManager(target metav1.Object) error {
+	if r.ManagerID == "" || r.ForceOwnership {
+		return nil
+	}
+	manager := target.GetAnnotations()[esv1beta1.AnnotationManager]
+	if manager != "" && manager != r.ManagerID {
+		return fmt.Errorf(errManagedByOther, target.GetName(), manager)
+	}
+	return nil
+}
+
+// setManager stamps the target with the identity of the controller.
+func (r *Reconciler) setManager(target metav1.Object) {
+	if r.ManagerID == "" {
+		return
+	}
+	annotations := target.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[esv1beta1.AnnotationManager] = r.ManagerID
+	target.SetAnnotations(annotations)
+}
@@ -0,0 +1,70 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestCheckManager(t *testing.T) {
+	stamped := func(manager string) *v1.Secret {
+		return &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:        "target",
+			Annotations: map[string]string{esv1beta1.AnnotationManager: manager},
+		}}
+	}
+	tests := []struct {
+		name    string
+		r       *Reconciler
+		target  *v1.Secret
+		wantErr string
+	}{
+		{name: "unstamped target", r: &Reconciler{ManagerID: "team-a"}, target: &v1.Secret{}},
+		{name: "stamped by the controller", r: &Reconciler{ManagerID: "team-a"}, target: stamped("team-a")},
+		{
+			name:    "stamped by another controller",
+			r:       &Reconciler{ManagerID: "team-a"},
+			target:  stamped("team-b"),
+			wantErr: "target target is managed by team-b, start the controller with --force-ownership to take it over",
+		},
+		{name: "forced ownership", r: &Reconciler{ManagerID: "team-a", ForceOwnership: true}, target: stamped("team-b")},
+		{name: "without manager identity", r: &Reconciler{}, target: stamped("team-b")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.r.checkManager(tt.target)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSetManager(t *testing.T) {
+	secret := &v1.Secret{}
+	(&Reconciler{}).setManager(secret)
+	assert.Empty(t, secret.Annotations)
+
+	(&Reconciler{ManagerID: "team-a"}).setManager(secret)
+	assert.Equal(t, map[string]string{esv1beta1.AnnotationManager: "team-a"}, secret.Annotations)
+}
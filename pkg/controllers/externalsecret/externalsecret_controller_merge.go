@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const errRemoveKeys = "could not remove keys %v from secret %s: %w"
+
+// managedKeys returns the keys written by the ExternalSecret: the keys owned by its field manager
+// and the keys listed in the managed-keys annotation. Keys written by older versions of the controller
+// or taken over by another manager are not owned by the field manager anymore, but are still listed in the annotation.
+func managedKeys(secret *v1.Secret, fieldOwner string) ([]string, error) {
+	keys, err := getManagedDataKeys(secret, fieldOwner)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		seen[key] = struct{}{}
+	}
+	for _, key := range strings.Split(secret.Annotations[esv1beta1.AnnotationManagedKeys], ",") {
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// managedKeysAnnotation returns the sorted keys of the data as value of the managed-keys annotation.
+func managedKeysAnnotation(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// removeSecretKeys deletes the keys from the secret with a merge patch.
+// Server-side apply only removes the keys owned by the field manager of the ExternalSecret,
+// the other keys previously written by it would remain in the secret forever.
+func (r *Reconciler) removeSecretKeys(ctx context.Context, secret *v1.Secret, removed map[string][]byte) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	data := make(map[string]any, len(removed))
+	keys := make([]string, 0, len(removed))
+	for k := range removed {
+		data[k] = nil
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	patch, err := json.Marshal(map[string]any{"data": data})
+	if err != nil {
+		return fmt.Errorf(errRemoveKeys, keys, secret.Name, err)
+	}
+	target := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secret.Name, Namespace: secret.Namespace}}
+	if err := r.Patch(ctx, target, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf(errRemoveKeys, keys, secret.Name, err)
+	}
+	return nil
+}
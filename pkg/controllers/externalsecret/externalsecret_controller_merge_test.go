@@ -0,0 +1,82 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestManagedKeys(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{esv1beta1.AnnotationManagedKeys: "annotated"},
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:  "externalsecrets.external-secrets.io/es",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{".":{},"f:owned":{}}}`)},
+				},
+				{
+					Manager:  "kubectl",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:other":{}}}`)},
+				},
+			},
+		},
+	}
+	keys, err := managedKeys(secret, "es")
+	if err != nil {
+		t.Fatalf("managedKeys() error = %v", err)
+	}
+	sort.Strings(keys)
+	if diff := cmp.Diff([]string{"annotated", "owned"}, keys); diff != "" {
+		t.Errorf("managedKeys() mismatch (-want +got):\n%s", diff)
+	}
+
+	got := managedKeysAnnotation(map[string][]byte{"b": nil, "a": nil})
+	if got != "a,b" {
+		t.Errorf("managedKeysAnnotation() = %q, want %q", got, "a,b")
+	}
+}
+
+func TestRemoveSecretKeys(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"},
+		Data: map[string][]byte{
+			"kept":    []byte("1"),
+			"removed": []byte("2"),
+		},
+	}
+	r := newFakeReconciler(secret.DeepCopy())
+	cl := r.Client
+
+	if err := r.removeSecretKeys(context.Background(), secret, map[string][]byte{"removed": []byte("2")}); err != nil {
+		t.Fatalf("removeSecretKeys() error = %v", err)
+	}
+	var got v1.Secret
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(secret), &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string][]byte{"kept": []byte("1")}, got.Data); diff != "" {
+		t.Errorf("unexpected data (-want +got):\n%s", diff)
+	}
+}
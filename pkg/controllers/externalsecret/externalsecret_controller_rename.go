@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errReleasePreviousTarget = "could not release the previous target %s: %w"
+
+	msgPreviousTargetDeleted  = "target renamed from %s to %s, deleted the previous Secret"
+	msgPreviousTargetReleased = "target renamed from %s to %s, removed the managed keys from the previous Secret"
+	msgPreviousTargetKept     = "target renamed from %s to %s, kept the previous Secret"
+)
+
+// releasePreviousTarget handles the Secret synced before the target was renamed.
+// status.binding names it until it is released, so a failed release is retried by the next sync.
+// With creationPolicy Owner the previous Secret is deleted if the ExternalSecret controls it,
+// Merge removes the keys written by the ExternalSecret and Orphan keeps the Secret as it is.
+func (r *Reconciler) releasePreviousTarget(ctx context.Context, es *esv1beta1.ExternalSecret, target string) error {
+	previous := es.Status.Binding.Name
+	if previous == "" || previous == target {
+		return nil
+	}
+	var secret v1.Secret
+	err := r.Get(ctx, types.NamespacedName{Namespace: es.Namespace, Name: previous}, &secret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(errReleasePreviousTarget, previous, err)
+	}
+
+	msg := msgPreviousTargetKept
+	switch es.Spec.Target.CreationPolicy { //nolint:exhaustive
+	case esv1beta1.CreatePolicyOwner:
+		if !metav1.IsControlledBy(&secret, es) {
+			break
+		}
+		if err := r.Delete(ctx, &secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf(errReleasePreviousTarget, previous, err)
+		}
+		msg = msgPreviousTargetDeleted
+	case esv1beta1.CreatePolicyMerge:
+		keys, err := managedKeys(&secret, es.Name)
+		if err != nil {
+			return fmt.Errorf(errReleasePreviousTarget, previous, err)
+		}
+		removed := make(map[string][]byte, len(keys))
+		for _, key := range keys {
+			if val, ok := secret.Data[key]; ok {
+				removed[key] = val
+			}
+		}
+		if err := r.removeSecretKeys(ctx, &secret, removed); err != nil {
+			return fmt.Errorf(errReleasePreviousTarget, previous, err)
+		}
+		msg = msgPreviousTargetReleased
+	}
+	r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonTargetRenamed, fmt.Sprintf(msg, previous, target))
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestReleasePreviousTarget(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default", UID: "es-uid"},
+		Status:     esv1beta1.ExternalSecretStatus{Binding: v1.LocalObjectReference{Name: "old"}},
+	}
+	controlledBy := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: "ExternalSecret", Name: "es", UID: uid, Controller: ptr.To(true)}}
+	}
+	tests := []struct {
+		name     string
+		policy   esv1beta1.ExternalSecretCreationPolicy
+		target   string
+		previous *v1.Secret
+		want     *v1.Secret
+		event    string
+	}{
+		{
+			name:     "owned secret is deleted",
+			policy:   esv1beta1.CreatePolicyOwner,
+			target:   "new",
+			previous: &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old", OwnerReferences: controlledBy("es-uid")}},
+			event:    "Normal TargetRenamed target renamed from old to new, deleted the previous Secret",
+		},
+		{
+			name:     "secret of another controller is kept",
+			policy:   esv1beta1.CreatePolicyOwner,
+			target:   "new",
+			previous: &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old", OwnerReferences: controlledBy("other-uid")}},
+			want:     &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old", OwnerReferences: controlledBy("other-uid")}},
+			event:    "Normal TargetRenamed target renamed from old to new, kept the previous Secret",
+		},
+		{
+			name:     "orphaned secret is kept",
+			policy:   esv1beta1.CreatePolicyOrphan,
+			target:   "new",
+			previous: &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old"}, Data: map[string][]byte{"key": []byte("value")}},
+			want:     &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old"}, Data: map[string][]byte{"key": []byte("value")}},
+			event:    "Normal TargetRenamed target renamed from old to new, kept the previous Secret",
+		},
+		{
+			name:   "merged keys are removed",
+			policy: esv1beta1.CreatePolicyMerge,
+			target: "new",
+			previous: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "old", Annotations: map[string]string{esv1beta1.AnnotationManagedKeys: "key"}},
+				Data:       map[string][]byte{"key": []byte("value"), "other": []byte("kept")},
+			},
+			want: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "old", Annotations: map[string]string{esv1beta1.AnnotationManagedKeys: "key"}},
+				Data:       map[string][]byte{"other": []byte("kept")},
+			},
+			event: "Normal TargetRenamed target renamed from old to new, removed the managed keys from the previous Secret",
+		},
+		{
+			name:   "previous secret is gone",
+			policy: esv1beta1.CreatePolicyOwner,
+			target: "new",
+		},
+		{
+			name:     "target unchanged",
+			policy:   esv1beta1.CreatePolicyOwner,
+			target:   "old",
+			previous: &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old", OwnerReferences: controlledBy("es-uid")}},
+			want:     &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old", OwnerReferences: controlledBy("es-uid")}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var objs []client.Object
+			if tc.previous != nil {
+				previous := tc.previous.DeepCopy()
+				previous.Namespace = es.Namespace
+				objs = append(objs, previous)
+			}
+			r := newFakeReconciler(objs...)
+			recorder := r.recorder.(*record.FakeRecorder)
+			es := es.DeepCopy()
+			es.Spec.Target.CreationPolicy = tc.policy
+
+			if err := r.releasePreviousTarget(context.Background(), es, tc.target); err != nil {
+				t.Fatalf("releasePreviousTarget() error = %v", err)
+			}
+
+			var got v1.Secret
+			err := r.Get(context.Background(), types.NamespacedName{Namespace: es.Namespace, Name: "old"}, &got)
+			switch {
+			case tc.want == nil && !apierrors.IsNotFound(err):
+				t.Errorf("releasePreviousTarget() kept the previous Secret, err = %v", err)
+			case tc.want != nil && err != nil:
+				t.Errorf("releasePreviousTarget() removed the previous Secret: %v", err)
+			case tc.want != nil:
+				if diff := cmp.Diff(tc.want.Data, got.Data); diff != "" {
+					t.Errorf("releasePreviousTarget() data mismatch (-want +got):\n%s", diff)
+				}
+			}
+
+			var event string
+			select {
+			case event = <-recorder.Events:
+			default:
+			}
+			if event != tc.event {
+				t.Errorf("releasePreviousTarget() event = %q, want %q", event, tc.event)
+			}
+		})
+	}
+}
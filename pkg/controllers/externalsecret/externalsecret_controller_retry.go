@@ -0,0 +1,103 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	defaultRetryInitialInterval = 10 * time.Second
+	defaultRetryMaxInterval     = 10 * time.Minute
+)
+
+// retryInterval returns the interval after the consecutive failures of the ExternalSecret,
+// it starts at the initial interval and is doubled after every failure up to the max interval.
+func (r *Reconciler) retryInterval(es *esv1beta1.ExternalSecret) time.Duration {
+	initial, maxInterval := r.RetryInitialInterval, r.RetryMaxInterval
+	if initial <= 0 {
+		initial = defaultRetryInitialInterval
+	}
+	if maxInterval <= 0 {
+		maxInterval = defaultRetryMaxInterval
+	}
+	if policy := es.Spec.RetryPolicy; policy != nil {
+		if policy.InitialInterval != nil {
+			initial = policy.InitialInterval.Duration
+		}
+		if policy.MaxInterval != nil {
+			maxInterval = policy.MaxInterval.Duration
+		}
+	}
+	interval := initial
+	for i := int32(1); i < es.Status.Failures && interval < maxInterval; i++ {
+		interval *= 2
+	}
+	return min(interval, maxInterval)
+}
+
+// scheduleRetry counts the failed sync and schedules the next attempt if the ExternalSecret has a retry policy,
+// otherwise the failed sync is retried by the rate limiter of the controller.
+// The resource version is kept to retry right away once the ExternalSecret changes.
+func (r *Reconciler) scheduleRetry(es *esv1beta1.ExternalSecret) {
+	es.Status.Failures++
+	if es.Spec.RetryPolicy == nil {
+		es.Status.NextRetryTime = nil
+		r.failedVersions.Delete(types.NamespacedName{Namespace: es.Namespace, Name: es.Name})
+		return
+	}
+	next := metav1.NewTime(time.Now().Add(r.retryInterval(es)))
+	es.Status.NextRetryTime = &next
+	r.failedVersions.Store(types.NamespacedName{Namespace: es.Namespace, Name: es.Name}, getResourceVersion(*es))
+}
+
+// resetRetries clears the failures after a successful sync.
+func (r *Reconciler) resetRetries(es *esv1beta1.ExternalSecret) {
+	es.Status.Failures = 0
+	es.Status.NextRetryTime = nil
+	r.failedVersions.Delete(types.NamespacedName{Namespace: es.Namespace, Name: es.Name})
+}
+
+// retryBackoff returns how long the next attempt is still delayed. Reconciles triggered before,
+// e.g. by the status update of the failed sync, are skipped unless the ExternalSecret changed
+// or a refresh was requested.
+func (r *Reconciler) retryBackoff(name types.NamespacedName, es *esv1beta1.ExternalSecret) time.Duration {
+	if es.Status.NextRetryTime == nil {
+		return 0
+	}
+	version, ok := r.failedVersions.Load(name)
+	if !ok || version != getResourceVersion(*es) {
+		return 0
+	}
+	if _, requested := r.refreshRequests.Load(name); requested {
+		return 0
+	}
+	return time.Until(es.Status.NextRetryTime.Time)
+}
+
+// failedResult requeues the ExternalSecret at its next scheduled attempt. Without a scheduled attempt
+// the error is returned, so the failed sync is retried by the rate limiter and counted by the controller metrics.
+func failedResult(es *esv1beta1.ExternalSecret, err error) (ctrl.Result, error) {
+	if es.Status.NextRetryTime == nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: max(time.Until(es.Status.NextRetryTime.Time), time.Second)}, nil
+}
@@ -0,0 +1,193 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestRetryInterval(t *testing.T) {
+	r := &Reconciler{RetryInitialInterval: time.Second, RetryMaxInterval: 10 * time.Second}
+	tests := []struct {
+		name     string
+		failures int32
+		policy   *esv1beta1.ExternalSecretRetryPolicy
+		want     time.Duration
+	}{
+		{name: "first failure", failures: 1, want: time.Second},
+		{name: "doubled", failures: 3, want: 4 * time.Second},
+		{name: "capped", failures: 100, want: 10 * time.Second},
+		{
+			name:     "retry policy",
+			failures: 2,
+			policy: &esv1beta1.ExternalSecretRetryPolicy{
+				InitialInterval: &metav1.Duration{Duration: time.Minute},
+				MaxInterval:     &metav1.Duration{Duration: time.Hour},
+			},
+			want: 2 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &esv1beta1.ExternalSecret{
+				Spec:   esv1beta1.ExternalSecretSpec{RetryPolicy: tt.policy},
+				Status: esv1beta1.ExternalSecretStatus{Failures: tt.failures},
+			}
+			if got := r.retryInterval(es); got != tt.want {
+				t.Errorf("retryInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	r := &Reconciler{}
+	name := types.NamespacedName{Namespace: "default", Name: "es"}
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name, Generation: 1},
+		Spec:       esv1beta1.ExternalSecretSpec{RetryPolicy: &esv1beta1.ExternalSecretRetryPolicy{}},
+	}
+
+	r.scheduleRetry(es)
+	if es.Status.Failures != 1 || es.Status.NextRetryTime == nil {
+		t.Fatalf("scheduleRetry() unexpected status: %+v", es.Status)
+	}
+	if got := r.retryBackoff(name, es); got <= 0 {
+		t.Errorf("retryBackoff() = %v, want the remaining backoff", got)
+	}
+
+	changed := es.DeepCopy()
+	changed.Generation = 2
+	if got := r.retryBackoff(name, changed); got != 0 {
+		t.Errorf("retryBackoff() of a changed ExternalSecret = %v, want 0", got)
+	}
+
+	r.refreshRequests.Store(name, struct{}{})
+	if got := r.retryBackoff(name, es); got != 0 {
+		t.Errorf("retryBackoff() with a refresh request = %v, want 0", got)
+	}
+	r.refreshRequests.Delete(name)
+
+	r.resetRetries(es)
+	if es.Status.Failures != 0 || es.Status.NextRetryTime != nil {
+		t.Errorf("resetRetries() unexpected status: %+v", es.Status)
+	}
+	if got := r.retryBackoff(name, es); got != 0 {
+		t.Errorf("retryBackoff() after a successful sync = %v, want 0", got)
+	}
+
+	// without retry policy the failed syncs are only counted
+	withoutPolicy := es.DeepCopy()
+	withoutPolicy.Spec.RetryPolicy = nil
+	r.scheduleRetry(withoutPolicy)
+	if withoutPolicy.Status.Failures != 1 || withoutPolicy.Status.NextRetryTime != nil {
+		t.Errorf("scheduleRetry() without retry policy unexpected status: %+v", withoutPolicy.Status)
+	}
+}
+
+// statusClient serves the status subresource of the fake client, which only supports updating it.
+type statusClient struct {
+	client.Client
+}
+
+func (c statusClient) SubResource(subResource string) client.SubResourceClient {
+	return statusSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), client: c.Client}
+}
+
+type statusSubResourceClient struct {
+	client.SubResourceClient
+	client client.Client
+}
+
+func (c statusSubResourceClient) Get(ctx context.Context, obj, _ client.Object, _ ...client.SubResourceGetOption) error {
+	return c.client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+}
+
+func TestReconcileFailedSync(t *testing.T) {
+	fakeProvider.Reset()
+	fakeProvider.WithGetSecret(nil, errors.New("boom"))
+	t.Cleanup(fakeProvider.Reset)
+
+	tests := []struct {
+		name        string
+		policy      *esv1beta1.ExternalSecretRetryPolicy
+		wantErr     bool
+		wantRequeue time.Duration
+	}{
+		{
+			name:    "without retry policy the error is returned",
+			wantErr: true,
+		},
+		{
+			name:        "with retry policy the backoff is scheduled",
+			policy:      &esv1beta1.ExternalSecretRetryPolicy{InitialInterval: &metav1.Duration{Duration: time.Minute}},
+			wantRequeue: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "default"},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						AWS: &esv1beta1.AWSProvider{Service: esv1beta1.AWSServiceSecretsManager},
+					},
+				},
+			}
+			es := &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+				Spec: esv1beta1.ExternalSecretSpec{
+					SecretStoreRef:  esv1beta1.SecretStoreRef{Name: "aws"},
+					RefreshInterval: &metav1.Duration{Duration: time.Hour},
+					Target:          esv1beta1.ExternalSecretTarget{Name: "target", CreationPolicy: esv1beta1.CreatePolicyOwner},
+					Data: []esv1beta1.ExternalSecretData{
+						{SecretKey: "key", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "remote"}},
+					},
+					RetryPolicy: tt.policy,
+				},
+			}
+			r := newFakeReconciler()
+			r.Client = statusClient{fake.NewClientBuilder().WithScheme(r.Scheme).
+				WithObjects(store, es).WithStatusSubresource(es).Build()}
+
+			res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "es"}})
+			if tt.wantErr {
+				require.ErrorContains(t, err, "boom")
+				assert.Equal(t, ctrl.Result{}, res)
+			} else {
+				require.NoError(t, err)
+				assert.InDelta(t, tt.wantRequeue, res.RequeueAfter, float64(5*time.Second))
+			}
+
+			var got esv1beta1.ExternalSecret
+			require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "es"}, &got))
+			assert.Equal(t, int32(1), got.Status.Failures)
+			assert.Equal(t, tt.policy != nil, got.Status.NextRetryTime != nil)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	defaultRotationGenerations = 2
+	rotationHashLength         = 10
+	rotationNameTemplate       = "%s-%s"
+
+	errRotateSecret      = "could not rotate secret"
+	errCreateGeneration  = "could not create secret %s: %w"
+	errGetGeneration     = "could not get secret %s: %w"
+	errUpdateGeneration  = "could not update secret %s: %w"
+	errPruneGenerations  = "could not prune previous generations: %w"
+	msgCreatedGeneration = "Created Secret %s"
+)
+
+func isRotationTarget(es *esv1beta1.ExternalSecret) bool {
+	return es.Spec.Target.Immutable && es.Spec.Target.Rotation != nil
+}
+
+// rotationName returns the name of the generation holding the data of the secret.
+func rotationName(alias string, secret *v1.Secret) string {
+	hash := utils.ObjectHash(map[string]any{"type": secret.Type, "data": secret.Data})
+	return fmt.Sprintf(rotationNameTemplate, alias, hash[:rotationHashLength])
+}
+
+// rotateSecret renders the data into the secret and stores it in an immutable secret named <name>-<hash>.
+// A new generation is created whenever the data changes, the current generation is labeled
+// and referenced in status.binding, previous generations exceeding the limit are deleted.
+func (r *Reconciler) rotateSecret(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret, dataMap map[string][]byte, metadata []esv1beta1.SecretMetadata) error {
+	alias := secret.Name
+	if err := r.applyTemplate(ctx, es, secret, dataMap, metadata); err != nil {
+		return fmt.Errorf(errApplyTemplate, err)
+	}
+	if err := r.validateSecretLimits(secret.Data); err != nil {
+		return err
+	}
+	if err := r.validateSecretValues(es, secret.Data); err != nil {
+		return err
+	}
+	secret.Name = rotationName(alias, secret)
+
+	var existing v1.Secret
+	err := r.Get(ctx, types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, &existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf(errGetGeneration, secret.Name, err)
+	}
+	if apierrors.IsNotFound(err) {
+		immutable := true
+		secret.Immutable = &immutable
+		secret.Labels[esv1beta1.LabelRotationOf] = archiveLabelValue(es)
+		secret.Labels[esv1beta1.LabelRotationCurrent] = "true"
+		secret.Annotations[esv1beta1.AnnotationRotationAlias] = alias
+		secret.Annotations[esv1beta1.AnnotationDataHash] = utils.ObjectHash(secret.Data)
+		r.setManager(secret)
+		if es.Spec.Target.CreationPolicy == esv1beta1.CreatePolicyOwner {
+			if err := controllerutil.SetControllerReference(es, secret, r.Scheme); err != nil {
+				return fmt.Errorf(errSetCtrlReference, err)
+			}
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return fmt.Errorf(errCreateGeneration, secret.Name, err)
+		}
+		r.recorder.Event(es, v1.EventTypeNormal, esv1beta1.ReasonCreated, fmt.Sprintf(msgCreatedGeneration, secret.Name))
+	}
+
+	es.Status.Binding = v1.LocalObjectReference{Name: secret.Name}
+	if err := r.pruneGenerations(ctx, es, secret.Name); err != nil {
+		return fmt.Errorf(errPruneGenerations, err)
+	}
+	return nil
+}
+
+// pruneGenerations moves the current label to the given generation and deletes
+// the oldest previous generations exceeding the limit of the ExternalSecret.
+func (r *Reconciler) pruneGenerations(ctx context.Context, es *esv1beta1.ExternalSecret, current string) error {
+	generations := defaultRotationGenerations
+	if es.Spec.Target.Rotation != nil && es.Spec.Target.Rotation.Generations != nil {
+		generations = *es.Spec.Target.Rotation.Generations
+	}
+
+	var list v1.SecretList
+	err := r.List(ctx, &list, client.InNamespace(es.Namespace), client.MatchingLabels{
+		esv1beta1.LabelRotationOf: archiveLabelValue(es),
+	})
+	if err != nil {
+		return err
+	}
+
+	previous := make([]*v1.Secret, 0, len(list.Items))
+	for i := range list.Items {
+		s := &list.Items[i]
+		// labels and annotations of immutable secrets can still be updated
+		isCurrent := s.Name == current
+		if (s.Labels[esv1beta1.LabelRotationCurrent] == "true") != isCurrent {
+			if isCurrent {
+				s.Labels[esv1beta1.LabelRotationCurrent] = "true"
+			} else {
+				delete(s.Labels, esv1beta1.LabelRotationCurrent)
+			}
+			if err := r.Update(ctx, s); err != nil {
+				return fmt.Errorf(errUpdateGeneration, s.Name, err)
+			}
+		}
+		if !isCurrent {
+			previous = append(previous, s)
+		}
+	}
+	if len(previous) <= generations {
+		return nil
+	}
+
+	sort.Slice(previous, func(i, j int) bool {
+		ti, tj := previous[i].CreationTimestamp, previous[j].CreationTimestamp
+		if ti.Equal(&tj) {
+			return previous[i].Name < previous[j].Name
+		}
+		return ti.Before(&tj)
+	})
+	for _, s := range previous[:len(previous)-generations] {
+		if err := r.Delete(ctx, s); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestRotateSecret(t *testing.T) {
+	generations := 1
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				Immutable: true,
+				Rotation: &esv1beta1.ExternalSecretRotation{
+					Strategy:    esv1beta1.RotationStrategySuffix,
+					Generations: &generations,
+				},
+			},
+		},
+	}
+	now := time.Now()
+	generation := func(name string, age time.Duration) client.Object {
+		return &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			Labels: map[string]string{
+				esv1beta1.LabelRotationOf:      archiveLabelValue(es),
+				esv1beta1.LabelRotationCurrent: "true",
+			},
+		}}
+	}
+	// the fake client does not set the creation timestamp,
+	// so the previous generations are created upfront
+	r := newFakeReconciler(
+		generation("target-older", 2*time.Hour),
+		generation("target-old", time.Hour),
+	)
+	cl := r.Client
+
+	rotate := func(value string) string {
+		secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "target", Namespace: "default"}}
+		if err := r.rotateSecret(context.Background(), es, secret, map[string][]byte{"key": []byte(value)}, nil); err != nil {
+			t.Fatalf("rotateSecret() error = %v", err)
+		}
+		return es.Status.Binding.Name
+	}
+
+	current := rotate("v1")
+	if len(current) != len("target-")+rotationHashLength {
+		t.Fatalf("unexpected generation name %q", current)
+	}
+	if again := rotate("v1"); again != current {
+		t.Errorf("unchanged data should keep generation %q, got %q", current, again)
+	}
+
+	var list v1.SecretList
+	if err := cl.List(context.Background(), &list); err != nil {
+		t.Fatal(err)
+	}
+	var names, labeled []string
+	for _, s := range list.Items {
+		names = append(names, s.Name)
+		if s.Labels[esv1beta1.LabelRotationCurrent] == "true" {
+			labeled = append(labeled, s.Name)
+		}
+	}
+	want := []string{current, "target-old"}
+	sort.Strings(want)
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("unexpected generations (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{current}, labeled); diff != "" {
+		t.Errorf("unexpected current generation (-want +got):\n%s", diff)
+	}
+
+	var created v1.Secret
+	if err := cl.Get(context.Background(), types.NamespacedName{Name: current, Namespace: "default"}, &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Annotations[esv1beta1.AnnotationRotationAlias] != "target" {
+		t.Errorf("generation has no alias annotation")
+	}
+	if created.Immutable == nil || !*created.Immutable {
+		t.Errorf("generation should be immutable")
+	}
+	if diff := cmp.Diff(map[string][]byte{"key": []byte("v1")}, created.Data); diff != "" {
+		t.Errorf("unexpected generation data (-want +got):\n%s", diff)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -30,6 +31,7 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
 	// Loading registered providers.
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
 	"github.com/external-secrets/external-secrets/pkg/utils"
@@ -39,26 +41,37 @@ import (
 	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
 )
 
-// getProviderSecretData returns the provider's secret data with the provided ExternalSecret.
-func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (map[string][]byte, error) {
+// getProviderSecretData returns the provider's secret data with the provided ExternalSecret,
+// along with the metadata the providers reported for the secrets they read.
+func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (map[string][]byte, []esv1beta1.SecretMetadata, error) {
 	// We MUST NOT create multiple instances of a provider client (mostly due to limitations with GCP)
 	// Clientmanager keeps track of the client instances
 	// that are created during the fetching process and closes clients
 	// if needed.
-	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate)
+	mgr := secretstore.NewManager(r.Client, r.ControllerClass, r.EnableFloodGate).ForExternalSecret(externalSecret)
 	defer mgr.Close(ctx)
 
-	providerData := make(map[string][]byte)
+	reads, err := readStores(ctx, externalSecret, mgr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merger := newDataFromMerger(externalSecret.Spec.DataFromMergePolicy)
+	var renewal time.Time
 	for i, remoteRef := range externalSecret.Spec.DataFrom {
 		var secretMap map[string][]byte
 		var err error
 
 		if remoteRef.Find != nil {
-			secretMap, err = r.handleFindAllSecrets(ctx, externalSecret, remoteRef, mgr, i)
+			secretMap, err = r.handleFindAllSecrets(ctx, externalSecret, remoteRef, mgr, reads, i)
 		} else if remoteRef.Extract != nil {
-			secretMap, err = r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr, i)
+			secretMap, err = r.handleExtractSecrets(ctx, externalSecret, remoteRef, mgr, reads, i)
 		} else if remoteRef.SourceRef != nil && remoteRef.SourceRef.GeneratorRef != nil {
-			secretMap, err = r.handleGenerateSecrets(ctx, externalSecret.Namespace, remoteRef, i)
+			var renewAt time.Time
+			secretMap, renewAt, err = r.handleGenerateSecrets(ctx, externalSecret.Namespace, remoteRef, i)
+			if !renewAt.IsZero() && (renewal.IsZero() || renewAt.Before(renewal)) {
+				renewal = renewAt
+			}
 		}
 		if errors.Is(err, esv1beta1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1beta1.DeletionPolicyRetain {
 			r.recorder.Event(
@@ -70,31 +83,44 @@ func (r *Reconciler) getProviderSecretData(ctx context.Context, externalSecret *
 			continue
 		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		providerData = utils.MergeByteMap(providerData, secretMap)
+		merger.merge(i, secretMap)
+	}
+	conflicts := merger.conflicts()
+	r.updateDataFromConflicts(externalSecret, conflicts)
+	if conflicts != "" && externalSecret.Spec.DataFromMergePolicy == esv1beta1.DataFromMergePolicyError {
+		return nil, nil, fmt.Errorf(errDataFromConflict, fmt.Sprintf(msgDataFromConflict, conflicts))
 	}
+	providerData := merger.data
 
 	for i, secretRef := range externalSecret.Spec.Data {
-		err := r.handleSecretData(ctx, i, *externalSecret, secretRef, providerData, mgr)
+		err := r.handleSecretData(ctx, i, *externalSecret, secretRef, providerData, mgr, reads)
 		if errors.Is(err, esv1beta1.NoSecretErr) && externalSecret.Spec.Target.DeletionPolicy != esv1beta1.DeletionPolicyRetain {
 			r.recorder.Event(externalSecret, v1.EventTypeNormal, esv1beta1.ReasonDeleted, fmt.Sprintf("secret does not exist at provider using .data[%d] key=%s", i, secretRef.RemoteRef.Key))
 			continue
 		}
 		if err != nil {
-			return nil, fmt.Errorf("error retrieving secret at .data[%d], key: %s, err: %w", i, secretRef.RemoteRef.Key, err)
+			return nil, nil, fmt.Errorf("error retrieving secret at .data[%d], key: %s, err: %w", i, secretRef.RemoteRef.Key, err)
 		}
 	}
 
-	return providerData, nil
+	externalSecret.Status.RenewalTime = nil
+	if !renewal.IsZero() {
+		externalSecret.Status.RenewalTime = &metav1.Time{Time: renewal}
+	}
+	for _, warning := range mgr.StoreWarnings() {
+		r.recorder.Event(externalSecret, v1.EventTypeWarning, esv1beta1.ReasonDeprecated, warning)
+	}
+	r.updateSecretProtections(externalSecret, mgr.SecretProtections())
+	r.updateSecretExpirations(externalSecret, mgr.SecretExpirations())
+	esmetrics.UpdateSourceSecretLifetimes(externalSecret, mgr.SecretLifetimes())
+
+	return providerData, mgr.SecretMetadata(), nil
 }
 
-func (r *Reconciler) handleSecretData(ctx context.Context, i int, externalSecret esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData, providerData map[string][]byte, cmgr *secretstore.Manager) error {
-	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, toStoreGenSourceRef(secretRef.SourceRef))
-	if err != nil {
-		return err
-	}
-	secretData, err := client.GetSecret(ctx, secretRef.RemoteRef)
+func (r *Reconciler) handleSecretData(ctx context.Context, i int, externalSecret esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData, providerData map[string][]byte, cmgr *secretstore.Manager, reads *storeReads) error {
+	secretData, err := getSecretData(ctx, &externalSecret, secretRef, cmgr, reads, i, r.chunkedCapacity(&externalSecret))
 	if err != nil {
 		return err
 	}
@@ -102,10 +128,33 @@ func (r *Reconciler) handleSecretData(ctx context.Context, i int, externalSecret
 	if err != nil {
 		return fmt.Errorf(errDecode, "spec.data", i, err)
 	}
+	secretData, err = utils.Normalize(secretRef.RemoteRef.Normalize, secretData)
+	if err != nil {
+		return fmt.Errorf(errNormalize, "spec.data", i, err)
+	}
 	providerData[secretRef.SecretKey] = secretData
 	return nil
 }
 
+// getSecretData returns the secret of a data entry, either read from the secretStoreRefs
+// of the ExternalSecret or fetched from the store of the entry.
+// With a stream limit the secret is streamed from stores that support it, reading at most limit bytes.
+func getSecretData(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, secretRef esv1beta1.ExternalSecretData,
+	cmgr *secretstore.Manager, reads *storeReads, i int, streamLimit int64) ([]byte, error) {
+	if reads != nil && secretRef.SourceRef == nil {
+		read := reads.data[i]
+		return read.secretMap[secretRef.SecretKey], read.err
+	}
+	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, toStoreGenSourceRef(secretRef.SourceRef))
+	if err != nil {
+		return nil, err
+	}
+	if streamer, ok := client.(esv1beta1.SecretStreamer); ok && streamLimit > 0 {
+		return readSecretStream(ctx, streamer, secretRef.RemoteRef, streamLimit)
+	}
+	return client.GetSecret(ctx, secretRef.RemoteRef)
+}
+
 func toStoreGenSourceRef(ref *esv1beta1.StoreSourceRef) *esv1beta1.StoreGeneratorSourceRef {
 	if ref == nil {
 		return nil
@@ -115,27 +164,39 @@ func toStoreGenSourceRef(ref *esv1beta1.StoreSourceRef) *esv1beta1.StoreGenerato
 	}
 }
 
-func (r *Reconciler) handleGenerateSecrets(ctx context.Context, namespace string, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, i int) (map[string][]byte, error) {
+// handleGenerateSecrets calls the generator of the dataFrom entry. For generators whose values expire
+// it also returns the time the generated values must be renewed, otherwise the zero time.
+func (r *Reconciler) handleGenerateSecrets(ctx context.Context, namespace string, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, i int) (map[string][]byte, time.Time, error) {
 	genDef, err := r.getGeneratorDefinition(ctx, namespace, remoteRef.SourceRef.GeneratorRef)
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 	gen, err := genv1alpha1.GetGenerator(genDef)
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
+	}
+	if _, ok := gen.(genv1alpha1.RotationOnly); ok {
+		return nil, time.Time{}, fmt.Errorf(errRotationOnly, i, remoteRef.SourceRef.GeneratorRef.Kind)
 	}
 	secretMap, err := gen.Generate(ctx, genDef, r.Client, namespace)
 	if err != nil {
-		return nil, fmt.Errorf(errGenerate, i, err)
+		return nil, time.Time{}, fmt.Errorf(errGenerate, i, err)
+	}
+	var renewAt time.Time
+	if renewer, ok := gen.(genv1alpha1.Renewer); ok {
+		renewAt, err = renewer.RenewalTime(genDef, secretMap)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf(errGenerate, i, err)
+		}
 	}
 	secretMap, err = utils.RewriteMap(remoteRef.Rewrite, secretMap)
 	if err != nil {
-		return nil, fmt.Errorf(errRewrite, i, err)
+		return nil, time.Time{}, fmt.Errorf(errRewrite, i, err)
 	}
 	if !utils.ValidateKeys(secretMap) {
-		return nil, fmt.Errorf(errInvalidKeys, "generator", i)
+		return nil, time.Time{}, fmt.Errorf(errInvalidKeys, "generator", i)
 	}
-	return secretMap, err
+	return secretMap, renewAt, err
 }
 
 // getGeneratorDefinition returns the generator JSON for a given sourceRef
@@ -181,14 +242,16 @@ func (r *Reconciler) getGeneratorDefinition(ctx context.Context, namespace strin
 	return &apiextensions.JSON{Raw: jsonRes}, nil
 }
 
-func (r *Reconciler) handleExtractSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, cmgr *secretstore.Manager, i int) (map[string][]byte, error) {
-	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, remoteRef.SourceRef)
+func (r *Reconciler) handleExtractSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, cmgr *secretstore.Manager, reads *storeReads, i int) (map[string][]byte, error) {
+	secretMap, err := getDataFromSecrets(ctx, externalSecret, remoteRef, cmgr, reads, i, func(client esv1beta1.SecretsClient) (map[string][]byte, error) {
+		return client.GetSecretMap(ctx, *remoteRef.Extract)
+	})
 	if err != nil {
 		return nil, err
 	}
-	secretMap, err := client.GetSecretMap(ctx, *remoteRef.Extract)
+	secretMap, err = utils.FilterMap(remoteRef.Extract.FilterPath, secretMap)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf(errFilter, i, err)
 	}
 	secretMap, err = utils.RewriteMap(remoteRef.Rewrite, secretMap)
 	if err != nil {
@@ -207,17 +270,27 @@ func (r *Reconciler) handleExtractSecrets(ctx context.Context, externalSecret *e
 	if err != nil {
 		return nil, fmt.Errorf(errDecode, "spec.dataFrom", i, err)
 	}
+	secretMap, err = utils.NormalizeMap(remoteRef.Extract.Normalize, secretMap)
+	if err != nil {
+		return nil, fmt.Errorf(errNormalize, "spec.dataFrom", i, err)
+	}
 	return secretMap, err
 }
 
-func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, cmgr *secretstore.Manager, i int) (map[string][]byte, error) {
-	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, remoteRef.SourceRef)
+func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef, cmgr *secretstore.Manager, reads *storeReads, i int) (map[string][]byte, error) {
+	secretMap, err := getDataFromSecrets(ctx, externalSecret, remoteRef, cmgr, reads, i, func(client esv1beta1.SecretsClient) (map[string][]byte, error) {
+		return client.GetAllSecrets(ctx, *remoteRef.Find)
+	})
 	if err != nil {
 		return nil, err
 	}
-	secretMap, err := client.GetAllSecrets(ctx, *remoteRef.Find)
-	if err != nil {
-		return nil, err
+	if remoteRef.Bundle != nil {
+		// the keys are dropped, so only the values are decoded
+		secretMap, err = utils.DecodeMap(remoteRef.Find.DecodingStrategy, secretMap)
+		if err != nil {
+			return nil, fmt.Errorf(errDecode, "spec.dataFrom", i, err)
+		}
+		return certificateBundle(remoteRef.Bundle, secretMap, i, time.Now())
 	}
 	secretMap, err = utils.RewriteMap(remoteRef.Rewrite, secretMap)
 	if err != nil {
@@ -238,9 +311,28 @@ func (r *Reconciler) handleFindAllSecrets(ctx context.Context, externalSecret *e
 	if err != nil {
 		return nil, fmt.Errorf(errDecode, "spec.dataFrom", i, err)
 	}
+	secretMap, err = utils.NormalizeMap(remoteRef.Find.Normalize, secretMap)
+	if err != nil {
+		return nil, fmt.Errorf(errNormalize, "spec.dataFrom", i, err)
+	}
 	return secretMap, err
 }
 
+// getDataFromSecrets returns the secrets of a dataFrom entry, either read from the secretStoreRefs
+// of the ExternalSecret or fetched from the store of the entry.
+func getDataFromSecrets(ctx context.Context, externalSecret *esv1beta1.ExternalSecret, remoteRef esv1beta1.ExternalSecretDataFromRemoteRef,
+	cmgr *secretstore.Manager, reads *storeReads, i int, fetch func(esv1beta1.SecretsClient) (map[string][]byte, error)) (map[string][]byte, error) {
+	if reads != nil && usesStoreRefs(remoteRef.SourceRef) {
+		read := reads.dataFrom[i]
+		return read.secretMap, read.err
+	}
+	client, err := cmgr.Get(ctx, externalSecret.Spec.SecretStoreRef, externalSecret.Namespace, remoteRef.SourceRef)
+	if err != nil {
+		return nil, err
+	}
+	return fetch(client)
+}
+
 func shouldSkipGenerator(r *Reconciler, generatorDef *apiextensions.JSON) (bool, error) {
 	var genControllerClass genv1alpha1.ControllerClassResource
 	err := json.Unmarshal(generatorDef.Raw, &genControllerClass)
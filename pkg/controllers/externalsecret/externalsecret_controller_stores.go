@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const errReadStore = "could not read from %s %s: %w"
+
+// storeReads holds the entries read from the secretStoreRefs of an ExternalSecret, indexed by entry.
+type storeReads struct {
+	data     map[int]*storeRead
+	dataFrom map[int]*storeRead
+}
+
+// storeRead is the merged result of an entry read from multiple stores.
+type storeRead struct {
+	secretMap map[string][]byte
+	err       error
+}
+
+// add merges the result of a store into the read, values of later stores take precedence.
+// Stores that do not have the secret are skipped, the read fails with NoSecretErr
+// only if none of the stores has it.
+func (s *storeRead) add(secretMap map[string][]byte, err error) error {
+	if errors.Is(err, esv1beta1.NoSecretErr) {
+		if s.secretMap == nil {
+			s.err = err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if s.secretMap == nil {
+		s.secretMap = make(map[string][]byte)
+	}
+	s.secretMap = utils.MergeByteMap(s.secretMap, secretMap)
+	s.err = nil
+	return nil
+}
+
+func (s *storeReads) get(reads map[int]*storeRead, i int) *storeRead {
+	if reads[i] == nil {
+		reads[i] = &storeRead{}
+	}
+	return reads[i]
+}
+
+// readStores reads the data and dataFrom entries that do not set a store in their sourceRef
+// from each of the secretStoreRefs of the ExternalSecret. The entries are read store by store:
+// the client manager keeps a single client per provider type, reading entry by entry would
+// create a new client for each entry if multiple stores use the same provider.
+// It returns nil if the ExternalSecret does not use secretStoreRefs.
+func readStores(ctx context.Context, es *esv1beta1.ExternalSecret, mgr *secretstore.Manager) (*storeReads, error) {
+	if len(es.Spec.SecretStoreRefs) == 0 {
+		return nil, nil
+	}
+	reads := &storeReads{
+		data:     make(map[int]*storeRead),
+		dataFrom: make(map[int]*storeRead),
+	}
+	for _, ref := range es.Spec.SecretStoreRefs {
+		client, err := mgr.Get(ctx, ref, es.Namespace, nil)
+		if err != nil {
+			return nil, err
+		}
+		for i, remoteRef := range es.Spec.DataFrom {
+			if !usesStoreRefs(remoteRef.SourceRef) {
+				continue
+			}
+			var secretMap map[string][]byte
+			switch {
+			case remoteRef.Find != nil:
+				secretMap, err = client.GetAllSecrets(ctx, *remoteRef.Find)
+			case remoteRef.Extract != nil:
+				secretMap, err = client.GetSecretMap(ctx, *remoteRef.Extract)
+			default:
+				continue
+			}
+			if err := reads.get(reads.dataFrom, i).add(secretMap, err); err != nil {
+				return nil, fmt.Errorf(errReadStore, storeKind(ref), ref.Name, err)
+			}
+		}
+		for i, secretRef := range es.Spec.Data {
+			if secretRef.SourceRef != nil {
+				continue
+			}
+			value, err := client.GetSecret(ctx, secretRef.RemoteRef)
+			if err := reads.get(reads.data, i).add(map[string][]byte{secretRef.SecretKey: value}, err); err != nil {
+				return nil, fmt.Errorf(errReadStore, storeKind(ref), ref.Name, err)
+			}
+		}
+	}
+	return reads, nil
+}
+
+// usesStoreRefs returns true if a dataFrom entry is read from the stores of the ExternalSecret.
+func usesStoreRefs(sourceRef *esv1beta1.StoreGeneratorSourceRef) bool {
+	return sourceRef == nil || (sourceRef.SecretStoreRef == nil && sourceRef.GeneratorRef == nil)
+}
+
+func storeKind(ref esv1beta1.SecretStoreRef) string {
+	if ref.Kind == "" {
+		return esv1beta1.SecretStoreKind
+	}
+	return ref.Kind
+}
@@ -0,0 +1,118 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+)
+
+func TestReadStores(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := esv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	newStore := func(name string, data ...esv1beta1.FakeProviderData) *esv1beta1.SecretStore {
+		return &esv1beta1.SecretStore{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: esv1beta1.SecretStoreSpec{
+				Provider: &esv1beta1.SecretStoreProvider{
+					Fake: &esv1beta1.FakeProvider{Data: data},
+				},
+			},
+		}
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newStore("base",
+			esv1beta1.FakeProviderData{Key: "shared", Value: "base"},
+			esv1beta1.FakeProviderData{Key: "base-only", Value: "base"},
+			esv1beta1.FakeProviderData{Key: "config", ValueMap: map[string]string{"host": "base", "port": "5432"}},
+		),
+		newStore("override",
+			esv1beta1.FakeProviderData{Key: "shared", Value: "override"},
+			esv1beta1.FakeProviderData{Key: "config", ValueMap: map[string]string{"host": "override"}},
+		),
+	).Build()
+
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRefs: []esv1beta1.SecretStoreRef{{Name: "base"}, {Name: "override"}},
+			Data: []esv1beta1.ExternalSecretData{
+				{SecretKey: "shared", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "shared"}},
+				{SecretKey: "base-only", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "base-only"}},
+				{SecretKey: "missing", RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"}},
+				{
+					SecretKey: "explicit",
+					RemoteRef: esv1beta1.ExternalSecretDataRemoteRef{Key: "shared"},
+					SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "base"}},
+				},
+			},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{Extract: &esv1beta1.ExternalSecretDataRemoteRef{Key: "config"}},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	mgr := secretstore.NewManager(kube, "", false)
+	defer mgr.Close(ctx)
+	reads, err := readStores(ctx, es, mgr)
+	if err != nil {
+		t.Fatalf("readStores() error = %v", err)
+	}
+
+	for i, want := range []string{"override", "base"} {
+		got, err := getSecretData(ctx, es, es.Spec.Data[i], mgr, reads, i, 0)
+		if err != nil {
+			t.Errorf("data[%d]: unexpected error %v", i, err)
+		}
+		if string(got) != want {
+			t.Errorf("data[%d] = %q, want %q", i, got, want)
+		}
+	}
+	if _, err := getSecretData(ctx, es, es.Spec.Data[2], mgr, reads, 2, 0); !errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Errorf("data[2]: expected NoSecretErr, got %v", err)
+	}
+	got, err := getSecretData(ctx, es, es.Spec.Data[3], mgr, reads, 3, 0)
+	if err != nil || string(got) != "base" {
+		t.Errorf("data[3] with sourceRef = %q, %v, want %q", got, err, "base")
+	}
+
+	secretMap, err := getDataFromSecrets(ctx, es, es.Spec.DataFrom[0], mgr, reads, 0, nil)
+	if err != nil {
+		t.Fatalf("dataFrom[0]: unexpected error %v", err)
+	}
+	want := map[string][]byte{"host": []byte("override"), "port": []byte("5432")}
+	if diff := cmp.Diff(want, secretMap); diff != "" {
+		t.Errorf("dataFrom[0] mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadStoresWithoutStoreRefs(t *testing.T) {
+	reads, err := readStores(context.Background(), &esv1beta1.ExternalSecret{}, nil)
+	if err != nil || reads != nil {
+		t.Errorf("readStores() = %v, %v, want nil", reads, err)
+	}
+}
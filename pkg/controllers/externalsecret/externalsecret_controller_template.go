@@ -16,7 +16,9 @@ package externalsecret
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 
@@ -28,11 +30,23 @@ import (
 	_ "github.com/external-secrets/external-secrets/pkg/provider/register" // Loading registered providers.
 )
 
+// providerMetadataKey holds the provider metadata in the label and annotation templates,
+// unless the data has a key of the same name.
+const providerMetadataKey = "providerMetadata"
+
+// templateMetadata is the metadata of a provider secret as exposed to the templates.
+type templateMetadata struct {
+	Version        string            `json:"version"`
+	LastModifiedAt string            `json:"lastModifiedAt"`
+	Tags           map[string]string `json:"tags"`
+}
+
 // merge template in the following order:
 // * template.Data (highest precedence)
 // * template.templateFrom
 // * secret via es.data or es.dataFrom.
-func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret, dataMap map[string][]byte) error {
+// The metadata of the provider secrets is available in the label and annotation templates.
+func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSecret, secret *v1.Secret, dataMap map[string][]byte, metadata []esv1beta1.SecretMetadata) error {
 	if err := setMetadata(secret, es); err != nil {
 		return err
 	}
@@ -70,6 +84,10 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 		return fmt.Errorf(errExecTpl, err)
 	}
 
+	p.DataMap, err = withProviderMetadata(dataMap, metadata)
+	if err != nil {
+		return fmt.Errorf(errExecTpl, err)
+	}
 	// get template data for labels
 	err = p.MergeMap(es.Spec.Target.Template.Metadata.Labels, esv1beta1.TemplateTargetLabels)
 	if err != nil {
@@ -88,6 +106,35 @@ func (r *Reconciler) applyTemplate(ctx context.Context, es *esv1beta1.ExternalSe
 	return nil
 }
 
+// withProviderMetadata returns the data with the provider metadata as JSON object keyed by
+// the provider key in providerMetadataKey, e.g. {"db/creds": {"version": "42", ...}}.
+func withProviderMetadata(dataMap map[string][]byte, metadata []esv1beta1.SecretMetadata) (map[string][]byte, error) {
+	if _, exists := dataMap[providerMetadataKey]; exists || len(metadata) == 0 {
+		return dataMap, nil
+	}
+	byKey := make(map[string]templateMetadata, len(metadata))
+	for _, m := range metadata {
+		tm := templateMetadata{Version: m.Version, Tags: make(map[string]string, len(m.Tags))}
+		if !m.LastModified.IsZero() {
+			tm.LastModifiedAt = m.LastModified.UTC().Format(time.RFC3339)
+		}
+		for k, v := range m.Tags {
+			tm.Tags[k] = v
+		}
+		byKey[m.Key] = tm
+	}
+	encoded, err := json.Marshal(byKey)
+	if err != nil {
+		return nil, err
+	}
+	withMetadata := make(map[string][]byte, len(dataMap)+1)
+	for k, v := range dataMap {
+		withMetadata[k] = v
+	}
+	withMetadata[providerMetadataKey] = encoded
+	return withMetadata, nil
+}
+
 // setMetadata sets Labels and Annotations to the given secret.
 func setMetadata(secret *v1.Secret, es *esv1beta1.ExternalSecret) error {
 	if secret.Labels == nil {
@@ -0,0 +1,72 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestApplyTemplateProviderMetadata(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "es", Namespace: "default"},
+		Spec: esv1beta1.ExternalSecretSpec{
+			Target: esv1beta1.ExternalSecretTarget{
+				Template: &esv1beta1.ExternalSecretTemplate{
+					EngineVersion: esv1beta1.TemplateEngineV2,
+					Metadata: esv1beta1.ExternalSecretTemplateMetadata{
+						Labels: map[string]string{
+							"vault-version": `{{ (index (.providerMetadata | fromJson) "db/creds").version }}`,
+						},
+						Annotations: map[string]string{
+							"last-modified": `{{ (index (.providerMetadata | fromJson) "db/creds").lastModifiedAt }}`,
+							"owner":         `{{ (index (.providerMetadata | fromJson) "db/creds").tags.owner }}`,
+						},
+					},
+				},
+			},
+		},
+	}
+	metadata := []esv1beta1.SecretMetadata{{
+		Key:          "db/creds",
+		Version:      "42",
+		LastModified: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Tags:         map[string]string{"owner": "team-a"},
+	}}
+	secret := &v1.Secret{Data: map[string][]byte{}}
+	r := &Reconciler{}
+	dataMap := map[string][]byte{"password": []byte("secret")}
+	if err := r.applyTemplate(context.Background(), es, secret, dataMap, metadata); err != nil {
+		t.Fatalf("applyTemplate() error = %v", err)
+	}
+	if got := secret.Labels["vault-version"]; got != "42" {
+		t.Errorf("applyTemplate() label vault-version = %q, want %q", got, "42")
+	}
+	if got := secret.Annotations["last-modified"]; got != "2024-03-01T12:00:00Z" {
+		t.Errorf("applyTemplate() annotation last-modified = %q", got)
+	}
+	if got := secret.Annotations["owner"]; got != "team-a" {
+		t.Errorf("applyTemplate() annotation owner = %q, want %q", got, "team-a")
+	}
+	if _, ok := secret.Data[providerMetadataKey]; ok {
+		t.Errorf("applyTemplate() wrote the provider metadata into the secret data")
+	}
+}
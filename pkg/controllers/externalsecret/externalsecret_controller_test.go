@@ -306,6 +306,34 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// target.kind=ConfigMap writes the data to a ConfigMap instead of a Secret
+	syncConfigMap := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.Kind = esv1beta1.TargetKindConfigMap
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.checkExternalSecret = func(es *esv1beta1.ExternalSecret) {
+			cm := &v1.ConfigMap{}
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), types.NamespacedName{
+					Name:      ExternalSecretTargetSecretName,
+					Namespace: ExternalSecretNamespace,
+				}, cm)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(cm.Data[targetProp]).To(Equal(secretVal))
+			Expect(cm.Annotations[esv1beta1.AnnotationDataHash]).To(Equal(utils.ObjectHash(configMapData(cm))))
+			Expect(ctest.HasOwnerRef(cm.ObjectMeta, "ExternalSecret", ExternalSecretName)).To(BeTrue())
+			Expect(es.Status.Binding.Name).To(Equal(cm.Name))
+
+			// no secret should be created
+			err := k8sClient.Get(context.Background(), types.NamespacedName{
+				Name:      ExternalSecretTargetSecretName,
+				Namespace: ExternalSecretNamespace,
+			}, &v1.Secret{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}
+	}
+
 	// their is no binding secret when a secret is not synced
 	skipBindingSecret := func(tc *testCase) {
 		tc.externalSecret.Spec.Target.CreationPolicy = esv1beta1.CreatePolicyNone
@@ -367,6 +395,42 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		}
 	}
 
+	// a secret controlled by another resource is taken over
+	// when it is annotated with the allow-adoption annotation
+	adoptSecretControlledByOther := func(tc *testCase) {
+		isController := true
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ExternalSecretTargetSecretName,
+				Namespace: ExternalSecretNamespace,
+				Annotations: map[string]string{
+					esv1beta1.AnnotationAllowAdoption: "true",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       "previous-owner",
+						UID:        "0f3a4c5e-8d3c-4b52-9c6a-2d5e8f1e2a11",
+						Controller: &isController,
+					},
+				},
+			},
+		}, client.FieldOwner(FakeManager))).To(Succeed())
+
+		tc.checkSecret = func(es *esv1beta1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+			Expect(ctest.HasOwnerRef(secret.ObjectMeta, "ExternalSecret", ExternalSecretName)).To(BeTrue())
+			Expect(ctest.HasOwnerRef(secret.ObjectMeta, "Deployment", "previous-owner")).To(BeFalse())
+
+			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretAdopted)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(v1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(esv1beta1.ConditionReasonTargetAdopted))
+		}
+	}
+
 	removeOutdatedLabelsAnnotations := func(tc *testCase) {
 		tc.externalSecret.ObjectMeta.Labels = map[string]string{
 			"label-key": "label-value",
@@ -1843,6 +1907,14 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 			*tc.externalSecret,
 		)).To(BeTrue())
 
+		// a ClusterSecretStore referenced by a data entry is ignored as well
+		sourceRef := tc.externalSecret.DeepCopy()
+		sourceRef.Spec.SecretStoreRef.Kind = esv1beta1.SecretStoreKind
+		sourceRef.Spec.Data[0].SourceRef = &esv1beta1.StoreSourceRef{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "shared", Kind: esv1beta1.ClusterSecretStoreKind},
+		}
+		Expect(clusterSecretStoreRef(*sourceRef)).To(Equal("shared"))
+
 		tc.checkCondition = func(es *esv1beta1.ExternalSecret) bool {
 			cond := GetExternalSecretCondition(es.Status, esv1beta1.ExternalSecretReady)
 			return cond == nil
@@ -2254,9 +2326,11 @@ var _ = Describe("ExternalSecret controller", Serial, func() {
 		Entry("should sync to target secrets with naming bigger than 63 characters", syncBigNames),
 		Entry("should expose the secret as a provisioned service binding secret", syncBindingSecret),
 		Entry("should not expose a provisioned service when no secret is synced", skipBindingSecret),
+		Entry("should sync to a ConfigMap with target.kind=ConfigMap", syncConfigMap),
 		Entry("should set labels and annotations from the ExternalSecret", syncLabelsAnnotations),
 		Entry("should merge labels and annotations to the ones owned by other entity", mergeLabelsAnnotations),
 		Entry("should removed outdated labels and annotations", removeOutdatedLabelsAnnotations),
+		Entry("should adopt a secret controlled by another resource with the allow-adoption annotation", adoptSecretControlledByOther),
 		Entry("should set prometheus counters", checkPrometheusCounters),
 		Entry("should merge with existing secret using creationPolicy=Merge", mergeWithSecret),
 		Entry("should kick reconciliation when secret changes using creationPolicy=Merge", mergeWithSecretUpdate),
@@ -2435,6 +2509,39 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 			Expect(shouldRefresh(es)).To(BeTrue())
 		})
 
+		It("should refresh when generated values must be renewed", func() {
+			es := esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 1,
+				},
+				Spec: esv1beta1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: 0},
+				},
+				Status: esv1beta1.ExternalSecretStatus{
+					RefreshTime: metav1.Now(),
+					RenewalTime: &metav1.Time{Time: time.Now().Add(time.Hour)},
+				},
+			}
+			es.Status.SyncedResourceVersion = getResourceVersion(es)
+			Expect(shouldRefresh(es)).To(BeFalse())
+
+			es.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Second)}
+			Expect(shouldRefresh(es)).To(BeTrue())
+		})
+
+		It("should requeue at the renewal time", func() {
+			es := esv1beta1.ExternalSecret{}
+			Expect(requeueAfter(&es, time.Hour)).To(Equal(time.Hour))
+
+			es.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(10 * time.Minute)}
+			Expect(requeueAfter(&es, time.Hour)).To(BeNumerically("~", 10*time.Minute, time.Second))
+			Expect(requeueAfter(&es, 0)).To(BeNumerically("~", 10*time.Minute, time.Second))
+			Expect(requeueAfter(&es, time.Minute)).To(Equal(time.Minute))
+
+			es.Status.RenewalTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			Expect(requeueAfter(&es, time.Hour)).To(Equal(time.Second))
+		})
+
 	})
 	Context("objectmeta hash", func() {
 		It("should produce different hashes for different k/v pairs", func() {
@@ -2535,6 +2642,21 @@ var _ = Describe("Controller Reconcile logic", func() {
 				},
 			})).To(BeFalse())
 		})
+
+		It("should reconcile if secret is immutable with rotation and has synced condition", func() {
+			Expect(shouldReconcile(esv1beta1.ExternalSecret{
+				Spec: esv1beta1.ExternalSecretSpec{
+					Target: esv1beta1.ExternalSecretTarget{
+						Immutable: true,
+						Rotation:  &esv1beta1.ExternalSecretRotation{Strategy: esv1beta1.RotationStrategySuffix},
+					},
+				},
+				Status: esv1beta1.ExternalSecretStatus{
+					SyncedResourceVersion: "some resource version",
+					Conditions:            []esv1beta1.ExternalSecretStatusCondition{{Reason: "SecretSynced"}},
+				},
+			})).To(BeTrue())
+		})
 	})
 })
 
@@ -0,0 +1,172 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errValidationFailed = "rendered data failed the validation: %s"
+	errTruncatedPEM     = "key %q holds a truncated or invalid PEM block"
+	errInvalidJSON      = "key %q holds invalid JSON"
+	errLowEntropy       = "key %q holds a password with an entropy of %.0f bits, below the minimum of %d bits"
+
+	defaultMinPasswordEntropy = 32
+)
+
+var (
+	pemBegin            = []byte("-----BEGIN ")
+	defaultPasswordKeys = regexp.MustCompile(`(?i)(password|passwd|pwd)`)
+	// jsonKeys hold JSON by the Kubernetes secret types.
+	jsonKeys = map[string]bool{
+		v1.DockerConfigJsonKey: true,
+		v1.DockerConfigKey:     true,
+	}
+)
+
+// secretValidationError is returned if the rendered values fail the validation,
+// its message is used as status message so the invalid keys can be spotted.
+type secretValidationError struct {
+	msg string
+}
+
+func (e *secretValidationError) Error() string {
+	return e.msg
+}
+
+// validateSecretValues checks the rendered values according to target.validation.
+// With action Warn a warning event is recorded and the values are written anyway.
+// The messages name the keys only, the values must not leak into the status.
+func (r *Reconciler) validateSecretValues(es *esv1beta1.ExternalSecret, data map[string][]byte) error {
+	validation := es.Spec.Target.Validation
+	if validation == nil {
+		return nil
+	}
+	findings, err := lintValues(validation, data)
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf(errValidationFailed, strings.Join(findings, ", "))
+	if validation.Action == esv1beta1.ValidationActionWarn {
+		r.recorder.Event(es, v1.EventTypeWarning, esv1beta1.ConditionReasonSecretValidationFailed, msg)
+		return nil
+	}
+	return &secretValidationError{msg: msg}
+}
+
+// lintValues returns the findings of the values, ordered by key.
+func lintValues(validation *esv1beta1.ExternalSecretValidation, data map[string][]byte) ([]string, error) {
+	passwordKeys := []*regexp.Regexp{defaultPasswordKeys}
+	if len(validation.PasswordKeys) > 0 {
+		passwordKeys = make([]*regexp.Regexp, len(validation.PasswordKeys))
+		for i, exp := range validation.PasswordKeys {
+			re, err := regexp.Compile(exp)
+			if err != nil {
+				return nil, fmt.Errorf("invalid passwordKeys regex %q: %w", exp, err)
+			}
+			passwordKeys[i] = re
+		}
+	}
+	minEntropy := int32(defaultMinPasswordEntropy)
+	if validation.MinPasswordEntropy != nil {
+		minEntropy = *validation.MinPasswordEntropy
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var findings []string
+	for _, key := range keys {
+		val := data[key]
+		if !validPEM(val) {
+			findings = append(findings, fmt.Sprintf(errTruncatedPEM, key))
+		}
+		if jsonKeys[key] && !json.Valid(val) {
+			findings = append(findings, fmt.Sprintf(errInvalidJSON, key))
+		}
+		if minEntropy > 0 && matchesAny(passwordKeys, key) {
+			if entropy := shannonEntropy(val); entropy < float64(minEntropy) {
+				findings = append(findings, fmt.Sprintf(errLowEntropy, key, entropy, minEntropy))
+			}
+		}
+	}
+	return findings, nil
+}
+
+// validPEM returns false if the value holds a PEM block that can't be decoded,
+// e.g. because the END line or a part of the base64 body was cut off.
+func validPEM(val []byte) bool {
+	begins := bytes.Count(val, pemBegin)
+	if begins == 0 {
+		return true
+	}
+	decoded := 0
+	for rest := val; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		decoded++
+	}
+	return decoded == begins
+}
+
+func matchesAny(res []*regexp.Regexp, key string) bool {
+	for _, re := range res {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// shannonEntropy returns the entropy of the value in bits,
+// the Shannon entropy of its bytes multiplied by its length.
+func shannonEntropy(val []byte) float64 {
+	if len(val) == 0 {
+		return 0
+	}
+	var counts [256]int
+	for _, b := range val {
+		counts[b]++
+	}
+	perByte := 0.0
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / float64(len(val))
+		perByte -= p * math.Log2(p)
+	}
+	return perByte * float64(len(val))
+}
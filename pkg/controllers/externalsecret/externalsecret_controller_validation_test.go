@@ -0,0 +1,117 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestValidateSecretValues(t *testing.T) {
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(strings.Repeat("certificate", 20))})
+	zero := int32(0)
+	tests := []struct {
+		name       string
+		validation *esv1beta1.ExternalSecretValidation
+		data       map[string][]byte
+		wantErr    string
+		wantEvent  bool
+	}{
+		{
+			name: "no validation",
+			data: map[string][]byte{"tls.crt": cert[:50]},
+		},
+		{
+			name:       "valid values",
+			validation: &esv1beta1.ExternalSecretValidation{},
+			data: map[string][]byte{
+				"tls.crt":           append(append([]byte{}, cert...), cert...),
+				".dockerconfigjson": []byte(`{"auths":{}}`),
+				"password":          []byte("x8#Kq2!vLm9@pZ4w"),
+			},
+		},
+		{
+			name:       "truncated PEM block",
+			validation: &esv1beta1.ExternalSecretValidation{},
+			data:       map[string][]byte{"tls.crt": cert[:len(cert)-20]},
+			wantErr:    `rendered data failed the validation: key "tls.crt" holds a truncated or invalid PEM block`,
+		},
+		{
+			name:       "truncated second PEM block",
+			validation: &esv1beta1.ExternalSecretValidation{},
+			data:       map[string][]byte{"ca.crt": append(append([]byte{}, cert...), cert[:50]...)},
+			wantErr:    `rendered data failed the validation: key "ca.crt" holds a truncated or invalid PEM block`,
+		},
+		{
+			name:       "invalid docker config",
+			validation: &esv1beta1.ExternalSecretValidation{},
+			data:       map[string][]byte{".dockerconfigjson": []byte(`{"auths":`)},
+			wantErr:    `rendered data failed the validation: key ".dockerconfigjson" holds invalid JSON`,
+		},
+		{
+			name:       "low entropy password",
+			validation: &esv1beta1.ExternalSecretValidation{},
+			data:       map[string][]byte{"DB_PASSWORD": []byte("aaaaaaaa"), "username": []byte("aaaaaaaa")},
+			wantErr:    `rendered data failed the validation: key "DB_PASSWORD" holds a password with an entropy of 0 bits, below the minimum of 32 bits`,
+		},
+		{
+			name:       "custom password keys",
+			validation: &esv1beta1.ExternalSecretValidation{PasswordKeys: []string{`^secret$`}},
+			data:       map[string][]byte{"password": []byte("aaaaaaaa"), "secret": []byte("abab")},
+			wantErr:    `rendered data failed the validation: key "secret" holds a password with an entropy of 4 bits, below the minimum of 32 bits`,
+		},
+		{
+			name:       "entropy check disabled",
+			validation: &esv1beta1.ExternalSecretValidation{MinPasswordEntropy: &zero},
+			data:       map[string][]byte{"password": []byte("aaaaaaaa")},
+		},
+		{
+			name:       "warn",
+			validation: &esv1beta1.ExternalSecretValidation{Action: esv1beta1.ValidationActionWarn},
+			data:       map[string][]byte{".dockercfg": []byte("{")},
+			wantEvent:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{recorder: recorder}
+			es := &esv1beta1.ExternalSecret{
+				Spec: esv1beta1.ExternalSecretSpec{
+					Target: esv1beta1.ExternalSecretTarget{Validation: tc.validation},
+				},
+			}
+			err := r.validateSecretValues(es, tc.data)
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("validateSecretValues() unexpected error = %v", err)
+			}
+			if tc.wantErr != "" {
+				var validationErr *secretValidationError
+				if !errors.As(err, &validationErr) || err.Error() != tc.wantErr {
+					t.Errorf("validateSecretValues() error = %v, want %q", err, tc.wantErr)
+				}
+			}
+			if got := len(recorder.Events) > 0; got != tc.wantEvent {
+				t.Errorf("validateSecretValues() recorded an event = %v, want %v", got, tc.wantEvent)
+			}
+		})
+	}
+}
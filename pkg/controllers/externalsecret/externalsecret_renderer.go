@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// Renderer renders the target Secret of an ExternalSecret without writing it to the cluster.
+// It allows to consume the provider data of an ExternalSecret without persisting it in a Secret,
+// e.g. through the secrets-store CSI driver.
+type Renderer struct {
+	r *Reconciler
+}
+
+// NewRenderer returns a Renderer that fetches the provider data the same way the ExternalSecret controller does.
+func NewRenderer(cl client.Client, restConfig *rest.Config, recorder record.EventRecorder, controllerClass string, enableFloodGate bool) *Renderer {
+	return &Renderer{
+		r: &Reconciler{
+			Client:          cl,
+			RestConfig:      restConfig,
+			ControllerClass: controllerClass,
+			EnableFloodGate: enableFloodGate,
+			recorder:        recorder,
+		},
+	}
+}
+
+// Render fetches the provider data of the ExternalSecret and applies its template.
+// The ExternalSecret is not modified.
+func (rd *Renderer) Render(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (*v1.Secret, error) {
+	es := externalSecret.DeepCopy()
+	secretName := es.Spec.Target.Name
+	if secretName == "" {
+		secretName = es.Name
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: es.Namespace,
+		},
+		Data: make(map[string][]byte),
+	}
+
+	dataMap, metadata, err := rd.r.getProviderSecretData(ctx, es)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errGetSecretData, err)
+	}
+	if err := rd.r.applyTemplate(ctx, es, secret, dataMap, metadata); err != nil {
+		return nil, fmt.Errorf(errApplyTemplate, err)
+	}
+	return secret, nil
+}
@@ -15,8 +15,14 @@ limitations under the License.
 package externalsecret
 
 import (
+	"context"
+	"fmt"
+	"sort"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret/esmetrics"
@@ -79,3 +85,97 @@ func filterOutCondition(conditions []esv1beta1.ExternalSecretStatusCondition, co
 	}
 	return newConditions
 }
+
+// changedProtectionKeys returns the keys whose protection flags differ between
+// the previous and the current protections. Keys that are new are not reported.
+func changedProtectionKeys(previous, current []esv1beta1.SecretProtectionStatus) []string {
+	known := make(map[string]esv1beta1.SecretProtectionStatus, len(previous))
+	for _, p := range previous {
+		known[p.Key] = p
+	}
+	var changed []string
+	for _, c := range current {
+		p, ok := known[c.Key]
+		if ok && p != c {
+			changed = append(changed, c.Key)
+		}
+	}
+	return changed
+}
+
+// storeRefIndexValues returns the stores referenced by the ExternalSecret as kind/name.
+func storeRefIndexValues(es *esv1beta1.ExternalSecret) []string {
+	set := make(map[string]struct{})
+	add := func(ref esv1beta1.SecretStoreRef) {
+		if ref.Name == "" {
+			return
+		}
+		kind := ref.Kind
+		if kind == "" {
+			kind = esv1beta1.SecretStoreKind
+		}
+		set[kind+"/"+ref.Name] = struct{}{}
+	}
+
+	add(es.Spec.SecretStoreRef)
+	for _, ref := range es.Spec.SecretStoreRefs {
+		add(ref)
+	}
+	for _, data := range es.Spec.Data {
+		if data.SourceRef != nil {
+			add(data.SourceRef.SecretStoreRef)
+		}
+	}
+	for _, data := range es.Spec.DataFrom {
+		if data.SourceRef != nil && data.SourceRef.SecretStoreRef != nil {
+			add(*data.SourceRef.SecretStoreRef)
+		}
+	}
+
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// adoptTarget prepares an existing target to be controlled by the ExternalSecret.
+// Targets controlled by another resource are only taken over if they are annotated with
+// AnnotationAllowAdoption=true, the controller reference of the other resource is removed in that case.
+// It returns the previous controller as kind/name if the target has been taken over.
+func adoptTarget(obj metav1.Object, es *esv1beta1.ExternalSecret) (string, error) {
+	ref := metav1.GetControllerOfNoCopy(obj)
+	if ref == nil || ref.UID == es.UID {
+		return "", nil
+	}
+	if obj.GetAnnotations()[esv1beta1.AnnotationAllowAdoption] != "true" {
+		return "", fmt.Errorf(errControlledByOther, obj.GetName(), ref.Kind, ref.Name, esv1beta1.AnnotationAllowAdoption)
+	}
+	previous := ref.Kind + "/" + ref.Name
+	refs := make([]metav1.OwnerReference, 0, len(obj.GetOwnerReferences()))
+	for _, r := range obj.GetOwnerReferences() {
+		if r.Controller != nil && *r.Controller {
+			continue
+		}
+		refs = append(refs, r)
+	}
+	obj.SetOwnerReferences(refs)
+	return previous, nil
+}
+
+// syncIDLength is the length of the first group of the reconcileID, so the sync ID can be searched in the logs.
+const syncIDLength = 8
+
+// syncIDFromContext returns a short ID of the current reconcile:
+// the prefix of the reconcileID logged by controller-runtime, or a random ID outside of a reconcile.
+func syncIDFromContext(ctx context.Context) string {
+	id := string(controller.ReconcileIDFromContext(ctx))
+	if id == "" {
+		id = string(uuid.NewUUID())
+	}
+	if len(id) > syncIDLength {
+		id = id[:syncIDLength]
+	}
+	return id
+}
@@ -15,6 +15,7 @@ limitations under the License.
 package externalsecret
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -223,3 +224,119 @@ func TestSetExternalSecretCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestChangedProtectionKeys(t *testing.T) {
+	previous := []esv1beta1.SecretProtectionStatus{
+		{Key: "unchanged", Masked: true, Protected: true},
+		{Key: "unmasked", Masked: true, Protected: true},
+		{Key: "unprotected", Masked: false, Protected: true},
+		{Key: "removed", Masked: true, Protected: true},
+	}
+	current := []esv1beta1.SecretProtectionStatus{
+		{Key: "unchanged", Masked: true, Protected: true},
+		{Key: "unmasked", Masked: false, Protected: true},
+		{Key: "unprotected", Masked: false, Protected: false},
+		{Key: "added", Masked: false, Protected: false},
+	}
+
+	got := changedProtectionKeys(previous, current)
+	if diff := cmp.Diff([]string{"unmasked", "unprotected"}, got); diff != "" {
+		t.Errorf("(-want, +got)\n%s", diff)
+	}
+	if got := changedProtectionKeys(nil, current); got != nil {
+		t.Errorf("expected no changes without previous protections, got %v", got)
+	}
+}
+
+func TestStoreRefIndexValues(t *testing.T) {
+	es := &esv1beta1.ExternalSecret{
+		Spec: esv1beta1.ExternalSecretSpec{
+			SecretStoreRef: esv1beta1.SecretStoreRef{Name: "default"},
+			Data: []esv1beta1.ExternalSecretData{
+				{SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "shared", Kind: esv1beta1.ClusterSecretStoreKind}}},
+				{SourceRef: &esv1beta1.StoreSourceRef{SecretStoreRef: esv1beta1.SecretStoreRef{Name: "default", Kind: esv1beta1.SecretStoreKind}}},
+				{},
+			},
+			DataFrom: []esv1beta1.ExternalSecretDataFromRemoteRef{
+				{SourceRef: &esv1beta1.StoreGeneratorSourceRef{SecretStoreRef: &esv1beta1.SecretStoreRef{Name: "other"}}},
+				{SourceRef: &esv1beta1.StoreGeneratorSourceRef{}},
+			},
+		},
+	}
+
+	want := []string{"ClusterSecretStore/shared", "SecretStore/default", "SecretStore/other"}
+	if diff := cmp.Diff(want, storeRefIndexValues(es)); diff != "" {
+		t.Errorf("storeRefIndexValues() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdoptTarget(t *testing.T) {
+	isController := true
+	es := &esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "es", UID: "es-uid"}}
+	other := metav1.OwnerReference{Kind: "Deployment", Name: "app", UID: "other-uid", Controller: &isController}
+	ownedBy := metav1.OwnerReference{Kind: "ConfigMap", Name: "cm", UID: "cm-uid"}
+
+	tests := []struct {
+		name         string
+		obj          *corev1.Secret
+		wantPrevious string
+		wantErr      bool
+		wantRefs     []metav1.OwnerReference
+	}{
+		{
+			name:     "unowned",
+			obj:      &corev1.Secret{},
+			wantRefs: nil,
+		},
+		{
+			name: "controlled by the external secret",
+			obj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ExternalSecret", Name: "es", UID: "es-uid", Controller: &isController},
+			}}},
+			wantRefs: []metav1.OwnerReference{{Kind: "ExternalSecret", Name: "es", UID: "es-uid", Controller: &isController}},
+		},
+		{
+			name: "controlled by another resource",
+			obj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:            "secret",
+				OwnerReferences: []metav1.OwnerReference{other},
+			}},
+			wantErr:  true,
+			wantRefs: []metav1.OwnerReference{other},
+		},
+		{
+			name: "controlled by another resource with adoption allowed",
+			obj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:            "secret",
+				Annotations:     map[string]string{esv1beta1.AnnotationAllowAdoption: "true"},
+				OwnerReferences: []metav1.OwnerReference{ownedBy, other},
+			}},
+			wantPrevious: "Deployment/app",
+			wantRefs:     []metav1.OwnerReference{ownedBy},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous, err := adoptTarget(tt.obj, es)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("adoptTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if previous != tt.wantPrevious {
+				t.Errorf("adoptTarget() previous = %q, want %q", previous, tt.wantPrevious)
+			}
+			if diff := cmp.Diff(tt.wantRefs, tt.obj.OwnerReferences); diff != "" {
+				t.Errorf("unexpected owner references (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncIDFromContext(t *testing.T) {
+	id := syncIDFromContext(context.Background())
+	if len(id) != syncIDLength {
+		t.Errorf("syncIDFromContext() = %q, want %d characters", id, syncIDLength)
+	}
+	if other := syncIDFromContext(context.Background()); other == id {
+		t.Errorf("syncIDFromContext() returned the same ID %q twice", id)
+	}
+}
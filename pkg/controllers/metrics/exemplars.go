@@ -0,0 +1,49 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// OpenMetricsPath serves the metrics in the OpenMetrics format including exemplars,
+	// the /metrics endpoint of controller-runtime only serves the text format.
+	OpenMetricsPath = "/metrics/openmetrics"
+
+	// SyncIDExemplarLabel is the exemplar label holding the ID of the sync that updated a metric.
+	SyncIDExemplarLabel = "sync_id"
+)
+
+// OpenMetricsHandler returns a handler serving the controller-runtime registry in the OpenMetrics format.
+func OpenMetricsHandler() http.Handler {
+	return promhttp.HandlerFor(crmetrics.Registry, promhttp.HandlerOpts{
+		ErrorHandling:     promhttp.HTTPErrorOnError,
+		EnableOpenMetrics: true,
+	})
+}
+
+// IncWithSyncID increments the counter and attaches the sync ID as exemplar.
+func IncWithSyncID(counter prometheus.Counter, syncID string) {
+	if adder, ok := counter.(prometheus.ExemplarAdder); ok && syncID != "" {
+		adder.AddWithExemplar(1, prometheus.Labels{SyncIDExemplarLabel: syncID})
+		return
+	}
+	counter.Inc()
+}
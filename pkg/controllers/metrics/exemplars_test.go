@@ -0,0 +1,40 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestIncWithSyncID(t *testing.T) {
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total"})
+	IncWithSyncID(counter, "")
+	IncWithSyncID(counter, "1a2b3c4d")
+
+	var m dto.Metric
+	if err := counter.Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.GetCounter().GetValue(); got != 2 {
+		t.Errorf("counter value = %v, want 2", got)
+	}
+	labels := m.GetCounter().GetExemplar().GetLabel()
+	if len(labels) != 1 || labels[0].GetName() != SyncIDExemplarLabel || labels[0].GetValue() != "1a2b3c4d" {
+		t.Errorf("unexpected exemplar labels %v", labels)
+	}
+}
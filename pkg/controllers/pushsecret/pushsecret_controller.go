@@ -46,10 +46,12 @@ const (
 	errPatchStatus           = "error merging"
 	errGetSecretStore        = "could not get SecretStore %q, %w"
 	errGetClusterSecretStore = "could not get ClusterSecretStore %q, %w"
-	errSetSecretFailed       = "could not write remote ref %v to target secretstore %v: %v"
+	errSetSecretFailed       = "could not write remote ref %v to target secretstore %v: %w"
 	errFailedSetSecret       = "set secret failed: %v"
 	errConvert               = "could not apply conversion strategy to keys: %v"
 	errUnmanagedStores       = "PushSecret %q has no managed stores to push to"
+	errNoSource              = "either selector.secret or selector.generatorRef must be set"
+	msgSecretExpiring        = "pushed secrets expire soon: %s"
 	pushSecretFinalizer      = "pushsecret.externalsecrets.io/finalizer"
 )
 
@@ -60,6 +62,9 @@ type Reconciler struct {
 	recorder        record.EventRecorder
 	RequeueInterval time.Duration
 	ControllerClass string
+	// ClusterSecretStoreEnabled is false if the controller does not process ClusterSecretStores,
+	// references to them are ignored like references to stores of other controller classes.
+	ClusterSecretStoreEnabled bool
 }
 
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -149,7 +154,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	secret, err := r.GetSecret(ctx, ps)
 	if err != nil {
-		r.markAsFailed(errFailedGetSecret, &ps, nil)
+		msg := errFailedGetSecret
+		if ps.Spec.Selector.GeneratorRef != nil {
+			msg = err.Error()
+		}
+		r.markAsFailed(msg, &ps, nil)
 
 		return ctrl.Result{}, err
 	}
@@ -182,6 +191,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 
 		totalSecrets := mergeSecretState(syncedSecrets, ps.Status.SyncedPushSecrets)
+		var pendingErr v1beta1.PushSecretPendingError
+		if errors.As(err, &pendingErr) {
+			log.Info("provider is not ready to accept the secret yet, retrying later", "error", err)
+			r.markAsPending(err.Error(), &ps, totalSecrets)
+			return ctrl.Result{RequeueAfter: pendingErr.RetryAfter}, nil
+		}
+
 		msg := fmt.Sprintf(errFailedSetSecret, err)
 		r.markAsFailed(msg, &ps, totalSecrets)
 
@@ -200,6 +216,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	r.markAsDone(&ps, syncedSecrets)
+	// the resource version of the source secret tells which data was pushed, e.g. to a SecretRotation
+	ps.Status.SyncedResourceVersion = secret.ResourceVersion
+	r.updateSecretExpirations(&ps, mgr.SecretExpirations())
 
 	return ctrl.Result{RequeueAfter: refreshInt}, nil
 }
@@ -213,6 +232,13 @@ func (r *Reconciler) markAsFailed(msg string, ps *esapi.PushSecret, syncState es
 	r.recorder.Event(ps, v1.EventTypeWarning, esapi.ReasonErrored, msg)
 }
 
+func (r *Reconciler) markAsPending(msg string, ps *esapi.PushSecret, syncState esapi.SyncedPushSecretsMap) {
+	cond := newPushSecretCondition(esapi.PushSecretReady, v1.ConditionFalse, esapi.ReasonPending, msg)
+	setPushSecretCondition(ps, *cond)
+	r.setSecrets(ps, syncState)
+	r.recorder.Event(ps, v1.EventTypeNormal, esapi.ReasonPending, msg)
+}
+
 func (r *Reconciler) markAsDone(ps *esapi.PushSecret, secrets esapi.SyncedPushSecretsMap) {
 	msg := "PushSecret synced successfully"
 	if ps.Spec.UpdatePolicy == esapi.PushSecretUpdatePolicyIfNotExists {
@@ -224,6 +250,22 @@ func (r *Reconciler) markAsDone(ps *esapi.PushSecret, secrets esapi.SyncedPushSe
 	r.recorder.Event(ps, v1.EventTypeNormal, esapi.ReasonSynced, msg)
 }
 
+// updateSecretExpirations raises the Expiring condition if the providers report
+// that pushed secrets expire soon, a warning event is emitted when the expirations change.
+func (r *Reconciler) updateSecretExpirations(ps *esapi.PushSecret, expirations []v1beta1.SecretExpiration) {
+	if len(expirations) == 0 {
+		ps.Status.Conditions = filterOutCondition(ps.Status.Conditions, esapi.PushSecretExpiring)
+		return
+	}
+	msg := fmt.Sprintf(msgSecretExpiring, secretstore.DescribeExpirations(expirations))
+	current := getPushSecretCondition(ps.Status, esapi.PushSecretExpiring)
+	if current == nil || current.Message != msg {
+		r.recorder.Event(ps, v1.EventTypeWarning, esapi.ReasonSecretExpiring, msg)
+	}
+	cond := newPushSecretCondition(esapi.PushSecretExpiring, v1.ConditionTrue, esapi.ReasonSecretExpiring, msg)
+	setPushSecretCondition(ps, *cond)
+}
+
 func (r *Reconciler) setSecrets(ps *esapi.PushSecret, status esapi.SyncedPushSecretsMap) {
 	ps.Status.SyncedPushSecrets = status
 }
@@ -319,36 +361,56 @@ func (r *Reconciler) handlePushSecretDataForStore(ctx context.Context, ps esapi.
 			return nil, fmt.Errorf(errConvert, err)
 		}
 		secret.Data = secretData
-		key := data.GetSecretKey()
-		if !secretKeyExists(key, secret) {
-			return out, fmt.Errorf("secret key %v does not exist", key)
+		entries, err := matchSecretData(data, secret)
+		if err != nil {
+			return out, err
 		}
-		switch ps.Spec.UpdatePolicy {
-		case esapi.PushSecretUpdatePolicyIfNotExists:
-			exists, err := secretClient.SecretExists(ctx, data.Match.RemoteRef)
-			if err != nil {
-				return out, fmt.Errorf("could not verify if secret exists in store: %w", err)
-			} else if exists {
-				out[storeKey][statusRef(data)] = data
-				continue
+		for _, entry := range entries {
+			if err := pushSecretData(ctx, secretClient, ps, secret, entry, storeName); err != nil {
+				return out, err
 			}
-		case esapi.PushSecretUpdatePolicyReplace:
-		default:
-		}
-		if err := secretClient.PushSecret(ctx, secret, data); err != nil {
-			return out, fmt.Errorf(errSetSecretFailed, key, storeName, err)
+			out[storeKey][statusRef(entry)] = entry
 		}
-		out[storeKey][statusRef(data)] = data
 	}
 	return out, nil
 }
 
+func pushSecretData(ctx context.Context, secretClient v1beta1.SecretsClient, ps esapi.PushSecret, secret *v1.Secret, data esapi.PushSecretData, storeName string) error {
+	key := data.GetSecretKey()
+	if !secretKeyExists(key, secret) {
+		return fmt.Errorf("secret key %v does not exist", key)
+	}
+	switch ps.Spec.UpdatePolicy {
+	case esapi.PushSecretUpdatePolicyIfNotExists:
+		exists, err := secretClient.SecretExists(ctx, data.Match.RemoteRef)
+		if err != nil {
+			return fmt.Errorf("could not verify if secret exists in store: %w", err)
+		} else if exists {
+			return nil
+		}
+	case esapi.PushSecretUpdatePolicyReplace:
+	default:
+	}
+	if err := secretClient.PushSecret(ctx, secret, data); err != nil {
+		return fmt.Errorf(errSetSecretFailed, key, storeName, err)
+	}
+	return nil
+}
+
 func secretKeyExists(key string, secret *v1.Secret) bool {
 	_, ok := secret.Data[key]
 	return key == "" || ok
 }
 
+// GetSecret returns the Secret to push: the selected Secret or,
+// if the PushSecret selects a generator, the generated values.
 func (r *Reconciler) GetSecret(ctx context.Context, ps esapi.PushSecret) (*v1.Secret, error) {
+	if ps.Spec.Selector.GeneratorRef != nil {
+		return r.generateSecret(ctx, ps)
+	}
+	if ps.Spec.Selector.Secret == nil {
+		return nil, errors.New(errNoSource)
+	}
 	secretName := types.NamespacedName{Name: ps.Spec.Selector.Secret.Name, Namespace: ps.Namespace}
 	secret := &v1.Secret{}
 	err := r.Client.Get(ctx, secretName, secret)
@@ -361,6 +423,9 @@ func (r *Reconciler) GetSecret(ctx context.Context, ps esapi.PushSecret) (*v1.Se
 func (r *Reconciler) GetSecretStores(ctx context.Context, ps esapi.PushSecret) (map[esapi.PushSecretStoreRef]v1beta1.GenericStore, error) {
 	stores := make(map[esapi.PushSecretStoreRef]v1beta1.GenericStore)
 	for _, refStore := range ps.Spec.SecretStoreRefs {
+		if refStore.Kind == v1beta1.ClusterSecretStoreKind && !r.ClusterSecretStoreEnabled {
+			continue
+		}
 		if refStore.LabelSelector != nil {
 			labelSelector, err := metav1.LabelSelectorAsSelector(refStore.LabelSelector)
 			if err != nil {
@@ -0,0 +1,87 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+
+	// Loading registered generators.
+	_ "github.com/external-secrets/external-secrets/pkg/generator/register"
+)
+
+const (
+	errGetGenerator = "could not get generator %s %q: %w"
+	errGenerate     = "error using generator %s %q: %w"
+	errInvalidKeys  = "generator %s %q returned invalid secret keys"
+	errRotationOnly = "generator %s %q can only be used by a SecretRotation, it does not revoke the values it replaces"
+)
+
+// generateSecret calls the generator selected by the PushSecret and returns its output
+// as a Secret that only lives in memory, so the generated values never end up in etcd.
+func (r *Reconciler) generateSecret(ctx context.Context, ps esapi.PushSecret) (*v1.Secret, error) {
+	ref := ps.Spec.Selector.GeneratorRef
+	genDef, err := r.getGeneratorDefinition(ctx, ps.Namespace, ref)
+	if err != nil {
+		return nil, fmt.Errorf(errGetGenerator, ref.Kind, ref.Name, err)
+	}
+	gen, err := genv1alpha1.GetGenerator(genDef)
+	if err != nil {
+		return nil, fmt.Errorf(errGenerate, ref.Kind, ref.Name, err)
+	}
+	if _, ok := gen.(genv1alpha1.RotationOnly); ok {
+		return nil, fmt.Errorf(errRotationOnly, ref.Kind, ref.Name)
+	}
+	secretMap, err := gen.Generate(ctx, genDef, r.Client, ps.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errGenerate, ref.Kind, ref.Name, err)
+	}
+	if !utils.ValidateKeys(secretMap) {
+		return nil, fmt.Errorf(errInvalidKeys, ref.Kind, ref.Name)
+	}
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ps.Name,
+			Namespace: ps.Namespace,
+		},
+		Data: secretMap,
+	}, nil
+}
+
+// getGeneratorDefinition fetches the generator resource and returns its JSON.
+func (r *Reconciler) getGeneratorDefinition(ctx context.Context, namespace string, ref *v1beta1.GeneratorRef) (*apiextensions.JSON, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, obj); err != nil {
+		return nil, err
+	}
+	jsonRes, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return &apiextensions.JSON{Raw: jsonRes}, nil
+}
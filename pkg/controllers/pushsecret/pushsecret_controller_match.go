@@ -0,0 +1,88 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errSecretKeyAndRegex   = "secretKey and secretKeyRegex cannot be set at the same time"
+	errRewriteWithoutRegex = "rewrite can only be used with secretKeyRegex"
+	errCompileKeyRegex     = "could not compile secretKeyRegex %q: %w"
+	errRewriteKeys         = "could not rewrite secret keys: %w"
+	errRewriteCollision    = "rewrite of secretKeyRegex %q maps multiple secret keys to the same remote key"
+)
+
+// matchSecretData expands the data entry into an entry per secret key.
+// Entries without secretKeyRegex are returned as is. For a secretKeyRegex every matching
+// secret key is pushed to the remote key that results from the rewrite operations.
+func matchSecretData(data esapi.PushSecretData, secret *v1.Secret) ([]esapi.PushSecretData, error) {
+	if data.Match.SecretKeyRegex == "" {
+		if len(data.Rewrite) > 0 {
+			return nil, errors.New(errRewriteWithoutRegex)
+		}
+		return []esapi.PushSecretData{data}, nil
+	}
+	if data.Match.SecretKey != "" {
+		return nil, errors.New(errSecretKeyAndRegex)
+	}
+	re, err := regexp.Compile(data.Match.SecretKeyRegex)
+	if err != nil {
+		return nil, fmt.Errorf(errCompileKeyRegex, data.Match.SecretKeyRegex, err)
+	}
+
+	// the rewrite operations work on the keys of a map, the values keep track of the original secret key
+	keys := make(map[string][]byte)
+	for key := range secret.Data {
+		if re.MatchString(key) {
+			keys[key] = []byte(key)
+		}
+	}
+	remoteKeys, err := utils.RewriteMap(data.Rewrite, keys)
+	if err != nil {
+		return nil, fmt.Errorf(errRewriteKeys, err)
+	}
+	if len(remoteKeys) != len(keys) {
+		return nil, fmt.Errorf(errRewriteCollision, data.Match.SecretKeyRegex)
+	}
+
+	out := make([]esapi.PushSecretData, 0, len(remoteKeys))
+	for remoteKey, secretKey := range remoteKeys {
+		out = append(out, esapi.PushSecretData{
+			Match: esapi.PushSecretMatch{
+				SecretKey: string(secretKey),
+				RemoteRef: esapi.PushSecretRemoteRef{
+					RemoteKey: remoteKey,
+					Property:  data.Match.RemoteRef.Property,
+				},
+			},
+			Metadata:           data.Metadata,
+			ConversionStrategy: data.ConversionStrategy,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Match.RemoteRef.RemoteKey < out[j].Match.RemoteRef.RemoteKey
+	})
+	return out, nil
+}
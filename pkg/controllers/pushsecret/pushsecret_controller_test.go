@@ -19,16 +19,20 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"strconv"
+	"testing"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 	ctest "github.com/external-secrets/external-secrets/pkg/controllers/commontest"
 	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret/psmetrics"
 	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
@@ -161,7 +165,7 @@ var _ = Describe("PushSecret controller", func() {
 						},
 					},
 					Selector: v1alpha1.PushSecretSelector{
-						Secret: v1alpha1.PushSecretSecret{
+						Secret: &v1alpha1.PushSecretSecret{
 							Name: SecretName,
 						},
 					},
@@ -225,6 +229,46 @@ var _ = Describe("PushSecret controller", func() {
 		}
 	}
 
+	// if the PushSecret selects a generator, the generated values are pushed.
+	syncSuccessfullyWithGenerator := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		generator := &genv1alpha1.Fake{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-generator",
+				Namespace: PushSecretNamespace,
+			},
+			Spec: genv1alpha1.FakeSpec{
+				Data: map[string]string{
+					defaultKey: newVal,
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), generator)).To(Succeed())
+		tc.secret = nil
+		tc.pushsecret.Spec.Selector = v1alpha1.PushSecretSelector{
+			GeneratorRef: &v1beta1.GeneratorRef{
+				APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "Fake",
+				Name:       generator.Name,
+			},
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, _ *v1.Secret) bool {
+			Eventually(func() bool {
+				By("checking if Provider value got updated with the generated value")
+				providerValue, ok := fakeProvider.SetSecretArgs[ps.Spec.Data[0].Match.RemoteRef.RemoteKey]
+				if !ok {
+					return false
+				}
+				return bytes.Equal(providerValue.Value, []byte(newVal))
+			}, time.Second*10, time.Second).Should(BeTrue())
+			By("checking that no Secret was created for the generated value")
+			err := k8sClient.Get(context.Background(), types.NamespacedName{Name: ps.Name, Namespace: ps.Namespace}, &v1.Secret{})
+			return apierrors.IsNotFound(err)
+		}
+	}
+
 	updateIfNotExists := func(tc *testCase) {
 		fakeProvider.SetSecretFn = func() error {
 			return nil
@@ -394,7 +438,7 @@ var _ = Describe("PushSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -458,7 +502,7 @@ var _ = Describe("PushSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -514,7 +558,7 @@ var _ = Describe("PushSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -569,7 +613,7 @@ var _ = Describe("PushSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -715,7 +759,7 @@ var _ = Describe("PushSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -755,6 +799,45 @@ var _ = Describe("PushSecret controller", func() {
 			return true
 		}
 	}
+	syncSuccessfullyWithSecretKeyRegex := func(tc *testCase) {
+		fakeProvider.SetSecretFn = func() error {
+			return nil
+		}
+		tc.pushsecret.Spec.Data = []v1alpha1.PushSecretData{
+			{
+				Match: v1alpha1.PushSecretMatch{
+					SecretKeyRegex: "^db-",
+				},
+				Rewrite: []v1beta1.ExternalSecretRewrite{
+					{
+						Regexp: &v1beta1.ExternalSecretRewriteRegexp{
+							Source: "^db-(.*)",
+							Target: "database/$1",
+						},
+					},
+				},
+			},
+		}
+		tc.secret.Data = map[string][]byte{
+			"db-user":     []byte("user"),
+			"db-password": []byte("password"),
+			"api-key":     []byte("key"),
+		}
+		tc.assert = func(ps *v1alpha1.PushSecret, secret *v1.Secret) bool {
+			Eventually(func() bool {
+				By("checking if the matching keys got pushed")
+				for remoteKey, secretKey := range map[string]string{"database/user": "db-user", "database/password": "db-password"} {
+					providerValue, ok := fakeProvider.SetSecretArgs[remoteKey]
+					if !ok || !bytes.Equal(providerValue.Value, secret.Data[secretKey]) {
+						return false
+					}
+				}
+				_, ok := fakeProvider.SetSecretArgs["api-key"]
+				return !ok
+			}, time.Second*10, time.Second).Should(BeTrue())
+			return true
+		}
+	}
 	// if target Secret name is not specified it should use the ExternalSecret name.
 	syncMatchingLabels := func(tc *testCase) {
 		fakeProvider.SetSecretFn = func() error {
@@ -781,7 +864,7 @@ var _ = Describe("PushSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -883,7 +966,7 @@ var _ = Describe("PushSecret controller", func() {
 					},
 				},
 				Selector: v1alpha1.PushSecretSelector{
-					Secret: v1alpha1.PushSecretSecret{
+					Secret: &v1alpha1.PushSecretSecret{
 						Name: SecretName,
 					},
 				},
@@ -1054,12 +1137,14 @@ var _ = Describe("PushSecret controller", func() {
 			// this must be optional so we can test faulty es configuration
 		},
 		Entry("should sync", syncSuccessfully),
+		Entry("should sync generated values", syncSuccessfullyWithGenerator),
 		Entry("should not update existing secret if UpdatePolicy=IfNotExists", updateIfNotExists),
 		Entry("should only update parts of secret that don't already exist if UpdatePolicy=IfNotExists", updateIfNotExistsPartialSecrets),
 		Entry("should update the PushSecret status correctly if UpdatePolicy=IfNotExists", updateIfNotExistsSyncStatus),
 		Entry("should fail if secret existence cannot be verified if UpdatePolicy=IfNotExists", updateIfNotExistsSyncFailed),
 		Entry("should sync with template", syncSuccessfullyWithTemplate),
 		Entry("should sync with conversion strategy", syncSuccessfullyWithConversionStrategy),
+		Entry("should sync keys matching secretKeyRegex", syncSuccessfullyWithSecretKeyRegex),
 		Entry("should delete if DeletionPolicy=Delete", syncAndDeleteSuccessfully),
 		Entry("should delete after DeletionPolicy changed from Delete to None", syncChangePolicyAndDeleteSuccessfully),
 		Entry("should track deletion tasks if Delete fails", failDelete),
@@ -1167,7 +1252,7 @@ var _ = Describe("PushSecret Controller Un/Managed Stores", func() {
 						},
 					},
 					Selector: v1alpha1.PushSecretSelector{
-						Secret: v1alpha1.PushSecretSecret{
+						Secret: &v1alpha1.PushSecretSecret{
 							Name: SecretName,
 						},
 					},
@@ -1388,3 +1473,108 @@ var _ = Describe("PushSecret Controller Un/Managed Stores", func() {
 		Entry("should skip unmanaged stores and sync managed stores", warnUnmanagedStoresAndSyncManagedStores),
 	)
 })
+
+func TestMatchSecretData(t *testing.T) {
+	secret := &v1.Secret{
+		Data: map[string][]byte{
+			"db-user":     []byte("user"),
+			"db-password": []byte("password"),
+			"api-key":     []byte("key"),
+		},
+	}
+	tests := []struct {
+		name    string
+		data    v1alpha1.PushSecretData
+		want    []v1alpha1.PushSecretMatch
+		wantErr string
+	}{
+		{
+			name: "secret key",
+			data: v1alpha1.PushSecretData{
+				Match: v1alpha1.PushSecretMatch{SecretKey: "api-key", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "api"}},
+			},
+			want: []v1alpha1.PushSecretMatch{
+				{SecretKey: "api-key", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "api"}},
+			},
+		},
+		{
+			name: "regex without rewrite",
+			data: v1alpha1.PushSecretData{
+				Match: v1alpha1.PushSecretMatch{SecretKeyRegex: "^db-"},
+			},
+			want: []v1alpha1.PushSecretMatch{
+				{SecretKey: "db-password", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "db-password"}},
+				{SecretKey: "db-user", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "db-user"}},
+			},
+		},
+		{
+			name: "regex with rewrite and property",
+			data: v1alpha1.PushSecretData{
+				Match: v1alpha1.PushSecretMatch{SecretKeyRegex: "^db-", RemoteRef: v1alpha1.PushSecretRemoteRef{Property: "value"}},
+				Rewrite: []v1beta1.ExternalSecretRewrite{
+					{Regexp: &v1beta1.ExternalSecretRewriteRegexp{Source: "^db-", Target: "database/"}},
+					{Operation: &v1beta1.ExternalSecretRewriteOperation{Type: v1beta1.RewriteOperationToUpper}},
+				},
+			},
+			want: []v1alpha1.PushSecretMatch{
+				{SecretKey: "db-password", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "DATABASE/PASSWORD", Property: "value"}},
+				{SecretKey: "db-user", RemoteRef: v1alpha1.PushSecretRemoteRef{RemoteKey: "DATABASE/USER", Property: "value"}},
+			},
+		},
+		{
+			name: "rewrite collision",
+			data: v1alpha1.PushSecretData{
+				Match: v1alpha1.PushSecretMatch{SecretKeyRegex: "^db-"},
+				Rewrite: []v1beta1.ExternalSecretRewrite{
+					{Regexp: &v1beta1.ExternalSecretRewriteRegexp{Source: ".*", Target: "db"}},
+				},
+			},
+			wantErr: `rewrite of secretKeyRegex "^db-" maps multiple secret keys to the same remote key`,
+		},
+		{
+			name: "secret key and regex",
+			data: v1alpha1.PushSecretData{
+				Match: v1alpha1.PushSecretMatch{SecretKey: "api-key", SecretKeyRegex: "^db-"},
+			},
+			wantErr: errSecretKeyAndRegex,
+		},
+		{
+			name: "rewrite without regex",
+			data: v1alpha1.PushSecretData{
+				Match: v1alpha1.PushSecretMatch{SecretKey: "api-key"},
+				Rewrite: []v1beta1.ExternalSecretRewrite{
+					{Operation: &v1beta1.ExternalSecretRewriteOperation{Type: v1beta1.RewriteOperationToUpper}},
+				},
+			},
+			wantErr: errRewriteWithoutRegex,
+		},
+		{
+			name: "invalid regex",
+			data: v1alpha1.PushSecretData{
+				Match: v1alpha1.PushSecretMatch{SecretKeyRegex: "("},
+			},
+			wantErr: "could not compile secretKeyRegex \"(\": error parsing regexp: missing closing ): `(`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchSecretData(tt.data, secret)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("matchSecretData() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("matchSecretData() unexpected error: %v", err)
+			}
+			matches := make([]v1alpha1.PushSecretMatch, len(got))
+			for i := range got {
+				matches[i] = got[i].Match
+			}
+			if !reflect.DeepEqual(matches, tt.want) {
+				t.Errorf("matchSecretData() = %v, want %v", matches, tt.want)
+			}
+		})
+	}
+}
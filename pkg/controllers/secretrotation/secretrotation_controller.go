@@ -0,0 +1,470 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretrotation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// Reconciler reconciles a SecretRotation object.
+type Reconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// PollInterval is the interval the PushSecret and the consumers are checked at during a rotation.
+	PollInterval time.Duration
+	recorder     record.EventRecorder
+}
+
+const (
+	defaultPollInterval        = 10 * time.Second
+	defaultVerificationTimeout = time.Hour
+	previousSecretTemplate     = "%s-previous"
+	rotationIDFormat           = "20060102-150405"
+
+	reasonInProgress = "InProgress"
+	reasonRotated    = "Rotated"
+	reasonFailed     = "Failed"
+
+	errGetRotation      = "could not get SecretRotation"
+	errPatchStatus      = "unable to patch status"
+	errGetPushSecret    = "could not get PushSecret %s: %w"
+	errNoSourceSecret   = "PushSecret %s does not select a Secret"
+	errGetSource        = "could not get secret %s: %w"
+	errWriteSecret      = "could not write secret %s: %w"
+	errTriggerPush      = "could not trigger PushSecret %s: %w"
+	errGetConsumer      = "could not get ExternalSecret %s: %w"
+	errRefreshConsumer  = "could not refresh ExternalSecret %s: %w"
+	errRevoke           = "could not revoke the previous credential: %w"
+	errDeletePrevious   = "could not delete secret %s: %w"
+	errTimeout          = "rotation did not complete within %s: %s"
+	errStrandedPrevious = "secret %s holds the credential replaced by the incomplete rotation %s, which has not been revoked: " +
+		"revoke it, delete the secret and trigger the rotation again"
+	errPreviousNotOwned = "secret %s is not owned by the SecretRotation and is not overwritten: delete or rename the secret and trigger the rotation again"
+
+	msgStarted      = "rotation %s started"
+	msgWaitPush     = "waiting for PushSecret %s to push the credential"
+	msgWaitConsumer = "waiting for ExternalSecret %s to pick up the credential"
+	msgGracePeriod  = "waiting for the grace period of %s"
+	msgRevoking     = "revoking the previous credential"
+	msgRotated      = "rotation %s completed"
+)
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("SecretRotation", req.NamespacedName)
+
+	var rotation esv1alpha1.SecretRotation
+	if err := r.Get(ctx, req.NamespacedName, &rotation); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, errGetRotation)
+		return ctrl.Result{}, err
+	}
+	if rotation.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	p := client.MergeFrom(rotation.DeepCopy())
+	defer func() {
+		if err := r.Status().Patch(ctx, &rotation, p); err != nil {
+			log.Error(err, errPatchStatus)
+		}
+	}()
+
+	switch rotation.Status.Phase {
+	case esv1alpha1.SecretRotationPhaseGenerating:
+		return r.generate(ctx, &rotation)
+	case esv1alpha1.SecretRotationPhasePushing:
+		return r.waitForPush(ctx, &rotation)
+	case esv1alpha1.SecretRotationPhaseVerifying:
+		return r.verify(ctx, &rotation)
+	case esv1alpha1.SecretRotationPhaseRevoking:
+		return r.revoke(ctx, &rotation)
+	case esv1alpha1.SecretRotationPhaseCompleted, esv1alpha1.SecretRotationPhaseFailed:
+	}
+
+	next, due := nextRotation(&rotation, time.Now())
+	if !due {
+		if next.IsZero() {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+	}
+	r.start(&rotation)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// nextRotation returns the time of the next rotation and whether it is due.
+// A rotation is due on creation and whenever the rotate annotation changed, otherwise once the rotation interval
+// passed since the last completed rotation. A failed rotation is only retried if the annotation changes.
+func nextRotation(rotation *esv1alpha1.SecretRotation, now time.Time) (time.Time, bool) {
+	if rotation.Status.RotationID == "" || rotation.Annotations[esv1alpha1.AnnotationRotate] != rotation.Status.Trigger {
+		return now, true
+	}
+	if rotation.Status.Phase == esv1alpha1.SecretRotationPhaseFailed || rotation.Spec.RotationInterval == nil || rotation.Status.LastRotationTime == nil {
+		return time.Time{}, false
+	}
+	next := rotation.Status.LastRotationTime.Add(rotation.Spec.RotationInterval.Duration)
+	return next, !next.After(now)
+}
+
+// start begins a new rotation, its status is written before anything is generated
+// so an interrupted rotation is continued instead of generating another credential.
+func (r *Reconciler) start(rotation *esv1alpha1.SecretRotation) {
+	now := metav1.Now()
+	rotation.Status.RotationID = now.UTC().Format(rotationIDFormat)
+	rotation.Status.Phase = esv1alpha1.SecretRotationPhaseGenerating
+	rotation.Status.Trigger = rotation.Annotations[esv1alpha1.AnnotationRotate]
+	rotation.Status.StartTime = &now
+	rotation.Status.PushTime = nil
+	msg := fmt.Sprintf(msgStarted, rotation.Status.RotationID)
+	r.setCondition(rotation, v1.ConditionFalse, reasonInProgress, msg)
+	r.recorder.Event(rotation, v1.EventTypeNormal, reasonInProgress, msg)
+}
+
+// generate writes a new credential to the Secret of the PushSecret, after storing the previous credential
+// in the previous Secret for the revocation. A Secret annotated with the rotation id already holds the
+// credential of the rotation and is not generated again. The rotation fails instead of overwriting
+// a previous Secret left behind by an incomplete rotation, its credential would never be revoked,
+// or a Secret of the same name that is not owned by the rotation.
+func (r *Reconciler) generate(ctx context.Context, rotation *esv1alpha1.SecretRotation) (ctrl.Result, error) {
+	ps, source, err := r.getPushSource(ctx, rotation)
+	if err != nil {
+		return r.handleError(rotation, err)
+	}
+	id := rotation.Status.RotationID
+	if source.Annotations[esv1alpha1.AnnotationRotationID] != id {
+		if len(source.Data) > 0 {
+			if err := r.checkPrevious(ctx, rotation); err != nil {
+				return r.handleError(rotation, err)
+			}
+			previous := newSecret(rotation, fmt.Sprintf(previousSecretTemplate, rotation.Name))
+			if err := r.writeSecret(ctx, rotation, previous, source.Data); err != nil {
+				return r.handleError(rotation, err)
+			}
+		}
+		data, err := r.generateCredential(ctx, rotation)
+		if err != nil {
+			return r.handleError(rotation, err)
+		}
+		if err := r.writeSecret(ctx, rotation, source, data); err != nil {
+			return r.handleError(rotation, err)
+		}
+	}
+	if err := r.setRotationID(ctx, ps, id); err != nil {
+		return r.handleError(rotation, fmt.Errorf(errTriggerPush, ps.Name, err))
+	}
+	rotation.Status.Phase = esv1alpha1.SecretRotationPhasePushing
+	r.setCondition(rotation, v1.ConditionFalse, reasonInProgress, fmt.Sprintf(msgWaitPush, ps.Name))
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// checkPrevious returns an error if the previous Secret is not owned by the rotation, e.g. a Secret of the user
+// that happens to have the name, or if it holds a credential of another rotation that failed before revoking it.
+// That credential may still be in use, so it is not revoked automatically.
+// Credentials of generators that don't implement genv1alpha1.Cleaner have nothing to revoke and are replaced.
+func (r *Reconciler) checkPrevious(ctx context.Context, rotation *esv1alpha1.SecretRotation) error {
+	var previous v1.Secret
+	name := fmt.Sprintf(previousSecretTemplate, rotation.Name)
+	err := r.Get(ctx, client.ObjectKey{Namespace: rotation.Namespace, Name: name}, &previous)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(errGetSource, name, err)
+	}
+	if !isOwnedBy(&previous, rotation) {
+		return &rotationError{msg: fmt.Sprintf(errPreviousNotOwned, name)}
+	}
+	stranded := previous.Annotations[esv1alpha1.AnnotationRotationID]
+	if stranded == rotation.Status.RotationID {
+		return nil
+	}
+	_, gen, err := r.getGenerator(ctx, rotation)
+	if err != nil {
+		return err
+	}
+	if _, ok := gen.(genv1alpha1.Cleaner); !ok {
+		return nil
+	}
+	return &rotationError{msg: fmt.Sprintf(errStrandedPrevious, name, stranded)}
+}
+
+// waitForPush waits until the PushSecret is ready and synced the Secret holding the new credential.
+func (r *Reconciler) waitForPush(ctx context.Context, rotation *esv1alpha1.SecretRotation) (ctrl.Result, error) {
+	ps, source, err := r.getPushSource(ctx, rotation)
+	if err != nil {
+		return r.handleError(rotation, err)
+	}
+	if !isPushSecretReady(ps) || source.ResourceVersion == "" || ps.Status.SyncedResourceVersion != source.ResourceVersion {
+		return r.waitOrTimeout(rotation, fmt.Sprintf(msgWaitPush, ps.Name))
+	}
+	now := metav1.Now()
+	rotation.Status.PushTime = &now
+	rotation.Status.Phase = esv1alpha1.SecretRotationPhaseVerifying
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// verify refreshes the ExternalSecrets consuming the credential and waits until all of them synced
+// after the push, followed by the grace period.
+func (r *Reconciler) verify(ctx context.Context, rotation *esv1alpha1.SecretRotation) (ctrl.Result, error) {
+	verification := rotation.Spec.Verification
+	if verification == nil {
+		verification = &esv1alpha1.SecretRotationVerification{}
+	}
+	synced := rotation.Status.PushTime.Time
+	for _, name := range verification.ExternalSecretNames {
+		var es esv1beta1.ExternalSecret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: rotation.Namespace, Name: name}, &es); err != nil {
+			return r.handleError(rotation, fmt.Errorf(errGetConsumer, name, err))
+		}
+		// changing an annotation forces the ExternalSecret to refresh
+		if err := r.setRotationID(ctx, &es, rotation.Status.RotationID); err != nil {
+			return r.handleError(rotation, fmt.Errorf(errRefreshConsumer, name, err))
+		}
+		if !isExternalSecretReady(&es) || es.Status.RefreshTime.Before(rotation.Status.PushTime) {
+			return r.waitOrTimeout(rotation, fmt.Sprintf(msgWaitConsumer, name))
+		}
+		if es.Status.RefreshTime.After(synced) {
+			synced = es.Status.RefreshTime.Time
+		}
+	}
+	if verification.GracePeriod != nil {
+		if remaining := time.Until(synced.Add(verification.GracePeriod.Duration)); remaining > 0 {
+			r.setCondition(rotation, v1.ConditionFalse, reasonInProgress, fmt.Sprintf(msgGracePeriod, verification.GracePeriod.Duration))
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+	rotation.Status.Phase = esv1alpha1.SecretRotationPhaseRevoking
+	r.setCondition(rotation, v1.ConditionFalse, reasonInProgress, msgRevoking)
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// revoke revokes the previous credential if the generator supports it and deletes the previous Secret.
+func (r *Reconciler) revoke(ctx context.Context, rotation *esv1alpha1.SecretRotation) (ctrl.Result, error) {
+	var previous v1.Secret
+	name := fmt.Sprintf(previousSecretTemplate, rotation.Name)
+	err := r.Get(ctx, client.ObjectKey{Namespace: rotation.Namespace, Name: name}, &previous)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return r.handleError(rotation, fmt.Errorf(errGetSource, name, err))
+	}
+	if err == nil && previous.Annotations[esv1alpha1.AnnotationRotationID] == rotation.Status.RotationID {
+		if err := r.cleanupCredential(ctx, rotation, previous.Data); err != nil {
+			return r.handleError(rotation, fmt.Errorf(errRevoke, err))
+		}
+		if err := r.Delete(ctx, &previous); err != nil && !apierrors.IsNotFound(err) {
+			return r.handleError(rotation, fmt.Errorf(errDeletePrevious, name, err))
+		}
+	}
+
+	now := metav1.Now()
+	rotation.Status.Phase = esv1alpha1.SecretRotationPhaseCompleted
+	rotation.Status.LastRotationTime = &now
+	msg := fmt.Sprintf(msgRotated, rotation.Status.RotationID)
+	r.setCondition(rotation, v1.ConditionTrue, reasonRotated, msg)
+	r.recorder.Event(rotation, v1.EventTypeNormal, reasonRotated, msg)
+	if rotation.Spec.RotationInterval == nil {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: rotation.Spec.RotationInterval.Duration}, nil
+}
+
+// getPushSource returns the PushSecret and the Secret it pushes, the Secret is
+// not persisted yet if it doesn't exist.
+func (r *Reconciler) getPushSource(ctx context.Context, rotation *esv1alpha1.SecretRotation) (*esv1alpha1.PushSecret, *v1.Secret, error) {
+	var ps esv1alpha1.PushSecret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: rotation.Namespace, Name: rotation.Spec.PushSecretName}, &ps); err != nil {
+		return nil, nil, fmt.Errorf(errGetPushSecret, rotation.Spec.PushSecretName, err)
+	}
+	if ps.Spec.Selector.Secret == nil {
+		return nil, nil, &rotationError{msg: fmt.Sprintf(errNoSourceSecret, ps.Name)}
+	}
+	source := newSecret(rotation, ps.Spec.Selector.Secret.Name)
+	err := r.Get(ctx, client.ObjectKeyFromObject(source), source)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf(errGetSource, source.Name, err)
+	}
+	return &ps, source, nil
+}
+
+func newSecret(rotation *esv1alpha1.SecretRotation, name string) *v1.Secret {
+	return &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: rotation.Namespace}}
+}
+
+// writeSecret replaces the data of the secret and annotates it with the rotation id.
+// Secrets created by the rotation are owned by it.
+func (r *Reconciler) writeSecret(ctx context.Context, rotation *esv1alpha1.SecretRotation, secret *v1.Secret, data map[string][]byte) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.CreationTimestamp.IsZero() {
+			if err := controllerutil.SetOwnerReference(rotation, secret, r.Scheme); err != nil {
+				return err
+			}
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[esv1alpha1.AnnotationRotationID] = rotation.Status.RotationID
+		secret.Data = data
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(errWriteSecret, secret.Name, err)
+	}
+	return nil
+}
+
+// isOwnedBy tells whether the secret was created by the rotation.
+func isOwnedBy(secret *v1.Secret, rotation *esv1alpha1.SecretRotation) bool {
+	for _, ref := range secret.OwnerReferences {
+		if ref.UID == rotation.UID && ref.Name == rotation.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// setRotationID annotates the object with the rotation id, which triggers its reconciliation.
+func (r *Reconciler) setRotationID(ctx context.Context, obj client.Object, id string) error {
+	if obj.GetAnnotations()[esv1alpha1.AnnotationRotationID] == id {
+		return nil
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[esv1alpha1.AnnotationRotationID] = id
+	obj.SetAnnotations(annotations)
+	return r.Update(ctx, obj)
+}
+
+// waitOrTimeout keeps waiting for the rotation to make progress, or fails it once the timeout passed.
+func (r *Reconciler) waitOrTimeout(rotation *esv1alpha1.SecretRotation, msg string) (ctrl.Result, error) {
+	timeout := defaultVerificationTimeout
+	if rotation.Spec.Verification != nil && rotation.Spec.Verification.Timeout != nil {
+		timeout = rotation.Spec.Verification.Timeout.Duration
+	}
+	if time.Since(rotation.Status.StartTime.Time) > timeout {
+		r.markAsFailed(rotation, fmt.Sprintf(errTimeout, timeout, msg))
+		return ctrl.Result{}, nil
+	}
+	r.setCondition(rotation, v1.ConditionFalse, reasonInProgress, msg)
+	return ctrl.Result{RequeueAfter: r.pollInterval()}, nil
+}
+
+// rotationError is an error the rotation can't recover from by retrying.
+type rotationError struct {
+	msg string
+}
+
+func (e *rotationError) Error() string {
+	return e.msg
+}
+
+// handleError fails the rotation on errors that can't be recovered from,
+// other errors are retried with the current phase.
+func (r *Reconciler) handleError(rotation *esv1alpha1.SecretRotation, err error) (ctrl.Result, error) {
+	if rerr, ok := err.(*rotationError); ok {
+		r.markAsFailed(rotation, rerr.msg)
+		return ctrl.Result{}, nil
+	}
+	r.setCondition(rotation, v1.ConditionFalse, reasonInProgress, err.Error())
+	r.recorder.Event(rotation, v1.EventTypeWarning, reasonInProgress, err.Error())
+	return ctrl.Result{}, err
+}
+
+func (r *Reconciler) markAsFailed(rotation *esv1alpha1.SecretRotation, msg string) {
+	rotation.Status.Phase = esv1alpha1.SecretRotationPhaseFailed
+	r.setCondition(rotation, v1.ConditionFalse, reasonFailed, msg)
+	r.recorder.Event(rotation, v1.EventTypeWarning, reasonFailed, msg)
+}
+
+func (r *Reconciler) setCondition(rotation *esv1alpha1.SecretRotation, status v1.ConditionStatus, reason, msg string) {
+	condition := esv1alpha1.SecretRotationStatusCondition{
+		Type:               esv1alpha1.SecretRotationReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            msg,
+		LastTransitionTime: metav1.Now(),
+	}
+	conditions := make([]esv1alpha1.SecretRotationStatusCondition, 0, len(rotation.Status.Conditions))
+	for _, c := range rotation.Status.Conditions {
+		if c.Type != condition.Type {
+			conditions = append(conditions, c)
+			continue
+		}
+		if c.Status == condition.Status {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+	}
+	rotation.Status.Conditions = append(conditions, condition)
+}
+
+func (r *Reconciler) pollInterval() time.Duration {
+	if r.PollInterval > 0 {
+		return r.PollInterval
+	}
+	return defaultPollInterval
+}
+
+func isPushSecretReady(ps *esv1alpha1.PushSecret) bool {
+	for _, c := range ps.Status.Conditions {
+		if c.Type == esv1alpha1.PushSecretReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isExternalSecretReady(es *esv1beta1.ExternalSecret) bool {
+	for _, c := range es.Status.Conditions {
+		if c.Type == esv1beta1.ExternalSecretReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// SetupWithManager returns a new controller builder that will be started by the provided Manager.
+// Status changes are ignored, the rotation requeues itself while it waits for the PushSecret and the consumers.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	r.recorder = mgr.GetEventRecorderFor("secretrotation")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(opts).
+		For(&esv1alpha1.SecretRotation{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+		)).
+		Complete(r)
+}
@@ -0,0 +1,366 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretrotation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+// revokingGenerator counts the generated credentials and records the revoked ones.
+type revokingGenerator struct {
+	generated int
+	revoked   []string
+}
+
+func (g *revokingGenerator) Generate(_ context.Context, _ *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, error) {
+	g.generated++
+	return map[string][]byte{"password": []byte("new")}, nil
+}
+
+func (g *revokingGenerator) Cleanup(_ context.Context, _ *apiextensions.JSON, data map[string][]byte, _ client.Client, _ string) error {
+	g.revoked = append(g.revoked, string(data["password"]))
+	return nil
+}
+
+const namespace = "default"
+
+func newTestReconciler(t *testing.T, rotation *esv1alpha1.SecretRotation) (*Reconciler, *revokingGenerator) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+	_ = esv1alpha1.AddToScheme(scheme)
+	_ = genv1alpha1.AddToScheme(scheme)
+
+	gen := &revokingGenerator{}
+	genv1alpha1.ForceRegister(genv1alpha1.FakeKind, gen)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&esv1alpha1.SecretRotation{}, &esv1alpha1.PushSecret{}, &esv1beta1.ExternalSecret{}).
+		WithObjects(
+			rotation,
+			&genv1alpha1.Fake{ObjectMeta: metav1.ObjectMeta{Name: "gen", Namespace: namespace}},
+			&esv1alpha1.PushSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "push", Namespace: namespace},
+				Spec: esv1alpha1.PushSecretSpec{
+					Selector: esv1alpha1.PushSecretSelector{Secret: &esv1alpha1.PushSecretSecret{Name: "creds"}},
+				},
+			},
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: namespace},
+				Data:       map[string][]byte{"password": []byte("old")},
+			},
+			&esv1beta1.ExternalSecret{ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: namespace}},
+		).Build()
+	return &Reconciler{
+		Client:   cl,
+		Log:      logr.Discard(),
+		Scheme:   scheme,
+		recorder: record.NewFakeRecorder(100),
+	}, gen
+}
+
+func newRotation() *esv1alpha1.SecretRotation {
+	return &esv1alpha1.SecretRotation{
+		ObjectMeta: metav1.ObjectMeta{Name: "rotation", Namespace: namespace},
+		Spec: esv1alpha1.SecretRotationSpec{
+			GeneratorRef: esv1beta1.GeneratorRef{
+				APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+				Kind:       genv1alpha1.FakeKind,
+				Name:       "gen",
+			},
+			PushSecretName: "push",
+			Verification: &esv1alpha1.SecretRotationVerification{
+				ExternalSecretNames: []string{"consumer"},
+			},
+		},
+	}
+}
+
+func reconcileRotation(t *testing.T, r *Reconciler) *esv1alpha1.SecretRotation {
+	t.Helper()
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: namespace, Name: "rotation"}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rotation esv1alpha1.SecretRotation
+	if err := r.Get(ctx, key, &rotation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &rotation
+}
+
+func expectPhase(t *testing.T, rotation *esv1alpha1.SecretRotation, phase esv1alpha1.SecretRotationPhase) {
+	t.Helper()
+	if rotation.Status.Phase != phase {
+		t.Fatalf("phase = %q, want %q (conditions: %v)", rotation.Status.Phase, phase, rotation.Status.Conditions)
+	}
+}
+
+func TestReconcileRotation(t *testing.T) {
+	r, gen := newTestReconciler(t, newRotation())
+	ctx := context.Background()
+
+	rotation := reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseGenerating)
+	id := rotation.Status.RotationID
+
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhasePushing)
+	var creds, previous v1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "creds"}, &creds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(creds.Data["password"]) != "new" || creds.Annotations[esv1alpha1.AnnotationRotationID] != id {
+		t.Fatalf("unexpected source secret: %v", creds)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "rotation-previous"}, &previous); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(previous.Data["password"]) != "old" {
+		t.Fatalf("unexpected previous secret: %v", previous.Data)
+	}
+
+	// the credential is not generated again while the rotation waits for the push
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhasePushing)
+	if gen.generated != 1 {
+		t.Fatalf("generated %d credentials, want 1", gen.generated)
+	}
+
+	var ps esv1alpha1.PushSecret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "push"}, &ps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ps.Annotations[esv1alpha1.AnnotationRotationID] != id {
+		t.Fatalf("PushSecret was not triggered: %v", ps.Annotations)
+	}
+	ps.Status.SyncedResourceVersion = creds.ResourceVersion
+	ps.Status.Conditions = []esv1alpha1.PushSecretStatusCondition{{Type: esv1alpha1.PushSecretReady, Status: v1.ConditionTrue}}
+	if err := r.Status().Update(ctx, &ps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseVerifying)
+
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseVerifying)
+	var es esv1beta1.ExternalSecret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "consumer"}, &es); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if es.Annotations[esv1alpha1.AnnotationRotationID] != id {
+		t.Fatalf("ExternalSecret was not refreshed: %v", es.Annotations)
+	}
+	es.Status.RefreshTime = metav1.Now()
+	es.Status.Conditions = []esv1beta1.ExternalSecretStatusCondition{{Type: esv1beta1.ExternalSecretReady, Status: v1.ConditionTrue}}
+	if err := r.Status().Update(ctx, &es); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseRevoking)
+
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseCompleted)
+	if len(gen.revoked) != 1 || gen.revoked[0] != "old" {
+		t.Fatalf("revoked = %v, want [old]", gen.revoked)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "rotation-previous"}, &previous); !apierrors.IsNotFound(err) {
+		t.Fatalf("previous secret was not deleted: %v", err)
+	}
+	if rotation.Status.LastRotationTime == nil || rotation.Status.Conditions[0].Status != v1.ConditionTrue {
+		t.Fatalf("unexpected status: %v", rotation.Status)
+	}
+
+	// completed rotations without an interval only rotate again if the annotation changes
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseCompleted)
+	rotation.Annotations = map[string]string{esv1alpha1.AnnotationRotate: "again"}
+	if err := r.Update(ctx, rotation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseGenerating)
+}
+
+func TestReconcileRotationTimeout(t *testing.T) {
+	rotation := newRotation()
+	r, gen := newTestReconciler(t, rotation)
+	rotation = reconcileRotation(t, r)
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhasePushing)
+
+	start := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	rotation.Status.StartTime = &start
+	if err := r.Status().Update(context.Background(), rotation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseFailed)
+	if len(gen.revoked) != 0 {
+		t.Fatalf("revoked %v after a failed rotation", gen.revoked)
+	}
+}
+
+func TestReconcileRotationStrandedPrevious(t *testing.T) {
+	r, gen := newTestReconciler(t, newRotation())
+	ctx := context.Background()
+	rotation := reconcileRotation(t, r)
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhasePushing)
+
+	// an earlier rotation failed after the credential was pushed, the previous Secret was not revoked
+	var previous v1.Secret
+	key := client.ObjectKey{Namespace: namespace, Name: "rotation-previous"}
+	if err := r.Get(ctx, key, &previous); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	previous.Annotations[esv1alpha1.AnnotationRotationID] = "20240101-000000"
+	if err := r.Update(ctx, &previous); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var creds v1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "creds"}, &creds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	creds.Annotations[esv1alpha1.AnnotationRotationID] = "20240102-000000"
+	if err := r.Update(ctx, &creds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rotation.Annotations = map[string]string{esv1alpha1.AnnotationRotate: "again"}
+	if err := r.Update(ctx, rotation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rotation.Status.Phase = esv1alpha1.SecretRotationPhaseFailed
+	if err := r.Status().Update(ctx, rotation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseGenerating)
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseFailed)
+	want := fmt.Sprintf(errStrandedPrevious, "rotation-previous", "20240101-000000")
+	if msg := rotation.Status.Conditions[0].Message; msg != want {
+		t.Fatalf("condition message = %q, want %q", msg, want)
+	}
+	if err := r.Get(ctx, key, &previous); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(previous.Data["password"]) != "old" || gen.generated != 1 || len(gen.revoked) != 0 {
+		t.Fatalf("stranded credential was replaced: %v, generated %d, revoked %v", previous.Data, gen.generated, gen.revoked)
+	}
+}
+
+func TestNextRotation(t *testing.T) {
+	now := time.Now()
+	last := metav1.NewTime(now.Add(-time.Hour))
+	tests := []struct {
+		name     string
+		rotation esv1alpha1.SecretRotation
+		due      bool
+	}{
+		{
+			name: "new rotation",
+			due:  true,
+		},
+		{
+			name: "annotation changed",
+			rotation: esv1alpha1.SecretRotation{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{esv1alpha1.AnnotationRotate: "now"}},
+				Status:     esv1alpha1.SecretRotationStatus{RotationID: "id", Phase: esv1alpha1.SecretRotationPhaseFailed},
+			},
+			due: true,
+		},
+		{
+			name: "interval passed",
+			rotation: esv1alpha1.SecretRotation{
+				Spec:   esv1alpha1.SecretRotationSpec{RotationInterval: &metav1.Duration{Duration: time.Minute}},
+				Status: esv1alpha1.SecretRotationStatus{RotationID: "id", Phase: esv1alpha1.SecretRotationPhaseCompleted, LastRotationTime: &last},
+			},
+			due: true,
+		},
+		{
+			name: "interval not passed",
+			rotation: esv1alpha1.SecretRotation{
+				Spec:   esv1alpha1.SecretRotationSpec{RotationInterval: &metav1.Duration{Duration: 2 * time.Hour}},
+				Status: esv1alpha1.SecretRotationStatus{RotationID: "id", Phase: esv1alpha1.SecretRotationPhaseCompleted, LastRotationTime: &last},
+			},
+		},
+		{
+			name: "failed rotations are not retried by the interval",
+			rotation: esv1alpha1.SecretRotation{
+				Spec:   esv1alpha1.SecretRotationSpec{RotationInterval: &metav1.Duration{Duration: time.Minute}},
+				Status: esv1alpha1.SecretRotationStatus{RotationID: "id", Phase: esv1alpha1.SecretRotationPhaseFailed, LastRotationTime: &last},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, due := nextRotation(&tc.rotation, now); due != tc.due {
+				t.Errorf("due = %v, want %v", due, tc.due)
+			}
+		})
+	}
+}
+
+func TestReconcileRotationPreviousNotOwned(t *testing.T) {
+	r, gen := newTestReconciler(t, newRotation())
+	ctx := context.Background()
+	// a Secret of the user that happens to have the name of the previous Secret
+	key := client.ObjectKey{Namespace: namespace, Name: "rotation-previous"}
+	if err := r.Create(ctx, &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: namespace},
+		Data:       map[string][]byte{"password": []byte("unrelated")},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rotation := reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseGenerating)
+	rotation = reconcileRotation(t, r)
+	expectPhase(t, rotation, esv1alpha1.SecretRotationPhaseFailed)
+	want := fmt.Sprintf(errPreviousNotOwned, "rotation-previous")
+	if msg := rotation.Status.Conditions[0].Message; msg != want {
+		t.Fatalf("condition message = %q, want %q", msg, want)
+	}
+	var previous v1.Secret
+	if err := r.Get(ctx, key, &previous); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(previous.Data["password"]) != "unrelated" || gen.generated != 0 {
+		t.Fatalf("secret of the user was overwritten: %v, generated %d", previous.Data, gen.generated)
+	}
+}
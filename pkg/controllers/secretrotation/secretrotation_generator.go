@@ -0,0 +1,92 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretrotation
+
+import (
+	"context"
+	"fmt"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+
+	// Loading registered generators.
+	_ "github.com/external-secrets/external-secrets/pkg/generator/register"
+)
+
+const (
+	errGetGenerator = "could not get generator %s %q: %w"
+	errGenerate     = "error using generator %s %q: %w"
+	errInvalidKeys  = "generator %s %q returned invalid secret keys"
+)
+
+// generateCredential generates a new credential with the generator of the rotation.
+func (r *Reconciler) generateCredential(ctx context.Context, rotation *esv1alpha1.SecretRotation) (map[string][]byte, error) {
+	ref := rotation.Spec.GeneratorRef
+	genDef, gen, err := r.getGenerator(ctx, rotation)
+	if err != nil {
+		return nil, err
+	}
+	secretMap, err := gen.Generate(ctx, genDef, r.Client, rotation.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errGenerate, ref.Kind, ref.Name, err)
+	}
+	if !utils.ValidateKeys(secretMap) {
+		return nil, fmt.Errorf(errInvalidKeys, ref.Kind, ref.Name)
+	}
+	return secretMap, nil
+}
+
+// cleanupCredential revokes a credential generated earlier,
+// generators that don't implement genv1alpha1.Cleaner have nothing to revoke.
+func (r *Reconciler) cleanupCredential(ctx context.Context, rotation *esv1alpha1.SecretRotation, data map[string][]byte) error {
+	ref := rotation.Spec.GeneratorRef
+	genDef, gen, err := r.getGenerator(ctx, rotation)
+	if err != nil {
+		return err
+	}
+	cleaner, ok := gen.(genv1alpha1.Cleaner)
+	if !ok {
+		return nil
+	}
+	if err := cleaner.Cleanup(ctx, genDef, data, r.Client, rotation.Namespace); err != nil {
+		return fmt.Errorf(errGenerate, ref.Kind, ref.Name, err)
+	}
+	return nil
+}
+
+func (r *Reconciler) getGenerator(ctx context.Context, rotation *esv1alpha1.SecretRotation) (*apiextensions.JSON, genv1alpha1.Generator, error) {
+	ref := rotation.Spec.GeneratorRef
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: rotation.Namespace}, obj); err != nil {
+		return nil, nil, fmt.Errorf(errGetGenerator, ref.Kind, ref.Name, err)
+	}
+	jsonRes, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, nil, fmt.Errorf(errGetGenerator, ref.Kind, ref.Name, err)
+	}
+	genDef := &apiextensions.JSON{Raw: jsonRes}
+	gen, err := genv1alpha1.GetGenerator(genDef)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errGenerate, ref.Kind, ref.Name, err)
+	}
+	return genDef, gen, nil
+}
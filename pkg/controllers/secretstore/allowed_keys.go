@@ -0,0 +1,111 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errCompileAllowedKey = "failed to compile allowed key regex %q: %w"
+	errKeyNotAllowed     = "remote key %q is not allowed by %s %s"
+)
+
+// allowedKeysClient rejects remote keys that do not match spec.allowedKeys of the store
+// before the request reaches the provider.
+type allowedKeysClient struct {
+	esv1beta1.SecretsClient
+	store   esv1beta1.GenericStore
+	allowed []*regexp.Regexp
+}
+
+// withAllowedKeys wraps the client if the store restricts the remote keys.
+func withAllowedKeys(store esv1beta1.GenericStore, secretClient esv1beta1.SecretsClient) (esv1beta1.SecretsClient, error) {
+	keys := store.GetSpec().AllowedKeys
+	if len(keys) == 0 {
+		return secretClient, nil
+	}
+	allowed := make([]*regexp.Regexp, len(keys))
+	for i, key := range keys {
+		re, err := regexp.Compile(key)
+		if err != nil {
+			return nil, fmt.Errorf(errCompileAllowedKey, key, err)
+		}
+		allowed[i] = re
+	}
+	return &allowedKeysClient{
+		SecretsClient: secretClient,
+		store:         store,
+		allowed:       allowed,
+	}, nil
+}
+
+// unwrapClient returns the provider client, so optional interfaces of the provider can be used.
+func unwrapClient(secretClient esv1beta1.SecretsClient) esv1beta1.SecretsClient {
+	if c, ok := secretClient.(*allowedKeysClient); ok {
+		return c.SecretsClient
+	}
+	return secretClient
+}
+
+func (c *allowedKeysClient) checkKey(key string) error {
+	for _, re := range c.allowed {
+		if re.MatchString(key) {
+			return nil
+		}
+	}
+	return fmt.Errorf(errKeyNotAllowed, key, c.store.GetKind(), c.store.GetName())
+}
+
+func (c *allowedKeysClient) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if err := c.checkKey(ref.Key); err != nil {
+		return nil, err
+	}
+	return c.SecretsClient.GetSecret(ctx, ref)
+}
+
+func (c *allowedKeysClient) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if err := c.checkKey(ref.Key); err != nil {
+		return nil, err
+	}
+	return c.SecretsClient.GetSecretMap(ctx, ref)
+}
+
+func (c *allowedKeysClient) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	if err := c.checkKey(data.GetRemoteKey()); err != nil {
+		return err
+	}
+	return c.SecretsClient.PushSecret(ctx, secret, data)
+}
+
+func (c *allowedKeysClient) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	if err := c.checkKey(remoteRef.GetRemoteKey()); err != nil {
+		return err
+	}
+	return c.SecretsClient.DeleteSecret(ctx, remoteRef)
+}
+
+func (c *allowedKeysClient) SecretExists(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) (bool, error) {
+	if err := c.checkKey(remoteRef.GetRemoteKey()); err != nil {
+		return false, err
+	}
+	return c.SecretsClient.SecretExists(ctx, remoteRef)
+}
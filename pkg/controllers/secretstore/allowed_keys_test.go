@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestAllowedKeys(t *testing.T) {
+	ctx := context.Background()
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			AllowedKeys: []string{`^team-a/`, `^shared$`},
+		},
+	}
+	provider := &MockFakeClient{id: "1"}
+
+	cl, err := withAllowedKeys(store, provider)
+	require.NoError(t, err)
+	assert.Same(t, provider, unwrapClient(cl))
+
+	_, err = cl.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "team-a/db"})
+	assert.NoError(t, err)
+	_, err = cl.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "shared"})
+	assert.NoError(t, err)
+
+	_, err = cl.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "team-b/db"})
+	assert.EqualError(t, err, `remote key "team-b/db" is not allowed by SecretStore vault`)
+	_, err = cl.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "shared/db"})
+	assert.Error(t, err)
+
+	err = cl.PushSecret(ctx, &corev1.Secret{}, esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "team-a/api"}},
+	})
+	assert.NoError(t, err)
+	err = cl.PushSecret(ctx, &corev1.Secret{}, esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "prod/api"}},
+	})
+	assert.EqualError(t, err, `remote key "prod/api" is not allowed by SecretStore vault`)
+	err = cl.DeleteSecret(ctx, esv1alpha1.PushSecretRemoteRef{RemoteKey: "prod/api"})
+	assert.Error(t, err)
+	_, err = cl.SecretExists(ctx, esv1alpha1.PushSecretRemoteRef{RemoteKey: "prod/api"})
+	assert.Error(t, err)
+
+	store.Spec.AllowedKeys = nil
+	cl, err = withAllowedKeys(store, provider)
+	require.NoError(t, err)
+	assert.Same(t, provider, cl)
+
+	store.Spec.AllowedKeys = []string{`(`}
+	_, err = withAllowedKeys(store, provider)
+	assert.Error(t, err)
+}
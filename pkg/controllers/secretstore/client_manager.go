@@ -18,7 +18,9 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
@@ -27,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 )
@@ -51,6 +54,24 @@ type Manager struct {
 
 	// store clients by provider type
 	clientMap map[clientKey]*clientVal
+
+	// protections reported by clients that have already been cleaned up
+	protections []esv1beta1.SecretProtectionStatus
+	// expirations reported by clients that have already been cleaned up
+	expirations []esv1beta1.SecretExpiration
+	// lifetimes reported by clients that have already been cleaned up
+	lifetimes []esv1beta1.SecretLifetime
+	// metadata reported by clients that have already been cleaned up
+	metadata []esv1beta1.SecretMetadata
+	// deprecation warnings of the stores, by kind and name of the store
+	warnings map[string]admission.Warnings
+	// the ExternalSecret requesting the clients, matched against the ClusterSecretStore conditions
+	subject *subject
+}
+
+type subject struct {
+	labels     map[string]string
+	targetName string
 }
 
 type clientKey struct {
@@ -74,6 +95,20 @@ func NewManager(ctrlClient client.Client, controllerClass string, enableFloodgat
 	}
 }
 
+// ForExternalSecret sets the ExternalSecret requesting the clients, its labels and the name
+// of its target are matched against the conditions of ClusterSecretStores.
+func (m *Manager) ForExternalSecret(es *esv1beta1.ExternalSecret) *Manager {
+	targetName := es.Spec.Target.Name
+	if targetName == "" {
+		targetName = es.Name
+	}
+	m.subject = &subject{
+		labels:     es.GetLabels(),
+		targetName: targetName,
+	}
+	return m
+}
+
 func (m *Manager) GetFromStore(ctx context.Context, store esv1beta1.GenericStore, namespace string) (esv1beta1.SecretsClient, error) {
 	storeProvider, err := esv1beta1.GetProvider(store)
 	if err != nil {
@@ -81,11 +116,21 @@ func (m *Manager) GetFromStore(ctx context.Context, store esv1beta1.GenericStore
 	}
 	secretClient := m.getStoredClient(ctx, storeProvider, store)
 	if secretClient != nil {
-		return secretClient, nil
+		return withAllowedKeys(store, secretClient)
 	}
 	m.log.V(1).Info("creating new client",
 		"provider", fmt.Sprintf("%T", storeProvider),
 		"store", fmt.Sprintf("%s/%s", store.GetNamespace(), store.GetName()))
+	if warnings := esv1beta1.StoreWarnings(store); len(warnings) > 0 {
+		if m.warnings == nil {
+			m.warnings = make(map[string]admission.Warnings)
+		}
+		m.warnings[store.GetKind()+" "+store.GetName()] = warnings
+	}
+	store, err = withProviderDefaults(ctx, store)
+	if err != nil {
+		return nil, err
+	}
 	// secret client is created only if we are going to refresh
 	// this skip an unnecessary check/request in the case we are not going to do anything
 	secretClient, err = storeProvider.NewClient(ctx, store, m.client, namespace)
@@ -97,7 +142,7 @@ func (m *Manager) GetFromStore(ctx context.Context, store esv1beta1.GenericStore
 		client: secretClient,
 		store:  store,
 	}
-	return secretClient, nil
+	return withAllowedKeys(store, secretClient)
 }
 
 // Get returns a provider client from the given storeRef or sourceRef.secretStoreRef
@@ -159,6 +204,10 @@ func (m *Manager) getStoredClient(ctx context.Context, storeProvider esv1beta1.P
 		"store", storeName)
 	// if we have a client, but it points to a different store
 	// we must clean it up
+	m.protections = append(m.protections, reportedProtections(val.client)...)
+	m.expirations = append(m.expirations, reportedExpirations(val.client)...)
+	m.lifetimes = append(m.lifetimes, reportedLifetimes(val.client)...)
+	m.metadata = append(m.metadata, reportedMetadata(val.client)...)
 	val.client.Close(ctx)
 	delete(m.clientMap, idx)
 	return nil
@@ -193,6 +242,111 @@ func (m *Manager) getStore(ctx context.Context, storeRef *esv1beta1.SecretStoreR
 	return &store, nil
 }
 
+// SecretProtections returns the protection flags reported by the clients
+// that were used through this manager.
+func (m *Manager) SecretProtections() []esv1beta1.SecretProtectionStatus {
+	protections := append([]esv1beta1.SecretProtectionStatus{}, m.protections...)
+	for _, val := range m.clientMap {
+		protections = append(protections, reportedProtections(val.client)...)
+	}
+	return protections
+}
+
+func reportedProtections(secretClient esv1beta1.SecretsClient) []esv1beta1.SecretProtectionStatus {
+	reporter, ok := secretClient.(esv1beta1.SecretProtectionReporter)
+	if !ok {
+		return nil
+	}
+	return reporter.SecretProtections()
+}
+
+// StoreWarnings returns the deprecation and migration warnings of the stores
+// used through this manager, prefixed with the kind and name of the store.
+func (m *Manager) StoreWarnings() []string {
+	stores := make([]string, 0, len(m.warnings))
+	for store := range m.warnings {
+		stores = append(stores, store)
+	}
+	sort.Strings(stores)
+	var warnings []string
+	for _, store := range stores {
+		for _, warning := range m.warnings[store] {
+			warnings = append(warnings, store+": "+warning)
+		}
+	}
+	return warnings
+}
+
+// SecretExpirations returns the upcoming expirations reported by the clients
+// that were used through this manager.
+func (m *Manager) SecretExpirations() []esv1beta1.SecretExpiration {
+	expirations := append([]esv1beta1.SecretExpiration{}, m.expirations...)
+	for _, val := range m.clientMap {
+		expirations = append(expirations, reportedExpirations(val.client)...)
+	}
+	return expirations
+}
+
+// DescribeExpirations returns a message listing the expirations, ordered by expiry.
+func DescribeExpirations(expirations []esv1beta1.SecretExpiration) string {
+	sorted := append([]esv1beta1.SecretExpiration{}, expirations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Expires.Equal(sorted[j].Expires) {
+			return sorted[i].Key < sorted[j].Key
+		}
+		return sorted[i].Expires.Before(sorted[j].Expires)
+	})
+	keys := make([]string, len(sorted))
+	for i, e := range sorted {
+		keys[i] = fmt.Sprintf("%s expires at %s", e.Key, e.Expires.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(keys, ", ")
+}
+
+func reportedExpirations(secretClient esv1beta1.SecretsClient) []esv1beta1.SecretExpiration {
+	reporter, ok := secretClient.(esv1beta1.SecretExpiryReporter)
+	if !ok {
+		return nil
+	}
+	return reporter.SecretExpirations()
+}
+
+// SecretLifetimes returns the creation and expiry times reported by the clients
+// that were used through this manager.
+func (m *Manager) SecretLifetimes() []esv1beta1.SecretLifetime {
+	lifetimes := append([]esv1beta1.SecretLifetime{}, m.lifetimes...)
+	for _, val := range m.clientMap {
+		lifetimes = append(lifetimes, reportedLifetimes(val.client)...)
+	}
+	return lifetimes
+}
+
+func reportedLifetimes(secretClient esv1beta1.SecretsClient) []esv1beta1.SecretLifetime {
+	reporter, ok := secretClient.(esv1beta1.SecretLifetimeReporter)
+	if !ok {
+		return nil
+	}
+	return reporter.SecretLifetimes()
+}
+
+// SecretMetadata returns the metadata of the provider secrets reported by the clients
+// that were used through this manager.
+func (m *Manager) SecretMetadata() []esv1beta1.SecretMetadata {
+	metadata := append([]esv1beta1.SecretMetadata{}, m.metadata...)
+	for _, val := range m.clientMap {
+		metadata = append(metadata, reportedMetadata(val.client)...)
+	}
+	return metadata
+}
+
+func reportedMetadata(secretClient esv1beta1.SecretsClient) []esv1beta1.SecretMetadata {
+	reporter, ok := secretClient.(esv1beta1.SecretMetadataReporter)
+	if !ok {
+		return nil
+	}
+	return reporter.SecretMetadata()
+}
+
 // Close cleans up all clients.
 func (m *Manager) Close(ctx context.Context) error {
 	var errs []string
@@ -225,41 +379,102 @@ func (m *Manager) shouldProcessSecret(store esv1beta1.GenericStore, ns string) (
 
 	nsLabels := labels.Set(namespace.GetLabels())
 	for _, condition := range store.GetSpec().Conditions {
-		var labelSelectors []*metav1.LabelSelector
-		if condition.NamespaceSelector != nil {
-			labelSelectors = append(labelSelectors, condition.NamespaceSelector)
+		hasNamespaceRules := condition.NamespaceSelector != nil || len(condition.Namespaces) > 0 || len(condition.NamespaceRegexes) > 0
+		hasSubjectRules := condition.ExternalSecretSelector != nil || len(condition.TargetNameRegexes) > 0
+		if !hasNamespaceRules && !hasSubjectRules {
+			continue
 		}
-		for _, n := range condition.Namespaces {
-			labelSelectors = append(labelSelectors, &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"kubernetes.io/metadata.name": n,
-				},
-			})
-		}
-
-		for _, ls := range labelSelectors {
-			selector, err := metav1.LabelSelectorAsSelector(ls)
+		if hasNamespaceRules {
+			match, err := matchesNamespace(condition, ns, nsLabels)
 			if err != nil {
-				return false, fmt.Errorf("failed to convert label selector into selector %v: %w", ls, err)
+				return false, err
 			}
-			if selector.Matches(nsLabels) {
-				return true, nil
+			if !match {
+				continue
 			}
 		}
-
-		for _, reg := range condition.NamespaceRegexes {
-			match, err := regexp.MatchString(reg, ns)
+		if hasSubjectRules {
+			match, err := m.matchesSubject(condition)
 			if err != nil {
-				// Should not happen since store validation already verified the regexes.
-				return false, fmt.Errorf("failed to compile regex %v: %w", reg, err)
+				return false, err
 			}
-
-			if match {
-				return true, nil
+			if !match {
+				continue
 			}
 		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// matchesNamespace returns true if the namespace is chosen by the namespace rules of the condition.
+func matchesNamespace(condition esv1beta1.ClusterSecretStoreCondition, ns string, nsLabels labels.Set) (bool, error) {
+	var labelSelectors []*metav1.LabelSelector
+	if condition.NamespaceSelector != nil {
+		labelSelectors = append(labelSelectors, condition.NamespaceSelector)
+	}
+	for _, n := range condition.Namespaces {
+		labelSelectors = append(labelSelectors, &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"kubernetes.io/metadata.name": n,
+			},
+		})
+	}
+
+	for _, ls := range labelSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(ls)
+		if err != nil {
+			return false, fmt.Errorf("failed to convert label selector into selector %v: %w", ls, err)
+		}
+		if selector.Matches(nsLabels) {
+			return true, nil
+		}
 	}
 
+	for _, reg := range condition.NamespaceRegexes {
+		match, err := regexp.MatchString(reg, ns)
+		if err != nil {
+			// Should not happen since store validation already verified the regexes.
+			return false, fmt.Errorf("failed to compile regex %v: %w", reg, err)
+		}
+
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchesSubject returns true if the ExternalSecret requesting the client is chosen
+// by the ExternalSecret selector and the target name regexes of the condition.
+// Requests without an ExternalSecret never match.
+func (m *Manager) matchesSubject(condition esv1beta1.ClusterSecretStoreCondition) (bool, error) {
+	if m.subject == nil {
+		return false, nil
+	}
+	if condition.ExternalSecretSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(condition.ExternalSecretSelector)
+		if err != nil {
+			return false, fmt.Errorf("failed to convert label selector into selector %v: %w", condition.ExternalSecretSelector, err)
+		}
+		if !selector.Matches(labels.Set(m.subject.labels)) {
+			return false, nil
+		}
+	}
+	if len(condition.TargetNameRegexes) == 0 {
+		return true, nil
+	}
+	for _, reg := range condition.TargetNameRegexes {
+		match, err := regexp.MatchString(reg, m.subject.targetName)
+		if err != nil {
+			// Should not happen since store validation already verified the regexes.
+			return false, fmt.Errorf("failed to compile regex %v: %w", reg, err)
+		}
+		if match {
+			return true, nil
+		}
+	}
 	return false, nil
 }
 
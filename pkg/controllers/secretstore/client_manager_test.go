@@ -310,6 +310,33 @@ func TestManagerGet(t *testing.T) {
 	}
 }
 
+func TestManagerStoreWarnings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = esv1beta1.AddToScheme(scheme)
+	fakeProvider := &WrapProvider{
+		newClientFunc: func(context.Context, esv1beta1.GenericStore, client.Client, string) (esv1beta1.SecretsClient, error) {
+			return &MockFakeClient{id: "1"}, nil
+		},
+		warnings: admission.Warnings{"auth.legacy is deprecated"},
+	}
+	esv1beta1.ForceRegister(fakeProvider, &esv1beta1.SecretStoreProvider{
+		AWS: &esv1beta1.AWSProvider{},
+	})
+	store := &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "foo"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{AWS: &esv1beta1.AWSProvider{}},
+		},
+	}
+	mgr := NewManager(fakeclient.NewClientBuilder().WithScheme(scheme).Build(), "", false)
+	assert.Empty(t, mgr.StoreWarnings())
+
+	_, err := mgr.GetFromStore(context.Background(), store, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SecretStore legacy: auth.legacy is deprecated"}, mgr.StoreWarnings())
+}
+
 func TestShouldProcessSecret(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = clientgoscheme.AddToScheme(scheme)
@@ -321,6 +348,7 @@ func TestShouldProcessSecret(t *testing.T) {
 		name       string
 		conditions []esv1beta1.ClusterSecretStoreCondition
 		namespace  *corev1.Namespace
+		subject    *esv1beta1.ExternalSecret
 		wantErr    string
 		want       bool
 	}{
@@ -366,6 +394,108 @@ func TestShouldProcessSecret(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "processes an ExternalSecret selector condition",
+			conditions: []esv1beta1.ClusterSecretStoreCondition{
+				{
+					NamespaceRegexes: []string{`test-*`},
+					ExternalSecretSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"team": "a"},
+					},
+				},
+			},
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testNamespace,
+				},
+			},
+			subject: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "es",
+					Labels: map[string]string{"team": "a"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "shouldn't process if the ExternalSecret selector doesn't match",
+			conditions: []esv1beta1.ClusterSecretStoreCondition{
+				{
+					NamespaceRegexes: []string{`test-*`},
+					ExternalSecretSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"team": "a"},
+					},
+				},
+			},
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testNamespace,
+				},
+			},
+			subject: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "es",
+					Labels: map[string]string{"team": "b"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "processes a target name regex condition",
+			conditions: []esv1beta1.ClusterSecretStoreCondition{
+				{
+					TargetNameRegexes: []string{`^app-`},
+				},
+			},
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testNamespace,
+				},
+			},
+			subject: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "es",
+				},
+				Spec: esv1beta1.ExternalSecretSpec{
+					Target: esv1beta1.ExternalSecretTarget{Name: "app-credentials"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "target name defaults to the ExternalSecret name",
+			conditions: []esv1beta1.ClusterSecretStoreCondition{
+				{
+					TargetNameRegexes: []string{`^app-`},
+				},
+			},
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testNamespace,
+				},
+			},
+			subject: &esv1beta1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "db-credentials",
+				},
+			},
+			want: false,
+		},
+		{
+			name: "shouldn't process a subject condition without an ExternalSecret",
+			conditions: []esv1beta1.ClusterSecretStoreCondition{
+				{
+					NamespaceRegexes:  []string{`test-*`},
+					TargetNameRegexes: []string{`.*`},
+				},
+			},
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testNamespace,
+				},
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -391,6 +521,9 @@ func TestShouldProcessSecret(t *testing.T) {
 				enableFloodgate: true,
 				clientMap:       clientMap,
 			}
+			if tt.subject != nil {
+				mgr.ForExternalSecret(tt.subject)
+			}
 
 			got, err := mgr.shouldProcessSecret(defaultStore, tt.namespace.Name)
 			require.NoError(t, err)
@@ -406,6 +539,7 @@ type WrapProvider struct {
 		esv1beta1.GenericStore,
 		client.Client,
 		string) (esv1beta1.SecretsClient, error)
+	warnings admission.Warnings
 }
 
 // NewClient constructs a SecretsManager Provider.
@@ -423,7 +557,7 @@ func (f *WrapProvider) Capabilities() esv1beta1.SecretStoreCapabilities {
 
 // ValidateStore checks if the provided store is valid.
 func (f *WrapProvider) ValidateStore(_ esv1beta1.GenericStore) (admission.Warnings, error) {
-	return nil, nil
+	return f.warnings, nil
 }
 
 type MockFakeClient struct {
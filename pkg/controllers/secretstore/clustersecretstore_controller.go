@@ -19,11 +19,15 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
@@ -36,6 +40,9 @@ import (
 // ClusterStoreReconciler reconciles a SecretStore object.
 type ClusterStoreReconciler struct {
 	client.Client
+	// APIReader lists the PushSecrets of all namespaces for the orphan cleanup,
+	// the cache of the client only holds the watched namespaces. Defaults to the client.
+	APIReader       client.Reader
 	Log             logr.Logger
 	Scheme          *runtime.Scheme
 	ControllerClass string
@@ -62,14 +69,33 @@ func (r *ClusterStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
-	return reconcile(ctx, req, &css, r.Client, log, r.ControllerClass, cssmetrics.GetGaugeVec, r.recorder, r.RequeueInterval)
+	return reconcile(ctx, req, &css, r.Client, r.APIReader, log, r.ControllerClass, cssmetrics.GetGaugeVec, r.recorder, r.RequeueInterval)
 }
 
 // SetupWithManager returns a new controller builder that will be started by the provided Manager.
 func (r *ClusterStoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.recorder = mgr.GetEventRecorderFor("cluster-secret-store")
 
+	// Index the referenced secrets to revalidate stores when their credentials have changed
+	if err := IndexReferencedSecrets(context.Background(), mgr.GetFieldIndexer(), &esapi.ClusterSecretStore{}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&esapi.ClusterSecretStore{}).
+		Watches(
+			&v1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findStoresForSecret),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+			builder.OnlyMetadata,
+		).
 		Complete(r)
 }
+
+func (r *ClusterStoreReconciler) findStoresForSecret(ctx context.Context, secret client.Object) []ctrl.Request {
+	stores, err := FindStoresForSecret(ctx, r.Client, secret, esapi.ClusterSecretStoreKind)
+	if err != nil {
+		return []ctrl.Request{}
+	}
+	return storeRequests(stores)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,20 +38,20 @@ const (
 	errUnableCreateClient  = "unable to create client"
 	errUnableValidateStore = "unable to validate store"
 	errUnableGetProvider   = "unable to get store provider"
+	errProviderVersion     = "unable to get provider version"
 
 	msgStoreValidated = "store validated"
+	msgValidationLost = "store is no longer valid, last validated at %s"
 )
 
-func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl client.Client, log logr.Logger,
+func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl client.Client, reader client.Reader, log logr.Logger,
 	controllerClass string, gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder, requeueInterval time.Duration) (ctrl.Result, error) {
 	if !ShouldProcessStore(ss, controllerClass) {
 		log.V(1).Info("skip store")
 		return ctrl.Result{}, nil
 	}
 
-	if ss.GetSpec().RefreshInterval != 0 {
-		requeueInterval = time.Second * time.Duration(ss.GetSpec().RefreshInterval)
-	}
+	requeueInterval = validationInterval(ss.GetSpec(), requeueInterval)
 
 	// patch status when done processing
 	p := client.MergeFrom(ss.Copy())
@@ -64,7 +65,7 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 	// validateStore modifies the store conditions
 	// we have to patch the status
 	log.V(1).Info("validating")
-	err := validateStore(ctx, req.Namespace, controllerClass, ss, cl, gaugeVecGetter, recorder)
+	version, err := validateStore(ctx, req.Namespace, controllerClass, ss, cl, gaugeVecGetter, recorder, log)
 	if err != nil {
 		log.Error(err, "unable to validate store")
 		return ctrl.Result{}, err
@@ -73,9 +74,31 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	// admission warnings are only shown at apply time, surface deprecations as events as well
+	for _, warning := range esapi.StoreWarnings(ss) {
+		recorder.Event(ss, v1.EventTypeWarning, esapi.ReasonDeprecated, warning)
+	}
+	var orphans []string
+	if ss.GetSpec().OrphanCleanup != nil {
+		if reader == nil {
+			reader = cl
+		}
+		var cleanupErr error
+		orphans, cleanupErr = cleanupOrphans(ctx, req.Namespace, controllerClass, ss, cl, reader, recorder)
+		if cleanupErr != nil {
+			// keep the previous report, the cleanup is retried with the next reconcile
+			log.Error(cleanupErr, errOrphanCleanup)
+			recorder.Event(ss, v1.EventTypeWarning, esapi.ReasonOrphanedSecrets, cleanupErr.Error())
+			orphans = ss.GetStatus().OrphanedSecrets
+		}
+	}
+	now := metav1.Now()
 	capStatus := esapi.SecretStoreStatus{
-		Capabilities: storeProvider.Capabilities(),
-		Conditions:   ss.GetStatus().Conditions,
+		Capabilities:    storeProvider.Capabilities(),
+		ProviderVersion: version,
+		LastValidated:   &now,
+		Conditions:      ss.GetStatus().Conditions,
+		OrphanedSecrets: orphans,
 	}
 	ss.SetStatus(capStatus)
 
@@ -90,26 +113,63 @@ func reconcile(ctx context.Context, req ctrl.Request, ss esapi.GenericStore, cl
 
 // validateStore tries to construct a new client
 // if it fails sets a condition and writes events.
+// It returns the backend version if the client is able to report it,
+// the previously reported version is kept if the lookup fails.
 func validateStore(ctx context.Context, namespace, controllerClass string, store esapi.GenericStore,
-	client client.Client, gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder) error {
+	client client.Client, gaugeVecGetter metrics.GaugeVevGetter, recorder record.EventRecorder, log logr.Logger) (string, error) {
 	mgr := NewManager(client, controllerClass, false)
 	defer mgr.Close(ctx)
 	cl, err := mgr.GetFromStore(ctx, store, namespace)
 	if err != nil {
+		recordLostValidation(store, recorder)
 		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, esapi.ReasonInvalidProviderConfig, errUnableCreateClient)
 		SetExternalSecretCondition(store, *cond, gaugeVecGetter)
 		recorder.Event(store, v1.EventTypeWarning, esapi.ReasonInvalidProviderConfig, err.Error())
-		return fmt.Errorf(errStoreClient, err)
+		return "", fmt.Errorf(errStoreClient, err)
 	}
 	validationResult, err := cl.Validate()
 	if err != nil && validationResult != esapi.ValidationResultUnknown {
+		recordLostValidation(store, recorder)
 		cond := NewSecretStoreCondition(esapi.SecretStoreReady, v1.ConditionFalse, esapi.ReasonValidationFailed, errUnableValidateStore)
 		SetExternalSecretCondition(store, *cond, gaugeVecGetter)
 		recorder.Event(store, v1.EventTypeWarning, esapi.ReasonValidationFailed, err.Error())
-		return fmt.Errorf(errValidationFailed, err)
+		return "", fmt.Errorf(errValidationFailed, err)
 	}
 
-	return nil
+	reporter, ok := unwrapClient(cl).(esapi.ProviderVersionReporter)
+	if !ok {
+		return "", nil
+	}
+	version, err := reporter.ProviderVersion(ctx)
+	if err != nil {
+		log.Error(err, errProviderVersion)
+		return store.GetStatus().ProviderVersion, nil
+	}
+	return version, nil
+}
+
+// validationInterval returns the interval the store is validated again:
+// spec.validationInterval, spec.refreshInterval or the controller default.
+func validationInterval(spec *esapi.SecretStoreSpec, defaultInterval time.Duration) time.Duration {
+	if spec.ValidationInterval != nil && spec.ValidationInterval.Duration > 0 {
+		return spec.ValidationInterval.Duration
+	}
+	if spec.RefreshInterval != 0 {
+		return time.Second * time.Duration(spec.RefreshInterval)
+	}
+	return defaultInterval
+}
+
+// recordLostValidation emits an event if the store was valid before the failed validation,
+// so the moment a store breaks, e.g. because of expired credentials, stands out from repeated failures.
+func recordLostValidation(store esapi.GenericStore, recorder record.EventRecorder) {
+	status := store.GetStatus()
+	cond := GetSecretStoreCondition(status, esapi.SecretStoreReady)
+	if cond == nil || cond.Status != v1.ConditionTrue || status.LastValidated == nil {
+		return
+	}
+	recorder.Event(store, v1.EventTypeWarning, esapi.ReasonValidationLost,
+		fmt.Sprintf(msgValidationLost, status.LastValidated.UTC().Format(time.RFC3339)))
 }
 
 // ShouldProcessStore returns true if the store should be processed.
@@ -16,11 +16,14 @@ package secretstore
 
 import (
 	"context"
+	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 
 	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 
@@ -118,6 +121,7 @@ var _ = Describe("SecretStore reconcile", func() {
 				return ss.GetStatus().Conditions[0].Reason == esapi.ReasonStoreValid &&
 					ss.GetStatus().Conditions[0].Type == esapi.SecretStoreReady &&
 					ss.GetStatus().Conditions[0].Status == corev1.ConditionTrue &&
+					ss.GetStatus().LastValidated != nil &&
 					hasEvent(tc.store.GetTypeMeta().Kind, ss.GetName(), esapi.ReasonStoreValid)
 			}).
 				WithTimeout(time.Second * 10).
@@ -246,3 +250,83 @@ func hasEvent(involvedKind, name, reason string) bool {
 	}
 	return false
 }
+
+func TestValidationInterval(t *testing.T) {
+	defaultInterval := time.Minute * 5
+	tests := []struct {
+		name string
+		spec esapi.SecretStoreSpec
+		want time.Duration
+	}{
+		{
+			name: "controller default",
+			want: defaultInterval,
+		},
+		{
+			name: "refresh interval",
+			spec: esapi.SecretStoreSpec{RefreshInterval: 60},
+			want: time.Minute,
+		},
+		{
+			name: "validation interval takes precedence",
+			spec: esapi.SecretStoreSpec{
+				RefreshInterval:    60,
+				ValidationInterval: &metav1.Duration{Duration: time.Hour},
+			},
+			want: time.Hour,
+		},
+		{
+			name: "zero validation interval",
+			spec: esapi.SecretStoreSpec{
+				RefreshInterval:    60,
+				ValidationInterval: &metav1.Duration{},
+			},
+			want: time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validationInterval(&tt.spec, defaultInterval))
+		})
+	}
+}
+
+func TestRecordLostValidation(t *testing.T) {
+	lastValidated := metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	tests := []struct {
+		name   string
+		status esapi.SecretStoreStatus
+		want   []string
+	}{
+		{
+			name: "never validated",
+		},
+		{
+			name: "was ready",
+			status: esapi.SecretStoreStatus{
+				Conditions:    []esapi.SecretStoreStatusCondition{{Type: esapi.SecretStoreReady, Status: corev1.ConditionTrue}},
+				LastValidated: &lastValidated,
+			},
+			want: []string{"Warning ValidationLost store is no longer valid, last validated at 2024-01-02T03:04:05Z"},
+		},
+		{
+			name: "already failing",
+			status: esapi.SecretStoreStatus{
+				Conditions:    []esapi.SecretStoreStatusCondition{{Type: esapi.SecretStoreReady, Status: corev1.ConditionFalse}},
+				LastValidated: &lastValidated,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			recordLostValidation(&esapi.SecretStore{Status: tt.status}, recorder)
+			close(recorder.Events)
+			var events []string
+			for ev := range recorder.Events {
+				events = append(events, ev)
+			}
+			assert.Equal(t, tt.want, events)
+		})
+	}
+}
@@ -44,11 +44,18 @@ func SetUpMetrics() {
 		Help:      "The status condition of a specific Cluster Secret Store",
 	}, ctrlmetrics.ConditionMetricLabelNames)
 
-	metrics.Registry.MustRegister(clusterSecretStoreReconcileDuration, clusterSecretStoreCondition)
+	clusterSecretStoreStartupValidationFailed := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ClusterSecretStoreSubsystem,
+		Name:      commonmetrics.StartupValidationFailedKey,
+		Help:      "Whether the Cluster Secret Store failed the validation at startup of the controller",
+	}, ctrlmetrics.NonConditionMetricLabelNames)
+
+	metrics.Registry.MustRegister(clusterSecretStoreReconcileDuration, clusterSecretStoreCondition, clusterSecretStoreStartupValidationFailed)
 
 	gaugeVecMetrics = map[string]*prometheus.GaugeVec{
-		ClusterSecretStoreReconcileDurationKey: clusterSecretStoreReconcileDuration,
-		commonmetrics.StatusConditionKey:       clusterSecretStoreCondition,
+		ClusterSecretStoreReconcileDurationKey:   clusterSecretStoreReconcileDuration,
+		commonmetrics.StatusConditionKey:         clusterSecretStoreCondition,
+		commonmetrics.StartupValidationFailedKey: clusterSecretStoreStartupValidationFailed,
 	}
 }
 
@@ -0,0 +1,170 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// referencedSecretsKey indexes the secrets referenced by the provider configuration of a store.
+// SecretStores are indexed by the secret name, ClusterSecretStores by namespace/name.
+const referencedSecretsKey = ".spec.provider.secretRefs"
+
+var (
+	secretKeySelectorType = reflect.TypeOf(esmeta.SecretKeySelector{})
+
+	registeredIndexes sync.Map
+)
+
+type indexRegistration struct {
+	indexer client.FieldIndexer
+	kind    string
+}
+
+// IndexReferencedSecrets registers a field index of the secrets referenced by
+// the provider configuration of the given store type (SecretStore or ClusterSecretStore).
+// The index is registered once per indexer, so it is safe to call it from multiple controllers.
+func IndexReferencedSecrets(ctx context.Context, indexer client.FieldIndexer, store esapi.GenericStore) error {
+	kind := fmt.Sprintf("%T", store)
+	if _, loaded := registeredIndexes.LoadOrStore(indexRegistration{indexer: indexer, kind: kind}, struct{}{}); loaded {
+		return nil
+	}
+	err := indexer.IndexField(ctx, store, referencedSecretsKey, func(obj client.Object) []string {
+		gs, ok := obj.(esapi.GenericStore)
+		if !ok {
+			return nil
+		}
+		return referencedSecrets(gs)
+	})
+	if err != nil {
+		registeredIndexes.Delete(indexRegistration{indexer: indexer, kind: kind})
+	}
+	return err
+}
+
+// FindStoresForSecret returns the stores of the given kind whose provider configuration references the secret.
+// The index must have been registered with IndexReferencedSecrets.
+func FindStoresForSecret(ctx context.Context, cl client.Client, secret client.Object, kind string) ([]esapi.GenericStore, error) {
+	if kind == esapi.ClusterSecretStoreKind {
+		var list esapi.ClusterSecretStoreList
+		err := cl.List(ctx, &list, client.MatchingFields{referencedSecretsKey: secret.GetNamespace() + "/" + secret.GetName()})
+		if err != nil {
+			return nil, err
+		}
+		stores := make([]esapi.GenericStore, len(list.Items))
+		for i := range list.Items {
+			stores[i] = &list.Items[i]
+		}
+		return stores, nil
+	}
+
+	var list esapi.SecretStoreList
+	err := cl.List(ctx, &list, client.InNamespace(secret.GetNamespace()), client.MatchingFields{referencedSecretsKey: secret.GetName()})
+	if err != nil {
+		return nil, err
+	}
+	stores := make([]esapi.GenericStore, len(list.Items))
+	for i := range list.Items {
+		stores[i] = &list.Items[i]
+	}
+	return stores, nil
+}
+
+// referencedSecrets returns the index values of all secrets referenced
+// through a SecretKeySelector in the provider configuration of the store.
+// Selectors of a ClusterSecretStore without namespace are skipped, they are resolved
+// in the namespace of the ExternalSecret and can not be matched to a single secret.
+func referencedSecrets(store esapi.GenericStore) []string {
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil {
+		return nil
+	}
+	isCluster := store.GetKind() == esapi.ClusterSecretStoreKind
+
+	set := make(map[string]struct{})
+	walkSecretKeySelectors(reflect.ValueOf(spec.Provider), func(sel *esmeta.SecretKeySelector) {
+		if sel.Name == "" {
+			return
+		}
+		if !isCluster {
+			set[sel.Name] = struct{}{}
+			return
+		}
+		if sel.Namespace != nil && *sel.Namespace != "" {
+			set[*sel.Namespace+"/"+sel.Name] = struct{}{}
+		}
+	})
+
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func walkSecretKeySelectors(v reflect.Value, fn func(*esmeta.SecretKeySelector)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walkSecretKeySelectors(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		if v.Type() == secretKeySelectorType {
+			sel := v.Interface().(esmeta.SecretKeySelector)
+			fn(&sel)
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				walkSecretKeySelectors(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkSecretKeySelectors(v.Index(i), fn)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkSecretKeySelectors(iter.Value(), fn)
+		}
+	default:
+	}
+}
+
+func storeRequests(stores []esapi.GenericStore) []ctrl.Request {
+	requests := make([]ctrl.Request, len(stores))
+	for i := range stores {
+		requests[i] = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      stores[i].GetName(),
+				Namespace: stores[i].GetNamespace(),
+			},
+		}
+	}
+	return requests
+}
@@ -0,0 +1,92 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestReferencedSecrets(t *testing.T) {
+	ns := "credentials"
+	gitlab := &esv1beta1.SecretStoreProvider{
+		Gitlab: &esv1beta1.GitlabProvider{
+			Auth: esv1beta1.GitlabAuth{
+				SecretRef: esv1beta1.GitlabSecretRef{
+					AccessToken: esmeta.SecretKeySelector{Name: "gitlab-token", Namespace: &ns, Key: "token"},
+				},
+			},
+		},
+	}
+	webhook := &esv1beta1.SecretStoreProvider{
+		Webhook: &esv1beta1.WebhookProvider{
+			Secrets: []esv1beta1.WebhookSecret{
+				{Name: "a", SecretRef: esmeta.SecretKeySelector{Name: "header-b"}},
+				{Name: "b", SecretRef: esmeta.SecretKeySelector{Name: "header-a"}},
+				{Name: "c", SecretRef: esmeta.SecretKeySelector{Name: "header-a", Key: "other"}},
+			},
+			Auth: &esv1beta1.WebhookAuth{
+				PrivateKeyJWT: &esv1beta1.WebhookPrivateKeyJWT{
+					PrivateKeySecretRef: esmeta.SecretKeySelector{Name: "jwt-key"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		store esv1beta1.GenericStore
+		want  []string
+	}{
+		{
+			name:  "no provider",
+			store: &esv1beta1.SecretStore{},
+			want:  nil,
+		},
+		{
+			name:  "secret store uses the secret name",
+			store: &esv1beta1.SecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: gitlab}},
+			want:  []string{"gitlab-token"},
+		},
+		{
+			name:  "cluster secret store uses namespace and name",
+			store: &esv1beta1.ClusterSecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: gitlab}},
+			want:  []string{"credentials/gitlab-token"},
+		},
+		{
+			name:  "cluster secret store skips selectors without namespace",
+			store: &esv1beta1.ClusterSecretStore{Spec: esv1beta1.SecretStoreSpec{Provider: webhook}},
+			want:  []string{},
+		},
+		{
+			name: "nested selectors are deduplicated and sorted",
+			store: &esv1beta1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       esv1beta1.SecretStoreSpec{Provider: webhook},
+			},
+			want: []string{"header-a", "header-b", "jwt-key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, referencedSecrets(tt.store))
+		})
+	}
+}
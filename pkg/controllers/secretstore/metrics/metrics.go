@@ -22,7 +22,10 @@ import (
 	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
 )
 
-const StatusConditionKey = "status_condition"
+const (
+	StatusConditionKey         = "status_condition"
+	StartupValidationFailedKey = "startup_validation_failed"
+)
 
 type GaugeVevGetter func(key string) *prometheus.GaugeVec
 
@@ -0,0 +1,209 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errOrphanCleanup        = "unable to clean up orphaned secrets"
+	errOrphanNotSupported   = "provider does not support listing managed secrets"
+	errListManagedSecrets   = "could not list managed secrets: %w"
+	errListPushSecrets      = "could not list PushSecrets: %w"
+	errDeleteOrphanedSecret = "could not delete orphaned secret %s: %w"
+
+	msgOrphanedSecrets      = "found %d orphaned secrets: %v"
+	msgOrphanedSecretDelete = "deleted orphaned secret %s"
+	msgOrphanCleanupPending = "not deleting orphaned secrets: PushSecret %s/%s is not ready"
+
+	msgOrphanPushedElsewhere = "not deleting orphaned secret %s: it is pushed by a PushSecret of another store"
+)
+
+// cleanupOrphans lists the provider secrets pushed through the store, identified by the owner tag
+// holding the store UID, and returns the ones that are not pushed by any PushSecret using the store. In Delete mode the orphaned secrets are
+// deleted from the provider, unless a PushSecret using the store is not ready: its remote keys
+// may not be known yet, so the deletion is postponed to the next reconcile.
+// Orphans whose remote key is pushed by a PushSecret of another store are not deleted either,
+// the other store may point to the same provider and takes the secret over with its next push.
+// The PushSecrets are listed with the reader, which must not be restricted to the watched namespaces:
+// the keys of PushSecrets in unwatched namespaces would be deleted as orphans.
+func cleanupOrphans(ctx context.Context, namespace, controllerClass string, store esapi.GenericStore,
+	kubeClient client.Client, reader client.Reader, recorder record.EventRecorder) ([]string, error) {
+	mgr := NewManager(kubeClient, controllerClass, false)
+	defer mgr.Close(ctx)
+	cl, err := mgr.GetFromStore(ctx, store, namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errStoreClient, err)
+	}
+	lister, ok := unwrapClient(cl).(esapi.ManagedSecretLister)
+	if !ok {
+		return nil, fmt.Errorf(errOrphanNotSupported)
+	}
+	managed, err := lister.ListManagedSecrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errListManagedSecrets, err)
+	}
+	pushSecrets, otherPushSecrets, err := pushSecretsForStore(ctx, reader, store)
+	if err != nil {
+		return nil, err
+	}
+	orphans := findOrphans(managed, lister, pushSecrets, store)
+	if len(orphans) == 0 {
+		return nil, nil
+	}
+
+	if store.GetSpec().OrphanCleanup.Mode != esapi.OrphanCleanupModeDelete {
+		recorder.Event(store, v1.EventTypeWarning, esapi.ReasonOrphanedSecrets, fmt.Sprintf(msgOrphanedSecrets, len(orphans), orphans))
+		return orphans, nil
+	}
+	for i := range pushSecrets {
+		if !isPushSecretReady(&pushSecrets[i]) {
+			recorder.Event(store, v1.EventTypeWarning, esapi.ReasonOrphanedSecrets,
+				fmt.Sprintf(msgOrphanCleanupPending, pushSecrets[i].Namespace, pushSecrets[i].Name))
+			return orphans, nil
+		}
+	}
+	pushedElsewhere := make(map[string]struct{})
+	for i := range otherPushSecrets {
+		addPushedKeys(pushedElsewhere, lister, &otherPushSecrets[i], "")
+	}
+	remaining := make([]string, 0, len(orphans))
+	for _, key := range orphans {
+		if _, ok := pushedElsewhere[key]; ok {
+			recorder.Event(store, v1.EventTypeWarning, esapi.ReasonOrphanedSecrets, fmt.Sprintf(msgOrphanPushedElsewhere, key))
+			remaining = append(remaining, key)
+			continue
+		}
+		if err := cl.DeleteSecret(ctx, esv1alpha1.PushSecretRemoteRef{RemoteKey: key}); err != nil {
+			recorder.Event(store, v1.EventTypeWarning, esapi.ReasonOrphanedSecrets, fmt.Errorf(errDeleteOrphanedSecret, key, err).Error())
+			remaining = append(remaining, key)
+			continue
+		}
+		recorder.Event(store, v1.EventTypeNormal, esapi.ReasonOrphanDeleted, fmt.Sprintf(msgOrphanedSecretDelete, key))
+	}
+	if len(remaining) == 0 {
+		return nil, nil
+	}
+	return remaining, nil
+}
+
+// pushSecretsForStore returns the PushSecrets which reference the store by name or label selector,
+// and the PushSecrets of all other stores. PushSecrets can only reference a SecretStore in their own namespace.
+func pushSecretsForStore(ctx context.Context, cl client.Reader, store esapi.GenericStore) ([]esv1alpha1.PushSecret, []esv1alpha1.PushSecret, error) {
+	var list esv1alpha1.PushSecretList
+	if err := cl.List(ctx, &list); err != nil {
+		return nil, nil, fmt.Errorf(errListPushSecrets, err)
+	}
+	var pushSecrets, others []esv1alpha1.PushSecret
+	for i := range list.Items {
+		if usesStore(&list.Items[i], store) {
+			pushSecrets = append(pushSecrets, list.Items[i])
+		} else {
+			others = append(others, list.Items[i])
+		}
+	}
+	return pushSecrets, others, nil
+}
+
+func usesStore(ps *esv1alpha1.PushSecret, store esapi.GenericStore) bool {
+	if store.GetKind() == esapi.SecretStoreKind && ps.Namespace != store.GetNamespace() {
+		return false
+	}
+	for _, ref := range ps.Spec.SecretStoreRefs {
+		if refersToStore(ref, store) {
+			return true
+		}
+	}
+	return false
+}
+
+func refersToStore(ref esv1alpha1.PushSecretStoreRef, store esapi.GenericStore) bool {
+	kind := ref.Kind
+	if kind == "" {
+		kind = esapi.SecretStoreKind
+	}
+	if kind != store.GetKind() {
+		return false
+	}
+	if ref.LabelSelector == nil {
+		return ref.Name == store.GetName()
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ref.LabelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(store.GetLabels()))
+}
+
+// findOrphans returns the sorted managed secrets that are neither synced
+// by one of the PushSecrets nor part of their spec.
+func findOrphans(managed []string, lister esapi.ManagedSecretLister, pushSecrets []esv1alpha1.PushSecret, store esapi.GenericStore) []string {
+	storeKey := fmt.Sprintf("%v/%v", store.GetKind(), store.GetName())
+	pushed := make(map[string]struct{})
+	for i := range pushSecrets {
+		addPushedKeys(pushed, lister, &pushSecrets[i], storeKey)
+	}
+
+	var orphans []string
+	for _, key := range managed {
+		if _, ok := pushed[key]; !ok {
+			orphans = append(orphans, key)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// addPushedKeys adds the managed secret keys of the remote keys in the spec of the PushSecret
+// and the ones it synced to the store, or to any store if storeKey is empty.
+func addPushedKeys(pushed map[string]struct{}, lister esapi.ManagedSecretLister, ps *esv1alpha1.PushSecret, storeKey string) {
+	for key, synced := range ps.Status.SyncedPushSecrets {
+		if storeKey != "" && key != storeKey {
+			continue
+		}
+		for _, data := range synced {
+			pushed[lister.ManagedSecretKey(data.Match.RemoteRef.RemoteKey)] = struct{}{}
+		}
+	}
+	for _, data := range ps.Spec.Data {
+		if data.Match.RemoteRef.RemoteKey != "" {
+			pushed[lister.ManagedSecretKey(data.Match.RemoteRef.RemoteKey)] = struct{}{}
+		}
+	}
+}
+
+func isPushSecretReady(ps *esv1alpha1.PushSecret) bool {
+	if !ps.DeletionTimestamp.IsZero() {
+		return false
+	}
+	for _, cond := range ps.Status.Conditions {
+		if cond.Type == esv1alpha1.PushSecretReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,289 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type listerClient struct {
+	MockFakeClient
+	managed []string
+	deleted []string
+}
+
+func (c *listerClient) ListManagedSecrets(_ context.Context) ([]string, error) {
+	return c.managed, nil
+}
+
+func (c *listerClient) ManagedSecretKey(remoteKey string) string {
+	return remoteKey
+}
+
+func (c *listerClient) DeleteSecret(_ context.Context, ref esv1beta1.PushSecretRemoteRef) error {
+	c.deleted = append(c.deleted, ref.GetRemoteKey())
+	return nil
+}
+
+func TestCleanupOrphans(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+	_ = esv1alpha1.AddToScheme(scheme)
+
+	const namespace = "default"
+	ready := []esv1alpha1.PushSecretStatusCondition{{Type: esv1alpha1.PushSecretReady, Status: corev1.ConditionTrue}}
+	pushSecret := func(name string, ref esv1alpha1.PushSecretStoreRef, remoteKey string, conditions []esv1alpha1.PushSecretStatusCondition) *esv1alpha1.PushSecret {
+		return &esv1alpha1.PushSecret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: esv1alpha1.PushSecretSpec{
+				SecretStoreRefs: []esv1alpha1.PushSecretStoreRef{ref},
+			},
+			Status: esv1alpha1.PushSecretStatus{
+				SyncedPushSecrets: esv1alpha1.SyncedPushSecretsMap{
+					"SecretStore/vault": {
+						remoteKey: {Match: esv1alpha1.PushSecretMatch{RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: remoteKey}}},
+					},
+				},
+				Conditions: conditions,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		mode        esv1beta1.OrphanCleanupMode
+		managed     []string
+		pushSecrets []client.Object
+		// uncached PushSecrets are only visible to the reader, e.g. in namespaces the cache does not watch
+		uncached    bool
+		wantOrphans []string
+		wantDeleted []string
+	}{
+		{
+			name:    "report orphans",
+			mode:    esv1beta1.OrphanCleanupModeReport,
+			managed: []string{"renamed", "db-creds", "api-key"},
+			pushSecrets: []client.Object{
+				pushSecret("db", esv1alpha1.PushSecretStoreRef{Name: "vault"}, "db-creds", ready),
+				pushSecret("api", esv1alpha1.PushSecretStoreRef{LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "a"},
+				}}, "api-key", ready),
+			},
+			wantOrphans: []string{"renamed"},
+		},
+		{
+			name:    "PushSecrets of other stores do not protect secrets",
+			mode:    esv1beta1.OrphanCleanupModeReport,
+			managed: []string{"db-creds"},
+			pushSecrets: []client.Object{
+				pushSecret("db", esv1alpha1.PushSecretStoreRef{Name: "other"}, "db-creds", ready),
+				pushSecret("cluster", esv1alpha1.PushSecretStoreRef{Name: "vault", Kind: esv1beta1.ClusterSecretStoreKind}, "db-creds", ready),
+			},
+			wantOrphans: []string{"db-creds"},
+		},
+		{
+			name:    "delete orphans",
+			mode:    esv1beta1.OrphanCleanupModeDelete,
+			managed: []string{"renamed", "db-creds", "old"},
+			pushSecrets: []client.Object{
+				pushSecret("db", esv1alpha1.PushSecretStoreRef{Name: "vault"}, "db-creds", ready),
+			},
+			wantDeleted: []string{"old", "renamed"},
+		},
+		{
+			name:    "postpone delete while a PushSecret is not ready",
+			mode:    esv1beta1.OrphanCleanupModeDelete,
+			managed: []string{"renamed", "db-creds"},
+			pushSecrets: []client.Object{
+				pushSecret("db", esv1alpha1.PushSecretStoreRef{Name: "vault"}, "db-creds", ready),
+				pushSecret("new", esv1alpha1.PushSecretStoreRef{Name: "vault"}, "new", nil),
+			},
+			wantOrphans: []string{"renamed"},
+		},
+		{
+			name:    "PushSecrets are listed with the reader",
+			mode:    esv1beta1.OrphanCleanupModeDelete,
+			managed: []string{"renamed", "db-creds"},
+			pushSecrets: []client.Object{
+				pushSecret("db", esv1alpha1.PushSecretStoreRef{Name: "vault"}, "db-creds", ready),
+			},
+			uncached:    true,
+			wantDeleted: []string{"renamed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lister := &listerClient{managed: tt.managed}
+			esv1beta1.ForceRegister(&WrapProvider{
+				newClientFunc: func(_ context.Context, _ esv1beta1.GenericStore, _ client.Client, _ string) (esv1beta1.SecretsClient, error) {
+					return lister, nil
+				},
+			}, &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}})
+
+			store := &esv1beta1.SecretStore{
+				TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+				ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: namespace, Labels: map[string]string{"team": "a"}},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider:      &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+					OrphanCleanup: &esv1beta1.SecretStoreOrphanCleanup{Mode: tt.mode},
+				},
+			}
+			kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(tt.pushSecrets...).Build()
+			reader := kube
+			if tt.uncached {
+				kube = fakeclient.NewClientBuilder().WithScheme(scheme).Build()
+			}
+
+			orphans, err := cleanupOrphans(context.Background(), namespace, "", store, kube, reader, record.NewFakeRecorder(10))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOrphans, orphans)
+			assert.Equal(t, tt.wantDeleted, lister.deleted)
+		})
+	}
+}
+
+// sharedRemote is a provider secret namespace two stores push to,
+// a secret is tagged with the UID of the store which pushed it last.
+type sharedRemote struct {
+	owners  map[string]string
+	deleted []string
+}
+
+type sharedRemoteClient struct {
+	MockFakeClient
+	remote *sharedRemote
+	owner  string
+}
+
+func (c *sharedRemoteClient) ListManagedSecrets(_ context.Context) ([]string, error) {
+	var keys []string
+	for key, owner := range c.remote.owners {
+		if owner == c.owner {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (c *sharedRemoteClient) ManagedSecretKey(remoteKey string) string {
+	return remoteKey
+}
+
+func (c *sharedRemoteClient) DeleteSecret(_ context.Context, ref esv1beta1.PushSecretRemoteRef) error {
+	delete(c.remote.owners, ref.GetRemoteKey())
+	c.remote.deleted = append(c.remote.deleted, ref.GetRemoteKey())
+	return nil
+}
+
+func TestCleanupOrphansTwoStores(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+	_ = esv1alpha1.AddToScheme(scheme)
+
+	const namespace = "default"
+	newStore := func(name string, mode esv1beta1.OrphanCleanupMode) *esv1beta1.SecretStore {
+		return &esv1beta1.SecretStore{
+			TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(name + "-uid")},
+			Spec: esv1beta1.SecretStoreSpec{
+				Provider:      &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+				OrphanCleanup: &esv1beta1.SecretStoreOrphanCleanup{Mode: mode},
+			},
+		}
+	}
+	// the PushSecret moved from store a to store b, which has not pushed the secret yet
+	moved := &esv1alpha1.PushSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: namespace},
+		Spec: esv1alpha1.PushSecretSpec{
+			SecretStoreRefs: []esv1alpha1.PushSecretStoreRef{{Name: "b"}},
+		},
+		Status: esv1alpha1.PushSecretStatus{
+			SyncedPushSecrets: esv1alpha1.SyncedPushSecretsMap{
+				"SecretStore/b": {
+					"db-creds": {Match: esv1alpha1.PushSecretMatch{RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "db-creds"}}},
+				},
+			},
+			Conditions: []esv1alpha1.PushSecretStatusCondition{{Type: esv1alpha1.PushSecretReady, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		mode        esv1beta1.OrphanCleanupMode
+		wantOrphans []string
+		wantDeleted []string
+	}{
+		{
+			name:        "report",
+			mode:        esv1beta1.OrphanCleanupModeReport,
+			wantOrphans: []string{"db-creds", "old"},
+		},
+		{
+			name:        "delete keeps secrets pushed through the other store",
+			mode:        esv1beta1.OrphanCleanupModeDelete,
+			wantOrphans: []string{"db-creds"},
+			wantDeleted: []string{"old"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote := &sharedRemote{owners: map[string]string{"db-creds": "a-uid", "old": "a-uid"}}
+			esv1beta1.ForceRegister(&WrapProvider{
+				newClientFunc: func(_ context.Context, store esv1beta1.GenericStore, _ client.Client, _ string) (esv1beta1.SecretsClient, error) {
+					return &sharedRemoteClient{remote: remote, owner: string(store.GetUID())}, nil
+				},
+			}, &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}})
+			kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(moved.DeepCopy()).Build()
+			storeA, storeB := newStore("a", tt.mode), newStore("b", tt.mode)
+
+			orphans, err := cleanupOrphans(context.Background(), namespace, "", storeA, kube, kube, record.NewFakeRecorder(10))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOrphans, orphans)
+			assert.Equal(t, tt.wantDeleted, remote.deleted)
+			assert.Contains(t, remote.owners, "db-creds")
+
+			// the push through store b moves the secret to it
+			remote.owners["db-creds"] = "b-uid"
+			orphans, err = cleanupOrphans(context.Background(), namespace, "", storeA, kube, kube, record.NewFakeRecorder(10))
+			require.NoError(t, err)
+			if tt.mode == esv1beta1.OrphanCleanupModeDelete {
+				assert.Empty(t, orphans)
+			} else {
+				assert.Equal(t, []string{"old"}, orphans)
+			}
+			orphans, err = cleanupOrphans(context.Background(), namespace, "", storeB, kube, kube, record.NewFakeRecorder(10))
+			require.NoError(t, err)
+			assert.Empty(t, orphans)
+			assert.Equal(t, tt.wantDeleted, remote.deleted)
+		})
+	}
+}
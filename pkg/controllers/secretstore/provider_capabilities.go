@@ -0,0 +1,64 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errInvalidCapabilitiesRef = "provider capabilities ConfigMap must be given as namespace/name, got %q"
+	errPublishCapabilities    = "could not publish provider capabilities to ConfigMap %s: %w"
+)
+
+// PublishProviderCapabilities writes the capabilities of the enabled providers to the ConfigMap given as namespace/name,
+// so tooling can tell what a provider supports before using it.
+// Every key of the ConfigMap is named after a provider, its value lists the capabilities in YAML.
+// Keys of providers that are no longer enabled are removed.
+func PublishProviderCapabilities(ctx context.Context, kube client.Client, ref string) error {
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return fmt.Errorf(errInvalidCapabilitiesRef, ref)
+	}
+	data := make(map[string]string)
+	for provider, capabilities := range esv1beta1.GetProviderCapabilities() {
+		raw, err := yaml.Marshal(capabilities)
+		if err != nil {
+			return err
+		}
+		data[provider] = string(raw)
+	}
+
+	cm := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	_, err := controllerutil.CreateOrUpdate(ctx, kube, cm, func() error {
+		cm.Data = data
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(errPublishCapabilities, types.NamespacedName{Namespace: namespace, Name: name}, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestPublishProviderCapabilities(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "capabilities", Namespace: "external-secrets"},
+		Data:       map[string]string{"removed": "capabilities: ReadOnly"},
+	}).Build()
+
+	require.NoError(t, PublishProviderCapabilities(ctx, kube, "external-secrets/capabilities"))
+
+	var cm corev1.ConfigMap
+	require.NoError(t, kube.Get(ctx, types.NamespacedName{Namespace: "external-secrets", Name: "capabilities"}, &cm))
+	assert.NotContains(t, cm.Data, "removed")
+	var capabilities esv1beta1.ProviderCapabilities
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data["fake"]), &capabilities))
+	assert.Equal(t, esv1beta1.GetProviderCapabilities()["fake"], capabilities)
+
+	assert.EqualError(t, PublishProviderCapabilities(ctx, kube, "capabilities"), `provider capabilities ConfigMap must be given as namespace/name, got "capabilities"`)
+}
@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errInvalidDefaultsRef  = "provider defaults ConfigMap must be given as namespace/name, got %q"
+	errGetProviderDefaults = "could not get provider defaults ConfigMap %s: %w"
+	errParseDefaults       = "could not parse provider defaults of %q in ConfigMap %s: %w"
+	errMergeDefaults       = "could not merge provider defaults of %q: %w"
+)
+
+var (
+	// providerDefaultsRef references the ConfigMap with the provider defaults, it is empty if not configured.
+	providerDefaultsRef types.NamespacedName
+	// providerDefaultsReader reads the ConfigMap with the provider defaults.
+	providerDefaultsReader client.Reader
+)
+
+// SetProviderDefaultsConfigMap configures the ConfigMap holding the global provider defaults, given as namespace/name.
+// Every key of the ConfigMap is named after a provider of the store spec, e.g. `vault` or `webhook`,
+// its value is the provider configuration in YAML that is merged under the configuration of every store of that provider.
+// The reader should not be backed by the cache of the manager: a cached read would watch every ConfigMap of the cluster
+// and fails if the namespace of the ConfigMap is not watched, e.g. with --namespaces.
+func SetProviderDefaultsConfigMap(ref string, reader client.Reader) error {
+	if ref == "" {
+		providerDefaultsRef = types.NamespacedName{}
+		providerDefaultsReader = nil
+		return nil
+	}
+	namespace, name, ok := strings.Cut(ref, "/")
+	if !ok || namespace == "" || name == "" {
+		return fmt.Errorf(errInvalidDefaultsRef, ref)
+	}
+	providerDefaultsRef = types.NamespacedName{Namespace: namespace, Name: name}
+	providerDefaultsReader = reader
+	return nil
+}
+
+// withProviderDefaults returns a copy of the store with the provider defaults merged under its provider configuration.
+// Fields set in the store take precedence, objects are merged recursively and lists are replaced.
+// Empty strings and null values of the store are considered unset, as not every field is omitted when empty.
+// The store is returned as-is if no defaults are configured for its provider.
+func withProviderDefaults(ctx context.Context, store esv1beta1.GenericStore) (esv1beta1.GenericStore, error) {
+	if providerDefaultsRef.Name == "" {
+		return store, nil
+	}
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil {
+		return store, nil
+	}
+
+	var cm v1.ConfigMap
+	if err := providerDefaultsReader.Get(ctx, providerDefaultsRef, &cm); err != nil {
+		return nil, fmt.Errorf(errGetProviderDefaults, providerDefaultsRef, err)
+	}
+	if len(cm.Data) == 0 {
+		return store, nil
+	}
+
+	raw, err := json.Marshal(spec.Provider)
+	if err != nil {
+		return nil, err
+	}
+	var providers map[string]any
+	if err := json.Unmarshal(raw, &providers); err != nil {
+		return nil, err
+	}
+	var merged string
+	for name, config := range providers {
+		defaults, ok := cm.Data[name]
+		if !ok {
+			continue
+		}
+		var base map[string]any
+		if err := yaml.Unmarshal([]byte(defaults), &base); err != nil {
+			return nil, fmt.Errorf(errParseDefaults, name, providerDefaultsRef, err)
+		}
+		overrides, ok := config.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf(errMergeDefaults, name, fmt.Errorf("unexpected provider configuration %T", config))
+		}
+		providers[name] = mergeDefaults(base, overrides)
+		merged = name
+	}
+	if merged == "" {
+		return store, nil
+	}
+
+	raw, err = json.Marshal(providers)
+	if err != nil {
+		return nil, err
+	}
+	var provider esv1beta1.SecretStoreProvider
+	if err := json.Unmarshal(raw, &provider); err != nil {
+		return nil, fmt.Errorf(errMergeDefaults, merged, err)
+	}
+	withDefaults := store.Copy()
+	withDefaults.GetSpec().Provider = &provider
+	return withDefaults, nil
+}
+
+// mergeDefaults merges the overrides into the defaults.
+func mergeDefaults(defaults, overrides map[string]any) map[string]any {
+	if defaults == nil {
+		defaults = make(map[string]any, len(overrides))
+	}
+	for key, override := range overrides {
+		if override == nil || override == "" {
+			continue
+		}
+		overrideMap, isMap := override.(map[string]any)
+		defaultMap, defaultIsMap := defaults[key].(map[string]any)
+		if isMap && defaultIsMap {
+			defaults[key] = mergeDefaults(defaultMap, overrideMap)
+			continue
+		}
+		defaults[key] = override
+	}
+	return defaults
+}
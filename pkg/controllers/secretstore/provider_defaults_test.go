@@ -0,0 +1,119 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestWithProviderDefaults(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "provider-defaults", Namespace: "external-secrets"},
+		Data: map[string]string{
+			"vault": `
+server: https://vault.example.com
+namespace: platform
+caBundle: Y2E=
+auth:
+  kubernetes:
+    mountPath: kubernetes
+    role: default
+`,
+		},
+	}
+	kube := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+
+	path := "secret"
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "default", Generation: 2},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Vault: &esv1beta1.VaultProvider{
+					Server: "https://vault.team-a.example.com",
+					Path:   &path,
+					Auth: esv1beta1.VaultAuth{
+						Kubernetes: &esv1beta1.VaultKubernetesAuth{Role: "team-a"},
+					},
+				},
+			},
+		},
+	}
+
+	// no defaults configured
+	require.NoError(t, SetProviderDefaultsConfigMap("", nil))
+	got, err := withProviderDefaults(ctx, store)
+	require.NoError(t, err)
+	assert.Same(t, store, got)
+
+	require.NoError(t, SetProviderDefaultsConfigMap("external-secrets/provider-defaults", kube))
+	defer func() { _ = SetProviderDefaultsConfigMap("", nil) }()
+	got, err = withProviderDefaults(ctx, store)
+	require.NoError(t, err)
+
+	vault := got.GetSpec().Provider.Vault
+	assert.Equal(t, "https://vault.team-a.example.com", vault.Server)
+	assert.Equal(t, &path, vault.Path)
+	assert.Equal(t, "platform", *vault.Namespace)
+	assert.Equal(t, []byte("ca"), vault.CABundle)
+	assert.Equal(t, "team-a", vault.Auth.Kubernetes.Role)
+	assert.Equal(t, "kubernetes", vault.Auth.Kubernetes.Path)
+	assert.Equal(t, store.GetGeneration(), got.GetGeneration())
+	// the store itself is not modified
+	assert.Nil(t, store.Spec.Provider.Vault.Namespace)
+
+	// stores of other providers are returned as-is
+	other := &esv1beta1.SecretStore{
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}},
+		},
+	}
+	got, err = withProviderDefaults(ctx, other)
+	require.NoError(t, err)
+	assert.Same(t, other, got)
+
+	// invalid defaults
+	cm.Data["vault"] = "server: [invalid"
+	require.NoError(t, kube.Update(ctx, cm))
+	_, err = withProviderDefaults(ctx, store)
+	assert.ErrorContains(t, err, `could not parse provider defaults of "vault"`)
+
+	// missing ConfigMap
+	require.NoError(t, kube.Delete(ctx, cm))
+	_, err = withProviderDefaults(ctx, store)
+	assert.ErrorContains(t, err, "could not get provider defaults ConfigMap external-secrets/provider-defaults")
+}
+
+func TestSetProviderDefaultsConfigMap(t *testing.T) {
+	defer func() { _ = SetProviderDefaultsConfigMap("", nil) }()
+	assert.NoError(t, SetProviderDefaultsConfigMap("ns/name", nil))
+	assert.Equal(t, "ns", providerDefaultsRef.Namespace)
+	assert.Equal(t, "name", providerDefaultsRef.Name)
+	assert.Error(t, SetProviderDefaultsConfigMap("name", nil))
+	assert.Error(t, SetProviderDefaultsConfigMap("/name", nil))
+}
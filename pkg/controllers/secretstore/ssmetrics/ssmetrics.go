@@ -44,11 +44,18 @@ func SetUpMetrics() {
 		Help:      "The status condition of a specific Secret Store",
 	}, ctrlmetrics.ConditionMetricLabelNames)
 
-	metrics.Registry.MustRegister(secretStoreReconcileDuration, secretStoreCondition)
+	secretStoreStartupValidationFailed := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: SecretStoreSubsystem,
+		Name:      commonmetrics.StartupValidationFailedKey,
+		Help:      "Whether the Secret Store failed the validation at startup of the controller",
+	}, ctrlmetrics.NonConditionMetricLabelNames)
+
+	metrics.Registry.MustRegister(secretStoreReconcileDuration, secretStoreCondition, secretStoreStartupValidationFailed)
 
 	gaugeVecMetrics = map[string]*prometheus.GaugeVec{
-		SecretStoreReconcileDurationKey:  secretStoreReconcileDuration,
-		commonmetrics.StatusConditionKey: secretStoreCondition,
+		SecretStoreReconcileDurationKey:          secretStoreReconcileDuration,
+		commonmetrics.StatusConditionKey:         secretStoreCondition,
+		commonmetrics.StartupValidationFailedKey: secretStoreStartupValidationFailed,
 	}
 }
 
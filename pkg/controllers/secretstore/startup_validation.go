@@ -0,0 +1,231 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esapi "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	ctrlmetrics "github.com/external-secrets/external-secrets/pkg/controllers/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/cssmetrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/metrics"
+	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore/ssmetrics"
+)
+
+const (
+	defaultStartupValidationConcurrency   = 5
+	defaultStartupValidationRetryInterval = time.Second * 30
+
+	errStartupValidationPending = "stores have not been validated yet"
+	errStartupValidationFailed  = "%d stores failed validation: %s"
+)
+
+// StartupValidator validates the stores processed by the controller once at startup.
+// Its Check reports the controller as not ready until all of them are valid,
+// so a rollout with broken credentials stops instead of silently not syncing.
+// Failing stores are validated again until they are valid, stores created after the startup are left to the store reconcilers.
+type StartupValidator struct {
+	Client          client.Client
+	Log             logr.Logger
+	ControllerClass string
+	// ClusterStores validates the ClusterSecretStores as well.
+	ClusterStores bool
+	// Concurrency is the number of stores validated in parallel, defaults to 5.
+	Concurrency int
+	// RetryInterval is the interval failing stores are validated again, defaults to 30s.
+	RetryInterval time.Duration
+
+	mu      sync.Mutex
+	done    bool
+	failing []string
+}
+
+// NeedLeaderElection returns false, every replica has to validate the stores to become ready.
+func (v *StartupValidator) NeedLeaderElection() bool {
+	return false
+}
+
+// Start validates the stores until all of them are valid or the context is done.
+// Errors listing the stores are retried like failed validations, returning them would stop the manager.
+func (v *StartupValidator) Start(ctx context.Context) error {
+	retryInterval := v.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = defaultStartupValidationRetryInterval
+	}
+	var failing map[string]bool
+	for {
+		stores, err := v.listStores(ctx)
+		if err != nil {
+			v.Log.Error(err, "could not list stores for the startup validation, retrying", "retryInterval", retryInterval)
+		} else {
+			if failing != nil {
+				stores = filterStores(stores, failing)
+			}
+			failing = v.validateStores(ctx, stores)
+			if len(failing) == 0 {
+				v.Log.Info("validated stores", "stores", len(stores))
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// Check implements a readiness check, it fails until all stores have been validated successfully.
+func (v *StartupValidator) Check(_ *http.Request) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.done {
+		return errors.New(errStartupValidationPending)
+	}
+	if len(v.failing) > 0 {
+		return fmt.Errorf(errStartupValidationFailed, len(v.failing), strings.Join(v.failing, ", "))
+	}
+	return nil
+}
+
+func (v *StartupValidator) listStores(ctx context.Context) ([]esapi.GenericStore, error) {
+	var stores []esapi.GenericStore
+	var ssList esapi.SecretStoreList
+	if err := v.Client.List(ctx, &ssList); err != nil {
+		return nil, err
+	}
+	for i := range ssList.Items {
+		stores = append(stores, &ssList.Items[i])
+	}
+	if v.ClusterStores {
+		var cssList esapi.ClusterSecretStoreList
+		if err := v.Client.List(ctx, &cssList); err != nil {
+			return nil, err
+		}
+		for i := range cssList.Items {
+			stores = append(stores, &cssList.Items[i])
+		}
+	}
+
+	processed := stores[:0]
+	for _, store := range stores {
+		if ShouldProcessStore(store, v.ControllerClass) {
+			processed = append(processed, store)
+		}
+	}
+	return processed, nil
+}
+
+// validateStores validates the stores with bounded concurrency and returns the keys of the failing stores.
+func (v *StartupValidator) validateStores(ctx context.Context, stores []esapi.GenericStore) map[string]bool {
+	concurrency := v.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultStartupValidationConcurrency
+	}
+	var mu sync.Mutex
+	failing := make(map[string]bool)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, store := range stores {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(store esapi.GenericStore) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := v.validateStore(ctx, store)
+			setStartupValidationFailed(store, err != nil)
+			if err != nil {
+				v.Log.Error(err, "store failed validation at startup", "kind", store.GetKind(), "namespace", store.GetNamespace(), "name", store.GetName())
+				mu.Lock()
+				failing[startupValidationKey(store)] = true
+				mu.Unlock()
+			}
+		}(store)
+	}
+	wg.Wait()
+
+	keys := make([]string, 0, len(failing))
+	for key := range failing {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	v.mu.Lock()
+	v.done = true
+	v.failing = keys
+	v.mu.Unlock()
+	return failing
+}
+
+// validateStore creates a client of the store and validates it like the store reconcilers,
+// without touching the status of the store.
+func (v *StartupValidator) validateStore(ctx context.Context, store esapi.GenericStore) error {
+	mgr := NewManager(v.Client, v.ControllerClass, false)
+	defer mgr.Close(ctx)
+	cl, err := mgr.GetFromStore(ctx, store, store.GetNamespace())
+	if err != nil {
+		return fmt.Errorf(errStoreClient, err)
+	}
+	validationResult, err := cl.Validate()
+	if err != nil && validationResult != esapi.ValidationResultUnknown {
+		return fmt.Errorf(errValidationFailed, err)
+	}
+	return nil
+}
+
+func filterStores(stores []esapi.GenericStore, keys map[string]bool) []esapi.GenericStore {
+	filtered := make([]esapi.GenericStore, 0, len(keys))
+	for _, store := range stores {
+		if keys[startupValidationKey(store)] {
+			filtered = append(filtered, store)
+		}
+	}
+	return filtered
+}
+
+func startupValidationKey(store esapi.GenericStore) string {
+	if store.GetNamespace() == "" {
+		return store.GetKind() + " " + store.GetName()
+	}
+	return store.GetKind() + " " + store.GetNamespace() + "/" + store.GetName()
+}
+
+func setStartupValidationFailed(store esapi.GenericStore, failed bool) {
+	getter := ssmetrics.GetGaugeVec
+	if store.GetKind() == esapi.ClusterSecretStoreKind {
+		getter = cssmetrics.GetGaugeVec
+	}
+	gauge := getter(metrics.StartupValidationFailedKey)
+	if gauge == nil {
+		return
+	}
+	labels := ctrlmetrics.RefineNonConditionMetricLabels(map[string]string{"name": store.GetName(), "namespace": store.GetNamespace()})
+	value := 0.0
+	if failed {
+		value = 1
+	}
+	gauge.With(labels).Set(value)
+}
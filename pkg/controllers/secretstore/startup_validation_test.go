@@ -0,0 +1,126 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestStartupValidator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = esv1beta1.AddToScheme(scheme)
+
+	esv1beta1.ForceRegister(&WrapProvider{
+		newClientFunc: func(_ context.Context, store esv1beta1.GenericStore, _ client.Client, _ string) (esv1beta1.SecretsClient, error) {
+			if store.GetLabels()["credentials"] == "broken" {
+				return nil, errors.New("invalid credentials")
+			}
+			return &MockFakeClient{}, nil
+		},
+	}, &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}})
+
+	spec := func(controller string) esv1beta1.SecretStoreSpec {
+		return esv1beta1.SecretStoreSpec{Controller: controller, Provider: &esv1beta1.SecretStoreProvider{Fake: &esv1beta1.FakeProvider{}}}
+	}
+	broken := map[string]string{"credentials": "broken"}
+	kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&esv1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "default"}, Spec: spec("")},
+		&esv1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "default", Labels: broken}, Spec: spec("")},
+		&esv1beta1.ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: "broken", Labels: broken}, Spec: spec("")},
+		&esv1beta1.ClusterSecretStore{ObjectMeta: metav1.ObjectMeta{Name: "other-class", Labels: broken}, Spec: spec("other")},
+	).Build()
+
+	validator := &StartupValidator{
+		Client:          kube,
+		Log:             logr.Discard(),
+		ControllerClass: "default",
+		ClusterStores:   true,
+		Concurrency:     2,
+		RetryInterval:   time.Millisecond * 10,
+	}
+	assert.EqualError(t, validator.Check(nil), "stores have not been validated yet")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- validator.Start(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		err := validator.Check(nil)
+		return err != nil && err.Error() == "2 stores failed validation: ClusterSecretStore broken, SecretStore default/broken"
+	}, time.Second, time.Millisecond*10)
+
+	// fixing the credentials makes the controller ready
+	var css esv1beta1.ClusterSecretStore
+	require.NoError(t, kube.Get(ctx, client.ObjectKey{Name: "broken"}, &css))
+	css.Labels = nil
+	require.NoError(t, kube.Update(ctx, &css))
+	require.NoError(t, kube.Delete(ctx, &esv1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "default"}}))
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("stores were not validated again")
+	}
+	assert.NoError(t, validator.Check(nil))
+}
+
+func TestStartupValidatorRetriesListErrors(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = esv1beta1.AddToScheme(scheme)
+
+	var mu sync.Mutex
+	failures := 2
+	kube := fakeclient.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, cl client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if failures > 0 {
+				failures--
+				return errors.New("connection refused")
+			}
+			return cl.List(ctx, list, opts...)
+		},
+	}).Build()
+	validator := &StartupValidator{
+		Client:          kube,
+		Log:             logr.Discard(),
+		ControllerClass: "default",
+		RetryInterval:   time.Millisecond * 10,
+	}
+
+	// a transient error listing the stores must not stop the manager
+	require.NoError(t, validator.Start(context.Background()))
+	assert.NoError(t, validator.Check(nil))
+	assert.Zero(t, failures)
+}
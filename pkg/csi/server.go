@@ -0,0 +1,181 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package csi implements a provider for the secrets-store CSI driver.
+// It allows pods to mount the data of an ExternalSecret as files
+// without creating a Secret in the cluster.
+package csi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/csi/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	// RuntimeName is the name the provider reports to the CSI driver.
+	RuntimeName = "external-secrets"
+
+	providerAPIVersion = "v1alpha1"
+
+	// attributePodNamespace is set by the CSI driver to the namespace of the pod mounting the volume.
+	attributePodNamespace = "csi.storage.k8s.io/pod.namespace"
+	// attributeExternalSecret is the SecretProviderClass parameter holding the name of the ExternalSecret.
+	attributeExternalSecret = "externalSecret"
+
+	errUnmarshalAttributes = "could not unmarshal attributes: %w"
+	errUnmarshalPermission = "could not unmarshal permission: %w"
+	errMissingAttribute    = "missing attribute %q"
+	errGetExternalSecret   = "could not get ExternalSecret %s: %w"
+	errRenderSecret        = "could not render ExternalSecret %s: %w"
+	errInvalidFileName     = "key %q can not be used as file name: %s"
+)
+
+// SecretRenderer renders the target Secret of an ExternalSecret.
+type SecretRenderer interface {
+	Render(ctx context.Context, externalSecret *esv1beta1.ExternalSecret) (*v1.Secret, error)
+}
+
+// Server implements the provider API of the secrets-store CSI driver.
+type Server struct {
+	v1alpha1.UnimplementedCSIDriverProviderServer
+
+	client   client.Client
+	renderer SecretRenderer
+	log      logr.Logger
+}
+
+// NewServer returns a Server that reads ExternalSecrets with the given client.
+func NewServer(cl client.Client, renderer SecretRenderer) *Server {
+	return &Server{
+		client:   cl,
+		renderer: renderer,
+		log:      ctrl.Log.WithName("csi"),
+	}
+}
+
+// Serve listens on the unix socket at socketPath and serves the provider API until ctx is done.
+func (s *Server) Serve(ctx context.Context, socketPath string) error {
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove stale socket %s: %w", socketPath, err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s: %w", socketPath, err)
+	}
+
+	srv := grpc.NewServer()
+	v1alpha1.RegisterCSIDriverProviderServer(srv, s)
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	s.log.Info("serving secrets-store CSI provider", "socket", socketPath)
+	return srv.Serve(listener)
+}
+
+// Version returns the name and version of the provider.
+func (s *Server) Version(_ context.Context, _ *v1alpha1.VersionRequest) (*v1alpha1.VersionResponse, error) {
+	runtimeVersion := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		runtimeVersion = info.Main.Version
+	}
+	return &v1alpha1.VersionResponse{
+		Version:        providerAPIVersion,
+		RuntimeName:    RuntimeName,
+		RuntimeVersion: runtimeVersion,
+	}, nil
+}
+
+// Mount renders the ExternalSecret referenced by the SecretProviderClass
+// and returns its data as files. Every key of the rendered Secret becomes one file.
+func (s *Server) Mount(ctx context.Context, req *v1alpha1.MountRequest) (*v1alpha1.MountResponse, error) {
+	var attributes map[string]string
+	if err := json.Unmarshal([]byte(req.GetAttributes()), &attributes); err != nil {
+		return nil, fmt.Errorf(errUnmarshalAttributes, err)
+	}
+	var permission os.FileMode
+	if err := json.Unmarshal([]byte(req.GetPermission()), &permission); err != nil {
+		return nil, fmt.Errorf(errUnmarshalPermission, err)
+	}
+
+	ref := types.NamespacedName{
+		Namespace: attributes[attributePodNamespace],
+		Name:      attributes[attributeExternalSecret],
+	}
+	if ref.Namespace == "" {
+		return nil, fmt.Errorf(errMissingAttribute, attributePodNamespace)
+	}
+	if ref.Name == "" {
+		return nil, fmt.Errorf(errMissingAttribute, attributeExternalSecret)
+	}
+
+	// the ExternalSecret is always read from the namespace of the pod,
+	// so a pod can only mount ExternalSecrets of its own namespace.
+	var externalSecret esv1beta1.ExternalSecret
+	if err := s.client.Get(ctx, ref, &externalSecret); err != nil {
+		return nil, fmt.Errorf(errGetExternalSecret, ref, err)
+	}
+
+	secret, err := s.renderer.Render(ctx, &externalSecret)
+	if err != nil {
+		return nil, fmt.Errorf(errRenderSecret, ref, err)
+	}
+
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return nil, fmt.Errorf(errInvalidFileName, key, strings.Join(errs, ", "))
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	files := make([]*v1alpha1.File, 0, len(keys))
+	for _, key := range keys {
+		files = append(files, &v1alpha1.File{
+			Path:     key,
+			Mode:     int32(permission),
+			Contents: secret.Data[key],
+		})
+	}
+
+	s.log.V(1).Info("mounted ExternalSecret", "ExternalSecret", ref, "files", len(files))
+	return &v1alpha1.MountResponse{
+		ObjectVersion: []*v1alpha1.ObjectVersion{{
+			Id:      fmt.Sprintf("externalsecret/%s", ref),
+			Version: utils.ObjectHash(secret.Data),
+		}},
+		Files: files,
+	}, nil
+}
@@ -0,0 +1,125 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package csi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/csi/v1alpha1"
+)
+
+type fakeRenderer struct {
+	data map[string][]byte
+}
+
+func (f *fakeRenderer) Render(_ context.Context, es *esv1beta1.ExternalSecret) (*v1.Secret, error) {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: es.Name, Namespace: es.Namespace},
+		Data:       f.data,
+	}, nil
+}
+
+func newTestServer(t *testing.T, data map[string][]byte) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, esv1beta1.AddToScheme(scheme))
+	kube := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(&esv1beta1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "app"},
+	}).Build()
+	return NewServer(kube, &fakeRenderer{data: data})
+}
+
+func mountRequest(t *testing.T, attributes map[string]string) *v1alpha1.MountRequest {
+	t.Helper()
+	raw, err := json.Marshal(attributes)
+	require.NoError(t, err)
+	return &v1alpha1.MountRequest{
+		Attributes: string(raw),
+		Permission: "420",
+		TargetPath: "/var/lib/kubelet/pods/uid/volumes/kubernetes.io~csi/secrets/mount",
+	}
+}
+
+func TestMount(t *testing.T) {
+	srv := newTestServer(t, map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("s3cr3t"),
+	})
+
+	res, err := srv.Mount(context.Background(), mountRequest(t, map[string]string{
+		attributePodNamespace:   "app",
+		attributeExternalSecret: "db-credentials",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []*v1alpha1.File{
+		{Path: "password", Mode: 420, Contents: []byte("s3cr3t")},
+		{Path: "username", Mode: 420, Contents: []byte("admin")},
+	}, res.GetFiles())
+	require.Len(t, res.GetObjectVersion(), 1)
+	assert.Equal(t, "externalsecret/app/db-credentials", res.GetObjectVersion()[0].GetId())
+	assert.NotEmpty(t, res.GetObjectVersion()[0].GetVersion())
+}
+
+func TestMountErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       map[string][]byte
+		attributes map[string]string
+		err        string
+	}{
+		{
+			name:       "missing ExternalSecret attribute",
+			attributes: map[string]string{attributePodNamespace: "app"},
+			err:        `missing attribute "externalSecret"`,
+		},
+		{
+			name:       "ExternalSecret in another namespace",
+			attributes: map[string]string{attributePodNamespace: "other", attributeExternalSecret: "db-credentials"},
+			err:        "could not get ExternalSecret other/db-credentials",
+		},
+		{
+			name:       "key is not a valid file name",
+			data:       map[string][]byte{"..": []byte("value")},
+			attributes: map[string]string{attributePodNamespace: "app", attributeExternalSecret: "db-credentials"},
+			err:        `key ".." can not be used as file name`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, tt.data)
+			_, err := srv.Mount(context.Background(), mountRequest(t, tt.attributes))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	srv := newTestServer(t, nil)
+	res, err := srv.Version(context.Background(), &v1alpha1.VersionRequest{Version: "v1alpha1"})
+	require.NoError(t, err)
+	assert.Equal(t, RuntimeName, res.GetRuntimeName())
+	assert.Equal(t, "v1alpha1", res.GetVersion())
+}
@@ -15,7 +15,9 @@ limitations under the License.
 package acr
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -23,6 +25,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
@@ -54,8 +57,37 @@ type TokenGetter interface {
 	GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error)
 }
 
+// dockerConfig is the payload of a kubernetes.io/dockerconfigjson secret.
+type dockerConfig struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+type generateCredentialsRequest struct {
+	TokenID string `json:"tokenId"`
+	Name    string `json:"name"`
+	Expiry  string `json:"expiry,omitempty"`
+}
+
+type generateCredentialsResult struct {
+	Username  string `json:"username"`
+	Passwords []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"passwords"`
+}
+
 const (
-	defaultLoginUsername = "00000000-0000-0000-0000-000000000000"
+	defaultLoginUsername          = "00000000-0000-0000-0000-000000000000"
+	defaultPasswordName           = "password1"
+	generateCredentialsAPIVersion = "2022-12-01"
+
+	errGenerateCredentials = "unable to generate credentials of token %s: %w"
 
 	errNoSpec     = "no config spec provided"
 	errParseSpec  = "unable to parse spec: %w"
@@ -90,7 +122,8 @@ func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON,
 		namespace,
 		kubeClient,
 		fetchACRAccessToken,
-		fetchACRRefreshToken)
+		fetchACRRefreshToken,
+		fetchScopeMapToken)
 }
 
 func (g *Generator) generate(
@@ -100,7 +133,8 @@ func (g *Generator) generate(
 	namespace string,
 	kubeClient kubernetes.Interface,
 	fetchAccessToken accessTokenFetcher,
-	fetchRefreshToken refreshTokenFetcher) (map[string][]byte, error) {
+	fetchRefreshToken refreshTokenFetcher,
+	fetchScopeMapToken scopeMapTokenFetcher) (map[string][]byte, error) {
 	if jsonSpec == nil {
 		return nil, fmt.Errorf(errNoSpec)
 	}
@@ -141,6 +175,13 @@ func (g *Generator) generate(
 	if err != nil {
 		return nil, err
 	}
+	if res.Spec.ScopeMapToken != nil {
+		username, password, err := fetchScopeMapToken(ctx, accessToken, res.Spec.EnvironmentType, res.Spec.ACRRegistry, res.Spec.ScopeMapToken)
+		if err != nil {
+			return nil, err
+		}
+		return credentials(res.Spec.ACRRegistry, username, password)
+	}
 	var acrToken string
 	acrToken, err = fetchRefreshToken(accessToken, res.Spec.TenantID, res.Spec.ACRRegistry)
 	if err != nil {
@@ -152,13 +193,88 @@ func (g *Generator) generate(
 			return nil, err
 		}
 	}
+	return credentials(res.Spec.ACRRegistry, defaultLoginUsername, acrToken)
+}
 
+// credentials returns the username and password, and the payload of a kubernetes.io/dockerconfigjson secret.
+func credentials(registry, username, password string) (map[string][]byte, error) {
+	dockerConfigJSON, err := json.Marshal(dockerConfig{
+		Auths: map[string]dockerConfigEntry{
+			registry: {
+				Username: username,
+				Password: password,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
 	return map[string][]byte{
-		"username": []byte(defaultLoginUsername),
-		"password": []byte(acrToken),
+		"username":         []byte(username),
+		"password":         []byte(password),
+		"dockerconfigjson": dockerConfigJSON,
 	}, nil
 }
 
+type scopeMapTokenFetcher func(ctx context.Context, aadAccessToken string, envType v1beta1.AzureEnvironmentType, registryURL string, token *genv1alpha1.ACRScopeMapToken) (string, string, error)
+
+// fetchScopeMapToken regenerates the password of an ACR token with the Azure Resource Manager API.
+// see: https://learn.microsoft.com/en-us/rest/api/containerregistry/registries/generate-credentials
+func fetchScopeMapToken(ctx context.Context, aadAccessToken string, envType v1beta1.AzureEnvironmentType, registryURL string, token *genv1alpha1.ACRScopeMapToken) (string, string, error) {
+	registryName, _, _ := strings.Cut(registryURL, ".")
+	registryID := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerRegistry/registries/%s",
+		token.SubscriptionID, token.ResourceGroup, registryName)
+	passwordName := token.PasswordName
+	if passwordName == "" {
+		passwordName = defaultPasswordName
+	}
+	request := generateCredentialsRequest{
+		TokenID: registryID + "/tokens/" + token.TokenName,
+		Name:    passwordName,
+	}
+	if token.Expiry != nil {
+		request.Expiry = time.Now().Add(token.Expiry.Duration).UTC().Format(time.RFC3339)
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", "", err
+	}
+	endpoint := strings.TrimSuffix(resourceManagerForType(envType), "/") + registryID + "/generateCredentials?api-version=" + generateCredentialsAPIVersion
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+aadAccessToken)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf(errGenerateCredentials, token.TokenName, err)
+	}
+	defer res.Body.Close()
+	// the credentials are generated asynchronously, the result is returned once they are ready
+	if res.StatusCode == http.StatusAccepted {
+		return "", "", fmt.Errorf(errGenerateCredentials, token.TokenName, errors.New("credentials are still being generated"))
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf(errGenerateCredentials, token.TokenName, fmt.Errorf("unexpected status code %d", res.StatusCode))
+	}
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", "", fmt.Errorf(errGenerateCredentials, token.TokenName, err)
+	}
+	var payload generateCredentialsResult
+	if err := json.Unmarshal(respBody, &payload); err != nil {
+		return "", "", fmt.Errorf(errGenerateCredentials, token.TokenName, err)
+	}
+	for _, p := range payload.Passwords {
+		if p.Name == passwordName {
+			return payload.Username, p.Value, nil
+		}
+	}
+	return "", "", fmt.Errorf(errGenerateCredentials, token.TokenName, fmt.Errorf("%s is missing in the response", passwordName))
+}
+
 type accessTokenFetcher func(acrRefreshToken, tenantID, registryURL, scope string) (string, error)
 
 func fetchACRAccessToken(acrRefreshToken, _, registryURL, scope string) (string, error) {
@@ -365,6 +481,20 @@ func audienceForType(t v1beta1.AzureEnvironmentType) string {
 	return azure.PublicCloud.TokenAudience + suffix
 }
 
+func resourceManagerForType(t v1beta1.AzureEnvironmentType) string {
+	switch t {
+	case v1beta1.AzureEnvironmentChinaCloud:
+		return azure.ChinaCloud.ResourceManagerEndpoint
+	case v1beta1.AzureEnvironmentGermanCloud:
+		return azure.GermanCloud.ResourceManagerEndpoint
+	case v1beta1.AzureEnvironmentUSGovernmentCloud:
+		return azure.USGovernmentCloud.ResourceManagerEndpoint
+	case v1beta1.AzureEnvironmentPublicCloud, "":
+		return azure.PublicCloud.ResourceManagerEndpoint
+	}
+	return azure.PublicCloud.ResourceManagerEndpoint
+}
+
 func parseSpec(data []byte) (*genv1alpha1.ACRAccessToken, error) {
 	var spec genv1alpha1.ACRAccessToken
 	err := yaml.Unmarshal(data, &spec)
@@ -30,6 +30,9 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 )
 
 func TestGenerate(t *testing.T) {
@@ -45,6 +48,7 @@ func TestGenerate(t *testing.T) {
 		namespace           string
 		accessTokenFetcher  accessTokenFetcher
 		refreshTokenFetcher refreshTokenFetcher
+		scopeMapFetcher     scopeMapTokenFetcher
 		clientSecretCreds   clientSecretCredentialFunc
 	}
 	tests := []struct {
@@ -123,8 +127,9 @@ spec:
 				},
 			},
 			want: map[string][]byte{
-				"username": []byte(defaultLoginUsername),
-				"password": []byte("acraccesstoken"),
+				"username":         []byte(defaultLoginUsername),
+				"password":         []byte("acraccesstoken"),
+				"dockerconfigjson": []byte(`{"auths":{"example.azurecr.io":{"username":"00000000-0000-0000-0000-000000000000","password":"acraccesstoken","auth":"MDAwMDAwMDAtMDAwMC0wMDAwLTAwMDAtMDAwMDAwMDAwMDAwOmFjcmFjY2Vzc3Rva2Vu"}}}`),
 			},
 		},
 		{
@@ -178,8 +183,72 @@ spec:
 				},
 			},
 			want: map[string][]byte{
-				"username": []byte(defaultLoginUsername),
-				"password": []byte("acrrefreshtoken"),
+				"username":         []byte(defaultLoginUsername),
+				"password":         []byte("acrrefreshtoken"),
+				"dockerconfigjson": []byte(`{"auths":{"example.azurecr.io":{"username":"00000000-0000-0000-0000-000000000000","password":"acrrefreshtoken","auth":"MDAwMDAwMDAtMDAwMC0wMDAwLTAwMDAtMDAwMDAwMDAwMDAwOmFjcnJlZnJlc2h0b2tlbg=="}}}`),
+			},
+		},
+		{
+			name: "return scope map token password if scopeMapToken is defined",
+			args: args{
+				jsonSpec: &apiextensions.JSON{
+					Raw: []byte(fmt.Sprintf(`apiVersion: generators.external-secrets.io/v1alpha1
+kind: ACRAccessToken
+spec:
+  tenantId: %s
+  registry: %s
+  scopeMapToken:
+    subscriptionId: sub
+    resourceGroup: rg
+    tokenName: pull-only
+  auth:
+    servicePrincipal:
+      secretRef:
+        clientSecret:
+          name: az-secret
+          key: clientsecret
+        clientId:
+          name: az-secret
+          key: clientid`, testUsername, testURL)),
+				},
+				crClient: clientfake.NewClientBuilder().WithObjects(&v1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "az-secret",
+						Namespace: "foobar",
+					},
+					Data: map[string][]byte{
+						"clientsecret": []byte("foo"),
+						"clientid":     []byte("bar"),
+					},
+				}).Build(),
+				namespace: "foobar",
+				ctx:       context.Background(),
+				accessTokenFetcher: func(acrRefreshToken, tenantID, registryURL, scope string) (string, error) {
+					t.Fail()
+					return "", nil
+				},
+				refreshTokenFetcher: func(aadAccessToken, tenantID, registryURL string) (string, error) {
+					t.Fail()
+					return "", nil
+				},
+				scopeMapFetcher: func(_ context.Context, aadAccessToken string, _ v1beta1.AzureEnvironmentType, registryURL string, token *genv1alpha1.ACRScopeMapToken) (string, string, error) {
+					assert.Equal(t, "1234", aadAccessToken)
+					assert.Equal(t, testURL, registryURL)
+					assert.Equal(t, "pull-only", token.TokenName)
+					return "pull-only", "tokenpassword", nil
+				},
+				clientSecretCreds: func(tenantID, clientID, clientSecret string, options *azidentity.ClientSecretCredentialOptions) (TokenGetter, error) {
+					return &FakeTokenGetter{
+						token: azcore.AccessToken{
+							Token: "1234",
+						},
+					}, nil
+				},
+			},
+			want: map[string][]byte{
+				"username":         []byte("pull-only"),
+				"password":         []byte("tokenpassword"),
+				"dockerconfigjson": []byte(`{"auths":{"example.azurecr.io":{"username":"pull-only","password":"tokenpassword","auth":"cHVsbC1vbmx5OnRva2VucGFzc3dvcmQ="}}}`),
 			},
 		},
 	}
@@ -196,6 +265,7 @@ spec:
 				tt.args.kubeClient,
 				tt.args.accessTokenFetcher,
 				tt.args.refreshTokenFetcher,
+				tt.args.scopeMapFetcher,
 			)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Generator.Generate() error = %v, wantErr %v", err, tt.wantErr)
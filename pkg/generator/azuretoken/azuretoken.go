@@ -0,0 +1,200 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuretoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault"
+)
+
+// Generator exchanges Kubernetes service account tokens for Microsoft Entra ID access tokens.
+type Generator struct {
+	// newCredential returns the credential exchanging the service account token.
+	newCredential func(ctx context.Context, spec *genv1alpha1.AzureWorkloadTokenSpec, kube client.Client, namespace string) (azcore.TokenCredential, error)
+}
+
+const (
+	defaultRenewBefore = 15 * time.Minute
+
+	errNoSpec            = "no config spec provided"
+	errParseSpec         = "unable to parse spec: %w"
+	errNoScopes          = "no scopes in spec"
+	errGetServiceAccount = "unable to get service account %s: %w"
+	errMissingIdentity   = "no %s in spec and service account %s is missing annotation %s"
+	errLocalClientInit   = "unable to create local client: %w"
+	errCredential        = "unable to create workload identity credential: %w"
+	errGetToken          = "unable to get access token: %w"
+	errNoExpiry          = "no expires_at in generated data"
+	errParseExpiry       = "unable to parse expires_at: %w"
+
+	contextTimeout = 30 * time.Second
+)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace)
+}
+
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	res, err := parseSpec(jsonSpec)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	newCredential := g.newCredential
+	if newCredential == nil {
+		newCredential = tokenCredential
+	}
+	cred, err := newCredential(ctx, &res.Spec, kube, namespace)
+	if err != nil {
+		return nil, err
+	}
+	tkn, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: res.Spec.Scopes})
+	if err != nil {
+		return nil, fmt.Errorf(errGetToken, err)
+	}
+	return map[string][]byte{
+		"token":      []byte(tkn.Token),
+		"expires_at": []byte(strconv.FormatInt(tkn.ExpiresOn.Unix(), 10)),
+	}, nil
+}
+
+// RenewalTime returns the time the access token is renewed, renewBefore ahead of its expiry.
+func (g *Generator) RenewalTime(jsonSpec *apiextensions.JSON, data map[string][]byte) (time.Time, error) {
+	res, err := parseSpec(jsonSpec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	expiresAt, ok := data["expires_at"]
+	if !ok {
+		return time.Time{}, errors.New(errNoExpiry)
+	}
+	unix, err := strconv.ParseInt(string(expiresAt), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(errParseExpiry, err)
+	}
+	renewBefore := defaultRenewBefore
+	if res.Spec.RenewBefore != nil {
+		renewBefore = res.Spec.RenewBefore.Duration
+	}
+	return time.Unix(unix, 0).Add(-renewBefore), nil
+}
+
+// tokenCredential returns a credential exchanging a token of the referenced service account.
+// Without a service account reference the token and environment variables
+// projected into the controller by the azure workload identity webhook are used.
+func tokenCredential(ctx context.Context, spec *genv1alpha1.AzureWorkloadTokenSpec, kube client.Client, namespace string) (azcore.TokenCredential, error) {
+	opts := azcore.ClientOptions{
+		Cloud: cloud.Configuration{ActiveDirectoryAuthorityHost: keyvault.AadEndpointForType(spec.EnvironmentType)},
+	}
+	if spec.ServiceAccountRef == nil {
+		cred, err := azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			ClientOptions: opts,
+			ClientID:      spec.ClientID,
+			TenantID:      spec.TenantID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(errCredential, err)
+		}
+		return cred, nil
+	}
+	tenantID, clientID, err := identity(ctx, spec, kube, namespace)
+	if err != nil {
+		return nil, err
+	}
+	// controller-runtime/client does not support TokenRequest,
+	// the local client is needed to request the service account token
+	restCfg, err := ctrlcfg.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf(errLocalClientInit, err)
+	}
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf(errLocalClientInit, err)
+	}
+	ref := spec.ServiceAccountRef
+	audiences := append([]string{keyvault.AzureDefaultAudience}, ref.Audiences...)
+	getAssertion := func(ctx context.Context) (string, error) {
+		return keyvault.FetchSAToken(ctx, namespace, ref.Name, audiences, clientset.CoreV1())
+	}
+	cred, err := azidentity.NewClientAssertionCredential(tenantID, clientID, getAssertion, &azidentity.ClientAssertionCredentialOptions{ClientOptions: opts})
+	if err != nil {
+		return nil, fmt.Errorf(errCredential, err)
+	}
+	return cred, nil
+}
+
+// identity returns the tenant and client ID of the spec,
+// falling back to the azure workload identity annotations of the referenced service account.
+func identity(ctx context.Context, spec *genv1alpha1.AzureWorkloadTokenSpec, kube client.Client, namespace string) (string, string, error) {
+	tenantID, clientID := spec.TenantID, spec.ClientID
+	if tenantID != "" && clientID != "" {
+		return tenantID, clientID, nil
+	}
+	name := spec.ServiceAccountRef.Name
+	var sa corev1.ServiceAccount
+	if err := kube.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &sa); err != nil {
+		return "", "", fmt.Errorf(errGetServiceAccount, name, err)
+	}
+	if clientID == "" {
+		var ok bool
+		if clientID, ok = sa.Annotations[keyvault.AnnotationClientID]; !ok {
+			return "", "", fmt.Errorf(errMissingIdentity, "clientId", name, keyvault.AnnotationClientID)
+		}
+	}
+	if tenantID == "" {
+		var ok bool
+		if tenantID, ok = sa.Annotations[keyvault.AnnotationTenantID]; !ok {
+			return "", "", fmt.Errorf(errMissingIdentity, "tenantId", name, keyvault.AnnotationTenantID)
+		}
+	}
+	return tenantID, clientID, nil
+}
+
+func parseSpec(jsonSpec *apiextensions.JSON) (*genv1alpha1.AzureWorkloadToken, error) {
+	if jsonSpec == nil {
+		return nil, errors.New(errNoSpec)
+	}
+	var res genv1alpha1.AzureWorkloadToken
+	if err := yaml.Unmarshal(jsonSpec.Raw, &res); err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	if len(res.Spec.Scopes) == 0 {
+		return nil, errors.New(errNoScopes)
+	}
+	return &res, nil
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.AzureWorkloadTokenKind, &Generator{})
+}
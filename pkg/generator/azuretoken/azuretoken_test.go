@@ -0,0 +1,169 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuretoken
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault"
+)
+
+var expiresOn = time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC)
+
+type fakeCredential struct {
+	err error
+}
+
+func (c fakeCredential) GetToken(_ context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	if c.err != nil {
+		return azcore.AccessToken{}, c.err
+	}
+	return azcore.AccessToken{Token: "token:" + strings.Join(opts.Scopes, ","), ExpiresOn: expiresOn}, nil
+}
+
+func testSpec(fields string) *apiextensions.JSON {
+	return &apiextensions.JSON{Raw: []byte(fmt.Sprintf(`{"spec":{"scopes":["api://my-api/.default"]%s}}`, fields))}
+}
+
+func TestGenerate(t *testing.T) {
+	g := &Generator{
+		newCredential: func(context.Context, *genv1alpha1.AzureWorkloadTokenSpec, client.Client, string) (azcore.TokenCredential, error) {
+			return fakeCredential{}, nil
+		},
+	}
+	got, err := g.generate(context.Background(), testSpec(""), clientfake.NewClientBuilder().Build(), "default")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"token":      []byte("token:api://my-api/.default"),
+		"expires_at": []byte("1714561200"),
+	}, got)
+
+	g.newCredential = func(context.Context, *genv1alpha1.AzureWorkloadTokenSpec, client.Client, string) (azcore.TokenCredential, error) {
+		return fakeCredential{err: errors.New("AADSTS70021: No matching federated identity record found")}, nil
+	}
+	_, err = g.generate(context.Background(), testSpec(""), clientfake.NewClientBuilder().Build(), "default")
+	assert.EqualError(t, err, "unable to get access token: AADSTS70021: No matching federated identity record found")
+
+	_, err = g.generate(context.Background(), nil, clientfake.NewClientBuilder().Build(), "default")
+	assert.EqualError(t, err, errNoSpec)
+	_, err = g.generate(context.Background(), &apiextensions.JSON{Raw: []byte(`{"spec":{}}`)}, clientfake.NewClientBuilder().Build(), "default")
+	assert.EqualError(t, err, errNoScopes)
+}
+
+func TestIdentity(t *testing.T) {
+	annotated := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "annotated",
+			Namespace: "default",
+			Annotations: map[string]string{
+				keyvault.AnnotationClientID: "sa-client",
+				keyvault.AnnotationTenantID: "sa-tenant",
+			},
+		},
+	}
+	plain := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "default"}}
+	kube := clientfake.NewClientBuilder().WithObjects(annotated, plain).Build()
+	tests := []struct {
+		name       string
+		spec       genv1alpha1.AzureWorkloadTokenSpec
+		wantTenant string
+		wantClient string
+		wantErr    string
+	}{
+		{
+			name:       "annotations of the service account",
+			spec:       genv1alpha1.AzureWorkloadTokenSpec{ServiceAccountRef: &smmeta.ServiceAccountSelector{Name: "annotated"}},
+			wantTenant: "sa-tenant",
+			wantClient: "sa-client",
+		},
+		{
+			name:       "spec overrides the annotations",
+			spec:       genv1alpha1.AzureWorkloadTokenSpec{ServiceAccountRef: &smmeta.ServiceAccountSelector{Name: "annotated"}, ClientID: "spec-client"},
+			wantTenant: "sa-tenant",
+			wantClient: "spec-client",
+		},
+		{
+			name:       "spec without service account lookup",
+			spec:       genv1alpha1.AzureWorkloadTokenSpec{ServiceAccountRef: &smmeta.ServiceAccountSelector{Name: "missing"}, ClientID: "spec-client", TenantID: "spec-tenant"},
+			wantTenant: "spec-tenant",
+			wantClient: "spec-client",
+		},
+		{
+			name:    "missing annotation",
+			spec:    genv1alpha1.AzureWorkloadTokenSpec{ServiceAccountRef: &smmeta.ServiceAccountSelector{Name: "plain"}, ClientID: "spec-client"},
+			wantErr: "no tenantId in spec and service account plain is missing annotation " + keyvault.AnnotationTenantID,
+		},
+		{
+			name:    "missing service account",
+			spec:    genv1alpha1.AzureWorkloadTokenSpec{ServiceAccountRef: &smmeta.ServiceAccountSelector{Name: "missing"}},
+			wantErr: `unable to get service account missing: serviceaccounts "missing" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenantID, clientID, err := identity(context.Background(), &tt.spec, kube, "default")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTenant, tenantID)
+			assert.Equal(t, tt.wantClient, clientID)
+		})
+	}
+}
+
+func TestRenewalTime(t *testing.T) {
+	data := map[string][]byte{"expires_at": []byte("1714561200")}
+	tests := []struct {
+		name    string
+		spec    *apiextensions.JSON
+		data    map[string][]byte
+		want    time.Time
+		wantErr string
+	}{
+		{name: "default renewBefore", spec: testSpec(""), data: data, want: expiresOn.Add(-defaultRenewBefore)},
+		{name: "renewBefore", spec: testSpec(`,"renewBefore":"5m"`), data: data, want: expiresOn.Add(-5 * time.Minute)},
+		{name: "no expiry", spec: testSpec(""), data: map[string][]byte{}, wantErr: errNoExpiry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&Generator{}).RenewalTime(tt.spec, tt.data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
@@ -17,10 +17,16 @@ package ecr
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
@@ -39,9 +45,49 @@ const (
 	errNoSpec     = "no config spec provided"
 	errParseSpec  = "unable to parse spec: %w"
 	errCreateSess = "unable to create aws session: %w"
-	errGetToken   = "unable to get authorization token: %w"
+	errGetToken   = "unable to get authorization token of region %s: %w"
+
+	// ecrCredentialHelper is the name of the amazon-ecr-credential-helper binary (docker-credential-ecr-login).
+	ecrCredentialHelper = "ecr-login"
 )
 
+// dockerConfig is the payload of a kubernetes.io/dockerconfigjson secret.
+type dockerConfig struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	Auth      string `json:"auth"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// authToken is the decoded authorization token of a region.
+type authToken struct {
+	region        string
+	username      string
+	password      string
+	auth          string
+	proxyEndpoint string
+	registry      string
+	expiresAt     time.Time
+}
+
+// regionToken is the entry of a region in the regions output.
+type regionToken struct {
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	ProxyEndpoint string `json:"proxy_endpoint"`
+	Registry      string `json:"registry"`
+	ExpiresAt     string `json:"expires_at"`
+}
+
+// credHelpersConfig is the credHelpers fragment of a docker or OCI client config.json.
+type credHelpersConfig struct {
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
 func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
 	return g.generate(ctx, jsonSpec, kube, namespace, ecrFactory)
 }
@@ -75,38 +121,135 @@ func (g *Generator) generate(
 	if err != nil {
 		return nil, fmt.Errorf(errCreateSess, err)
 	}
-	client := ecrFunc(sess)
+	tokens := make([]authToken, 0, 1+len(res.Spec.Regions))
+	for _, region := range generatorRegions(&res.Spec) {
+		token, err := getAuthToken(ecrFunc(sess, endpointConfig(&res.Spec, region)))
+		if err != nil {
+			return nil, fmt.Errorf(errGetToken, region, err)
+		}
+		token.region = region
+		tokens = append(tokens, token)
+	}
+
+	auths := make(map[string]dockerConfigEntry, len(tokens))
+	helpers := make(map[string]string, len(tokens))
+	expiresAt := tokens[0].expiresAt
+	for _, token := range tokens {
+		auths[token.registry] = dockerConfigEntry{
+			Username:  token.username,
+			Password:  token.password,
+			Auth:      token.auth,
+			ExpiresAt: token.expiresAt.Format(time.RFC3339),
+		}
+		helpers[token.registry] = ecrCredentialHelper
+		if token.expiresAt.Before(expiresAt) {
+			expiresAt = token.expiresAt
+		}
+	}
+	dockerConfigJSON, err := json.Marshal(dockerConfig{Auths: auths})
+	if err != nil {
+		return nil, err
+	}
+	credHelpers, err := json.Marshal(credHelpersConfig{CredHelpers: helpers})
+	if err != nil {
+		return nil, err
+	}
+	// the keys of the first region are kept as is, the other regions are only
+	// part of the regions map and the merged docker configs.
+	out := map[string][]byte{
+		"username":         []byte(tokens[0].username),
+		"password":         []byte(tokens[0].password),
+		"proxy_endpoint":   []byte(tokens[0].proxyEndpoint),
+		"expires_at":       []byte(strconv.FormatInt(expiresAt.Unix(), 10)),
+		"registry":         []byte(tokens[0].registry),
+		"dockerconfigjson": dockerConfigJSON,
+		"cred_helpers":     credHelpers,
+	}
+	if len(tokens) > 1 {
+		regions := make(map[string]regionToken, len(tokens))
+		for _, token := range tokens {
+			regions[token.region] = regionToken{
+				Username:      token.username,
+				Password:      token.password,
+				ProxyEndpoint: token.proxyEndpoint,
+				Registry:      token.registry,
+				ExpiresAt:     strconv.FormatInt(token.expiresAt.Unix(), 10),
+			}
+		}
+		if out["regions"], err = json.Marshal(regions); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// getAuthToken fetches and decodes the authorization token of the registry of the client.
+func getAuthToken(client ecriface.ECRAPI) (authToken, error) {
 	out, err := client.GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{})
 	if err != nil {
-		return nil, fmt.Errorf(errGetToken, err)
+		return authToken{}, err
 	}
 	if len(out.AuthorizationData) != 1 {
-		return nil, fmt.Errorf("unexpected number of authorization tokens. expected 1, found %d", len(out.AuthorizationData))
+		return authToken{}, fmt.Errorf("unexpected number of authorization tokens. expected 1, found %d", len(out.AuthorizationData))
 	}
 
 	// AuthorizationToken is base64 encoded {username}:{password} string
 	decodedToken, err := base64.StdEncoding.DecodeString(*out.AuthorizationData[0].AuthorizationToken)
 	if err != nil {
-		return nil, err
+		return authToken{}, err
 	}
 	parts := strings.Split(string(decodedToken), ":")
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("unexpected token format")
+		return authToken{}, fmt.Errorf("unexpected token format")
 	}
-
-	exp := out.AuthorizationData[0].ExpiresAt.UTC().Unix()
-	return map[string][]byte{
-		"username":       []byte(parts[0]),
-		"password":       []byte(parts[1]),
-		"proxy_endpoint": []byte(*out.AuthorizationData[0].ProxyEndpoint),
-		"expires_at":     []byte(strconv.FormatInt(exp, 10)),
+	proxyEndpoint := *out.AuthorizationData[0].ProxyEndpoint
+	return authToken{
+		username:      parts[0],
+		password:      parts[1],
+		auth:          *out.AuthorizationData[0].AuthorizationToken,
+		proxyEndpoint: proxyEndpoint,
+		registry:      registryHost(proxyEndpoint),
+		expiresAt:     out.AuthorizationData[0].ExpiresAt.UTC(),
 	}, nil
 }
 
-type ecrFactoryFunc func(aws *session.Session) ecriface.ECRAPI
+// generatorRegions returns region followed by the additional regions, without duplicates.
+func generatorRegions(spec *genv1alpha1.ECRAuthorizationTokenSpec) []string {
+	regions := []string{spec.Region}
+	for _, region := range spec.Regions {
+		if !slices.Contains(regions, region) {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+// endpointConfig returns the client config of the region with the requested endpoint variants.
+func endpointConfig(spec *genv1alpha1.ECRAuthorizationTokenSpec, region string) *aws.Config {
+	cfg := aws.NewConfig().WithRegion(region)
+	if spec.UseFIPSEndpoint {
+		cfg.UseFIPSEndpoint = endpoints.FIPSEndpointStateEnabled
+	}
+	if spec.UseDualStackEndpoint {
+		cfg.UseDualStackEndpoint = endpoints.DualStackEndpointStateEnabled
+	}
+	return cfg
+}
+
+// registryHost returns the registry host of the proxy endpoint, e.g.
+// 123456789012.dkr.ecr.eu-west-1.amazonaws.com for https://123456789012.dkr.ecr.eu-west-1.amazonaws.com.
+func registryHost(proxyEndpoint string) string {
+	u, err := url.Parse(proxyEndpoint)
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(proxyEndpoint, "/")
+	}
+	return u.Host
+}
+
+type ecrFactoryFunc func(sess *session.Session, cfg *aws.Config) ecriface.ECRAPI
 
-func ecrFactory(aws *session.Session) ecriface.ECRAPI {
-	return ecr.New(aws)
+func ecrFactory(sess *session.Session, cfg *aws.Config) ecriface.ECRAPI {
+	return ecr.New(sess, cfg)
 }
 
 func parseSpec(data []byte) (*genv1alpha1.ECRAuthorizationToken, error) {
@@ -22,6 +22,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
@@ -87,7 +89,7 @@ func TestGenerate(t *testing.T) {
 						AuthorizationData: []*ecr.AuthorizationData{
 							{
 								AuthorizationToken: utilpointer.To(base64.StdEncoding.EncodeToString([]byte("uuser:pass"))),
-								ProxyEndpoint:      utilpointer.To("foo"),
+								ProxyEndpoint:      utilpointer.To("https://123456789012.dkr.ecr.eu-west-1.amazonaws.com"),
 								ExpiresAt:          &t,
 							},
 						},
@@ -110,10 +112,13 @@ spec:
 				},
 			},
 			want: map[string][]byte{
-				"username":       []byte("uuser"),
-				"password":       []byte("pass"),
-				"proxy_endpoint": []byte("foo"),
-				"expires_at":     []byte("1234"),
+				"username":         []byte("uuser"),
+				"password":         []byte("pass"),
+				"proxy_endpoint":   []byte("https://123456789012.dkr.ecr.eu-west-1.amazonaws.com"),
+				"expires_at":       []byte("1234"),
+				"registry":         []byte("123456789012.dkr.ecr.eu-west-1.amazonaws.com"),
+				"dockerconfigjson": []byte(`{"auths":{"123456789012.dkr.ecr.eu-west-1.amazonaws.com":{"username":"uuser","password":"pass","auth":"dXVzZXI6cGFzcw==","expiresAt":"1970-01-01T00:20:34Z"}}}`),
+				"cred_helpers":     []byte(`{"credHelpers":{"123456789012.dkr.ecr.eu-west-1.amazonaws.com":"ecr-login"}}`),
 			},
 		},
 	}
@@ -125,7 +130,7 @@ spec:
 				tt.args.jsonSpec,
 				tt.args.kube,
 				tt.args.namespace,
-				func(aws *session.Session) ecriface.ECRAPI {
+				func(sess *session.Session, cfg *aws.Config) ecriface.ECRAPI {
 					return &FakeECR{
 						authTokenFunc: tt.args.authTokenFunc,
 					}
@@ -142,6 +147,70 @@ spec:
 	}
 }
 
+func TestGenerateRegions(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-aws-creds", Namespace: "foobar"},
+		Data: map[string][]byte{
+			"key-id":        []byte("foo"),
+			"access-secret": []byte("bar"),
+		},
+	}).Build()
+	jsonSpec := &apiextensions.JSON{Raw: []byte(`apiVersion: generators.external-secrets.io/v1alpha1
+kind: ECRAuthorizationToken
+spec:
+  region: eu-west-1
+  regions: [us-east-1, eu-west-1]
+  useFIPSEndpoint: true
+  useDualStackEndpoint: true
+  auth:
+    secretRef:
+      accessKeyIDSecretRef:
+        name: "my-aws-creds"
+        key: "key-id"
+      secretAccessKeySecretRef:
+        name: "my-aws-creds"
+        key: "access-secret"`)}
+	expiry := map[string]int64{"eu-west-1": 2000, "us-east-1": 1000}
+	var regions []string
+	g := &Generator{}
+	got, err := g.generate(context.Background(), jsonSpec, kube, "foobar", func(sess *session.Session, cfg *aws.Config) ecriface.ECRAPI {
+		region := aws.StringValue(cfg.Region)
+		regions = append(regions, region)
+		if cfg.UseFIPSEndpoint != endpoints.FIPSEndpointStateEnabled || cfg.UseDualStackEndpoint != endpoints.DualStackEndpointStateEnabled {
+			t.Errorf("unexpected endpoint config of region %s: %+v", region, cfg)
+		}
+		return &FakeECR{
+			authTokenFunc: func(in *ecr.GetAuthorizationTokenInput) (*ecr.GetAuthorizationTokenOutput, error) {
+				return &ecr.GetAuthorizationTokenOutput{
+					AuthorizationData: []*ecr.AuthorizationData{{
+						AuthorizationToken: utilpointer.To(base64.StdEncoding.EncodeToString([]byte("AWS:" + region))),
+						ProxyEndpoint:      utilpointer.To("https://123456789012.dkr.ecr." + region + ".amazonaws.com"),
+						ExpiresAt:          utilpointer.To(time.Unix(expiry[region], 0)),
+					}},
+				}, nil
+			},
+		}
+	})
+	if err != nil {
+		t.Fatalf("Generator.Generate() error = %v", err)
+	}
+	if !reflect.DeepEqual(regions, []string{"eu-west-1", "us-east-1"}) {
+		t.Errorf("Generator.Generate() regions = %v", regions)
+	}
+	want := map[string]string{
+		"password":         "eu-west-1",
+		"registry":         "123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+		"expires_at":       "1000",
+		"dockerconfigjson": `{"auths":{"123456789012.dkr.ecr.eu-west-1.amazonaws.com":{"username":"AWS","password":"eu-west-1","auth":"QVdTOmV1LXdlc3QtMQ==","expiresAt":"1970-01-01T00:33:20Z"},"123456789012.dkr.ecr.us-east-1.amazonaws.com":{"username":"AWS","password":"us-east-1","auth":"QVdTOnVzLWVhc3QtMQ==","expiresAt":"1970-01-01T00:16:40Z"}}}`,
+		"regions":          `{"eu-west-1":{"username":"AWS","password":"eu-west-1","proxy_endpoint":"https://123456789012.dkr.ecr.eu-west-1.amazonaws.com","registry":"123456789012.dkr.ecr.eu-west-1.amazonaws.com","expires_at":"2000"},"us-east-1":{"username":"AWS","password":"us-east-1","proxy_endpoint":"https://123456789012.dkr.ecr.us-east-1.amazonaws.com","registry":"123456789012.dkr.ecr.us-east-1.amazonaws.com","expires_at":"1000"}}`,
+	}
+	for key, val := range want {
+		if string(got[key]) != val {
+			t.Errorf("Generator.Generate() %s = %s, want %s", key, got[key], val)
+		}
+	}
+}
+
 type FakeECR struct {
 	ecriface.ECRAPI
 	authTokenFunc func(*ecr.GetAuthorizationTokenInput) (*ecr.GetAuthorizationTokenOutput, error)
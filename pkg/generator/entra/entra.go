@@ -0,0 +1,382 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/go-autorest/autorest/azure"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault"
+)
+
+// Generator adds client secrets to Microsoft Entra ID app registrations.
+type Generator struct {
+	httpClient *http.Client
+	now        func() time.Time
+	// graphURL overrides the Microsoft Graph endpoint of the environment.
+	graphURL string
+	// newCredential returns the credential used to authenticate with Microsoft Graph.
+	newCredential func(ctx context.Context, spec *genv1alpha1.EntraClientSecretSpec, kube client.Client, namespace string) (azcore.TokenCredential, error)
+}
+
+const (
+	defaultDisplayName = "external-secrets"
+	defaultValidity    = 90 * 24 * time.Hour
+	// renewFraction of the validity that is left when the client secret is renewed by default.
+	renewFraction = 3
+
+	errNoSpec            = "no config spec provided"
+	errParseSpec         = "unable to parse spec: %w"
+	errNoApplicationID   = "no applicationId in spec"
+	errNoAuth            = "no auth method in spec, expected servicePrincipal, managedIdentity or workloadIdentity"
+	errGraphNotAvailable = "microsoft graph is not available in environment %s"
+	errGetSecret         = "unable to get secret %s: %w"
+	errMissingKey        = "key %s does not exist in secret %s"
+	errGetServiceAccount = "unable to get service account %s: %w"
+	errMissingAnnotation = "service account %s is missing annotation %s"
+	errLocalClientInit   = "unable to create local client: %w"
+	errGetToken          = "unable to get microsoft graph access token: %w"
+	errAddPassword       = "unable to add client secret to application %s: %w"
+	errRemovePassword    = "unable to remove client secret %s of application %s: %w"
+	errRequest           = "error performing request: %w"
+	errDecode            = "error decoding response: %w"
+	errGraph             = "unexpected status code %d: %s"
+	errNoKeyID           = "no key_id in generated data"
+	errNoExpiry          = "no expires_at in generated data"
+	errParseExpiry       = "unable to parse expires_at: %w"
+	errInvalidRenewal    = "renewBefore %s exceeds the validity %s"
+
+	contextTimeout    = 30 * time.Second
+	httpClientTimeout = 10 * time.Second
+)
+
+// passwordCredential is the passwordCredential resource of Microsoft Graph.
+type passwordCredential struct {
+	DisplayName string     `json:"displayName,omitempty"`
+	EndDateTime *time.Time `json:"endDateTime,omitempty"`
+	KeyID       string     `json:"keyId,omitempty"`
+	SecretText  string     `json:"secretText,omitempty"`
+}
+
+type addPasswordRequest struct {
+	PasswordCredential passwordCredential `json:"passwordCredential"`
+}
+
+type removePasswordRequest struct {
+	KeyID string `json:"keyId"`
+}
+
+type graphError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace)
+}
+
+// generate adds a new client secret to the app registration with the addPassword action of Microsoft Graph.
+// The existing client secrets of the app registration are kept, they are removed by Cleanup.
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	res, err := parseSpec(jsonSpec)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	now := time.Now
+	if g.now != nil {
+		now = g.now
+	}
+	displayName := res.Spec.DisplayName
+	if displayName == "" {
+		displayName = defaultDisplayName
+	}
+	endDateTime := now().Add(validity(&res.Spec)).UTC()
+	var created passwordCredential
+	err = g.graphRequest(ctx, kube, namespace, &res.Spec, "addPassword", addPasswordRequest{
+		PasswordCredential: passwordCredential{
+			DisplayName: displayName,
+			EndDateTime: &endDateTime,
+		},
+	}, &created)
+	if err != nil {
+		return nil, fmt.Errorf(errAddPassword, res.Spec.ApplicationID, err)
+	}
+	if created.EndDateTime != nil {
+		endDateTime = *created.EndDateTime
+	}
+	return map[string][]byte{
+		"client_id":     []byte(res.Spec.ApplicationID),
+		"client_secret": []byte(created.SecretText),
+		"key_id":        []byte(created.KeyID),
+		"expires_at":    []byte(strconv.FormatInt(endDateTime.Unix(), 10)),
+	}, nil
+}
+
+// Cleanup removes the client secret of the generated data from the app registration.
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, data map[string][]byte, kube client.Client, namespace string) error {
+	res, err := parseSpec(jsonSpec)
+	if err != nil {
+		return err
+	}
+	keyID, ok := data["key_id"]
+	if !ok {
+		return errors.New(errNoKeyID)
+	}
+	ctx, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	err = g.graphRequest(ctx, kube, namespace, &res.Spec, "removePassword", removePasswordRequest{KeyID: string(keyID)}, nil)
+	if err != nil {
+		return fmt.Errorf(errRemovePassword, keyID, res.Spec.ApplicationID, err)
+	}
+	return nil
+}
+
+// RotationOnly marks the generator to be used by SecretRotations only:
+// every generation adds a client secret that is only removed by Cleanup.
+func (g *Generator) RotationOnly() {}
+
+// RenewalTime returns the time the client secret is renewed:
+// renewBefore ahead of its expiry, by default when a third of its validity is left.
+func (g *Generator) RenewalTime(jsonSpec *apiextensions.JSON, data map[string][]byte) (time.Time, error) {
+	res, err := parseSpec(jsonSpec)
+	if err != nil {
+		return time.Time{}, err
+	}
+	expiresAt, ok := data["expires_at"]
+	if !ok {
+		return time.Time{}, errors.New(errNoExpiry)
+	}
+	unix, err := strconv.ParseInt(string(expiresAt), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(errParseExpiry, err)
+	}
+	lifetime := validity(&res.Spec)
+	renewBefore := lifetime / renewFraction
+	if res.Spec.RenewBefore != nil {
+		renewBefore = res.Spec.RenewBefore.Duration
+		if renewBefore >= lifetime {
+			return time.Time{}, fmt.Errorf(errInvalidRenewal, renewBefore, lifetime)
+		}
+	}
+	return time.Unix(unix, 0).Add(-renewBefore), nil
+}
+
+// graphRequest performs an action on the application of the spec and decodes the response into out.
+func (g *Generator) graphRequest(ctx context.Context, kube client.Client, namespace string, spec *genv1alpha1.EntraClientSecretSpec, action string, body, out any) error {
+	base := g.graphURL
+	if base == "" {
+		var err error
+		base, err = graphEndpointForType(spec.EnvironmentType)
+		if err != nil {
+			return err
+		}
+	}
+	base = strings.TrimSuffix(base, "/")
+	newCredential := g.newCredential
+	if newCredential == nil {
+		newCredential = tokenCredential
+	}
+	cred, err := newCredential(ctx, spec, kube, namespace)
+	if err != nil {
+		return err
+	}
+	tkn, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{base + "/.default"}})
+	if err != nil {
+		return fmt.Errorf(errGetToken, err)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	// the application is addressed by its application (client) ID instead of its object ID
+	endpoint := fmt.Sprintf("%s/v1.0/applications(appId='%s')/%s", base, url.PathEscape(spec.ApplicationID), action)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+tkn.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	hc := g.httpClient
+	if hc == nil {
+		hc = &http.Client{
+			Timeout: httpClientTimeout,
+		}
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var gErr graphError
+		if err := json.NewDecoder(resp.Body).Decode(&gErr); err != nil || gErr.Error.Message == "" {
+			return fmt.Errorf(errGraph, resp.StatusCode, http.StatusText(resp.StatusCode))
+		}
+		return fmt.Errorf(errGraph, resp.StatusCode, gErr.Error.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf(errDecode, err)
+	}
+	return nil
+}
+
+// tokenCredential returns the credential of the auth method of the spec.
+func tokenCredential(ctx context.Context, spec *genv1alpha1.EntraClientSecretSpec, kube client.Client, namespace string) (azcore.TokenCredential, error) {
+	opts := azcore.ClientOptions{
+		Cloud: cloud.Configuration{ActiveDirectoryAuthorityHost: keyvault.AadEndpointForType(spec.EnvironmentType)},
+	}
+	switch {
+	case spec.Auth.ServicePrincipal != nil:
+		ref := spec.Auth.ServicePrincipal.SecretRef
+		clientID, err := secretValue(ctx, kube, namespace, ref.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		clientSecret, err := secretValue(ctx, kube, namespace, ref.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+		return azidentity.NewClientSecretCredential(spec.TenantID, clientID, clientSecret, &azidentity.ClientSecretCredentialOptions{ClientOptions: opts})
+	case spec.Auth.ManagedIdentity != nil:
+		miOpts := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: opts}
+		if id := spec.Auth.ManagedIdentity.IdentityID; id != "" {
+			miOpts.ID = azidentity.ResourceID(id)
+		}
+		return azidentity.NewManagedIdentityCredential(miOpts)
+	case spec.Auth.WorkloadIdentity != nil:
+		return workloadIdentityCredential(ctx, spec.Auth.WorkloadIdentity.ServiceAccountRef, kube, namespace, opts)
+	default:
+		return nil, errors.New(errNoAuth)
+	}
+}
+
+// workloadIdentityCredential exchanges a token of the referenced service account for an Entra ID access token.
+// Without a service account reference the environment variables set by the azure workload identity webhook are used.
+func workloadIdentityCredential(ctx context.Context, ref *smmeta.ServiceAccountSelector, kube client.Client, namespace string, opts azcore.ClientOptions) (azcore.TokenCredential, error) {
+	if ref == nil {
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{ClientOptions: opts})
+	}
+	var sa corev1.ServiceAccount
+	if err := kube.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, &sa); err != nil {
+		return nil, fmt.Errorf(errGetServiceAccount, ref.Name, err)
+	}
+	clientID, ok := sa.Annotations[keyvault.AnnotationClientID]
+	if !ok {
+		return nil, fmt.Errorf(errMissingAnnotation, ref.Name, keyvault.AnnotationClientID)
+	}
+	tenantID, ok := sa.Annotations[keyvault.AnnotationTenantID]
+	if !ok {
+		return nil, fmt.Errorf(errMissingAnnotation, ref.Name, keyvault.AnnotationTenantID)
+	}
+	// controller-runtime/client does not support TokenRequest,
+	// the local client is needed to request the service account token
+	restCfg, err := ctrlcfg.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf(errLocalClientInit, err)
+	}
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf(errLocalClientInit, err)
+	}
+	audiences := append([]string{keyvault.AzureDefaultAudience}, ref.Audiences...)
+	getAssertion := func(ctx context.Context) (string, error) {
+		return keyvault.FetchSAToken(ctx, namespace, ref.Name, audiences, clientset.CoreV1())
+	}
+	return azidentity.NewClientAssertionCredential(tenantID, clientID, getAssertion, &azidentity.ClientAssertionCredentialOptions{ClientOptions: opts})
+}
+
+func graphEndpointForType(t v1beta1.AzureEnvironmentType) (string, error) {
+	switch t {
+	case v1beta1.AzureEnvironmentChinaCloud:
+		return azure.ChinaCloud.MicrosoftGraphEndpoint, nil
+	case v1beta1.AzureEnvironmentUSGovernmentCloud:
+		return azure.USGovernmentCloud.MicrosoftGraphEndpoint, nil
+	case v1beta1.AzureEnvironmentGermanCloud:
+		return "", fmt.Errorf(errGraphNotAvailable, t)
+	default:
+		return azure.PublicCloud.MicrosoftGraphEndpoint, nil
+	}
+}
+
+func validity(spec *genv1alpha1.EntraClientSecretSpec) time.Duration {
+	if spec.Validity != nil {
+		return spec.Validity.Duration
+	}
+	return defaultValidity
+}
+
+func secretValue(ctx context.Context, kube client.Client, namespace string, ref smmeta.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf(errGetSecret, ref.Name, err)
+	}
+	val, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf(errMissingKey, ref.Key, ref.Name)
+	}
+	return string(val), nil
+}
+
+func parseSpec(jsonSpec *apiextensions.JSON) (*genv1alpha1.EntraClientSecret, error) {
+	if jsonSpec == nil {
+		return nil, errors.New(errNoSpec)
+	}
+	var res genv1alpha1.EntraClientSecret
+	if err := yaml.Unmarshal(jsonSpec.Raw, &res); err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.ApplicationID == "" {
+		return nil, errors.New(errNoApplicationID)
+	}
+	return &res, nil
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.EntraClientSecretKind, &Generator{})
+}
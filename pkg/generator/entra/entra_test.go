@@ -0,0 +1,195 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+const appID = "00000000-0000-0000-0000-000000000001"
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(_ context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token:" + opts.Scopes[0]}, nil
+}
+
+func testGenerator(srv *httptest.Server, now time.Time) *Generator {
+	return &Generator{
+		httpClient: srv.Client(),
+		now:        func() time.Time { return now },
+		graphURL:   srv.URL,
+		newCredential: func(context.Context, *genv1alpha1.EntraClientSecretSpec, client.Client, string) (azcore.TokenCredential, error) {
+			return fakeCredential{}, nil
+		},
+	}
+}
+
+func testSpec(fields string) *apiextensions.JSON {
+	return &apiextensions.JSON{Raw: []byte(fmt.Sprintf(`{"spec":{"applicationId":%q,"auth":{"managedIdentity":{}}%s}}`, appID, fields))}
+}
+
+func TestGenerate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name            string
+		spec            *apiextensions.JSON
+		status          int
+		body            string
+		wantDisplayName string
+		wantEnd         time.Time
+		want            map[string][]byte
+		wantErr         string
+	}{
+		{
+			name:            "adds client secret with defaults",
+			spec:            testSpec(""),
+			status:          http.StatusOK,
+			body:            `{"keyId":"key-1","secretText":"s3cr3t","endDateTime":"2024-07-30T10:00:00Z"}`,
+			wantDisplayName: defaultDisplayName,
+			wantEnd:         now.Add(defaultValidity),
+			want: map[string][]byte{
+				"client_id":     []byte(appID),
+				"client_secret": []byte("s3cr3t"),
+				"key_id":        []byte("key-1"),
+				"expires_at":    []byte("1722333600"),
+			},
+		},
+		{
+			name:            "adds client secret with display name and validity",
+			spec:            testSpec(`,"displayName":"team-a","validity":"24h"`),
+			status:          http.StatusOK,
+			body:            `{"keyId":"key-1","secretText":"s3cr3t"}`,
+			wantDisplayName: "team-a",
+			wantEnd:         now.Add(24 * time.Hour),
+			want: map[string][]byte{
+				"client_id":     []byte(appID),
+				"client_secret": []byte("s3cr3t"),
+				"key_id":        []byte("key-1"),
+				"expires_at":    []byte("1714644000"),
+			},
+		},
+		{
+			name:            "graph error",
+			spec:            testSpec(""),
+			status:          http.StatusForbidden,
+			body:            `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`,
+			wantDisplayName: defaultDisplayName,
+			wantEnd:         now.Add(defaultValidity),
+			wantErr:         "unable to add client secret to application " + appID + ": unexpected status code 403: Insufficient privileges to complete the operation.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "/v1.0/applications(appId='"+appID+"')/addPassword", req.URL.Path)
+				assert.Equal(t, "Bearer token:http://"+req.Host+"/.default", req.Header.Get("Authorization"))
+				var body addPasswordRequest
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				assert.Equal(t, tt.wantDisplayName, body.PasswordCredential.DisplayName)
+				require.NotNil(t, body.PasswordCredential.EndDateTime)
+				assert.True(t, tt.wantEnd.Equal(*body.PasswordCredential.EndDateTime))
+				rw.WriteHeader(tt.status)
+				rw.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			got, err := testGenerator(srv, now).generate(context.Background(), tt.spec, clientfake.NewClientBuilder().Build(), "default")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := (&Generator{}).generate(context.Background(), nil, clientfake.NewClientBuilder().Build(), "default")
+	assert.EqualError(t, err, errNoSpec)
+	_, err = (&Generator{}).generate(context.Background(), &apiextensions.JSON{Raw: []byte(`{"spec":{}}`)}, clientfake.NewClientBuilder().Build(), "default")
+	assert.EqualError(t, err, errNoApplicationID)
+}
+
+func TestCleanup(t *testing.T) {
+	var removed string
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1.0/applications(appId='"+appID+"')/removePassword", req.URL.Path)
+		var body removePasswordRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		removed = body.KeyID
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	g := testGenerator(srv, time.Now())
+
+	err := g.Cleanup(context.Background(), testSpec(""), map[string][]byte{"key_id": []byte("key-1")}, clientfake.NewClientBuilder().Build(), "default")
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", removed)
+
+	err = g.Cleanup(context.Background(), testSpec(""), map[string][]byte{}, clientfake.NewClientBuilder().Build(), "default")
+	assert.EqualError(t, err, errNoKeyID)
+}
+
+func TestRotationOnly(t *testing.T) {
+	// client secrets are only removed by Cleanup, ExternalSecrets would add one on every refresh
+	var gen genv1alpha1.Generator = &Generator{}
+	_, ok := gen.(genv1alpha1.RotationOnly)
+	assert.True(t, ok)
+}
+
+func TestRenewalTime(t *testing.T) {
+	expiresAt := time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+	data := map[string][]byte{"expires_at": []byte("1722333600")}
+	tests := []struct {
+		name    string
+		spec    *apiextensions.JSON
+		data    map[string][]byte
+		want    time.Time
+		wantErr string
+	}{
+		{name: "third of the default validity", spec: testSpec(""), data: data, want: expiresAt.Add(-30 * 24 * time.Hour)},
+		{name: "third of the validity", spec: testSpec(`,"validity":"24h"`), data: data, want: expiresAt.Add(-8 * time.Hour)},
+		{name: "renewBefore", spec: testSpec(`,"renewBefore":"168h"`), data: data, want: expiresAt.Add(-7 * 24 * time.Hour)},
+		{name: "renewBefore exceeds validity", spec: testSpec(`,"validity":"24h","renewBefore":"48h"`), data: data, wantErr: "renewBefore 48h0m0s exceeds the validity 24h0m0s"},
+		{name: "no expiry", spec: testSpec(""), data: map[string][]byte{}, wantErr: errNoExpiry},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&Generator{}).RenewalTime(tt.spec, tt.data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
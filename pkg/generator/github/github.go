@@ -29,6 +29,8 @@ import (
 	"sigs.k8s.io/yaml"
 
 	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
 )
 
 type Generator struct {
@@ -38,6 +40,7 @@ type Generator struct {
 type Github struct {
 	HTTP       *http.Client
 	Kube       client.Client
+	Name       string
 	Namespace  string
 	URL        string
 	InstallTkn string
@@ -92,6 +95,7 @@ func (g *Generator) generate(
 		return nil, fmt.Errorf("error performing request: %w", err)
 	}
 	defer resp.Body.Close()
+	metrics.NewQuotaObserver(constants.GeneratorGithub, genv1alpha1.GithubAccessTokenKind, gh.Namespace, gh.Name).ObserveResponse(resp)
 
 	// git access token
 	var gat map[string]any
@@ -119,7 +123,7 @@ func newGHClient(ctx context.Context, k client.Client, n string, hc *http.Client
 	if err != nil {
 		return nil, fmt.Errorf(errParseSpec, err)
 	}
-	gh := &Github{Kube: k, Namespace: n, HTTP: hc}
+	gh := &Github{Kube: k, Name: res.Name, Namespace: n, HTTP: hc}
 
 	ghPath := fmt.Sprintf("/app/installations/%s/access_tokens", res.Spec.InstallID)
 	gh.URL = defaultGithubAPI + ghPath
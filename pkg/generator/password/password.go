@@ -17,6 +17,7 @@ package password
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/sethvargo/go-password/password"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -37,6 +38,7 @@ const (
 	errNoSpec    = "no config spec provided"
 	errParseSpec = "unable to parse spec: %w"
 	errGetToken  = "unable to get authorization token: %w"
+	errPolicy    = "password does not satisfy ClusterPasswordPolicy %s: %w"
 )
 
 type generateFunc func(
@@ -46,16 +48,26 @@ type generateFunc func(
 	digits int,
 	noUpper bool,
 	allowRepeat bool,
+	excludedCharacters string,
 ) (string, error)
 
-func (g *Generator) Generate(_ context.Context, jsonSpec *apiextensions.JSON, _ client.Client, _ string) (map[string][]byte, error) {
+// resolvePolicyFunc returns the ClusterPasswordPolicy that applies to the spec, or nil.
+type resolvePolicyFunc func(spec *genv1alpha1.PasswordSpec) (*genv1alpha1.ClusterPasswordPolicy, error)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, _ string) (map[string][]byte, error) {
 	return g.generate(
 		jsonSpec,
+		func(spec *genv1alpha1.PasswordSpec) (*genv1alpha1.ClusterPasswordPolicy, error) {
+			if kube == nil {
+				return nil, nil
+			}
+			return genv1alpha1.ResolvePasswordPolicy(ctx, kube, spec)
+		},
 		generateSafePassword,
 	)
 }
 
-func (g *Generator) generate(jsonSpec *apiextensions.JSON, passGen generateFunc) (map[string][]byte, error) {
+func (g *Generator) generate(jsonSpec *apiextensions.JSON, resolvePolicy resolvePolicyFunc, passGen generateFunc) (map[string][]byte, error) {
 	if jsonSpec == nil {
 		return nil, fmt.Errorf(errNoSpec)
 	}
@@ -63,7 +75,24 @@ func (g *Generator) generate(jsonSpec *apiextensions.JSON, passGen generateFunc)
 	if err != nil {
 		return nil, fmt.Errorf(errParseSpec, err)
 	}
+	policy, err := resolvePolicy(&res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	var minDigits, minSymbols int
+	var excludedCharacters string
 	symbolCharacters := defaultSymbolChars
+	if policy != nil {
+		if err := policy.Spec.Check(&res.Spec); err != nil {
+			return nil, fmt.Errorf(errPolicy, policy.Name, err)
+		}
+		minDigits = policy.Spec.Digits
+		minSymbols = policy.Spec.Symbols
+		excludedCharacters = policy.Spec.ExcludedCharacters
+		if policy.Spec.SymbolCharacters != nil {
+			symbolCharacters = *policy.Spec.SymbolCharacters
+		}
+	}
 	if res.Spec.SymbolCharacters != nil {
 		symbolCharacters = *res.Spec.SymbolCharacters
 	}
@@ -71,11 +100,11 @@ func (g *Generator) generate(jsonSpec *apiextensions.JSON, passGen generateFunc)
 	if res.Spec.Length > 0 {
 		passLen = res.Spec.Length
 	}
-	digits := int(float32(passLen) * digitFactor)
+	digits := max(int(float32(passLen)*digitFactor), minDigits)
 	if res.Spec.Digits != nil {
 		digits = *res.Spec.Digits
 	}
-	symbols := int(float32(passLen) * symbolFactor)
+	symbols := max(int(float32(passLen)*symbolFactor), minSymbols)
 	if res.Spec.Symbols != nil {
 		symbols = *res.Spec.Symbols
 	}
@@ -86,6 +115,7 @@ func (g *Generator) generate(jsonSpec *apiextensions.JSON, passGen generateFunc)
 		digits,
 		res.Spec.NoUpper,
 		res.Spec.AllowRepeat,
+		excludedCharacters,
 	)
 	if err != nil {
 		return nil, err
@@ -102,9 +132,13 @@ func generateSafePassword(
 	digits int,
 	noUpper bool,
 	allowRepeat bool,
+	excludedCharacters string,
 ) (string, error) {
 	gen, err := password.NewGenerator(&password.GeneratorInput{
-		Symbols: symbolCharacters,
+		LowerLetters: withoutCharacters(password.LowerLetters, excludedCharacters),
+		UpperLetters: withoutCharacters(password.UpperLetters, excludedCharacters),
+		Digits:       withoutCharacters(password.Digits, excludedCharacters),
+		Symbols:      withoutCharacters(symbolCharacters, excludedCharacters),
 	})
 	if err != nil {
 		return "", err
@@ -118,6 +152,19 @@ func generateSafePassword(
 	)
 }
 
+// withoutCharacters removes the excluded characters from the character set.
+func withoutCharacters(set, excluded string) string {
+	if excluded == "" {
+		return set
+	}
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(excluded, r) {
+			return -1
+		}
+		return r
+	}, set)
+}
+
 func parseSpec(data []byte) (*genv1alpha1.Password, error) {
 	var spec genv1alpha1.Password
 	err := yaml.Unmarshal(data, &spec)
@@ -21,8 +21,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
 )
 
+func noPolicy(_ *genv1alpha1.PasswordSpec) (*genv1alpha1.ClusterPasswordPolicy, error) {
+	return nil, nil
+}
+
 func TestGenerate(t *testing.T) {
 	type args struct {
 		jsonSpec *apiextensions.JSON
@@ -57,7 +64,7 @@ func TestGenerate(t *testing.T) {
 				jsonSpec: &apiextensions.JSON{
 					Raw: []byte(`{}`),
 				},
-				passGen: func(len int, symbols int, symbolCharacters string, digits int, noUpper bool, allowRepeat bool,
+				passGen: func(len int, symbols int, symbolCharacters string, digits int, noUpper bool, allowRepeat bool, _ string,
 				) (string, error) {
 					assert.Equal(t, defaultLength, len)
 					assert.Equal(t, defaultSymbolChars, symbolCharacters)
@@ -79,7 +86,7 @@ func TestGenerate(t *testing.T) {
 				jsonSpec: &apiextensions.JSON{
 					Raw: []byte(`{"spec":{"length":48,"digits":2, "symbols":2, "symbolCharacters":"-_.", "noUpper": true, "allowRepeat": true}}`),
 				},
-				passGen: func(len int, symbols int, symbolCharacters string, digits int, noUpper bool, allowRepeat bool,
+				passGen: func(len int, symbols int, symbolCharacters string, digits int, noUpper bool, allowRepeat bool, _ string,
 				) (string, error) {
 					assert.Equal(t, 48, len)
 					assert.Equal(t, "-_.", symbolCharacters)
@@ -101,7 +108,7 @@ func TestGenerate(t *testing.T) {
 				jsonSpec: &apiextensions.JSON{
 					Raw: []byte(`{}`),
 				},
-				passGen: func(len int, symbols int, symbolCharacters string, digits int, noUpper bool, allowRepeat bool,
+				passGen: func(len int, symbols int, symbolCharacters string, digits int, noUpper bool, allowRepeat bool, _ string,
 				) (string, error) {
 					return "", fmt.Errorf("boom")
 				},
@@ -112,7 +119,7 @@ func TestGenerate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := &Generator{}
-			got, err := g.generate(tt.args.jsonSpec, tt.args.passGen)
+			got, err := g.generate(tt.args.jsonSpec, noPolicy, tt.args.passGen)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Generator.Generate() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -123,3 +130,49 @@ func TestGenerate(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateWithPolicy(t *testing.T) {
+	symbolCharacters := "-_"
+	policy := &genv1alpha1.ClusterPasswordPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "strong"},
+		Spec: genv1alpha1.ClusterPasswordPolicySpec{
+			Length:             32,
+			Digits:             10,
+			Symbols:            4,
+			SymbolCharacters:   &symbolCharacters,
+			ExcludedCharacters: "lI1O0",
+		},
+	}
+	withPolicy := func(_ *genv1alpha1.PasswordSpec) (*genv1alpha1.ClusterPasswordPolicy, error) {
+		return policy, nil
+	}
+	g := &Generator{}
+
+	_, err := g.generate(&apiextensions.JSON{Raw: []byte(`{"spec":{"length":24}}`)}, withPolicy, generateSafePassword)
+	assert.ErrorContains(t, err, "length 24 is shorter than the minimum length 32")
+
+	got, err := g.generate(&apiextensions.JSON{Raw: []byte(`{"spec":{"length":40}}`)}, withPolicy,
+		func(len int, symbols int, symbolCharacters string, digits int, noUpper bool, allowRepeat bool, excludedCharacters string) (string, error) {
+			assert.Equal(t, 40, len)
+			assert.Equal(t, 10, symbols)
+			assert.Equal(t, "-_", symbolCharacters)
+			assert.Equal(t, 10, digits)
+			assert.Equal(t, "lI1O0", excludedCharacters)
+			return "foobar", nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"password": []byte("foobar")}, got)
+}
+
+func TestGenerateSafePasswordExcludedCharacters(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		pass, err := generateSafePassword(32, 2, "-_.+", 8, false, false, "lI1O0-")
+		assert.NoError(t, err)
+		assert.NotContains(t, pass, "l")
+		assert.NotContains(t, pass, "I")
+		assert.NotContains(t, pass, "1")
+		assert.NotContains(t, pass, "O")
+		assert.NotContains(t, pass, "0")
+		assert.NotContains(t, pass, "-")
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package random
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+type Generator struct{}
+
+const (
+	defaultLength = 32
+	valueKey      = "value"
+
+	// stateNameTemplate is the name of the secret holding the value of an OnSpecChange generator.
+	stateNameTemplate = "random-value-%s"
+	// annotationSpecHash records the hash of the spec the stored value was generated from.
+	annotationSpecHash = "generators.external-secrets.io/spec-hash"
+
+	errNoSpec        = "no config spec provided"
+	errParseSpec     = "unable to parse spec: %w"
+	errFormat        = "unsupported format %q"
+	errGenerate      = "unable to generate random value: %w"
+	errNoName        = "regenerate policy OnSpecChange requires the name of the generator"
+	errNoClient      = "regenerate policy OnSpecChange requires a kubernetes client"
+	errGetState      = "unable to get state secret %s: %w"
+	errWriteState    = "unable to write state secret %s: %w"
+	errUnknownPolicy = "unsupported regenerate policy %q"
+)
+
+// randomFunc returns a new random value for the spec.
+type randomFunc func(spec *genv1alpha1.RandomValueSpec) ([]byte, error)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace, randomValue)
+}
+
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string, newValue randomFunc) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+
+	switch res.Spec.Regenerate {
+	case "", genv1alpha1.RandomValueRegenerateAlways:
+		val, err := newValue(&res.Spec)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{valueKey: val}, nil
+	case genv1alpha1.RandomValueRegenerateOnSpecChange:
+		val, err := stableValue(ctx, kube, namespace, res, newValue)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{valueKey: val}, nil
+	default:
+		return nil, fmt.Errorf(errUnknownPolicy, res.Spec.Regenerate)
+	}
+}
+
+// stableValue returns the value stored for the current spec in the state secret
+// of the generator. A new value is generated and stored when the secret does not
+// exist yet or the value was generated from a different spec.
+func stableValue(ctx context.Context, kube client.Client, namespace string, res *genv1alpha1.RandomValue, newValue randomFunc) ([]byte, error) {
+	if res.Name == "" {
+		return nil, fmt.Errorf(errNoName)
+	}
+	if kube == nil {
+		return nil, fmt.Errorf(errNoClient)
+	}
+	name := fmt.Sprintf(stateNameTemplate, res.Name)
+	hash := utils.ObjectHash(res.Spec)
+
+	var state corev1.Secret
+	err := kube.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &state)
+	notFound := apierrors.IsNotFound(err)
+	if err != nil && !notFound {
+		return nil, fmt.Errorf(errGetState, name, err)
+	}
+	if val, ok := state.Data[valueKey]; ok && state.Annotations[annotationSpecHash] == hash {
+		return val, nil
+	}
+
+	val, err := newValue(&res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	state.Name = name
+	state.Namespace = namespace
+	if state.Annotations == nil {
+		state.Annotations = make(map[string]string)
+	}
+	state.Annotations[annotationSpecHash] = hash
+	state.Data = map[string][]byte{valueKey: val}
+	if res.UID != "" {
+		state.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(res, genv1alpha1.RandomValueGroupVersionKind),
+		}
+	}
+	if notFound {
+		err = kube.Create(ctx, &state)
+	} else {
+		err = kube.Update(ctx, &state)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errWriteState, name, err)
+	}
+	return val, nil
+}
+
+func randomValue(spec *genv1alpha1.RandomValueSpec) ([]byte, error) {
+	length := defaultLength
+	if spec.Length > 0 {
+		length = spec.Length
+	}
+	switch spec.Format {
+	case "", genv1alpha1.RandomValueFormatUUIDv4:
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return nil, fmt.Errorf(errGenerate, err)
+		}
+		return []byte(id.String()), nil
+	case genv1alpha1.RandomValueFormatUUIDv7:
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf(errGenerate, err)
+		}
+		return []byte(id.String()), nil
+	case genv1alpha1.RandomValueFormatHex:
+		buf, err := randomBytes(length)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(hex.EncodeToString(buf)), nil
+	case genv1alpha1.RandomValueFormatBytes:
+		return randomBytes(length)
+	default:
+		return nil, fmt.Errorf(errFormat, spec.Format)
+	}
+}
+
+func randomBytes(length int) ([]byte, error) {
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf(errGenerate, err)
+	}
+	return buf, nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.RandomValue, error) {
+	var spec genv1alpha1.RandomValue
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.RandomValueKind, &Generator{})
+}
@@ -0,0 +1,114 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package random
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func TestRandomValue(t *testing.T) {
+	v4, err := randomValue(&genv1alpha1.RandomValueSpec{})
+	require.NoError(t, err)
+	id, err := uuid.ParseBytes(v4)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), id.Version())
+
+	v7, err := randomValue(&genv1alpha1.RandomValueSpec{Format: genv1alpha1.RandomValueFormatUUIDv7})
+	require.NoError(t, err)
+	id, err = uuid.ParseBytes(v7)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(7), id.Version())
+
+	hexVal, err := randomValue(&genv1alpha1.RandomValueSpec{Format: genv1alpha1.RandomValueFormatHex, Length: 16})
+	require.NoError(t, err)
+	assert.Len(t, hexVal, 32)
+	_, err = hex.DecodeString(string(hexVal))
+	assert.NoError(t, err)
+
+	raw, err := randomValue(&genv1alpha1.RandomValueSpec{Format: genv1alpha1.RandomValueFormatBytes})
+	require.NoError(t, err)
+	assert.Len(t, raw, defaultLength)
+
+	_, err = randomValue(&genv1alpha1.RandomValueSpec{Format: "base64"})
+	assert.Error(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	counter := 0
+	next := func(_ *genv1alpha1.RandomValueSpec) ([]byte, error) {
+		counter++
+		return []byte{byte('0' + counter)}, nil
+	}
+	spec := func(raw string) *apiextensions.JSON {
+		return &apiextensions.JSON{Raw: []byte(raw)}
+	}
+	g := &Generator{}
+
+	_, err := g.generate(ctx, nil, nil, "default", next)
+	assert.Error(t, err)
+	_, err = g.generate(ctx, spec(`no json`), nil, "default", next)
+	assert.Error(t, err)
+
+	t.Run("always regenerates", func(t *testing.T) {
+		counter = 0
+		first, err := g.generate(ctx, spec(`{"spec":{}}`), nil, "default", next)
+		require.NoError(t, err)
+		second, err := g.generate(ctx, spec(`{"spec":{}}`), nil, "default", next)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("1"), first[valueKey])
+		assert.Equal(t, []byte("2"), second[valueKey])
+	})
+
+	t.Run("on spec change keeps value", func(t *testing.T) {
+		counter = 0
+		kube := clientfake.NewClientBuilder().Build()
+		v1 := spec(`{"metadata":{"name":"session","uid":"1234"},"spec":{"format":"Hex","regenerate":"OnSpecChange"}}`)
+		first, err := g.generate(ctx, v1, kube, "default", next)
+		require.NoError(t, err)
+		second, err := g.generate(ctx, v1, kube, "default", next)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("1"), first[valueKey])
+		assert.Equal(t, first, second)
+
+		var state corev1.Secret
+		require.NoError(t, kube.Get(ctx, client.ObjectKey{Namespace: "default", Name: "random-value-session"}, &state))
+		assert.Equal(t, []byte("1"), state.Data[valueKey])
+		require.Len(t, state.OwnerReferences, 1)
+		assert.Equal(t, genv1alpha1.RandomValueKind, state.OwnerReferences[0].Kind)
+
+		v2 := spec(`{"metadata":{"name":"session","uid":"1234"},"spec":{"format":"Hex","length":64,"regenerate":"OnSpecChange"}}`)
+		third, err := g.generate(ctx, v2, kube, "default", next)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("2"), third[valueKey])
+	})
+
+	t.Run("on spec change requires a name", func(t *testing.T) {
+		_, err := g.generate(ctx, spec(`{"spec":{"regenerate":"OnSpecChange"}}`), clientfake.NewClientBuilder().Build(), "default", next)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,163 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	provider "github.com/external-secrets/external-secrets/pkg/provider/kubernetes"
+)
+
+// Generator requests tokens of service accounts on remote clusters.
+type Generator struct {
+	// newClientset returns the client of the remote cluster.
+	newClientset func(*rest.Config) (kubernetes.Interface, error)
+}
+
+const (
+	defaultExpirationSeconds = int64(3600)
+	// renewFraction of the token lifetime that is left when it is renewed by default.
+	renewFraction = 3
+
+	errNoSpec          = "no config spec provided"
+	errParseSpec       = "unable to parse spec: %w"
+	errNoServiceAcct   = "no serviceAccount name and namespace in spec"
+	errRemoteConfig    = "unable to configure remote cluster: %w"
+	errCreateToken     = "unable to create token of service account %s/%s: %w"
+	errNoExpiry        = "no expires_at in generated data"
+	errParseExpiry     = "unable to parse expires_at: %w"
+	errInvalidRenewal  = "renewBefore %s exceeds the token lifetime %s"
+	errLocalClientInit = "unable to create local client: %w"
+)
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	// controller-runtime/client does not support TokenRequest or other subresource APIs,
+	// the local client is needed for auth.serviceAccount
+	restCfg, err := ctrlcfg.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf(errLocalClientInit, err)
+	}
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf(errLocalClientInit, err)
+	}
+	return g.generate(ctx, jsonSpec, kube, clientset.CoreV1(), namespace)
+}
+
+// generate requests a token of the service account on the remote cluster with the TokenRequest API.
+// The token is not bound to an object, it stays valid until it expires or the service account is deleted.
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace string) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	sa := res.Spec.ServiceAccount
+	if sa.Name == "" || sa.Namespace == "" {
+		return nil, fmt.Errorf(errNoServiceAcct)
+	}
+	p := &provider.Provider{}
+	cfg, err := p.NewGeneratorConfig(ctx, kube, corev1, res.Spec.Server, res.Spec.Auth, namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errRemoteConfig, err)
+	}
+	newClientset := g.newClientset
+	if newClientset == nil {
+		newClientset = func(cfg *rest.Config) (kubernetes.Interface, error) {
+			return kubernetes.NewForConfig(cfg)
+		}
+	}
+	remote, err := newClientset(cfg)
+	if err != nil {
+		return nil, fmt.Errorf(errRemoteConfig, err)
+	}
+
+	expirationSeconds := expiration(&res.Spec)
+	tr, err := remote.CoreV1().ServiceAccounts(sa.Namespace).CreateToken(ctx, sa.Name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         res.Spec.Audiences,
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf(errCreateToken, sa.Namespace, sa.Name, err)
+	}
+	return map[string][]byte{
+		"token":      []byte(tr.Status.Token),
+		"expires_at": []byte(strconv.FormatInt(tr.Status.ExpirationTimestamp.Unix(), 10)),
+	}, nil
+}
+
+// RenewalTime returns the time the token is renewed:
+// renewBefore ahead of its expiry, by default when a third of its lifetime is left.
+func (g *Generator) RenewalTime(jsonSpec *apiextensions.JSON, data map[string][]byte) (time.Time, error) {
+	if jsonSpec == nil {
+		return time.Time{}, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(errParseSpec, err)
+	}
+	expiresAt, ok := data["expires_at"]
+	if !ok {
+		return time.Time{}, fmt.Errorf(errNoExpiry)
+	}
+	unix, err := strconv.ParseInt(string(expiresAt), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(errParseExpiry, err)
+	}
+	lifetime := time.Duration(expiration(&res.Spec)) * time.Second
+	renewBefore := lifetime / renewFraction
+	if res.Spec.RenewBefore != nil {
+		renewBefore = res.Spec.RenewBefore.Duration
+		if renewBefore >= lifetime {
+			return time.Time{}, fmt.Errorf(errInvalidRenewal, renewBefore, lifetime)
+		}
+	}
+	return time.Unix(unix, 0).Add(-renewBefore), nil
+}
+
+func expiration(spec *genv1alpha1.ServiceAccountTokenSpec) int64 {
+	if spec.ExpirationSeconds != nil {
+		return *spec.ExpirationSeconds
+	}
+	return defaultExpirationSeconds
+}
+
+func parseSpec(data []byte) (*genv1alpha1.ServiceAccountToken, error) {
+	var spec genv1alpha1.ServiceAccountToken
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.ServiceAccountTokenKind, &Generator{})
+}
@@ -0,0 +1,146 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const tokenSpec = `apiVersion: generators.external-secrets.io/v1alpha1
+kind: ServiceAccountToken
+spec:
+  server:
+    url: https://remote.example.com
+    caBundle: Y2E=
+  auth:
+    token:
+      bearerToken:
+        name: remote-credentials
+        key: token
+  serviceAccount:
+    name: deployer
+    namespace: ci
+  audiences:
+  - https://remote.example.com
+  expirationSeconds: 1800`
+
+func TestGenerate(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("remote-token")},
+	}).Build()
+	expiresAt := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	remote := kubefake.NewSimpleClientset()
+	var gotRequest *authenticationv1.TokenRequest
+	var gotSA, gotNamespace string
+	remote.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		create := action.(k8stesting.CreateActionImpl)
+		gotRequest = create.GetObject().(*authenticationv1.TokenRequest)
+		gotSA, gotNamespace = create.Name, create.GetNamespace()
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{
+			Token:               "minted-token",
+			ExpirationTimestamp: metav1.NewTime(expiresAt),
+		}}, nil
+	})
+	var gotConfig *rest.Config
+	g := &Generator{newClientset: func(cfg *rest.Config) (kubernetes.Interface, error) {
+		gotConfig = cfg
+		return remote, nil
+	}}
+
+	data, err := g.generate(context.Background(), &apiextensions.JSON{Raw: []byte(tokenSpec)}, kube, nil, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "https://remote.example.com", gotConfig.Host)
+	assert.Equal(t, "remote-token", gotConfig.BearerToken)
+	assert.Equal(t, "deployer", gotSA)
+	assert.Equal(t, "ci", gotNamespace)
+	assert.Equal(t, []string{"https://remote.example.com"}, gotRequest.Spec.Audiences)
+	assert.Equal(t, int64(1800), *gotRequest.Spec.ExpirationSeconds)
+	assert.Equal(t, map[string][]byte{
+		"token":      []byte("minted-token"),
+		"expires_at": []byte(strconv.FormatInt(expiresAt.Unix(), 10)),
+	}, data)
+
+	renewal, err := g.RenewalTime(&apiextensions.JSON{Raw: []byte(tokenSpec)}, data)
+	require.NoError(t, err)
+	assert.Equal(t, expiresAt.Add(-10*time.Minute), renewal)
+}
+
+func TestGenerateMissingServiceAccount(t *testing.T) {
+	spec := `spec:
+  auth:
+    token:
+      bearerToken:
+        name: remote-credentials
+        key: token
+  serviceAccount:
+    name: deployer`
+	_, err := (&Generator{}).generate(context.Background(), &apiextensions.JSON{Raw: []byte(spec)}, clientfake.NewClientBuilder().Build(), nil, "default")
+	assert.EqualError(t, err, errNoServiceAcct)
+}
+
+func TestRenewalTime(t *testing.T) {
+	expiresAt := time.Unix(1700000000, 0)
+	data := map[string][]byte{"expires_at": []byte("1700000000")}
+	tests := []struct {
+		name    string
+		spec    string
+		want    time.Time
+		wantErr string
+	}{
+		{
+			name: "default lifetime",
+			spec: `spec: {}`,
+			want: expiresAt.Add(-20 * time.Minute),
+		},
+		{
+			name: "renewBefore",
+			spec: `spec: {renewBefore: 5m}`,
+			want: expiresAt.Add(-5 * time.Minute),
+		},
+		{
+			name:    "renewBefore exceeds the lifetime",
+			spec:    `spec: {renewBefore: 2h}`,
+			wantErr: "renewBefore 2h0m0s exceeds the token lifetime 1h0m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&Generator{}).RenewalTime(&apiextensions.JSON{Raw: []byte(tt.spec)}, data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
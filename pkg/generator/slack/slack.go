@@ -0,0 +1,184 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+type Generator struct {
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+const (
+	defaultSlackAPI = "https://slack.com/api"
+	oauthPath       = "/oauth.v2.access"
+
+	errNoSpec          = "no config spec provided"
+	errParseSpec       = "unable to parse spec: %w"
+	errGetSecret       = "unable to get secret %s: %w"
+	errMissingKey      = "key %s does not exist in secret %s"
+	errRequest         = "error performing request: %w"
+	errDecode          = "error decoding response: %w"
+	errRotate          = "unable to rotate token: %s"
+	errUnexpectedCode  = "unexpected status code %d"
+	errStoreRefreshTkn = "unable to store rotated refresh token in secret %s: %w"
+
+	contextTimeout    = 30 * time.Second
+	httpClientTimeout = 5 * time.Second
+)
+
+// tokenResponse is the response of oauth.v2.access with grant_type=refresh_token.
+type tokenResponse struct {
+	OK           bool   `json:"ok"`
+	Error        string `json:"error"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	return g.generate(ctx, jsonSpec, kube, namespace)
+}
+
+// generate exchanges the refresh token for a new access token.
+// Slack invalidates the used refresh token, so the rotated refresh token
+// is written back to the referenced secret before the access token is returned.
+func (g *Generator) generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, contextTimeout)
+	defer cancel()
+
+	clientSecret, _, err := secretValue(ctx, kube, namespace, res.Spec.Auth.ClientSecret.SecretRef)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, refreshSecret, err := secretValue(ctx, kube, namespace, res.Spec.Auth.RefreshToken.SecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	tkn, err := g.rotate(ctx, res.Spec, clientSecret, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// the update fails on a conflict, so a concurrent rotation can not overwrite the newer refresh token
+	if tkn.RefreshToken != "" && tkn.RefreshToken != refreshToken {
+		refreshSecret.Data[res.Spec.Auth.RefreshToken.SecretRef.Key] = []byte(tkn.RefreshToken)
+		if err := kube.Update(ctx, refreshSecret); err != nil {
+			return nil, fmt.Errorf(errStoreRefreshTkn, refreshSecret.Name, err)
+		}
+	}
+
+	now := time.Now
+	if g.now != nil {
+		now = g.now
+	}
+	expiresAt := now().Add(time.Duration(tkn.ExpiresIn) * time.Second)
+	return map[string][]byte{
+		"token":      []byte(tkn.AccessToken),
+		"token_type": []byte(tkn.TokenType),
+		"expires_at": []byte(strconv.FormatInt(expiresAt.Unix(), 10)),
+	}, nil
+}
+
+func (g *Generator) rotate(ctx context.Context, spec genv1alpha1.SlackAccessTokenSpec, clientSecret, refreshToken string) (*tokenResponse, error) {
+	hc := g.httpClient
+	if hc == nil {
+		hc = &http.Client{
+			Timeout: httpClientTimeout,
+		}
+	}
+	base := defaultSlackAPI
+	if spec.URL != "" {
+		base = strings.TrimSuffix(spec.URL, "/")
+	}
+	form := url.Values{
+		"client_id":     {spec.ClientID},
+		"client_secret": {clientSecret},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+oauthPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf(errRequest, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequest, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(errUnexpectedCode, resp.StatusCode)
+	}
+
+	var tkn tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tkn); err != nil {
+		return nil, fmt.Errorf(errDecode, err)
+	}
+	// Slack reports errors with status 200 and ok=false
+	if !tkn.OK {
+		return nil, fmt.Errorf(errRotate, tkn.Error)
+	}
+	return &tkn, nil
+}
+
+func secretValue(ctx context.Context, kube client.Client, namespace string, ref esmeta.SecretKeySelector) (string, *corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		return "", nil, fmt.Errorf(errGetSecret, ref.Name, err)
+	}
+	val, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", nil, fmt.Errorf(errMissingKey, ref.Key, ref.Name)
+	}
+	return string(val), secret, nil
+}
+
+func parseSpec(data []byte) (*genv1alpha1.SlackAccessToken, error) {
+	var spec genv1alpha1.SlackAccessToken
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.SlackAccessTokenKind, &Generator{})
+}
@@ -0,0 +1,124 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testHTTPSrv(t *testing.T, status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, oauthPath, req.URL.Path)
+		require.NoError(t, req.ParseForm())
+		assert.Equal(t, "1234.5678", req.PostForm.Get("client_id"))
+		assert.Equal(t, "client-secret", req.PostForm.Get("client_secret"))
+		assert.Equal(t, "refresh_token", req.PostForm.Get("grant_type"))
+		assert.Equal(t, "xoxe-1-old", req.PostForm.Get("refresh_token"))
+		rw.WriteHeader(status)
+		rw.Write([]byte(body))
+	}))
+}
+
+func TestGenerate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	spec := func(url string) *apiextensions.JSON {
+		return &apiextensions.JSON{Raw: []byte(fmt.Sprintf(`{"spec":{"url":%q,"clientID":"1234.5678","auth":{
+			"clientSecret":{"secretRef":{"name":"slack-app","key":"client-secret"}},
+			"refreshToken":{"secretRef":{"name":"slack-refresh","key":"token"}}}}}`, url))}
+	}
+	newKube := func() client.Client {
+		return clientfake.NewClientBuilder().WithObjects(
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "slack-app", Namespace: "default"},
+				Data:       map[string][]byte{"client-secret": []byte("client-secret")},
+			},
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "slack-refresh", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("xoxe-1-old")},
+			},
+		).Build()
+	}
+
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		want        map[string][]byte
+		wantErr     string
+		wantRefresh string
+	}{
+		{
+			name:   "rotates tokens",
+			status: http.StatusOK,
+			body:   `{"ok":true,"access_token":"xoxe.xoxb-1-new","refresh_token":"xoxe-1-new","token_type":"bot","expires_in":43200}`,
+			want: map[string][]byte{
+				"token":      []byte("xoxe.xoxb-1-new"),
+				"token_type": []byte("bot"),
+				"expires_at": []byte("1700043200"),
+			},
+			wantRefresh: "xoxe-1-new",
+		},
+		{
+			name:        "slack error",
+			status:      http.StatusOK,
+			body:        `{"ok":false,"error":"invalid_refresh_token"}`,
+			wantErr:     "unable to rotate token: invalid_refresh_token",
+			wantRefresh: "xoxe-1-old",
+		},
+		{
+			name:        "unexpected status code",
+			status:      http.StatusInternalServerError,
+			wantErr:     "unexpected status code 500",
+			wantRefresh: "xoxe-1-old",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := testHTTPSrv(t, tt.status, tt.body)
+			defer srv.Close()
+			kube := newKube()
+			g := &Generator{httpClient: srv.Client(), now: func() time.Time { return now }}
+			got, err := g.generate(context.Background(), spec(srv.URL), kube, "default")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			var refresh v1.Secret
+			require.NoError(t, kube.Get(context.Background(), client.ObjectKey{Name: "slack-refresh", Namespace: "default"}, &refresh))
+			assert.Equal(t, tt.wantRefresh, string(refresh.Data["token"]))
+		})
+	}
+
+	_, err := (&Generator{}).generate(context.Background(), nil, newKube(), "default")
+	assert.EqualError(t, err, errNoSpec)
+	_, err = (&Generator{}).generate(context.Background(), spec("http://localhost"), clientfake.NewClientBuilder().Build(), "default")
+	assert.Error(t, err)
+}
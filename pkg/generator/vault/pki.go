@@ -0,0 +1,173 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vaultdynamic
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	provider "github.com/external-secrets/external-secrets/pkg/provider/vault"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// PKIGenerator issues certificates from a role of the Vault PKI secrets engine.
+type PKIGenerator struct{}
+
+const (
+	defaultPKIMount = "pki"
+	pkiIssuePath    = "%s/issue/%s"
+
+	// renewFraction of the certificate lifetime that is left when it is renewed by default.
+	renewFraction = 3
+
+	errNoPKIRole      = "no role in spec"
+	errNoCommonName   = "no commonName in spec"
+	errIssueCert      = "unable to issue certificate: %w"
+	errNoCertificate  = "no certificate in generated data"
+	errParseCert      = "unable to parse certificate: %w"
+	errInvalidRenewal = "renewBefore %s exceeds the certificate lifetime %s"
+)
+
+func (g *PKIGenerator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
+	c := &provider.Provider{NewVaultClient: provider.NewVaultClient}
+	corev1, err := newCoreV1Client()
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(ctx, c, jsonSpec, kube, corev1, namespace)
+}
+
+func (g *PKIGenerator) generate(ctx context.Context, c *provider.Provider, jsonSpec *apiextensions.JSON, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace string) (map[string][]byte, error) {
+	if jsonSpec == nil {
+		return nil, fmt.Errorf(errNoSpec)
+	}
+	res, err := parsePKISpec(jsonSpec.Raw)
+	if err != nil {
+		return nil, fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.Provider == nil {
+		return nil, fmt.Errorf("no Vault provider config in spec")
+	}
+	if res.Spec.Role == "" {
+		return nil, fmt.Errorf(errNoPKIRole)
+	}
+	if res.Spec.CommonName == "" {
+		return nil, fmt.Errorf(errNoCommonName)
+	}
+	cl, err := c.NewGeneratorClient(ctx, kube, corev1, res.Spec.Provider, namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errVaultClient, err)
+	}
+
+	mount := defaultPKIMount
+	if res.Spec.Mount != "" {
+		mount = strings.Trim(res.Spec.Mount, "/")
+	}
+	params := map[string]any{
+		"common_name": res.Spec.CommonName,
+	}
+	if len(res.Spec.AltNames) > 0 {
+		params["alt_names"] = strings.Join(res.Spec.AltNames, ",")
+	}
+	if len(res.Spec.IPSANs) > 0 {
+		params["ip_sans"] = strings.Join(res.Spec.IPSANs, ",")
+	}
+	if len(res.Spec.URISANs) > 0 {
+		params["uri_sans"] = strings.Join(res.Spec.URISANs, ",")
+	}
+	if res.Spec.TTL != nil {
+		params["ttl"] = strconv.FormatInt(int64(res.Spec.TTL.Seconds()), 10) + "s"
+	}
+
+	result, err := cl.Logical().WriteWithContext(ctx, fmt.Sprintf(pkiIssuePath, mount, res.Spec.Role), params)
+	if err != nil {
+		return nil, fmt.Errorf(errIssueCert, err)
+	}
+	if result == nil || result.Data == nil {
+		return nil, fmt.Errorf(errIssueCert, fmt.Errorf("empty response from Vault"))
+	}
+
+	response := make(map[string][]byte)
+	for _, k := range []string{"certificate", "private_key", "private_key_type", "issuing_ca", "serial_number", "expiration"} {
+		if _, ok := result.Data[k]; !ok {
+			continue
+		}
+		response[k], err = utils.GetByteValueFromMap(result.Data, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if chain, ok := result.Data["ca_chain"].([]any); ok {
+		certs := make([]string, 0, len(chain))
+		for _, c := range chain {
+			certs = append(certs, fmt.Sprint(c))
+		}
+		response["ca_chain"] = []byte(strings.Join(certs, "\n"))
+	}
+	if _, ok := response["certificate"]; !ok {
+		return nil, fmt.Errorf(errIssueCert, fmt.Errorf(errNoCertificate))
+	}
+	return response, nil
+}
+
+// RenewalTime returns the time the issued certificate is renewed:
+// renewBefore ahead of its expiry, by default when a third of its lifetime is left.
+func (g *PKIGenerator) RenewalTime(jsonSpec *apiextensions.JSON, data map[string][]byte) (time.Time, error) {
+	if jsonSpec == nil {
+		return time.Time{}, fmt.Errorf(errNoSpec)
+	}
+	res, err := parsePKISpec(jsonSpec.Raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(errParseSpec, err)
+	}
+	block, _ := pem.Decode(data["certificate"])
+	if block == nil {
+		return time.Time{}, fmt.Errorf(errNoCertificate)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(errParseCert, err)
+	}
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	renewBefore := lifetime / renewFraction
+	if res.Spec.RenewBefore != nil {
+		renewBefore = res.Spec.RenewBefore.Duration
+		if renewBefore >= lifetime {
+			return time.Time{}, fmt.Errorf(errInvalidRenewal, renewBefore, lifetime)
+		}
+	}
+	return cert.NotAfter.Add(-renewBefore), nil
+}
+
+func parsePKISpec(data []byte) (*genv1alpha1.VaultPKICertificate, error) {
+	var spec genv1alpha1.VaultPKICertificate
+	err := yaml.Unmarshal(data, &spec)
+	return &spec, err
+}
+
+func init() {
+	genv1alpha1.Register(genv1alpha1.VaultPKICertificateKind, &PKIGenerator{})
+}
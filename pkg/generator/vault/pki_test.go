@@ -0,0 +1,152 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vaultdynamic
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	vault "github.com/hashicorp/vault/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	utilfake "github.com/external-secrets/external-secrets/pkg/provider/util/fake"
+	provider "github.com/external-secrets/external-secrets/pkg/provider/vault"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
+)
+
+const pkiSpec = `apiVersion: generators.external-secrets.io/v1alpha1
+kind: VaultPKICertificate
+spec:
+  provider:
+    auth:
+      kubernetes:
+        role: test
+        serviceAccountRef:
+          name: "testing"
+  mount: "pki_int/"
+  role: web
+  commonName: www.example.com
+  altNames:
+  - example.com
+  ipSANs:
+  - 10.0.0.1
+  ttl: 24h
+  renewBefore: 6h`
+
+func testCertificate(t *testing.T, notBefore, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestVaultPKICertificateGenerator(t *testing.T) {
+	cert := testCertificate(t, time.Now(), time.Now().Add(24*time.Hour))
+	var gotPath string
+	var gotParams map[string]any
+	c := &provider.Provider{NewVaultClient: func(cfg *vault.Config) (util.Client, error) {
+		cl, err := fake.ClientWithLoginMock(cfg)
+		if err != nil {
+			return nil, err
+		}
+		logical := fake.NewVaultLogical()
+		logical.WriteWithContextFn = func(_ context.Context, path string, data map[string]any) (*vault.Secret, error) {
+			gotPath, gotParams = path, data
+			return &vault.Secret{Data: map[string]any{
+				"certificate":      string(cert),
+				"private_key":      "key",
+				"private_key_type": "ec",
+				"issuing_ca":       "ca",
+				"ca_chain":         []any{"intermediate", "root"},
+				"serial_number":    "01",
+				"expiration":       json.Number("1700000000"),
+			}}, nil
+		}
+		cl.(*util.VaultClient).LogicalField = logical
+		return cl, nil
+	}}
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testing",
+			Namespace: "testing",
+		},
+	}).Build()
+
+	gen := &PKIGenerator{}
+	val, err := gen.generate(context.Background(), c, &apiextensions.JSON{Raw: []byte(pkiSpec)}, kube, utilfake.NewCreateTokenMock().WithToken("ok"), "testing")
+	require.NoError(t, err)
+	assert.Equal(t, "pki_int/issue/web", gotPath)
+	assert.Equal(t, map[string]any{
+		"common_name": "www.example.com",
+		"alt_names":   "example.com",
+		"ip_sans":     "10.0.0.1",
+		"ttl":         "86400s",
+	}, gotParams)
+	assert.Equal(t, map[string][]byte{
+		"certificate":      cert,
+		"private_key":      []byte("key"),
+		"private_key_type": []byte("ec"),
+		"issuing_ca":       []byte("ca"),
+		"ca_chain":         []byte("intermediate\nroot"),
+		"serial_number":    []byte("01"),
+		"expiration":       []byte("1700000000"),
+	}, val)
+
+	_, err = gen.generate(context.Background(), c, &apiextensions.JSON{Raw: []byte(`spec: {provider: {}}`)}, kube, nil, "testing")
+	assert.EqualError(t, err, errNoPKIRole)
+}
+
+func TestVaultPKICertificateRenewalTime(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(30 * time.Hour)
+	data := map[string][]byte{"certificate": testCertificate(t, notBefore, notAfter)}
+	gen := &PKIGenerator{}
+
+	renewAt, err := gen.RenewalTime(&apiextensions.JSON{Raw: []byte(`spec: {}`)}, data)
+	require.NoError(t, err)
+	assert.Equal(t, notAfter.Add(-10*time.Hour), renewAt.UTC())
+
+	renewAt, err = gen.RenewalTime(&apiextensions.JSON{Raw: []byte(pkiSpec)}, data)
+	require.NoError(t, err)
+	assert.Equal(t, notAfter.Add(-6*time.Hour), renewAt.UTC())
+
+	_, err = gen.RenewalTime(&apiextensions.JSON{Raw: []byte(`spec: {renewBefore: 48h}`)}, data)
+	assert.Error(t, err)
+	_, err = gen.RenewalTime(&apiextensions.JSON{Raw: []byte(`spec: {}`)}, map[string][]byte{})
+	assert.EqualError(t, err, errNoCertificate)
+}
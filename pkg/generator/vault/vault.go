@@ -39,11 +39,23 @@ const (
 	errParseSpec   = "unable to parse spec: %w"
 	errVaultClient = "unable to setup Vault client: %w"
 	errGetSecret   = "unable to get dynamic secret: %w"
+	errRevokeToken = "unable to revoke token: %w"
+
+	revokeAccessorPath = "auth/token/revoke-accessor"
+	revokeTokenPath    = "auth/token/revoke"
 )
 
 func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON, kube client.Client, namespace string) (map[string][]byte, error) {
 	c := &provider.Provider{NewVaultClient: provider.NewVaultClient}
+	corev1, err := newCoreV1Client()
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(ctx, c, jsonSpec, kube, corev1, namespace)
+}
 
+// newCoreV1Client returns a client for the TokenRequest API.
+func newCoreV1Client() (typedcorev1.CoreV1Interface, error) {
 	// controller-runtime/client does not support TokenRequest or other subresource APIs
 	// so we need to construct our own client and use it to fetch tokens
 	// (for Kubernetes service account token auth)
@@ -55,8 +67,7 @@ func (g *Generator) Generate(ctx context.Context, jsonSpec *apiextensions.JSON,
 	if err != nil {
 		return nil, err
 	}
-
-	return g.generate(ctx, c, jsonSpec, kube, clientset.CoreV1(), namespace)
+	return clientset.CoreV1(), nil
 }
 
 func (g *Generator) generate(ctx context.Context, c *provider.Provider, jsonSpec *apiextensions.JSON, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace string) (map[string][]byte, error) {
@@ -123,6 +134,47 @@ func (g *Generator) generate(ctx context.Context, c *provider.Provider, jsonSpec
 	return response, nil
 }
 
+// Cleanup revokes the token generated with resultType Auth, by its accessor if the data has one.
+// Data results carry no lease, there is nothing to revoke.
+func (g *Generator) Cleanup(ctx context.Context, jsonSpec *apiextensions.JSON, data map[string][]byte, kube client.Client, namespace string) error {
+	c := &provider.Provider{NewVaultClient: provider.NewVaultClient}
+	corev1, err := newCoreV1Client()
+	if err != nil {
+		return err
+	}
+	return g.cleanup(ctx, c, jsonSpec, data, kube, corev1, namespace)
+}
+
+func (g *Generator) cleanup(ctx context.Context, c *provider.Provider, jsonSpec *apiextensions.JSON, data map[string][]byte, kube client.Client, corev1 typedcorev1.CoreV1Interface, namespace string) error {
+	if jsonSpec == nil {
+		return fmt.Errorf(errNoSpec)
+	}
+	res, err := parseSpec(jsonSpec.Raw)
+	if err != nil {
+		return fmt.Errorf(errParseSpec, err)
+	}
+	if res.Spec.ResultType != genv1alpha1.VaultDynamicSecretResultTypeAuth {
+		return nil
+	}
+	path, params := revokeTokenPath, map[string]any{"token": string(data["client_token"])}
+	if accessor := string(data["accessor"]); accessor != "" {
+		path, params = revokeAccessorPath, map[string]any{"accessor": accessor}
+	} else if len(data["client_token"]) == 0 {
+		return nil
+	}
+	if res.Spec.Provider == nil {
+		return fmt.Errorf("no Vault provider config in spec")
+	}
+	cl, err := c.NewGeneratorClient(ctx, kube, corev1, res.Spec.Provider, namespace)
+	if err != nil {
+		return fmt.Errorf(errVaultClient, err)
+	}
+	if _, err := cl.Logical().WriteWithContext(ctx, path, params); err != nil {
+		return fmt.Errorf(errRevokeToken, err)
+	}
+	return nil
+}
+
 func parseSpec(data []byte) (*genv1alpha1.VaultDynamicSecret, error) {
 	var spec genv1alpha1.VaultDynamicSecret
 	err := yaml.Unmarshal(data, &spec)
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	vault "github.com/hashicorp/vault/api"
 	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +32,7 @@ import (
 	utilfake "github.com/external-secrets/external-secrets/pkg/provider/util/fake"
 	provider "github.com/external-secrets/external-secrets/pkg/provider/vault"
 	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
 )
 
 type args struct {
@@ -178,3 +180,74 @@ spec:
 		})
 	}
 }
+
+func TestVaultDynamicSecretCleanup(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "testing"},
+		Data:       map[string][]byte{"token": []byte("root")},
+	}).Build()
+	spec := func(resultType string) *apiextensions.JSON {
+		return &apiextensions.JSON{Raw: []byte(`apiVersion: generators.external-secrets.io/v1alpha1
+kind: VaultDynamicSecret
+spec:
+  provider:
+    auth:
+      tokenSecretRef:
+        name: vault-token
+        key: token
+  method: POST
+  path: "auth/token/create"
+  resultType: ` + resultType)}
+	}
+	cases := map[string]struct {
+		spec     *apiextensions.JSON
+		data     map[string][]byte
+		wantPath string
+		wantData map[string]any
+	}{
+		"RevokeAccessor": {
+			spec:     spec("Auth"),
+			data:     map[string][]byte{"client_token": []byte("s.token"), "accessor": []byte("accessor")},
+			wantPath: "auth/token/revoke-accessor",
+			wantData: map[string]any{"accessor": "accessor"},
+		},
+		"RevokeToken": {
+			spec:     spec("Auth"),
+			data:     map[string][]byte{"client_token": []byte("s.token")},
+			wantPath: "auth/token/revoke",
+			wantData: map[string]any{"token": "s.token"},
+		},
+		"NothingToRevoke": {
+			spec: spec("Data"),
+			data: map[string][]byte{"password": []byte("secret")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotPath string
+			var gotData map[string]any
+			c := &provider.Provider{NewVaultClient: func(cfg *vault.Config) (util.Client, error) {
+				cl, err := fake.ClientWithLoginMock(cfg)
+				if err != nil {
+					return nil, err
+				}
+				vc := cl.(*util.VaultClient)
+				vc.LogicalField = fake.Logical{WriteWithContextFn: func(_ context.Context, path string, data map[string]any) (*vault.Secret, error) {
+					gotPath, gotData = path, data
+					return nil, nil
+				}}
+				return vc, nil
+			}}
+			err := (&Generator{}).cleanup(context.Background(), c, tc.spec, tc.data, kube, nil, "testing")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotPath != tc.wantPath {
+				t.Errorf("revoked at %q, want %q", gotPath, tc.wantPath)
+			}
+			if diff := cmp.Diff(tc.wantData, gotData); diff != "" {
+				t.Errorf("unexpected revoke parameters (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const providerInFlightRequests = "provider_inflight_requests"
+
+var inFlightRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Subsystem: ExternalSecretSubsystem,
+	Name:      providerInFlightRequests,
+	Help:      "Number of requests towards the secret provider that wait for a response",
+}, []string{"provider", "store_kind", "store_namespace", "store_name"})
+
+// InFlightRoundTripper returns a http.RoundTripper that counts the requests of next until their
+// response headers are received, labeled with the provider and the store.
+func InFlightRoundTripper(provider, storeKind, storeNamespace, storeName string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	gauge := inFlightRequests.WithLabelValues(provider, storeKind, storeNamespace, storeName)
+	return promhttp.InstrumentRoundTripperInFlight(gauge, next)
+}
+
+// DeleteInFlightRequests removes the in-flight requests series of the store, e.g. once the store is gone.
+func DeleteInFlightRequests(provider, storeKind, storeNamespace, storeName string) {
+	inFlightRequests.DeleteLabelValues(provider, storeKind, storeNamespace, storeName)
+}
@@ -46,5 +46,5 @@ func deriveStatus(err error) string {
 }
 
 func init() {
-	metrics.Registry.MustRegister(syncCallsTotal)
+	metrics.Registry.MustRegister(syncCallsTotal, quotaRemaining, quotaLimit, inFlightRequests)
 }
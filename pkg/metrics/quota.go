@@ -0,0 +1,159 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	providerQuotaRemaining = "provider_quota_remaining"
+	providerQuotaLimit     = "provider_quota_limit"
+
+	// defaultQuota is the quota label of rate limit headers that don't name their resource.
+	defaultQuota = "requests"
+
+	// headers of GitLab (RateLimit-*) and GitHub (X-RateLimit-*).
+	headerRateLimitLimit      = "Ratelimit-Limit"
+	headerRateLimitRemaining  = "Ratelimit-Remaining"
+	headerXRateLimitLimit     = "X-Ratelimit-Limit"
+	headerXRateLimitRemaining = "X-Ratelimit-Remaining"
+	headerXRateLimitResource  = "X-Ratelimit-Resource"
+	// prefix of the Azure throttling headers, e.g. x-ms-ratelimit-remaining-subscription-reads.
+	headerAzureRemainingPrefix = "x-ms-ratelimit-remaining-"
+)
+
+var (
+	quotaLabels = []string{"provider", "quota", "store_kind", "store_namespace", "store_name"}
+
+	quotaRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      providerQuotaRemaining,
+		Help:      "Remaining API quota of the secret provider as reported by the last response",
+	}, quotaLabels)
+
+	quotaLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      providerQuotaLimit,
+		Help:      "API quota limit of the secret provider as reported by the last response",
+	}, quotaLabels)
+)
+
+// QuotaObserver records the quota headers of the responses of a provider for a store.
+type QuotaObserver struct {
+	provider  string
+	kind      string
+	namespace string
+	name      string
+}
+
+// NewQuotaObserver returns a QuotaObserver labeling the quotas with the provider and the store.
+func NewQuotaObserver(provider, storeKind, storeNamespace, storeName string) *QuotaObserver {
+	return &QuotaObserver{
+		provider:  provider,
+		kind:      storeKind,
+		namespace: storeNamespace,
+		name:      storeName,
+	}
+}
+
+// ObserveResponse records the quotas of the response headers, responses without quota headers are ignored.
+func (o *QuotaObserver) ObserveResponse(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	for quota, values := range parseQuotaHeaders(resp.Header) {
+		labels := []string{o.provider, quota, o.kind, o.namespace, o.name}
+		if values.remaining != nil {
+			quotaRemaining.WithLabelValues(labels...).Set(*values.remaining)
+		}
+		if values.limit != nil {
+			quotaLimit.WithLabelValues(labels...).Set(*values.limit)
+		}
+	}
+}
+
+// RoundTripper returns a http.RoundTripper that records the quotas of the responses of next,
+// http.DefaultTransport if next is nil.
+func (o *QuotaObserver) RoundTripper(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &quotaRoundTripper{observer: o, next: next}
+}
+
+type quotaRoundTripper struct {
+	observer *QuotaObserver
+	next     http.RoundTripper
+}
+
+func (t *quotaRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		t.observer.ObserveResponse(resp)
+	}
+	return resp, err
+}
+
+type quotaValues struct {
+	remaining *float64
+	limit     *float64
+}
+
+// parseQuotaHeaders returns the quotas of the headers by the name of the quota.
+func parseQuotaHeaders(header http.Header) map[string]quotaValues {
+	quotas := make(map[string]quotaValues)
+	rateLimit := func(quota, remainingHeader, limitHeader string) {
+		values := quotaValues{
+			remaining: parseQuotaValue(header.Get(remainingHeader)),
+			limit:     parseQuotaValue(header.Get(limitHeader)),
+		}
+		if values.remaining != nil || values.limit != nil {
+			quotas[quota] = values
+		}
+	}
+	rateLimit(defaultQuota, headerRateLimitRemaining, headerRateLimitLimit)
+	xQuota := defaultQuota
+	if resource := header.Get(headerXRateLimitResource); resource != "" {
+		xQuota = resource
+	}
+	rateLimit(xQuota, headerXRateLimitRemaining, headerXRateLimitLimit)
+
+	for name := range header {
+		lower := strings.ToLower(name)
+		if !strings.HasPrefix(lower, headerAzureRemainingPrefix) {
+			continue
+		}
+		if remaining := parseQuotaValue(header.Get(name)); remaining != nil {
+			quotas[strings.TrimPrefix(lower, headerAzureRemainingPrefix)] = quotaValues{remaining: remaining}
+		}
+	}
+	return quotas
+}
+
+func parseQuotaValue(value string) *float64 {
+	if value == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
@@ -0,0 +1,83 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaObserver(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        http.Header
+		wantRemaining map[string]float64
+		wantLimit     map[string]float64
+	}{
+		{
+			name: "gitlab",
+			header: http.Header{
+				"Ratelimit-Limit":     {"2000"},
+				"Ratelimit-Remaining": {"1990"},
+			},
+			wantRemaining: map[string]float64{"requests": 1990},
+			wantLimit:     map[string]float64{"requests": 2000},
+		},
+		{
+			name: "github",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"5000"},
+				"X-Ratelimit-Remaining": {"4999"},
+				"X-Ratelimit-Resource":  {"core"},
+			},
+			wantRemaining: map[string]float64{"core": 4999},
+			wantLimit:     map[string]float64{"core": 5000},
+		},
+		{
+			name: "azure",
+			header: http.Header{
+				"X-Ms-Ratelimit-Remaining-Subscription-Reads": {"11999"},
+			},
+			wantRemaining: map[string]float64{"subscription-reads": 11999},
+		},
+		{
+			name: "invalid values are ignored",
+			header: http.Header{
+				"Ratelimit-Remaining": {"unlimited"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotaRemaining.Reset()
+			quotaLimit.Reset()
+			NewQuotaObserver("provider", "SecretStore", "default", "store").ObserveResponse(&http.Response{Header: tt.header})
+
+			assert.Equal(t, len(tt.wantRemaining), testutil.CollectAndCount(quotaRemaining))
+			assert.Equal(t, len(tt.wantLimit), testutil.CollectAndCount(quotaLimit))
+			for quota, want := range tt.wantRemaining {
+				got := testutil.ToFloat64(quotaRemaining.WithLabelValues("provider", quota, "SecretStore", "default", "store"))
+				assert.Equal(t, want, got)
+			}
+			for quota, want := range tt.wantLimit {
+				got := testutil.ToFloat64(quotaLimit.WithLabelValues("provider", quota, "SecretStore", "default", "store"))
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
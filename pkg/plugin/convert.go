@@ -0,0 +1,176 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/plugin/v1alpha1"
+)
+
+// ToRemoteRef converts the remote ref of an ExternalSecret to its API message.
+func ToRemoteRef(ref esv1beta1.ExternalSecretDataRemoteRef) *v1alpha1.RemoteRef {
+	return &v1alpha1.RemoteRef{
+		Key:            ref.Key,
+		Property:       ref.Property,
+		Version:        ref.Version,
+		MetadataPolicy: string(ref.MetadataPolicy),
+	}
+}
+
+// FromRemoteRef converts the API message to the remote ref of an ExternalSecret.
+func FromRemoteRef(ref *v1alpha1.RemoteRef) esv1beta1.ExternalSecretDataRemoteRef {
+	return esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            ref.GetKey(),
+		Property:       ref.GetProperty(),
+		Version:        ref.GetVersion(),
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicy(ref.GetMetadataPolicy()),
+	}
+}
+
+// ToFind converts the find criteria of an ExternalSecret to its API message.
+func ToFind(find esv1beta1.ExternalSecretFind) *v1alpha1.Find {
+	out := &v1alpha1.Find{
+		Path: find.Path,
+		Tags: find.Tags,
+	}
+	if find.Name != nil {
+		out.NameRegexp = find.Name.RegExp
+	}
+	return out
+}
+
+// FromFind converts the API message to the find criteria of an ExternalSecret.
+func FromFind(find *v1alpha1.Find) esv1beta1.ExternalSecretFind {
+	out := esv1beta1.ExternalSecretFind{
+		Path: find.Path,
+		Tags: find.GetTags(),
+	}
+	if find.GetNameRegexp() != "" {
+		out.Name = &esv1beta1.FindName{RegExp: find.GetNameRegexp()}
+	}
+	return out
+}
+
+// ToSecret converts the pushed Kubernetes Secret to its API message.
+func ToSecret(secret *corev1.Secret) *v1alpha1.Secret {
+	return &v1alpha1.Secret{
+		Name:        secret.Name,
+		Namespace:   secret.Namespace,
+		Type:        string(secret.Type),
+		Data:        secret.Data,
+		Labels:      secret.Labels,
+		Annotations: secret.Annotations,
+	}
+}
+
+// FromSecret converts the API message to the pushed Kubernetes Secret.
+func FromSecret(secret *v1alpha1.Secret) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.GetName(),
+			Namespace:   secret.GetNamespace(),
+			Labels:      secret.GetLabels(),
+			Annotations: secret.GetAnnotations(),
+		},
+		Type: corev1.SecretType(secret.GetType()),
+		Data: secret.GetData(),
+	}
+}
+
+// ToPushSecretData converts the data of a push to its API message.
+func ToPushSecretData(data esv1beta1.PushSecretData) *v1alpha1.PushSecretData {
+	out := &v1alpha1.PushSecretData{
+		SecretKey: data.GetSecretKey(),
+		RemoteKey: data.GetRemoteKey(),
+		Property:  data.GetProperty(),
+	}
+	if metadata := data.GetMetadata(); metadata != nil {
+		out.Metadata = metadata.Raw
+	}
+	return out
+}
+
+// FromPushSecretData converts the API message to the data of a push.
+func FromPushSecretData(data *v1alpha1.PushSecretData) esv1beta1.PushSecretData {
+	out := esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{
+			SecretKey: data.GetSecretKey(),
+			RemoteRef: esv1alpha1.PushSecretRemoteRef{
+				RemoteKey: data.GetRemoteKey(),
+				Property:  data.GetProperty(),
+			},
+		},
+	}
+	if len(data.GetMetadata()) > 0 {
+		out.Metadata = &apiextensionsv1.JSON{Raw: data.GetMetadata()}
+	}
+	return out
+}
+
+// ToPushRemoteRef converts the remote ref of a push to its API message.
+func ToPushRemoteRef(ref esv1beta1.PushSecretRemoteRef) *v1alpha1.PushRemoteRef {
+	return &v1alpha1.PushRemoteRef{
+		RemoteKey: ref.GetRemoteKey(),
+		Property:  ref.GetProperty(),
+	}
+}
+
+// FromPushRemoteRef converts the API message to the remote ref of a push.
+func FromPushRemoteRef(ref *v1alpha1.PushRemoteRef) esv1beta1.PushSecretRemoteRef {
+	return esv1alpha1.PushSecretRemoteRef{
+		RemoteKey: ref.GetRemoteKey(),
+		Property:  ref.GetProperty(),
+	}
+}
+
+// ToStatus converts an error of a SecretsClient to a gRPC status error.
+// A NoSecretError is returned as NOT_FOUND.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, esv1beta1.NoSecretErr) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// FromStatus converts a gRPC status error to an error of a SecretsClient.
+// NOT_FOUND is returned as NoSecretError.
+func FromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	if st.Code() == codes.NotFound {
+		return esv1beta1.NoSecretErr
+	}
+	return errors.New(st.Message())
+}
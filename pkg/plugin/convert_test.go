@@ -0,0 +1,69 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestPushSecretDataRoundTrip(t *testing.T) {
+	data := esv1alpha1.PushSecretData{
+		Match: esv1alpha1.PushSecretMatch{
+			SecretKey: "key",
+			RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "remote", Property: "prop"},
+		},
+		Metadata: &apiextensionsv1.JSON{Raw: []byte(`{"kind":"PushSecretMetadata"}`)},
+	}
+	assert.Equal(t, data, FromPushSecretData(ToPushSecretData(data)))
+
+	data.Metadata = nil
+	assert.Equal(t, data, FromPushSecretData(ToPushSecretData(data)))
+}
+
+func TestFindRoundTrip(t *testing.T) {
+	path := "team-a/"
+	find := esv1beta1.ExternalSecretFind{
+		Path: &path,
+		Name: &esv1beta1.FindName{RegExp: "^db-"},
+		Tags: map[string]string{"env": "prod"},
+	}
+	assert.Equal(t, find, FromFind(ToFind(find)))
+	assert.Equal(t, esv1beta1.ExternalSecretFind{}, FromFind(ToFind(esv1beta1.ExternalSecretFind{})))
+}
+
+func TestStatus(t *testing.T) {
+	assert.NoError(t, ToStatus(nil))
+	assert.NoError(t, FromStatus(nil))
+
+	err := ToStatus(esv1beta1.NoSecretErr)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.ErrorIs(t, FromStatus(err), esv1beta1.NoSecretErr)
+
+	err = ToStatus(errors.New("access denied"))
+	assert.Equal(t, codes.Unknown, status.Code(err))
+	assert.EqualError(t, FromStatus(err), "access denied")
+
+	err = status.Error(codes.Unimplemented, "not implemented")
+	assert.Equal(t, err, ToStatus(err))
+}
@@ -0,0 +1,156 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin is the SDK of out-of-tree providers. The controller calls them over gRPC
+// through the API in the v1alpha1 package, the Server adapts a SecretsClient to this API.
+package plugin
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/plugin/v1alpha1"
+)
+
+// Provider is implemented by out-of-tree providers.
+// A client is created for every call with the store the call is made for.
+type Provider interface {
+	NewClient(ctx context.Context, store *v1alpha1.Store) (esv1beta1.SecretsClient, error)
+}
+
+// Server implements the plugin API with a Provider.
+type Server struct {
+	v1alpha1.UnimplementedSecretsProviderServer
+
+	provider Provider
+}
+
+var _ v1alpha1.SecretsProviderServer = &Server{}
+
+// NewServer returns a Server calling the given Provider.
+func NewServer(provider Provider) *Server {
+	return &Server{provider: provider}
+}
+
+// Serve serves the Provider on the listener until it is closed.
+func Serve(listener net.Listener, provider Provider, opts ...grpc.ServerOption) error {
+	srv := grpc.NewServer(opts...)
+	v1alpha1.RegisterSecretsProviderServer(srv, NewServer(provider))
+	return srv.Serve(listener)
+}
+
+// withClient calls fn with a client of the store and closes it afterwards.
+func (s *Server) withClient(ctx context.Context, store *v1alpha1.Store, fn func(esv1beta1.SecretsClient) error) error {
+	client, err := s.provider.NewClient(ctx, store)
+	if err != nil {
+		return ToStatus(err)
+	}
+	defer func() {
+		_ = client.Close(ctx)
+	}()
+	return ToStatus(fn(client))
+}
+
+func (s *Server) GetSecret(ctx context.Context, req *v1alpha1.GetSecretRequest) (*v1alpha1.GetSecretResponse, error) {
+	resp := &v1alpha1.GetSecretResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(client esv1beta1.SecretsClient) error {
+		value, err := client.GetSecret(ctx, FromRemoteRef(req.GetRef()))
+		resp.Value = value
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) GetSecretMap(ctx context.Context, req *v1alpha1.GetSecretRequest) (*v1alpha1.SecretMapResponse, error) {
+	resp := &v1alpha1.SecretMapResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(client esv1beta1.SecretsClient) error {
+		data, err := client.GetSecretMap(ctx, FromRemoteRef(req.GetRef()))
+		resp.Data = data
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) GetAllSecrets(ctx context.Context, req *v1alpha1.GetAllSecretsRequest) (*v1alpha1.SecretMapResponse, error) {
+	resp := &v1alpha1.SecretMapResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(client esv1beta1.SecretsClient) error {
+		data, err := client.GetAllSecrets(ctx, FromFind(req.GetFind()))
+		resp.Data = data
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) PushSecret(ctx context.Context, req *v1alpha1.PushSecretRequest) (*v1alpha1.PushSecretResponse, error) {
+	err := s.withClient(ctx, req.GetStore(), func(client esv1beta1.SecretsClient) error {
+		return client.PushSecret(ctx, FromSecret(req.GetSecret()), FromPushSecretData(req.GetData()))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.PushSecretResponse{}, nil
+}
+
+func (s *Server) DeleteSecret(ctx context.Context, req *v1alpha1.DeleteSecretRequest) (*v1alpha1.DeleteSecretResponse, error) {
+	err := s.withClient(ctx, req.GetStore(), func(client esv1beta1.SecretsClient) error {
+		return client.DeleteSecret(ctx, FromPushRemoteRef(req.GetRef()))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.DeleteSecretResponse{}, nil
+}
+
+func (s *Server) SecretExists(ctx context.Context, req *v1alpha1.SecretExistsRequest) (*v1alpha1.SecretExistsResponse, error) {
+	resp := &v1alpha1.SecretExistsResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(client esv1beta1.SecretsClient) error {
+		exists, err := client.SecretExists(ctx, FromPushRemoteRef(req.GetRef()))
+		resp.Exists = exists
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Validate reports errors of the validation in the response, errors creating the client are returned as status.
+func (s *Server) Validate(ctx context.Context, req *v1alpha1.ValidateRequest) (*v1alpha1.ValidateResponse, error) {
+	resp := &v1alpha1.ValidateResponse{}
+	err := s.withClient(ctx, req.GetStore(), func(client esv1beta1.SecretsClient) error {
+		result, err := client.Validate()
+		resp.Result = v1alpha1.ValidationResult(result)
+		if err != nil {
+			resp.Result = v1alpha1.ValidationResult_VALIDATION_RESULT_ERROR
+			resp.Message = err.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
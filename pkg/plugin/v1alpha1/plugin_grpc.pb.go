@@ -0,0 +1,368 @@
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// This is the API of out-of-tree providers, it mirrors the SecretsClient interface of the controller.
+// Errors are returned as gRPC status, NOT_FOUND signals that a secret does not exist in the provider.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v0.0.0
+// source: plugin.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	SecretsProvider_GetSecret_FullMethodName     = "/externalsecrets.plugin.v1alpha1.SecretsProvider/GetSecret"
+	SecretsProvider_GetSecretMap_FullMethodName  = "/externalsecrets.plugin.v1alpha1.SecretsProvider/GetSecretMap"
+	SecretsProvider_GetAllSecrets_FullMethodName = "/externalsecrets.plugin.v1alpha1.SecretsProvider/GetAllSecrets"
+	SecretsProvider_PushSecret_FullMethodName    = "/externalsecrets.plugin.v1alpha1.SecretsProvider/PushSecret"
+	SecretsProvider_DeleteSecret_FullMethodName  = "/externalsecrets.plugin.v1alpha1.SecretsProvider/DeleteSecret"
+	SecretsProvider_SecretExists_FullMethodName  = "/externalsecrets.plugin.v1alpha1.SecretsProvider/SecretExists"
+	SecretsProvider_Validate_FullMethodName      = "/externalsecrets.plugin.v1alpha1.SecretsProvider/Validate"
+)
+
+// SecretsProviderClient is the client API for SecretsProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SecretsProviderClient interface {
+	// GetSecret returns a single secret from the provider.
+	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
+	// GetSecretMap returns multiple key/value pairs of a single secret from the provider.
+	GetSecretMap(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*SecretMapResponse, error)
+	// GetAllSecrets returns the secrets of the provider matching the find criteria.
+	GetAllSecrets(ctx context.Context, in *GetAllSecretsRequest, opts ...grpc.CallOption) (*SecretMapResponse, error)
+	// PushSecret writes a single secret into the provider.
+	PushSecret(ctx context.Context, in *PushSecretRequest, opts ...grpc.CallOption) (*PushSecretResponse, error)
+	// DeleteSecret deletes a secret from the provider.
+	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
+	// SecretExists checks if a secret is present in the provider.
+	SecretExists(ctx context.Context, in *SecretExistsRequest, opts ...grpc.CallOption) (*SecretExistsResponse, error)
+	// Validate checks if the store is configured correctly and able to retrieve secrets.
+	Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error)
+}
+
+type secretsProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSecretsProviderClient(cc grpc.ClientConnInterface) SecretsProviderClient {
+	return &secretsProviderClient{cc}
+}
+
+func (c *secretsProviderClient) GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSecretResponse)
+	err := c.cc.Invoke(ctx, SecretsProvider_GetSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) GetSecretMap(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*SecretMapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretMapResponse)
+	err := c.cc.Invoke(ctx, SecretsProvider_GetSecretMap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) GetAllSecrets(ctx context.Context, in *GetAllSecretsRequest, opts ...grpc.CallOption) (*SecretMapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretMapResponse)
+	err := c.cc.Invoke(ctx, SecretsProvider_GetAllSecrets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) PushSecret(ctx context.Context, in *PushSecretRequest, opts ...grpc.CallOption) (*PushSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushSecretResponse)
+	err := c.cc.Invoke(ctx, SecretsProvider_PushSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSecretResponse)
+	err := c.cc.Invoke(ctx, SecretsProvider_DeleteSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) SecretExists(ctx context.Context, in *SecretExistsRequest, opts ...grpc.CallOption) (*SecretExistsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretExistsResponse)
+	err := c.cc.Invoke(ctx, SecretsProvider_SecretExists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateResponse)
+	err := c.cc.Invoke(ctx, SecretsProvider_Validate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SecretsProviderServer is the server API for SecretsProvider service.
+// All implementations must embed UnimplementedSecretsProviderServer
+// for forward compatibility
+type SecretsProviderServer interface {
+	// GetSecret returns a single secret from the provider.
+	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
+	// GetSecretMap returns multiple key/value pairs of a single secret from the provider.
+	GetSecretMap(context.Context, *GetSecretRequest) (*SecretMapResponse, error)
+	// GetAllSecrets returns the secrets of the provider matching the find criteria.
+	GetAllSecrets(context.Context, *GetAllSecretsRequest) (*SecretMapResponse, error)
+	// PushSecret writes a single secret into the provider.
+	PushSecret(context.Context, *PushSecretRequest) (*PushSecretResponse, error)
+	// DeleteSecret deletes a secret from the provider.
+	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
+	// SecretExists checks if a secret is present in the provider.
+	SecretExists(context.Context, *SecretExistsRequest) (*SecretExistsResponse, error)
+	// Validate checks if the store is configured correctly and able to retrieve secrets.
+	Validate(context.Context, *ValidateRequest) (*ValidateResponse, error)
+	mustEmbedUnimplementedSecretsProviderServer()
+}
+
+// UnimplementedSecretsProviderServer must be embedded to have forward compatible implementations.
+type UnimplementedSecretsProviderServer struct {
+}
+
+func (UnimplementedSecretsProviderServer) GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecret not implemented")
+}
+func (UnimplementedSecretsProviderServer) GetSecretMap(context.Context, *GetSecretRequest) (*SecretMapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSecretMap not implemented")
+}
+func (UnimplementedSecretsProviderServer) GetAllSecrets(context.Context, *GetAllSecretsRequest) (*SecretMapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllSecrets not implemented")
+}
+func (UnimplementedSecretsProviderServer) PushSecret(context.Context, *PushSecretRequest) (*PushSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushSecret not implemented")
+}
+func (UnimplementedSecretsProviderServer) DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSecret not implemented")
+}
+func (UnimplementedSecretsProviderServer) SecretExists(context.Context, *SecretExistsRequest) (*SecretExistsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretExists not implemented")
+}
+func (UnimplementedSecretsProviderServer) Validate(context.Context, *ValidateRequest) (*ValidateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Validate not implemented")
+}
+func (UnimplementedSecretsProviderServer) mustEmbedUnimplementedSecretsProviderServer() {}
+
+// UnsafeSecretsProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SecretsProviderServer will
+// result in compilation errors.
+type UnsafeSecretsProviderServer interface {
+	mustEmbedUnimplementedSecretsProviderServer()
+}
+
+func RegisterSecretsProviderServer(s grpc.ServiceRegistrar, srv SecretsProviderServer) {
+	s.RegisterService(&SecretsProvider_ServiceDesc, srv)
+}
+
+func _SecretsProvider_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).GetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretsProvider_GetSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).GetSecret(ctx, req.(*GetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_GetSecretMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).GetSecretMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretsProvider_GetSecretMap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).GetSecretMap(ctx, req.(*GetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_GetAllSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).GetAllSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretsProvider_GetAllSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).GetAllSecrets(ctx, req.(*GetAllSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_PushSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).PushSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretsProvider_PushSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).PushSecret(ctx, req.(*PushSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_DeleteSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).DeleteSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretsProvider_DeleteSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).DeleteSecret(ctx, req.(*DeleteSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_SecretExists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretExistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).SecretExists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretsProvider_SecretExists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).SecretExists(ctx, req.(*SecretExistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).Validate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SecretsProvider_Validate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).Validate(ctx, req.(*ValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SecretsProvider_ServiceDesc is the grpc.ServiceDesc for SecretsProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SecretsProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "externalsecrets.plugin.v1alpha1.SecretsProvider",
+	HandlerType: (*SecretsProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSecret",
+			Handler:    _SecretsProvider_GetSecret_Handler,
+		},
+		{
+			MethodName: "GetSecretMap",
+			Handler:    _SecretsProvider_GetSecretMap_Handler,
+		},
+		{
+			MethodName: "GetAllSecrets",
+			Handler:    _SecretsProvider_GetAllSecrets_Handler,
+		},
+		{
+			MethodName: "PushSecret",
+			Handler:    _SecretsProvider_PushSecret_Handler,
+		},
+		{
+			MethodName: "DeleteSecret",
+			Handler:    _SecretsProvider_DeleteSecret_Handler,
+		},
+		{
+			MethodName: "SecretExists",
+			Handler:    _SecretsProvider_SecretExists_Handler,
+		},
+		{
+			MethodName: "Validate",
+			Handler:    _SecretsProvider_Validate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugin.proto",
+}
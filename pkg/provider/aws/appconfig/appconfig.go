@@ -0,0 +1,260 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
+)
+
+// https://github.com/external-secrets/external-secrets/issues/644
+var _ esv1beta1.SecretsClient = &AppConfig{}
+
+// AppConfig is a provider for the hosted configuration store of AWS AppConfig.
+type AppConfig struct {
+	sess         *session.Session
+	client       ACInterface
+	referentAuth bool
+}
+
+// ACInterface is a subset of the appconfig api.
+// see: https://docs.aws.amazon.com/sdk-for-go/api/service/appconfig/appconfigiface/
+type ACInterface interface {
+	GetHostedConfigurationVersionWithContext(aws.Context, *appconfig.GetHostedConfigurationVersionInput, ...request.Option) (*appconfig.GetHostedConfigurationVersionOutput, error)
+	ListHostedConfigurationVersionsWithContext(aws.Context, *appconfig.ListHostedConfigurationVersionsInput, ...request.Option) (*appconfig.ListHostedConfigurationVersionsOutput, error)
+}
+
+const (
+	errReadOnly         = "AppConfig stores are read-only, %s is not supported"
+	errFindNotSupported = "find is not supported by AppConfig stores"
+	errInvalidKey       = "invalid key %q, expected <application id>/<configuration profile id>"
+	errNotStructured    = "configuration %s has content type %q, expected JSON or YAML"
+	errConvertYAML      = "unable to convert YAML configuration %s: %w"
+	errUnmarshal        = "unable to unmarshal configuration %s: %w"
+	errMissingProperty  = "key %s does not exist in configuration %s"
+)
+
+// New constructs an AppConfig Provider that is specific to a store.
+func New(sess *session.Session, cfg *aws.Config, referentAuth bool) (*AppConfig, error) {
+	return &AppConfig{
+		sess:         sess,
+		referentAuth: referentAuth,
+		client:       appconfig.New(sess, cfg),
+	}, nil
+}
+
+// GetSecret returns the content of a hosted configuration version,
+// or a property of it if the content is JSON or YAML.
+// The key is <application id>/<configuration profile id>, the version is a version number or a version label.
+// Without a version the latest version is returned.
+func (ac *AppConfig) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	out, err := ac.getConfiguration(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return out.Content, nil
+	}
+	content, err := toJSON(ref.Key, out)
+	if err != nil {
+		return nil, err
+	}
+	return property(ref.Key, content, ref.Property)
+}
+
+// GetSecretMap flattens a JSON or YAML configuration into secret keys,
+// the keys of nested objects are joined with a dot, e.g. database.password.
+func (ac *AppConfig) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	out, err := ac.getConfiguration(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	data, err := toJSON(ref.Key, out)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property != "" {
+		if data, err = property(ref.Key, data, ref.Property); err != nil {
+			return nil, err
+		}
+	}
+	var kv map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&kv); err != nil {
+		return nil, fmt.Errorf(errUnmarshal, ref.Key, err)
+	}
+	secretData := make(map[string][]byte)
+	if err := flatten("", kv, secretData); err != nil {
+		return nil, fmt.Errorf(errUnmarshal, ref.Key, err)
+	}
+	return secretData, nil
+}
+
+func (ac *AppConfig) getConfiguration(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+	application, profile, ok := strings.Cut(ref.Key, "/")
+	if !ok || application == "" || profile == "" {
+		return nil, fmt.Errorf(errInvalidKey, ref.Key)
+	}
+	version, err := strconv.ParseInt(ref.Version, 10, 64)
+	if err != nil {
+		// resolve the latest version, with the version label if one is given
+		input := &appconfig.ListHostedConfigurationVersionsInput{
+			ApplicationId:          &application,
+			ConfigurationProfileId: &profile,
+			MaxResults:             aws.Int64(1),
+		}
+		if ref.Version != "" {
+			input.VersionLabel = &ref.Version
+		}
+		versions, err := ac.client.ListHostedConfigurationVersionsWithContext(ctx, input)
+		metrics.ObserveAPICall(constants.ProviderAWSAC, constants.CallAWSACListHostedConfigurationVersions, err)
+		if err != nil {
+			return nil, notFound(err)
+		}
+		// versions are listed from the latest to the oldest
+		if len(versions.Items) == 0 || versions.Items[0].VersionNumber == nil {
+			return nil, esv1beta1.NoSecretErr
+		}
+		version = *versions.Items[0].VersionNumber
+	}
+	out, err := ac.client.GetHostedConfigurationVersionWithContext(ctx, &appconfig.GetHostedConfigurationVersionInput{
+		ApplicationId:          &application,
+		ConfigurationProfileId: &profile,
+		VersionNumber:          &version,
+	})
+	metrics.ObserveAPICall(constants.ProviderAWSAC, constants.CallAWSACGetHostedConfigurationVersion, err)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return out, nil
+}
+
+func notFound(err error) error {
+	var nf *appconfig.ResourceNotFoundException
+	if errors.As(err, &nf) {
+		return esv1beta1.NoSecretErr
+	}
+	return util.SanitizeErr(err)
+}
+
+func property(key string, content []byte, prop string) ([]byte, error) {
+	if strings.Contains(prop, ".") {
+		val := gjson.GetBytes(content, strings.ReplaceAll(prop, ".", "\\."))
+		if val.Exists() {
+			return []byte(val.String()), nil
+		}
+	}
+	val := gjson.GetBytes(content, prop)
+	if !val.Exists() {
+		return nil, fmt.Errorf(errMissingProperty, prop, key)
+	}
+	return []byte(val.String()), nil
+}
+
+// toJSON returns the content of a JSON or YAML configuration as JSON.
+func toJSON(key string, out *appconfig.GetHostedConfigurationVersionOutput) ([]byte, error) {
+	contentType := strings.ToLower(aws.StringValue(out.ContentType))
+	switch {
+	case strings.Contains(contentType, "json"):
+		return out.Content, nil
+	case strings.Contains(contentType, "yaml"):
+		content, err := yaml.YAMLToJSON(out.Content)
+		if err != nil {
+			return nil, fmt.Errorf(errConvertYAML, key, err)
+		}
+		return content, nil
+	default:
+		return nil, fmt.Errorf(errNotStructured, key, contentType)
+	}
+}
+
+// flatten adds the values of the object to data, prefixing the keys of nested objects with the key of their parent.
+// Strings are added as they are, numbers, booleans and arrays as JSON.
+func flatten(prefix string, obj map[string]any, data map[string][]byte) error {
+	for k, v := range obj {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			if err := flatten(key, val, data); err != nil {
+				return err
+			}
+		case string:
+			data[key] = []byte(val)
+		default:
+			raw, err := json.Marshal(val)
+			if err != nil {
+				return err
+			}
+			data[key] = raw
+		}
+	}
+	return nil
+}
+
+// GetAllSecrets is not supported, configurations are addressed by the IDs of their application and profile.
+func (ac *AppConfig) GetAllSecrets(_ context.Context, _ esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, errors.New(errFindNotSupported)
+}
+
+func (ac *AppConfig) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return fmt.Errorf(errReadOnly, "PushSecret")
+}
+
+func (ac *AppConfig) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return fmt.Errorf(errReadOnly, "DeleteSecret")
+}
+
+func (ac *AppConfig) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, fmt.Errorf(errReadOnly, "SecretExists")
+}
+
+func (ac *AppConfig) Close(_ context.Context) error {
+	return nil
+}
+
+func (ac *AppConfig) Validate() (esv1beta1.ValidationResult, error) {
+	// skip validation stack because it depends on the namespace
+	// of the ExternalSecret
+	if ac.referentAuth {
+		return esv1beta1.ValidationResultUnknown, nil
+	}
+	_, err := ac.sess.Config.Credentials.Get()
+	if err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
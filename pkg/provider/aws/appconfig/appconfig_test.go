@@ -0,0 +1,187 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appconfig
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	fakeac "github.com/external-secrets/external-secrets/pkg/provider/aws/appconfig/fake"
+)
+
+const (
+	jsonConfig = `{"database":{"host":"db.example.com","port":5432,"password":"s3cr3t"},"flags":["a","b"],"enabled":true}`
+	yamlConfig = "database:\n  host: db.example.com\n  port: 5432\n  password: s3cr3t\nflags: [a, b]\nenabled: true\n"
+)
+
+// fakeClient serves the versions of the configuration app/profile, the latest version is the last one.
+func fakeClient(t *testing.T, contentType string, versions ...string) *fakeac.Client {
+	return &fakeac.Client{
+		ListHostedConfigurationVersionsWithContextFn: func(_ aws.Context, input *appconfig.ListHostedConfigurationVersionsInput, _ ...request.Option) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+			if *input.ApplicationId != "app" || *input.ConfigurationProfileId != "profile" {
+				return nil, &appconfig.ResourceNotFoundException{}
+			}
+			assert.Equal(t, int64(1), *input.MaxResults)
+			out := &appconfig.ListHostedConfigurationVersionsOutput{}
+			for i := len(versions); i > 0; i-- {
+				label := fmt.Sprintf("v%d", i)
+				if input.VersionLabel != nil && *input.VersionLabel != label {
+					continue
+				}
+				out.Items = append(out.Items, &appconfig.HostedConfigurationVersionSummary{VersionNumber: aws.Int64(int64(i)), VersionLabel: &label})
+			}
+			return out, nil
+		},
+		GetHostedConfigurationVersionWithContextFn: func(_ aws.Context, input *appconfig.GetHostedConfigurationVersionInput, _ ...request.Option) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+			v := *input.VersionNumber
+			if *input.ApplicationId != "app" || *input.ConfigurationProfileId != "profile" || v < 1 || v > int64(len(versions)) {
+				return nil, &appconfig.ResourceNotFoundException{}
+			}
+			return &appconfig.GetHostedConfigurationVersionOutput{
+				Content:       []byte(versions[v-1]),
+				ContentType:   &contentType,
+				VersionNumber: input.VersionNumber,
+			}, nil
+		},
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		versions    []string
+		ref         esv1beta1.ExternalSecretDataRemoteRef
+		want        string
+		wantErr     string
+	}{
+		{
+			name:        "latest version",
+			contentType: "text/plain",
+			versions:    []string{"old", "new"},
+			ref:         esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile"},
+			want:        "new",
+		},
+		{
+			name:        "version number",
+			contentType: "text/plain",
+			versions:    []string{"old", "new"},
+			ref:         esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile", Version: "1"},
+			want:        "old",
+		},
+		{
+			name:        "version label",
+			contentType: "text/plain",
+			versions:    []string{"old", "new"},
+			ref:         esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile", Version: "v1"},
+			want:        "old",
+		},
+		{
+			name:        "property of JSON",
+			contentType: "application/json",
+			versions:    []string{jsonConfig},
+			ref:         esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile", Property: "database.password"},
+			want:        "s3cr3t",
+		},
+		{
+			name:        "property of YAML",
+			contentType: "application/x-yaml",
+			versions:    []string{yamlConfig},
+			ref:         esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile", Property: "database.port"},
+			want:        "5432",
+		},
+		{
+			name:        "missing property",
+			contentType: "application/json",
+			versions:    []string{jsonConfig},
+			ref:         esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile", Property: "database.user"},
+			wantErr:     "key database.user does not exist in configuration app/profile",
+		},
+		{
+			name:        "property of plain text",
+			contentType: "text/plain",
+			versions:    []string{"plain"},
+			ref:         esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile", Property: "database"},
+			wantErr:     `configuration app/profile has content type "text/plain", expected JSON or YAML`,
+		},
+		{
+			name:     "unknown configuration",
+			versions: []string{"plain"},
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/other"},
+			wantErr:  esv1beta1.NoSecretErr.Error(),
+		},
+		{
+			name:     "unknown version label",
+			versions: []string{"plain"},
+			ref:      esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile", Version: "v9"},
+			wantErr:  esv1beta1.NoSecretErr.Error(),
+		},
+		{
+			name:    "invalid key",
+			ref:     esv1beta1.ExternalSecretDataRemoteRef{Key: "app"},
+			wantErr: `invalid key "app", expected <application id>/<configuration profile id>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ac := &AppConfig{client: fakeClient(t, tt.contentType, tt.versions...)}
+			got, err := ac.GetSecret(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestGetSecretMap(t *testing.T) {
+	want := map[string][]byte{
+		"database.host":     []byte("db.example.com"),
+		"database.port":     []byte("5432"),
+		"database.password": []byte("s3cr3t"),
+		"flags":             []byte(`["a","b"]`),
+		"enabled":           []byte("true"),
+	}
+	for contentType, content := range map[string]string{"application/json": jsonConfig, "application/x-yaml": yamlConfig} {
+		t.Run(contentType, func(t *testing.T) {
+			ac := &AppConfig{client: fakeClient(t, contentType, content)}
+			got, err := ac.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile"})
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			got, err = ac.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile", Property: "database"})
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]byte{
+				"host":     []byte("db.example.com"),
+				"port":     []byte("5432"),
+				"password": []byte("s3cr3t"),
+			}, got)
+		})
+	}
+
+	ac := &AppConfig{client: fakeClient(t, "text/plain", "plain")}
+	_, err := ac.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "app/profile"})
+	assert.EqualError(t, err, `configuration app/profile has content type "text/plain", expected JSON or YAML`)
+}
@@ -0,0 +1,38 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/appconfig"
+)
+
+// Client implements the aws appconfig interface.
+type Client struct {
+	GetHostedConfigurationVersionWithContextFn   GetHostedConfigurationVersionWithContextFn
+	ListHostedConfigurationVersionsWithContextFn ListHostedConfigurationVersionsWithContextFn
+}
+
+type GetHostedConfigurationVersionWithContextFn func(aws.Context, *appconfig.GetHostedConfigurationVersionInput, ...request.Option) (*appconfig.GetHostedConfigurationVersionOutput, error)
+type ListHostedConfigurationVersionsWithContextFn func(aws.Context, *appconfig.ListHostedConfigurationVersionsInput, ...request.Option) (*appconfig.ListHostedConfigurationVersionsOutput, error)
+
+func (c *Client) GetHostedConfigurationVersionWithContext(ctx aws.Context, input *appconfig.GetHostedConfigurationVersionInput, options ...request.Option) (*appconfig.GetHostedConfigurationVersionOutput, error) {
+	return c.GetHostedConfigurationVersionWithContextFn(ctx, input, options...)
+}
+
+func (c *Client) ListHostedConfigurationVersionsWithContext(ctx aws.Context, input *appconfig.ListHostedConfigurationVersionsInput, options ...request.Option) (*appconfig.ListHostedConfigurationVersionsOutput, error) {
+	return c.ListHostedConfigurationVersionsWithContextFn(ctx, input, options...)
+}
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/aws/appconfig"
 	awsauth "github.com/external-secrets/external-secrets/pkg/provider/aws/auth"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/parameterstore"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/secretsmanager"
@@ -38,6 +39,7 @@ import (
 
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.RemoteRefFeatureValidator = &Provider{}
 
 // Provider satisfies the provider interface.
 type Provider struct{}
@@ -55,6 +57,16 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// UnsupportedRemoteRefFeatures returns the remote ref features the service of the store rejects:
+// only the Parameter Store can push a whole Secret.
+func (p *Provider) UnsupportedRemoteRefFeatures(store esv1beta1.GenericStore) []esv1beta1.RemoteRefFeature {
+	prov, err := util.GetAWSProvider(store)
+	if err == nil && prov.Service == esv1beta1.AWSServiceParameterStore {
+		return nil
+	}
+	return []esv1beta1.RemoteRefFeature{esv1beta1.RemoteRefFeaturePushWholeSecret}
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	return newClient(ctx, store, kube, namespace, awsauth.DefaultSTSProvider)
@@ -109,6 +121,8 @@ func validateRegion(prov *esv1beta1.AWSProvider) error {
 			serviceskey = "secretsmanager"
 		} else if prov.Service == esv1beta1.AWSServiceParameterStore {
 			serviceskey = "ssm"
+		} else if prov.Service == esv1beta1.AWSServiceAppConfig {
+			serviceskey = "appconfig"
 		}
 		service, ok := p.Services()[serviceskey]
 		if ok {
@@ -154,9 +168,11 @@ func newClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Cl
 		sess := &session.Session{Config: cfg}
 		switch prov.Service {
 		case esv1beta1.AWSServiceSecretsManager:
-			return secretsmanager.New(sess, cfg, prov.SecretsManager, true)
+			return secretsmanager.New(sess, cfg, prov.SecretsManager, true, string(store.GetUID()))
 		case esv1beta1.AWSServiceParameterStore:
 			return parameterstore.New(sess, cfg, true)
+		case esv1beta1.AWSServiceAppConfig:
+			return appconfig.New(sess, cfg, true)
 		}
 		return nil, fmt.Errorf(errUnknownProviderService, prov.Service)
 	}
@@ -193,9 +209,11 @@ func newClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Cl
 
 	switch prov.Service {
 	case esv1beta1.AWSServiceSecretsManager:
-		return secretsmanager.New(sess, cfg, prov.SecretsManager, false)
+		return secretsmanager.New(sess, cfg, prov.SecretsManager, false, string(store.GetUID()))
 	case esv1beta1.AWSServiceParameterStore:
 		return parameterstore.New(sess, cfg, false)
+	case esv1beta1.AWSServiceAppConfig:
+		return appconfig.New(sess, cfg, false)
 	}
 	return nil, fmt.Errorf(errUnknownProviderService, prov.Service)
 }
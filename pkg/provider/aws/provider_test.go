@@ -226,6 +226,21 @@ func TestValidateStore(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid region appconfig",
+			args: args{
+				store: &esv1beta1.SecretStore{
+					Spec: esv1beta1.SecretStoreSpec{
+						Provider: &esv1beta1.SecretStoreProvider{
+							AWS: &esv1beta1.AWSProvider{
+								Region:  "eu-west-1",
+								Service: esv1beta1.AWSServiceAppConfig,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "valid secretsmanager config: force delete without recovery",
 			args: args{
@@ -35,6 +35,7 @@ type Client struct {
 	DescribeSecretWithContextFn DescribeSecretWithContextFn
 	DeleteSecretWithContextFn   DeleteSecretWithContextFn
 	ListSecretsFn               ListSecretsFn
+	TagResourceWithContextFn    TagResourceWithContextFn
 }
 
 type CreateSecretWithContextFn func(aws.Context, *awssm.CreateSecretInput, ...request.Option) (*awssm.CreateSecretOutput, error)
@@ -43,6 +44,7 @@ type PutSecretValueWithContextFn func(aws.Context, *awssm.PutSecretValueInput, .
 type DescribeSecretWithContextFn func(aws.Context, *awssm.DescribeSecretInput, ...request.Option) (*awssm.DescribeSecretOutput, error)
 type DeleteSecretWithContextFn func(ctx aws.Context, input *awssm.DeleteSecretInput, opts ...request.Option) (*awssm.DeleteSecretOutput, error)
 type ListSecretsFn func(ctx aws.Context, input *awssm.ListSecretsInput, opts ...request.Option) (*awssm.ListSecretsOutput, error)
+type TagResourceWithContextFn func(aws.Context, *awssm.TagResourceInput, ...request.Option) (*awssm.TagResourceOutput, error)
 
 func (sm Client) CreateSecretWithContext(ctx aws.Context, input *awssm.CreateSecretInput, options ...request.Option) (*awssm.CreateSecretOutput, error) {
 	return sm.CreateSecretWithContextFn(ctx, input, options...)
@@ -144,6 +146,14 @@ func NewDescribeSecretWithContextFn(output *awssm.DescribeSecretOutput, err erro
 	}
 }
 
+// TagResourceWithContext succeeds unless TagResourceWithContextFn is set.
+func (sm Client) TagResourceWithContext(ctx aws.Context, input *awssm.TagResourceInput, options ...request.Option) (*awssm.TagResourceOutput, error) {
+	if sm.TagResourceWithContextFn == nil {
+		return &awssm.TagResourceOutput{}, nil
+	}
+	return sm.TagResourceWithContextFn(ctx, input, options...)
+}
+
 // NewClient init a new fake client.
 func NewClient() *Client {
 	return &Client{
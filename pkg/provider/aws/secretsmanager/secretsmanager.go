@@ -60,6 +60,8 @@ type SecretsManager struct {
 	referentAuth bool
 	cache        map[string]*awssm.GetSecretValueOutput
 	config       *esv1beta1.SecretsManager
+	// owner is the UID of the store, tagged on pushed secrets to tell which secrets it manages.
+	owner string
 }
 
 // SMInterface is a subset of the smiface api.
@@ -72,6 +74,7 @@ type SMInterface interface {
 	PutSecretValueWithContext(aws.Context, *awssm.PutSecretValueInput, ...request.Option) (*awssm.PutSecretValueOutput, error)
 	DescribeSecretWithContext(aws.Context, *awssm.DescribeSecretInput, ...request.Option) (*awssm.DescribeSecretOutput, error)
 	DeleteSecretWithContext(ctx aws.Context, input *awssm.DeleteSecretInput, opts ...request.Option) (*awssm.DeleteSecretOutput, error)
+	TagResourceWithContext(aws.Context, *awssm.TagResourceInput, ...request.Option) (*awssm.TagResourceOutput, error)
 }
 
 const (
@@ -79,18 +82,21 @@ const (
 	managedBy                 = "managed-by"
 	externalSecrets           = "external-secrets"
 	initialVersion            = "00000000-0000-0000-0000-000000000001"
+	errNoOwner                = "unable to list managed secrets: the store has no UID"
 )
 
 var log = ctrl.Log.WithName("provider").WithName("aws").WithName("secretsmanager")
 
 // New creates a new SecretsManager client.
-func New(sess *session.Session, cfg *aws.Config, secretsManagerCfg *esv1beta1.SecretsManager, referentAuth bool) (*SecretsManager, error) {
+// The owner is the UID of the store, pushed secrets are tagged with it.
+func New(sess *session.Session, cfg *aws.Config, secretsManagerCfg *esv1beta1.SecretsManager, referentAuth bool, owner string) (*SecretsManager, error) {
 	return &SecretsManager{
 		sess:         sess,
 		client:       awssm.New(sess, cfg),
 		referentAuth: referentAuth,
 		cache:        make(map[string]*awssm.GetSecretValueOutput),
 		config:       secretsManagerCfg,
+		owner:        owner,
 	}, nil
 }
 
@@ -323,6 +329,16 @@ func isManagedByESO(data *awssm.DescribeSecretOutput) bool {
 	return false
 }
 
+// secretOwner returns the value of the owner tag, empty if the secret has none.
+func secretOwner(tags []*awssm.Tag) string {
+	for _, tag := range tags {
+		if tag.Key != nil && *tag.Key == esv1beta1.ManagedSecretOwnerTag && tag.Value != nil {
+			return *tag.Value
+		}
+	}
+	return ""
+}
+
 // GetAllSecrets syncs multiple secrets from aws provider into a single Kubernetes Secret.
 func (sm *SecretsManager) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
 	if ref.Name != nil {
@@ -433,6 +449,52 @@ func (sm *SecretsManager) findByTags(ctx context.Context, ref esv1beta1.External
 	return data, nil
 }
 
+// ListManagedSecrets returns the names of the secrets tagged with managed-by=external-secrets
+// that have been pushed through the store, other stores may manage secrets in the same account.
+func (sm *SecretsManager) ListManagedSecrets(_ context.Context) ([]string, error) {
+	if sm.owner == "" {
+		return nil, errors.New(errNoOwner)
+	}
+	filters := []*awssm.Filter{
+		{
+			Key:    utilpointer.To(awssm.FilterNameStringTypeTagKey),
+			Values: []*string{utilpointer.To(esv1beta1.ManagedSecretOwnerTag)},
+		},
+		{
+			Key:    utilpointer.To(awssm.FilterNameStringTypeTagValue),
+			Values: []*string{utilpointer.To(sm.owner)},
+		},
+	}
+	var names []string
+	var nextToken *string
+	for {
+		it, err := sm.client.ListSecrets(&awssm.ListSecretsInput{
+			Filters:   filters,
+			NextToken: nextToken,
+		})
+		metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMListSecrets, err)
+		if err != nil {
+			return nil, err
+		}
+		for _, secret := range it.SecretList {
+			// the filters match the tag key and value independently
+			if isManagedByESO(&awssm.DescribeSecretOutput{Tags: secret.Tags}) && secretOwner(secret.Tags) == sm.owner {
+				names = append(names, *secret.Name)
+			}
+		}
+		nextToken = it.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+	return names, nil
+}
+
+// ManagedSecretKey returns the remote key, PushSecrets reference secrets by name.
+func (sm *SecretsManager) ManagedSecretKey(remoteKey string) string {
+	return remoteKey
+}
+
 func (sm *SecretsManager) fetchAndSet(ctx context.Context, data map[string][]byte, name string) error {
 	sec, err := sm.fetch(ctx, esv1beta1.ExternalSecretDataRemoteRef{
 		Key: name,
@@ -569,6 +631,12 @@ func (sm *SecretsManager) createSecretWithContext(ctx context.Context, secretNam
 		},
 		ClientRequestToken: utilpointer.To(initialVersion),
 	}
+	if sm.owner != "" {
+		input.Tags = append(input.Tags, &awssm.Tag{
+			Key:   utilpointer.To(esv1beta1.ManagedSecretOwnerTag),
+			Value: utilpointer.To(sm.owner),
+		})
+	}
 	if secretPushFormat == SecretPushFormatString {
 		input.SetSecretBinary(nil).SetSecretString(string(value))
 	}
@@ -588,6 +656,9 @@ func (sm *SecretsManager) putSecretValueWithContext(ctx context.Context, secretI
 	if !isManagedByESO(data) {
 		return fmt.Errorf("secret not managed by external-secrets")
 	}
+	if err := sm.tagOwner(ctx, data); err != nil {
+		return err
+	}
 	if awsSecret != nil && bytes.Equal(awsSecret.SecretBinary, value) || utils.CompareStringAndByteSlices(awsSecret.SecretString, value) {
 		return nil
 	}
@@ -614,3 +685,22 @@ func (sm *SecretsManager) putSecretValueWithContext(ctx context.Context, secretI
 
 	return err
 }
+
+// tagOwner tags a managed secret with the store pushing it, e.g. after a PushSecret moved to
+// another store or for a secret pushed before secrets were tagged with their store.
+func (sm *SecretsManager) tagOwner(ctx context.Context, data *awssm.DescribeSecretOutput) error {
+	if sm.owner == "" || secretOwner(data.Tags) == sm.owner {
+		return nil
+	}
+	_, err := sm.client.TagResourceWithContext(ctx, &awssm.TagResourceInput{
+		SecretId: data.ARN,
+		Tags: []*awssm.Tag{
+			{
+				Key:   utilpointer.To(esv1beta1.ManagedSecretOwnerTag),
+				Value: utilpointer.To(sm.owner),
+			},
+		},
+	})
+	metrics.ObserveAPICall(constants.ProviderAWSSM, constants.CallAWSSMTagResource, err)
+	return err
+}
@@ -31,6 +31,7 @@ import (
 	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -1259,6 +1260,109 @@ func TestSecretsManagerGetAllSecrets(t *testing.T) {
 	}
 }
 
+func TestListManagedSecrets(t *testing.T) {
+	owned := []*awssm.Tag{
+		{Key: ptr.To(managedBy), Value: ptr.To(externalSecrets)},
+		{Key: ptr.To(esv1beta1.ManagedSecretOwnerTag), Value: ptr.To("store-uid")},
+	}
+	fc := fakesm.NewClient()
+	fc.ListSecretsFn = func(ctx context.Context, input *awssm.ListSecretsInput, opts ...request.Option) (*awssm.ListSecretsOutput, error) {
+		assert.Len(t, input.Filters, 2)
+		assert.Equal(t, esv1beta1.ManagedSecretOwnerTag, *input.Filters[0].Values[0])
+		assert.Equal(t, "store-uid", *input.Filters[1].Values[0])
+		if input.NextToken == nil {
+			return &awssm.ListSecretsOutput{
+				SecretList: []*awssm.SecretListEntry{
+					{Name: ptr.To("pushed"), Tags: owned},
+					// tag key and value filters match independently
+					{Name: ptr.To("mixed"), Tags: []*awssm.Tag{
+						{Key: ptr.To(managedBy), Value: ptr.To(externalSecrets)},
+						{Key: ptr.To(esv1beta1.ManagedSecretOwnerTag), Value: ptr.To("other-store-uid")},
+						{Key: ptr.To("team"), Value: ptr.To("store-uid")},
+					}},
+					{Name: ptr.To("unmanaged"), Tags: []*awssm.Tag{
+						{Key: ptr.To(managedBy), Value: ptr.To("terraform")},
+						{Key: ptr.To(esv1beta1.ManagedSecretOwnerTag), Value: ptr.To("store-uid")},
+					}},
+				},
+				NextToken: ptr.To("next"),
+			}, nil
+		}
+		return &awssm.ListSecretsOutput{
+			SecretList: []*awssm.SecretListEntry{{Name: ptr.To("orphan"), Tags: owned}},
+		}, nil
+	}
+	sm := SecretsManager{client: fc, owner: "store-uid"}
+	names, err := sm.ListManagedSecrets(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pushed", "orphan"}, names)
+
+	fc.ListSecretsFn = func(ctx context.Context, input *awssm.ListSecretsInput, opts ...request.Option) (*awssm.ListSecretsOutput, error) {
+		return nil, errors.New("boom")
+	}
+	_, err = sm.ListManagedSecrets(context.Background())
+	assert.EqualError(t, err, "boom")
+
+	// without owner every managed secret would be listed, including those of other stores
+	_, err = (&SecretsManager{client: fc}).ListManagedSecrets(context.Background())
+	assert.EqualError(t, err, errNoOwner)
+}
+
+func TestPushSecretOwnerTag(t *testing.T) {
+	arn := "arn:aws:secretsmanager:us-east-1:702902267788:secret:fake-key"
+	secret := &corev1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+	data := fake.PushSecretData{SecretKey: "key", RemoteKey: "fake-key"}
+	managed := &awssm.Tag{Key: ptr.To(managedBy), Value: ptr.To(externalSecrets)}
+
+	t.Run("new secret", func(t *testing.T) {
+		fc := fakesm.NewClient()
+		fc.GetSecretValueWithContextFn = fakesm.NewGetSecretValueWithContextFn(nil, &awssm.ResourceNotFoundException{})
+		var tags []*awssm.Tag
+		fc.CreateSecretWithContextFn = func(_ aws.Context, input *awssm.CreateSecretInput, _ ...request.Option) (*awssm.CreateSecretOutput, error) {
+			tags = input.Tags
+			return &awssm.CreateSecretOutput{ARN: &arn}, nil
+		}
+		sm := SecretsManager{client: fc, owner: "store-uid"}
+		require.NoError(t, sm.PushSecret(context.Background(), secret, data))
+		assert.Equal(t, "store-uid", secretOwner(tags))
+	})
+
+	tests := []struct {
+		name    string
+		tags    []*awssm.Tag
+		wantTag bool
+	}{
+		{name: "secret pushed before owners were tagged", tags: []*awssm.Tag{managed}, wantTag: true},
+		{name: "secret of the store", tags: []*awssm.Tag{managed, {Key: ptr.To(esv1beta1.ManagedSecretOwnerTag), Value: ptr.To("store-uid")}}},
+		{name: "secret of another store", tags: []*awssm.Tag{managed, {Key: ptr.To(esv1beta1.ManagedSecretOwnerTag), Value: ptr.To("other-store-uid")}}, wantTag: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := fakesm.NewClient()
+			fc.GetSecretValueWithContextFn = fakesm.NewGetSecretValueWithContextFn(&awssm.GetSecretValueOutput{
+				ARN:          &arn,
+				SecretBinary: []byte("value"),
+				VersionId:    ptr.To(initialVersion),
+			}, nil)
+			fc.DescribeSecretWithContextFn = fakesm.NewDescribeSecretWithContextFn(&awssm.DescribeSecretOutput{ARN: &arn, Tags: tt.tags}, nil)
+			var tagged *awssm.TagResourceInput
+			fc.TagResourceWithContextFn = func(_ aws.Context, input *awssm.TagResourceInput, _ ...request.Option) (*awssm.TagResourceOutput, error) {
+				tagged = input
+				return &awssm.TagResourceOutput{}, nil
+			}
+			sm := SecretsManager{client: fc, owner: "store-uid"}
+			require.NoError(t, sm.PushSecret(context.Background(), secret, data))
+			if !tt.wantTag {
+				assert.Nil(t, tagged)
+				return
+			}
+			require.NotNil(t, tagged)
+			assert.Equal(t, arn, *tagged.SecretId)
+			assert.Equal(t, "store-uid", secretOwner(tagged.Tags))
+		})
+	}
+}
+
 func TestSecretsManagerValidate(t *testing.T) {
 	type fields struct {
 		sess         *session.Session
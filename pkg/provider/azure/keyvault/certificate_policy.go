@@ -0,0 +1,129 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/keyvault/keyvault"
+	pointer "k8s.io/utils/ptr"
+)
+
+const (
+	errCertificatePolicyObjectType = "certificatePolicy is only supported for certificates"
+	errUnknownLifetimeAction       = "unknown lifetime action %q, expected %s or %s"
+	errLifetimeActionTrigger       = "lifetime action %s needs either daysBeforeExpiry or lifetimePercentage"
+	errLifetimePercentage          = "lifetimePercentage must be between 1 and 99"
+	errDaysBeforeExpiry            = "daysBeforeExpiry must be at least 1"
+)
+
+// CertificatePolicy overrides parts of the policy of a pushed certificate.
+// The policy of an existing certificate is kept for the fields that are not set.
+type CertificatePolicy struct {
+	// IssuerName is the name of the issuer of the certificate, e.g. Self or Unknown.
+	IssuerName string `json:"issuerName,omitempty"`
+	// LifetimeActions replace the lifetime actions of the certificate.
+	LifetimeActions []CertificateLifetimeAction `json:"lifetimeActions,omitempty"`
+}
+
+// CertificateLifetimeAction is an action Key Vault performs during the lifetime of a certificate.
+type CertificateLifetimeAction struct {
+	// Action is EmailContacts or AutoRenew.
+	Action string `json:"action"`
+	// DaysBeforeExpiry triggers the action the given number of days before the certificate expires.
+	DaysBeforeExpiry *int32 `json:"daysBeforeExpiry,omitempty"`
+	// LifetimePercentage triggers the action at the given percentage of the lifetime of the certificate.
+	LifetimePercentage *int32 `json:"lifetimePercentage,omitempty"`
+}
+
+// validate checks the lifetime actions of the policy.
+func (p *CertificatePolicy) validate() error {
+	for _, action := range p.LifetimeActions {
+		switch keyvault.ActionType(action.Action) {
+		case keyvault.EmailContacts, keyvault.AutoRenew:
+		default:
+			return fmt.Errorf(errUnknownLifetimeAction, action.Action, keyvault.EmailContacts, keyvault.AutoRenew)
+		}
+		if (action.DaysBeforeExpiry == nil) == (action.LifetimePercentage == nil) {
+			return fmt.Errorf(errLifetimeActionTrigger, action.Action)
+		}
+		if action.LifetimePercentage != nil && (*action.LifetimePercentage < 1 || *action.LifetimePercentage > 99) {
+			return errors.New(errLifetimePercentage)
+		}
+		if action.DaysBeforeExpiry != nil && *action.DaysBeforeExpiry < 1 {
+			return errors.New(errDaysBeforeExpiry)
+		}
+	}
+	return nil
+}
+
+// certificatePolicy returns the policy of an imported certificate: the issuer and lifetime actions
+// of the existing certificate, overridden by the ones set in the metadata.
+// The key, secret and X509 properties are left to the imported certificate.
+// It returns nil if there is nothing to keep or override, so Key Vault applies its defaults.
+func (m PushSecretMetadata) certificatePolicy(existing *keyvault.CertificatePolicy) *keyvault.CertificatePolicy {
+	policy := &keyvault.CertificatePolicy{}
+	if existing != nil {
+		policy.IssuerParameters = existing.IssuerParameters
+		policy.LifetimeActions = existing.LifetimeActions
+	}
+	if override := m.CertificatePolicy; override != nil {
+		if override.IssuerName != "" {
+			policy.IssuerParameters = &keyvault.IssuerParameters{Name: pointer.To(override.IssuerName)}
+		}
+		if len(override.LifetimeActions) > 0 {
+			policy.LifetimeActions = pointer.To(override.lifetimeActions())
+		}
+	}
+	if policy.IssuerParameters == nil && policy.LifetimeActions == nil {
+		return nil
+	}
+	return policy
+}
+
+// certificatePolicyApplied returns false if the policy of the certificate in the vault doesn't match
+// the policy of the metadata, so it has to be imported again although the certificate didn't change.
+func (m PushSecretMetadata) certificatePolicyApplied(existing *keyvault.CertificatePolicy) bool {
+	override := m.CertificatePolicy
+	if override == nil {
+		return true
+	}
+	if existing == nil {
+		existing = &keyvault.CertificatePolicy{}
+	}
+	if override.IssuerName != "" && (existing.IssuerParameters == nil || pointer.Deref(existing.IssuerParameters.Name, "") != override.IssuerName) {
+		return false
+	}
+	if len(override.LifetimeActions) > 0 && (existing.LifetimeActions == nil || !reflect.DeepEqual(*existing.LifetimeActions, override.lifetimeActions())) {
+		return false
+	}
+	return true
+}
+
+func (p *CertificatePolicy) lifetimeActions() []keyvault.LifetimeAction {
+	actions := make([]keyvault.LifetimeAction, len(p.LifetimeActions))
+	for i, action := range p.LifetimeActions {
+		actions[i] = keyvault.LifetimeAction{
+			Trigger: &keyvault.Trigger{
+				DaysBeforeExpiry:   action.DaysBeforeExpiry,
+				LifetimePercentage: action.LifetimePercentage,
+			},
+			Action: &keyvault.Action{ActionType: keyvault.ActionType(action.Action)},
+		}
+	}
+	return actions
+}
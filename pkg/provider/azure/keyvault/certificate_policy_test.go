@@ -0,0 +1,179 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	pointer "k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault/fake"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestAzureKeyVaultPushCertificatePolicy(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	secret := &corev1.Secret{Data: map[string][]byte{"tls.crt": der}}
+
+	managed := map[string]*string{"managed-by": pointer.To(managerLabel)}
+	emailAction := keyvault.LifetimeAction{
+		Trigger: &keyvault.Trigger{LifetimePercentage: pointer.To(int32(80))},
+		Action:  &keyvault.Action{ActionType: keyvault.EmailContacts},
+	}
+	renewAction := keyvault.LifetimeAction{
+		Trigger: &keyvault.Trigger{DaysBeforeExpiry: pointer.To(int32(30))},
+		Action:  &keyvault.Action{ActionType: keyvault.AutoRenew},
+	}
+	existingPolicy := &keyvault.CertificatePolicy{
+		IssuerParameters: &keyvault.IssuerParameters{Name: pointer.To("Self"), CertificateType: pointer.To("OV-SSL")},
+		LifetimeActions:  &[]keyvault.LifetimeAction{emailAction},
+		KeyProperties:    &keyvault.KeyProperties{KeyType: pointer.To("RSA")},
+	}
+	renewMetadata := `{"certificatePolicy":{"lifetimeActions":[{"action":"AutoRenew","daysBeforeExpiry":30}]}}`
+
+	tests := []struct {
+		name       string
+		remoteKey  string
+		metadata   string
+		existing   keyvault.CertificateBundle
+		wantErr    string
+		wantPolicy *keyvault.CertificatePolicy
+		wantImport bool
+	}{
+		{
+			name:       "new certificate with import defaults",
+			remoteKey:  "cert/tls",
+			existing:   keyvault.CertificateBundle{Tags: managed},
+			wantImport: true,
+		},
+		{
+			name:      "existing policy is kept",
+			remoteKey: "cert/tls",
+			existing:  keyvault.CertificateBundle{Tags: managed, Policy: existingPolicy},
+			wantPolicy: &keyvault.CertificatePolicy{
+				IssuerParameters: existingPolicy.IssuerParameters,
+				LifetimeActions:  existingPolicy.LifetimeActions,
+			},
+			wantImport: true,
+		},
+		{
+			name:      "lifetime actions of the metadata override the existing ones",
+			remoteKey: "cert/tls",
+			metadata:  renewMetadata,
+			existing:  keyvault.CertificateBundle{Tags: managed, Policy: existingPolicy},
+			wantPolicy: &keyvault.CertificatePolicy{
+				IssuerParameters: existingPolicy.IssuerParameters,
+				LifetimeActions:  &[]keyvault.LifetimeAction{renewAction},
+			},
+			wantImport: true,
+		},
+		{
+			name:      "changed policy of an unchanged certificate",
+			remoteKey: "cert/tls",
+			metadata:  `{"certificatePolicy":{"issuerName":"Unknown"}}`,
+			existing:  keyvault.CertificateBundle{Cer: &der, Tags: managed, Policy: existingPolicy},
+			wantPolicy: &keyvault.CertificatePolicy{
+				IssuerParameters: &keyvault.IssuerParameters{Name: pointer.To("Unknown")},
+				LifetimeActions:  existingPolicy.LifetimeActions,
+			},
+			wantImport: true,
+		},
+		{
+			name:      "policy already applied",
+			remoteKey: "cert/tls",
+			metadata:  renewMetadata,
+			existing: keyvault.CertificateBundle{Cer: &der, Tags: managed, Policy: &keyvault.CertificatePolicy{
+				LifetimeActions: &[]keyvault.LifetimeAction{renewAction},
+			}},
+		},
+		{
+			name:      "policy of keys",
+			remoteKey: "key/tls",
+			metadata:  renewMetadata,
+			wantErr:   errCertificatePolicyObjectType,
+		},
+		{
+			name:      "unknown lifetime action",
+			remoteKey: "cert/tls",
+			metadata:  `{"certificatePolicy":{"lifetimeActions":[{"action":"Delete","daysBeforeExpiry":30}]}}`,
+			wantErr:   `unknown lifetime action "Delete", expected EmailContacts or AutoRenew`,
+		},
+		{
+			name:      "lifetime action with two triggers",
+			remoteKey: "cert/tls",
+			metadata:  `{"certificatePolicy":{"lifetimeActions":[{"action":"AutoRenew","daysBeforeExpiry":30,"lifetimePercentage":80}]}}`,
+			wantErr:   "lifetime action AutoRenew needs either daysBeforeExpiry or lifetimePercentage",
+		},
+		{
+			name:      "invalid lifetime percentage",
+			remoteKey: "cert/tls",
+			metadata:  `{"certificatePolicy":{"lifetimeActions":[{"action":"EmailContacts","lifetimePercentage":100}]}}`,
+			wantErr:   errLifetimePercentage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &fake.AzureMockClient{}
+			mockClient.WithCertificate("", "", "", tt.existing, nil)
+			var imported *keyvault.CertificateImportParameters
+			mockClient.WithImportCertificateFunc(func(_ context.Context, _, _ string, params keyvault.CertificateImportParameters) (keyvault.CertificateBundle, error) {
+				imported = &params
+				return keyvault.CertificateBundle{}, nil
+			})
+			az := &Azure{
+				provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+				baseClient: mockClient,
+			}
+			data := testingfake.PushSecretData{SecretKey: "tls.crt", RemoteKey: tt.remoteKey}
+			if tt.metadata != "" {
+				data.Metadata = &apiextensionsv1.JSON{Raw: []byte(tt.metadata)}
+			}
+			err := az.PushSecret(context.Background(), secret, data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if !tt.wantImport {
+				assert.Nil(t, imported)
+				return
+			}
+			require.NotNil(t, imported)
+			assert.Equal(t, tt.wantPolicy, imported.CertificatePolicy)
+		})
+	}
+}
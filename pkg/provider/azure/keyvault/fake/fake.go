@@ -25,6 +25,8 @@ type AzureMockClient struct {
 	getSecret          func(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (result keyvault.SecretBundle, err error)
 	getSecretsComplete func(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.SecretListResultIterator, err error)
 	getCertificate     func(ctx context.Context, vaultBaseURL string, certificateName string, certificateVersion string) (result keyvault.CertificateBundle, err error)
+	getCertificates    func(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.CertificateListResultIterator, err error)
+	getKeys            func(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.KeyListResultIterator, err error)
 	setSecret          func(ctx context.Context, vaultBaseURL string, secretName string, parameters keyvault.SecretSetParameters) (result keyvault.SecretBundle, err error)
 	importCertificate  func(ctx context.Context, vaultBaseURL string, certificateName string, parameters keyvault.CertificateImportParameters) (result keyvault.CertificateBundle, err error)
 	importKey          func(ctx context.Context, vaultBaseURL string, keyName string, parameters keyvault.KeyImportParameters) (result keyvault.KeyBundle, err error)
@@ -49,6 +51,14 @@ func (mc *AzureMockClient) GetSecretsComplete(ctx context.Context, vaultBaseURL
 	return mc.getSecretsComplete(ctx, vaultBaseURL, maxresults)
 }
 
+func (mc *AzureMockClient) GetCertificatesComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.CertificateListResultIterator, err error) {
+	return mc.getCertificates(ctx, vaultBaseURL, maxresults)
+}
+
+func (mc *AzureMockClient) GetKeysComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.KeyListResultIterator, err error) {
+	return mc.getKeys(ctx, vaultBaseURL, maxresults)
+}
+
 func (mc *AzureMockClient) SetSecret(ctx context.Context, vaultBaseURL, secretName string, parameters keyvault.SecretSetParameters) (keyvault.SecretBundle, error) {
 	return mc.setSecret(ctx, vaultBaseURL, secretName, parameters)
 }
@@ -105,6 +115,13 @@ func (mc *AzureMockClient) WithImportCertificate(apiOutput keyvault.CertificateB
 	}
 }
 
+// WithImportCertificateFunc calls fn for imported certificates, e.g. to check the import parameters.
+func (mc *AzureMockClient) WithImportCertificateFunc(fn func(ctx context.Context, vaultBaseURL, certificateName string, parameters keyvault.CertificateImportParameters) (keyvault.CertificateBundle, error)) {
+	if mc != nil {
+		mc.importCertificate = fn
+	}
+}
+
 func (mc *AzureMockClient) WithImportKey(output keyvault.KeyBundle, err error) {
 	if mc != nil {
 		mc.importKey = func(_ context.Context, _ string, _ string, _ keyvault.KeyImportParameters) (keyvault.KeyBundle, error) {
@@ -113,6 +130,13 @@ func (mc *AzureMockClient) WithImportKey(output keyvault.KeyBundle, err error) {
 	}
 }
 
+// WithImportKeyFunc calls fn for imported keys, e.g. to check the import parameters.
+func (mc *AzureMockClient) WithImportKeyFunc(fn func(ctx context.Context, vaultBaseURL, keyName string, parameters keyvault.KeyImportParameters) (keyvault.KeyBundle, error)) {
+	if mc != nil {
+		mc.importKey = fn
+	}
+}
+
 func (mc *AzureMockClient) WithSetSecret(output keyvault.SecretBundle, err error) {
 	if mc != nil {
 		mc.setSecret = func(_ context.Context, _, _ string, _ keyvault.SecretSetParameters) (keyvault.SecretBundle, error) {
@@ -152,3 +176,19 @@ func (mc *AzureMockClient) WithList(_ string, apiOutput keyvault.SecretListResul
 		}
 	}
 }
+
+func (mc *AzureMockClient) WithCertificateList(apiOutput keyvault.CertificateListResultIterator, err error) {
+	if mc != nil {
+		mc.getCertificates = func(_ context.Context, _ string, _ *int32) (keyvault.CertificateListResultIterator, error) {
+			return apiOutput, err
+		}
+	}
+}
+
+func (mc *AzureMockClient) WithKeyList(apiOutput keyvault.KeyListResultIterator, err error) {
+	if mc != nil {
+		mc.getKeys = func(_ context.Context, _ string, _ *int32) (keyvault.KeyListResultIterator, error) {
+			return apiOutput, err
+		}
+	}
+}
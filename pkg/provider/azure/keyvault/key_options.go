@@ -0,0 +1,150 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/profiles/latest/keyvault/keyvault"
+	"github.com/Azure/go-autorest/autorest/date"
+	pointer "k8s.io/utils/ptr"
+)
+
+const (
+	// KeyProtectionSoftware imports keys as software protected keys, the default of Key Vault.
+	KeyProtectionSoftware = "Software"
+	// KeyProtectionHSM imports keys as hardware (HSM) protected keys.
+	KeyProtectionHSM = "HSM"
+
+	hsmKeyTypeSuffix = "-HSM"
+
+	errKeyOptionsObjectType  = "keyOperations, keyAttributes and protection are only supported for keys"
+	errUnknownKeyOperation   = "unknown key operation %q, expected one of %v"
+	errUnknownKeyProtection  = "unknown key protection %q, expected %s or %s"
+	errKeyExpiresAndRotation = "keyAttributes.expires can't be combined with rotationPolicy"
+	errKeyNotBeforeExpires   = "keyAttributes.notBefore must be before keyAttributes.expires"
+)
+
+// KeyAttributes are the attributes set on the imported versions of a pushed key.
+type KeyAttributes struct {
+	// Enabled determines whether the key can be used, Key Vault enables keys by default.
+	Enabled *bool `json:"enabled,omitempty"`
+	// NotBefore is the time before which the key can't be used, in RFC 3339 format.
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	// Expires is the expiry time of the key, in RFC 3339 format.
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// hasKeyOptions returns true if the metadata configures the import of keys.
+func (m PushSecretMetadata) hasKeyOptions() bool {
+	return len(m.KeyOperations) > 0 || m.KeyAttributes != nil || m.Protection != ""
+}
+
+// validateKeyOptions checks the key operations, attributes and protection of the metadata.
+func (m PushSecretMetadata) validateKeyOptions() error {
+	for _, op := range m.KeyOperations {
+		if !slices.Contains(keyvault.PossibleJSONWebKeyOperationValues(), keyvault.JSONWebKeyOperation(op)) {
+			return fmt.Errorf(errUnknownKeyOperation, op, keyvault.PossibleJSONWebKeyOperationValues())
+		}
+	}
+	switch m.Protection {
+	case "", KeyProtectionSoftware, KeyProtectionHSM:
+	default:
+		return fmt.Errorf(errUnknownKeyProtection, m.Protection, KeyProtectionSoftware, KeyProtectionHSM)
+	}
+	attrs := m.KeyAttributes
+	if attrs == nil {
+		return nil
+	}
+	if attrs.Expires != nil && m.RotationPolicy != nil {
+		return errors.New(errKeyExpiresAndRotation)
+	}
+	if attrs.NotBefore != nil && attrs.Expires != nil && !attrs.NotBefore.Before(*attrs.Expires) {
+		return errors.New(errKeyNotBeforeExpires)
+	}
+	return nil
+}
+
+// applyKeyOptions sets the key operations, attributes and protection on the import parameters.
+func (m PushSecretMetadata) applyKeyOptions(params *keyvault.KeyImportParameters) {
+	if len(m.KeyOperations) > 0 {
+		ops := slices.Clone(m.KeyOperations)
+		params.Key.KeyOps = &ops
+	}
+	if m.Protection != "" {
+		params.Hsm = pointer.To(m.Protection == KeyProtectionHSM)
+	}
+	if attrs := m.KeyAttributes; attrs != nil {
+		params.KeyAttributes.Enabled = attrs.Enabled
+		if attrs.NotBefore != nil {
+			params.KeyAttributes.NotBefore = pointer.To(date.UnixTime(*attrs.NotBefore))
+		}
+		if attrs.Expires != nil {
+			params.KeyAttributes.Expires = pointer.To(date.UnixTime(*attrs.Expires))
+		}
+	}
+}
+
+// keyOptionsApplied returns false if the key in the vault doesn't match the configured
+// options, so a new version has to be imported although the key material didn't change.
+func (m PushSecretMetadata) keyOptionsApplied(bundle keyvault.KeyBundle) bool {
+	if bundle.Key == nil {
+		return false
+	}
+	if len(m.KeyOperations) > 0 {
+		if bundle.Key.KeyOps == nil || !sameElements(m.KeyOperations, *bundle.Key.KeyOps) {
+			return false
+		}
+	}
+	if m.Protection != "" && isHSMKey(bundle.Key.Kty) != (m.Protection == KeyProtectionHSM) {
+		return false
+	}
+	attrs := m.KeyAttributes
+	if attrs == nil {
+		return true
+	}
+	current := bundle.Attributes
+	if current == nil {
+		current = &keyvault.KeyAttributes{}
+	}
+	if attrs.Enabled != nil && (current.Enabled == nil || *current.Enabled != *attrs.Enabled) {
+		return false
+	}
+	return sameUnixTime(attrs.NotBefore, current.NotBefore) && sameUnixTime(attrs.Expires, current.Expires)
+}
+
+// isHSMKey returns true for the key types of hardware protected keys, e.g. RSA-HSM.
+func isHSMKey(kty keyvault.JSONWebKeyType) bool {
+	return strings.HasSuffix(string(kty), hsmKeyTypeSuffix)
+}
+
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// sameUnixTime compares a configured time with the time of the vault, an unset time always matches.
+func sameUnixTime(want *time.Time, got *date.UnixTime) bool {
+	if want == nil {
+		return true
+	}
+	return got != nil && time.Time(*got).Unix() == want.Unix()
+}
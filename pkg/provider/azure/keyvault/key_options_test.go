@@ -0,0 +1,153 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	pointer "k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault/fake"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestAzureKeyVaultPushKeyOptions(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		Data: map[string][]byte{"key": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})},
+	}
+	jwKey, err := jwk.FromRaw(privateKey)
+	require.NoError(t, err)
+	buf, err := json.Marshal(jwKey)
+	require.NoError(t, err)
+	var pushedKey keyvault.JSONWebKey
+	require.NoError(t, json.Unmarshal(buf, &pushedKey))
+
+	managed := map[string]*string{"managed-by": pointer.To(managerLabel)}
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	metadata := `{"keyOperations":["sign","verify"],"protection":"HSM",` +
+		`"keyAttributes":{"enabled":false,"notBefore":"2024-01-01T00:00:00Z","expires":"2025-01-01T00:00:00Z"}}`
+	hsmKey := pushedKey
+	hsmKey.Kty = keyvault.RSAHSM
+	hsmKey.KeyOps = &[]string{"verify", "sign"}
+	applied := &keyvault.KeyAttributes{
+		Enabled:   pointer.To(false),
+		NotBefore: pointer.To(date.UnixTime(notBefore)),
+		Expires:   pointer.To(date.UnixTime(expires)),
+	}
+
+	tests := []struct {
+		name       string
+		remoteKey  string
+		metadata   string
+		existing   keyvault.KeyBundle
+		wantErr    string
+		wantImport bool
+	}{
+		{
+			name:       "import with key options",
+			remoteKey:  "key/signing",
+			metadata:   metadata,
+			existing:   keyvault.KeyBundle{Tags: managed},
+			wantImport: true,
+		},
+		{
+			name:      "options already applied",
+			remoteKey: "key/signing",
+			metadata:  metadata,
+			existing:  keyvault.KeyBundle{Key: &hsmKey, Tags: managed, Attributes: applied},
+		},
+		{
+			name:       "changed options of an unchanged key",
+			remoteKey:  "key/signing",
+			metadata:   metadata,
+			existing:   keyvault.KeyBundle{Key: &pushedKey, Tags: managed, Attributes: applied},
+			wantImport: true,
+		},
+		{
+			name:      "options of secrets",
+			remoteKey: "signing",
+			metadata:  `{"protection":"HSM"}`,
+			wantErr:   errKeyOptionsObjectType,
+		},
+		{
+			name:      "unknown key operation",
+			remoteKey: "key/signing",
+			metadata:  `{"keyOperations":["export"]}`,
+			wantErr:   `unknown key operation "export", expected one of [decrypt encrypt sign unwrapKey verify wrapKey]`,
+		},
+		{
+			name:      "unknown protection",
+			remoteKey: "key/signing",
+			metadata:  `{"protection":"Cloud"}`,
+			wantErr:   `unknown key protection "Cloud", expected Software or HSM`,
+		},
+		{
+			name:      "expires with rotation policy",
+			remoteKey: "key/signing",
+			metadata:  `{"rotationPolicy":{"expiryTime":"P90D"},"keyAttributes":{"expires":"2025-01-01T00:00:00Z"}}`,
+			wantErr:   errKeyExpiresAndRotation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &fake.AzureMockClient{}
+			mockClient.WithKey("", "", "", tt.existing, nil)
+			var imported *keyvault.KeyImportParameters
+			mockClient.WithImportKeyFunc(func(_ context.Context, _, _ string, params keyvault.KeyImportParameters) (keyvault.KeyBundle, error) {
+				imported = &params
+				return keyvault.KeyBundle{}, nil
+			})
+			az := &Azure{
+				provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+				baseClient: mockClient,
+			}
+			data := testingfake.PushSecretData{SecretKey: "key", RemoteKey: tt.remoteKey, Metadata: &apiextensionsv1.JSON{Raw: []byte(tt.metadata)}}
+			err := az.PushSecret(context.Background(), secret, data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if !tt.wantImport {
+				assert.Nil(t, imported)
+				return
+			}
+			require.NotNil(t, imported)
+			assert.Equal(t, &[]string{"sign", "verify"}, imported.Key.KeyOps)
+			assert.Equal(t, pointer.To(true), imported.Hsm)
+			assert.Equal(t, applied, imported.KeyAttributes)
+		})
+	}
+}
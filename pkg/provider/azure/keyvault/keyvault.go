@@ -22,16 +22,19 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/keyvault/keyvault"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	kvauth "github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/confidential"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/tidwall/gjson"
@@ -49,6 +52,7 @@ import (
 	gopkcs12 "software.sslmate.com/src/go-pkcs12"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	"github.com/external-secrets/external-secrets/pkg/constants"
 	"github.com/external-secrets/external-secrets/pkg/metrics"
 	"github.com/external-secrets/external-secrets/pkg/utils"
@@ -64,7 +68,10 @@ const (
 	AnnotationTenantID   = "azure.workload.identity/tenant-id"
 	managerLabel         = "external-secrets"
 
+	recommendedMinRefreshInterval = time.Minute
+
 	errUnexpectedStoreSpec      = "unexpected store spec"
+	errNoOwner                  = "unable to list managed secrets: the store has no UID"
 	errMissingAuthType          = "cannot initialize Azure Client: no valid authType was specified"
 	errPropNotExist             = "property %s does not exist in key %s"
 	errTagNotExist              = "tag %s does not exist"
@@ -82,20 +89,47 @@ const (
 	errFindSecret               = "could not find secret %s/%s: %w"
 	errFindDataKey              = "no data for %q in secret '%s/%s'"
 
-	errInvalidStore                   = "invalid store"
-	errInvalidStoreSpec               = "invalid store spec"
-	errInvalidStoreProv               = "invalid store provider"
-	errInvalidAzureProv               = "invalid azure keyvault provider"
-	errInvalidSecRefClientID          = "invalid AuthSecretRef.ClientID: %w"
-	errInvalidSecRefClientSecret      = "invalid AuthSecretRef.ClientSecret: %w"
-	errInvalidSecRefClientCertificate = "invalid AuthSecretRef.ClientCertificate: %w"
-	errInvalidSARef                   = "invalid ServiceAccountRef: %w"
+	errInvalidStore                           = "invalid store"
+	errInvalidStoreSpec                       = "invalid store spec"
+	errInvalidStoreProv                       = "invalid store provider"
+	errInvalidAzureProv                       = "invalid azure keyvault provider"
+	errInvalidSecRefClientID                  = "invalid AuthSecretRef.ClientID: %w"
+	errInvalidSecRefClientSecret              = "invalid AuthSecretRef.ClientSecret: %w"
+	errInvalidSecRefClientCertificate         = "invalid AuthSecretRef.ClientCertificate: %w"
+	errInvalidSecRefClientCertificatePassword = "invalid AuthSecretRef.ClientCertificatePassword: %w"
+	errInvalidSARef                           = "invalid ServiceAccountRef: %w"
 
 	errMissingWorkloadEnvVars = "missing environment variables. AZURE_CLIENT_ID, AZURE_TENANT_ID and AZURE_FEDERATED_TOKEN_FILE must be set"
 	errReadTokenFile          = "unable to read token file %s: %w"
 	errMissingSAAnnotation    = "missing service account annotation: %s"
+
+	errDecodePushSecretMetadata = "failed to decode PushSecret metadata: %w"
+	errPKCS12PasswordObjectType = "pkcs12Password is only supported for certificates"
+	errPKCS12Password           = "invalid pkcs12Password: %w"
+	errDecodePKCS12             = "could not decode PKCS#12 bundle with password: %w"
+	errEncodePKCS12             = "could not encode PKCS#12 bundle: %w"
 )
 
+// PushSecretMetadata is the metadata of a PushSecret entry pushed to Azure Key Vault.
+type PushSecretMetadata struct {
+	// PKCS12Password references the password of a password-protected PKCS#12 certificate bundle.
+	// The secret is always read from the namespace of the PushSecret.
+	PKCS12Password *esmeta.SecretKeySelector `json:"pkcs12Password,omitempty"`
+	// RotationPolicy is applied to pushed keys, the expiry time is also set on imported key versions.
+	RotationPolicy *KeyRotationPolicy `json:"rotationPolicy,omitempty"`
+	// Strategy defines how a whole Secret is pushed: JSON encoded as a single secret by default,
+	// or with SplitKeys as a secret per key named <remoteKey>-<secretKey>.
+	Strategy string `json:"strategy,omitempty"`
+	// KeyOperations are the operations allowed with pushed keys, e.g. sign, verify, wrapKey.
+	KeyOperations []string `json:"keyOperations,omitempty"`
+	// KeyAttributes are set on the imported versions of pushed keys.
+	KeyAttributes *KeyAttributes `json:"keyAttributes,omitempty"`
+	// Protection of pushed keys, Software or HSM. Defaults to the Key Vault default, Software.
+	Protection string `json:"protection,omitempty"`
+	// CertificatePolicy overrides the issuer and lifetime actions of pushed certificates.
+	CertificatePolicy *CertificatePolicy `json:"certificatePolicy,omitempty"`
+}
+
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &Azure{}
 var _ esv1beta1.Provider = &Azure{}
@@ -106,6 +140,8 @@ type SecretClient interface {
 	GetSecret(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (result keyvault.SecretBundle, err error)
 	GetSecretsComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.SecretListResultIterator, err error)
 	GetCertificate(ctx context.Context, vaultBaseURL string, certificateName string, certificateVersion string) (result keyvault.CertificateBundle, err error)
+	GetCertificatesComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.CertificateListResultIterator, err error)
+	GetKeysComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.KeyListResultIterator, err error)
 	SetSecret(ctx context.Context, vaultBaseURL string, secretName string, parameters keyvault.SecretSetParameters) (result keyvault.SecretBundle, err error)
 	ImportKey(ctx context.Context, vaultBaseURL string, keyName string, parameters keyvault.KeyImportParameters) (result keyvault.KeyBundle, err error)
 	ImportCertificate(ctx context.Context, vaultBaseURL string, certificateName string, parameters keyvault.CertificateImportParameters) (result keyvault.CertificateBundle, err error)
@@ -115,12 +151,18 @@ type SecretClient interface {
 }
 
 type Azure struct {
-	crClient   client.Client
-	kubeClient kcorev1.CoreV1Interface
-	store      esv1beta1.GenericStore
-	provider   *esv1beta1.AzureKVProvider
-	baseClient SecretClient
-	namespace  string
+	crClient       client.Client
+	kubeClient     kcorev1.CoreV1Interface
+	store          esv1beta1.GenericStore
+	provider       *esv1beta1.AzureKVProvider
+	baseClient     SecretClient
+	rotationClient KeyRotationPolicyClient
+	namespace      string
+
+	// expirations of the keys read or pushed by the client
+	expirations []esv1beta1.SecretExpiration
+	// creation and expiry times of the objects read by the client
+	lifetimes []esv1beta1.SecretLifetime
 }
 
 func init() {
@@ -134,6 +176,12 @@ func (a *Azure) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// RecommendedMinRefreshInterval returns the shortest recommended refresh interval.
+// Key Vault throttles requests per vault, so short intervals quickly exhaust the limit.
+func (a *Azure) RecommendedMinRefreshInterval() time.Duration {
+	return recommendedMinRefreshInterval
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (a *Azure) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	return newClient(ctx, store, kube, namespace)
@@ -182,11 +230,29 @@ func newClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Cl
 
 	cl := keyvault.New()
 	cl.Authorizer = authorizer
+	cl.Sender = autorest.DecorateSender(autorest.CreateSender(),
+		withQuotaObserver(metrics.NewQuotaObserver(constants.ProviderAzureKV, store.GetKind(), store.GetNamespace(), store.GetName())))
+	// throttled vaults are paused by the circuit breaker instead of retried
+	cl.SendDecorators = sendDecorators(cl.RetryAttempts, cl.RetryDuration)
 	az.baseClient = &cl
+	az.rotationClient = &rotationPolicyClient{Client: cl.Client}
 
 	return az, err
 }
 
+// withQuotaObserver records the x-ms-ratelimit-remaining-* headers of the responses.
+func withQuotaObserver(quota *metrics.QuotaObserver) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			resp, err := s.Do(r)
+			if err == nil {
+				quota.ObserveResponse(resp)
+			}
+			return resp, err
+		})
+	}
+}
+
 func getProvider(store esv1beta1.GenericStore) (*esv1beta1.AzureKVProvider, error) {
 	spc := store.GetSpec()
 	if spc == nil || spc.Provider.AzureKV == nil {
@@ -222,6 +288,16 @@ func (a *Azure) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings,
 				return nil, fmt.Errorf(errInvalidSecRefClientSecret, err)
 			}
 		}
+		if p.AuthSecretRef.ClientCertificate != nil {
+			if err := utils.ValidateReferentSecretSelector(store, *p.AuthSecretRef.ClientCertificate); err != nil {
+				return nil, fmt.Errorf(errInvalidSecRefClientCertificate, err)
+			}
+		}
+		if p.AuthSecretRef.ClientCertificatePassword != nil {
+			if err := utils.ValidateReferentSecretSelector(store, *p.AuthSecretRef.ClientCertificatePassword); err != nil {
+				return nil, fmt.Errorf(errInvalidSecRefClientCertificatePassword, err)
+			}
+		}
 	}
 	if p.ServiceAccountRef != nil {
 		if err := utils.ValidateReferentServiceAccountSelector(store, *p.ServiceAccountRef); err != nil {
@@ -270,7 +346,12 @@ func (a *Azure) deleteKeyVaultKey(ctx context.Context, keyName string) error {
 func (a *Azure) deleteKeyVaultSecret(ctx context.Context, secretName string) error {
 	value, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, "")
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
-	ok, err := canDelete(value.Tags, err)
+	return a.deleteKeyVaultSecretBundle(ctx, secretName, value.Tags, err)
+}
+
+// deleteKeyVaultSecretBundle deletes the secret if the tags returned by getting it mark it as managed.
+func (a *Azure) deleteKeyVaultSecretBundle(ctx context.Context, secretName string, tags map[string]*string, err error) error {
+	ok, err := canDelete(tags, err)
 	if err != nil {
 		return fmt.Errorf("error getting secret %v: %w", secretName, err)
 	}
@@ -305,7 +386,7 @@ func (a *Azure) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecret
 	objectType, secretName := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: remoteRef.GetRemoteKey()})
 	switch objectType {
 	case defaultObjType:
-		return a.deleteKeyVaultSecret(ctx, secretName)
+		return a.deleteSecretOrSplitKeys(ctx, secretName)
 	case objectTypeCert:
 		return a.deleteKeyVaultCertificate(ctx, secretName)
 	case objectTypeKey:
@@ -343,6 +424,35 @@ func (a *Azure) SecretExists(ctx context.Context, remoteRef esv1beta1.PushSecret
 	return true, nil
 }
 
+func parsePushSecretMetadata(data esv1beta1.PushSecretData) (PushSecretMetadata, error) {
+	var metadata PushSecretMetadata
+	if data.GetMetadata() == nil {
+		return metadata, nil
+	}
+	if err := json.Unmarshal(data.GetMetadata().Raw, &metadata); err != nil {
+		return metadata, fmt.Errorf(errDecodePushSecretMetadata, err)
+	}
+	return metadata, nil
+}
+
+// decryptPKCS12 decodes a password-protected PKCS#12 bundle and encodes it again without password,
+// so it can be imported like any other certificate. The password is read from the namespace of the PushSecret.
+func (a *Azure) decryptPKCS12(ctx context.Context, namespace string, ref *esmeta.SecretKeySelector, value []byte) ([]byte, error) {
+	password, err := resolvers.SecretKeyRef(ctx, a.crClient, esv1beta1.SecretStoreKind, namespace, ref)
+	if err != nil {
+		return nil, fmt.Errorf(errPKCS12Password, err)
+	}
+	key, cert, caCerts, err := gopkcs12.DecodeChain(value, password)
+	if err != nil {
+		return nil, fmt.Errorf(errDecodePKCS12, err)
+	}
+	decrypted, err := gopkcs12.Passwordless.Encode(key, cert, caCerts, "")
+	if err != nil {
+		return nil, fmt.Errorf(errEncodePKCS12, err)
+	}
+	return decrypted, nil
+}
+
 func getCertificateFromValue(value []byte) (*x509.Certificate, error) {
 	// 1st: try decode pkcs12
 	_, localCert, err := gopkcs12.Decode(value, "")
@@ -409,7 +519,37 @@ func canCreate(tags map[string]*string, err error) (bool, error) {
 	return true, nil
 }
 
-func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value []byte) error {
+// owner returns the UID of the store, tagged on pushed objects to tell which objects it manages.
+func (a *Azure) owner() string {
+	if a.store == nil {
+		return ""
+	}
+	return string(a.store.GetUID())
+}
+
+// managedTags returns the tags of a pushed object, the object is owned by the store pushing it.
+// An object pushed by a client without store keeps its owner.
+func (a *Azure) managedTags(existing map[string]*string) map[string]*string {
+	tags := map[string]*string{
+		"managed-by": pointer.To(managerLabel),
+	}
+	if owner := a.owner(); owner != "" {
+		tags[esv1beta1.ManagedSecretOwnerTag] = pointer.To(owner)
+	} else if owner, ok := existing[esv1beta1.ManagedSecretOwnerTag]; ok && owner != nil {
+		tags[esv1beta1.ManagedSecretOwnerTag] = owner
+	}
+	return tags
+}
+
+// ownsObject tells whether the existing object is tagged with the store, an unchanged object
+// owned by another store or pushed before objects were tagged is pushed again to move it to the store.
+func (a *Azure) ownsObject(tags map[string]*string) bool {
+	owner := a.owner()
+	return owner == "" || (tags[esv1beta1.ManagedSecretOwnerTag] != nil && *tags[esv1beta1.ManagedSecretOwnerTag] == owner)
+}
+
+// setKeyVaultSecret sets the value of the secret, tagged as managed by external-secrets and with the additional tags.
+func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value []byte, tags map[string]*string) error {
 	secret, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, "")
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
 	ok, err := canCreate(secret.Tags, err)
@@ -420,27 +560,32 @@ func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value
 		return nil
 	}
 	val := string(value)
-	if secret.Value != nil && val == *secret.Value {
+	secretTags := a.managedTags(secret.Tags)
+	if secret.Value != nil && val == *secret.Value && a.ownsObject(secret.Tags) {
 		return nil
 	}
+	for k, v := range tags {
+		secretTags[k] = v
+	}
 	secretParams := keyvault.SecretSetParameters{
 		Value: &val,
-		Tags: map[string]*string{
-			"managed-by": pointer.To(managerLabel),
-		},
+		Tags:  secretTags,
 		SecretAttributes: &keyvault.SecretAttributes{
 			Enabled: pointer.To(true),
 		},
 	}
-	_, err = a.baseClient.SetSecret(ctx, *a.provider.VaultURL, secretName, secretParams)
-	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	err = retryWhileBeingDeleted(ctx, defaultObjType, secretName, func() error {
+		_, err := a.baseClient.SetSecret(ctx, *a.provider.VaultURL, secretName, secretParams)
+		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("could not set secret %v: %w", secretName, err)
 	}
 	return nil
 }
 
-func (a *Azure) setKeyVaultCertificate(ctx context.Context, secretName string, value []byte) error {
+func (a *Azure) setKeyVaultCertificate(ctx context.Context, secretName string, value []byte, metadata PushSecretMetadata) error {
 	val := b64.StdEncoding.EncodeToString(value)
 	localCert, err := getCertificateFromValue(value)
 	if err != nil {
@@ -456,17 +601,20 @@ func (a *Azure) setKeyVaultCertificate(ctx context.Context, secretName string, v
 		return nil
 	}
 	b512 := sha3.Sum512(localCert.Raw)
-	if cert.Cer != nil && b512 == sha3.Sum512(*cert.Cer) {
+	if cert.Cer != nil && b512 == sha3.Sum512(*cert.Cer) && metadata.certificatePolicyApplied(cert.Policy) && a.ownsObject(cert.Tags) {
 		return nil
 	}
 	params := keyvault.CertificateImportParameters{
 		Base64EncodedCertificate: &val,
-		Tags: map[string]*string{
-			"managed-by": pointer.To(managerLabel),
-		},
-	}
-	_, err = a.baseClient.ImportCertificate(ctx, *a.provider.VaultURL, secretName, params)
-	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVImportCertificate, err)
+		// keep the policy of an existing certificate instead of resetting it to the import defaults
+		CertificatePolicy: metadata.certificatePolicy(cert.Policy),
+		Tags:              a.managedTags(cert.Tags),
+	}
+	err = retryWhileBeingDeleted(ctx, objectTypeCert, secretName, func() error {
+		_, err := a.baseClient.ImportCertificate(ctx, *a.provider.VaultURL, secretName, params)
+		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVImportCertificate, err)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("could not import certificate %v: %w", secretName, err)
 	}
@@ -482,9 +630,12 @@ func equalKeys(newKey, oldKey keyvault.JSONWebKey) bool {
 		newKey.X != nil && oldKey.X != nil && *newKey.X == *oldKey.X &&
 		newKey.Y != nil && oldKey.Y != nil && *newKey.Y == *oldKey.Y
 
-	return newKey.Kty == oldKey.Kty && (rsaCheck || symmetricCheck)
+	// the key type of the vault tells whether the key is HSM protected, e.g. RSA-HSM
+	sameType := strings.TrimSuffix(string(newKey.Kty), hsmKeyTypeSuffix) == strings.TrimSuffix(string(oldKey.Kty), hsmKeyTypeSuffix)
+	return sameType && (rsaCheck || symmetricCheck)
 }
-func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []byte) error {
+func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []byte, metadata PushSecretMetadata) error {
+	policy := metadata.RotationPolicy
 	key, err := getKeyFromValue(value)
 	if err != nil {
 		return fmt.Errorf("could not load private key %v: %w", secretName, err)
@@ -511,39 +662,96 @@ func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []b
 	if !ok {
 		return nil
 	}
-	if keyFromVault.Key != nil && equalKeys(azkey, *keyFromVault.Key) {
-		return nil
+	if keyFromVault.Key != nil && equalKeys(azkey, *keyFromVault.Key) && metadata.keyOptionsApplied(keyFromVault) && a.ownsObject(keyFromVault.Tags) {
+		if policy == nil {
+			return nil
+		}
+		if keyFromVault.Attributes != nil && keyFromVault.Attributes.Expires != nil {
+			a.reportExpiry(objectTypeKey+"/"+secretName, time.Time(*keyFromVault.Attributes.Expires), policy.notifyBeforeExpiry())
+		}
+		return a.setKeyRotationPolicy(ctx, secretName, policy)
 	}
 	params := keyvault.KeyImportParameters{
 		Key:           &azkey,
 		KeyAttributes: &keyvault.KeyAttributes{},
-		Tags: map[string]*string{
-			"managed-by": pointer.To(managerLabel),
-		},
-	}
-	_, err = a.baseClient.ImportKey(ctx, *a.provider.VaultURL, secretName, params)
-	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVImportKey, err)
+		Tags:          a.managedTags(keyFromVault.Tags),
+	}
+	var expires time.Time
+	if policy != nil {
+		expires = policy.expires(time.Now())
+		params.KeyAttributes.Expires = pointer.To(date.UnixTime(expires))
+	}
+	metadata.applyKeyOptions(&params)
+	err = retryWhileBeingDeleted(ctx, objectTypeKey, secretName, func() error {
+		_, err := a.baseClient.ImportKey(ctx, *a.provider.VaultURL, secretName, params)
+		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVImportKey, err)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("could not import key %v: %w", secretName, err)
 	}
-	return nil
+	if policy == nil {
+		return nil
+	}
+	a.reportExpiry(objectTypeKey+"/"+secretName, expires, policy.notifyBeforeExpiry())
+	return a.setKeyRotationPolicy(ctx, secretName, policy)
 }
 
 // PushSecret stores secrets into a Key vault instance.
 func (a *Azure) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	metadata, err := parsePushSecretMetadata(data)
+	if err != nil {
+		return err
+	}
+	if err := validateStrategy(metadata, data); err != nil {
+		return err
+	}
 	if data.GetSecretKey() == "" {
-		return fmt.Errorf("pushing the whole secret is not yet implemented")
+		return a.pushWholeSecret(ctx, secret, data.GetRemoteKey(), metadata)
 	}
 
 	objectType, secretName := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: data.GetRemoteKey()})
 	value := secret.Data[data.GetSecretKey()]
+	if metadata.RotationPolicy != nil {
+		if objectType != objectTypeKey {
+			return errors.New(errRotationPolicyObjectType)
+		}
+		if err := metadata.RotationPolicy.validate(); err != nil {
+			return err
+		}
+	}
+	if metadata.hasKeyOptions() {
+		if objectType != objectTypeKey {
+			return errors.New(errKeyOptionsObjectType)
+		}
+		if err := metadata.validateKeyOptions(); err != nil {
+			return err
+		}
+	}
+	if metadata.CertificatePolicy != nil {
+		if objectType != objectTypeCert {
+			return errors.New(errCertificatePolicyObjectType)
+		}
+		if err := metadata.CertificatePolicy.validate(); err != nil {
+			return err
+		}
+	}
+	if metadata.PKCS12Password != nil {
+		if objectType != objectTypeCert {
+			return errors.New(errPKCS12PasswordObjectType)
+		}
+		value, err = a.decryptPKCS12(ctx, secret.Namespace, metadata.PKCS12Password, value)
+		if err != nil {
+			return err
+		}
+	}
 	switch objectType {
 	case defaultObjType:
-		return a.setKeyVaultSecret(ctx, secretName, value)
+		return a.setKeyVaultSecret(ctx, secretName, value, nil)
 	case objectTypeCert:
-		return a.setKeyVaultCertificate(ctx, secretName, value)
+		return a.setKeyVaultCertificate(ctx, secretName, value, metadata)
 	case objectTypeKey:
-		return a.setKeyVaultKey(ctx, secretName, value)
+		return a.setKeyVaultKey(ctx, secretName, value, metadata)
 	default:
 		return fmt.Errorf("secret type %v not supported", objectType)
 	}
@@ -551,7 +759,17 @@ func (a *Azure) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1
 
 // Implements store.Client.GetAllSecrets Interface.
 // Retrieves a map[string][]byte with the secret names as key and the secret itself as the calue.
+// With a find.path starting with cert/ or key/ the certificates or keys are retrieved instead of the secrets.
 func (a *Azure) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Path != nil {
+		objectType, prefix := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: *ref.Path})
+		switch objectType {
+		case objectTypeCert:
+			return a.getAllCertificates(ctx, ref, prefix)
+		case objectTypeKey:
+			return a.getAllKeys(ctx, ref, prefix)
+		}
+	}
 	basicClient := a.baseClient
 	secretsMap := make(map[string][]byte)
 	checkTags := len(ref.Tags) > 0
@@ -581,6 +799,9 @@ func (a *Azure) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretF
 			return nil, err
 		}
 
+		if secretResp.Attributes != nil {
+			a.reportLifetime(secretName, secretResp.Attributes.Created, secretResp.Attributes.Expires)
+		}
 		secretValue := *secretResp.Value
 		secretsMap[secretName] = []byte(secretValue)
 
@@ -592,6 +813,56 @@ func (a *Azure) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretF
 	return secretsMap, nil
 }
 
+// ListManagedSecrets returns the names of the secrets tagged with managed-by=external-secrets
+// that have been pushed through the store, other stores may manage secrets in the same vault.
+// Keys and certificates are not listed, secrets backing a certificate are skipped.
+func (a *Azure) ListManagedSecrets(ctx context.Context) ([]string, error) {
+	owner := a.owner()
+	if owner == "" {
+		return nil, errors.New(errNoOwner)
+	}
+	secretListIter, err := a.baseClient.GetSecretsComplete(ctx, *a.provider.VaultURL, nil)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecrets, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	seen := make(map[string]struct{})
+	for secretListIter.NotDone() {
+		secret := secretListIter.Value()
+		manager, ok := secret.Tags["managed-by"]
+		isManaged := secret.Managed != nil && *secret.Managed
+		isOwned := secret.Tags[esv1beta1.ManagedSecretOwnerTag] != nil && *secret.Tags[esv1beta1.ManagedSecretOwnerTag] == owner
+		if secret.ID != nil && !isManaged && isOwned && ok && manager != nil && *manager == managerLabel {
+			name := path.Base(*secret.ID)
+			// secrets split from a whole Secret are listed by the remote key they were pushed to
+			if from, ok := secret.Tags[splitFromTag]; ok && from != nil {
+				name = *from
+			}
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+		err = secretListIter.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// ManagedSecretKey strips the default object type from the remote key, keys and certificates keep the prefix.
+func (a *Azure) ManagedSecretKey(remoteKey string) string {
+	objectType, secretName := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: remoteKey})
+	if objectType != defaultObjType {
+		return remoteKey
+	}
+	return secretName
+}
+
 // Retrieves a tag value if specified and all tags in JSON format if not.
 func getSecretTag(tags map[string]*string, property string) ([]byte, error) {
 	if property == "" {
@@ -666,6 +937,9 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataR
 		if err != nil {
 			return nil, err
 		}
+		if secretResp.Attributes != nil {
+			a.reportLifetime(ref.Key, secretResp.Attributes.Created, secretResp.Attributes.Expires)
+		}
 		if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
 			return getSecretTag(secretResp.Tags, ref.Property)
 		}
@@ -679,6 +953,9 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataR
 		if err != nil {
 			return nil, err
 		}
+		if certResp.Attributes != nil {
+			a.reportLifetime(ref.Key, certResp.Attributes.Created, certResp.Attributes.Expires)
+		}
 		if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
 			return getSecretTag(certResp.Tags, ref.Property)
 		}
@@ -693,6 +970,12 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataR
 		if err != nil {
 			return nil, err
 		}
+		if keyResp.Attributes != nil {
+			a.reportLifetime(ref.Key, keyResp.Attributes.Created, keyResp.Attributes.Expires)
+			if keyResp.Attributes.Expires != nil {
+				a.reportExpiry(ref.Key, time.Time(*keyResp.Attributes.Expires), defaultExpiryNotification)
+			}
+		}
 		if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
 			return getSecretTag(keyResp.Tags, ref.Property)
 		}
@@ -702,6 +985,26 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataR
 	return nil, fmt.Errorf(errUnknownObjectType, secretName)
 }
 
+// reportLifetime records the creation and expiry time of an object read by the client.
+func (a *Azure) reportLifetime(key string, created, expires *date.UnixTime) {
+	if created == nil && expires == nil {
+		return
+	}
+	lifetime := esv1beta1.SecretLifetime{Key: key}
+	if created != nil {
+		lifetime.Created = time.Time(*created)
+	}
+	if expires != nil {
+		lifetime.Expires = time.Time(*expires)
+	}
+	a.lifetimes = append(a.lifetimes, lifetime)
+}
+
+// SecretLifetimes returns the creation and expiry times of the objects read by the client.
+func (a *Azure) SecretLifetimes() []esv1beta1.SecretLifetime {
+	return a.lifetimes
+}
+
 // returns a SecretBundle with the tags values.
 func (a *Azure) getSecretTags(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string]*string, error) {
 	_, secretName := getObjType(ref)
@@ -1033,9 +1336,23 @@ func (a *Azure) getAuthorizerFromCredentials(ctx context.Context) (autorest.Auth
 			return nil, err
 		}
 
+		var clientCertificatePassword string
+		if a.provider.AuthSecretRef.ClientCertificatePassword != nil {
+			clientCertificatePassword, err = resolvers.SecretKeyRef(
+				ctx,
+				a.crClient,
+				a.store.GetKind(),
+				a.namespace, a.provider.AuthSecretRef.ClientCertificatePassword,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		return getAuthorizerForClientCertificate(
 			clientID,
 			[]byte(clientCertificate),
+			clientCertificatePassword,
 			*a.provider.TenantID,
 			a.provider.EnvironmentType,
 		)
@@ -1049,8 +1366,9 @@ func getAuthorizerForClientSecret(clientID, clientSecret, tenantID string, envir
 	return clientCredentialsConfig.Authorizer()
 }
 
-func getAuthorizerForClientCertificate(clientID string, certificateBytes []byte, tenantID string, environmentType esv1beta1.AzureEnvironmentType) (autorest.Authorizer, error) {
+func getAuthorizerForClientCertificate(clientID string, certificateBytes []byte, password, tenantID string, environmentType esv1beta1.AzureEnvironmentType) (autorest.Authorizer, error) {
 	clientCertificateConfig := NewClientInMemoryCertificateConfig(clientID, certificateBytes, tenantID)
+	clientCertificateConfig.Password = password
 	clientCertificateConfig.Resource = kvResourceForProviderConfig(environmentType)
 	clientCertificateConfig.AADEndpoint = AadEndpointForType(environmentType)
 	return clientCertificateConfig.Authorizer()
@@ -1147,17 +1465,29 @@ func isValidSecret(checkTags, checkName bool, ref esv1beta1.ExternalSecretFind,
 	if secret.ID == nil || !*secret.Attributes.Enabled {
 		return false, ""
 	}
+	var prefix string
+	if ref.Path != nil {
+		prefix = *ref.Path
+	}
+	return isValidObject(checkTags, checkName, ref, *secret.ID, secret.Tags, prefix)
+}
 
-	if checkTags && !okByTags(ref, secret) {
+// isValidObject tells whether the secret, certificate or key with the given ID matches the find criteria,
+// its name must start with the prefix. It returns the name of the object.
+func isValidObject(checkTags, checkName bool, ref esv1beta1.ExternalSecretFind, id string, tags map[string]*string, prefix string) (bool, string) {
+	if checkTags && !okByTags(ref, tags) {
 		return false, ""
 	}
 
-	secretName := path.Base(*secret.ID)
-	if checkName && !okByName(ref, secretName) {
+	name := path.Base(id)
+	if !strings.HasPrefix(name, prefix) {
+		return false, ""
+	}
+	if checkName && !okByName(ref, name) {
 		return false, ""
 	}
 
-	return true, secretName
+	return true, name
 }
 
 func okByName(ref esv1beta1.ExternalSecretFind, secretName string) bool {
@@ -1165,10 +1495,10 @@ func okByName(ref esv1beta1.ExternalSecretFind, secretName string) bool {
 	return matches
 }
 
-func okByTags(ref esv1beta1.ExternalSecretFind, secret keyvault.SecretItem) bool {
+func okByTags(ref esv1beta1.ExternalSecretFind, tags map[string]*string) bool {
 	tagsFound := true
 	for k, v := range ref.Tags {
-		if val, ok := secret.Tags[k]; !ok || *val != v {
+		if val, ok := tags[k]; !ok || *val != v {
 			tagsFound = false
 			break
 		}
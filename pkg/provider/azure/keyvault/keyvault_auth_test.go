@@ -16,19 +16,29 @@ package keyvault
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/golang-jwt/jwt/v5"
 	tassert "github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pointer "k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	v1 "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -367,6 +377,7 @@ func TestAuth(t *testing.T) {
 		},
 	}
 	authType := esv1beta1.AzureServicePrincipal
+	pfx := mockPKCS12(t, "pfx-password")
 
 	type testCase struct {
 		name     string
@@ -546,6 +557,65 @@ func TestAuth(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "correct configuration with pkcs12 certificate authentication",
+			objects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "password",
+					Namespace: "foo",
+				},
+				Data: map[string][]byte{
+					"id":          []byte("foo"),
+					"certificate": pfx,
+					"pfxpassword": []byte("pfx-password"),
+				},
+			}},
+			store: &esv1beta1.ClusterSecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: esv1beta1.ClusterSecretStoreKind,
+				},
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{}},
+			},
+			provider: &esv1beta1.AzureKVProvider{
+				AuthType: &authType,
+				VaultURL: &vaultURL,
+				TenantID: pointer.To("mytenant"),
+				AuthSecretRef: &esv1beta1.AzureKVAuth{
+					ClientID:                  &v1.SecretKeySelector{Name: "password", Namespace: pointer.To("foo"), Key: "id"},
+					ClientCertificate:         &v1.SecretKeySelector{Name: "password", Namespace: pointer.To("foo"), Key: "certificate"},
+					ClientCertificatePassword: &v1.SecretKeySelector{Name: "password", Namespace: pointer.To("foo"), Key: "pfxpassword"},
+				},
+			},
+		},
+		{
+			name:   "bad config: wrong pkcs12 password",
+			expErr: "failed to get oauth token from certificate auth: failed to decode certificate: failed to decode PKCS#12 archive: pkcs12: decryption password incorrect",
+			objects: []client.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "password",
+					Namespace: "foo",
+				},
+				Data: map[string][]byte{
+					"id":          []byte("foo"),
+					"certificate": pfx,
+				},
+			}},
+			store: &esv1beta1.ClusterSecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: esv1beta1.ClusterSecretStoreKind,
+				},
+				Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{}},
+			},
+			provider: &esv1beta1.AzureKVProvider{
+				AuthType: &authType,
+				VaultURL: &vaultURL,
+				TenantID: pointer.To("mytenant"),
+				AuthSecretRef: &esv1beta1.AzureKVAuth{
+					ClientID:          &v1.SecretKeySelector{Name: "password", Namespace: pointer.To("foo"), Key: "id"},
+					ClientCertificate: &v1.SecretKeySelector{Name: "password", Namespace: pointer.To("foo"), Key: "certificate"},
+				},
+			},
+		},
 	} {
 		t.Run(row.name, func(t *testing.T) {
 			k8sClient := clientfake.NewClientBuilder().WithObjects(row.objects...).Build()
@@ -568,6 +638,78 @@ func TestAuth(t *testing.T) {
 	}
 }
 
+func TestCertificateChainAssertion(t *testing.T) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	tassert.Nil(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	tassert.Nil(t, err)
+	ca, _ := x509.ParseCertificate(caDER)
+
+	leafKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	tassert.Nil(t, err)
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	tassert.Nil(t, err)
+	leaf, _ := x509.ParseCertificate(leafDER)
+
+	// the issuer comes first in the bundle, the leaf must still be selected by its private key
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})...)
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(leafKey)})...)
+	pfx, err := gopkcs12.Modern.Encode(leafKey, leaf, []*x509.Certificate{ca}, "pass")
+	tassert.Nil(t, err)
+
+	for name, loaded := range map[string]struct {
+		data     []byte
+		password string
+	}{
+		"pem":    {data: bundle},
+		"pkcs12": {data: pfx, password: "pass"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			chain, key, err := loadCertificate(loaded.data, loaded.password)
+			tassert.Nil(t, err)
+			tassert.Len(t, chain, 2)
+			tassert.True(t, chain[0].Equal(leaf))
+			tassert.True(t, chain[1].Equal(ca))
+
+			secret := &certificateChainSecret{chain: chain, key: key, clientID: "client", tokenEndpoint: "https://login.example.com/token"}
+			values := url.Values{}
+			tassert.Nil(t, secret.SetAuthenticationValues(nil, &values))
+			tassert.Equal(t, clientAssertionType, values.Get("client_assertion_type"))
+
+			claims := jwt.MapClaims{}
+			token, err := jwt.ParseWithClaims(values.Get("client_assertion"), claims, func(*jwt.Token) (any, error) {
+				return &leafKey.PublicKey, nil
+			})
+			tassert.Nil(t, err)
+			tassert.Len(t, token.Header["x5c"], 2)
+			tassert.NotEmpty(t, token.Header["x5t"])
+			tassert.Equal(t, "client", claims["sub"])
+			tassert.Equal(t, "https://login.example.com/token", claims["aud"])
+		})
+	}
+}
+
+func mockPKCS12(t *testing.T, password string) []byte {
+	chain, key, err := loadCertificateFromBytes([]byte(mockCertificate))
+	tassert.Nil(t, err)
+	pfx, err := gopkcs12.Modern.Encode(key, chain[0], nil, password)
+	tassert.Nil(t, err)
+	return pfx
+}
+
 func getTokenFromAuthorizer(t *testing.T, authorizer autorest.Authorizer) string {
 	rq, _ := http.NewRequest("POST", "http://example.com", http.NoBody)
 	_, err := authorizer.WithAuthorization()(
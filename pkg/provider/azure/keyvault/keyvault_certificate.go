@@ -14,21 +14,29 @@
 package keyvault
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec // required for the x5t header
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/url"
+	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/golang-jwt/jwt/v5"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
 )
 
 // ClientInMemoryCertificateConfig struct includes a Certificate field to hold the certificate data as a byte slice.
 type ClientInMemoryCertificateConfig struct {
 	ClientID    string
 	Certificate []byte // Certificate data as a byte slice
+	Password    string // Password of the PKCS#12 archive, if any
 	TenantID    string
 	AuxTenants  []string
 	AADEndpoint string
@@ -52,30 +60,115 @@ func (ccc ClientInMemoryCertificateConfig) ServicePrincipalToken() (*adal.Servic
 		return nil, err
 	}
 	// Use the byte slice directly instead of reading from a file
-	certificate, rsaPrivateKey, err := loadCertificateFromBytes(ccc.Certificate)
+	chain, rsaPrivateKey, err := loadCertificate(ccc.Certificate, ccc.Password)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode certificate: %w", err)
 	}
-	return adal.NewServicePrincipalTokenFromCertificate(*oauthConfig, ccc.ClientID, certificate, rsaPrivateKey, ccc.Resource)
+	secret := &certificateChainSecret{
+		chain:         chain,
+		key:           rsaPrivateKey,
+		clientID:      ccc.ClientID,
+		tokenEndpoint: oauthConfig.TokenEndpoint.String(),
+	}
+	return adal.NewServicePrincipalTokenWithSecret(*oauthConfig, ccc.ClientID, ccc.Resource, secret)
+}
+
+// certificateChainSecret authenticates with a client assertion signed by the private key of the certificate.
+// Unlike adal.ServicePrincipalCertificateSecret the x5c header holds the whole certificate chain,
+// which is required for subject name and issuer (SN+I) authentication.
+type certificateChainSecret struct {
+	chain         []*x509.Certificate
+	key           *rsa.PrivateKey
+	clientID      string
+	tokenEndpoint string
+}
+
+// SetAuthenticationValues implements adal.ServicePrincipalSecret.
+func (s *certificateChainSecret) SetAuthenticationValues(_ *adal.ServicePrincipalToken, v *url.Values) error {
+	assertion, err := s.signJWT()
+	if err != nil {
+		return err
+	}
+	v.Set("client_assertion", assertion)
+	v.Set("client_assertion_type", clientAssertionType)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s *certificateChainSecret) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("marshalling certificateChainSecret is not supported")
+}
+
+func (s *certificateChainSecret) signJWT() (string, error) {
+	thumbprint := sha1.Sum(s.chain[0].Raw) //nolint:gosec // the x5t header is defined as the SHA-1 thumbprint
+	x5c := make([]string, len(s.chain))
+	for i, cert := range s.chain {
+		x5c[i] = base64.StdEncoding.EncodeToString(cert.Raw)
+	}
+	jti := make([]byte, 20)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"aud": s.tokenEndpoint,
+		"iss": s.clientID,
+		"sub": s.clientID,
+		"jti": base64.URLEncoding.EncodeToString(jti),
+		"nbf": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	})
+	token.Header["x5t"] = base64.URLEncoding.EncodeToString(thumbprint[:])
+	token.Header["x5c"] = x5c
+	return token.SignedString(s.key)
 }
 
-func loadCertificateFromBytes(certificateBytes []byte) (*x509.Certificate, *rsa.PrivateKey, error) {
-	var cert *x509.Certificate
+// loadCertificate decodes either a PKCS#12 archive or a PEM bundle.
+// PKCS#12 archives are DER encoded and therefore always start with an ASN.1 SEQUENCE tag.
+// It returns the certificate chain starting with the certificate of the private key.
+func loadCertificate(certificateBytes []byte, password string) ([]*x509.Certificate, *rsa.PrivateKey, error) {
+	if len(certificateBytes) > 0 && certificateBytes[0] == asn1SequenceTag {
+		return loadCertificateFromPKCS12(certificateBytes, password)
+	}
+	return loadCertificateFromBytes(certificateBytes)
+}
+
+const (
+	asn1SequenceTag         = 0x30
+	clientAssertionType     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionLifetime = 10 * time.Minute
+)
+
+func loadCertificateFromPKCS12(pfx []byte, password string) ([]*x509.Certificate, *rsa.PrivateKey, error) {
+	key, cert, caCerts, err := gopkcs12.DecodeChain(pfx, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode PKCS#12 archive: %w", err)
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("found unknown private key type in PKCS#12 archive")
+	}
+	return append([]*x509.Certificate{cert}, caCerts...), privateKey, nil
+}
+
+func loadCertificateFromBytes(certificateBytes []byte) ([]*x509.Certificate, *rsa.PrivateKey, error) {
+	var certs []*x509.Certificate
 	var privateKey *rsa.PrivateKey
 	var err error
 
-	// Extract certificate and private key
+	// Extract certificates and private key
 	for {
 		block, rest := pem.Decode(certificateBytes)
 		if block == nil {
 			break
 		}
 		if block.Type == "CERTIFICATE" {
-			cert, err = x509.ParseCertificate(block.Bytes)
+			cert, err := x509.ParseCertificate(block.Bytes)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to parse PEM certificate: %w", err)
 			}
+			certs = append(certs, cert)
 		} else {
 			privateKey, err = parsePrivateKey(block.Bytes)
 			if err != nil {
@@ -85,7 +178,7 @@ func loadCertificateFromBytes(certificateBytes []byte) (*x509.Certificate, *rsa.
 		certificateBytes = rest
 	}
 
-	if cert == nil {
+	if len(certs) == 0 {
 		return nil, nil, errors.New("no certificate found in PEM file")
 	}
 
@@ -93,7 +186,14 @@ func loadCertificateFromBytes(certificateBytes []byte) (*x509.Certificate, *rsa.
 		return nil, nil, errors.New("no private key found in PEM file")
 	}
 
-	return cert, privateKey, nil
+	// the certificate of the private key goes first, the chain may be in any order
+	for i, cert := range certs {
+		if pub, ok := cert.PublicKey.(*rsa.PublicKey); ok && pub.Equal(&privateKey.PublicKey) {
+			certs[0], certs[i] = certs[i], certs[0]
+			break
+		}
+	}
+	return certs, privateKey, nil
 }
 
 func parsePrivateKey(der []byte) (*rsa.PrivateKey, error) {
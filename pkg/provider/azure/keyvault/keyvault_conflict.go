@@ -0,0 +1,80 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	// innerErrObjectIsBeingDeleted is the inner error code returned by Key Vault
+	// when writing to an object that is still being deleted in a soft-delete enabled vault.
+	innerErrObjectIsBeingDeleted = "ObjectIsBeingDeleted"
+	msgObjectIsBeingDeleted      = "is currently being deleted"
+
+	errObjectBeingDeleted = "%s %v is currently being deleted, waiting for the deletion to complete before pushing"
+
+	// pushPendingRequeue is the interval after which a pending push is retried.
+	pushPendingRequeue = 30 * time.Second
+)
+
+// pushConflictBackoff is used to wait for a soft-deleted object to be purged
+// before giving up and reporting the push as pending.
+var pushConflictBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Steps:    4,
+}
+
+// isObjectBeingDeleted checks if err is the 409 conflict Key Vault returns
+// when an object with the same name is currently being deleted.
+func isObjectBeingDeleted(err error) bool {
+	aerr := autorest.DetailedError{}
+	if !errors.As(err, &aerr) || aerr.StatusCode != http.StatusConflict {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, innerErrObjectIsBeingDeleted) || strings.Contains(msg, msgObjectIsBeingDeleted)
+}
+
+// retryWhileBeingDeleted calls fn until it no longer fails because the object is being deleted.
+// If the object is still being deleted after the backoff is exhausted, a PushSecretPendingError is returned.
+func retryWhileBeingDeleted(ctx context.Context, objectType, name string, fn func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, pushConflictBackoff, func(context.Context) (bool, error) {
+		lastErr = fn()
+		if isObjectBeingDeleted(lastErr) {
+			return false, nil
+		}
+		return true, lastErr
+	})
+	if err != nil && wait.Interrupted(err) && isObjectBeingDeleted(lastErr) {
+		return esv1beta1.PushSecretPendingError{
+			Message:    fmt.Sprintf(errObjectBeingDeleted, objectType, name),
+			RetryAfter: pushPendingRequeue,
+		}
+	}
+	return err
+}
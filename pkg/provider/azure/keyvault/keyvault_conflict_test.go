@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+var errBeingDeleted = autorest.DetailedError{
+	StatusCode: 409,
+	Method:     "PUT",
+	Message:    "Secret example-1 is currently being deleted.",
+}
+
+func TestIsObjectBeingDeleted(t *testing.T) {
+	tassert.True(t, isObjectBeingDeleted(errBeingDeleted))
+	tassert.True(t, isObjectBeingDeleted(autorest.DetailedError{StatusCode: 409, Message: "ObjectIsBeingDeleted"}))
+	tassert.False(t, isObjectBeingDeleted(autorest.DetailedError{StatusCode: 409, Message: "Conflict"}))
+	tassert.False(t, isObjectBeingDeleted(autorest.DetailedError{StatusCode: 404, Message: "is currently being deleted"}))
+	tassert.False(t, isObjectBeingDeleted(errors.New("is currently being deleted")))
+	tassert.False(t, isObjectBeingDeleted(nil))
+}
+
+func TestRetryWhileBeingDeleted(t *testing.T) {
+	defaultBackoff := pushConflictBackoff
+	pushConflictBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	defer func() { pushConflictBackoff = defaultBackoff }()
+
+	t.Run("succeeds once deletion completed", func(t *testing.T) {
+		calls := 0
+		err := retryWhileBeingDeleted(context.Background(), defaultObjType, secretName, func() error {
+			calls++
+			if calls < 2 {
+				return errBeingDeleted
+			}
+			return nil
+		})
+		tassert.Nil(t, err)
+		tassert.Equal(t, 2, calls)
+	})
+
+	t.Run("returns other errors immediately", func(t *testing.T) {
+		calls := 0
+		err := retryWhileBeingDeleted(context.Background(), defaultObjType, secretName, func() error {
+			calls++
+			return errors.New("boom")
+		})
+		tassert.EqualError(t, err, "boom")
+		tassert.Equal(t, 1, calls)
+	})
+
+	t.Run("reports pending when deletion does not complete", func(t *testing.T) {
+		calls := 0
+		err := retryWhileBeingDeleted(context.Background(), defaultObjType, secretName, func() error {
+			calls++
+			return errBeingDeleted
+		})
+		var pending esv1beta1.PushSecretPendingError
+		tassert.True(t, errors.As(err, &pending))
+		tassert.Equal(t, pushPendingRequeue, pending.RetryAfter)
+		tassert.EqualError(t, err, "secret example-1 is currently being deleted, waiting for the deletion to complete before pushing")
+		tassert.Equal(t, 3, calls)
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"encoding/json"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+// getAllCertificates returns the CER contents of the enabled certificates matching the find criteria by name,
+// their names must start with the prefix.
+func (a *Azure) getAllCertificates(ctx context.Context, ref esv1beta1.ExternalSecretFind, prefix string) (map[string][]byte, error) {
+	checkTags := len(ref.Tags) > 0
+	checkName := ref.Name != nil && ref.Name.RegExp != ""
+
+	certListIter, err := a.baseClient.GetCertificatesComplete(ctx, *a.provider.VaultURL, nil)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificates, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := make(map[string][]byte)
+	for certListIter.NotDone() {
+		cert := certListIter.Value()
+		enabled := cert.ID != nil && cert.Attributes != nil && cert.Attributes.Enabled != nil && *cert.Attributes.Enabled
+		if enabled {
+			if ok, name := isValidObject(checkTags, checkName, ref, *cert.ID, cert.Tags, prefix); ok {
+				certResp, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, name, "")
+				metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
+				err = parseError(err)
+				if err != nil {
+					return nil, err
+				}
+				if certResp.Attributes != nil {
+					a.reportLifetime(objectTypeCert+"/"+name, certResp.Attributes.Created, certResp.Attributes.Expires)
+				}
+				if certResp.Cer != nil {
+					certs[name] = *certResp.Cer
+				}
+			}
+		}
+		if err := certListIter.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return certs, nil
+}
+
+// getAllKeys returns the JWKs of the enabled keys matching the find criteria by name, their names must start with the prefix.
+// Keys backing a certificate are skipped, the certificate is found with the cert/ path.
+func (a *Azure) getAllKeys(ctx context.Context, ref esv1beta1.ExternalSecretFind, prefix string) (map[string][]byte, error) {
+	checkTags := len(ref.Tags) > 0
+	checkName := ref.Name != nil && ref.Name.RegExp != ""
+
+	keyListIter, err := a.baseClient.GetKeysComplete(ctx, *a.provider.VaultURL, nil)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKeys, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string][]byte)
+	for keyListIter.NotDone() {
+		key := keyListIter.Value()
+		enabled := key.Kid != nil && key.Attributes != nil && key.Attributes.Enabled != nil && *key.Attributes.Enabled
+		managed := key.Managed != nil && *key.Managed
+		if enabled && !managed {
+			if ok, name := isValidObject(checkTags, checkName, ref, *key.Kid, key.Tags, prefix); ok {
+				keyResp, err := a.baseClient.GetKey(ctx, *a.provider.VaultURL, name, "")
+				metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKey, err)
+				err = parseError(err)
+				if err != nil {
+					return nil, err
+				}
+				if keyResp.Attributes != nil {
+					a.reportLifetime(objectTypeKey+"/"+name, keyResp.Attributes.Created, keyResp.Attributes.Expires)
+				}
+				jwk, err := json.Marshal(keyResp.Key)
+				if err != nil {
+					return nil, err
+				}
+				keys[name] = jwk
+			}
+		}
+		if err := keyListIter.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,115 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	pointer "k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault/fake"
+)
+
+// findClient serves certificates and keys by name.
+type findClient struct {
+	*fake.AzureMockClient
+	certs map[string][]byte
+	keys  map[string]string
+}
+
+func (c *findClient) GetCertificate(_ context.Context, _, name, _ string) (keyvault.CertificateBundle, error) {
+	return keyvault.CertificateBundle{Cer: pointer.To(c.certs[name])}, nil
+}
+
+func (c *findClient) GetKey(_ context.Context, _, name, _ string) (keyvault.KeyBundle, error) {
+	return keyvault.KeyBundle{Key: &keyvault.JSONWebKey{Kid: pointer.To(c.keys[name])}}, nil
+}
+
+func TestAzureKeyVaultFindCertificatesAndKeys(t *testing.T) {
+	enabled := &keyvault.CertificateAttributes{Enabled: pointer.To(true)}
+	certList := []keyvault.CertificateItem{
+		{ID: pointer.To("https://vault/certificates/root-ca"), Attributes: enabled, Tags: map[string]*string{"bundle": pointer.To("trust")}},
+		{ID: pointer.To("https://vault/certificates/intermediate-ca"), Attributes: enabled, Tags: map[string]*string{"bundle": pointer.To("trust")}},
+		{ID: pointer.To("https://vault/certificates/server"), Attributes: enabled},
+		{ID: pointer.To("https://vault/certificates/old-ca"), Attributes: &keyvault.CertificateAttributes{Enabled: pointer.To(false)}},
+	}
+	certPage := keyvault.NewCertificateListResultPage(keyvault.CertificateListResult{Value: &certList},
+		func(context.Context, keyvault.CertificateListResult) (keyvault.CertificateListResult, error) {
+			return keyvault.CertificateListResult{}, nil
+		})
+	keyEnabled := &keyvault.KeyAttributes{Enabled: pointer.To(true)}
+	keyList := []keyvault.KeyItem{
+		{Kid: pointer.To("https://vault/keys/signing"), Attributes: keyEnabled},
+		// key backing a certificate
+		{Kid: pointer.To("https://vault/keys/server"), Attributes: keyEnabled, Managed: pointer.To(true)},
+	}
+	keyPage := keyvault.NewKeyListResultPage(keyvault.KeyListResult{Value: &keyList},
+		func(context.Context, keyvault.KeyListResult) (keyvault.KeyListResult, error) {
+			return keyvault.KeyListResult{}, nil
+		})
+	mockClient := &fake.AzureMockClient{}
+	mockClient.WithCertificateList(keyvault.NewCertificateListResultIterator(certPage), nil)
+	mockClient.WithKeyList(keyvault.NewKeyListResultIterator(keyPage), nil)
+	sm := Azure{
+		provider: &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+		baseClient: &findClient{
+			AzureMockClient: mockClient,
+			certs:           map[string][]byte{"root-ca": []byte("root"), "intermediate-ca": []byte("intermediate"), "server": []byte("server")},
+			keys:            map[string]string{"signing": "signing-kid"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		ref  esv1beta1.ExternalSecretFind
+		want map[string][]byte
+	}{
+		{
+			name: "all certificates",
+			ref:  esv1beta1.ExternalSecretFind{Path: pointer.To("cert/")},
+			want: map[string][]byte{"root-ca": []byte("root"), "intermediate-ca": []byte("intermediate"), "server": []byte("server")},
+		},
+		{
+			name: "certificates by tags",
+			ref:  esv1beta1.ExternalSecretFind{Path: pointer.To("cert/"), Tags: map[string]string{"bundle": "trust"}},
+			want: map[string][]byte{"root-ca": []byte("root"), "intermediate-ca": []byte("intermediate")},
+		},
+		{
+			name: "certificates by prefix and name",
+			ref:  esv1beta1.ExternalSecretFind{Path: pointer.To("cert/r"), Name: &esv1beta1.FindName{RegExp: "-ca$"}},
+			want: map[string][]byte{"root-ca": []byte("root")},
+		},
+		{
+			name: "keys",
+			ref:  esv1beta1.ExternalSecretFind{Path: pointer.To("key/")},
+			want: map[string][]byte{"signing": []byte(`{"kid":"signing-kid"}`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sm.GetAllSecrets(context.Background(), tt.ref)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unexpected secrets: %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	pointer "k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	// PushStrategySplitKeys pushes every key of a Secret as a Key Vault secret of its own,
+	// named <remoteKey>-<secretKey>, instead of a single JSON encoded secret.
+	PushStrategySplitKeys = "SplitKeys"
+
+	// splitFromTag is set on the secrets pushed with the SplitKeys strategy,
+	// its value is the remote key the secret was split from.
+	splitFromTag = "split-from"
+
+	errWholeSecretObjectType = "pushing the whole secret is only supported for secrets"
+	errSplitKeysSecretKey    = "strategy %s pushes the whole secret and cannot be used with a secretKey"
+	errUnknownStrategy       = "unknown push strategy %q"
+	errSplitKeyName          = "cannot push key %q: %q is not a valid Key Vault secret name"
+	errMarshalSecret         = "could not marshal secret data: %w"
+)
+
+// secretNameRegexp matches the names allowed for Key Vault secrets.
+var secretNameRegexp = regexp.MustCompile(`^[0-9a-zA-Z-]{1,127}$`)
+
+// validateStrategy checks the push strategy of the metadata against the pushed data.
+func validateStrategy(metadata PushSecretMetadata, data esv1beta1.PushSecretData) error {
+	switch metadata.Strategy {
+	case "":
+		return nil
+	case PushStrategySplitKeys:
+		if data.GetSecretKey() != "" {
+			return fmt.Errorf(errSplitKeysSecretKey, PushStrategySplitKeys)
+		}
+		return nil
+	default:
+		return fmt.Errorf(errUnknownStrategy, metadata.Strategy)
+	}
+}
+
+// pushWholeSecret pushes all keys of the secret, either JSON encoded as a single secret
+// or with the SplitKeys strategy as a secret per key.
+func (a *Azure) pushWholeSecret(ctx context.Context, secret *corev1.Secret, remoteKey string, metadata PushSecretMetadata) error {
+	objectType, secretName := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: remoteKey})
+	if objectType != defaultObjType {
+		return errors.New(errWholeSecretObjectType)
+	}
+	if metadata.Strategy == PushStrategySplitKeys {
+		return a.pushSplitKeys(ctx, secret, secretName)
+	}
+	data := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		data[k] = string(v)
+	}
+	value, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf(errMarshalSecret, err)
+	}
+	return a.setKeyVaultSecret(ctx, secretName, value, nil)
+}
+
+// pushSplitKeys pushes every key of the secret as <secretName>-<key> and deletes
+// the secrets split from secretName whose keys were removed from the secret.
+func (a *Azure) pushSplitKeys(ctx context.Context, secret *corev1.Secret, secretName string) error {
+	keys := make([]string, 0, len(secret.Data))
+	for k := range secret.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pushed := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		name := secretName + "-" + k
+		if !secretNameRegexp.MatchString(name) {
+			return fmt.Errorf(errSplitKeyName, k, name)
+		}
+		tags := map[string]*string{splitFromTag: pointer.To(secretName)}
+		if err := a.setKeyVaultSecret(ctx, name, secret.Data[k], tags); err != nil {
+			return err
+		}
+		pushed[name] = struct{}{}
+	}
+	return a.deleteSplitKeys(ctx, secretName, pushed)
+}
+
+// deleteSecretOrSplitKeys deletes the secret or, if it does not exist,
+// the secrets that were split from it with the SplitKeys strategy.
+func (a *Azure) deleteSecretOrSplitKeys(ctx context.Context, secretName string) error {
+	value, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, "")
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	var noSecretErr esv1beta1.NoSecretError
+	if errors.As(parseError(err), &noSecretErr) {
+		return a.deleteSplitKeys(ctx, secretName, nil)
+	}
+	return a.deleteKeyVaultSecretBundle(ctx, secretName, value.Tags, err)
+}
+
+// deleteSplitKeys deletes the managed secrets split from secretName, except the ones to keep.
+func (a *Azure) deleteSplitKeys(ctx context.Context, secretName string, keep map[string]struct{}) error {
+	secretListIter, err := a.baseClient.GetSecretsComplete(ctx, *a.provider.VaultURL, nil)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecrets, err)
+	err = parseError(err)
+	var noSecretErr esv1beta1.NoSecretError
+	if errors.As(err, &noSecretErr) {
+		// nothing to delete
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var stale []string
+	for secretListIter.NotDone() {
+		secret := secretListIter.Value()
+		from, ok := secret.Tags[splitFromTag]
+		if secret.ID != nil && ok && from != nil && *from == secretName {
+			name := path.Base(*secret.ID)
+			if _, ok := keep[name]; !ok {
+				stale = append(stale, name)
+			}
+		}
+		err = secretListIter.Next()
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range stale {
+		if err := a.deleteKeyVaultSecret(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	pointer "k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault/fake"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+// splitKeysClient keeps the secrets of a vault in memory and records the secrets that are set and deleted.
+type splitKeysClient struct {
+	*fake.AzureMockClient
+	secrets map[string]keyvault.SecretBundle
+	set     map[string]keyvault.SecretSetParameters
+	deleted []string
+}
+
+func newSplitKeysClient(secrets map[string]keyvault.SecretBundle) *splitKeysClient {
+	return &splitKeysClient{
+		AzureMockClient: &fake.AzureMockClient{},
+		secrets:         secrets,
+		set:             make(map[string]keyvault.SecretSetParameters),
+	}
+}
+
+func (c *splitKeysClient) GetSecret(_ context.Context, _, secretName, _ string) (keyvault.SecretBundle, error) {
+	secret, ok := c.secrets[secretName]
+	if !ok {
+		return keyvault.SecretBundle{}, autorest.DetailedError{StatusCode: 404, Method: "GET", Message: "Not Found"}
+	}
+	return secret, nil
+}
+
+func (c *splitKeysClient) GetSecretsComplete(_ context.Context, _ string, _ *int32) (keyvault.SecretListResultIterator, error) {
+	items := make([]keyvault.SecretItem, 0, len(c.secrets))
+	for name, secret := range c.secrets {
+		items = append(items, keyvault.SecretItem{ID: pointer.To("https://vault/secrets/" + name), Tags: secret.Tags})
+	}
+	getNextPage := func(context.Context, keyvault.SecretListResult) (keyvault.SecretListResult, error) {
+		return keyvault.SecretListResult{}, nil
+	}
+	page := keyvault.NewSecretListResultPage(keyvault.SecretListResult{Value: &items}, getNextPage)
+	return keyvault.NewSecretListResultIterator(page), nil
+}
+
+func (c *splitKeysClient) SetSecret(_ context.Context, _, secretName string, parameters keyvault.SecretSetParameters) (keyvault.SecretBundle, error) {
+	c.set[secretName] = parameters
+	return keyvault.SecretBundle{}, nil
+}
+
+func (c *splitKeysClient) DeleteSecret(_ context.Context, _, secretName string) (keyvault.DeletedSecretBundle, error) {
+	c.deleted = append(c.deleted, secretName)
+	return keyvault.DeletedSecretBundle{}, nil
+}
+
+func splitFrom(remoteKey string) map[string]*string {
+	return map[string]*string{"managed-by": pointer.To(managerLabel), splitFromTag: pointer.To(remoteKey)}
+}
+
+func TestAzureKeyVaultPushWholeSecret(t *testing.T) {
+	splitKeys := &apiextensionsv1.JSON{Raw: []byte(`{"strategy":"SplitKeys"}`)}
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			"user":     []byte("admin"),
+			"password": []byte("s3cr3t"),
+		},
+	}
+	existing := map[string]keyvault.SecretBundle{
+		"db-user":    {Value: pointer.To("admin"), Tags: splitFrom("db")},
+		"db-host":    {Value: pointer.To("localhost"), Tags: splitFrom("db")},
+		"dbx-token":  {Value: pointer.To("token"), Tags: splitFrom("dbx")},
+		"db-comment": {Value: pointer.To("not pushed"), Tags: map[string]*string{"managed-by": pointer.To(managerLabel)}},
+	}
+
+	tests := []struct {
+		name        string
+		data        testingfake.PushSecretData
+		secret      *corev1.Secret
+		wantErr     string
+		wantSet     map[string]string
+		wantDeleted []string
+	}{
+		{
+			name:    "push the secret as JSON",
+			data:    testingfake.PushSecretData{RemoteKey: "db"},
+			secret:  secret,
+			wantSet: map[string]string{"db": `{"password":"s3cr3t","user":"admin"}`},
+		},
+		{
+			name:        "push a secret per key and delete removed keys",
+			data:        testingfake.PushSecretData{RemoteKey: "db", Metadata: splitKeys},
+			secret:      secret,
+			wantSet:     map[string]string{"db-password": "s3cr3t"},
+			wantDeleted: []string{"db-host"},
+		},
+		{
+			name: "keys that are not valid secret names",
+			data: testingfake.PushSecretData{RemoteKey: "db", Metadata: splitKeys},
+			secret: &corev1.Secret{
+				Data: map[string][]byte{"db_user": []byte("admin")},
+			},
+			wantErr: `cannot push key "db_user": "db-db_user" is not a valid Key Vault secret name`,
+		},
+		{
+			name:    "split keys of a single key",
+			data:    testingfake.PushSecretData{SecretKey: "user", RemoteKey: "db", Metadata: splitKeys},
+			secret:  secret,
+			wantErr: "strategy SplitKeys pushes the whole secret and cannot be used with a secretKey",
+		},
+		{
+			name:    "whole secret to a key",
+			data:    testingfake.PushSecretData{RemoteKey: "key/db"},
+			secret:  secret,
+			wantErr: errWholeSecretObjectType,
+		},
+		{
+			name:    "unknown strategy",
+			data:    testingfake.PushSecretData{RemoteKey: "db", Metadata: &apiextensionsv1.JSON{Raw: []byte(`{"strategy":"Flatten"}`)}},
+			secret:  secret,
+			wantErr: `unknown push strategy "Flatten"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets := make(map[string]keyvault.SecretBundle, len(existing))
+			for k, v := range existing {
+				secrets[k] = v
+			}
+			mockClient := newSplitKeysClient(secrets)
+			az := &Azure{
+				provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+				baseClient: mockClient,
+			}
+			err := az.PushSecret(context.Background(), tt.secret, tt.data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			got := make(map[string]string, len(mockClient.set))
+			for name, params := range mockClient.set {
+				got[name] = *params.Value
+				assert.Equal(t, managerLabel, *params.Tags["managed-by"])
+				if tt.data.Metadata != nil {
+					assert.Equal(t, "db", *params.Tags[splitFromTag])
+				}
+			}
+			assert.Equal(t, tt.wantSet, got)
+			assert.Equal(t, tt.wantDeleted, mockClient.deleted)
+		})
+	}
+}
+
+func TestAzureKeyVaultDeleteSplitKeys(t *testing.T) {
+	mockClient := newSplitKeysClient(map[string]keyvault.SecretBundle{
+		"db-user":     {Tags: splitFrom("db")},
+		"db-password": {Tags: splitFrom("db")},
+		"db-comment":  {Tags: map[string]*string{"managed-by": pointer.To(managerLabel)}},
+		"dbx-token":   {Tags: splitFrom("dbx")},
+	})
+	az := &Azure{
+		provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+		baseClient: mockClient,
+	}
+	err := az.DeleteSecret(context.Background(), testingfake.PushSecretData{RemoteKey: "db"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"db-user", "db-password"}, mockClient.deleted)
+}
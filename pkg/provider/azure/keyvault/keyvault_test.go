@@ -22,11 +22,17 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pointer "k8s.io/utils/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	v1 "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -686,6 +692,65 @@ func TestAzureKeyVaultPushSecret(t *testing.T) {
 		}
 	}
 
+	p12Key, p12Leaf, p12CACerts, _ := gopkcs12.DecodeChain(p12Cert, "")
+	protectedP12Cert, _ := gopkcs12.Modern.Encode(p12Key, p12Leaf, p12CACerts, "changeit")
+	passwordMetadata := func(key string) *apiextensionsv1.JSON {
+		return &apiextensionsv1.JSON{Raw: []byte(fmt.Sprintf(`{"pkcs12Password":{"name":"pfx-password","key":%q}}`, key))}
+	}
+	certWithPassword := func(smtc *secretManagerTestCase) {
+		smtc.setValue = protectedP12Cert
+		smtc.pushData = testingfake.PushSecretData{
+			SecretKey: secretKey,
+			RemoteKey: certName,
+			Metadata:  passwordMetadata("password"),
+		}
+		smtc.certOutput = keyvault.CertificateBundle{
+			X509Thumbprint: pointer.To("123"),
+			Tags: map[string]*string{
+				"managed-by": pointer.To("external-secrets"),
+			},
+		}
+	}
+
+	certWithoutPassword := func(smtc *secretManagerTestCase) {
+		smtc.setValue = protectedP12Cert
+		smtc.pushData = testingfake.PushSecretData{
+			SecretKey: secretKey,
+			RemoteKey: certName,
+		}
+		smtc.expectError = "value from secret is not a valid certificate"
+	}
+
+	certWrongPassword := func(smtc *secretManagerTestCase) {
+		smtc.setValue = protectedP12Cert
+		smtc.pushData = testingfake.PushSecretData{
+			SecretKey: secretKey,
+			RemoteKey: certName,
+			Metadata:  passwordMetadata("wrong"),
+		}
+		smtc.expectError = "could not decode PKCS#12 bundle with password"
+	}
+
+	certPasswordNotFound := func(smtc *secretManagerTestCase) {
+		smtc.setValue = protectedP12Cert
+		smtc.pushData = testingfake.PushSecretData{
+			SecretKey: secretKey,
+			RemoteKey: certName,
+			Metadata:  passwordMetadata("missing"),
+		}
+		smtc.expectError = "invalid pkcs12Password"
+	}
+
+	secretWithPassword := func(smtc *secretManagerTestCase) {
+		smtc.setValue = protectedP12Cert
+		smtc.pushData = testingfake.PushSecretData{
+			SecretKey: secretKey,
+			RemoteKey: secretName,
+			Metadata:  passwordMetadata("password"),
+		}
+		smtc.expectError = errPKCS12PasswordObjectType
+	}
+
 	certNotManagedByES := func(smtc *secretManagerTestCase) {
 		smtc.setValue = p12Cert
 		smtc.pushData = testingfake.PushSecretData{
@@ -749,6 +814,11 @@ func TestAzureKeyVaultPushSecret(t *testing.T) {
 		makeValidSecretManagerTestCaseCustom(certDERSuccess),
 		makeValidSecretManagerTestCaseCustom(certImportCertificateError),
 		makeValidSecretManagerTestCaseCustom(certFingerprintMatches),
+		makeValidSecretManagerTestCaseCustom(certWithPassword),
+		makeValidSecretManagerTestCaseCustom(certWithoutPassword),
+		makeValidSecretManagerTestCaseCustom(certWrongPassword),
+		makeValidSecretManagerTestCaseCustom(certPasswordNotFound),
+		makeValidSecretManagerTestCaseCustom(secretWithPassword),
 		makeValidSecretManagerTestCaseCustom(certNotManagedByES),
 		makeValidSecretManagerTestCaseCustom(certNoManagerTags),
 		makeValidSecretManagerTestCaseCustom(certNotACertificate),
@@ -776,10 +846,18 @@ func TestAzureKeyVaultPushSecret(t *testing.T) {
 
 	sm := Azure{
 		provider: &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+		crClient: clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pfx-password", Namespace: "default"},
+			Data: map[string][]byte{
+				"password": []byte("changeit"),
+				"wrong":    []byte("guess"),
+			},
+		}).Build(),
 	}
 	for k, v := range successCases {
 		sm.baseClient = v.mockClient
 		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
 			Data: map[string][]byte{
 				secretKey: v.setValue,
 			},
@@ -1484,6 +1562,121 @@ func TestAzureKeyVaultSecretManagerGetAllSecrets(t *testing.T) {
 	}
 }
 
+func TestAzureKeyVaultListManagedSecrets(t *testing.T) {
+	owned := func(tags map[string]*string) map[string]*string {
+		tags[esv1beta1.ManagedSecretOwnerTag] = pointer.To("store-uid")
+		return tags
+	}
+	managed := owned(map[string]*string{"managed-by": pointer.To(managerLabel)})
+	secretList := []keyvault.SecretItem{
+		{ID: pointer.To("https://vault/secrets/pushed"), Tags: managed},
+		{ID: pointer.To("https://vault/secrets/other"), Tags: owned(map[string]*string{"managed-by": pointer.To("terraform")})},
+		{ID: pointer.To("https://vault/secrets/untagged")},
+		// secrets pushed through another store or before objects were tagged with their store
+		{ID: pointer.To("https://vault/secrets/other-store"), Tags: map[string]*string{"managed-by": pointer.To(managerLabel), esv1beta1.ManagedSecretOwnerTag: pointer.To("other-store-uid")}},
+		{ID: pointer.To("https://vault/secrets/unowned"), Tags: map[string]*string{"managed-by": pointer.To(managerLabel)}},
+		// secret backing a pushed certificate
+		{ID: pointer.To("https://vault/secrets/cert"), Tags: managed, Managed: pointer.To(true)},
+		// secrets split from a whole Secret
+		{ID: pointer.To("https://vault/secrets/db-user"), Tags: owned(splitFrom("db"))},
+		{ID: pointer.To("https://vault/secrets/db-password"), Tags: owned(splitFrom("db"))},
+	}
+	getNextPage := func(ctx context.Context, list keyvault.SecretListResult) (keyvault.SecretListResult, error) {
+		return keyvault.SecretListResult{}, nil
+	}
+	page := keyvault.NewSecretListResultPage(keyvault.SecretListResult{Value: &secretList}, getNextPage)
+	mockClient := &fake.AzureMockClient{}
+	mockClient.WithList("", keyvault.NewSecretListResultIterator(page), nil)
+
+	sm := Azure{
+		store:      &esv1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{UID: "store-uid"}},
+		provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+		baseClient: mockClient,
+	}
+	names, err := sm.ListManagedSecrets(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"pushed", "db"}) {
+		t.Errorf("unexpected managed secrets: %v", names)
+	}
+	unowned := Azure{provider: sm.provider, baseClient: mockClient}
+	if _, err := unowned.ListManagedSecrets(context.Background()); err == nil || err.Error() != errNoOwner {
+		t.Errorf("unexpected error without store UID: %v", err)
+	}
+
+	keys := map[string]string{
+		"pushed":        "pushed",
+		"secret/pushed": "pushed",
+		"key/pushed":    "key/pushed",
+		"cert/pushed":   "cert/pushed",
+	}
+	for remoteKey, want := range keys {
+		if got := sm.ManagedSecretKey(remoteKey); got != want {
+			t.Errorf("ManagedSecretKey(%q) = %q, want %q", remoteKey, got, want)
+		}
+	}
+}
+
+func TestAzureKeyVaultPushSecretOwner(t *testing.T) {
+	managed := map[string]*string{"managed-by": pointer.To(managerLabel)}
+	baseClient := newSplitKeysClient(map[string]keyvault.SecretBundle{
+		"unchanged":   {Value: pointer.To("value"), Tags: map[string]*string{"managed-by": pointer.To(managerLabel), esv1beta1.ManagedSecretOwnerTag: pointer.To("store-uid")}},
+		"unowned":     {Value: pointer.To("value"), Tags: managed},
+		"other-store": {Value: pointer.To("value"), Tags: map[string]*string{"managed-by": pointer.To(managerLabel), esv1beta1.ManagedSecretOwnerTag: pointer.To("other-store-uid")}},
+	})
+	az := &Azure{
+		store:      &esv1beta1.SecretStore{ObjectMeta: metav1.ObjectMeta{UID: "store-uid"}},
+		provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+		baseClient: baseClient,
+	}
+	for _, name := range []string{"new", "unchanged", "unowned", "other-store"} {
+		if err := az.setKeyVaultSecret(context.Background(), name, []byte("value"), nil); err != nil {
+			t.Fatalf("unexpected error pushing %s: %v", name, err)
+		}
+	}
+	owners := make(map[string]string)
+	for name, params := range baseClient.set {
+		owners[name] = *params.Tags[esv1beta1.ManagedSecretOwnerTag]
+	}
+	want := map[string]string{"new": "store-uid", "unowned": "store-uid", "other-store": "store-uid"}
+	if !reflect.DeepEqual(owners, want) {
+		t.Errorf("unexpected owners of the pushed secrets: %v", owners)
+	}
+}
+
+func TestAzureKeyVaultSecretLifetimes(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := &fake.AzureMockClient{}
+	mockClient.WithValue("", "", "", keyvault.SecretBundle{
+		Value:      pointer.To("value"),
+		Attributes: &keyvault.SecretAttributes{Created: pointer.To(date.UnixTime(created))},
+	}, nil)
+	mockClient.WithCertificate("", "", "", keyvault.CertificateBundle{
+		Cer:        &[]byte{},
+		Attributes: &keyvault.CertificateAttributes{Created: pointer.To(date.UnixTime(created)), Expires: pointer.To(date.UnixTime(expires))},
+	}, nil)
+	mockClient.WithKey("", "", "", keyvault.KeyBundle{Key: &keyvault.JSONWebKey{}}, nil)
+
+	sm := Azure{
+		provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+		baseClient: mockClient,
+	}
+	for _, key := range []string{"secret/password", "cert/tls", "key/signing"} {
+		if _, err := sm.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	want := []esv1beta1.SecretLifetime{
+		{Key: "secret/password", Created: created},
+		{Key: "cert/tls", Created: created, Expires: expires},
+	}
+	if !reflect.DeepEqual(sm.SecretLifetimes(), want) {
+		t.Errorf("unexpected lifetimes: %v", sm.SecretLifetimes())
+	}
+}
+
 func makeValidRef() *esv1beta1.ExternalSecretDataRemoteRef {
 	return &esv1beta1.ExternalSecretDataRemoteRef{
 		Key:      "test-secret",
@@ -1693,3 +1886,79 @@ func TestAzureKeyVaultSecretExists(t *testing.T) {
 		}
 	}
 }
+
+func TestAzureKeyVaultSecretManagerGetAllSecretsByPath(t *testing.T) {
+	enabled := &keyvault.SecretAttributes{Enabled: pointer.To(true)}
+	item := func(name string) keyvault.SecretItem {
+		return keyvault.SecretItem{ID: pointer.To("https://vault/secrets/" + name), Attributes: enabled}
+	}
+	// the prefix matches at the start and end of pages and on a page after an empty page
+	pages := map[string]keyvault.SecretListResult{
+		"": {
+			Value:    &[]keyvault.SecretItem{item("team-a-db"), item("team-b-db"), item("team-a-api")},
+			NextLink: pointer.To("2"),
+		},
+		"2": {
+			Value:    &[]keyvault.SecretItem{},
+			NextLink: pointer.To("3"),
+		},
+		"3": {
+			Value:    &[]keyvault.SecretItem{item("team-a-cache"), item("shared-team-a-db"), item("team-b-api")},
+			NextLink: pointer.To("4"),
+		},
+		"4": {
+			Value: &[]keyvault.SecretItem{item("team-a-")},
+		},
+	}
+	getNextPage := func(_ context.Context, list keyvault.SecretListResult) (keyvault.SecretListResult, error) {
+		if list.NextLink == nil {
+			return keyvault.SecretListResult{}, nil
+		}
+		return pages[*list.NextLink], nil
+	}
+
+	tests := []struct {
+		name string
+		find esv1beta1.ExternalSecretFind
+		want []string
+	}{
+		{
+			name: "path",
+			find: esv1beta1.ExternalSecretFind{Path: pointer.To("team-a-")},
+			want: []string{"team-a-db", "team-a-api", "team-a-cache", "team-a-"},
+		},
+		{
+			name: "path and name",
+			find: esv1beta1.ExternalSecretFind{Path: pointer.To("team-a-"), Name: &esv1beta1.FindName{RegExp: "db$"}},
+			want: []string{"team-a-db"},
+		},
+		{
+			name: "no match",
+			find: esv1beta1.ExternalSecretFind{Path: pointer.To("team-c-")},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &fake.AzureMockClient{}
+			page := keyvault.NewSecretListResultPage(pages[""], getNextPage)
+			mockClient.WithList("", keyvault.NewSecretListResultIterator(page), nil)
+			mockClient.WithValue("", "", "", keyvault.SecretBundle{Value: pointer.To(secretString)}, nil)
+			sm := Azure{
+				provider:   &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+				baseClient: mockClient,
+			}
+			out, err := sm.GetAllSecrets(context.Background(), tt.find)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			want := make(map[string][]byte, len(tt.want))
+			for _, name := range tt.want {
+				want[name] = []byte(secretString)
+			}
+			if !reflect.DeepEqual(out, want) {
+				t.Errorf("unexpected secrets: expected %v, got %v", tt.want, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	// throttleFailureThreshold is the number of consecutive throttled responses that open the circuit of a vault.
+	throttleFailureThreshold = 3
+	// defaultThrottleRetryAfter is the pause if the responses don't advertise a Retry-After.
+	defaultThrottleRetryAfter = 30 * time.Second
+	// maxThrottleRetryAfter caps the advertised Retry-After.
+	maxThrottleRetryAfter = 10 * time.Minute
+	// breakerTTL is the time the breaker of a vault is kept without requests,
+	// a little longer than the default refresh interval of an hour.
+	breakerTTL = 2 * time.Hour
+
+	errVaultThrottled = "vault %s responded %d times with status %d, requests are paused"
+	errVaultPaused    = "vault %s is throttled, requests are paused"
+)
+
+// retryStatusCodes are the status codes the client retries, throttled responses
+// are left to the circuit breaker as retrying them would only extend the throttling.
+var retryStatusCodes = slices.DeleteFunc(slices.Clone(autorest.StatusCodesForRetry), func(code int) bool {
+	return code == http.StatusTooManyRequests
+})
+
+// vaultBreakers holds the circuit breaker of every vault by its host.
+// The breakers are shared by all clients, as the clients are created for every reconcile.
+var vaultBreakers = newBreakers(breakerTTL)
+
+// vaultBreaker pauses the requests to a vault after repeated throttled responses.
+type vaultBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	// lastUsed is guarded by the mutex of the breakers.
+	lastUsed time.Time
+}
+
+type breakers struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	now    func() time.Time
+	vaults map[string]*vaultBreaker
+}
+
+func newBreakers(ttl time.Duration) *breakers {
+	return &breakers{
+		ttl:    ttl,
+		now:    time.Now,
+		vaults: make(map[string]*vaultBreaker),
+	}
+}
+
+// get returns the breaker of the vault. Breakers of vaults without requests within the ttl,
+// e.g. of deleted stores, are evicted unless their circuit is still open.
+func (b *breakers) get(host string) *vaultBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	for vault, breaker := range b.vaults {
+		if now.Sub(breaker.lastUsed) > b.ttl && breaker.paused(now) <= 0 {
+			delete(b.vaults, vault)
+		}
+	}
+	host = strings.ToLower(host)
+	breaker, ok := b.vaults[host]
+	if !ok {
+		breaker = &vaultBreaker{}
+		b.vaults[host] = breaker
+	}
+	breaker.lastUsed = now
+	return breaker
+}
+
+// paused returns how long the requests are still paused.
+func (b *vaultBreaker) paused(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.openUntil.Sub(now)
+}
+
+// record counts the failed responses and opens the circuit once the threshold is reached.
+// After the pause the next failure opens the circuit again, a successful response closes it.
+// It returns the pause if the circuit was opened.
+func (b *vaultBreaker) record(resp *http.Response, now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !isThrottled(resp) {
+		b.failures = 0
+		return 0
+	}
+	b.failures++
+	if b.failures < throttleFailureThreshold {
+		return 0
+	}
+	pause := retryAfter(resp, now)
+	b.openUntil = now.Add(pause)
+	return pause
+}
+
+// isThrottled tells whether the vault throttles the requests, with a 429 or with an error advertising a Retry-After, e.g. a 503.
+// Other server errors are retried by the client and don't pause the vault.
+func isThrottled(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode >= http.StatusBadRequest && resp.Header.Get("Retry-After") != ""
+}
+
+// retryAfter returns the pause advertised by the Retry-After header in seconds or as HTTP date.
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	pause := defaultThrottleRetryAfter
+	value := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		pause = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		pause = date.Sub(now)
+	}
+	return min(pause, maxThrottleRetryAfter)
+}
+
+// sendDecorators retries failed requests and pauses throttled vaults.
+// The circuit breaker wraps the retries, so it only sees the responses the retries gave up on.
+func sendDecorators(attempts int, backoff time.Duration) []autorest.SendDecorator {
+	return []autorest.SendDecorator{
+		autorest.DoRetryForStatusCodes(attempts, backoff, retryStatusCodes...),
+		withCircuitBreaker(),
+	}
+}
+
+// withCircuitBreaker returns an esv1beta1.ThrottledError instead of sending requests to a vault
+// whose circuit is open. It replaces the retries of the client for throttled responses,
+// retrying them would only extend the throttling.
+func withCircuitBreaker() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			breaker := vaultBreakers.get(r.URL.Host)
+			if pause := breaker.paused(time.Now()); pause > 0 {
+				return nil, esv1beta1.ThrottledError{
+					Message:    fmt.Sprintf(errVaultPaused, r.URL.Host),
+					RetryAfter: pause,
+				}
+			}
+			resp, err := s.Do(r)
+			if err != nil {
+				return resp, err
+			}
+			if pause := breaker.record(resp, time.Now()); pause > 0 {
+				resp.Body.Close()
+				return nil, esv1beta1.ThrottledError{
+					Message:    fmt.Sprintf(errVaultThrottled, r.URL.Host, throttleFailureThreshold, resp.StatusCode),
+					RetryAfter: pause,
+				}
+			}
+			return resp, nil
+		})
+	}
+}
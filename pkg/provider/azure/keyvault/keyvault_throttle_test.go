@@ -0,0 +1,162 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	status := http.StatusTooManyRequests
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	cl := autorest.Client{SendDecorators: []autorest.SendDecorator{withCircuitBreaker()}}
+	send := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+		require.NoError(t, err)
+		resp, err := cl.Send(req)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	// a success resets the failures
+	for range throttleFailureThreshold - 1 {
+		resp, err := send()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	}
+	status = http.StatusOK
+	_, err := send()
+	require.NoError(t, err)
+
+	status = http.StatusServiceUnavailable
+	for range throttleFailureThreshold - 1 {
+		_, err := send()
+		require.NoError(t, err)
+	}
+	var throttledErr esv1beta1.ThrottledError
+	_, err = send()
+	require.True(t, errors.As(err, &throttledErr), "unexpected error %v", err)
+	assert.Equal(t, 120*time.Second, throttledErr.RetryAfter)
+	assert.Equal(t, 2*throttleFailureThreshold, hits)
+
+	// requests are paused without calling the vault
+	_, err = send()
+	require.True(t, errors.As(err, &throttledErr), "unexpected error %v", err)
+	assert.LessOrEqual(t, throttledErr.RetryAfter, 120*time.Second)
+	assert.Equal(t, 2*throttleFailureThreshold, hits)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "seconds", value: "42", want: 42 * time.Second},
+		{name: "http date", value: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute},
+		{name: "missing", value: "", want: defaultThrottleRetryAfter},
+		{name: "invalid", value: "soon", want: defaultThrottleRetryAfter},
+		{name: "capped", value: "86400", want: maxThrottleRetryAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.value != "" {
+				resp.Header.Set("Retry-After", tt.value)
+			}
+			assert.Equal(t, tt.want, retryAfter(resp, now))
+		})
+	}
+}
+
+func TestSendDecorators(t *testing.T) {
+	var statuses []int
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(hits, len(statuses)-1)]
+		hits++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	cl := autorest.Client{SendDecorators: sendDecorators(3, time.Millisecond)}
+	send := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+		require.NoError(t, err)
+		resp, err := cl.Send(req)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	t.Run("server errors are retried", func(t *testing.T) {
+		statuses, hits = []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK}, 0
+		resp, err := send()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 3, hits)
+	})
+	t.Run("server errors without Retry-After don't open the circuit", func(t *testing.T) {
+		statuses, hits = []int{http.StatusInternalServerError}, 0
+		for range throttleFailureThreshold + 1 {
+			resp, err := send()
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		}
+	})
+	t.Run("throttled responses are not retried", func(t *testing.T) {
+		statuses, hits = []int{http.StatusTooManyRequests}, 0
+		resp, err := send()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, 1, hits)
+	})
+}
+
+func TestBreakersEviction(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newBreakers(time.Hour)
+	b.now = func() time.Time { return now }
+
+	idle := b.get("idle.vault.azure.net")
+	open := b.get("open.vault.azure.net")
+	open.openUntil = now.Add(3 * time.Hour)
+	now = now.Add(2 * time.Hour)
+	used := b.get("Used.vault.azure.net")
+
+	assert.Len(t, b.vaults, 2)
+	assert.Same(t, open, b.vaults["open.vault.azure.net"])
+	assert.Same(t, used, b.get("used.vault.azure.net"))
+	assert.NotSame(t, idle, b.get("idle.vault.azure.net"))
+}
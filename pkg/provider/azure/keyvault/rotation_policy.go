@@ -0,0 +1,222 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	// the rotation policy API is not part of the keyvault SDK version in use.
+	rotationPolicyAPIVersion   = "7.3"
+	rotationPolicyActionNotify = "Notify"
+
+	defaultExpiryNotification = "P30D"
+
+	errRotationPolicyObjectType = "rotationPolicy is only supported for keys"
+	errInvalidISODuration       = "invalid ISO 8601 duration %q, expected e.g. P90D"
+	errGetRotationPolicy        = "cannot get rotation policy of key %v: %w"
+	errUpdateRotationPolicy     = "cannot update rotation policy of key %v: %w"
+)
+
+var isoDurationRegex = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?$`)
+
+// KeyRotationPolicy is the rotation policy applied to a pushed key.
+type KeyRotationPolicy struct {
+	// ExpiryTime is the validity of a pushed key version as ISO 8601 duration, e.g. P90D.
+	ExpiryTime string `json:"expiryTime"`
+	// NotifyBeforeExpiry is the ISO 8601 duration before the expiry at which Key Vault sends
+	// a near expiry event and the expiry is reported in the PushSecret status. Defaults to P30D.
+	NotifyBeforeExpiry string `json:"notifyBeforeExpiry,omitempty"`
+}
+
+// isoDuration is an ISO 8601 duration limited to dates, which is sufficient for key lifetimes.
+type isoDuration struct {
+	years, months, days int
+}
+
+func parseISODuration(s string) (isoDuration, error) {
+	m := isoDurationRegex.FindStringSubmatch(s)
+	if m == nil || s == "P" {
+		return isoDuration{}, fmt.Errorf(errInvalidISODuration, s)
+	}
+	n := make([]int, 4)
+	for i, v := range m[1:] {
+		if v != "" {
+			n[i], _ = strconv.Atoi(v)
+		}
+	}
+	return isoDuration{years: n[0], months: n[1], days: 7*n[2] + n[3]}, nil
+}
+
+func (d isoDuration) addTo(t time.Time) time.Time {
+	return t.AddDate(d.years, d.months, d.days)
+}
+
+func (d isoDuration) subtractFrom(t time.Time) time.Time {
+	return t.AddDate(-d.years, -d.months, -d.days)
+}
+
+func (p *KeyRotationPolicy) validate() error {
+	if _, err := parseISODuration(p.ExpiryTime); err != nil {
+		return err
+	}
+	_, err := parseISODuration(p.notifyBeforeExpiry())
+	return err
+}
+
+func (p *KeyRotationPolicy) notifyBeforeExpiry() string {
+	if p.NotifyBeforeExpiry == "" {
+		return defaultExpiryNotification
+	}
+	return p.NotifyBeforeExpiry
+}
+
+// expires returns the expiry of a key version created at the given time.
+func (p *KeyRotationPolicy) expires(created time.Time) time.Time {
+	d, _ := parseISODuration(p.ExpiryTime)
+	return d.addTo(created)
+}
+
+func (p *KeyRotationPolicy) toAPI() keyRotationPolicy {
+	return keyRotationPolicy{
+		LifetimeActions: []keyLifetimeAction{
+			{
+				Trigger: keyLifetimeActionTrigger{TimeBeforeExpiry: p.notifyBeforeExpiry()},
+				Action:  keyLifetimeActionType{Type: rotationPolicyActionNotify},
+			},
+		},
+		Attributes: keyRotationPolicyAttributes{ExpiryTime: p.ExpiryTime},
+	}
+}
+
+type keyRotationPolicy struct {
+	LifetimeActions []keyLifetimeAction         `json:"lifetimeActions"`
+	Attributes      keyRotationPolicyAttributes `json:"attributes"`
+}
+
+type keyLifetimeAction struct {
+	Trigger keyLifetimeActionTrigger `json:"trigger"`
+	Action  keyLifetimeActionType    `json:"action"`
+}
+
+type keyLifetimeActionTrigger struct {
+	TimeAfterCreate  string `json:"timeAfterCreate,omitempty"`
+	TimeBeforeExpiry string `json:"timeBeforeExpiry,omitempty"`
+}
+
+type keyLifetimeActionType struct {
+	Type string `json:"type"`
+}
+
+type keyRotationPolicyAttributes struct {
+	ExpiryTime string `json:"expiryTime,omitempty"`
+}
+
+// KeyRotationPolicyClient manages the rotation policy of keys.
+type KeyRotationPolicyClient interface {
+	GetKeyRotationPolicy(ctx context.Context, vaultBaseURL, keyName string) (keyRotationPolicy, error)
+	UpdateKeyRotationPolicy(ctx context.Context, vaultBaseURL, keyName string, policy keyRotationPolicy) error
+}
+
+// rotationPolicyClient calls the rotation policy API with the authorizer of the keyvault client.
+type rotationPolicyClient struct {
+	autorest.Client
+}
+
+func (c *rotationPolicyClient) GetKeyRotationPolicy(ctx context.Context, vaultBaseURL, keyName string) (keyRotationPolicy, error) {
+	var policy keyRotationPolicy
+	resp, err := c.send(ctx, vaultBaseURL, keyName, autorest.AsGet())
+	if err != nil {
+		return policy, err
+	}
+	// keys without rotation policy
+	if resp.StatusCode == http.StatusNotFound {
+		return policy, autorest.Respond(resp, autorest.ByClosing())
+	}
+	err = autorest.Respond(resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&policy),
+		autorest.ByClosing())
+	return policy, err
+}
+
+func (c *rotationPolicyClient) UpdateKeyRotationPolicy(ctx context.Context, vaultBaseURL, keyName string, policy keyRotationPolicy) error {
+	resp, err := c.send(ctx, vaultBaseURL, keyName, autorest.AsPut(), autorest.AsContentType("application/json; charset=utf-8"), autorest.WithJSON(policy))
+	if err != nil {
+		return err
+	}
+	return autorest.Respond(resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByClosing())
+}
+
+func (c *rotationPolicyClient) send(ctx context.Context, vaultBaseURL, keyName string, decorators ...autorest.PrepareDecorator) (*http.Response, error) {
+	decorators = append(decorators,
+		autorest.WithCustomBaseURL("{vaultBaseUrl}", map[string]any{"vaultBaseUrl": vaultBaseURL}),
+		autorest.WithPathParameters("/keys/{key-name}/rotationpolicy", map[string]any{"key-name": autorest.Encode("path", keyName)}),
+		autorest.WithQueryParameters(map[string]any{"api-version": rotationPolicyAPIVersion}))
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), decorators...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Send(req, autorest.DoRetryForStatusCodes(c.RetryAttempts, c.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+// setKeyRotationPolicy updates the rotation policy of the key if it differs from the desired policy.
+func (a *Azure) setKeyRotationPolicy(ctx context.Context, keyName string, policy *KeyRotationPolicy) error {
+	desired := policy.toAPI()
+	current, err := a.rotationClient.GetKeyRotationPolicy(ctx, *a.provider.VaultURL, keyName)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKeyRotationPolicy, err)
+	if err != nil {
+		return fmt.Errorf(errGetRotationPolicy, keyName, err)
+	}
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+	err = a.rotationClient.UpdateKeyRotationPolicy(ctx, *a.provider.VaultURL, keyName, desired)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVUpdateKeyRotationPolicy, err)
+	if err != nil {
+		return fmt.Errorf(errUpdateRotationPolicy, keyName, err)
+	}
+	return nil
+}
+
+// reportExpiry records the expiry of the object if it is within the notification period.
+func (a *Azure) reportExpiry(key string, expires time.Time, notifyBeforeExpiry string) {
+	d, err := parseISODuration(notifyBeforeExpiry)
+	if err != nil || time.Now().Before(d.subtractFrom(expires)) {
+		return
+	}
+	a.expirations = append(a.expirations, esv1beta1.SecretExpiration{Key: key, Expires: expires})
+}
+
+// SecretExpirations returns the keys read or pushed by the client that expire soon.
+func (a *Azure) SecretExpirations() []esv1beta1.SecretExpiration {
+	return a.expirations
+}
@@ -0,0 +1,216 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	pointer "k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault/fake"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+type fakeRotationClient struct {
+	current keyRotationPolicy
+	updated *keyRotationPolicy
+}
+
+func (c *fakeRotationClient) GetKeyRotationPolicy(_ context.Context, _, _ string) (keyRotationPolicy, error) {
+	return c.current, nil
+}
+
+func (c *fakeRotationClient) UpdateKeyRotationPolicy(_ context.Context, _, _ string, policy keyRotationPolicy) error {
+	c.updated = &policy
+	return nil
+}
+
+func TestParseISODuration(t *testing.T) {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"P90D":   start.AddDate(0, 0, 90),
+		"P2W":    start.AddDate(0, 0, 14),
+		"P1Y6M":  start.AddDate(1, 6, 0),
+		"P1M10D": start.AddDate(0, 1, 10),
+	}
+	for s, want := range tests {
+		d, err := parseISODuration(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, want, d.addTo(start), s)
+	}
+	for _, s := range []string{"", "P", "90D", "PT1H", "P-1D"} {
+		_, err := parseISODuration(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestAzureKeyVaultPushKeyRotationPolicy(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		Data: map[string][]byte{"key": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})},
+	}
+	jwKey, err := jwk.FromRaw(privateKey)
+	require.NoError(t, err)
+	buf, err := json.Marshal(jwKey)
+	require.NoError(t, err)
+	var pushedKey keyvault.JSONWebKey
+	require.NoError(t, json.Unmarshal(buf, &pushedKey))
+
+	managed := map[string]*string{"managed-by": pointer.To(managerLabel)}
+	metadata := &apiextensionsv1.JSON{Raw: []byte(`{"rotationPolicy":{"expiryTime":"P90D","notifyBeforeExpiry":"P14D"}}`)}
+	desired := keyRotationPolicy{
+		LifetimeActions: []keyLifetimeAction{{
+			Trigger: keyLifetimeActionTrigger{TimeBeforeExpiry: "P14D"},
+			Action:  keyLifetimeActionType{Type: rotationPolicyActionNotify},
+		}},
+		Attributes: keyRotationPolicyAttributes{ExpiryTime: "P90D"},
+	}
+	soon := date.UnixTime(time.Now().Add(72 * time.Hour))
+	later := date.UnixTime(time.Now().AddDate(0, 0, 60))
+
+	tests := []struct {
+		name            string
+		remoteKey       string
+		metadata        string
+		existing        keyvault.KeyBundle
+		current         keyRotationPolicy
+		wantErr         string
+		wantUpdate      bool
+		wantExpirations int
+	}{
+		{
+			name:       "import key and apply the policy",
+			remoteKey:  "key/signing",
+			existing:   keyvault.KeyBundle{Tags: managed},
+			wantUpdate: true,
+		},
+		{
+			name:      "unchanged key and policy",
+			remoteKey: "key/signing",
+			existing: keyvault.KeyBundle{
+				Key:        &pushedKey,
+				Tags:       managed,
+				Attributes: &keyvault.KeyAttributes{Expires: &later},
+			},
+			current: desired,
+		},
+		{
+			name:      "report the upcoming expiry of an unchanged key",
+			remoteKey: "key/signing",
+			existing: keyvault.KeyBundle{
+				Key:        &pushedKey,
+				Tags:       managed,
+				Attributes: &keyvault.KeyAttributes{Expires: &soon},
+			},
+			current:         desired,
+			wantExpirations: 1,
+		},
+		{
+			name:      "policy of secrets",
+			remoteKey: "signing",
+			wantErr:   errRotationPolicyObjectType,
+		},
+		{
+			name:      "invalid expiry time",
+			remoteKey: "key/signing",
+			metadata:  `{"rotationPolicy":{"expiryTime":"90 days"}}`,
+			wantErr:   `invalid ISO 8601 duration "90 days", expected e.g. P90D`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &fake.AzureMockClient{}
+			mockClient.WithKey("", "", "", tt.existing, nil)
+			mockClient.WithImportKey(keyvault.KeyBundle{}, nil)
+			rotationClient := &fakeRotationClient{current: tt.current}
+			az := &Azure{
+				provider:       &esv1beta1.AzureKVProvider{VaultURL: pointer.To(fakeURL)},
+				baseClient:     mockClient,
+				rotationClient: rotationClient,
+			}
+			data := testingfake.PushSecretData{SecretKey: "key", RemoteKey: tt.remoteKey, Metadata: metadata}
+			if tt.metadata != "" {
+				data.Metadata = &apiextensionsv1.JSON{Raw: []byte(tt.metadata)}
+			}
+			err := az.PushSecret(context.Background(), secret, data)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantUpdate {
+				assert.Equal(t, &desired, rotationClient.updated)
+			} else {
+				assert.Nil(t, rotationClient.updated)
+			}
+			assert.Len(t, az.SecretExpirations(), tt.wantExpirations)
+		})
+	}
+}
+
+func TestRotationPolicyClient(t *testing.T) {
+	var gotMethod, gotPath, gotVersion string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotVersion = r.Method, r.URL.Path, r.URL.Query().Get("api-version")
+		gotBody, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/keys/missing/rotationpolicy" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"lifetimeActions":[{"trigger":{"timeBeforeExpiry":"P30D"},"action":{"type":"Notify"}}],"attributes":{"expiryTime":"P90D"}}`))
+	}))
+	defer server.Close()
+
+	c := &rotationPolicyClient{Client: autorest.NewClientWithUserAgent("test")}
+	policy, err := c.GetKeyRotationPolicy(context.Background(), server.URL, "signing")
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodGet, gotMethod)
+	assert.Equal(t, "/keys/signing/rotationpolicy", gotPath)
+	assert.Equal(t, rotationPolicyAPIVersion, gotVersion)
+	assert.Equal(t, (&KeyRotationPolicy{ExpiryTime: "P90D"}).toAPI(), policy)
+
+	policy, err = c.GetKeyRotationPolicy(context.Background(), server.URL, "missing")
+	require.NoError(t, err)
+	assert.Equal(t, keyRotationPolicy{}, policy)
+
+	err = c.UpdateKeyRotationPolicy(context.Background(), server.URL, "signing", policy)
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPut, gotMethod)
+	assert.JSONEq(t, `{"lifetimeActions":null,"attributes":{}}`, string(gotBody))
+}
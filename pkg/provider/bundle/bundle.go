@@ -0,0 +1,176 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// FormatVersion is the version of the bundle format written by Seal.
+	FormatVersion = 1
+
+	// KeySize is the size of the AES-256 key the bundle is encrypted with.
+	KeySize = 32
+
+	errInvalidKeySize   = "encryption key must be %d bytes, got %d"
+	errUnsupportedFmt   = "unsupported bundle format version %d"
+	errInvalidSignature = "invalid bundle signature"
+	errDecryptBundle    = "unable to decrypt bundle: %w"
+	errDecodeBundle     = "unable to decode bundle: %w"
+	errNoPEM            = "no PEM data found"
+	errNotEd25519       = "key is not an Ed25519 key"
+)
+
+// Snapshot is the content of a bundle: the secrets read from a store at a point in time.
+type Snapshot struct {
+	// CreatedAt is the time the snapshot was exported.
+	CreatedAt time.Time `json:"createdAt"`
+	// Source describes the store the snapshot was exported from.
+	Source string `json:"source,omitempty"`
+	// Secrets maps the remote keys to their values.
+	Secrets map[string][]byte `json:"secrets"`
+}
+
+// envelope is the serialized bundle. The snapshot is encrypted with AES-GCM,
+// the signature covers the version, the nonce and the ciphertext.
+type envelope struct {
+	Version    int    `json:"version"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+	Signature  []byte `json:"signature"`
+}
+
+// Seal encrypts the snapshot with the AES-256 key and signs it with the Ed25519 private key.
+func Seal(snapshot *Snapshot, key []byte, signingKey ed25519.PrivateKey) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	env := envelope{
+		Version: FormatVersion,
+		Nonce:   nonce,
+	}
+	env.Ciphertext = aead.Seal(nil, nonce, plaintext, versionBytes(FormatVersion))
+	env.Signature = ed25519.Sign(signingKey, signedData(&env))
+	return json.Marshal(env)
+}
+
+// Open verifies the signature of the bundle with the Ed25519 public key and decrypts the snapshot.
+func Open(data, key []byte, verificationKey ed25519.PublicKey) (*Snapshot, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		// do not wrap the error, it may contain parts of the file
+		return nil, fmt.Errorf(errDecodeBundle, errors.New("invalid bundle format"))
+	}
+	if env.Version != FormatVersion {
+		return nil, fmt.Errorf(errUnsupportedFmt, env.Version)
+	}
+	if !ed25519.Verify(verificationKey, signedData(&env), env.Signature) {
+		return nil, errors.New(errInvalidSignature)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf(errDecryptBundle, errors.New("invalid nonce"))
+	}
+	plaintext, err := aead.Open(nil, env.Nonce, env.Ciphertext, versionBytes(env.Version))
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptBundle, err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(plaintext, &snapshot); err != nil {
+		return nil, fmt.Errorf(errDecodeBundle, err)
+	}
+	return &snapshot, nil
+}
+
+// ParsePublicKey parses a PEM encoded PKIX Ed25519 public key.
+func ParsePublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(errNoPEM)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New(errNotEd25519)
+	}
+	return pub, nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS #8 Ed25519 private key.
+func ParsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(errNoPEM)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New(errNotEd25519)
+	}
+	return priv, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf(errInvalidKeySize, KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func signedData(env *envelope) []byte {
+	var buf bytes.Buffer
+	buf.Write(versionBytes(env.Version))
+	buf.Write(env.Nonce)
+	buf.Write(env.Ciphertext)
+	return buf.Bytes()
+}
+
+func versionBytes(version int) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(version))
+}
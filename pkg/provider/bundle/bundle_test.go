@@ -0,0 +1,99 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKeys(t *testing.T) ([]byte, ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	key := make([]byte, KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key, pub, priv
+}
+
+func TestSealOpen(t *testing.T) {
+	key, pub, priv := testKeys(t)
+	snapshot := &Snapshot{
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Source:    "SecretStore default/aws",
+		Secrets:   map[string][]byte{"db": []byte(`{"user":"admin"}`)},
+	}
+	data, err := Seal(snapshot, key, priv)
+	require.NoError(t, err)
+
+	got, err := Open(data, key, pub)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, got)
+
+	t.Run("wrong verification key", func(t *testing.T) {
+		_, otherPub, _ := testKeys(t)
+		_, err := Open(data, key, otherPub)
+		assert.EqualError(t, err, errInvalidSignature)
+	})
+	t.Run("wrong decryption key", func(t *testing.T) {
+		otherKey, _, _ := testKeys(t)
+		_, err := Open(data, otherKey, pub)
+		assert.ErrorContains(t, err, "unable to decrypt bundle")
+	})
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		var env envelope
+		require.NoError(t, json.Unmarshal(data, &env))
+		env.Ciphertext[0] ^= 0xff
+		tampered, err := json.Marshal(env)
+		require.NoError(t, err)
+		_, err = Open(tampered, key, pub)
+		assert.EqualError(t, err, errInvalidSignature)
+	})
+	t.Run("not a bundle", func(t *testing.T) {
+		_, err := Open([]byte("root:x:0:0:root:/root:/bin/bash"), key, pub)
+		assert.EqualError(t, err, "unable to decode bundle: invalid bundle format")
+	})
+	t.Run("invalid key size", func(t *testing.T) {
+		_, err := Seal(snapshot, key[:16], priv)
+		assert.EqualError(t, err, "encryption key must be 32 bytes, got 16")
+	})
+}
+
+func TestParseKeys(t *testing.T) {
+	_, pub, priv := testKeys(t)
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	gotPriv, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}))
+	require.NoError(t, err)
+	assert.Equal(t, priv, gotPriv)
+	gotPub, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+	require.NoError(t, err)
+	assert.Equal(t, pub, gotPub)
+
+	_, err = ParsePublicKey([]byte("not pem"))
+	assert.EqualError(t, err, errNoPEM)
+}
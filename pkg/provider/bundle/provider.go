@@ -0,0 +1,286 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/feature"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	errMissingBundleProvider = "missing store provider bundle"
+	errPathNotAbsolute       = "bundle path must be an absolute path"
+	errPathNotClean          = "bundle path must not contain relative elements, use %s"
+	errPathOutsideDir        = "bundle path must be inside the bundle directory %s"
+	errNotRegularFile        = "bundle is not a regular file"
+	errBundleTooLarge        = "bundle exceeds the maximum size of %d bytes"
+	errReadBundle            = "unable to read bundle %s: %w"
+	errOpenBundle            = "unable to open bundle %s: %w"
+	errDecryptionKey         = "unable to read decryption key: %w"
+	errVerificationKey       = "unable to read verification key: %w"
+	errReadOnly              = "bundle stores are read-only, %s is not supported"
+	errTagsNotSupported      = "find by tags is not supported by bundle stores"
+	errUnmarshalSecret       = "unable to unmarshal secret: %w"
+
+	defaultBundleDir     = "/var/run/external-secrets/bundles"
+	defaultMaxBundleSize = 64 << 20
+)
+
+var (
+	bundleDir     string
+	maxBundleSize int64
+)
+
+// Provider reads secrets from an offline snapshot bundle.
+type Provider struct{}
+
+// client serves the secrets of an opened snapshot.
+type client struct {
+	snapshot *Snapshot
+}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+// UnsupportedRemoteRefFeatures returns the remote ref features bundle stores reject.
+func (p *Provider) UnsupportedRemoteRefFeatures(_ esv1beta1.GenericStore) []esv1beta1.RemoteRefFeature {
+	return []esv1beta1.RemoteRefFeature{
+		esv1beta1.RemoteRefFeatureFindTags,
+	}
+}
+
+// NewClient verifies and decrypts the bundle referenced by the store.
+// The bundle is read on every call, so an updated bundle is picked up on the next sync.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKey, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &prov.DecryptionKeySecretRef)
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptionKey, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf(errDecryptionKey, err)
+	}
+	pemKey, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &prov.VerificationKeySecretRef)
+	if err != nil {
+		return nil, fmt.Errorf(errVerificationKey, err)
+	}
+	verificationKey, err := ParsePublicKey([]byte(pemKey))
+	if err != nil {
+		return nil, fmt.Errorf(errVerificationKey, err)
+	}
+
+	data, err := readBundle(prov.Path)
+	if err != nil {
+		return nil, fmt.Errorf(errReadBundle, prov.Path, err)
+	}
+	snapshot, err := Open(data, key, verificationKey)
+	if err != nil {
+		return nil, fmt.Errorf(errOpenBundle, prov.Path, err)
+	}
+	return &client{snapshot: snapshot}, nil
+}
+
+// readBundle reads the bundle at path after resolving its symlinks.
+// Only regular files inside the bundle directory are read, up to the maximum bundle size.
+func readBundle(path string) ([]byte, error) {
+	dir, err := filepath.EvalSymlinks(bundleDir)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := filepath.EvalSymlinks(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(dir, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf(errPathOutsideDir, bundleDir)
+	}
+	// stat before opening, opening a fifo would block until it has a writer
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, errors.New(errNotRegularFile)
+	}
+	f, err := os.Open(resolved)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBundleSize {
+		return nil, fmt.Errorf(errBundleTooLarge, maxBundleSize)
+	}
+	return data, nil
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.BundleProvider, error) {
+	if store == nil {
+		return nil, errors.New(errMissingBundleProvider)
+	}
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil || spec.Provider.Bundle == nil {
+		return nil, errors.New(errMissingBundleProvider)
+	}
+	return spec.Provider.Bundle, nil
+}
+
+// ValidateStore checks the bundle path and the key references of the store.
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(prov.Path) {
+		return nil, errors.New(errPathNotAbsolute)
+	}
+	if clean := filepath.Clean(prov.Path); clean != prov.Path {
+		return nil, fmt.Errorf(errPathNotClean, clean)
+	}
+	if err := utils.ValidateSecretSelector(store, prov.DecryptionKeySecretRef); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateSecretSelector(store, prov.VerificationKeySecretRef); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// GetSecret returns a single secret of the snapshot.
+func (c *client) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, ok := c.snapshot.Secrets[ref.Key]
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	if ref.Property == "" {
+		return value, nil
+	}
+	val := gjson.GetBytes(value, ref.Property)
+	if !val.Exists() {
+		return nil, esv1beta1.NoSecretErr
+	}
+	return []byte(val.String()), nil
+}
+
+// GetSecretMap returns the key/value pairs of a JSON secret of the snapshot.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	kv := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &kv); err != nil {
+		return nil, fmt.Errorf(errUnmarshalSecret, err)
+	}
+	secretData := make(map[string][]byte, len(kv))
+	for k, v := range kv {
+		var strVal string
+		if err := json.Unmarshal(v, &strVal); err == nil {
+			secretData[k] = []byte(strVal)
+		} else {
+			secretData[k] = v
+		}
+	}
+	return secretData, nil
+}
+
+// GetAllSecrets returns the secrets of the snapshot matching the name and path.
+func (c *client) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errors.New(errTagsNotSupported)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		m, err := find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		matcher = m
+	}
+	data := make(map[string][]byte)
+	for key, value := range c.snapshot.Secrets {
+		if ref.Path != nil && !strings.HasPrefix(key, *ref.Path) {
+			continue
+		}
+		if matcher != nil && !matcher.MatchName(key) {
+			continue
+		}
+		data[key] = value
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, data)
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return fmt.Errorf(errReadOnly, "PushSecret")
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return fmt.Errorf(errReadOnly, "DeleteSecret")
+}
+
+func (c *client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, fmt.Errorf(errReadOnly, "SecretExists")
+}
+
+// Validate reports the store as ready, the bundle has already been verified when the client was created.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(_ context.Context) error {
+	return nil
+}
+
+func init() {
+	fs := pflag.NewFlagSet("bundle", pflag.ExitOnError)
+	fs.StringVar(&bundleDir, "bundle-dir", defaultBundleDir, "Directory bundle stores read their bundles from, bundles outside of it are rejected.")
+	fs.Int64Var(&maxBundleSize, "bundle-max-size", defaultMaxBundleSize, "Maximum size in bytes of a bundle read by a bundle store.")
+	feature.Register(feature.Feature{
+		Flags: fs,
+	})
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Bundle: &esv1beta1.BundleProvider{},
+	})
+}
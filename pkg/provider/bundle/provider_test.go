@@ -0,0 +1,159 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestProvider(t *testing.T) {
+	key, pub, priv := testKeys(t)
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	data, err := Seal(&Snapshot{
+		CreatedAt: time.Now(),
+		Secrets: map[string][]byte{
+			"app/db":    []byte(`{"user":"admin","port":5432}`),
+			"app/token": []byte("t0k3n"),
+			"other/key": []byte("value"),
+		},
+	}, key, priv)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "bundle.json")
+	require.NoError(t, os.WriteFile(bundlePath, data, 0o600))
+	setBundleDir(t, dir, defaultMaxBundleSize)
+
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle-keys", Namespace: "default"},
+		Data: map[string][]byte{
+			"key": []byte(base64.StdEncoding.EncodeToString(key)),
+			"pub": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}),
+		},
+	}).Build()
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Bundle: &esv1beta1.BundleProvider{
+					Path:                     bundlePath,
+					DecryptionKeySecretRef:   esmeta.SecretKeySelector{Name: "bundle-keys", Key: "key"},
+					VerificationKeySecretRef: esmeta.SecretKeySelector{Name: "bundle-keys", Key: "pub"},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	p := &Provider{}
+	_, err = p.ValidateStore(store)
+	require.NoError(t, err)
+	c, err := p.NewClient(ctx, store, kube, "default")
+	require.NoError(t, err)
+
+	got, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app/token"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("t0k3n"), got)
+
+	got, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db", Property: "user"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("admin"), got)
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+
+	gotMap, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "app/db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"user": []byte("admin"), "port": []byte("5432")}, gotMap)
+
+	path := "app/"
+	gotAll, err := c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: &path, Name: &esv1beta1.FindName{RegExp: "token"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"app/token": []byte("t0k3n")}, gotAll)
+
+	assert.EqualError(t, c.PushSecret(ctx, nil, nil), "bundle stores are read-only, PushSecret is not supported")
+
+	t.Run("missing bundle", func(t *testing.T) {
+		missing := store.DeepCopy()
+		missing.Spec.Provider.Bundle.Path = filepath.Join(dir, "missing.json")
+		_, err := p.NewClient(ctx, missing, kube, "default")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+	t.Run("path outside of the bundle directory", func(t *testing.T) {
+		outsidePath := filepath.Join(t.TempDir(), "bundle.json")
+		require.NoError(t, os.WriteFile(outsidePath, data, 0o600))
+		outside := store.DeepCopy()
+		outside.Spec.Provider.Bundle.Path = outsidePath
+		_, err := p.NewClient(ctx, outside, kube, "default")
+		assert.EqualError(t, err, fmt.Sprintf("unable to read bundle %s: bundle path must be inside the bundle directory %s", outsidePath, dir))
+	})
+	t.Run("symlink out of the bundle directory", func(t *testing.T) {
+		link := filepath.Join(dir, "zero.json")
+		require.NoError(t, os.Symlink("/dev/zero", link))
+		linked := store.DeepCopy()
+		linked.Spec.Provider.Bundle.Path = link
+		_, err := p.NewClient(ctx, linked, kube, "default")
+		assert.EqualError(t, err, fmt.Sprintf("unable to read bundle %s: bundle path must be inside the bundle directory %s", link, dir))
+	})
+	t.Run("device file", func(t *testing.T) {
+		setBundleDir(t, "/dev", defaultMaxBundleSize)
+		device := store.DeepCopy()
+		device.Spec.Provider.Bundle.Path = "/dev/zero"
+		_, err := p.NewClient(ctx, device, kube, "default")
+		assert.EqualError(t, err, "unable to read bundle /dev/zero: bundle is not a regular file")
+	})
+	t.Run("oversized bundle", func(t *testing.T) {
+		setBundleDir(t, dir, int64(len(data)-1))
+		_, err := p.NewClient(ctx, store, kube, "default")
+		assert.EqualError(t, err, fmt.Sprintf("unable to read bundle %s: bundle exceeds the maximum size of %d bytes", bundlePath, len(data)-1))
+	})
+	t.Run("relative path", func(t *testing.T) {
+		relative := store.DeepCopy()
+		relative.Spec.Provider.Bundle.Path = "bundle.json"
+		_, err := p.ValidateStore(relative)
+		assert.EqualError(t, err, errPathNotAbsolute)
+	})
+	t.Run("path with relative elements", func(t *testing.T) {
+		traversal := store.DeepCopy()
+		traversal.Spec.Provider.Bundle.Path = "/var/run/external-secrets/bundles/../../../../etc/passwd"
+		_, err := p.ValidateStore(traversal)
+		assert.EqualError(t, err, "bundle path must not contain relative elements, use /etc/passwd")
+	})
+}
+
+func setBundleDir(t *testing.T, dir string, maxSize int64) {
+	t.Helper()
+	prevDir, prevSize := bundleDir, maxBundleSize
+	bundleDir, maxBundleSize = dir, maxSize
+	t.Cleanup(func() { bundleDir, maxBundleSize = prevDir, prevSize })
+}
@@ -0,0 +1,260 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The client talks to the REST API of Cloudflare, see https://developers.cloudflare.com/api/.
+const (
+	kvPathFormat           = "/accounts/%s/storage/kv/namespaces/%s"
+	secretsStorePathFormat = "/accounts/%s/secrets_store/stores/%s/secrets"
+
+	// kvListLimit is the maximum page size of the keys of a namespace.
+	kvListLimit = 1000
+	// secretsStorePageSize is the page size of the secrets of a store.
+	secretsStorePageSize = 100
+	// maxResponseSize bounds the size of a response read from Cloudflare, KV values are limited to 25 MiB.
+	maxResponseSize = 32 << 20
+
+	// secretsStoreScope makes pushed secrets available to Workers bindings.
+	secretsStoreScope = "workers"
+	managedComment    = "managed by external-secrets"
+
+	errRequest  = "request to Cloudflare failed: %w"
+	errResponse = "Cloudflare returned %s: %s"
+)
+
+var errNotFound = errors.New("not found")
+
+// envelope is the common structure of the JSON responses of the API.
+type envelope struct {
+	Success    bool            `json:"success"`
+	Errors     []apiMessage    `json:"errors"`
+	Result     json.RawMessage `json:"result"`
+	ResultInfo resultInfo      `json:"result_info"`
+}
+
+type apiMessage struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type resultInfo struct {
+	Cursor     string `json:"cursor"`
+	Page       int    `json:"page"`
+	TotalPages int    `json:"total_pages"`
+}
+
+type kvKey struct {
+	Name string `json:"name"`
+}
+
+type storeSecret struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Value   string   `json:"value,omitempty"`
+	Scopes  []string `json:"scopes,omitempty"`
+	Comment string   `json:"comment,omitempty"`
+}
+
+// kvGetValue returns the value of the key, or errNotFound if the namespace has no such key.
+func (c *client) kvGetValue(ctx context.Context, key string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, c.kvValuePath(key), nil, "", nil)
+}
+
+// kvListKeys returns the names of all keys of the namespace with the prefix.
+func (c *client) kvListKeys(ctx context.Context, prefix string) ([]string, error) {
+	var names []string
+	cursor := ""
+	for {
+		query := url.Values{"limit": {strconv.Itoa(kvListLimit)}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		var keys []kvKey
+		info, err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf(kvPathFormat, c.accountID, c.kvNamespaceID)+"/keys?"+query.Encode(), nil, &keys)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			names = append(names, k.Name)
+		}
+		if info.Cursor == "" {
+			return names, nil
+		}
+		cursor = info.Cursor
+	}
+}
+
+// kvPutValue writes the value of the key, the metadata of the key marks it as managed by external-secrets.
+func (c *client) kvPutValue(ctx context.Context, key string, value []byte) error {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("value", string(value)); err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	if err := form.WriteField("metadata", `{"managed-by":"external-secrets"}`); err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	if err := form.Close(); err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	_, err := c.do(ctx, http.MethodPut, c.kvValuePath(key), &body, form.FormDataContentType(), nil)
+	return err
+}
+
+func (c *client) kvDeleteValue(ctx context.Context, key string) error {
+	_, err := c.do(ctx, http.MethodDelete, c.kvValuePath(key), nil, "", nil)
+	return err
+}
+
+func (c *client) kvValuePath(key string) string {
+	return fmt.Sprintf(kvPathFormat, c.accountID, c.kvNamespaceID) + "/values/" + url.PathEscape(key)
+}
+
+// findStoreSecret returns the secret of the store with the name, or nil if the store has no such secret.
+func (c *client) findStoreSecret(ctx context.Context, name string) (*storeSecret, error) {
+	for page := 1; ; page++ {
+		query := url.Values{
+			"search":   {name},
+			"page":     {strconv.Itoa(page)},
+			"per_page": {strconv.Itoa(secretsStorePageSize)},
+		}
+		var secrets []storeSecret
+		info, err := c.doJSON(ctx, http.MethodGet, c.secretsStorePath()+"?"+query.Encode(), nil, &secrets)
+		if err != nil {
+			return nil, err
+		}
+		// search matches substrings of the names, the name must match exactly
+		for i := range secrets {
+			if secrets[i].Name == name {
+				return &secrets[i], nil
+			}
+		}
+		if page >= info.TotalPages {
+			return nil, nil
+		}
+	}
+}
+
+func (c *client) createStoreSecret(ctx context.Context, name string, value []byte) error {
+	req := []storeSecret{{Name: name, Value: string(value), Scopes: []string{secretsStoreScope}, Comment: managedComment}}
+	_, err := c.doJSON(ctx, http.MethodPost, c.secretsStorePath(), req, nil)
+	return err
+}
+
+func (c *client) updateStoreSecret(ctx context.Context, id string, value []byte) error {
+	req := storeSecret{Value: string(value)}
+	_, err := c.doJSON(ctx, http.MethodPatch, c.secretsStorePath()+"/"+url.PathEscape(id), req, nil)
+	return err
+}
+
+func (c *client) deleteStoreSecret(ctx context.Context, id string) error {
+	_, err := c.doJSON(ctx, http.MethodDelete, c.secretsStorePath()+"/"+url.PathEscape(id), nil, nil)
+	return err
+}
+
+func (c *client) secretsStorePath() string {
+	return fmt.Sprintf(secretsStorePathFormat, c.accountID, c.secretsStoreID)
+}
+
+// doJSON sends the request as JSON and decodes the result of the response envelope into result.
+func (c *client) doJSON(ctx context.Context, method, path string, req, result any) (resultInfo, error) {
+	var body io.Reader
+	contentType := ""
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return resultInfo{}, fmt.Errorf(errRequest, err)
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+	var env envelope
+	if _, err := c.do(ctx, method, path, body, contentType, &env); err != nil {
+		return resultInfo{}, err
+	}
+	if result != nil && len(env.Result) > 0 {
+		if err := json.Unmarshal(env.Result, result); err != nil {
+			return resultInfo{}, fmt.Errorf(errRequest, err)
+		}
+	}
+	return env.ResultInfo, nil
+}
+
+// do sends the request and returns the body of the response. If env is set, the body is decoded into it.
+// Responses with status 404 return errNotFound.
+func (c *client) do(ctx context.Context, method, path string, body io.Reader, contentType string, env *envelope) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, body)
+	if err != nil {
+		return nil, fmt.Errorf(errRequest, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf(errRequest, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf(errRequest, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf(errResponse, resp.Status, errorMessage(data))
+	}
+	if env != nil {
+		if err := json.Unmarshal(data, env); err != nil {
+			return nil, fmt.Errorf(errRequest, err)
+		}
+		if !env.Success {
+			return nil, fmt.Errorf(errResponse, resp.Status, errorMessage(data))
+		}
+	}
+	return data, nil
+}
+
+// errorMessage returns the messages of the errors of the response envelope, or the body if it is no envelope.
+func errorMessage(data []byte) string {
+	var env envelope
+	if json.Unmarshal(data, &env) != nil || len(env.Errors) == 0 {
+		return strings.TrimSpace(string(data))
+	}
+	msgs := make([]string, len(env.Errors))
+	for i, e := range env.Errors {
+		msgs[i] = fmt.Sprintf("%s (%d)", e.Message, e.Code)
+	}
+	return strings.Join(msgs, ", ")
+}
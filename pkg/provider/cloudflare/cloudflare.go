@@ -0,0 +1,363 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	corev1 "k8s.io/api/core/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	defaultURL     = "https://api.cloudflare.com/client/v4"
+	requestTimeout = 30 * time.Second
+
+	errMissingCloudflareProvider = "missing store provider cloudflare"
+	errMissingAccountID          = "accountID is required"
+	errNamespaceOrStore          = "exactly one of kvNamespaceID and secretsStoreID must be set"
+	errInvalidURL                = "invalid url %q: %w"
+	errInvalidAPITokenRef        = "invalid auth.apiTokenSecretRef: %w"
+	errResolveAPIToken           = "could not resolve the API token: %w"
+	errSecretsStoreNotReadable   = "the values of the Cloudflare Secrets Store can't be read, use a Workers KV namespace to sync secrets from Cloudflare"
+	errVersionNotSupported       = "versions are not supported by Workers KV"
+	errPropertyNotFound          = "property %s not found in key %s"
+	errNotJSONObject             = "value of key %s is not a JSON object: %w"
+	errFindByTags                = "find by tags is not supported by cloudflare"
+	errPropertyNotSupported      = "properties are not supported by the Secrets Store"
+	errPushWholeSecret           = "pushing the whole secret is not yet implemented"
+	errSetProperty               = "could not set property %s of key %s: %w"
+)
+
+var _ esv1beta1.SecretsClient = &client{}
+var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.RemoteRefFeatureValidator = &Provider{}
+
+// Provider syncs secrets with the Workers KV namespaces and the Secrets Stores of Cloudflare.
+type Provider struct{}
+
+// client syncs the keys of a single KV namespace or the secrets of a single Secrets Store.
+type client struct {
+	http           *http.Client
+	url            string
+	apiToken       string
+	accountID      string
+	kvNamespaceID  string
+	secretsStoreID string
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Cloudflare: &esv1beta1.CloudflareProvider{},
+	})
+}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadWrite
+}
+
+// UnsupportedRemoteRefFeatures returns the remote ref features Cloudflare rejects: Workers KV keys have neither versions nor tags,
+// and only single keys of a Secret can be pushed.
+func (p *Provider) UnsupportedRemoteRefFeatures(_ esv1beta1.GenericStore) []esv1beta1.RemoteRefFeature {
+	return []esv1beta1.RemoteRefFeature{
+		esv1beta1.RemoteRefFeatureVersion,
+		esv1beta1.RemoteRefFeatureFindTags,
+		esv1beta1.RemoteRefFeaturePushWholeSecret,
+	}
+}
+
+// NewClient resolves the API token of the store.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	token, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &prov.Auth.APITokenSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf(errResolveAPIToken, err)
+	}
+	apiURL := defaultURL
+	if prov.URL != "" {
+		apiURL = strings.TrimSuffix(prov.URL, "/")
+	}
+	return &client{
+		http:           &http.Client{Timeout: requestTimeout},
+		url:            apiURL,
+		apiToken:       strings.TrimSpace(token),
+		accountID:      prov.AccountID,
+		kvNamespaceID:  prov.KVNamespaceID,
+		secretsStoreID: prov.SecretsStoreID,
+	}, nil
+}
+
+// ValidateStore checks the account, the namespace or store and the API token reference of the store.
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if prov.AccountID == "" {
+		return nil, errors.New(errMissingAccountID)
+	}
+	if (prov.KVNamespaceID == "") == (prov.SecretsStoreID == "") {
+		return nil, errors.New(errNamespaceOrStore)
+	}
+	if prov.URL != "" {
+		if _, err := url.ParseRequestURI(prov.URL); err != nil {
+			return nil, fmt.Errorf(errInvalidURL, prov.URL, err)
+		}
+	}
+	if err := utils.ValidateReferentSecretSelector(store, prov.Auth.APITokenSecretRef); err != nil {
+		return nil, fmt.Errorf(errInvalidAPITokenRef, err)
+	}
+	return nil, nil
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.CloudflareProvider, error) {
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil || spec.Provider.Cloudflare == nil {
+		return nil, errors.New(errMissingCloudflareProvider)
+	}
+	return spec.Provider.Cloudflare, nil
+}
+
+// GetSecret returns the value of the KV key. With a property the value is expected to be JSON,
+// nested values are supported by gjson expressions.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if c.secretsStoreID != "" {
+		return nil, errors.New(errSecretsStoreNotReadable)
+	}
+	if ref.Version != "" {
+		return nil, errors.New(errVersionNotSupported)
+	}
+	value, err := c.getValue(ctx, ref.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return value, nil
+	}
+	result := gjson.GetBytes(value, ref.Property)
+	if !result.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	if result.Type == gjson.String {
+		return []byte(result.Str), nil
+	}
+	return []byte(result.Raw), nil
+}
+
+// GetSecretMap returns the entries of the JSON object stored in the KV key.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	value, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var entries map[string]any
+	if err := json.Unmarshal(value, &entries); err != nil {
+		return nil, fmt.Errorf(errNotJSONObject, ref.Key, err)
+	}
+	data := make(map[string][]byte, len(entries))
+	for k := range entries {
+		data[k], err = utils.GetByteValueFromMap(entries, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// GetAllSecrets returns the KV keys starting with the find path, filtered by the find name.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if c.secretsStoreID != "" {
+		return nil, errors.New(errSecretsStoreNotReadable)
+	}
+	if len(ref.Tags) > 0 {
+		return nil, errors.New(errFindByTags)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	prefix := ""
+	if ref.Path != nil {
+		prefix = *ref.Path
+	}
+	names, err := c.kvListKeys(ctx, prefix)
+	metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareKVListKeys, err)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string][]byte, len(names))
+	for _, name := range names {
+		if matcher != nil && !matcher.MatchName(name) {
+			continue
+		}
+		value, err := c.getValue(ctx, name)
+		// the key may have been deleted since it was listed
+		if errors.Is(err, esv1beta1.NoSecretError{}) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		data[name] = value
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, data)
+}
+
+func (c *client) getValue(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.kvGetValue(ctx, key)
+	if errors.Is(err, errNotFound) {
+		metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareKVGetValue, nil)
+		return nil, esv1beta1.NoSecretError{}
+	}
+	metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareKVGetValue, err)
+	return value, err
+}
+
+// PushSecret writes the value to the KV key or the secret of the Secrets Store.
+// With a property the KV key holds a JSON object and only the property is set.
+// The Secrets Store doesn't expose the values of its secrets, so existing secrets are always updated.
+func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	if data.GetSecretKey() == "" {
+		return errors.New(errPushWholeSecret)
+	}
+	value := secret.Data[data.GetSecretKey()]
+	if c.secretsStoreID != "" {
+		return c.pushStoreSecret(ctx, data, value)
+	}
+
+	current, err := c.getValue(ctx, data.GetRemoteKey())
+	if err != nil && !errors.Is(err, esv1beta1.NoSecretError{}) {
+		return err
+	}
+	if data.GetProperty() != "" {
+		if len(current) == 0 {
+			current = []byte("{}")
+		}
+		value, err = sjson.SetBytes(current, data.GetProperty(), string(value))
+		if err != nil {
+			return fmt.Errorf(errSetProperty, data.GetProperty(), data.GetRemoteKey(), err)
+		}
+	}
+	if current != nil && bytes.Equal(current, value) {
+		return nil
+	}
+	err = c.kvPutValue(ctx, data.GetRemoteKey(), value)
+	metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareKVPutValue, err)
+	return err
+}
+
+func (c *client) pushStoreSecret(ctx context.Context, data esv1beta1.PushSecretData, value []byte) error {
+	if data.GetProperty() != "" {
+		return errors.New(errPropertyNotSupported)
+	}
+	existing, err := c.findStoreSecret(ctx, data.GetRemoteKey())
+	metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareSecretsStoreList, err)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		err = c.createStoreSecret(ctx, data.GetRemoteKey(), value)
+		metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareSecretsStoreCreate, err)
+		return err
+	}
+	err = c.updateStoreSecret(ctx, existing.ID, value)
+	metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareSecretsStoreUpdate, err)
+	return err
+}
+
+// DeleteSecret deletes the KV key or the secret of the Secrets Store, keys that don't exist are ignored.
+func (c *client) DeleteSecret(ctx context.Context, ref esv1beta1.PushSecretRemoteRef) error {
+	if c.secretsStoreID != "" {
+		existing, err := c.findStoreSecret(ctx, ref.GetRemoteKey())
+		metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareSecretsStoreList, err)
+		if err != nil || existing == nil {
+			return err
+		}
+		err = c.deleteStoreSecret(ctx, existing.ID)
+		metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareSecretsStoreDelete, err)
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		return err
+	}
+	err := c.kvDeleteValue(ctx, ref.GetRemoteKey())
+	metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareKVDeleteValue, err)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	return err
+}
+
+// SecretExists returns true if the KV key or the secret of the Secrets Store exists.
+func (c *client) SecretExists(ctx context.Context, ref esv1beta1.PushSecretRemoteRef) (bool, error) {
+	if c.secretsStoreID != "" {
+		existing, err := c.findStoreSecret(ctx, ref.GetRemoteKey())
+		metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareSecretsStoreList, err)
+		return existing != nil, err
+	}
+	_, err := c.getValue(ctx, ref.GetRemoteKey())
+	if errors.Is(err, esv1beta1.NoSecretError{}) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Validate lists the KV keys or the secrets of the store, which requires a valid API token with read permissions.
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx := context.Background()
+	var err error
+	if c.secretsStoreID != "" {
+		_, err = c.doJSON(ctx, http.MethodGet, c.secretsStorePath()+"?per_page=1", nil, nil)
+		metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareSecretsStoreList, err)
+	} else {
+		_, err = c.doJSON(ctx, http.MethodGet, fmt.Sprintf(kvPathFormat, c.accountID, c.kvNamespaceID)+"/keys?limit=10", nil, nil)
+		metrics.ObserveAPICall(constants.ProviderCloudflare, constants.CallCloudflareKVListKeys, err)
+	}
+	if err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) Close(_ context.Context) error {
+	c.http.CloseIdleConnections()
+	return nil
+}
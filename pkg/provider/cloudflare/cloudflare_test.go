@@ -0,0 +1,345 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+const (
+	testToken   = "cf-token"
+	kvPrefix    = "/accounts/acc/storage/kv/namespaces/ns"
+	storePrefix = "/accounts/acc/secrets_store/stores/store/secrets"
+)
+
+// fakeCloudflare serves the KV and Secrets Store calls from the kv and store maps,
+// the keys of a namespace are listed two per page.
+type fakeCloudflare struct {
+	t      *testing.T
+	kv     map[string]string
+	store  map[string]string
+	writes int
+}
+
+func (f *fakeCloudflare) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10000,"message":"Authentication error"}]}`))
+		return
+	}
+	switch {
+	case strings.HasPrefix(r.URL.Path, kvPrefix+"/values/"):
+		key := strings.TrimPrefix(r.URL.Path, kvPrefix+"/values/")
+		switch r.Method {
+		case http.MethodGet:
+			value, ok := f.kv[key]
+			if !ok {
+				f.notFound(w)
+				return
+			}
+			_, _ = w.Write([]byte(value))
+		case http.MethodPut:
+			require.NoError(f.t, r.ParseMultipartForm(1<<20))
+			assert.JSONEq(f.t, `{"managed-by":"external-secrets"}`, r.FormValue("metadata"))
+			f.kv[key] = r.FormValue("value")
+			f.writes++
+			f.result(w, nil, nil)
+		case http.MethodDelete:
+			if _, ok := f.kv[key]; !ok {
+				f.notFound(w)
+				return
+			}
+			delete(f.kv, key)
+			f.result(w, nil, nil)
+		}
+	case r.URL.Path == kvPrefix+"/keys":
+		var names []string
+		for k := range f.kv {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				names = append(names, k)
+			}
+		}
+		sort.Strings(names)
+		start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		end := min(start+2, len(names))
+		keys := make([]kvKey, 0, end-start)
+		for _, name := range names[start:end] {
+			keys = append(keys, kvKey{Name: name})
+		}
+		cursor := ""
+		if end < len(names) {
+			cursor = strconv.Itoa(end)
+		}
+		f.result(w, keys, &resultInfo{Cursor: cursor})
+	case r.URL.Path == storePrefix && r.Method == http.MethodGet:
+		var secrets []storeSecret
+		for name := range f.store {
+			if strings.Contains(name, r.URL.Query().Get("search")) {
+				secrets = append(secrets, storeSecret{ID: "id-" + name, Name: name})
+			}
+		}
+		f.result(w, secrets, &resultInfo{Page: 1, TotalPages: 1})
+	case r.URL.Path == storePrefix && r.Method == http.MethodPost:
+		var req []storeSecret
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&req))
+		for _, s := range req {
+			assert.Equal(f.t, []string{secretsStoreScope}, s.Scopes)
+			f.store[s.Name] = s.Value
+		}
+		f.writes++
+		f.result(w, req, nil)
+	case strings.HasPrefix(r.URL.Path, storePrefix+"/id-"):
+		name := strings.TrimPrefix(r.URL.Path, storePrefix+"/id-")
+		if _, ok := f.store[name]; !ok {
+			f.notFound(w)
+			return
+		}
+		switch r.Method {
+		case http.MethodPatch:
+			var req storeSecret
+			require.NoError(f.t, json.NewDecoder(r.Body).Decode(&req))
+			f.store[name] = req.Value
+			f.writes++
+		case http.MethodDelete:
+			delete(f.store, name)
+		}
+		f.result(w, nil, nil)
+	default:
+		f.notFound(w)
+	}
+}
+
+func (f *fakeCloudflare) result(w http.ResponseWriter, result any, info *resultInfo) {
+	resp := map[string]any{"success": true, "errors": []any{}, "result": result}
+	if info != nil {
+		resp["result_info"] = info
+	}
+	require.NoError(f.t, json.NewEncoder(w).Encode(resp))
+}
+
+func (f *fakeCloudflare) notFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":10009,"message":"get: 'key not found'"}]}`))
+}
+
+func newTestClient(t *testing.T, server *httptest.Server, prov esv1beta1.CloudflareProvider) esv1beta1.SecretsClient {
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte(testToken + "\n")},
+	}).Build()
+	prov.AccountID = "acc"
+	prov.URL = server.URL + "/"
+	prov.Auth = esv1beta1.CloudflareAuth{APITokenSecretRef: esmeta.SecretKeySelector{Name: "cloudflare", Key: "token"}}
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare", Namespace: "default"},
+		Spec:       esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Cloudflare: &prov}},
+	}
+	p := &Provider{}
+	_, err := p.ValidateStore(store)
+	require.NoError(t, err)
+	c, err := p.NewClient(context.Background(), store, kube, "default")
+	require.NoError(t, err)
+	return c
+}
+
+func TestWorkersKV(t *testing.T) {
+	fake := &fakeCloudflare{t: t, kv: map[string]string{
+		"db":       `{"user":"admin","port":5432,"nested":{"password":"s3cr3t"}}`,
+		"token":    "t0k3n",
+		"mq/user":  "guest",
+		"mq/pass":  "guest",
+		"mq/vhost": "/",
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	ctx := context.Background()
+	c := newTestClient(t, server, esv1beta1.CloudflareProvider{KVNamespaceID: "ns"})
+
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	value, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "t0k3n", string(value))
+
+	value, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Property: "nested.password"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(value))
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretError{})
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "token", Version: "2"})
+	assert.EqualError(t, err, errVersionNotSupported)
+
+	data, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"user":   []byte("admin"),
+		"port":   []byte("5432"),
+		"nested": []byte(`{"password":"s3cr3t"}`),
+	}, data)
+
+	path := "mq/"
+	data, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: &path, Name: &esv1beta1.FindName{RegExp: "(user|vhost)$"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"mq/user": []byte("guest"), "mq/vhost": []byte("/")}, data)
+
+	_, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "a"}})
+	assert.EqualError(t, err, errFindByTags)
+
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("n3w")}}
+	require.NoError(t, c.PushSecret(ctx, secret, testingfake.PushSecretData{SecretKey: "password", RemoteKey: "api/password"}))
+	assert.Equal(t, "n3w", fake.kv["api/password"])
+	// unchanged values are not written again
+	require.NoError(t, c.PushSecret(ctx, secret, testingfake.PushSecretData{SecretKey: "password", RemoteKey: "api/password"}))
+	assert.Equal(t, 1, fake.writes)
+
+	require.NoError(t, c.PushSecret(ctx, secret, testingfake.PushSecretData{SecretKey: "password", RemoteKey: "db", Property: "nested.password"}))
+	assert.JSONEq(t, `{"user":"admin","port":5432,"nested":{"password":"n3w"}}`, fake.kv["db"])
+
+	exists, err := c.SecretExists(ctx, testingfake.PushSecretData{RemoteKey: "api/password"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+	require.NoError(t, c.DeleteSecret(ctx, testingfake.PushSecretData{RemoteKey: "api/password"}))
+	require.NoError(t, c.DeleteSecret(ctx, testingfake.PushSecretData{RemoteKey: "api/password"}))
+	exists, err = c.SecretExists(ctx, testingfake.PushSecretData{RemoteKey: "api/password"})
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestSecretsStore(t *testing.T) {
+	fake := &fakeCloudflare{t: t, store: map[string]string{"db_password": "old"}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	ctx := context.Background()
+	c := newTestClient(t, server, esv1beta1.CloudflareProvider{SecretsStoreID: "store"})
+
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db_password"})
+	assert.EqualError(t, err, errSecretsStoreNotReadable)
+
+	secret := &corev1.Secret{Data: map[string][]byte{"password": []byte("n3w")}}
+	require.NoError(t, c.PushSecret(ctx, secret, testingfake.PushSecretData{SecretKey: "password", RemoteKey: "db_password"}))
+	require.NoError(t, c.PushSecret(ctx, secret, testingfake.PushSecretData{SecretKey: "password", RemoteKey: "db"}))
+	assert.Equal(t, map[string]string{"db_password": "n3w", "db": "n3w"}, fake.store)
+
+	err = c.PushSecret(ctx, secret, testingfake.PushSecretData{SecretKey: "password", RemoteKey: "db", Property: "password"})
+	assert.EqualError(t, err, errPropertyNotSupported)
+
+	require.NoError(t, c.DeleteSecret(ctx, testingfake.PushSecretData{RemoteKey: "db"}))
+	exists, err := c.SecretExists(ctx, testingfake.PushSecretData{RemoteKey: "db"})
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = c.SecretExists(ctx, testingfake.PushSecretData{RemoteKey: "db_password"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestAuthenticationError(t *testing.T) {
+	server := httptest.NewServer(&fakeCloudflare{t: t})
+	defer server.Close()
+	c := &client{http: server.Client(), url: server.URL, apiToken: "invalid", accountID: "acc", kvNamespaceID: "ns"}
+	_, err := c.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "token"})
+	assert.EqualError(t, err, fmt.Sprintf(errResponse, "403 Forbidden", "Authentication error (10000)"))
+}
+
+func TestValidateStore(t *testing.T) {
+	auth := esv1beta1.CloudflareAuth{APITokenSecretRef: esmeta.SecretKeySelector{Name: "cloudflare", Key: "token"}}
+	namespace := "default"
+	tests := []struct {
+		name    string
+		kind    string
+		prov    *esv1beta1.CloudflareProvider
+		wantErr string
+	}{
+		{
+			name: "kv namespace",
+			prov: &esv1beta1.CloudflareProvider{AccountID: "acc", KVNamespaceID: "ns", Auth: auth},
+		},
+		{
+			name: "referent cluster store",
+			kind: esv1beta1.ClusterSecretStoreKind,
+			prov: &esv1beta1.CloudflareProvider{AccountID: "acc", SecretsStoreID: "store", Auth: auth},
+		},
+		{
+			name:    "missing provider",
+			wantErr: errMissingCloudflareProvider,
+		},
+		{
+			name:    "missing account",
+			prov:    &esv1beta1.CloudflareProvider{KVNamespaceID: "ns", Auth: auth},
+			wantErr: errMissingAccountID,
+		},
+		{
+			name:    "namespace and store",
+			prov:    &esv1beta1.CloudflareProvider{AccountID: "acc", KVNamespaceID: "ns", SecretsStoreID: "store", Auth: auth},
+			wantErr: errNamespaceOrStore,
+		},
+		{
+			name:    "neither namespace nor store",
+			prov:    &esv1beta1.CloudflareProvider{AccountID: "acc", Auth: auth},
+			wantErr: errNamespaceOrStore,
+		},
+		{
+			name:    "invalid url",
+			prov:    &esv1beta1.CloudflareProvider{AccountID: "acc", KVNamespaceID: "ns", URL: "api", Auth: auth},
+			wantErr: `invalid url "api": parse "api": invalid URI for request`,
+		},
+		{
+			name: "namespace in namespaced store",
+			prov: &esv1beta1.CloudflareProvider{AccountID: "acc", KVNamespaceID: "ns", Auth: esv1beta1.CloudflareAuth{
+				APITokenSecretRef: esmeta.SecretKeySelector{Name: "cloudflare", Key: "token", Namespace: &namespace},
+			}},
+			wantErr: "invalid auth.apiTokenSecretRef: namespace not allowed with namespaced SecretStore",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{Kind: tc.kind},
+				Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Cloudflare: tc.prov}},
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
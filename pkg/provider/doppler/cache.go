@@ -0,0 +1,104 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doppler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	dClient "github.com/external-secrets/external-secrets/pkg/provider/doppler/client"
+)
+
+// cacheTTL is the time the downloads of a store are kept without being used,
+// a little longer than the default refresh interval of an hour.
+const cacheTTL = 2 * time.Hour
+
+// etagCache keeps the last secrets download of every config together with its ETag, per store.
+// Doppler responds with 304 Not Modified if the ETag still matches, so unchanged
+// configs are served from the cache instead of being downloaded again.
+var etagCache = newSecretsCache(cacheTTL)
+
+// storeDownloads are the cached downloads of a store, made with the token of the store.
+type storeDownloads struct {
+	tokenHash string
+	entries   map[string]*dClient.SecretsResponse
+	lastUsed  time.Time
+}
+
+type secretsCache struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	now    func() time.Time
+	stores map[types.UID]*storeDownloads
+}
+
+func newSecretsCache(ttl time.Duration) *secretsCache {
+	return &secretsCache{
+		ttl:    ttl,
+		now:    time.Now,
+		stores: make(map[types.UID]*storeDownloads),
+	}
+}
+
+// get returns the cached download of the request, downloads made with another token are not returned.
+func (c *secretsCache) get(store types.UID, token string, request dClient.SecretsRequest) (*dClient.SecretsResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	downloads, ok := c.stores[store]
+	if !ok || downloads.tokenHash != hash(token) {
+		return nil, false
+	}
+	downloads.lastUsed = c.now()
+	response, ok := downloads.entries[requestKey(request)]
+	return response, ok
+}
+
+// set caches the download of the request. The downloads of the store are replaced when its token changed,
+// and the downloads of stores that have not been used within the ttl, e.g. deleted stores, are evicted.
+func (c *secretsCache) set(store types.UID, token string, request dClient.SecretsRequest, response *dClient.SecretsResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for uid, downloads := range c.stores {
+		if now.Sub(downloads.lastUsed) > c.ttl {
+			delete(c.stores, uid)
+		}
+	}
+	downloads, ok := c.stores[store]
+	if !ok || downloads.tokenHash != hash(token) {
+		downloads = &storeDownloads{
+			tokenHash: hash(token),
+			entries:   make(map[string]*dClient.SecretsResponse),
+		}
+		c.stores[store] = downloads
+	}
+	downloads.lastUsed = now
+	downloads.entries[requestKey(request)] = response
+}
+
+// requestKey identifies a secrets download of a store.
+func requestKey(request dClient.SecretsRequest) string {
+	return strings.Join([]string{request.Project, request.Config, request.NameTransformer, request.Format}, "\x00")
+}
+
+func hash(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doppler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/external-secrets/external-secrets/pkg/provider/doppler/client"
+)
+
+func TestSecretsCache(t *testing.T) {
+	now := time.Now()
+	cache := newSecretsCache(time.Hour)
+	cache.now = func() time.Time { return now }
+	request := client.SecretsRequest{Project: dopplerProjectVal, Config: "prd"}
+	response := &client.SecretsResponse{ETag: "etag-1"}
+
+	cache.set("store-a", "token-a", request, response)
+	if got, ok := cache.get("store-a", "token-a", request); !ok || got != response {
+		t.Fatalf("expected the cached download, got %v", got)
+	}
+	if _, ok := cache.get("store-b", "token-a", request); ok {
+		t.Error("download of another store with the same token must not be shared")
+	}
+	if _, ok := cache.get("store-a", "token-b", request); ok {
+		t.Error("download made with another token must not be returned")
+	}
+	if _, ok := cache.get("store-a", "token-a", client.SecretsRequest{Project: dopplerProjectVal, Config: "dev"}); ok {
+		t.Error("download of another config must not be returned")
+	}
+
+	// a new token replaces the downloads of the store
+	cache.set("store-a", "token-b", client.SecretsRequest{Project: dopplerProjectVal, Config: "dev"}, response)
+	if len(cache.stores["store-a"].entries) != 1 {
+		t.Errorf("expected the downloads of the old token to be dropped, got %v", cache.stores["store-a"].entries)
+	}
+
+	// stores that are not used within the ttl are evicted
+	now = now.Add(2 * time.Hour)
+	cache.set("store-b", "token-a", request, response)
+	if _, ok := cache.stores["store-a"]; ok {
+		t.Error("expected the unused store to be evicted")
+	}
+	if _, ok := cache.get("store-b", "token-a", request); !ok {
+		t.Error("expected the download of the used store to be kept")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -52,11 +53,14 @@ type Client struct {
 	config          string
 	nameTransformer string
 	format          string
+	configs         []esv1beta1.DopplerConfig
 
 	kube      kclient.Client
 	store     *esv1beta1.DopplerProvider
 	namespace string
 	storeKind string
+	// storeUID scopes the cached downloads to the store, they are not cached without it.
+	storeUID types.UID
 }
 
 // SecretsClientInterface defines the required Doppler Client methods.
@@ -217,18 +221,57 @@ func (c *Client) Close(_ context.Context) error {
 }
 
 func (c *Client) getSecrets(_ context.Context) (map[string][]byte, error) {
+	secrets, err := c.getConfigSecrets(c.project, c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, config := range c.configs {
+		project := config.Project
+		if project == "" {
+			project = c.project
+		}
+		configSecrets, err := c.getConfigSecrets(project, config.Config)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range configSecrets {
+			secrets[config.Prefix+key] = value
+		}
+	}
+
+	return secrets, nil
+}
+
+func (c *Client) getConfigSecrets(project, config string) (map[string][]byte, error) {
 	request := dClient.SecretsRequest{
-		Project:         c.project,
-		Config:          c.config,
+		Project:         project,
+		Config:          config,
 		NameTransformer: c.nameTransformer,
 		Format:          c.format,
 	}
 
+	// send the ETag of the cached download so Doppler only returns the secrets if they changed
+	var cached *dClient.SecretsResponse
+	found := false
+	if c.storeUID != "" {
+		cached, found = etagCache.get(c.storeUID, c.dopplerToken, request)
+	}
+	if found {
+		request.ETag = cached.ETag
+	}
+
 	response, err := c.doppler.GetSecrets(request)
 	if err != nil {
 		return nil, fmt.Errorf(errGetSecrets, err)
 	}
 
+	if found && !response.Modified {
+		response = cached
+	} else if response.ETag != "" && c.storeUID != "" {
+		etagCache.set(c.storeUID, c.dopplerToken, request, response)
+	}
+
 	if c.format != "" {
 		return map[string][]byte{
 			secretsDownloadFileKey: response.Body,
@@ -279,6 +279,88 @@ func TestGetSecretMap(t *testing.T) {
 	}
 }
 
+func TestGetAllSecretsConfigs(t *testing.T) {
+	configSecrets := map[string]client.Secrets{
+		"auth-api/prd":     {validSecretName: validSecretValue, "SHARED": "prd"},
+		"auth-api/prd_db":  {"PASSWORD": "db-pass"},
+		"billing-api/prd":  {validSecretName: "billing"},
+		"auth-api/prd_sso": {"SHARED": "sso"},
+	}
+	fakeClient := &fake.DopplerClient{}
+	fakeClient.WithSecretsFunc(func(request client.SecretsRequest) (*client.SecretsResponse, error) {
+		secrets, ok := configSecrets[request.Project+"/"+request.Config]
+		if !ok {
+			return nil, fmt.Errorf("config %s/%s not found", request.Project, request.Config)
+		}
+		return &client.SecretsResponse{Modified: true, Secrets: secrets}, nil
+	})
+
+	c := Client{
+		doppler: fakeClient,
+		project: dopplerProjectVal,
+		config:  "prd",
+		configs: []esv1beta1.DopplerConfig{
+			{Config: "prd_db", Prefix: "DB_"},
+			{Project: "billing-api", Config: "prd", Prefix: "BILLING_"},
+			{Config: "prd_sso"},
+		},
+	}
+
+	got, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]byte{
+		validSecretName:              []byte(validSecretValue),
+		"SHARED":                     []byte("sso"),
+		"DB_PASSWORD":                []byte("db-pass"),
+		"BILLING_" + validSecretName: []byte("billing"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected secrets (-want, +got):\n%s", diff)
+	}
+
+	c.configs = append(c.configs, esv1beta1.DopplerConfig{Config: "missing"})
+	if _, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{}); !ErrorContains(err, "could not get secrets") {
+		t.Errorf("expected error for missing config, got %v", err)
+	}
+}
+
+func TestGetAllSecretsETagCache(t *testing.T) {
+	var requests []client.SecretsRequest
+	fakeClient := &fake.DopplerClient{}
+	fakeClient.WithSecretsFunc(func(request client.SecretsRequest) (*client.SecretsResponse, error) {
+		requests = append(requests, request)
+		if request.ETag == "etag-1" {
+			return &client.SecretsResponse{Modified: false, ETag: request.ETag}, nil
+		}
+		return &client.SecretsResponse{Modified: true, ETag: "etag-1", Secrets: client.Secrets{validSecretName: validSecretValue}}, nil
+	})
+
+	c := Client{
+		doppler:      fakeClient,
+		dopplerToken: "etag-cache-test",
+		storeUID:     "etag-cache-test",
+		project:      dopplerProjectVal,
+		config:       "prd",
+	}
+
+	want := map[string][]byte{validSecretName: []byte(validSecretValue)}
+	for i := 0; i < 2; i++ {
+		got, err := c.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("[%d] unexpected secrets (-want, +got):\n%s", i, diff)
+		}
+	}
+
+	if len(requests) != 2 || requests[0].ETag != "" || requests[1].ETag != "etag-1" {
+		t.Errorf("expected the second request to send the cached ETag, got %+v", requests)
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
@@ -405,6 +487,13 @@ func withAuth(name, key string, namespace *string) storeModifier {
 	}
 }
 
+func withConfigs(configs ...esv1beta1.DopplerConfig) storeModifier {
+	return func(store *esv1beta1.SecretStore) *esv1beta1.SecretStore {
+		store.Spec.Provider.Doppler.Configs = configs
+		return store
+	}
+}
+
 type ValidateStoreTestCase struct {
 	label string
 	store *esv1beta1.SecretStore
@@ -435,6 +524,24 @@ func TestValidateStore(t *testing.T) {
 			store: makeSecretStore(withAuth(secretName, "", nil)),
 			err:   nil,
 		},
+		{
+			label: "invalid store missing configs.config",
+			store: makeSecretStore(withAuth(secretName, "", nil), withConfigs(esv1beta1.DopplerConfig{Config: "prd"}, esv1beta1.DopplerConfig{Prefix: "DB_"})),
+			err:   fmt.Errorf("invalid store: configs[1].config cannot be empty"),
+		},
+		{
+			label: "invalid store format with configs",
+			store: makeSecretStore(withAuth(secretName, "", nil), withConfigs(esv1beta1.DopplerConfig{Config: "prd"}), func(store *esv1beta1.SecretStore) *esv1beta1.SecretStore {
+				store.Spec.Provider.Doppler.Format = "json"
+				return store
+			}),
+			err: fmt.Errorf("invalid store: format cannot be used with configs"),
+		},
+		{
+			label: "valid additional configs",
+			store: makeSecretStore(withAuth(secretName, "", nil), withConfigs(esv1beta1.DopplerConfig{Project: "billing-api", Config: "prd", Prefix: "BILLING_"})),
+			err:   nil,
+		},
 	}
 	p := Provider{}
 	for _, tc := range testCases {
@@ -25,6 +25,7 @@ import (
 
 type DopplerClient struct {
 	getSecret     func(request client.SecretRequest) (*client.SecretResponse, error)
+	getSecrets    func(request client.SecretsRequest) (*client.SecretsResponse, error)
 	updateSecrets func(request client.UpdateSecretsRequest) error
 }
 
@@ -40,9 +41,11 @@ func (dc *DopplerClient) GetSecret(request client.SecretRequest) (*client.Secret
 	return dc.getSecret(request)
 }
 
-func (dc *DopplerClient) GetSecrets(_ client.SecretsRequest) (*client.SecretsResponse, error) {
-	// Not implemented
-	return &client.SecretsResponse{}, nil
+func (dc *DopplerClient) GetSecrets(request client.SecretsRequest) (*client.SecretsResponse, error) {
+	if dc.getSecrets == nil {
+		return &client.SecretsResponse{}, nil
+	}
+	return dc.getSecrets(request)
 }
 
 func (dc *DopplerClient) UpdateSecrets(request client.UpdateSecretsRequest) error {
@@ -60,6 +63,12 @@ func (dc *DopplerClient) WithValue(request client.SecretRequest, response *clien
 	}
 }
 
+func (dc *DopplerClient) WithSecretsFunc(fn func(request client.SecretsRequest) (*client.SecretsResponse, error)) {
+	if dc != nil {
+		dc.getSecrets = fn
+	}
+}
+
 func (dc *DopplerClient) WithUpdateValue(request client.UpdateSecretsRequest, err error) {
 	if dc != nil {
 		dc.updateSecrets = func(requestIn client.UpdateSecretsRequest) error {
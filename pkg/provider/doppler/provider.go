@@ -29,9 +29,10 @@ import (
 )
 
 const (
-	errNewClient    = "unable to create DopplerClient : %s"
-	errInvalidStore = "invalid store: %s"
-	errDopplerStore = "missing or invalid Doppler SecretStore"
+	errNewClient     = "unable to create DopplerClient : %s"
+	errInvalidStore  = "invalid store: %s"
+	errDopplerStore  = "missing or invalid Doppler SecretStore"
+	errFormatConfigs = "format cannot be used with configs"
 )
 
 // Provider is a Doppler secrets provider implementing NewClient and ValidateStore for the esv1beta1.Provider interface.
@@ -59,6 +60,10 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	}
 
 	dopplerStoreSpec := storeSpec.Provider.Doppler
+	// a download is a single file per config, the files of several configs can't be merged
+	if dopplerStoreSpec.Format != "" && len(dopplerStoreSpec.Configs) > 0 {
+		return nil, fmt.Errorf(errInvalidStore, errFormatConfigs)
+	}
 
 	// Default Key to dopplerToken if not specified
 	if dopplerStoreSpec.Auth.SecretRef.DopplerToken.Key == "" {
@@ -70,6 +75,7 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 		store:     dopplerStoreSpec,
 		namespace: namespace,
 		storeKind: store.GetObjectKind().GroupVersionKind().Kind,
+		storeUID:  store.GetUID(),
 	}
 
 	if err := client.setAuth(ctx); err != nil {
@@ -99,6 +105,7 @@ func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	client.config = client.store.Config
 	client.nameTransformer = client.store.NameTransformer
 	client.format = client.store.Format
+	client.configs = client.store.Configs
 
 	return client, nil
 }
@@ -115,5 +122,15 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 		return nil, fmt.Errorf(errInvalidStore, "dopplerToken.name cannot be empty")
 	}
 
+	if dopplerStoreSpec.Format != "" && len(dopplerStoreSpec.Configs) > 0 {
+		return nil, fmt.Errorf(errInvalidStore, errFormatConfigs)
+	}
+
+	for i, config := range dopplerStoreSpec.Configs {
+		if config.Config == "" {
+			return nil, fmt.Errorf(errInvalidStore, fmt.Sprintf("configs[%d].config cannot be empty", i))
+		}
+	}
+
 	return nil, nil
 }
@@ -0,0 +1,144 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// The client talks to the JSON gRPC gateway etcd serves on its client port,
+// see https://etcd.io/docs/v3.5/dev-guide/api_grpc_gateway/.
+const (
+	rangePath  = "/v3/kv/range"
+	statusPath = "/v3/maintenance/status"
+
+	// maxResponseSize bounds the size of a response read from etcd.
+	maxResponseSize = 64 << 20
+
+	errRequest  = "request to etcd failed: %w"
+	errResponse = "etcd returned %s: %s"
+)
+
+// rangeRequest is the gateway encoding of etcdserverpb.RangeRequest, keys are base64 encoded
+// by the []byte fields and int64 values are encoded as strings.
+type rangeRequest struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end,omitempty"`
+	Revision int64  `json:"revision,omitempty,string"`
+}
+
+type rangeResponse struct {
+	Kvs []keyValue `json:"kvs"`
+}
+
+type keyValue struct {
+	Key         []byte `json:"key"`
+	Value       []byte `json:"value"`
+	ModRevision int64  `json:"mod_revision,omitempty,string"`
+}
+
+type statusResponse struct {
+	Version string `json:"version"`
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+	Error   string `json:"error"`
+}
+
+// rangeKeys returns the key, or the keys between key and rangeEnd if rangeEnd is set.
+func (c *client) rangeKeys(ctx context.Context, req rangeRequest) ([]keyValue, error) {
+	var resp rangeResponse
+	if err := c.post(ctx, rangePath, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Kvs, nil
+}
+
+func (c *client) status(ctx context.Context) (statusResponse, error) {
+	var resp statusResponse
+	err := c.post(ctx, statusPath, struct{}{}, &resp)
+	return resp, err
+}
+
+// post sends the request to the endpoints in order, the next endpoint is only
+// tried if an endpoint can't be reached.
+func (c *client) post(ctx context.Context, path string, req, resp any) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	var errs []error
+	for _, endpoint := range c.endpoints {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+path, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf(errRequest, err)
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpResp, err := c.http.Do(httpReq)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return decodeResponse(httpResp, resp)
+	}
+	return fmt.Errorf(errRequest, errors.Join(errs...))
+}
+
+func decodeResponse(httpResp *http.Response, resp any) error {
+	defer httpResp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(httpResp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		msg := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &errResp) == nil && (errResp.Message != "" || errResp.Error != "") {
+			msg = errResp.Message
+			if msg == "" {
+				msg = errResp.Error
+			}
+		}
+		return fmt.Errorf(errResponse, httpResp.Status, msg)
+	}
+	if err := json.Unmarshal(data, resp); err != nil {
+		return fmt.Errorf(errRequest, err)
+	}
+	return nil
+}
+
+// prefixRangeEnd returns the end of the range of all keys with the prefix,
+// like clientv3.GetPrefixRangeEnd: the prefix with its last byte below 0xff incremented.
+func prefixRangeEnd(prefix string) []byte {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// the prefix is empty or only holds 0xff bytes, "\x00" ranges over all keys from the prefix
+	return []byte{0}
+}
@@ -0,0 +1,305 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/find"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+
+	errMissingEtcdProvider = "missing store provider etcd"
+	errMissingEndpoints    = "at least one endpoint is required"
+	errInvalidEndpoint     = "invalid endpoint %q: %w"
+	errEndpointScheme      = "endpoint %q must use https to present the client certificate"
+	errInvalidCABundle     = "caBundle does not contain a PEM encoded certificate"
+	errInvalidCertRef      = "invalid auth.certSecretRef: %w"
+	errInvalidKeyRef       = "invalid auth.keySecretRef: %w"
+	errResolveCert         = "could not resolve the client certificate: %w"
+	errResolveKey          = "could not resolve the client key: %w"
+	errInvalidKeyPair      = "invalid client certificate: %w"
+	errInvalidRevision     = "version %q is not an etcd revision: %w"
+	errPropertyNotFound    = "property %s not found in key %s"
+	errNotJSONObject       = "value of key %s is not a JSON object: %w"
+	errFindByTags          = "find by tags is not supported by etcd"
+	errNotImplemented      = "not implemented"
+)
+
+var _ esv1beta1.SecretsClient = &client{}
+var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.RemoteRefFeatureValidator = &Provider{}
+var _ esv1beta1.ProviderVersionReporter = &client{}
+
+// Provider reads secrets from the keys of an etcd v3 cluster.
+type Provider struct{}
+
+// client reads the keys of a single store, they are prefixed by the prefix of the store.
+type client struct {
+	http      *http.Client
+	endpoints []string
+	prefix    string
+	timeout   time.Duration
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Etcd: &esv1beta1.EtcdProvider{},
+	})
+}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadOnly
+}
+
+// UnsupportedRemoteRefFeatures returns the remote ref features etcd rejects: keys have no tags.
+func (p *Provider) UnsupportedRemoteRefFeatures(_ esv1beta1.GenericStore) []esv1beta1.RemoteRefFeature {
+	return []esv1beta1.RemoteRefFeature{
+		esv1beta1.RemoteRefFeatureFindTags,
+	}
+}
+
+// NewClient resolves the client certificate of the store.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &prov.Auth.CertSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf(errResolveCert, err)
+	}
+	key, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &prov.Auth.KeySecretRef)
+	if err != nil {
+		return nil, fmt.Errorf(errResolveKey, err)
+	}
+	keyPair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidKeyPair, err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(prov.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(prov.CABundle) {
+			return nil, errors.New(errInvalidCABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	timeout := defaultTimeout
+	if prov.Timeout != nil {
+		timeout = prov.Timeout.Duration
+	}
+	return &client{
+		http:      &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+		endpoints: prov.Endpoints,
+		prefix:    prov.Prefix,
+		timeout:   timeout,
+	}, nil
+}
+
+// ValidateStore checks the endpoints and the client certificate references of the store.
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if len(prov.Endpoints) == 0 {
+		return nil, errors.New(errMissingEndpoints)
+	}
+	for _, endpoint := range prov.Endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidEndpoint, endpoint, err)
+		}
+		if u.Scheme != "https" {
+			return nil, fmt.Errorf(errEndpointScheme, endpoint)
+		}
+	}
+	if len(prov.CABundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(prov.CABundle) {
+		return nil, errors.New(errInvalidCABundle)
+	}
+	if err := utils.ValidateSecretSelector(store, prov.Auth.CertSecretRef); err != nil {
+		return nil, fmt.Errorf(errInvalidCertRef, err)
+	}
+	if err := utils.ValidateSecretSelector(store, prov.Auth.KeySecretRef); err != nil {
+		return nil, fmt.Errorf(errInvalidKeyRef, err)
+	}
+	return nil, nil
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.EtcdProvider, error) {
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil || spec.Provider.Etcd == nil {
+		return nil, errors.New(errMissingEtcdProvider)
+	}
+	return spec.Provider.Etcd, nil
+}
+
+// GetSecret returns the value of the key, the version of the ref selects an etcd revision.
+// With a property the value is expected to be JSON, nested values are supported by gjson expressions.
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, err := c.getValue(ctx, ref.Key, ref.Version)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Property == "" {
+		return value, nil
+	}
+	result := gjson.GetBytes(value, ref.Property)
+	if !result.Exists() {
+		return nil, fmt.Errorf(errPropertyNotFound, ref.Property, ref.Key)
+	}
+	if result.Type == gjson.String {
+		return []byte(result.Str), nil
+	}
+	return []byte(result.Raw), nil
+}
+
+// GetSecretMap returns the entries of the JSON object stored in the key.
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	value, err := c.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	var entries map[string]any
+	if err := json.Unmarshal(value, &entries); err != nil {
+		return nil, fmt.Errorf(errNotJSONObject, ref.Key, err)
+	}
+	data := make(map[string][]byte, len(entries))
+	for k := range entries {
+		data[k], err = utils.GetByteValueFromMap(entries, k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// GetAllSecrets returns the keys below the prefix of the store and the find path,
+// named by their key without the prefix of the store.
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, errors.New(errFindByTags)
+	}
+	var matcher *find.Matcher
+	if ref.Name != nil {
+		var err error
+		matcher, err = find.New(*ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	prefix := c.prefix
+	if ref.Path != nil {
+		prefix += *ref.Path
+	}
+	kvs, err := c.rangeKeys(ctx, rangeRequest{Key: []byte(prefix), RangeEnd: prefixRangeEnd(prefix)})
+	metrics.ObserveAPICall(constants.ProviderEtcd, constants.CallEtcdRange, err)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string][]byte, len(kvs))
+	for _, kv := range kvs {
+		name := strings.TrimPrefix(string(kv.Key), c.prefix)
+		if matcher != nil && !matcher.MatchName(name) {
+			continue
+		}
+		data[name] = kv.Value
+	}
+	return utils.ConvertKeys(ref.ConversionStrategy, data)
+}
+
+func (c *client) getValue(ctx context.Context, key, version string) ([]byte, error) {
+	req := rangeRequest{Key: []byte(c.prefix + key)}
+	if version != "" {
+		revision, err := strconv.ParseInt(version, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidRevision, version, err)
+		}
+		req.Revision = revision
+	}
+	kvs, err := c.rangeKeys(ctx, req)
+	metrics.ObserveAPICall(constants.ProviderEtcd, constants.CallEtcdRange, err)
+	if err != nil {
+		return nil, err
+	}
+	if len(kvs) == 0 {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	return kvs[0].Value, nil
+}
+
+// ProviderVersion returns the version of the etcd server.
+func (c *client) ProviderVersion(ctx context.Context) (string, error) {
+	status, err := c.status(ctx)
+	metrics.ObserveAPICall(constants.ProviderEtcd, constants.CallEtcdStatus, err)
+	if err != nil {
+		return "", err
+	}
+	return status.Version, nil
+}
+
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	_, err := c.status(context.Background())
+	metrics.ObserveAPICall(constants.ProviderEtcd, constants.CallEtcdStatus, err)
+	if err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *client) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.PushSecretData) error {
+	return errors.New(errNotImplemented)
+}
+
+func (c *client) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+	return errors.New(errNotImplemented)
+}
+
+func (c *client) SecretExists(_ context.Context, _ esv1beta1.PushSecretRemoteRef) (bool, error) {
+	return false, errors.New(errNotImplemented)
+}
+
+func (c *client) Close(_ context.Context) error {
+	c.http.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,264 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+// fakeEtcd serves the range and status calls of the etcd gateway from the keys,
+// the revision 1 holds the previous values of the keys.
+func fakeEtcd(t *testing.T, keys, previous map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case statusPath:
+			_, _ = w.Write([]byte(`{"header":{"revision":"2"},"version":"3.5.9"}`))
+		case rangePath:
+			var req rangeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			values := keys
+			if req.Revision == 1 {
+				values = previous
+			}
+			if req.Revision > 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"etcdserver: mvcc: required revision is a future revision","code":11,"message":"etcdserver: mvcc: required revision is a future revision"}`))
+				return
+			}
+			var resp rangeResponse
+			for k, v := range values {
+				key := []byte(k)
+				match := bytes.Equal(key, req.Key)
+				if len(req.RangeEnd) > 0 {
+					match = bytes.Compare(key, req.Key) >= 0 && bytes.Compare(key, req.RangeEnd) < 0
+				}
+				if match {
+					resp.Kvs = append(resp.Kvs, keyValue{Key: key, Value: []byte(v)})
+				}
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(resp))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestProvider(t *testing.T) {
+	server := httptest.NewUnstartedServer(fakeEtcd(t, map[string]string{
+		"/platform/db":      `{"user":"admin","port":5432,"nested":{"password":"s3cr3t"}}`,
+		"/platform/token":   "t0k3n",
+		"/platform/mq/user": "guest",
+		"/other/key":        "value",
+	}, map[string]string{
+		"/platform/token": "old",
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	cert, key := clientCertificate(t)
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-client", Namespace: "default"},
+		Data:       map[string][]byte{"tls.crt": cert, "tls.key": key},
+	}).Build()
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Etcd: &esv1beta1.EtcdProvider{
+					// the first endpoint is unreachable, the request is sent to the next one
+					Endpoints: []string{"https://127.0.0.1:1", server.URL},
+					Prefix:    "/platform/",
+					CABundle:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+					Auth: esv1beta1.EtcdAuth{
+						CertSecretRef: esmeta.SecretKeySelector{Name: "etcd-client", Key: "tls.crt"},
+						KeySecretRef:  esmeta.SecretKeySelector{Name: "etcd-client", Key: "tls.key"},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	p := &Provider{}
+	_, err := p.ValidateStore(store)
+	require.NoError(t, err)
+	c, err := p.NewClient(ctx, store, kube, "default")
+	require.NoError(t, err)
+	defer c.Close(ctx)
+
+	result, err := c.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+	version, err := c.(esv1beta1.ProviderVersionReporter).ProviderVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "3.5.9", version)
+
+	value, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "token"})
+	require.NoError(t, err)
+	assert.Equal(t, "t0k3n", string(value))
+
+	value, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "token", Version: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(value))
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "token", Version: "3"})
+	assert.EqualError(t, err, "etcd returned 400 Bad Request: etcdserver: mvcc: required revision is a future revision")
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "token", Version: "latest"})
+	assert.ErrorContains(t, err, `version "latest" is not an etcd revision`)
+
+	value, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Property: "nested.password"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(value))
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db", Property: "missing"})
+	assert.EqualError(t, err, "property missing not found in key db")
+
+	_, err = c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretError{})
+
+	data, err := c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"user":   []byte("admin"),
+		"port":   []byte("5432"),
+		"nested": []byte(`{"password":"s3cr3t"}`),
+	}, data)
+
+	_, err = c.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "token"})
+	assert.ErrorContains(t, err, "value of key token is not a JSON object")
+
+	data, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: "^(db|token)$"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"db":    []byte(`{"user":"admin","port":5432,"nested":{"password":"s3cr3t"}}`),
+		"token": []byte("t0k3n"),
+	}, data)
+
+	path := "mq/"
+	data, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Path: &path})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"mq/user": []byte("guest")}, data)
+
+	_, err = c.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Tags: map[string]string{"team": "a"}})
+	assert.EqualError(t, err, errFindByTags)
+}
+
+func TestValidateStore(t *testing.T) {
+	auth := esv1beta1.EtcdAuth{
+		CertSecretRef: esmeta.SecretKeySelector{Name: "etcd-client", Key: "tls.crt"},
+		KeySecretRef:  esmeta.SecretKeySelector{Name: "etcd-client", Key: "tls.key"},
+	}
+	tests := []struct {
+		name    string
+		kind    string
+		prov    *esv1beta1.EtcdProvider
+		wantErr string
+	}{
+		{
+			name: "valid",
+			prov: &esv1beta1.EtcdProvider{Endpoints: []string{"https://etcd:2379"}, Auth: auth},
+		},
+		{
+			name:    "missing provider",
+			wantErr: errMissingEtcdProvider,
+		},
+		{
+			name:    "missing endpoints",
+			prov:    &esv1beta1.EtcdProvider{Auth: auth},
+			wantErr: errMissingEndpoints,
+		},
+		{
+			name:    "plain http",
+			prov:    &esv1beta1.EtcdProvider{Endpoints: []string{"http://etcd:2379"}, Auth: auth},
+			wantErr: `endpoint "http://etcd:2379" must use https to present the client certificate`,
+		},
+		{
+			name:    "invalid caBundle",
+			prov:    &esv1beta1.EtcdProvider{Endpoints: []string{"https://etcd:2379"}, CABundle: []byte("ca"), Auth: auth},
+			wantErr: errInvalidCABundle,
+		},
+		{
+			name:    "cluster store without namespace",
+			kind:    esv1beta1.ClusterSecretStoreKind,
+			prov:    &esv1beta1.EtcdProvider{Endpoints: []string{"https://etcd:2379"}, Auth: auth},
+			wantErr: "invalid auth.certSecretRef: cluster scope requires namespace",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{Kind: tc.kind},
+				Spec:     esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{Etcd: tc.prov}},
+			}
+			_, err := (&Provider{}).ValidateStore(store)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestPrefixRangeEnd(t *testing.T) {
+	assert.Equal(t, []byte("/platform0"), prefixRangeEnd("/platform/"))
+	assert.Equal(t, []byte("b"), prefixRangeEnd("a\xff"))
+	assert.Equal(t, []byte{0}, prefixRangeEnd(""))
+}
+
+func clientCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -17,8 +17,13 @@ package fake
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
@@ -34,7 +39,10 @@ var (
 	errMissingStore        = fmt.Errorf("missing store provider")
 	errMissingFakeProvider = fmt.Errorf("missing store provider fake")
 	errMissingKeyField     = "key must be set in data %v"
-	errMissingValueField   = "at least one of value or valueMap must be set in data %v"
+	errMissingValueField   = "at least one of value, values or valueMap must be set in data %v"
+	errInvalidErrorRate    = "errorRate must be between 0 and 100, got %d"
+	errInjectedFault       = errors.New("injected fault")
+	warnDeprecatedValueMap = "data[%d].valueMap is deprecated, use data[%d].value instead"
 )
 
 type SourceOrigin string
@@ -46,14 +54,36 @@ const (
 
 type Data struct {
 	Value    string
+	Values   []string
 	Version  string
 	ValueMap map[string]string
 	Origin   SourceOrigin
+
+	mu    sync.Mutex
+	reads int
+}
+
+// next returns the value of the next read: the static value
+// or the next value of the sequence, keeping the last one once it is exhausted.
+func (d *Data) next() string {
+	if len(d.Values) == 0 {
+		return d.Value
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.reads >= len(d.Values)-1 {
+		return d.Values[len(d.Values)-1]
+	}
+	d.reads++
+	return d.Values[d.reads-1]
 }
+
 type Config map[string]*Data
 type Provider struct {
-	config   Config
-	database map[string]Config
+	config    Config
+	database  map[string]Config
+	latency   time.Duration
+	errorRate int
 }
 
 // Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
@@ -75,8 +105,10 @@ func (p *Provider) NewClient(_ context.Context, store esv1beta1.GenericStore, _
 	}
 	// We want to remove any FakeSecretStore entry from memory
 	// this will ensure SecretStores can delete from memory.
+	previous := make(Config)
 	for key, data := range cfg {
 		if data.Origin == FakeSecretStore {
+			previous[key] = data
 			delete(cfg, key)
 		}
 	}
@@ -84,17 +116,29 @@ func (p *Provider) NewClient(_ context.Context, store esv1beta1.GenericStore, _
 		key := mapKey(data.Key, data.Version)
 		cfg[key] = &Data{
 			Value:   data.Value,
+			Values:  data.Values,
 			Version: data.Version,
 			Origin:  FakeSecretStore,
 		}
 		if data.ValueMap != nil {
 			cfg[key].ValueMap = data.ValueMap
 		}
+		// keep the position in an unchanged sequence, so refreshes pick up the next value
+		if prev, ok := previous[key]; ok && slices.Equal(prev.Values, data.Values) {
+			prev.mu.Lock()
+			cfg[key].reads = prev.reads
+			prev.mu.Unlock()
+		}
 	}
 	p.database[store.GetName()] = cfg
-	return &Provider{
-		config: cfg,
-	}, nil
+	cl := &Provider{
+		config:    cfg,
+		errorRate: c.ErrorRate,
+	}
+	if c.Latency != nil {
+		cl.latency = c.Latency.Duration
+	}
+	return cl, nil
 }
 
 func getProvider(store esv1beta1.GenericStore) (*esv1beta1.FakeProvider, error) {
@@ -108,16 +152,47 @@ func getProvider(store esv1beta1.GenericStore) (*esv1beta1.FakeProvider, error)
 	return spc.Provider.Fake, nil
 }
 
-func (p *Provider) DeleteSecret(_ context.Context, _ esv1beta1.PushSecretRemoteRef) error {
+// inject delays the call by the configured latency and fails it at the configured error rate.
+func (p *Provider) inject(ctx context.Context) error {
+	if p.latency > 0 {
+		timer := time.NewTimer(p.latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	//nolint:gosec // fault injection does not need a cryptographically secure random number
+	if p.errorRate > 0 && rand.Intn(100) < p.errorRate {
+		return errInjectedFault
+	}
+	return nil
+}
+
+// DeleteSecret deletes a pushed secret, the secrets of the store are kept.
+func (p *Provider) DeleteSecret(ctx context.Context, ref esv1beta1.PushSecretRemoteRef) error {
+	if err := p.inject(ctx); err != nil {
+		return err
+	}
+	if data, ok := p.config[ref.GetRemoteKey()]; ok && data.Origin == FakeSetSecret {
+		delete(p.config, ref.GetRemoteKey())
+	}
 	return nil
 }
 
-func (p *Provider) SecretExists(_ context.Context, ref esv1beta1.PushSecretRemoteRef) (bool, error) {
+func (p *Provider) SecretExists(ctx context.Context, ref esv1beta1.PushSecretRemoteRef) (bool, error) {
+	if err := p.inject(ctx); err != nil {
+		return false, err
+	}
 	_, ok := p.config[ref.GetRemoteKey()]
 	return ok, nil
 }
 
-func (p *Provider) PushSecret(_ context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+func (p *Provider) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	if err := p.inject(ctx); err != nil {
+		return err
+	}
 	value := secret.Data[data.GetSecretKey()]
 	currentData, ok := p.config[data.GetRemoteKey()]
 	if !ok {
@@ -138,7 +213,10 @@ func (p *Provider) PushSecret(_ context.Context, secret *corev1.Secret, data esv
 
 // GetAllSecrets returns multiple secrets from the given ExternalSecretFind
 // Currently, only the Name operator is supported.
-func (p *Provider) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+func (p *Provider) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if err := p.inject(ctx); err != nil {
+		return nil, err
+	}
 	if ref.Name != nil {
 		matcher, err := find.New(*ref.Name)
 		if err != nil {
@@ -159,11 +237,11 @@ func (p *Provider) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecret
 				// Need to get only the latest version
 				if version < data.Version {
 					latestVersionMap[originalKey] = data.Version
-					dataMap[originalKey] = []byte(data.Value)
+					dataMap[originalKey] = []byte(data.next())
 				}
 			} else {
 				latestVersionMap[originalKey] = data.Version
-				dataMap[originalKey] = []byte(data.Value)
+				dataMap[originalKey] = []byte(data.next())
 			}
 		}
 		return utils.ConvertKeys(ref.ConversionStrategy, dataMap)
@@ -172,14 +250,22 @@ func (p *Provider) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecret
 }
 
 // GetSecret returns a single secret from the provider.
-func (p *Provider) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+func (p *Provider) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	if err := p.inject(ctx); err != nil {
+		return nil, err
+	}
+	return p.getSecret(ref)
+}
+
+func (p *Provider) getSecret(ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	data, ok := p.config[mapKey(ref.Key, ref.Version)]
 	if !ok || data.Version != ref.Version {
 		return nil, esv1beta1.NoSecretErr
 	}
 
+	value := data.next()
 	if ref.Property != "" {
-		val := gjson.Get(data.Value, ref.Property)
+		val := gjson.Get(value, ref.Property)
 		if !val.Exists() {
 			return nil, esv1beta1.NoSecretErr
 		}
@@ -187,11 +273,14 @@ func (p *Provider) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretData
 		return []byte(val.String()), nil
 	}
 
-	return []byte(data.Value), nil
+	return []byte(value), nil
 }
 
 // GetSecretMap returns multiple k/v pairs from the provider.
 func (p *Provider) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	if err := p.inject(ctx); err != nil {
+		return nil, err
+	}
 	ddata, ok := p.config[mapKey(ref.Key, ref.Version)]
 	if !ok || ddata.Version != ref.Version {
 		return nil, esv1beta1.NoSecretErr
@@ -202,7 +291,7 @@ func (p *Provider) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecre
 		return convertMap(ddata.ValueMap), nil
 	}
 
-	data, err := p.GetSecret(ctx, ref)
+	data, err := p.getSecret(ref)
 	if err != nil {
 		return nil, err
 	}
@@ -248,15 +337,22 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 	if prov == nil {
 		return nil, nil
 	}
+	if prov.ErrorRate < 0 || prov.ErrorRate > 100 {
+		return nil, fmt.Errorf(errInvalidErrorRate, prov.ErrorRate)
+	}
+	var warnings admission.Warnings
 	for pos, data := range prov.Data {
 		if data.Key == "" {
 			return nil, fmt.Errorf(errMissingKeyField, pos)
 		}
-		if data.Value == "" && data.ValueMap == nil {
+		if data.Value == "" && len(data.Values) == 0 && data.ValueMap == nil {
 			return nil, fmt.Errorf(errMissingValueField, pos)
 		}
+		if data.ValueMap != nil {
+			warnings = append(warnings, fmt.Sprintf(warnDeprecatedValueMap, pos, pos))
+		}
 	}
-	return nil, nil
+	return warnings, nil
 }
 
 func mapKey(key, version string) string {
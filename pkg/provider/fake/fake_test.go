@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/onsi/gomega"
@@ -29,6 +30,7 @@ import (
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/conformance"
 	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
 )
 
@@ -73,10 +75,16 @@ func TestValidateStore(t *testing.T) {
 	gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf(errMissingValueField, 0)))
 	// spec ok
 	data.Value = "bar"
+	store.Spec.Provider.Fake.Data = []esv1beta1.FakeProviderData{data}
+	warnings, err := p.ValidateStore(store)
+	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(warnings).To(gomega.BeEmpty())
+	// deprecated valueMap
 	data.ValueMap = map[string]string{"foo": "bar"}
 	store.Spec.Provider.Fake.Data = []esv1beta1.FakeProviderData{data}
-	_, err = p.ValidateStore(store)
+	warnings, err = p.ValidateStore(store)
 	gomega.Expect(err).To(gomega.BeNil())
+	gomega.Expect(warnings).To(gomega.ConsistOf(fmt.Sprintf(warnDeprecatedValueMap, 0, 0)))
 }
 func TestClose(t *testing.T) {
 	p := &Provider{}
@@ -324,6 +332,97 @@ func TestGetSecret(t *testing.T) {
 	}
 }
 
+func TestGetSecretSequence(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "secret-store-sequence",
+		},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Fake: &esv1beta1.FakeProvider{
+					Data: []esv1beta1.FakeProviderData{
+						{
+							Key:    "/foo",
+							Values: []string{"v1", "v2", "v3"},
+						},
+					},
+				},
+			},
+		},
+	}
+	ref := esv1beta1.ExternalSecretDataRemoteRef{Key: "/foo"}
+	// every client continues the sequence of the previous one, the last value is kept
+	for _, expValue := range []string{"v1", "v2", "v3", "v3"} {
+		cl, err := p.NewClient(context.Background(), store, nil, "")
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		out, err := cl.GetSecret(context.Background(), ref)
+		gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		gomega.Expect(string(out)).To(gomega.Equal(expValue))
+	}
+
+	// a changed sequence starts over
+	store.Spec.Provider.Fake.Data[0].Values = []string{"w1", "w2"}
+	cl, err := p.NewClient(context.Background(), store, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	out, err := cl.GetSecret(context.Background(), ref)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(string(out)).To(gomega.Equal("w1"))
+}
+
+func TestFaultInjection(t *testing.T) {
+	gomega.RegisterTestingT(t)
+	p := &Provider{}
+	store := &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "secret-store-faults",
+		},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Fake: &esv1beta1.FakeProvider{
+					Data: []esv1beta1.FakeProviderData{
+						{
+							Key:   "/foo",
+							Value: "bar",
+						},
+					},
+					ErrorRate: 100,
+				},
+			},
+		},
+	}
+	ref := esv1beta1.ExternalSecretDataRemoteRef{Key: "/foo"}
+	cl, err := p.NewClient(context.Background(), store, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	_, err = cl.GetSecret(context.Background(), ref)
+	gomega.Expect(err).To(gomega.MatchError(errInjectedFault))
+	_, err = cl.GetSecretMap(context.Background(), ref)
+	gomega.Expect(err).To(gomega.MatchError(errInjectedFault))
+
+	// latency honors the deadline of the call
+	store.Spec.Provider.Fake.ErrorRate = 0
+	store.Spec.Provider.Fake.Latency = &metav1.Duration{Duration: time.Hour}
+	cl, err = p.NewClient(context.Background(), store, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = cl.GetSecret(ctx, ref)
+	gomega.Expect(err).To(gomega.MatchError(context.DeadlineExceeded))
+
+	store.Spec.Provider.Fake.Latency = &metav1.Duration{Duration: time.Millisecond}
+	cl, err = p.NewClient(context.Background(), store, nil, "")
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	out, err := cl.GetSecret(context.Background(), ref)
+	gomega.Expect(err).ToNot(gomega.HaveOccurred())
+	gomega.Expect(string(out)).To(gomega.Equal("bar"))
+
+	// invalid error rate
+	store.Spec.Provider.Fake.ErrorRate = 101
+	_, err = p.ValidateStore(store)
+	gomega.Expect(err).To(gomega.BeEquivalentTo(fmt.Errorf(errInvalidErrorRate, 101)))
+}
+
 type setSecretTestCase struct {
 	name       string
 	input      []esv1beta1.FakeProviderData
@@ -609,3 +708,39 @@ func TestGetSecretMap(t *testing.T) {
 		})
 	}
 }
+
+// conformanceBackend seeds the secrets of the conformance suite like the data of a store.
+type conformanceBackend struct {
+	provider *Provider
+}
+
+func (b *conformanceBackend) Seed(_ context.Context, key string, value []byte) error {
+	b.provider.config[key] = &Data{Value: string(value), Origin: FakeSecretStore}
+	return nil
+}
+
+func (b *conformanceBackend) Remove(_ context.Context, key string) error {
+	delete(b.provider.config, key)
+	return nil
+}
+
+func TestConformance(t *testing.T) {
+	p := &Provider{}
+	cl, err := p.NewClient(context.Background(), &esv1beta1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "conformance"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Fake: &esv1beta1.FakeProvider{},
+			},
+		},
+	}, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	suite := &conformance.Suite{
+		Client:       cl,
+		Backend:      &conformanceBackend{provider: cl.(*Provider)},
+		Capabilities: p.Capabilities(),
+	}
+	suite.Run(t)
+}
@@ -18,15 +18,42 @@ import (
 	"context"
 	"fmt"
 
+	"cloud.google.com/go/iam/credentials/apiv1/credentialspb"
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"github.com/googleapis/gax-go/v2"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"grpc.go4.org/credentials/oauth"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
 	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
+const (
+	serviceAccountResourceTemplate = "projects/-/serviceAccounts/%s"
+
+	errImpersonate = "unable to impersonate service account %s: %w"
+)
+
 func NewTokenSource(ctx context.Context, auth esv1beta1.GCPSMAuth, projectID, storeKind string, kube kclient.Client, namespace string) (oauth2.TokenSource, error) {
+	ts, err := baseTokenSource(ctx, auth, projectID, storeKind, kube, namespace)
+	if err != nil || auth.ServiceAccountImpersonation == nil {
+		return ts, err
+	}
+	iamc, err := newIAMClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer iamc.Close()
+	return impersonatedTokenSource(ctx, iamc, ts, auth.ServiceAccountImpersonation)
+}
+
+func baseTokenSource(ctx context.Context, auth esv1beta1.GCPSMAuth, projectID, storeKind string, kube kclient.Client, namespace string) (oauth2.TokenSource, error) {
 	ts, err := serviceAccountTokenSource(ctx, auth, storeKind, kube, namespace)
 	if ts != nil || err != nil {
 		return ts, err
@@ -64,3 +91,33 @@ func serviceAccountTokenSource(ctx context.Context, auth esv1beta1.GCPSMAuth, st
 	}
 	return config.TokenSource(ctx), nil
 }
+
+// impersonatedTokenSource exchanges the token of the base token source for an access token
+// of the target service account, through the chain of delegates if any.
+func impersonatedTokenSource(ctx context.Context, iamc IamClient, base oauth2.TokenSource, imp *esv1beta1.GCPServiceAccountImpersonation) (oauth2.TokenSource, error) {
+	delegates := make([]string, len(imp.Delegates))
+	for i, d := range imp.Delegates {
+		delegates[i] = fmt.Sprintf(serviceAccountResourceTemplate, d)
+	}
+	scopes := imp.Scopes
+	if len(scopes) == 0 {
+		scopes = secretmanager.DefaultAuthScopes()
+	}
+	req := &credentialspb.GenerateAccessTokenRequest{
+		Name:      fmt.Sprintf(serviceAccountResourceTemplate, imp.TargetServiceAccount),
+		Delegates: delegates,
+		Scope:     scopes,
+	}
+	if imp.Lifetime != nil {
+		req.Lifetime = durationpb.New(imp.Lifetime.Duration)
+	}
+	resp, err := iamc.GenerateAccessToken(ctx, req, gax.WithGRPCOptions(grpc.PerRPCCredentials(oauth.TokenSource{TokenSource: base})))
+	metrics.ObserveAPICall(constants.ProviderGCPSM, constants.CallGCPSMGenerateAccessToken, err)
+	if err != nil {
+		return nil, fmt.Errorf(errImpersonate, imp.TargetServiceAccount, err)
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: resp.GetAccessToken(),
+		Expiry:      resp.GetExpireTime().AsTime(),
+	}), nil
+}
@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/iam/credentials/apiv1/credentialspb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+func TestImpersonatedTokenSource(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	var gotReq *credentialspb.GenerateAccessTokenRequest
+	iamc := &fakeIAMClient{
+		generateAccessTokenFunc: func(_ context.Context, req *credentialspb.GenerateAccessTokenRequest, _ ...gax.CallOption) (*credentialspb.GenerateAccessTokenResponse, error) {
+			gotReq = req
+			return &credentialspb.GenerateAccessTokenResponse{
+				AccessToken: "impersonated",
+				ExpireTime:  timestamppb.New(expiry),
+			}, nil
+		},
+	}
+	base := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "base"})
+
+	ts, err := impersonatedTokenSource(context.Background(), iamc, base, &esv1beta1.GCPServiceAccountImpersonation{
+		TargetServiceAccount: "target@team.iam.gserviceaccount.com",
+		Delegates:            []string{"first@central.iam.gserviceaccount.com", "second@team.iam.gserviceaccount.com"},
+		Scopes:               []string{"https://www.googleapis.com/auth/secretmanager"},
+		Lifetime:             &metav1.Duration{Duration: 30 * time.Minute},
+	})
+	assert.NoError(t, err)
+	tok, err := ts.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "impersonated", tok.AccessToken)
+	assert.True(t, expiry.Equal(tok.Expiry))
+
+	assert.Equal(t, "projects/-/serviceAccounts/target@team.iam.gserviceaccount.com", gotReq.GetName())
+	assert.Equal(t, []string{
+		"projects/-/serviceAccounts/first@central.iam.gserviceaccount.com",
+		"projects/-/serviceAccounts/second@team.iam.gserviceaccount.com",
+	}, gotReq.GetDelegates())
+	assert.Equal(t, []string{"https://www.googleapis.com/auth/secretmanager"}, gotReq.GetScope())
+	assert.Equal(t, 30*time.Minute, gotReq.GetLifetime().AsDuration())
+
+	iamc.generateAccessTokenFunc = func(context.Context, *credentialspb.GenerateAccessTokenRequest, ...gax.CallOption) (*credentialspb.GenerateAccessTokenResponse, error) {
+		return nil, errors.New("permission denied")
+	}
+	_, err = impersonatedTokenSource(context.Background(), iamc, base, &esv1beta1.GCPServiceAccountImpersonation{
+		TargetServiceAccount: "target@team.iam.gserviceaccount.com",
+	})
+	assert.EqualError(t, err, "unable to impersonate service account target@team.iam.gserviceaccount.com: permission denied")
+}
@@ -64,6 +64,7 @@ const (
 	errInvalidGCPProv         = "invalid gcp secrets manager provider"
 	errInvalidAuthSecretRef   = "invalid auth secret data: %w"
 	errInvalidWISARef         = "invalid workload identity service account reference: %w"
+	errInvalidImpersonation   = "invalid service account impersonation: %s"
 	errUnexpectedFindOperator = "unexpected find operator"
 
 	managedByKey   = "managed-by"
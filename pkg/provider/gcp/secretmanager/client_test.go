@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"github.com/googleapis/gax-go/v2"
@@ -29,6 +30,7 @@ import (
 	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pointer "k8s.io/utils/ptr"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
@@ -1143,6 +1145,40 @@ func TestValidateStore(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "valid impersonation",
+			wantErr: false,
+			args: args{
+				auth: esv1beta1.GCPSMAuth{
+					ServiceAccountImpersonation: &esv1beta1.GCPServiceAccountImpersonation{
+						TargetServiceAccount: "target@project.iam.gserviceaccount.com",
+						Delegates:            []string{"delegate@project.iam.gserviceaccount.com"},
+						Lifetime:             &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			},
+		},
+		{
+			name:    "impersonation without target",
+			wantErr: true,
+			args: args{
+				auth: esv1beta1.GCPSMAuth{
+					ServiceAccountImpersonation: &esv1beta1.GCPServiceAccountImpersonation{},
+				},
+			},
+		},
+		{
+			name:    "impersonation lifetime too long",
+			wantErr: true,
+			args: args{
+				auth: esv1beta1.GCPSMAuth{
+					ServiceAccountImpersonation: &esv1beta1.GCPServiceAccountImpersonation{
+						TargetServiceAccount: "target@project.iam.gserviceaccount.com",
+						Lifetime:             &metav1.Duration{Duration: 13 * time.Hour},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
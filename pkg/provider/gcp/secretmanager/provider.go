@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"golang.org/x/oauth2"
@@ -29,6 +30,9 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
+// maxImpersonationLifetime is the maximum lifetime of an access token of an impersonated service account.
+const maxImpersonationLifetime = 12 * time.Hour
+
 // Provider is a secrets provider for GCP Secret Manager.
 // It implements the necessary NewClient() and ValidateStore() funcs.
 type Provider struct{}
@@ -36,6 +40,7 @@ type Provider struct{}
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &Client{}
 var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.RemoteRefFeatureValidator = &Provider{}
 
 func init() {
 	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
@@ -56,6 +61,11 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// UnsupportedRemoteRefFeatures returns the remote ref features GCP Secret Manager rejects: only single keys of a Secret can be pushed.
+func (p *Provider) UnsupportedRemoteRefFeatures(_ esv1beta1.GenericStore) []esv1beta1.RemoteRefFeature {
+	return []esv1beta1.RemoteRefFeature{esv1beta1.RemoteRefFeaturePushWholeSecret}
+}
+
 // NewClient constructs a GCP Provider.
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	storeSpec := store.GetSpec()
@@ -136,6 +146,14 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 			return nil, fmt.Errorf(errInvalidWISARef, err)
 		}
 	}
+	if imp := g.Auth.ServiceAccountImpersonation; imp != nil {
+		if imp.TargetServiceAccount == "" {
+			return nil, fmt.Errorf(errInvalidImpersonation, "targetServiceAccount must not be empty")
+		}
+		if imp.Lifetime != nil && (imp.Lifetime.Duration < time.Second || imp.Lifetime.Duration > maxImpersonationLifetime) {
+			return nil, fmt.Errorf(errInvalidImpersonation, "lifetime must be between 1s and 12h")
+		}
+	}
 	return nil, nil
 }
 
@@ -0,0 +1,283 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+
+	filterEnvironmentScope = "filter[environment_scope]"
+	headerPrivateToken     = "PRIVATE-TOKEN"
+)
+
+// Server is an in-memory GitLab API serving the subset of the project and group
+// variable endpoints used by the provider. Lists are paginated like the GitLab API,
+// unknown projects, groups and variables return 404 and forbidden ones return 403.
+type Server struct {
+	*httptest.Server
+
+	// Token is the access token expected in the PRIVATE-TOKEN header.
+	Token string
+
+	mu               sync.Mutex
+	projects         map[string]bool
+	groups           map[string]bool
+	projectVariables map[string][]gitlab.ProjectVariable
+	groupVariables   map[string][]gitlab.GroupVariable
+	projectGroups    map[string][]gitlab.ProjectGroup
+	forbidden        map[string]bool
+	requests         []string
+}
+
+// NewServer starts a fake GitLab API accepting the given access token.
+// The server must be closed by the caller.
+func NewServer(token string) *Server {
+	s := &Server{
+		Token:            token,
+		projects:         make(map[string]bool),
+		groups:           make(map[string]bool),
+		projectVariables: make(map[string][]gitlab.ProjectVariable),
+		groupVariables:   make(map[string][]gitlab.GroupVariable),
+		projectGroups:    make(map[string][]gitlab.ProjectGroup),
+		forbidden:        make(map[string]bool),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/{id}/variables", s.listProjectVariables)
+	mux.HandleFunc("GET /api/v4/projects/{id}/variables/{key}", s.getProjectVariable)
+	mux.HandleFunc("GET /api/v4/projects/{id}/groups", s.listProjectGroups)
+	mux.HandleFunc("GET /api/v4/groups/{id}/variables", s.listGroupVariables)
+	mux.HandleFunc("GET /api/v4/groups/{id}/variables/{key}", s.getGroupVariable)
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+// AddProjectVariable stores a variable of the project, the project is created if it does not exist.
+func (s *Server) AddProjectVariable(pid string, v gitlab.ProjectVariable) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v.EnvironmentScope == "" {
+		v.EnvironmentScope = "*"
+	}
+	s.projects[pid] = true
+	s.projectVariables[pid] = append(s.projectVariables[pid], v)
+}
+
+// AddGroupVariable stores a variable of the group, the group is created if it does not exist.
+func (s *Server) AddGroupVariable(gid string, v gitlab.GroupVariable) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v.EnvironmentScope == "" {
+		v.EnvironmentScope = "*"
+	}
+	s.groups[gid] = true
+	s.groupVariables[gid] = append(s.groupVariables[gid], v)
+}
+
+// AddProjectGroup adds an ancestor group to the project, both are created if they do not exist.
+func (s *Server) AddProjectGroup(pid string, g gitlab.ProjectGroup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects[pid] = true
+	s.groups[strconv.Itoa(g.ID)] = true
+	s.projectGroups[pid] = append(s.projectGroups[pid], g)
+}
+
+// ForbidProject makes all requests for the project fail with 403.
+func (s *Server) ForbidProject(pid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects[pid] = true
+	s.forbidden["projects/"+pid] = true
+}
+
+// ForbidGroup makes all requests for the group fail with 403.
+func (s *Server) ForbidGroup(gid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups[gid] = true
+	s.forbidden["groups/"+gid] = true
+}
+
+// Requests returns the method and request URI of all requests received by the server.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+		s.mu.Unlock()
+		if r.Header.Get(headerPrivateToken) != s.Token {
+			writeMessage(w, http.StatusUnauthorized, "401 Unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) listProjectVariables(w http.ResponseWriter, r *http.Request) {
+	pid := r.PathValue("id")
+	if !s.checkAccess(w, "projects/", pid, s.projects) {
+		return
+	}
+	s.mu.Lock()
+	vars := append([]gitlab.ProjectVariable(nil), s.projectVariables[pid]...)
+	s.mu.Unlock()
+	writePage(w, r, vars)
+}
+
+func (s *Server) getProjectVariable(w http.ResponseWriter, r *http.Request) {
+	pid := r.PathValue("id")
+	if !s.checkAccess(w, "projects/", pid, s.projects) {
+		return
+	}
+	s.mu.Lock()
+	vars := append([]gitlab.ProjectVariable(nil), s.projectVariables[pid]...)
+	s.mu.Unlock()
+	writeVariable(w, r, vars, func(v gitlab.ProjectVariable) (string, string) {
+		return v.Key, v.EnvironmentScope
+	})
+}
+
+func (s *Server) listProjectGroups(w http.ResponseWriter, r *http.Request) {
+	pid := r.PathValue("id")
+	if !s.checkAccess(w, "projects/", pid, s.projects) {
+		return
+	}
+	s.mu.Lock()
+	groups := append([]gitlab.ProjectGroup(nil), s.projectGroups[pid]...)
+	s.mu.Unlock()
+	writePage(w, r, groups)
+}
+
+func (s *Server) listGroupVariables(w http.ResponseWriter, r *http.Request) {
+	gid := r.PathValue("id")
+	if !s.checkAccess(w, "groups/", gid, s.groups) {
+		return
+	}
+	s.mu.Lock()
+	vars := append([]gitlab.GroupVariable(nil), s.groupVariables[gid]...)
+	s.mu.Unlock()
+	writePage(w, r, vars)
+}
+
+func (s *Server) getGroupVariable(w http.ResponseWriter, r *http.Request) {
+	gid := r.PathValue("id")
+	if !s.checkAccess(w, "groups/", gid, s.groups) {
+		return
+	}
+	s.mu.Lock()
+	vars := append([]gitlab.GroupVariable(nil), s.groupVariables[gid]...)
+	s.mu.Unlock()
+	writeVariable(w, r, vars, func(v gitlab.GroupVariable) (string, string) {
+		return v.Key, v.EnvironmentScope
+	})
+}
+
+// checkAccess writes a 403 for forbidden and a 404 for unknown projects or groups.
+func (s *Server) checkAccess(w http.ResponseWriter, prefix, id string, known map[string]bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.forbidden[prefix+id] {
+		writeMessage(w, http.StatusForbidden, "403 Forbidden")
+		return false
+	}
+	if !known[id] {
+		if prefix == "groups/" {
+			writeMessage(w, http.StatusNotFound, "404 Group Not Found")
+		} else {
+			writeMessage(w, http.StatusNotFound, "404 Project Not Found")
+		}
+		return false
+	}
+	return true
+}
+
+// writeVariable looks up a single variable by key. Like the GitLab API it filters
+// on filter[environment_scope] and returns 409 if the key is ambiguous without a filter.
+func writeVariable[V GitVariable](w http.ResponseWriter, r *http.Request, vars []V, attrs func(V) (string, string)) {
+	key := r.PathValue("key")
+	scope, filtered := r.URL.Query()[filterEnvironmentScope]
+	var matches []V
+	for _, v := range vars {
+		k, s := attrs(v)
+		if k != key || (filtered && s != scope[0]) {
+			continue
+		}
+		matches = append(matches, v)
+	}
+	switch len(matches) {
+	case 0:
+		writeMessage(w, http.StatusNotFound, "404 Variable Not Found")
+	case 1:
+		writeJSON(w, http.StatusOK, matches[0])
+	default:
+		writeMessage(w, http.StatusConflict, "There are multiple variables with provided parameters. Please use 'filter[environment_scope]'")
+	}
+}
+
+// writePage writes the page requested through the page and per_page parameters
+// along with the pagination headers of the GitLab API.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	perPage = min(perPage, maxPerPage)
+	totalPages := max((len(items)+perPage-1)/perPage, 1)
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+
+	w.Header().Set("X-Page", strconv.Itoa(page))
+	w.Header().Set("X-Per-Page", strconv.Itoa(perPage))
+	w.Header().Set("X-Total", strconv.Itoa(len(items)))
+	w.Header().Set("X-Total-Pages", strconv.Itoa(totalPages))
+	if page > 1 {
+		w.Header().Set("X-Prev-Page", strconv.Itoa(page-1))
+	}
+	if page < totalPages {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	writeJSON(w, http.StatusOK, append(make([]T, 0, end-start), items[start:end]...))
+}
+
+func writeMessage(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -54,7 +54,9 @@ const (
 
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &gitlabBase{}
+var _ esv1beta1.SecretProtectionReporter = &gitlabBase{}
 var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.RemoteRefFeatureValidator = &Provider{}
 
 type ProjectsClient interface {
 	ListProjectsGroups(pid any, opt *gitlab.ListProjectGroupOptions, options ...gitlab.RequestOptionFunc) ([]*gitlab.ProjectGroup, *gitlab.Response, error)
@@ -137,53 +139,43 @@ func (g *gitlabBase) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecr
 		return nil, err
 	}
 
-	var gopts = &gitlab.ListGroupVariablesOptions{PerPage: 100}
 	secretData := make(map[string][]byte)
 	for _, groupID := range g.store.GroupIDs {
-		for groupPage := 1; ; groupPage++ {
-			gopts.Page = groupPage
-			groupVars, response, err := g.groupVariablesClient.ListVariables(groupID, gopts)
-			metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabGroupListVariables, err)
-			if err != nil {
-				return nil, err
-			}
-			for _, data := range groupVars {
-				matching, key, isWildcard := matchesFilter(effectiveEnvironment, data.EnvironmentScope, data.Key, matcher)
-				if !matching && !isWildcard {
-					continue
-				}
-				secretData[key] = []byte(data.Value)
-			}
-			if response.CurrentPage >= response.TotalPages {
-				break
-			}
-		}
-	}
-
-	var popts = &gitlab.ListProjectVariablesOptions{PerPage: 100}
-	for projectPage := 1; ; projectPage++ {
-		popts.Page = projectPage
-		projectData, response, err := g.projectVariablesClient.ListVariables(g.store.ProjectID, popts)
-		metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabProjectListVariables, err)
+		groupVars, err := g.listGroupVariables(groupID)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, data := range projectData {
+		// a variable of the environment takes precedence over the wildcard variable of the same group
+		scoped := make(map[string]bool)
+		for _, data := range groupVars {
 			matching, key, isWildcard := matchesFilter(effectiveEnvironment, data.EnvironmentScope, data.Key, matcher)
-
-			if !matching {
+			if !matching || (isWildcard && scoped[key]) {
 				continue
 			}
-			_, exists := secretData[key]
-			if exists && isWildcard {
-				continue
+			if !isWildcard {
+				scoped[key] = true
 			}
 			secretData[key] = []byte(data.Value)
+			g.recordProtection(key, data.Masked, data.Protected)
 		}
-		if response.CurrentPage >= response.TotalPages {
-			break
+	}
+
+	projectData, err := g.listProjectVariables()
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range projectData {
+		matching, key, isWildcard := matchesFilter(effectiveEnvironment, data.EnvironmentScope, data.Key, matcher)
+
+		if !matching {
+			continue
+		}
+		_, exists := secretData[key]
+		if exists && isWildcard {
+			continue
 		}
+		secretData[key] = []byte(data.Value)
+		g.recordProtection(key, data.Masked, data.Protected)
 	}
 
 	return secretData, nil
@@ -216,46 +208,35 @@ func (g *gitlabBase) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDa
 	// 	"masked": true,
 	// 	"environment_scope": "*"
 	// }
-	var vopts *gitlab.GetProjectVariableOptions
-	if g.store.Environment != "" {
-		vopts = &gitlab.GetProjectVariableOptions{Filter: &gitlab.VariableFilter{EnvironmentScope: g.store.Environment}}
-	}
-
-	data, resp, err := g.projectVariablesClient.GetVariable(g.store.ProjectID, ref.Key, vopts)
-	metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabProjectVariableGet, err)
-	if !isEmptyOrWildcard(g.store.Environment) && resp.StatusCode == http.StatusNotFound {
-		vopts.Filter.EnvironmentScope = "*"
-		data, resp, err = g.projectVariablesClient.GetVariable(g.store.ProjectID, ref.Key, vopts)
-		metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabProjectVariableGet, err)
-	}
-
-	if resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound && err != nil {
+	data, err := g.getProjectVariable(ref.Key)
+	if err != nil {
 		return nil, err
 	}
 
-	err = g.ResolveGroupIds()
-	if err != nil {
+	if err := g.ResolveGroupIds(); err != nil {
 		return nil, err
 	}
 
 	var result []byte
-	if resp.StatusCode < 300 {
+	if data != nil {
 		result, err = extractVariable(ref, data.Value)
-	}
-
-	for i := len(g.store.GroupIDs) - 1; i >= 0; i-- {
-		groupID := g.store.GroupIDs[i]
 		if result != nil {
-			return result, nil
+			g.recordProtection(ref.Key, data.Masked, data.Protected)
 		}
+	} else {
+		err = fmt.Errorf(errVariableNotFound, ref.Key)
+	}
 
-		groupVar, resp, err := g.groupVariablesClient.GetVariable(groupID, ref.Key, nil)
-		metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabGroupGetVariable, err)
-		if resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound && err != nil {
-			return nil, err
+	for i := len(g.store.GroupIDs) - 1; i >= 0 && result == nil; i-- {
+		groupVar, groupErr := g.getGroupVariable(g.store.GroupIDs[i], ref.Key)
+		if groupErr != nil {
+			return nil, groupErr
 		}
-		if resp.StatusCode < 300 {
+		if groupVar != nil {
 			result, _ = extractVariable(ref, groupVar.Value)
+			if result != nil {
+				g.recordProtection(ref.Key, groupVar.Masked, groupVar.Protected)
+			}
 		}
 	}
 
@@ -307,6 +288,37 @@ func (g *gitlabBase) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSec
 	return secretData, nil
 }
 
+// recordProtection keeps track of the masked/protected flags of a variable
+// if the store enables protection verification.
+func (g *gitlabBase) recordProtection(key string, masked, protected bool) {
+	if !g.store.VerifyProtection {
+		return
+	}
+	if g.protections == nil {
+		g.protections = make(map[string]esv1beta1.SecretProtectionStatus)
+	}
+	g.protections[key] = esv1beta1.SecretProtectionStatus{
+		Key:       key,
+		Masked:    masked,
+		Protected: protected,
+	}
+}
+
+// SecretProtections returns the masked/protected flags of the variables read by this client.
+func (g *gitlabBase) SecretProtections() []esv1beta1.SecretProtectionStatus {
+	if len(g.protections) == 0 {
+		return nil
+	}
+	protections := make([]esv1beta1.SecretProtectionStatus, 0, len(g.protections))
+	for _, p := range g.protections {
+		protections = append(protections, p)
+	}
+	sort.Slice(protections, func(i, j int) bool {
+		return protections[i].Key < protections[j].Key
+	})
+	return protections
+}
+
 func isEmptyOrWildcard(environment string) bool {
 	return environment == "" || environment == "*"
 }
@@ -331,19 +343,32 @@ func (g *gitlabBase) Close(_ context.Context) error {
 	return nil
 }
 
+// ResolveGroupIds discovers the ancestor groups of the project if inheritFromGroups is set.
+// The groups are cached per project for the configured TTL.
 func (g *gitlabBase) ResolveGroupIds() error {
-	if g.store.InheritFromGroups {
-		projectGroups, resp, err := g.projectsClient.ListProjectsGroups(g.store.ProjectID, nil)
-		metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabListProjectsGroups, err)
-		if resp.StatusCode >= 400 && err != nil {
-			return err
-		}
-		sort.Sort(ProjectGroupPathSorter(projectGroups))
-		discoveredIds := make([]string, len(projectGroups))
-		for i, group := range projectGroups {
-			discoveredIds[i] = strconv.Itoa(group.ID)
+	if !g.store.InheritFromGroups {
+		return nil
+	}
+	key := groupCacheKey(g.store.URL, g.store.ProjectID)
+	if g.groupCacheTTL > 0 {
+		if ids, ok := cachedProjectGroups(key, g.refreshGroups); ok {
+			g.store.GroupIDs = ids
+			return nil
 		}
-		g.store.GroupIDs = discoveredIds
+	}
+	projectGroups, resp, err := g.projectsClient.ListProjectsGroups(g.store.ProjectID, nil)
+	metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabListProjectsGroups, err)
+	if resp.StatusCode >= 400 && err != nil {
+		return err
+	}
+	sort.Sort(ProjectGroupPathSorter(projectGroups))
+	discoveredIds := make([]string, len(projectGroups))
+	for i, group := range projectGroups {
+		discoveredIds[i] = strconv.Itoa(group.ID)
+	}
+	g.store.GroupIDs = discoveredIds
+	if g.groupCacheTTL > 0 {
+		cacheProjectGroups(key, g.refreshGroups, g.groupCacheTTL, discoveredIds)
 	}
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	tassert "github.com/stretchr/testify/assert"
@@ -404,12 +405,19 @@ func TestGetSecret(t *testing.T) {
 		smtc.expectedSecret = smtc.projectAPIOutput.Value
 	}
 	onlyWildcardSecret := func(smtc *secretManagerTestCase) {
-		smtc.projectAPIOutput.Value = ""
-		smtc.projectAPIResponse.Response.StatusCode = 404
+		smtc.projectAPIOutput.Value = projectvalue
+		smtc.projectAPIOutput.EnvironmentScope = "*"
 		smtc.groupAPIResponse = nil
 		smtc.groupAPIOutput = nil
 		smtc.expectedSecret = smtc.projectAPIOutput.Value
 	}
+	missingSecret := func(smtc *secretManagerTestCase) {
+		smtc.projectAPIOutput.Value = projectvalue
+		smtc.projectAPIOutput.EnvironmentScope = environmentTest
+		smtc.groupAPIResponse = nil
+		smtc.groupAPIOutput = nil
+		smtc.expectError = fmt.Sprintf(errVariableNotFound, testKey)
+	}
 	groupSecretProjectOverride := func(smtc *secretManagerTestCase) {
 		smtc.projectAPIOutput.Value = projectvalue
 		smtc.groupAPIOutput.Key = testKey
@@ -418,7 +426,7 @@ func TestGetSecret(t *testing.T) {
 	}
 	groupWithoutProjectOverride := func(smtc *secretManagerTestCase) {
 		smtc.groupIDs = []string{groupid}
-		smtc.projectAPIResponse.Response.StatusCode = 404
+		smtc.projectAPIOutput.EnvironmentScope = environmentTest
 		smtc.groupAPIOutput.Key = testKey
 		smtc.groupAPIOutput.Value = groupvalue
 		smtc.expectedSecret = smtc.groupAPIOutput.Value
@@ -427,15 +435,17 @@ func TestGetSecret(t *testing.T) {
 	successCases := []*secretManagerTestCase{
 		makeValidSecretManagerTestCaseCustom(onlyProjectSecret),
 		makeValidSecretManagerTestCaseCustom(onlyWildcardSecret),
+		makeValidSecretManagerTestCaseCustom(missingSecret),
 		makeValidSecretManagerTestCaseCustom(groupSecretProjectOverride),
 		makeValidSecretManagerTestCaseCustom(groupWithoutProjectOverride),
 		makeValidSecretManagerTestCaseCustom(setAPIErr),
 		makeValidSecretManagerTestCaseCustom(setNilMockClient),
 	}
 
-	sm := gitlabBase{}
-	sm.store = &esv1beta1.GitlabProvider{}
 	for k, v := range successCases {
+		// a new client per case, the clients keep the listed project variables
+		sm := gitlabBase{}
+		sm.store = &esv1beta1.GitlabProvider{}
 		sm.projectVariablesClient = v.mockProjectVarClient
 		sm.groupVariablesClient = v.mockGroupVarClient
 		sm.store.ProjectID = v.projectID
@@ -451,6 +461,35 @@ func TestGetSecret(t *testing.T) {
 	}
 }
 
+func TestGetSecretProtections(t *testing.T) {
+	maskedProjectSecret := func(smtc *secretManagerTestCase) {
+		smtc.projectAPIOutput.Value = projectvalue
+		smtc.projectAPIOutput.Masked = true
+		smtc.projectAPIOutput.Protected = true
+		smtc.groupAPIResponse = nil
+		smtc.groupAPIOutput = nil
+	}
+
+	for _, verify := range []bool{true, false} {
+		v := makeValidSecretManagerTestCaseCustom(maskedProjectSecret)
+		sm := gitlabBase{
+			store: &esv1beta1.GitlabProvider{
+				ProjectID:        v.projectID,
+				VerifyProtection: verify,
+			},
+			projectVariablesClient: v.mockProjectVarClient,
+			groupVariablesClient:   v.mockGroupVarClient,
+		}
+		_, err := sm.GetSecret(context.Background(), *v.ref)
+		tassert.NoError(t, err)
+		var want []esv1beta1.SecretProtectionStatus
+		if verify {
+			want = []esv1beta1.SecretProtectionStatus{{Key: v.ref.Key, Masked: true, Protected: true}}
+		}
+		tassert.Equal(t, want, sm.SecretProtections(), "verifyProtection=%t", verify)
+	}
+}
+
 func TestResolveGroupIds(t *testing.T) {
 	v := makeValidSecretManagerTestCaseCustom()
 	sm := gitlabBase{}
@@ -645,9 +684,9 @@ func TestGetAllSecrets(t *testing.T) {
 		makeValidSecretManagerGetAllTestCaseCustom(setNilMockClient),
 	}
 
-	sm := gitlabBase{}
-	sm.store = &esv1beta1.GitlabProvider{}
 	for k, v := range cases {
+		sm := gitlabBase{}
+		sm.store = &esv1beta1.GitlabProvider{}
 		sm.projectVariablesClient = v.mockProjectVarClient
 		sm.groupVariablesClient = v.mockGroupVarClient
 		sm.store.Environment = v.apiInputEnv
@@ -705,10 +744,10 @@ func TestGetAllSecretsWithGroups(t *testing.T) {
 		makeValidSecretManagerGetAllTestCaseCustom(groupAndProjectWithDifferentEnvSecrets),
 	}
 
-	sm := gitlabBase{}
-	sm.store = &esv1beta1.GitlabProvider{}
-	sm.store.Environment = environment
 	for k, v := range cases {
+		sm := gitlabBase{}
+		sm.store = &esv1beta1.GitlabProvider{}
+		sm.store.Environment = environment
 		sm.projectVariablesClient = v.mockProjectVarClient
 		sm.groupVariablesClient = v.mockGroupVarClient
 		sm.store.ProjectID = v.projectID
@@ -783,9 +822,9 @@ func TestGetSecretMap(t *testing.T) {
 		makeValidSecretManagerTestCaseCustom(setAPIErr),
 	}
 
-	sm := gitlabBase{}
-	sm.store = &esv1beta1.GitlabProvider{}
 	for k, v := range successCases {
+		sm := gitlabBase{}
+		sm.store = &esv1beta1.GitlabProvider{}
 		sm.projectVariablesClient = v.mockProjectVarClient
 		sm.groupVariablesClient = v.mockGroupVarClient
 		out, err := sm.GetSecretMap(context.Background(), *v.ref)
@@ -896,3 +935,338 @@ func ErrorContains(out error, want string) bool {
 }
 
 type storeModifier func(*esv1beta1.SecretStore) *esv1beta1.SecretStore
+
+const (
+	fakeServerToken     = "fake-token"
+	fakeServerProjectID = "1"
+	fakeServerTokenName = "gitlab-token"
+	fakeServerTokenKey  = "token"
+)
+
+func withURL(url string) storeModifier {
+	return func(store *esv1beta1.SecretStore) *esv1beta1.SecretStore {
+		store.Spec.Provider.Gitlab.URL = url
+		return store
+	}
+}
+
+// newFakeServerClient creates a client through the provider that talks to the fake GitLab server.
+func newFakeServerClient(t *testing.T, srv *fakegitlab.Server, token, environment string, fn ...storeModifier) esv1beta1.SecretsClient {
+	t.Helper()
+	ctx := context.Background()
+	const namespace = "namespace"
+	kube := clientfake.NewClientBuilder().Build()
+	tassert.Nil(t, createK8sSecret(ctx, t, kube, namespace, fakeServerTokenName, fakeServerTokenKey, []byte(token)))
+
+	fn = append(fn, withAccessToken(fakeServerTokenName, fakeServerTokenKey, nil), withURL(srv.URL))
+	store := makeSecretStore(fakeServerProjectID, environment, fn...)
+	store.Namespace = namespace
+	client, err := (&Provider{}).NewClient(ctx, store, kube, namespace)
+	tassert.Nil(t, err)
+	return client
+}
+
+func TestFakeServerGetSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(srv *fakegitlab.Server)
+		token       string
+		environment string
+		groups      storeModifier
+		key         string
+		want        string
+		wantErr     string
+	}{
+		{
+			name: "environment scope takes precedence over wildcard",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: "wildcard"})
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: projectvalue, EnvironmentScope: environment})
+			},
+			environment: environment,
+			key:         testKey,
+			want:        projectvalue,
+		},
+		{
+			name: "falls back to wildcard scope",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: "wildcard"})
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: projectvalue, EnvironmentScope: environmentTest})
+			},
+			environment: environment,
+			key:         testKey,
+			want:        "wildcard",
+		},
+		{
+			name: "hyphens are replaced with underscores",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: "MY_KEY", Value: projectvalue})
+			},
+			key:  "MY-KEY",
+			want: projectvalue,
+		},
+		{
+			name: "ambiguous key without environment",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: "wildcard"})
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: projectvalue, EnvironmentScope: environment})
+			},
+			key:     testKey,
+			wantErr: fmt.Sprintf(errAmbiguousVariable, testKey),
+		},
+		{
+			name: "falls back to group variable",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: projectvalue, EnvironmentScope: environmentTest})
+				srv.AddGroupVariable("10", gitlab.GroupVariable{Key: testKey, Value: groupvalue})
+			},
+			environment: environment,
+			groups:      withGroups([]string{"10"}, false),
+			key:         testKey,
+			want:        groupvalue,
+		},
+		{
+			name: "group environment scope takes precedence over wildcard",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: "OTHER", Value: projectvalue})
+				srv.AddGroupVariable("10", gitlab.GroupVariable{Key: testKey, Value: "wildcard"})
+				srv.AddGroupVariable("10", gitlab.GroupVariable{Key: testKey, Value: groupvalue, EnvironmentScope: environment})
+				srv.AddGroupVariable("10", gitlab.GroupVariable{Key: testKey, Value: "test", EnvironmentScope: environmentTest})
+			},
+			environment: environment,
+			groups:      withGroups([]string{"10"}, false),
+			key:         testKey,
+			want:        groupvalue,
+		},
+		{
+			name: "group variable of another environment is ignored",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: "OTHER", Value: projectvalue})
+				srv.AddProjectGroup(fakeServerProjectID, gitlab.ProjectGroup{ID: 10, FullPath: "parent"})
+				srv.AddProjectGroup(fakeServerProjectID, gitlab.ProjectGroup{ID: 11, FullPath: "parent/child"})
+				srv.AddGroupVariable("10", gitlab.GroupVariable{Key: testKey, Value: "parent"})
+				srv.AddGroupVariable("11", gitlab.GroupVariable{Key: testKey, Value: "child", EnvironmentScope: environmentTest})
+			},
+			environment: environment,
+			groups:      withGroups(nil, true),
+			key:         testKey,
+			want:        "parent",
+		},
+		{
+			name: "nearest inherited group wins",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: "OTHER", Value: projectvalue})
+				srv.AddProjectGroup(fakeServerProjectID, gitlab.ProjectGroup{ID: 10, FullPath: "parent"})
+				srv.AddProjectGroup(fakeServerProjectID, gitlab.ProjectGroup{ID: 11, FullPath: "parent/child"})
+				srv.AddGroupVariable("10", gitlab.GroupVariable{Key: testKey, Value: "parent"})
+				srv.AddGroupVariable("11", gitlab.GroupVariable{Key: testKey, Value: "child"})
+			},
+			groups: withGroups(nil, true),
+			key:    testKey,
+			want:   "child",
+		},
+		{
+			name: "missing variable",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: "OTHER", Value: projectvalue})
+			},
+			environment: environment,
+			key:         testKey,
+			wantErr:     fmt.Sprintf(errVariableNotFound, testKey),
+		},
+		{
+			name: "forbidden project",
+			setup: func(srv *fakegitlab.Server) {
+				srv.ForbidProject(fakeServerProjectID)
+			},
+			key:     testKey,
+			wantErr: "403 Forbidden",
+		},
+		{
+			name: "forbidden group",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: "OTHER", Value: projectvalue})
+				srv.ForbidGroup("10")
+			},
+			groups:  withGroups([]string{"10"}, false),
+			key:     testKey,
+			wantErr: "403 Forbidden",
+		},
+		{
+			name: "invalid token",
+			setup: func(srv *fakegitlab.Server) {
+				srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: projectvalue})
+			},
+			token:   "invalid",
+			key:     testKey,
+			wantErr: "401 Unauthorized",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := fakegitlab.NewServer(fakeServerToken)
+			defer srv.Close()
+			tc.setup(srv)
+
+			token := fakeServerToken
+			if tc.token != "" {
+				token = tc.token
+			}
+			var fn []storeModifier
+			if tc.groups != nil {
+				fn = append(fn, tc.groups)
+			}
+			client := newFakeServerClient(t, srv, token, tc.environment, fn...)
+
+			got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: tc.key})
+			if tc.wantErr != "" {
+				tassert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			tassert.Nil(t, err)
+			tassert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestFakeServerGetAllSecrets(t *testing.T) {
+	srv := fakegitlab.NewServer(fakeServerToken)
+	defer srv.Close()
+
+	// more variables than fit on a single page
+	const total = 250
+	want := make(map[string][]byte)
+	for i := 0; i < total; i++ {
+		key := fmt.Sprintf("test_%03d", i)
+		srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: key, Value: key})
+		want[key] = []byte(key)
+	}
+	srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: "test_scoped", Value: projectvalue, EnvironmentScope: environment})
+	srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: "test_other", Value: projectvalue, EnvironmentScope: environmentTest})
+	want["test_scoped"] = []byte(projectvalue)
+
+	srv.AddProjectGroup(fakeServerProjectID, gitlab.ProjectGroup{ID: 10, FullPath: "parent"})
+	srv.AddGroupVariable("10", gitlab.GroupVariable{Key: "test_group", Value: groupvalue})
+	srv.AddGroupVariable("10", gitlab.GroupVariable{Key: "test_000", Value: groupvalue, EnvironmentScope: environment})
+	srv.AddGroupVariable("10", gitlab.GroupVariable{Key: "ignored", Value: groupvalue})
+	srv.AddGroupVariable("10", gitlab.GroupVariable{Key: "test_group_scoped", Value: groupvalue, EnvironmentScope: environment})
+	srv.AddGroupVariable("10", gitlab.GroupVariable{Key: "test_group_scoped", Value: "wildcard"})
+	srv.AddGroupVariable("10", gitlab.GroupVariable{Key: "test_group_other", Value: groupvalue, EnvironmentScope: environmentTest})
+	want["test_group"] = []byte(groupvalue)
+	// the environment scoped group variable takes precedence over the wildcard one, regardless of their order
+	want["test_group_scoped"] = []byte(groupvalue)
+	// an environment scoped group variable takes precedence over a wildcard project variable
+	want["test_000"] = []byte(groupvalue)
+
+	client := newFakeServerClient(t, srv, fakeServerToken, environment, withGroups(nil, true))
+	got, err := client.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: makeFindName(findTestPrefix)})
+	tassert.Nil(t, err)
+	tassert.Equal(t, want, got)
+
+	var pages []string
+	for _, req := range srv.Requests() {
+		if strings.HasPrefix(req, "GET /api/v4/projects/"+fakeServerProjectID+"/variables?") {
+			pages = append(pages, req)
+		}
+	}
+	tassert.Len(t, pages, 3)
+}
+
+func TestFakeServerGetAllSecretsForbidden(t *testing.T) {
+	srv := fakegitlab.NewServer(fakeServerToken)
+	defer srv.Close()
+	srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: testKey, Value: projectvalue})
+	srv.ForbidGroup("10")
+
+	client := newFakeServerClient(t, srv, fakeServerToken, "", withGroups([]string{"10"}, false))
+	_, err := client.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: makeFindName(findTestPrefix)})
+	tassert.ErrorContains(t, err, "403 Forbidden")
+}
+
+func TestFakeServerProjectVariablesCache(t *testing.T) {
+	srv := fakegitlab.NewServer(fakeServerToken)
+	defer srv.Close()
+	for i := 0; i < 150; i++ {
+		key := fmt.Sprintf("test_%03d", i)
+		srv.AddProjectVariable(fakeServerProjectID, gitlab.ProjectVariable{Key: key, Value: key})
+	}
+	projectRequests := func() int {
+		n := 0
+		for _, req := range srv.Requests() {
+			if strings.HasPrefix(req, "GET /api/v4/projects/"+fakeServerProjectID+"/variables") {
+				n++
+			}
+		}
+		return n
+	}
+
+	// the keys are served from a single paginated list per client
+	client := newFakeServerClient(t, srv, fakeServerToken, "")
+	for _, key := range []string{"test_000", "test_120", "test_149"} {
+		got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: key})
+		tassert.Nil(t, err)
+		tassert.Equal(t, key, string(got))
+	}
+	_, err := client.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{Name: makeFindName(findTestPrefix)})
+	tassert.Nil(t, err)
+	tassert.Equal(t, 2, projectRequests())
+
+	// a new client lists the variables again
+	client = newFakeServerClient(t, srv, fakeServerToken, "")
+	_, err = client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "test_000"})
+	tassert.Nil(t, err)
+	tassert.Equal(t, 4, projectRequests())
+}
+
+func withAnnotation(key, value string) storeModifier {
+	return func(store *esv1beta1.SecretStore) *esv1beta1.SecretStore {
+		metav1.SetMetaDataAnnotation(&store.ObjectMeta, key, value)
+		return store
+	}
+}
+
+func TestFakeServerProjectGroupsCache(t *testing.T) {
+	srv := fakegitlab.NewServer(fakeServerToken)
+	defer srv.Close()
+	srv.AddProjectGroup(fakeServerProjectID, gitlab.ProjectGroup{ID: 10, FullPath: "parent"})
+	srv.AddGroupVariable("10", gitlab.GroupVariable{Key: testKey, Value: groupvalue})
+
+	groupLookups := func() int {
+		n := 0
+		for _, req := range srv.Requests() {
+			if strings.HasPrefix(req, "GET /api/v4/projects/"+fakeServerProjectID+"/groups") {
+				n++
+			}
+		}
+		return n
+	}
+	getSecret := func(fn ...storeModifier) {
+		t.Helper()
+		client := newFakeServerClient(t, srv, fakeServerToken, "", append(fn, withGroups(nil, true))...)
+		got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: testKey})
+		tassert.Nil(t, err)
+		tassert.Equal(t, groupvalue, string(got))
+	}
+
+	// the groups are discovered once and shared by the clients of the project
+	getSecret()
+	getSecret()
+	tassert.Equal(t, 1, groupLookups())
+
+	// changing the refresh annotation forces a new discovery
+	getSecret(withAnnotation(AnnotationRefreshGroups, "1"))
+	getSecret(withAnnotation(AnnotationRefreshGroups, "1"))
+	tassert.Equal(t, 2, groupLookups())
+
+	// expired groups are discovered again
+	defer func(ttl time.Duration) { groupCacheTTL = ttl }(groupCacheTTL)
+	groupCacheTTL = time.Nanosecond
+	getSecret(withAnnotation(AnnotationRefreshGroups, "2"))
+	getSecret(withAnnotation(AnnotationRefreshGroups, "2"))
+	tassert.Equal(t, 4, groupLookups())
+
+	// caching can be disabled
+	groupCacheTTL = 0
+	getSecret()
+	tassert.Equal(t, 5, groupLookups())
+}
@@ -0,0 +1,73 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"slices"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/external-secrets/external-secrets/pkg/cache"
+	"github.com/external-secrets/external-secrets/pkg/feature"
+)
+
+const (
+	// AnnotationRefreshGroups forces the rediscovery of the project groups when its value changes,
+	// e.g. `kubectl annotate secretstore <name> gitlab.external-secrets.io/refresh-groups="$(date +%s)" --overwrite`.
+	AnnotationRefreshGroups = "gitlab.external-secrets.io/refresh-groups"
+
+	projectGroupsCacheKind = "ProjectGroups"
+	defaultGroupCacheTTL   = 5 * time.Minute
+	groupCacheSize         = 1024
+)
+
+var (
+	groupCacheTTL time.Duration
+	groupCache    = cache.Must[projectGroups](groupCacheSize, nil)
+)
+
+// projectGroups holds the ancestor groups discovered for a project.
+type projectGroups struct {
+	ids       []string
+	expiresAt time.Time
+}
+
+func init() {
+	fs := pflag.NewFlagSet("gitlab", pflag.ExitOnError)
+	fs.DurationVar(&groupCacheTTL, "gitlab-group-cache-ttl", defaultGroupCacheTTL, "Duration the groups discovered for a GitLab project with inheritFromGroups are cached, 0 disables the cache.")
+	feature.Register(feature.Feature{
+		Flags: fs,
+	})
+}
+
+// groupCacheKey identifies a project on a GitLab instance.
+func groupCacheKey(url, projectID string) cache.Key {
+	return cache.Key{Name: projectID, Namespace: url, Kind: projectGroupsCacheKind}
+}
+
+// cachedProjectGroups returns the cached groups of the project unless they expired.
+// The refresh value is the version of the entry, a different value is a cache miss.
+func cachedProjectGroups(key cache.Key, refresh string) ([]string, bool) {
+	groups, ok := groupCache.Get(refresh, key)
+	if !ok || time.Now().After(groups.expiresAt) {
+		return nil, false
+	}
+	return slices.Clone(groups.ids), true
+}
+
+func cacheProjectGroups(key cache.Key, refresh string, ttl time.Duration, ids []string) {
+	groupCache.Add(refresh, key, projectGroups{ids: slices.Clone(ids), expiresAt: time.Now().Add(ttl)})
+}
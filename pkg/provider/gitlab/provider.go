@@ -17,15 +17,21 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/xanzy/go-gitlab"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
+const recommendedMinRefreshInterval = time.Minute
+
 // Provider satisfies the provider interface.
 type Provider struct{}
 
@@ -39,6 +45,26 @@ type gitlabBase struct {
 	projectsClient         ProjectsClient
 	projectVariablesClient ProjectVariablesClient
 	groupVariablesClient   GroupVariablesClient
+
+	// groupCacheTTL is the duration the discovered project groups are cached, caching is disabled if 0.
+	groupCacheTTL time.Duration
+	// refreshGroups is the value of the refresh annotation of the store.
+	refreshGroups string
+
+	// protections holds the masked/protected flags of the variables read by this client,
+	// it is only populated if the store enables protection verification.
+	protections map[string]esv1beta1.SecretProtectionStatus
+
+	// projectVariables is the variable list of the project, it is served until projectVariablesExpiry.
+	projectVariables       []*gitlab.ProjectVariable
+	projectVariablesExpiry time.Time
+	// groupVariables holds the variable lists of the groups by group ID.
+	groupVariables map[string]cachedGroupVariables
+}
+
+type cachedGroupVariables struct {
+	variables []*gitlab.GroupVariable
+	expiry    time.Time
 }
 
 // Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
@@ -46,6 +72,20 @@ func (g *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// UnsupportedRemoteRefFeatures returns the remote ref features GitLab ignores: variables have no versions or metadata.
+func (g *Provider) UnsupportedRemoteRefFeatures(_ esv1beta1.GenericStore) []esv1beta1.RemoteRefFeature {
+	return []esv1beta1.RemoteRefFeature{
+		esv1beta1.RemoteRefFeatureVersion,
+		esv1beta1.RemoteRefFeatureMetadataPolicyFetch,
+	}
+}
+
+// RecommendedMinRefreshInterval returns the shortest recommended refresh interval.
+// The GitLab API is rate limited per user, every refresh lists the variables of the project and its groups.
+func (g *Provider) RecommendedMinRefreshInterval() time.Duration {
+	return recommendedMinRefreshInterval
+}
+
 // Method on GitLab Provider to set up projectVariablesClient with credentials, populate projectID and environment.
 func (g *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	storeSpec := store.GetSpec()
@@ -59,9 +99,13 @@ func (g *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 		store:     storeSpecGitlab,
 		namespace: namespace,
 		storeKind: store.GetObjectKind().GroupVersionKind().Kind,
+
+		groupCacheTTL: groupCacheTTL,
+		refreshGroups: store.GetAnnotations()[AnnotationRefreshGroups],
 	}
 
-	client, err := gl.getClient(ctx, storeSpecGitlab)
+	quota := metrics.NewQuotaObserver(constants.ProviderGitLab, store.GetKind(), store.GetNamespace(), store.GetName())
+	client, err := gl.getClient(ctx, storeSpecGitlab, quota)
 	if err != nil {
 		return nil, err
 	}
@@ -72,14 +116,17 @@ func (g *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore,
 	return gl, nil
 }
 
-func (g *gitlabBase) getClient(ctx context.Context, provider *esv1beta1.GitlabProvider) (*gitlab.Client, error) {
+func (g *gitlabBase) getClient(ctx context.Context, provider *esv1beta1.GitlabProvider, quota *metrics.QuotaObserver) (*gitlab.Client, error) {
 	credentials, err := g.getAuth(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create projectVariablesClient options
-	var opts []gitlab.ClientOptionFunc
+	// record the RateLimit-* headers of the responses
+	opts := []gitlab.ClientOptionFunc{
+		gitlab.WithHTTPClient(&http.Client{Transport: quota.RoundTripper(nil)}),
+	}
 	if provider.URL != "" {
 		opts = append(opts, gitlab.WithBaseURL(provider.URL))
 	}
@@ -0,0 +1,145 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitlab
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	// projectVariablesTTL bounds how long a client serves keys from the listed variables,
+	// clients usually live for a single reconcile only.
+	projectVariablesTTL = 30 * time.Second
+	variablesPerPage    = 100
+
+	errVariableNotFound  = "variable %s not found"
+	errAmbiguousVariable = "variable %s is defined for multiple environment scopes, set the environment of the store"
+)
+
+// listProjectVariables returns all variables of the project. The paginated list is fetched
+// once per client and reused for a short TTL, so the keys of an ExternalSecret don't cost
+// an API call each.
+func (g *gitlabBase) listProjectVariables() ([]*gitlab.ProjectVariable, error) {
+	if time.Now().Before(g.projectVariablesExpiry) {
+		return g.projectVariables, nil
+	}
+	var variables []*gitlab.ProjectVariable
+	opts := &gitlab.ListProjectVariablesOptions{PerPage: variablesPerPage}
+	for page := 1; ; page++ {
+		opts.Page = page
+		data, response, err := g.projectVariablesClient.ListVariables(g.store.ProjectID, opts)
+		metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabProjectListVariables, err)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, data...)
+		if response.CurrentPage >= response.TotalPages {
+			break
+		}
+	}
+	g.projectVariables = variables
+	g.projectVariablesExpiry = time.Now().Add(projectVariablesTTL)
+	return variables, nil
+}
+
+// listGroupVariables returns all variables of the group, cached per client like the project variables.
+func (g *gitlabBase) listGroupVariables(groupID string) ([]*gitlab.GroupVariable, error) {
+	if cached, ok := g.groupVariables[groupID]; ok && time.Now().Before(cached.expiry) {
+		return cached.variables, nil
+	}
+	var variables []*gitlab.GroupVariable
+	opts := &gitlab.ListGroupVariablesOptions{PerPage: variablesPerPage}
+	for page := 1; ; page++ {
+		opts.Page = page
+		data, response, err := g.groupVariablesClient.ListVariables(groupID, opts)
+		metrics.ObserveAPICall(constants.ProviderGitLab, constants.CallGitLabGroupListVariables, err)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, data...)
+		if response.CurrentPage >= response.TotalPages {
+			break
+		}
+	}
+	if g.groupVariables == nil {
+		g.groupVariables = make(map[string]cachedGroupVariables)
+	}
+	g.groupVariables[groupID] = cachedGroupVariables{
+		variables: variables,
+		expiry:    time.Now().Add(projectVariablesTTL),
+	}
+	return variables, nil
+}
+
+// getProjectVariable looks up the variable of the key in the listed project variables.
+// It returns nil if the project has no match.
+func (g *gitlabBase) getProjectVariable(key string) (*gitlab.ProjectVariable, error) {
+	variables, err := g.listProjectVariables()
+	if err != nil {
+		return nil, err
+	}
+	return selectVariable(variables, key, g.store.Environment, func(v *gitlab.ProjectVariable) (string, string) {
+		return v.Key, v.EnvironmentScope
+	})
+}
+
+// getGroupVariable looks up the variable of the key in the listed group variables, with the same
+// environment scope filtering as the project variables. It returns nil if the group has no match.
+func (g *gitlabBase) getGroupVariable(groupID, key string) (*gitlab.GroupVariable, error) {
+	variables, err := g.listGroupVariables(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return selectVariable(variables, key, g.store.Environment, func(v *gitlab.GroupVariable) (string, string) {
+		return v.Key, v.EnvironmentScope
+	})
+}
+
+// selectVariable picks the variable of the key like the GitLab API would: the environment
+// of the store takes precedence over the wildcard scope, without an environment the key must be unique.
+func selectVariable[V any](variables []*V, key, environment string, attrs func(*V) (string, string)) (*V, error) {
+	var scoped, wildcard *V
+	matches := 0
+	for _, v := range variables {
+		k, scope := attrs(v)
+		if k != key {
+			continue
+		}
+		matches++
+		if environment != "" && scope == environment {
+			scoped = v
+		}
+		if scope == "*" {
+			wildcard = v
+		}
+		if environment == "" {
+			scoped = v
+		}
+	}
+	switch {
+	case environment == "" && matches > 1:
+		return nil, fmt.Errorf(errAmbiguousVariable, key)
+	case scoped != nil:
+		return scoped, nil
+	default:
+		return wildcard, nil
+	}
+}
@@ -41,14 +41,16 @@ const (
 	errKeeperSecurityNoFields                   = "invalid Secret. Secret %s does not contain any valid field/file"
 	keeperSecurityFileRef                       = "fileRef"
 	keeperSecurityMfa                           = "oneTimeCode"
-	errTagsNotImplemented                       = "'find.tags' is not implemented in the KeeperSecurity provider"
-	errPathNotImplemented                       = "'find.path' is not implemented in the KeeperSecurity provider"
+	errTagsNotImplemented                       = "'find.tags' only supports the record type tag %q in the KeeperSecurity provider"
 	errInvalidJSONSecret                        = "invalid Secret. Secret %s can not be converted to JSON. %w"
 	errInvalidRegex                             = "find.name.regex. Invalid Regular expresion %s. %w"
 	errInvalidRemoteRefKey                      = "match.remoteRef.remoteKey. Invalid format. Format should match secretName/key got %s"
 	errInvalidSecretType                        = "ESO can only push/delete %s record types. Secret %s is type %s"
 	errFieldNotFound                            = "secret %s does not contain any custom field with label %s"
 
+	// findTagRecordType filters the records found by their record type, e.g. login.
+	findTagRecordType = "type"
+
 	externalSecretType = "externalSecrets"
 	secretType         = "secret"
 	LoginType          = "login"
@@ -93,13 +95,17 @@ type Secret struct {
 	Fields []Field       `json:"fields"`
 	Custom []CustomField `json:"custom"`
 	Files  []File        `json:"files"`
+
+	// attachments are the file attachments of the record, their content is downloaded by loadFiles.
+	attachments []*ksm.KeeperFile
+	filesLoaded bool
 }
 
 func (c *Client) Validate() (esv1beta1.ValidationResult, error) {
 	return esv1beta1.ValidationResultReady, nil
 }
 
-func (c *Client) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	record, err := c.findSecretByID(ref.Key)
 	if err != nil {
 		return nil, err
@@ -108,11 +114,16 @@ func (c *Client) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRe
 	if err != nil {
 		return nil, err
 	}
+	if ref.Property == "" || !secret.hasField(ref.Property) {
+		if err := secret.loadFiles(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	return secret.getItem(ref)
 }
 
-func (c *Client) GetSecretMap(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
 	record, err := c.findSecretByID(ref.Key)
 	if err != nil {
 		return nil, err
@@ -121,16 +132,26 @@ func (c *Client) GetSecretMap(_ context.Context, ref esv1beta1.ExternalSecretDat
 	if err != nil {
 		return nil, err
 	}
+	if err := secret.loadFiles(ctx); err != nil {
+		return nil, err
+	}
 
 	return secret.getItems(ref)
 }
 
-func (c *Client) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	if ref.Tags != nil {
-		return nil, fmt.Errorf(errTagsNotImplemented)
+// GetAllSecrets returns the records shared with the application as JSON, named by their title.
+// find.path filters the records by the UID of their folder, the tag type by their record type.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	recordType, err := findRecordType(ref.Tags)
+	if err != nil {
+		return nil, err
 	}
-	if ref.Path != nil {
-		return nil, fmt.Errorf(errPathNotImplemented)
+	var name *regexp.Regexp
+	if ref.Name != nil {
+		name, err = regexp.Compile(ref.Name.RegExp)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidRegex, ref.Name.RegExp, err)
+		}
 	}
 	secretData := make(map[string][]byte)
 	records, err := c.findSecrets()
@@ -138,17 +159,23 @@ func (c *Client) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFi
 		return nil, err
 	}
 	for _, record := range records {
+		if ref.Path != nil && !inFolder(record, *ref.Path) {
+			continue
+		}
 		secret, err := c.getValidKeeperSecret(record)
 		if err != nil {
 			return nil, err
 		}
-		match, err := regexp.MatchString(ref.Name.RegExp, secret.Title)
-		if err != nil {
-			return nil, fmt.Errorf(errInvalidRegex, ref.Name.RegExp, err)
+		if recordType != "" && secret.Type != recordType {
+			continue
 		}
-		if !match {
+		if name != nil && !name.MatchString(secret.Title) {
 			continue
 		}
+		// the files are only downloaded for the matching records
+		if err := secret.loadFiles(ctx); err != nil {
+			return nil, err
+		}
 		secretData[secret.Title], err = secret.getItem(esv1beta1.ExternalSecretDataRemoteRef{})
 		if err != nil {
 			return nil, err
@@ -158,6 +185,22 @@ func (c *Client) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFi
 	return secretData, nil
 }
 
+// findRecordType returns the record type of the find tags, the only tag supported.
+func findRecordType(tags map[string]string) (string, error) {
+	for tag := range tags {
+		if tag != findTagRecordType {
+			return "", fmt.Errorf(errTagsNotImplemented, findTagRecordType)
+		}
+	}
+
+	return tags[findTagRecordType], nil
+}
+
+// inFolder returns true if the record is in the folder, or in a subfolder of the shared folder.
+func inFolder(record *ksm.Record, folderUID string) bool {
+	return record.FolderUid() == folderUID || record.InnerFolderUid() == folderUID
+}
+
 func (c *Client) Close(_ context.Context) error {
 	return nil
 }
@@ -167,6 +210,10 @@ func (c *Client) PushSecret(_ context.Context, secret *corev1.Secret, data esv1b
 		return fmt.Errorf("pushing the whole secret is not yet implemented")
 	}
 
+	metadata, err := parsePushSecretMetadata(data)
+	if err != nil {
+		return err
+	}
 	value := secret.Data[data.GetSecretKey()]
 	parts, err := c.buildSecretNameAndKey(data)
 	if err != nil {
@@ -174,16 +221,16 @@ func (c *Client) PushSecret(_ context.Context, secret *corev1.Secret, data esv1b
 	}
 	record, err := c.findSecretByName(parts[0])
 	if err != nil {
-		_, err = c.createSecret(parts[0], parts[1], value)
+		_, err = c.createSecret(parts[0], parts[1], value, metadata)
 		if err != nil {
 			return err
 		}
 	}
 	if record != nil {
-		if record.Type() != externalSecretType {
-			return fmt.Errorf(errInvalidSecretType, externalSecretType, record.Title(), record.Type())
+		if record.Type() != metadata.RecordType {
+			return fmt.Errorf(errInvalidSecretType, metadata.RecordType, record.Title(), record.Type())
 		}
-		err = c.updateSecret(record, parts[1], value)
+		err = c.updateSecret(record, parts[1], value, metadata)
 		if err != nil {
 			return err
 		}
@@ -225,9 +272,22 @@ func (c *Client) buildSecretNameAndKey(remoteRef esv1beta1.PushSecretRemoteRef)
 	return parts, nil
 }
 
-func (c *Client) createSecret(name, key string, value []byte) (string, error) {
+func (c *Client) createSecret(name, key string, value []byte, metadata PushSecretMetadata) (string, error) {
 	normalizedKey := strings.ToLower(key)
-	externalSecretRecord := ksm.NewRecordCreate(externalSecretType, name)
+	externalSecretRecord := ksm.NewRecordCreate(metadata.RecordType, name)
+	if metadata.FieldType != "" {
+		field, err := metadata.newTypedField(key, value)
+		if err != nil {
+			return "", err
+		}
+		if metadata.standardField(key) {
+			externalSecretRecord.Fields = append(externalSecretRecord.Fields, field)
+		} else {
+			externalSecretRecord.Custom = append(externalSecretRecord.Custom, field)
+		}
+
+		return c.ksmClient.CreateSecretWithRecordData("", c.folderID, externalSecretRecord)
+	}
 	login := regexp.MustCompile(LoginTypeExpr)
 	pass := regexp.MustCompile(PasswordType)
 	url := regexp.MustCompile(URLTypeExpr)
@@ -255,7 +315,14 @@ func (c *Client) createSecret(name, key string, value []byte) (string, error) {
 	return c.ksmClient.CreateSecretWithRecordData("", c.folderID, externalSecretRecord)
 }
 
-func (c *Client) updateSecret(secret *ksm.Record, key string, value []byte) error {
+func (c *Client) updateSecret(secret *ksm.Record, key string, value []byte, metadata PushSecretMetadata) error {
+	if metadata.FieldType != "" {
+		if err := metadata.setTypedField(secret, key, value); err != nil {
+			return err
+		}
+
+		return c.ksmClient.Save(secret)
+	}
 	normalizedKey := strings.ToLower(key)
 	login := regexp.MustCompile(LoginTypeExpr)
 	pass := regexp.MustCompile(PasswordType)
@@ -359,18 +426,23 @@ func (s *Secret) validate() error {
 	return nil
 }
 
+// addFiles adds the file attachments without their content, see loadFiles.
 func (s *Secret) addFiles(keeperFiles []*ksm.KeeperFile) {
 	for _, f := range keeperFiles {
-		s.Files = append(
-			s.Files,
-			File{
-				Title:   f.Title,
-				Content: string(f.GetFileData()),
-			},
-		)
+		s.Files = append(s.Files, File{Title: f.Title})
+		s.attachments = append(s.attachments, f)
 	}
 }
 
+// hasField returns true if the key is a field or custom field of the secret, which don't require the files.
+func (s *Secret) hasField(key string) bool {
+	if field, _ := s.getField(key); field != nil {
+		return true
+	}
+	customField, _ := s.getCustomField(key)
+	return customField != nil
+}
+
 func (s *Secret) getItem(ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	if ref.Property != "" {
 		return s.getProperty(ref.Property)
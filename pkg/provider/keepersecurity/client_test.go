@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	ksm "github.com/keeper-security/secrets-manager-go/core"
@@ -144,6 +145,7 @@ func TestClientGetAllSecrets(t *testing.T) {
 		ref v1beta1.ExternalSecretFind
 	}
 	var path = "path_to_fail"
+	var sharedFolder = "sharedFolder"
 	tests := []struct {
 		name    string
 		fields  fields
@@ -168,10 +170,14 @@ func TestClientGetAllSecrets(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Path not Implemented",
+			name: "Get secrets of another folder",
 			fields: fields{
-				ksmClient: &fake.MockKeeperClient{},
-				folderID:  folderID,
+				ksmClient: &fake.MockKeeperClient{
+					GetSecretsFn: func(strings []string) ([]*ksm.Record, error) {
+						return generateRecords(), nil
+					},
+				},
+				folderID: folderID,
 			},
 			args: args{
 				ctx: context.Background(),
@@ -179,7 +185,57 @@ func TestClientGetAllSecrets(t *testing.T) {
 					Path: &path,
 				},
 			},
-			wantErr: true,
+			want:    map[string][]byte{},
+			wantErr: false,
+		},
+		{
+			name: "Get secrets of a folder",
+			fields: fields{
+				ksmClient: &fake.MockKeeperClient{
+					GetSecretsFn: func(strings []string) ([]*ksm.Record, error) {
+						records := generateRecords()
+						records[1] = inSharedFolder(records[1], sharedFolder)
+						return records, nil
+					},
+				},
+				folderID: folderID,
+			},
+			args: args{
+				ctx: context.Background(),
+				ref: v1beta1.ExternalSecretFind{
+					Path: &sharedFolder,
+				},
+			},
+			want: map[string][]byte{
+				record1: []byte(outputRecord1),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Get secrets by record type",
+			fields: fields{
+				ksmClient: &fake.MockKeeperClient{
+					GetSecretsFn: func(filter []string) ([]*ksm.Record, error) {
+						records := generateRecords()
+						records[2].RawJson = strings.Replace(records[2].RawJson, `"type":"login"`, `"type":"databaseCredentials"`, 1)
+						return records, nil
+					},
+				},
+				folderID: folderID,
+			},
+			args: args{
+				ctx: context.Background(),
+				ref: v1beta1.ExternalSecretFind{
+					Tags: map[string]string{
+						findTagRecordType: LoginType,
+					},
+				},
+			},
+			want: map[string][]byte{
+				record0: []byte(outputRecord0),
+				record1: []byte(outputRecord1),
+			},
+			wantErr: false,
 		},
 		{
 			name: "Get secrets with matching regex",
@@ -632,6 +688,14 @@ func TestClientPushSecret(t *testing.T) {
 	}
 }
 
+// inSharedFolder returns a copy of the record that is shared with the application by the folder.
+func inSharedFolder(record *ksm.Record, folderUID string) *ksm.Record {
+	shared := ksm.NewRecordFromJson(map[string]any{"recordUid": record.Uid}, nil, folderUID)
+	shared.RecordDict = record.RecordDict
+	shared.RawJson = record.RawJson
+	return shared
+}
+
 func generateRecords() []*ksm.Record {
 	var records []*ksm.Record
 	for i := 0; i < 3; i++ {
@@ -0,0 +1,98 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepersecurity
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	ksm "github.com/keeper-security/secrets-manager-go/core"
+)
+
+const (
+	// maxFileSize bounds the size of a downloaded file attachment.
+	maxFileSize         = 64 << 20
+	fileDownloadTimeout = 2 * time.Minute
+
+	errFileDownload   = "unable to download file %s of secret %s: %w"
+	errFileNoURL      = "no download URL"
+	errFileKey        = "unable to decrypt the file key"
+	errFileStatus     = "download returned %s"
+	errFileTooLarge   = "file exceeds %d bytes"
+	errFileDecryption = "unable to decrypt the file: %w"
+)
+
+var fileHTTPClient = &http.Client{Timeout: fileDownloadTimeout}
+
+// loadFiles downloads the content of the file attachments of the secret. The files are only
+// downloaded if they are part of the result, KSM doesn't return their content with the record.
+func (s *Secret) loadFiles(ctx context.Context) error {
+	if s.filesLoaded {
+		return nil
+	}
+	for i, f := range s.attachments {
+		data, err := downloadFile(ctx, f)
+		if err != nil {
+			return fmt.Errorf(errFileDownload, f.Title, s.Title, err)
+		}
+		s.Files[i].Content = string(data)
+	}
+	s.filesLoaded = true
+	return nil
+}
+
+// downloadFile returns the decrypted content of the file. Unlike KeeperFile.GetFileData,
+// failures are reported instead of returning an empty file.
+func downloadFile(ctx context.Context, f *ksm.KeeperFile) ([]byte, error) {
+	if len(f.FileData) > 0 {
+		return f.FileData, nil
+	}
+	url := f.GetUrl()
+	if url == "" {
+		return nil, fmt.Errorf(errFileNoURL)
+	}
+	key := f.DecryptFileKey()
+	if len(key) == 0 {
+		return nil, fmt.Errorf(errFileKey)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := fileHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(errFileStatus, resp.Status)
+	}
+	encrypted, err := io.ReadAll(io.LimitReader(resp.Body, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted) > maxFileSize {
+		return nil, fmt.Errorf(errFileTooLarge, maxFileSize)
+	}
+	data, err := ksm.Decrypt(encrypted, key)
+	if err != nil {
+		return nil, fmt.Errorf(errFileDecryption, err)
+	}
+	f.FileData = data
+	return data, nil
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepersecurity
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ksm "github.com/keeper-security/secrets-manager-go/core"
+
+	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/keepersecurity/fake"
+)
+
+func TestClientFileAttachments(t *testing.T) {
+	recordKey := []byte(strings.Repeat("r", 32))
+	fileKey := []byte(strings.Repeat("f", 32))
+	encryptedFileKey, err := ksm.EncryptAesGcm(fileKey, recordKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedContent, err := ksm.EncryptAesGcm([]byte("-----BEGIN CERTIFICATE-----"), fileKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tls.crt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(encryptedContent)
+	}))
+	defer server.Close()
+
+	newRecord := func(fileURL string) *ksm.Record {
+		record := generateRecords()[0]
+		record.Files = []*ksm.KeeperFile{{
+			Title:          "tls.crt",
+			RecordKeyBytes: recordKey,
+			F:              map[string]any{"url": server.URL + fileURL, "fileKey": ksm.BytesToBase64(encryptedFileKey)},
+		}}
+		return record
+	}
+	tests := []struct {
+		name    string
+		fileURL string
+		ref     v1beta1.ExternalSecretDataRemoteRef
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "download file",
+			fileURL: "/tls.crt",
+			ref:     v1beta1.ExternalSecretDataRemoteRef{Key: record0, Property: "tls.crt"},
+			want:    "-----BEGIN CERTIFICATE-----",
+		},
+		{
+			name:    "fields don't download the files",
+			fileURL: "/expired",
+			ref:     v1beta1.ExternalSecretDataRemoteRef{Key: record0, Property: PasswordKey},
+			want:    "bar",
+		},
+		{
+			name:    "failed download",
+			fileURL: "/expired",
+			ref:     v1beta1.ExternalSecretDataRemoteRef{Key: record0, Property: "tls.crt"},
+			wantErr: "unable to download file tls.crt of secret record0: download returned 404 Not Found",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{ksmClient: &fake.MockKeeperClient{
+				GetSecretsFn: func(filter []string) ([]*ksm.Record, error) {
+					return []*ksm.Record{newRecord(tc.fileURL)}, nil
+				},
+			}}
+			got, err := c.GetSecret(context.Background(), tc.ref)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("GetSecret() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetSecret() unexpected error = %v", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("GetSecret() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepersecurity
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ksm "github.com/keeper-security/secrets-manager-go/core"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errDecodePushSecretMetadata = "failed to decode PushSecret metadata: %w"
+	errJSONFieldValue           = "value of field type %s must be JSON, e.g. {\"hostName\":\"db\",\"port\":\"5432\"} for host: %w"
+	errStandardFieldNotFound    = "secret %s does not contain a field of type %s"
+
+	customSection = "custom"
+)
+
+// jsonFieldTypes are the field types whose values are JSON instead of a plain string,
+// see https://docs.keeper.io/secrets-manager/secrets-manager/about/field-record-types.
+var jsonFieldTypes = map[string]bool{
+	"name":             true,
+	"phone":            true,
+	"address":          true,
+	"host":             true,
+	"keyPair":          true,
+	"bankAccount":      true,
+	"paymentCard":      true,
+	"securityQuestion": true,
+	"pamHostname":      true,
+	"pamResources":     true,
+	"schedule":         true,
+	"script":           true,
+	"passkey":          true,
+	"birthDate":        true,
+	"date":             true,
+	"expirationDate":   true,
+	"checkbox":         true,
+}
+
+// PushSecretMetadata is the metadata of a PushSecret entry pushed to Keeper.
+type PushSecretMetadata struct {
+	// RecordType of the created records, e.g. login or databaseCredentials. Existing records
+	// are only updated if they are of this type. Defaults to externalSecrets.
+	RecordType string `json:"recordType,omitempty"`
+	// FieldType of the field the value is written to, e.g. password, host or text.
+	// If the key of the remote key equals the field type, the value is written to the standard
+	// field of the type, otherwise to a custom field labeled with the key.
+	// By default login, password and url keys are written to standard fields and other keys to
+	// custom fields of type secret.
+	FieldType string `json:"fieldType,omitempty"`
+}
+
+func parsePushSecretMetadata(data esv1beta1.PushSecretData) (PushSecretMetadata, error) {
+	metadata := PushSecretMetadata{RecordType: externalSecretType}
+	if data.GetMetadata() == nil {
+		return metadata, nil
+	}
+	if err := json.Unmarshal(data.GetMetadata().Raw, &metadata); err != nil {
+		return metadata, fmt.Errorf(errDecodePushSecretMetadata, err)
+	}
+	if metadata.RecordType == "" {
+		metadata.RecordType = externalSecretType
+	}
+	return metadata, nil
+}
+
+// typedField is a field of a record, the generic form of the field types of the SDK.
+type typedField struct {
+	Type  string `json:"type"`
+	Label string `json:"label,omitempty"`
+	Value []any  `json:"value"`
+}
+
+// standardField returns true if the key is written to the standard field of the field type.
+func (m PushSecretMetadata) standardField(key string) bool {
+	return m.FieldType == key
+}
+
+// newTypedField returns the field of the field type for the key, labeled by the key if it is a custom field.
+func (m PushSecretMetadata) newTypedField(key string, value []byte) (typedField, error) {
+	fieldValue, err := m.fieldValue(value)
+	if err != nil {
+		return typedField{}, err
+	}
+	field := typedField{Type: m.FieldType, Value: fieldValue}
+	if !m.standardField(key) {
+		field.Label = key
+	}
+	return field, nil
+}
+
+// fieldValue returns the value of a field of the field type, values of complex field types are decoded from JSON.
+func (m PushSecretMetadata) fieldValue(value []byte) ([]any, error) {
+	if !jsonFieldTypes[m.FieldType] {
+		return []any{string(value)}, nil
+	}
+	var decoded any
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return nil, fmt.Errorf(errJSONFieldValue, m.FieldType, err)
+	}
+	return []any{decoded}, nil
+}
+
+// setTypedField writes the value to the field of the record, missing custom fields are added to the record.
+func (m PushSecretMetadata) setTypedField(record *ksm.Record, key string, value []byte) error {
+	field, err := m.newTypedField(key, value)
+	if err != nil {
+		return err
+	}
+	if m.standardField(key) {
+		if !record.FieldExists("fields", m.FieldType) {
+			return fmt.Errorf(errStandardFieldNotFound, record.Title(), m.FieldType)
+		}
+		return record.SetStandardFieldValue(m.FieldType, field.Value)
+	}
+	if len(record.GetCustomFieldsByLabel(key)) > 0 {
+		return record.SetCustomFieldValue(key, field.Value)
+	}
+	addCustomField(record, field)
+	return nil
+}
+
+// addCustomField appends the field to the custom fields of the record. Record.AddCustomField
+// only accepts the field types of the SDK, which can't be built from a field type name.
+func addCustomField(record *ksm.Record, field typedField) {
+	custom, _ := record.RecordDict[customSection].([]any)
+	record.RecordDict[customSection] = append(custom, map[string]any{
+		"type":  field.Type,
+		"label": field.Label,
+		"value": field.Value,
+	})
+	record.RawJson = ksm.DictToJson(record.RecordDict)
+}
@@ -0,0 +1,157 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keepersecurity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	ksm "github.com/keeper-security/secrets-manager-go/core"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/external-secrets/external-secrets/pkg/provider/keepersecurity/fake"
+	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+func TestClientPushSecretTypedFields(t *testing.T) {
+	secretKey := "secret-key"
+	tests := []struct {
+		name       string
+		remoteKey  string
+		metadata   string
+		value      string
+		existing   *ksm.Record
+		wantCreate string
+		wantSave   string
+		wantErr    string
+	}{
+		{
+			name:       "create record with a standard field",
+			remoteKey:  "db/password",
+			metadata:   `{"recordType":"databaseCredentials","fieldType":"password"}`,
+			value:      "s3cr3t",
+			wantCreate: `{"type":"databaseCredentials","title":"db","fields":[{"type":"password","value":["s3cr3t"]}]}`,
+		},
+		{
+			name:       "create record with a custom host field",
+			remoteKey:  "db/primary",
+			metadata:   `{"fieldType":"host"}`,
+			value:      `{"hostName":"db","port":"5432"}`,
+			wantCreate: `{"type":"externalSecrets","title":"db","custom":[{"type":"host","label":"primary","value":[{"hostName":"db","port":"5432"}]}]}`,
+		},
+		{
+			name:      "invalid JSON value",
+			remoteKey: "db/primary",
+			metadata:  `{"fieldType":"host"}`,
+			value:     "db:5432",
+			wantErr:   `value of field type host must be JSON, e.g. {"hostName":"db","port":"5432"} for host: invalid character 'd' looking for beginning of value`,
+		},
+		{
+			name:      "add a custom field to an existing record",
+			remoteKey: "db/apiKey",
+			metadata:  `{"recordType":"login","fieldType":"text"}`,
+			value:     "k3y",
+			existing:  loginRecord(),
+			wantSave:  `{"custom":[{"label":"apiKey","type":"text","value":["k3y"]}],"fields":[{"type":"login","value":["admin"]},{"type":"password","value":["old"]}],"title":"db","type":"login"}`,
+		},
+		{
+			name:      "update a standard field of an existing record",
+			remoteKey: "db/password",
+			metadata:  `{"recordType":"login","fieldType":"password"}`,
+			value:     "n3w",
+			existing:  loginRecord(),
+			wantSave:  `{"fields":[{"type":"login","value":["admin"]},{"type":"password","value":["n3w"]}],"title":"db","type":"login"}`,
+		},
+		{
+			name:      "missing standard field",
+			remoteKey: "db/oneTimeCode",
+			metadata:  `{"recordType":"login","fieldType":"oneTimeCode"}`,
+			value:     "otpauth://totp/db",
+			existing:  loginRecord(),
+			wantErr:   "secret db does not contain a field of type oneTimeCode",
+		},
+		{
+			name:      "record of another type",
+			remoteKey: "db/password",
+			metadata:  `{"fieldType":"password"}`,
+			value:     "n3w",
+			existing:  loginRecord(),
+			wantErr:   "ESO can only push/delete externalSecrets record types. Secret db is type login",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var created, saved string
+			c := &Client{
+				folderID: folderID,
+				ksmClient: &fake.MockKeeperClient{
+					GetSecretByTitleFn: func(recordTitle string) (*ksm.Record, error) {
+						if tc.existing == nil {
+							return nil, errors.New("NotFound")
+						}
+						return tc.existing, nil
+					},
+					CreateSecretWithRecordDataFn: func(recUID, folderUID string, recordData *ksm.RecordCreate) (string, error) {
+						data, err := json.Marshal(recordData)
+						created = string(data)
+						return "uid", err
+					},
+					SaveFn: func(record *ksm.Record) error {
+						saved = record.RawJson
+						return nil
+					},
+				},
+			}
+			s := &corev1.Secret{Data: map[string][]byte{secretKey: []byte(tc.value)}}
+			data := testingfake.PushSecretData{
+				SecretKey: secretKey,
+				RemoteKey: tc.remoteKey,
+				Metadata:  &apiextensionsv1.JSON{Raw: []byte(tc.metadata)},
+			}
+			err := c.PushSecret(context.Background(), s, data)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("PushSecret() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PushSecret() unexpected error = %v", err)
+			}
+			if created != tc.wantCreate {
+				t.Errorf("PushSecret() created %s, want %s", created, tc.wantCreate)
+			}
+			if saved != tc.wantSave {
+				t.Errorf("PushSecret() saved %s, want %s", saved, tc.wantSave)
+			}
+		})
+	}
+}
+
+func loginRecord() *ksm.Record {
+	record := &ksm.Record{Uid: "db", RecordDict: map[string]any{
+		"title": "db",
+		"type":  LoginType,
+		"fields": []any{
+			map[string]any{"type": LoginType, "value": []any{"admin"}},
+			map[string]any{"type": PasswordType, "value": []any{"old"}},
+		},
+	}}
+	record.RawJson = ksm.DictToJson(record.RecordDict)
+	return record
+}
@@ -22,6 +22,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
@@ -34,8 +37,82 @@ const (
 	errMissingCredentials                  = "missing credentials: \"%s\""
 	errEmptyKey                            = "key %s found but empty"
 	errUnableCreateToken                   = "cannot create service account token: %q"
+	errParseKubeconfig                     = "could not parse Auth.Kubeconfig: %w"
+	errKubeconfigUnsupported               = "kubeconfig must not use %s"
 )
 
+// restConfig returns the config of the clients with user-defined scope.
+func (c *Client) restConfig(ctx context.Context) (*rest.Config, error) {
+	if c.store.Auth.Kubeconfig != nil {
+		return c.kubeconfigRESTConfig(ctx)
+	}
+	if err := c.setAuth(ctx); err != nil {
+		return nil, err
+	}
+	return &rest.Config{
+		Host:        c.store.Server.URL,
+		BearerToken: string(c.BearerToken),
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: false,
+			CertData: c.Certificate,
+			KeyData:  c.Key,
+			CAData:   c.CA,
+		},
+	}, nil
+}
+
+// kubeconfigRESTConfig returns the config of the current context of the kubeconfig.
+// The caBundle or caProvider of the server replace the certificate authority of the kubeconfig.
+func (c *Client) kubeconfigRESTConfig(ctx context.Context) (*rest.Config, error) {
+	kubeconfig, err := c.fetchSecretKey(ctx, c.store.Auth.Kubeconfig.SecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch Auth.Kubeconfig: %w", err)
+	}
+	apiConfig, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf(errParseKubeconfig, err)
+	}
+	// validate before the config is built, building it reads the referenced files
+	if err := validateKubeconfig(apiConfig); err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.NewDefaultClientConfig(*apiConfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf(errParseKubeconfig, err)
+	}
+	if c.store.Server.CABundle != nil || c.store.Server.CAProvider != nil {
+		if err := c.setCA(ctx); err != nil {
+			return nil, err
+		}
+		config.TLSClientConfig.CAData = c.CA
+	}
+	return config, nil
+}
+
+// validateKubeconfig rejects kubeconfigs that would make the controller read its own files,
+// e.g. its service account token, or run commands.
+func validateKubeconfig(config *clientcmdapi.Config) error {
+	for _, cluster := range config.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return fmt.Errorf(errKubeconfigUnsupported, "file references")
+		}
+		if cluster.InsecureSkipTLSVerify {
+			return fmt.Errorf(errKubeconfigUnsupported, "insecure-skip-tls-verify")
+		}
+	}
+	for _, user := range config.AuthInfos {
+		switch {
+		case user.ClientCertificate != "" || user.ClientKey != "" || user.TokenFile != "":
+			return fmt.Errorf(errKubeconfigUnsupported, "file references")
+		case user.Exec != nil:
+			return fmt.Errorf(errKubeconfigUnsupported, "exec plugins")
+		case user.AuthProvider != nil:
+			return fmt.Errorf(errKubeconfigUnsupported, "auth provider plugins")
+		}
+	}
+	return nil
+}
+
 func (c *Client) setAuth(ctx context.Context) error {
 	err := c.setCA(ctx)
 	if err != nil {
@@ -258,3 +258,98 @@ func TestSetAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeconfigRESTConfig(t *testing.T) {
+	kubeconfig := func(cluster, user string) []byte {
+		return []byte(`apiVersion: v1
+kind: Config
+current-context: remote
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com:6443
+` + cluster + `
+users:
+- name: remote
+  user:
+` + user)
+	}
+	tests := []struct {
+		name       string
+		kubeconfig []byte
+		server     esv1beta1.KubernetesServer
+		wantHost   string
+		wantCA     []byte
+		wantToken  string
+		wantErr    string
+	}{
+		{
+			name:       "token and certificate authority of the kubeconfig",
+			kubeconfig: kubeconfig("    certificate-authority-data: MTIzNA==", "    token: my-token"),
+			wantHost:   "https://remote.example.com:6443",
+			wantCA:     []byte("1234"),
+			wantToken:  "my-token",
+		},
+		{
+			name:       "ca bundle of the server replaces the certificate authority",
+			kubeconfig: kubeconfig("    certificate-authority-data: MTIzNA==", "    token: my-token"),
+			server:     esv1beta1.KubernetesServer{CABundle: []byte("5678")},
+			wantHost:   "https://remote.example.com:6443",
+			wantCA:     []byte("5678"),
+			wantToken:  "my-token",
+		},
+		{
+			name:       "token file",
+			kubeconfig: kubeconfig("", "    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token"),
+			wantErr:    "kubeconfig must not use file references",
+		},
+		{
+			name:       "exec plugin",
+			kubeconfig: kubeconfig("", "    exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: cat"),
+			wantErr:    "kubeconfig must not use exec plugins",
+		},
+		{
+			name:       "insecure",
+			kubeconfig: kubeconfig("    insecure-skip-tls-verify: true", "    token: my-token"),
+			wantErr:    "kubeconfig must not use insecure-skip-tls-verify",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &Client{
+				ctrlClient: fclient.NewClientBuilder().WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "remote-cluster", Namespace: "default"},
+					Data:       map[string][]byte{"kubeconfig": tt.kubeconfig},
+				}).Build(),
+				store: &esv1beta1.KubernetesProvider{
+					Server: tt.server,
+					Auth: esv1beta1.KubernetesAuth{
+						Kubeconfig: &esv1beta1.KubeconfigAuth{
+							SecretRef: v1.SecretKeySelector{Name: "remote-cluster", Key: "kubeconfig"},
+						},
+					},
+				},
+				namespace: "default",
+				storeKind: esv1beta1.SecretStoreKind,
+			}
+			config, err := k.restConfig(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("restConfig() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("restConfig() unexpected error: %v", err)
+			}
+			if config.Host != tt.wantHost || config.BearerToken != tt.wantToken || !cmp.Equal(config.CAData, tt.wantCA) {
+				t.Errorf("unexpected config: host %s, token %s, ca %s", config.Host, config.BearerToken, config.CAData)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/constants"
@@ -39,6 +40,9 @@ import (
 const (
 	metaLabels      = "labels"
 	metaAnnotations = "annotations"
+
+	applyFieldManagerName = "external-secrets"
+	maxFieldManagerLength = 128
 )
 
 func (c *Client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
@@ -108,6 +112,9 @@ func (c *Client) PushSecret(ctx context.Context, secret *v1.Secret, data esv1bet
 	if data.GetProperty() == "" && data.GetSecretKey() != "" {
 		return fmt.Errorf("requires property in RemoteRef to push secret value if secret key is defined")
 	}
+	if c.store.ServerSideApply {
+		return c.applySecret(ctx, secret, data)
+	}
 
 	extSecret, getErr := c.userSecretClient.Get(ctx, data.GetRemoteKey(), metav1.GetOptions{})
 	metrics.ObserveAPICall(constants.ProviderKubernetes, constants.CallKubernetesGetSecret, getErr)
@@ -336,7 +343,8 @@ func convertMap(in map[string][]byte) map[string]string {
 	return out
 }
 
-func (c *Client) createSecret(ctx context.Context, secret *v1.Secret, typed v1.SecretType, remoteRef esv1beta1.PushSecretData) error {
+// pushedData returns the data of the remote secret written by the push.
+func (c *Client) pushedData(secret *v1.Secret, remoteRef esv1beta1.PushSecretData) (map[string][]byte, error) {
 	data := make(map[string][]byte)
 
 	if remoteRef.GetProperty() != "" {
@@ -344,7 +352,7 @@ func (c *Client) createSecret(ctx context.Context, secret *v1.Secret, typed v1.S
 		if remoteRef.GetSecretKey() == "" {
 			value, err := c.marshalData(secret)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			data[remoteRef.GetProperty()] = value
@@ -356,6 +364,14 @@ func (c *Client) createSecret(ctx context.Context, secret *v1.Secret, typed v1.S
 		// push the whole secret as is using each key of the secret as a property in the created secret
 		data = secret.Data
 	}
+	return data, nil
+}
+
+func (c *Client) createSecret(ctx context.Context, secret *v1.Secret, typed v1.SecretType, remoteRef esv1beta1.PushSecretData) error {
+	data, err := c.pushedData(secret, remoteRef)
+	if err != nil {
+		return err
+	}
 
 	s := v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -366,11 +382,45 @@ func (c *Client) createSecret(ctx context.Context, secret *v1.Secret, typed v1.S
 		Type: typed,
 	}
 
-	_, err := c.userSecretClient.Create(ctx, &s, metav1.CreateOptions{})
+	_, err = c.userSecretClient.Create(ctx, &s, metav1.CreateOptions{})
 	metrics.ObserveAPICall(constants.ProviderKubernetes, constants.CallKubernetesCreateSecret, err)
 	return err
 }
 
+// applySecret pushes the data with server-side apply. The whole secret is owned by the field manager
+// of external-secrets, a pushed property by a field manager of its own, so pushes of different
+// properties into the same remote secret don't remove each other's values.
+func (c *Client) applySecret(ctx context.Context, secret *v1.Secret, remoteRef esv1beta1.PushSecretData) error {
+	data, err := c.pushedData(secret, remoteRef)
+	if err != nil {
+		return err
+	}
+	apply := applycorev1.Secret(remoteRef.GetRemoteKey(), c.store.RemoteNamespace).WithData(data)
+	// only the whole secret determines the type, a property is pushed into a secret of any type
+	if remoteRef.GetProperty() == "" && secret.Type != "" {
+		apply = apply.WithType(secret.Type)
+	}
+	_, err = c.userSecretClient.Apply(ctx, apply, metav1.ApplyOptions{
+		FieldManager: applyFieldManager(remoteRef.GetProperty()),
+		Force:        true,
+	})
+	metrics.ObserveAPICall(constants.ProviderKubernetes, constants.CallKubernetesApplySecret, err)
+	return err
+}
+
+// applyFieldManager returns the field manager of a pushed property, names exceeding
+// the maximum length of a field manager are hashed.
+func applyFieldManager(property string) string {
+	if property == "" {
+		return applyFieldManagerName
+	}
+	manager := applyFieldManagerName + "-" + property
+	if len(manager) > maxFieldManagerLength {
+		manager = applyFieldManagerName + "-" + utils.ObjectHash(property)
+	}
+	return manager
+}
+
 // fullDelete removes remote secret completely.
 func (c *Client) fullDelete(ctx context.Context, secretName string) error {
 	err := c.userSecretClient.Delete(ctx, secretName, metav1.DeleteOptions{})
@@ -27,10 +27,12 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 
 	"github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	testingfake "github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 const (
@@ -42,6 +44,8 @@ type fakeClient struct {
 	secretMap           map[string]*v1.Secret
 	expectedListOptions metav1.ListOptions
 	err                 error
+	// fieldManagers records the field manager of every apply
+	fieldManagers []string
 }
 
 func (fk *fakeClient) Get(_ context.Context, name string, _ metav1.GetOptions) (*v1.Secret, error) {
@@ -102,6 +106,26 @@ func (fk *fakeClient) Update(_ context.Context, secret *v1.Secret, _ metav1.Upda
 	return s, nil
 }
 
+// Apply merges the applied data into the secret, it does not remove fields no longer applied.
+func (fk *fakeClient) Apply(_ context.Context, secret *applycorev1.SecretApplyConfiguration, opts metav1.ApplyOptions) (*v1.Secret, error) {
+	fk.fieldManagers = append(fk.fieldManagers, opts.FieldManager)
+	s, ok := fk.secretMap[*secret.Name]
+	if !ok {
+		s = &v1.Secret{Type: v1.SecretTypeOpaque}
+		fk.secretMap[*secret.Name] = s
+	}
+	if s.Data == nil {
+		s.Data = make(map[string][]byte)
+	}
+	for k, v := range secret.Data {
+		s.Data[k] = v
+	}
+	if secret.Type != nil {
+		s.Type = *secret.Type
+	}
+	return s, nil
+}
+
 var binaryTestData = []byte{0x00, 0xff, 0x00, 0xff, 0xac, 0xab, 0x28, 0x21}
 
 func TestGetSecret(t *testing.T) {
@@ -1047,3 +1071,70 @@ func TestPushSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestPushSecretServerSideApply(t *testing.T) {
+	tests := []struct {
+		name              string
+		data              testingfake.PushSecretData
+		secret            *v1.Secret
+		wantSecret        *v1.Secret
+		wantFieldManagers []string
+	}{
+		{
+			name: "apply the whole secret",
+			data: testingfake.PushSecretData{RemoteKey: "mysec"},
+			secret: &v1.Secret{
+				Type: v1.SecretTypeTLS,
+				Data: map[string][]byte{"tls.crt": []byte("crt"), "tls.key": []byte("key")},
+			},
+			wantSecret: &v1.Secret{
+				Type: v1.SecretTypeTLS,
+				Data: map[string][]byte{"token": []byte("foo"), "tls.crt": []byte("crt"), "tls.key": []byte("key")},
+			},
+			wantFieldManagers: []string{"external-secrets"},
+		},
+		{
+			name: "apply a key into a property",
+			data: testingfake.PushSecretData{RemoteKey: "mysec", SecretKey: "password", Property: "db-password"},
+			secret: &v1.Secret{
+				Type: v1.SecretTypeTLS,
+				Data: map[string][]byte{"password": []byte("s3cr3t")},
+			},
+			wantSecret: &v1.Secret{
+				Type: v1.SecretTypeOpaque,
+				Data: map[string][]byte{"token": []byte("foo"), "db-password": []byte("s3cr3t")},
+			},
+			wantFieldManagers: []string{"external-secrets-db-password"},
+		},
+		{
+			name: "apply a property with a long name",
+			data: testingfake.PushSecretData{RemoteKey: "mysec", SecretKey: "password", Property: strings.Repeat("p", 120)},
+			secret: &v1.Secret{
+				Data: map[string][]byte{"password": []byte("s3cr3t")},
+			},
+			wantSecret: &v1.Secret{
+				Type: v1.SecretTypeOpaque,
+				Data: map[string][]byte{"token": []byte("foo"), strings.Repeat("p", 120): []byte("s3cr3t")},
+			},
+			wantFieldManagers: []string{"external-secrets-" + utils.ObjectHash(strings.Repeat("p", 120))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fClient := &fakeClient{
+				t: t,
+				secretMap: map[string]*v1.Secret{
+					"mysec": {Type: v1.SecretTypeOpaque, Data: map[string][]byte{"token": []byte("foo")}},
+				},
+			}
+			p := &Client{
+				userSecretClient: fClient,
+				store:            &esv1beta1.KubernetesProvider{ServerSideApply: true},
+			}
+			err := p.PushSecret(context.Background(), tt.secret, tt.data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSecret, fClient.secretMap["mysec"])
+			assert.Equal(t, tt.wantFieldManagers, fClient.fieldManagers)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
@@ -28,6 +29,7 @@ import (
 	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
 )
 
 // https://github.com/external-secrets/external-secrets/issues/644
@@ -40,6 +42,7 @@ type KClient interface {
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	Create(ctx context.Context, secret *v1.Secret, opts metav1.CreateOptions) (*v1.Secret, error)
 	Update(ctx context.Context, secret *v1.Secret, opts metav1.UpdateOptions) (*v1.Secret, error)
+	Apply(ctx context.Context, secret *applycorev1.SecretApplyConfiguration, opts metav1.ApplyOptions) (*v1.Secret, error)
 }
 
 type RClient interface {
@@ -123,21 +126,11 @@ func (p *Provider) newClient(ctx context.Context, store esv1beta1.GenericStore,
 		return client, nil
 	}
 
-	if err := client.setAuth(ctx); err != nil {
+	config, err := client.restConfig(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	config := &rest.Config{
-		Host:        client.store.Server.URL,
-		BearerToken: string(client.BearerToken),
-		TLSClientConfig: rest.TLSClientConfig{
-			Insecure: false,
-			CertData: client.Certificate,
-			KeyData:  client.Key,
-			CAData:   client.CA,
-		},
-	}
-
 	userClientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("error configuring clientset: %w", err)
@@ -147,6 +140,22 @@ func (p *Provider) newClient(ctx context.Context, store esv1beta1.GenericStore,
 	return client, nil
 }
 
+// NewGeneratorConfig returns the config for a remote cluster to be used by generators.
+// The credentials are resolved in the namespace of the generator.
+func (p *Provider) NewGeneratorConfig(ctx context.Context, kube kclient.Client, corev1 typedcorev1.CoreV1Interface, server esv1beta1.KubernetesServer, auth esv1beta1.KubernetesAuth, namespace string) (*rest.Config, error) {
+	client := &Client{
+		ctrlClientset: corev1,
+		ctrlClient:    kube,
+		store: &esv1beta1.KubernetesProvider{
+			Server: server,
+			Auth:   auth,
+		},
+		namespace: namespace,
+		storeKind: resolvers.EmptyStoreKind,
+	}
+	return client.restConfig(ctx)
+}
+
 func isReferentSpec(prov *esv1beta1.KubernetesProvider) bool {
 	if prov.Auth.Cert != nil {
 		if prov.Auth.Cert.ClientCert.Namespace == nil {
@@ -166,6 +175,11 @@ func isReferentSpec(prov *esv1beta1.KubernetesProvider) bool {
 			return true
 		}
 	}
+	if prov.Auth.Kubeconfig != nil {
+		if prov.Auth.Kubeconfig.SecretRef.Namespace == nil {
+			return true
+		}
+	}
 	return false
 }
 
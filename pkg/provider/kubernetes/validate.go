@@ -31,7 +31,8 @@ import (
 func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
 	storeSpec := store.GetSpec()
 	k8sSpec := storeSpec.Provider.Kubernetes
-	if k8sSpec.Server.CABundle == nil && k8sSpec.Server.CAProvider == nil {
+	// a kubeconfig brings the certificate authority of its cluster
+	if k8sSpec.Auth.Kubeconfig == nil && k8sSpec.Server.CABundle == nil && k8sSpec.Server.CAProvider == nil {
 		return nil, fmt.Errorf("a CABundle or CAProvider is required")
 	}
 	if store.GetObjectKind().GroupVersionKind().Kind == esv1beta1.ClusterSecretStoreKind &&
@@ -61,6 +62,17 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 			return nil, err
 		}
 	}
+	if k8sSpec.Auth.Kubeconfig != nil {
+		if k8sSpec.Auth.Kubeconfig.SecretRef.Name == "" {
+			return nil, fmt.Errorf("Kubeconfig.SecretRef.Name cannot be empty")
+		}
+		if k8sSpec.Auth.Kubeconfig.SecretRef.Key == "" {
+			return nil, fmt.Errorf("Kubeconfig.SecretRef.Key cannot be empty")
+		}
+		if err := utils.ValidateSecretSelector(store, k8sSpec.Auth.Kubeconfig.SecretRef); err != nil {
+			return nil, err
+		}
+	}
 	if k8sSpec.Auth.ServiceAccount != nil {
 		if err := utils.ValidateReferentServiceAccountSelector(store, *k8sSpec.Auth.ServiceAccount); err != nil {
 			return nil, err
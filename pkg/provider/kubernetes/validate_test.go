@@ -242,6 +242,45 @@ func TestValidateStore(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid kubeconfig auth key",
+			store: &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						Kubernetes: &esv1beta1.KubernetesProvider{
+							Auth: esv1beta1.KubernetesAuth{
+								Kubeconfig: &esv1beta1.KubeconfigAuth{
+									SecretRef: v1.SecretKeySelector{
+										Name: "remote-cluster",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid kubeconfig auth without ca",
+			store: &esv1beta1.SecretStore{
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						Kubernetes: &esv1beta1.KubernetesProvider{
+							Auth: esv1beta1.KubernetesAuth{
+								Kubeconfig: &esv1beta1.KubeconfigAuth{
+									SecretRef: v1.SecretKeySelector{
+										Name: "remote-cluster",
+										Key:  "kubeconfig",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -43,6 +43,10 @@ const (
 	errOnePasswordStoreNilSpecProviderOnePassword = "nil spec.provider.onepassword"
 	errOnePasswordStoreMissingRefName             = "missing: spec.provider.onepassword.auth.secretRef.connectTokenSecretRef.name"
 	errOnePasswordStoreMissingRefKey              = "missing: spec.provider.onepassword.auth.secretRef.connectTokenSecretRef.key"
+	errOnePasswordStoreMissingSARefName           = "missing: spec.provider.onepassword.auth.secretRef.serviceAccountTokenSecretRef.name"
+	errOnePasswordStoreMissingSARefKey            = "missing: spec.provider.onepassword.auth.secretRef.serviceAccountTokenSecretRef.key"
+	errOnePasswordStoreAmbiguousAuth              = "only one of connectTokenSecretRef or serviceAccountTokenSecretRef may be set: spec.provider.onepassword.auth.secretRef"
+	errOnePasswordStoreMissingConnectHost         = "missing: spec.provider.onepassword.connectHost"
 	errOnePasswordStoreAtLeastOneVault            = "must be at least one vault: spec.provider.onepassword.vaults"
 	errOnePasswordStoreInvalidConnectHost         = "unable to parse URL: spec.provider.onepassword.connectHost: %w"
 	errOnePasswordStoreNonUniqueVaultNumbers      = "vault order numbers must be unique"
@@ -97,6 +101,25 @@ func (provider *ProviderOnePassword) Capabilities() esv1beta1.SecretStoreCapabil
 // NewClient constructs a 1Password Provider.
 func (provider *ProviderOnePassword) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	config := store.GetSpec().Provider.OnePassword
+	if config.Auth.SecretRef.ServiceAccountToken != nil {
+		token, err := resolvers.SecretKeyRef(
+			ctx,
+			kube,
+			store.GetKind(),
+			namespace,
+			config.Auth.SecretRef.ServiceAccountToken,
+		)
+		if err != nil {
+			return nil, err
+		}
+		provider.client, err = newSDKClient(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		provider.vaults = config.Vaults
+		return provider, nil
+	}
+
 	token, err := resolvers.SecretKeyRef(
 		ctx,
 		kube,
@@ -132,6 +155,28 @@ func validateStore(store esv1beta1.GenericStore) error {
 
 	// check mandatory fields
 	config := storeSpec.Provider.OnePassword
+	if config.Auth.SecretRef.ServiceAccountToken != nil {
+		if err := validateServiceAccountAuth(store, config); err != nil {
+			return err
+		}
+	} else if err := validateConnectAuth(store, config); err != nil {
+		return err
+	}
+
+	// check at least one vault
+	if len(config.Vaults) == 0 {
+		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreAtLeastOneVault))
+	}
+
+	// ensure vault numbers are unique
+	if !hasUniqueVaultNumbers(config.Vaults) {
+		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreNonUniqueVaultNumbers))
+	}
+
+	return nil
+}
+
+func validateConnectAuth(store esv1beta1.GenericStore, config *esv1beta1.OnePasswordProvider) error {
 	if config.Auth.SecretRef.ConnectToken.Name == "" {
 		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingRefName))
 	}
@@ -144,19 +189,32 @@ func validateStore(store esv1beta1.GenericStore) error {
 		return fmt.Errorf(errOnePasswordStore, err)
 	}
 
-	// check at least one vault
-	if len(config.Vaults) == 0 {
-		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreAtLeastOneVault))
+	// check valid URL
+	if config.ConnectHost == "" {
+		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingConnectHost))
+	}
+	if _, err := url.Parse(config.ConnectHost); err != nil {
+		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreInvalidConnectHost, err))
 	}
 
-	// ensure vault numbers are unique
-	if !hasUniqueVaultNumbers(config.Vaults) {
-		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreNonUniqueVaultNumbers))
+	return nil
+}
+
+func validateServiceAccountAuth(store esv1beta1.GenericStore, config *esv1beta1.OnePasswordProvider) error {
+	saToken := config.Auth.SecretRef.ServiceAccountToken
+	if config.Auth.SecretRef.ConnectToken.Name != "" || config.Auth.SecretRef.ConnectToken.Key != "" {
+		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreAmbiguousAuth))
+	}
+	if saToken.Name == "" {
+		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingSARefName))
+	}
+	if saToken.Key == "" {
+		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingSARefKey))
 	}
 
-	// check valid URL
-	if _, err := url.Parse(config.ConnectHost); err != nil {
-		return fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreInvalidConnectHost, err))
+	// check namespace compared to kind
+	if err := utils.ValidateSecretSelector(store, *saToken); err != nil {
+		return fmt.Errorf(errOnePasswordStore, err)
 	}
 
 	return nil
@@ -633,6 +633,114 @@ func TestValidateStore(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreInvalidConnectHost, fmt.Errorf("parse \":/invalid.invalid\": missing protocol scheme"))),
 		},
+		{
+			checkNote: "invalid: missing connectHost",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "SecretStore",
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						OnePassword: &esv1beta1.OnePasswordProvider{
+							Auth: &esv1beta1.OnePasswordAuth{
+								SecretRef: &esv1beta1.OnePasswordAuthSecretRef{
+									ConnectToken: esmeta.SecretKeySelector{
+										Name: mySecret,
+										Key:  token,
+									},
+								},
+							},
+							Vaults: map[string]int{
+								myVault: 1,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingConnectHost)),
+		},
+		{
+			checkNote: "valid: service account token",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "SecretStore",
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						OnePassword: &esv1beta1.OnePasswordProvider{
+							Auth: &esv1beta1.OnePasswordAuth{
+								SecretRef: &esv1beta1.OnePasswordAuthSecretRef{
+									ServiceAccountToken: &esmeta.SecretKeySelector{
+										Name: mySecret,
+										Key:  token,
+									},
+								},
+							},
+							Vaults: map[string]int{
+								myVault: 1,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			checkNote: "invalid: both connect and service account token",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "SecretStore",
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						OnePassword: &esv1beta1.OnePasswordProvider{
+							Auth: &esv1beta1.OnePasswordAuth{
+								SecretRef: &esv1beta1.OnePasswordAuthSecretRef{
+									ConnectToken: esmeta.SecretKeySelector{
+										Name: mySecret,
+										Key:  token,
+									},
+									ServiceAccountToken: &esmeta.SecretKeySelector{
+										Name: mySecret,
+										Key:  token,
+									},
+								},
+							},
+							ConnectHost: connectHost,
+							Vaults: map[string]int{
+								myVault: 1,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreAmbiguousAuth)),
+		},
+		{
+			checkNote: "invalid: missing serviceAccountTokenSecretRef.key",
+			store: &esv1beta1.SecretStore{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "SecretStore",
+				},
+				Spec: esv1beta1.SecretStoreSpec{
+					Provider: &esv1beta1.SecretStoreProvider{
+						OnePassword: &esv1beta1.OnePasswordProvider{
+							Auth: &esv1beta1.OnePasswordAuth{
+								SecretRef: &esv1beta1.OnePasswordAuthSecretRef{
+									ServiceAccountToken: &esmeta.SecretKeySelector{
+										Name: mySecret,
+									},
+								},
+							},
+							Vaults: map[string]int{
+								myVault: 1,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Errorf(errOnePasswordStore, fmt.Errorf(errOnePasswordStoreMissingSARefKey)),
+		},
 	}
 
 	// run the tests
@@ -0,0 +1,406 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onepassword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/1Password/connect-sdk-go/connect"
+	"github.com/1Password/connect-sdk-go/onepassword"
+	opsdk "github.com/1password/onepassword-sdk-go"
+)
+
+const (
+	integrationName    = "external-secrets"
+	integrationVersion = "v1"
+
+	errSDKNotSupported      = "%s is not supported when using a 1Password Service Account"
+	errSDKVaultNotFound     = "vault %q not found"
+	errSDKCreateClient      = "error creating 1Password SDK client: %w"
+	errSDKFilesNotSupported = "documents and files are not supported when using a 1Password Service Account"
+)
+
+// sdkClient implements connect.Client on top of the 1Password SDK, so that the
+// provider can talk to 1Password using a Service Account token instead of a Connect Server.
+// Only the subset of connect.Client used by the provider is implemented.
+type sdkClient struct {
+	items  opsdk.ItemsAPI
+	vaults opsdk.VaultsAPI
+}
+
+var _ connect.Client = &sdkClient{}
+
+// newSDKClient creates a connect.Client backed by the 1Password SDK using a Service Account token.
+func newSDKClient(ctx context.Context, token string) (connect.Client, error) {
+	client, err := opsdk.NewClient(ctx,
+		opsdk.WithServiceAccountToken(token),
+		opsdk.WithIntegrationInfo(integrationName, integrationVersion),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(errSDKCreateClient, err)
+	}
+	return &sdkClient{
+		items:  client.Items,
+		vaults: client.Vaults,
+	}, nil
+}
+
+// GetVaultByTitle returns the vault with the given title.
+func (c *sdkClient) GetVaultByTitle(title string) (*onepassword.Vault, error) {
+	vaults, err := c.GetVaultsByTitle(title)
+	if err != nil {
+		return nil, err
+	}
+	if len(vaults) != 1 {
+		return nil, fmt.Errorf(errSDKVaultNotFound, title)
+	}
+	return &vaults[0], nil
+}
+
+// GetVaultsByTitle returns all vaults with the given title.
+func (c *sdkClient) GetVaultsByTitle(title string) ([]onepassword.Vault, error) {
+	all, err := c.GetVaults()
+	if err != nil {
+		return nil, err
+	}
+	vaults := make([]onepassword.Vault, 0, 1)
+	for _, vault := range all {
+		if vault.Name == title {
+			vaults = append(vaults, vault)
+		}
+	}
+	return vaults, nil
+}
+
+// GetVaults returns all vaults the Service Account has access to.
+func (c *sdkClient) GetVaults() ([]onepassword.Vault, error) {
+	it, err := c.vaults.ListAll(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	vaults := make([]onepassword.Vault, 0)
+	for {
+		vault, err := it.Next()
+		if errors.Is(err, opsdk.ErrorIteratorDone) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		vaults = append(vaults, onepassword.Vault{
+			ID:   vault.ID,
+			Name: vault.Title,
+		})
+	}
+	return vaults, nil
+}
+
+// GetItems returns an overview (ID, title, category and vault) of all items in a vault.
+func (c *sdkClient) GetItems(vaultUUID string) ([]onepassword.Item, error) {
+	it, err := c.items.ListAll(context.Background(), vaultUUID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]onepassword.Item, 0)
+	for {
+		overview, err := it.Next()
+		if errors.Is(err, opsdk.ErrorIteratorDone) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, onepassword.Item{
+			ID:       overview.ID,
+			Title:    overview.Title,
+			Category: toConnectCategory(overview.Category),
+			Vault:    onepassword.ItemVault{ID: overview.VaultID},
+		})
+	}
+	return items, nil
+}
+
+// GetItemsByTitle returns an overview of all items in a vault with the given title.
+func (c *sdkClient) GetItemsByTitle(title, vaultUUID string) ([]onepassword.Item, error) {
+	all, err := c.GetItems(vaultUUID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]onepassword.Item, 0, 1)
+	for _, item := range all {
+		if item.Title == title {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// GetItemByUUID returns the full item, including its fields.
+func (c *sdkClient) GetItemByUUID(uuid, vaultUUID string) (*onepassword.Item, error) {
+	item, err := c.items.Get(context.Background(), vaultUUID, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return toConnectItem(&item), nil
+}
+
+// CreateItem creates a new item in the given vault.
+func (c *sdkClient) CreateItem(item *onepassword.Item, vaultUUID string) (*onepassword.Item, error) {
+	created, err := c.items.Create(context.Background(), opsdk.ItemCreateParams{
+		Category: toSDKCategory(item.Category),
+		VaultID:  vaultUUID,
+		Title:    item.Title,
+		Fields:   toSDKFields(item.Fields),
+		Sections: toSDKSections(item.Sections),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toConnectItem(&created), nil
+}
+
+// UpdateItem replaces the fields of an existing item.
+func (c *sdkClient) UpdateItem(item *onepassword.Item, vaultUUID string) (*onepassword.Item, error) {
+	current, err := c.items.Get(context.Background(), vaultUUID, item.ID)
+	if err != nil {
+		return nil, err
+	}
+	current.Fields = toSDKFields(item.Fields)
+	current.Sections = toSDKSections(item.Sections)
+	updated, err := c.items.Put(context.Background(), current)
+	if err != nil {
+		return nil, err
+	}
+	return toConnectItem(&updated), nil
+}
+
+// DeleteItem deletes an item from the given vault.
+func (c *sdkClient) DeleteItem(item *onepassword.Item, vaultUUID string) error {
+	return c.items.Delete(context.Background(), vaultUUID, item.ID)
+}
+
+// DeleteItemByID deletes an item from the given vault.
+func (c *sdkClient) DeleteItemByID(itemUUID, vaultUUID string) error {
+	return c.items.Delete(context.Background(), vaultUUID, itemUUID)
+}
+
+// GetFileContent is not supported by the 1Password SDK.
+func (c *sdkClient) GetFileContent(_ *onepassword.File) ([]byte, error) {
+	return nil, errors.New(errSDKFilesNotSupported)
+}
+
+// GetVault is not implemented.
+func (c *sdkClient) GetVault(_ string) (*onepassword.Vault, error) {
+	return nil, fmt.Errorf(errSDKNotSupported, "GetVault")
+}
+
+// GetVaultByUUID is not implemented.
+func (c *sdkClient) GetVaultByUUID(_ string) (*onepassword.Vault, error) {
+	return nil, fmt.Errorf(errSDKNotSupported, "GetVaultByUUID")
+}
+
+// GetItem is not implemented.
+func (c *sdkClient) GetItem(_, _ string) (*onepassword.Item, error) {
+	return nil, fmt.Errorf(errSDKNotSupported, "GetItem")
+}
+
+// GetItemByTitle is not implemented.
+func (c *sdkClient) GetItemByTitle(_, _ string) (*onepassword.Item, error) {
+	return nil, fmt.Errorf(errSDKNotSupported, "GetItemByTitle")
+}
+
+// DeleteItemByTitle is not implemented.
+func (c *sdkClient) DeleteItemByTitle(_, _ string) error {
+	return fmt.Errorf(errSDKNotSupported, "DeleteItemByTitle")
+}
+
+// GetFiles is not supported by the 1Password SDK.
+func (c *sdkClient) GetFiles(_, _ string) ([]onepassword.File, error) {
+	return nil, errors.New(errSDKFilesNotSupported)
+}
+
+// GetFile is not supported by the 1Password SDK.
+func (c *sdkClient) GetFile(_, _, _ string) (*onepassword.File, error) {
+	return nil, errors.New(errSDKFilesNotSupported)
+}
+
+// DownloadFile is not supported by the 1Password SDK.
+func (c *sdkClient) DownloadFile(_ *onepassword.File, _ string, _ bool) (string, error) {
+	return "", errors.New(errSDKFilesNotSupported)
+}
+
+// LoadStructFromItemByUUID is not implemented.
+func (c *sdkClient) LoadStructFromItemByUUID(_ interface{}, _, _ string) error {
+	return fmt.Errorf(errSDKNotSupported, "LoadStructFromItemByUUID")
+}
+
+// LoadStructFromItemByTitle is not implemented.
+func (c *sdkClient) LoadStructFromItemByTitle(_ interface{}, _, _ string) error {
+	return fmt.Errorf(errSDKNotSupported, "LoadStructFromItemByTitle")
+}
+
+// LoadStructFromItem is not implemented.
+func (c *sdkClient) LoadStructFromItem(_ interface{}, _, _ string) error {
+	return fmt.Errorf(errSDKNotSupported, "LoadStructFromItem")
+}
+
+// LoadStruct is not implemented.
+func (c *sdkClient) LoadStruct(_ interface{}) error {
+	return fmt.Errorf(errSDKNotSupported, "LoadStruct")
+}
+
+var sdkToConnectCategory = map[opsdk.ItemCategory]onepassword.ItemCategory{
+	opsdk.ItemCategoryLogin:                onepassword.Login,
+	opsdk.ItemCategoryPassword:             onepassword.Password,
+	opsdk.ItemCategoryAPICredentials:       onepassword.ApiCredential,
+	opsdk.ItemCategoryServer:               onepassword.Server,
+	opsdk.ItemCategoryDatabase:             onepassword.Database,
+	opsdk.ItemCategoryCreditCard:           onepassword.CreditCard,
+	opsdk.ItemCategoryMembership:           onepassword.Membership,
+	opsdk.ItemCategoryPassport:             onepassword.Passport,
+	opsdk.ItemCategorySoftwareLicense:      onepassword.SoftwareLicense,
+	opsdk.ItemCategoryOutdoorLicense:       onepassword.OutdoorLicense,
+	opsdk.ItemCategorySecureNote:           onepassword.SecureNote,
+	opsdk.ItemCategoryRouter:               onepassword.WirelessRouter,
+	opsdk.ItemCategoryBankAccount:          onepassword.BankAccount,
+	opsdk.ItemCategoryDriverLicense:        onepassword.DriverLicense,
+	opsdk.ItemCategoryIdentity:             onepassword.Identity,
+	opsdk.ItemCategoryRewards:              onepassword.RewardProgram,
+	opsdk.ItemCategoryDocument:             onepassword.Document,
+	opsdk.ItemCategoryEmail:                onepassword.EmailAccount,
+	opsdk.ItemCategorySocialSecurityNumber: onepassword.SocialSecurityNumber,
+	opsdk.ItemCategoryMedicalRecord:        onepassword.MedicalRecord,
+	opsdk.ItemCategorySSHKey:               onepassword.SSHKey,
+}
+
+func toConnectCategory(category opsdk.ItemCategory) onepassword.ItemCategory {
+	if c, ok := sdkToConnectCategory[category]; ok {
+		return c
+	}
+	return onepassword.Custom
+}
+
+func toSDKCategory(category onepassword.ItemCategory) opsdk.ItemCategory {
+	for sdkCategory, connectCategory := range sdkToConnectCategory {
+		if connectCategory == category {
+			return sdkCategory
+		}
+	}
+	return opsdk.ItemCategoryUnsupported
+}
+
+func toConnectItem(item *opsdk.Item) *onepassword.Item {
+	sections := make(map[string]*onepassword.ItemSection, len(item.Sections))
+	connectItem := &onepassword.Item{
+		ID:       item.ID,
+		Title:    item.Title,
+		Category: toConnectCategory(item.Category),
+		Vault:    onepassword.ItemVault{ID: item.VaultID},
+		Version:  int(item.Version),
+		Sections: make([]*onepassword.ItemSection, 0, len(item.Sections)),
+		Fields:   make([]*onepassword.ItemField, 0, len(item.Fields)),
+	}
+	for _, section := range item.Sections {
+		s := &onepassword.ItemSection{ID: section.ID, Label: section.Title}
+		sections[section.ID] = s
+		connectItem.Sections = append(connectItem.Sections, s)
+	}
+	for _, field := range item.Fields {
+		f := &onepassword.ItemField{
+			ID:    field.ID,
+			Label: field.Title,
+			Value: field.Value,
+			Type:  toConnectFieldType(field.FieldType),
+		}
+		if field.SectionID != nil {
+			f.Section = sections[*field.SectionID]
+		}
+		connectItem.Fields = append(connectItem.Fields, f)
+	}
+	return connectItem
+}
+
+func toSDKFields(fields []*onepassword.ItemField) []opsdk.ItemField {
+	sdkFields := make([]opsdk.ItemField, 0, len(fields))
+	for _, field := range fields {
+		f := opsdk.ItemField{
+			ID:        field.ID,
+			Title:     field.Label,
+			Value:     field.Value,
+			FieldType: toSDKFieldType(field.Type),
+		}
+		// the SDK requires an ID, connect generates one when missing
+		if f.ID == "" {
+			f.ID = field.Label
+		}
+		if field.Section != nil && field.Section.ID != "" {
+			sectionID := field.Section.ID
+			f.SectionID = &sectionID
+		}
+		sdkFields = append(sdkFields, f)
+	}
+	return sdkFields
+}
+
+func toSDKSections(sections []*onepassword.ItemSection) []opsdk.ItemSection {
+	sdkSections := make([]opsdk.ItemSection, 0, len(sections))
+	for _, section := range sections {
+		sdkSections = append(sdkSections, opsdk.ItemSection{
+			ID:    section.ID,
+			Title: section.Label,
+		})
+	}
+	return sdkSections
+}
+
+func toConnectFieldType(fieldType opsdk.ItemFieldType) onepassword.ItemFieldType {
+	switch fieldType {
+	case opsdk.ItemFieldTypeConcealed:
+		return onepassword.FieldTypeConcealed
+	case opsdk.ItemFieldTypeText:
+		return onepassword.FieldTypeString
+	case opsdk.ItemFieldTypeCreditCardType:
+		return onepassword.FieldTypeCreditCardType
+	case opsdk.ItemFieldTypePhone:
+		return onepassword.FieldTypePhone
+	case opsdk.ItemFieldTypeURL:
+		return onepassword.FieldTypeURL
+	case opsdk.ItemFieldTypeTOTP:
+		return onepassword.FieldTypeOTP
+	default:
+		return onepassword.FieldTypeUnknown
+	}
+}
+
+func toSDKFieldType(fieldType onepassword.ItemFieldType) opsdk.ItemFieldType {
+	switch fieldType {
+	case onepassword.FieldTypeConcealed:
+		return opsdk.ItemFieldTypeConcealed
+	case onepassword.FieldTypeString:
+		return opsdk.ItemFieldTypeText
+	case onepassword.FieldTypeCreditCardType:
+		return opsdk.ItemFieldTypeCreditCardType
+	case onepassword.FieldTypePhone:
+		return opsdk.ItemFieldTypePhone
+	case onepassword.FieldTypeURL:
+		return opsdk.ItemFieldTypeURL
+	case onepassword.FieldTypeOTP:
+		return opsdk.ItemFieldTypeTOTP
+	default:
+		return opsdk.ItemFieldTypeUnsupported
+	}
+}
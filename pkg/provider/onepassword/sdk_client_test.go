@@ -0,0 +1,179 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onepassword
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/1Password/connect-sdk-go/onepassword"
+	opsdk "github.com/1password/onepassword-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+type fakeSDKVaults struct {
+	vaults []opsdk.VaultOverview
+}
+
+func (f *fakeSDKVaults) ListAll(_ context.Context) (*opsdk.Iterator[opsdk.VaultOverview], error) {
+	return opsdk.NewIterator(f.vaults), nil
+}
+
+type fakeSDKItems struct {
+	items map[string]opsdk.Item
+	puts  []opsdk.Item
+}
+
+func (f *fakeSDKItems) Create(_ context.Context, params opsdk.ItemCreateParams) (opsdk.Item, error) {
+	item := opsdk.Item{
+		ID:       params.Title + "-id",
+		Title:    params.Title,
+		Category: params.Category,
+		VaultID:  params.VaultID,
+		Fields:   params.Fields,
+		Sections: params.Sections,
+	}
+	f.items[item.ID] = item
+	return item, nil
+}
+
+func (f *fakeSDKItems) Get(_ context.Context, _, itemID string) (opsdk.Item, error) {
+	item, ok := f.items[itemID]
+	if !ok {
+		return opsdk.Item{}, errors.New("not found")
+	}
+	return item, nil
+}
+
+func (f *fakeSDKItems) Put(_ context.Context, item opsdk.Item) (opsdk.Item, error) {
+	item.Version++
+	f.items[item.ID] = item
+	f.puts = append(f.puts, item)
+	return item, nil
+}
+
+func (f *fakeSDKItems) Delete(_ context.Context, _, itemID string) error {
+	delete(f.items, itemID)
+	return nil
+}
+
+func (f *fakeSDKItems) ListAll(_ context.Context, vaultID string) (*opsdk.Iterator[opsdk.ItemOverview], error) {
+	overviews := make([]opsdk.ItemOverview, 0, len(f.items))
+	for _, item := range f.items {
+		if item.VaultID != vaultID {
+			continue
+		}
+		overviews = append(overviews, opsdk.ItemOverview{
+			ID:       item.ID,
+			Title:    item.Title,
+			Category: item.Category,
+			VaultID:  item.VaultID,
+		})
+	}
+	return opsdk.NewIterator(overviews), nil
+}
+
+func newFakeSDKProvider() (*ProviderOnePassword, *fakeSDKItems) {
+	items := &fakeSDKItems{
+		items: map[string]opsdk.Item{
+			myItemID: {
+				ID:       myItemID,
+				Title:    myItem,
+				Category: opsdk.ItemCategoryLogin,
+				VaultID:  myVaultID,
+				Fields: []opsdk.ItemField{
+					{ID: "password", Title: password, Value: value1, FieldType: opsdk.ItemFieldTypeConcealed},
+					{ID: key2, Title: key2, Value: value2, FieldType: opsdk.ItemFieldTypeText},
+				},
+			},
+		},
+	}
+	return &ProviderOnePassword{
+		vaults: map[string]int{myVault: 1},
+		client: &sdkClient{
+			items: items,
+			vaults: &fakeSDKVaults{vaults: []opsdk.VaultOverview{
+				{ID: myVaultID, Title: myVault},
+				{ID: myOtherVaultID, Title: myOtherVault},
+			}},
+		},
+	}, items
+}
+
+func TestSDKClientGetSecret(t *testing.T) {
+	provider, _ := newFakeSDKProvider()
+
+	got, err := provider.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: myItem})
+	require.NoError(t, err)
+	assert.Equal(t, []byte(value1), got)
+
+	got, err = provider.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: myItem, Property: key2})
+	require.NoError(t, err)
+	assert.Equal(t, []byte(value2), got)
+
+	_, err = provider.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"})
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestSDKClientGetSecretMap(t *testing.T) {
+	provider, _ := newFakeSDKProvider()
+
+	got, err := provider.GetSecretMap(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: myItem})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{password: []byte(value1), key2: []byte(value2)}, got)
+}
+
+func TestSDKClientUpdateItem(t *testing.T) {
+	provider, items := newFakeSDKProvider()
+
+	item, err := provider.findItem(myItem)
+	require.NoError(t, err)
+	item.Fields, err = updateFieldValue(item.Fields, key2, value3)
+	require.NoError(t, err)
+	_, err = provider.client.UpdateItem(item, item.Vault.ID)
+	require.NoError(t, err)
+
+	require.Len(t, items.puts, 1)
+	assert.Equal(t, uint32(1), items.puts[0].Version)
+	assert.Equal(t, value3, items.puts[0].Fields[1].Value)
+	assert.Equal(t, opsdk.ItemFieldTypeText, items.puts[0].Fields[1].FieldType)
+}
+
+func TestSDKClientCreateAndDeleteItem(t *testing.T) {
+	provider, items := newFakeSDKProvider()
+
+	created, err := provider.client.CreateItem(&onepassword.Item{
+		Title:    myOtherItem,
+		Category: onepassword.Server,
+		Fields:   []*onepassword.ItemField{generateNewItemField(password, value4)},
+	}, myVaultID)
+	require.NoError(t, err)
+	assert.Equal(t, onepassword.Server, created.Category)
+	assert.Equal(t, opsdk.ItemCategoryServer, items.items[created.ID].Category)
+	assert.Equal(t, opsdk.ItemFieldTypeConcealed, items.items[created.ID].Fields[0].FieldType)
+
+	require.NoError(t, provider.client.DeleteItem(created, myVaultID))
+	assert.NotContains(t, items.items, created.ID)
+}
+
+func TestSDKClientVaultNotFound(t *testing.T) {
+	provider, _ := newFakeSDKProvider()
+	_, err := provider.client.GetVaultByTitle(myNonMatchingVault)
+	assert.EqualError(t, err, `vault "my-non-matching-vault" not found`)
+}
@@ -0,0 +1,279 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+	"github.com/external-secrets/external-secrets/pkg/plugin"
+	"github.com/external-secrets/external-secrets/pkg/plugin/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+	"github.com/external-secrets/external-secrets/pkg/utils/resolvers"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+
+	errMissingPluginProvider = "missing store provider plugin"
+	errMissingEndpoint       = "endpoint must not be empty"
+	errInvalidCABundle       = "caBundle does not contain a PEM encoded certificate"
+	errConnect               = "could not connect to plugin %s: %w"
+	errResolveSecret         = "could not resolve secret %s: %w"
+	errInvalidSecret         = "invalid secret %s: %w"
+	errDuplicateSecret       = "secret name %q is used more than once"
+	errMissingSecretName     = "secret name must not be empty"
+	errInsecureEndpoint      = "caBundle is required to connect to plugin %s, set insecure to send the secrets of the store in plaintext"
+	errInsecureClusterStore  = "insecure is not allowed for a ClusterSecretStore, set caBundle to connect to plugin %s"
+	warnInsecureEndpoint     = "the connection to plugin %s is not encrypted, the secrets of the store are sent in plaintext"
+)
+
+var _ esv1beta1.SecretsClient = &client{}
+var _ esv1beta1.Provider = &Provider{}
+
+// Provider calls an out-of-tree provider over gRPC.
+type Provider struct{}
+
+// client calls the plugin on behalf of a single store.
+type client struct {
+	conn    *grpc.ClientConn
+	plugin  v1alpha1.SecretsProviderClient
+	store   *v1alpha1.Store
+	timeout time.Duration
+}
+
+func init() {
+	esv1beta1.Register(&Provider{}, &esv1beta1.SecretStoreProvider{
+		Plugin: &esv1beta1.PluginProvider{},
+	})
+}
+
+// Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
+// The capabilities of a plugin are not known in advance, unsupported calls fail at the plugin.
+func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
+	return esv1beta1.SecretStoreReadWrite
+}
+
+// NewClient resolves the secrets of the store and connects to the plugin.
+func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kclient.Client, namespace string) (esv1beta1.SecretsClient, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	// the store may have been created before the webhook rejected insecure endpoints
+	if err := validateTransport(store, prov); err != nil {
+		return nil, err
+	}
+
+	pluginStore := &v1alpha1.Store{
+		Name:            store.GetName(),
+		Namespace:       store.GetNamespace(),
+		Kind:            store.GetKind(),
+		Secrets:         make(map[string][]byte, len(prov.Secrets)),
+		SourceNamespace: namespace,
+	}
+	if prov.Config != nil {
+		pluginStore.Config = prov.Config.Raw
+	}
+	for i := range prov.Secrets {
+		secret := &prov.Secrets[i]
+		value, err := resolvers.SecretKeyRef(ctx, kube, store.GetKind(), namespace, &secret.SecretRef)
+		if err != nil {
+			return nil, fmt.Errorf(errResolveSecret, secret.Name, err)
+		}
+		pluginStore.Secrets[secret.Name] = []byte(value)
+	}
+
+	creds := insecure.NewCredentials()
+	if len(prov.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(prov.CABundle) {
+			return nil, fmt.Errorf(errInvalidCABundle)
+		}
+		creds = credentials.NewTLS(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(prov.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf(errConnect, prov.Endpoint, err)
+	}
+
+	timeout := defaultTimeout
+	if prov.Timeout != nil {
+		timeout = prov.Timeout.Duration
+	}
+	return &client{
+		conn:    conn,
+		plugin:  v1alpha1.NewSecretsProviderClient(conn),
+		store:   pluginStore,
+		timeout: timeout,
+	}, nil
+}
+
+// ValidateStore checks the endpoint and secret references of the store.
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	prov, err := getProvider(store)
+	if err != nil {
+		return nil, err
+	}
+	if prov.Endpoint == "" {
+		return nil, fmt.Errorf(errMissingEndpoint)
+	}
+	names := make(map[string]bool, len(prov.Secrets))
+	for _, secret := range prov.Secrets {
+		if secret.Name == "" {
+			return nil, fmt.Errorf(errMissingSecretName)
+		}
+		if names[secret.Name] {
+			return nil, fmt.Errorf(errDuplicateSecret, secret.Name)
+		}
+		names[secret.Name] = true
+		if err := utils.ValidateSecretSelector(store, secret.SecretRef); err != nil {
+			return nil, fmt.Errorf(errInvalidSecret, secret.Name, err)
+		}
+	}
+	if err := validateTransport(store, prov); err != nil {
+		return nil, err
+	}
+	if len(prov.CABundle) == 0 && !isUnixSocket(prov.Endpoint) {
+		return admission.Warnings{fmt.Sprintf(warnInsecureEndpoint, prov.Endpoint)}, nil
+	}
+	return nil, nil
+}
+
+// validateTransport checks that the secrets of the store are only sent in plaintext to a unix socket,
+// or to a network endpoint the store explicitly allows with insecure. A ClusterSecretStore is used
+// by many namespaces, it must always verify the plugin it sends their secrets to.
+func validateTransport(store esv1beta1.GenericStore, prov *esv1beta1.PluginProvider) error {
+	if len(prov.CABundle) > 0 || isUnixSocket(prov.Endpoint) {
+		return nil
+	}
+	if !prov.Insecure {
+		return fmt.Errorf(errInsecureEndpoint, prov.Endpoint)
+	}
+	if store.GetKind() == esv1beta1.ClusterSecretStoreKind {
+		return fmt.Errorf(errInsecureClusterStore, prov.Endpoint)
+	}
+	return nil
+}
+
+// isUnixSocket returns true if the endpoint is a unix socket, e.g. shared with a sidecar of the controller.
+func isUnixSocket(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "unix:")
+}
+
+func getProvider(store esv1beta1.GenericStore) (*esv1beta1.PluginProvider, error) {
+	spec := store.GetSpec()
+	if spec == nil || spec.Provider == nil || spec.Provider.Plugin == nil {
+		return nil, fmt.Errorf(errMissingPluginProvider)
+	}
+	return spec.Provider.Plugin, nil
+}
+
+func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := c.plugin.GetSecret(ctx, &v1alpha1.GetSecretRequest{Store: c.store, Ref: plugin.ToRemoteRef(ref)})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginGetSecret, err)
+	if err != nil {
+		return nil, plugin.FromStatus(err)
+	}
+	return resp.GetValue(), nil
+}
+
+func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := c.plugin.GetSecretMap(ctx, &v1alpha1.GetSecretRequest{Store: c.store, Ref: plugin.ToRemoteRef(ref)})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginGetSecretMap, err)
+	if err != nil {
+		return nil, plugin.FromStatus(err)
+	}
+	return resp.GetData(), nil
+}
+
+func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := c.plugin.GetAllSecrets(ctx, &v1alpha1.GetAllSecretsRequest{Store: c.store, Find: plugin.ToFind(ref)})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginGetAllSecrets, err)
+	if err != nil {
+		return nil, plugin.FromStatus(err)
+	}
+	return resp.GetData(), nil
+}
+
+func (c *client) PushSecret(ctx context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	_, err := c.plugin.PushSecret(ctx, &v1alpha1.PushSecretRequest{
+		Store:  c.store,
+		Secret: plugin.ToSecret(secret),
+		Data:   plugin.ToPushSecretData(data),
+	})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginPushSecret, err)
+	return plugin.FromStatus(err)
+}
+
+func (c *client) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	_, err := c.plugin.DeleteSecret(ctx, &v1alpha1.DeleteSecretRequest{Store: c.store, Ref: plugin.ToPushRemoteRef(remoteRef)})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginDeleteSecret, err)
+	return plugin.FromStatus(err)
+}
+
+func (c *client) SecretExists(ctx context.Context, remoteRef esv1beta1.PushSecretRemoteRef) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := c.plugin.SecretExists(ctx, &v1alpha1.SecretExistsRequest{Store: c.store, Ref: plugin.ToPushRemoteRef(remoteRef)})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginSecretExists, err)
+	if err != nil {
+		return false, plugin.FromStatus(err)
+	}
+	return resp.GetExists(), nil
+}
+
+func (c *client) Validate() (esv1beta1.ValidationResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	resp, err := c.plugin.Validate(ctx, &v1alpha1.ValidateRequest{Store: c.store})
+	metrics.ObserveAPICall(constants.ProviderPlugin, constants.CallPluginValidate, err)
+	if err != nil {
+		return esv1beta1.ValidationResultError, plugin.FromStatus(err)
+	}
+	result := esv1beta1.ValidationResult(resp.GetResult())
+	if result == esv1beta1.ValidationResultError {
+		return result, fmt.Errorf("%s", resp.GetMessage())
+	}
+	return result, nil
+}
+
+func (c *client) Close(_ context.Context) error {
+	return c.conn.Close()
+}
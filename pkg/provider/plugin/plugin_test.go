@@ -0,0 +1,233 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/plugin"
+	"github.com/external-secrets/external-secrets/pkg/plugin/v1alpha1"
+)
+
+// fakePlugin is an out-of-tree provider keeping its secrets in memory.
+type fakePlugin struct {
+	data   map[string][]byte
+	stores []*v1alpha1.Store
+	err    error
+}
+
+type fakePluginClient struct {
+	*fakePlugin
+}
+
+func (p *fakePlugin) NewClient(_ context.Context, store *v1alpha1.Store) (esv1beta1.SecretsClient, error) {
+	p.stores = append(p.stores, store)
+	return &fakePluginClient{p}, nil
+}
+
+func (c *fakePluginClient) GetSecret(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	val, ok := c.data[ref.Key]
+	if !ok {
+		return nil, esv1beta1.NoSecretErr
+	}
+	return val, nil
+}
+
+func (c *fakePluginClient) GetSecretMap(_ context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	return map[string][]byte{ref.Key: c.data[ref.Key]}, nil
+}
+
+func (c *fakePluginClient) GetAllSecrets(_ context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if ref.Name == nil {
+		return nil, errors.New("find.name is required")
+	}
+	return c.data, nil
+}
+
+func (c *fakePluginClient) PushSecret(_ context.Context, secret *corev1.Secret, data esv1beta1.PushSecretData) error {
+	c.data[data.GetRemoteKey()] = secret.Data[data.GetSecretKey()]
+	return nil
+}
+
+func (c *fakePluginClient) DeleteSecret(_ context.Context, ref esv1beta1.PushSecretRemoteRef) error {
+	delete(c.data, ref.GetRemoteKey())
+	return nil
+}
+
+func (c *fakePluginClient) SecretExists(_ context.Context, ref esv1beta1.PushSecretRemoteRef) (bool, error) {
+	_, ok := c.data[ref.GetRemoteKey()]
+	return ok, nil
+}
+
+func (c *fakePluginClient) Validate() (esv1beta1.ValidationResult, error) {
+	if c.err != nil {
+		return esv1beta1.ValidationResultError, c.err
+	}
+	return esv1beta1.ValidationResultReady, nil
+}
+
+func (c *fakePluginClient) Close(_ context.Context) error {
+	return nil
+}
+
+func servePlugin(t *testing.T, p plugin.Provider) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "plugin.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	go func() {
+		_ = plugin.Serve(listener, p)
+	}()
+	t.Cleanup(func() { _ = listener.Close() })
+	return "unix://" + socket
+}
+
+func makeStore(endpoint string) *esv1beta1.SecretStore {
+	return &esv1beta1.SecretStore{
+		TypeMeta:   metav1.TypeMeta{Kind: esv1beta1.SecretStoreKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "plugin", Namespace: "default"},
+		Spec: esv1beta1.SecretStoreSpec{
+			Provider: &esv1beta1.SecretStoreProvider{
+				Plugin: &esv1beta1.PluginProvider{
+					Endpoint: endpoint,
+					Config:   &apiextensionsv1.JSON{Raw: []byte(`{"region":"eu"}`)},
+					Secrets: []esv1beta1.PluginSecret{{
+						Name:      "token",
+						SecretRef: esmeta.SecretKeySelector{Name: "plugin-credentials", Key: "token"},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakePlugin{data: map[string][]byte{"foo": []byte("bar")}}
+	store := makeStore(servePlugin(t, fake))
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "plugin-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}).Build()
+
+	client, err := (&Provider{}).NewClient(ctx, store, kube, "default")
+	require.NoError(t, err)
+	defer client.Close(ctx)
+
+	val, err := client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bar"), val)
+	require.NotEmpty(t, fake.stores)
+	assert.Equal(t, "plugin", fake.stores[0].GetName())
+	assert.Equal(t, esv1beta1.SecretStoreKind, fake.stores[0].GetKind())
+	assert.JSONEq(t, `{"region":"eu"}`, string(fake.stores[0].GetConfig()))
+	assert.Equal(t, map[string][]byte{"token": []byte("s3cr3t")}, fake.stores[0].GetSecrets())
+
+	_, err = client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "missing"})
+	assert.ErrorIs(t, err, esv1beta1.NoSecretErr)
+
+	secretMap, err := client.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"foo": []byte("bar")}, secretMap)
+
+	_, err = client.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{})
+	assert.EqualError(t, err, "find.name is required")
+
+	push := esv1alpha1.PushSecretData{Match: esv1alpha1.PushSecretMatch{
+		SecretKey: "key",
+		RemoteRef: esv1alpha1.PushSecretRemoteRef{RemoteKey: "pushed"},
+	}}
+	err = client.PushSecret(ctx, &corev1.Secret{Data: map[string][]byte{"key": []byte("value")}}, push)
+	require.NoError(t, err)
+	exists, err := client.SecretExists(ctx, push.Match.RemoteRef)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	all, err := client.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{Name: &esv1beta1.FindName{RegExp: ".*"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"foo": []byte("bar"), "pushed": []byte("value")}, all)
+
+	require.NoError(t, client.DeleteSecret(ctx, push.Match.RemoteRef))
+	exists, err = client.SecretExists(ctx, push.Match.RemoteRef)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	result, err := client.Validate()
+	require.NoError(t, err)
+	assert.Equal(t, esv1beta1.ValidationResultReady, result)
+
+	fake.err = errors.New("invalid token")
+	result, err = client.Validate()
+	assert.EqualError(t, err, "invalid token")
+	assert.Equal(t, esv1beta1.ValidationResultError, result)
+}
+
+func TestValidateStore(t *testing.T) {
+	store := makeStore("unix:///var/run/plugin.sock")
+	warnings, err := (&Provider{}).ValidateStore(store)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	store.Spec.Provider.Plugin.Endpoint = "plugin.default.svc:8443"
+	_, err = (&Provider{}).ValidateStore(store)
+	assert.EqualError(t, err, fmt.Sprintf(errInsecureEndpoint, "plugin.default.svc:8443"))
+
+	store.Spec.Provider.Plugin.Insecure = true
+	warnings, err = (&Provider{}).ValidateStore(store)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	// a ClusterSecretStore sends the secrets of many namespaces to the plugin
+	clusterStore := &esv1beta1.ClusterSecretStore{
+		TypeMeta: metav1.TypeMeta{Kind: esv1beta1.ClusterSecretStoreKind},
+		Spec: esv1beta1.SecretStoreSpec{Provider: &esv1beta1.SecretStoreProvider{
+			Plugin: &esv1beta1.PluginProvider{Endpoint: "plugin.default.svc:8443", Insecure: true},
+		}},
+	}
+	_, err = (&Provider{}).ValidateStore(clusterStore)
+	assert.EqualError(t, err, fmt.Sprintf(errInsecureClusterStore, "plugin.default.svc:8443"))
+	_, err = (&Provider{}).NewClient(context.Background(), clusterStore, clientfake.NewClientBuilder().Build(), "default")
+	assert.EqualError(t, err, fmt.Sprintf(errInsecureClusterStore, "plugin.default.svc:8443"))
+
+	store.Spec.Provider.Plugin.Endpoint = ""
+	_, err = (&Provider{}).ValidateStore(store)
+	assert.EqualError(t, err, errMissingEndpoint)
+
+	store = makeStore("unix:///var/run/plugin.sock")
+	store.Spec.Provider.Plugin.Secrets = append(store.Spec.Provider.Plugin.Secrets, store.Spec.Provider.Plugin.Secrets[0])
+	_, err = (&Provider{}).ValidateStore(store)
+	assert.EqualError(t, err, `secret name "token" is used more than once`)
+
+	store = makeStore("unix:///var/run/plugin.sock")
+	ns := "other"
+	store.Spec.Provider.Plugin.Secrets[0].SecretRef.Namespace = &ns
+	_, err = (&Provider{}).ValidateStore(store)
+	assert.Error(t, err)
+}
@@ -42,6 +42,11 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
 }
 
+// UnsupportedRemoteRefFeatures returns the remote ref features Scaleway rejects: only single keys of a Secret can be pushed.
+func (p *Provider) UnsupportedRemoteRefFeatures(_ esv1beta1.GenericStore) []esv1beta1.RemoteRefFeature {
+	return []esv1beta1.RemoteRefFeature{esv1beta1.RemoteRefFeaturePushWholeSecret}
+}
+
 func (p *Provider) NewClient(ctx context.Context, store esv1beta1.GenericStore, kube kubeClient.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	cfg, err := getConfig(store)
 	if err != nil {
@@ -0,0 +1,297 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance contains a test suite that checks a SecretsClient against
+// the behavior the controllers rely on. Provider authors run it against their implementation
+// with a fake or a real backend, e.g. from a unit test or the e2e suites.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/testing/fake"
+)
+
+const (
+	defaultPrefix  = "conformance"
+	defaultTimeout = time.Minute
+
+	// Names of the checks, they can be skipped with Suite.Skip.
+	CheckGetSecret           = "GetSecret"
+	CheckGetSecretProperty   = "GetSecretProperty"
+	CheckGetSecretMap        = "GetSecretMap"
+	CheckGetAllSecrets       = "GetAllSecrets"
+	CheckMetadataPolicyFetch = "MetadataPolicyFetch"
+	CheckNoSecretError       = "NoSecretError"
+	CheckPushSecret          = "PushSecret"
+	CheckDeleteSecret        = "DeleteSecret"
+)
+
+// Backend seeds the secrets the read checks expect in the provider.
+type Backend interface {
+	// Seed creates or replaces the secret with the given key and value.
+	Seed(ctx context.Context, key string, value []byte) error
+	// Remove deletes the secret, it is called to clean up the seeded secrets.
+	Remove(ctx context.Context, key string) error
+}
+
+// MetadataBackend may be implemented by a Backend of a provider
+// that supports metadataPolicy=Fetch.
+type MetadataBackend interface {
+	// SeedMetadata sets the metadata (tags, labels, ...) of a seeded secret.
+	SeedMetadata(ctx context.Context, key string, metadata map[string]string) error
+}
+
+// Suite runs the conformance checks against a SecretsClient.
+type Suite struct {
+	// Client is the SecretsClient under test.
+	Client esv1beta1.SecretsClient
+	// Backend seeds the secrets of the read checks.
+	Backend Backend
+	// Capabilities of the provider, the write checks only run for ReadWrite and WriteOnly providers.
+	Capabilities esv1beta1.SecretStoreCapabilities
+	// Prefix of the keys created by the suite, defaults to "conformance".
+	// A random suffix is added, so multiple runs against the same backend don't collide.
+	Prefix string
+	// Skip lists the checks that are not supported by the provider.
+	Skip []string
+	// Timeout of a single check, defaults to 1m.
+	Timeout time.Duration
+}
+
+// Run runs all checks as subtests of t.
+func (s *Suite) Run(t *testing.T) {
+	prefix := s.Prefix
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	//nolint:gosec // the suffix only has to differ between runs
+	prefix = fmt.Sprintf("%s-%06d", prefix, rand.Intn(1000000))
+
+	canRead := s.Capabilities != esv1beta1.SecretStoreWriteOnly
+	canWrite := s.Capabilities != esv1beta1.SecretStoreReadOnly
+	checks := []struct {
+		name    string
+		enabled bool
+		fn      func(context.Context, *testing.T, string)
+	}{
+		{CheckGetSecret, canRead, s.checkGetSecret},
+		{CheckGetSecretProperty, canRead, s.checkGetSecretProperty},
+		{CheckGetSecretMap, canRead, s.checkGetSecretMap},
+		{CheckGetAllSecrets, canRead, s.checkGetAllSecrets},
+		{CheckMetadataPolicyFetch, canRead, s.checkMetadataPolicyFetch},
+		{CheckNoSecretError, canRead, s.checkNoSecretError},
+		{CheckPushSecret, canWrite, s.checkPushSecret},
+		{CheckDeleteSecret, canWrite, s.checkDeleteSecret},
+	}
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			if !c.enabled || slices.Contains(s.Skip, c.name) {
+				t.Skipf("%s is not supported by the provider", c.name)
+			}
+			timeout := s.Timeout
+			if timeout == 0 {
+				timeout = defaultTimeout
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			c.fn(ctx, t, fmt.Sprintf("%s-%s", prefix, slug(c.name)))
+		})
+	}
+}
+
+// seed seeds the secret and registers its removal.
+func (s *Suite) seed(ctx context.Context, t *testing.T, key string, value []byte) {
+	t.Helper()
+	if s.Backend == nil {
+		t.Skip("the suite has no backend to seed secrets")
+	}
+	if err := s.Backend.Seed(ctx, key, value); err != nil {
+		t.Fatalf("seeding %s: %v", key, err)
+	}
+	t.Cleanup(func() {
+		if err := s.Backend.Remove(context.Background(), key); err != nil {
+			t.Errorf("removing %s: %v", key, err)
+		}
+	})
+}
+
+func (s *Suite) checkGetSecret(ctx context.Context, t *testing.T, key string) {
+	s.seed(ctx, t, key, []byte("value"))
+	got, err := s.Client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: key})
+	if err != nil {
+		t.Fatalf("GetSecret(%s) error = %v", key, err)
+	}
+	if string(got) != "value" {
+		t.Errorf("GetSecret(%s) = %q, want %q", key, got, "value")
+	}
+}
+
+func (s *Suite) checkGetSecretProperty(ctx context.Context, t *testing.T, key string) {
+	s.seed(ctx, t, key, []byte(`{"username":"foo","password":"bar"}`))
+	got, err := s.Client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: key, Property: "password"})
+	if err != nil {
+		t.Fatalf("GetSecret(%s, password) error = %v", key, err)
+	}
+	if string(got) != "bar" {
+		t.Errorf("GetSecret(%s, password) = %q, want %q", key, got, "bar")
+	}
+}
+
+func (s *Suite) checkGetSecretMap(ctx context.Context, t *testing.T, key string) {
+	s.seed(ctx, t, key, []byte(`{"username":"foo","password":"bar"}`))
+	got, err := s.Client.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: key})
+	if err != nil {
+		t.Fatalf("GetSecretMap(%s) error = %v", key, err)
+	}
+	want := map[string]string{"username": "foo", "password": "bar"}
+	if len(got) != len(want) {
+		t.Errorf("GetSecretMap(%s) returned %d keys, want %d", key, len(got), len(want))
+	}
+	for k, v := range want {
+		if string(got[k]) != v {
+			t.Errorf("GetSecretMap(%s)[%s] = %q, want %q", key, k, got[k], v)
+		}
+	}
+}
+
+func (s *Suite) checkGetAllSecrets(ctx context.Context, t *testing.T, key string) {
+	keys := []string{key + "-a", key + "-b"}
+	for _, k := range keys {
+		s.seed(ctx, t, k, []byte(k))
+	}
+	got, err := s.Client.GetAllSecrets(ctx, esv1beta1.ExternalSecretFind{
+		Name: &esv1beta1.FindName{RegExp: "^" + regexp.QuoteMeta(key) + "-"},
+	})
+	if err != nil {
+		t.Fatalf("GetAllSecrets(%s-*) error = %v", key, err)
+	}
+	if len(got) != len(keys) {
+		t.Errorf("GetAllSecrets(%s-*) returned %d secrets, want %d", key, len(got), len(keys))
+	}
+	// providers may convert the keys, e.g. replace the path separators, so only the values are compared
+	for _, k := range keys {
+		found := false
+		for _, v := range got {
+			found = found || string(v) == k
+		}
+		if !found {
+			t.Errorf("GetAllSecrets(%s-*) is missing the value of %s", key, k)
+		}
+	}
+}
+
+func (s *Suite) checkMetadataPolicyFetch(ctx context.Context, t *testing.T, key string) {
+	mb, ok := s.Backend.(MetadataBackend)
+	if !ok {
+		t.Skip("the backend does not implement MetadataBackend")
+	}
+	s.seed(ctx, t, key, []byte("value"))
+	if err := mb.SeedMetadata(ctx, key, map[string]string{"team": "conformance"}); err != nil {
+		t.Fatalf("seeding metadata of %s: %v", key, err)
+	}
+	got, err := s.Client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{
+		Key:            key,
+		Property:       "team",
+		MetadataPolicy: esv1beta1.ExternalSecretMetadataPolicyFetch,
+	})
+	if err != nil {
+		t.Fatalf("GetSecret(%s, metadataPolicy=Fetch) error = %v", key, err)
+	}
+	if string(got) != "conformance" {
+		t.Errorf("GetSecret(%s, metadataPolicy=Fetch) = %q, want %q", key, got, "conformance")
+	}
+}
+
+// checkNoSecretError verifies missing secrets are reported with NoSecretError,
+// the controllers rely on it to apply the deletionPolicy.
+func (s *Suite) checkNoSecretError(ctx context.Context, t *testing.T, key string) {
+	missing := key + "-missing"
+	if _, err := s.Client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: missing}); !errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Errorf("GetSecret(%s) error = %v, want NoSecretError", missing, err)
+	}
+	if _, err := s.Client.GetSecretMap(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: missing}); !errors.Is(err, esv1beta1.NoSecretErr) {
+		t.Errorf("GetSecretMap(%s) error = %v, want NoSecretError", missing, err)
+	}
+}
+
+func (s *Suite) checkPushSecret(ctx context.Context, t *testing.T, key string) {
+	ref := fake.PushSecretData{SecretKey: "key", RemoteKey: key}
+	t.Cleanup(func() {
+		_ = s.Client.DeleteSecret(context.Background(), ref)
+	})
+	for _, value := range []string{"first", "second"} {
+		if err := s.Client.PushSecret(ctx, pushSource(value), ref); err != nil {
+			t.Fatalf("PushSecret(%s, %s) error = %v", key, value, err)
+		}
+		exists, err := s.Client.SecretExists(ctx, ref)
+		if err != nil {
+			t.Fatalf("SecretExists(%s) error = %v", key, err)
+		}
+		if !exists {
+			t.Errorf("SecretExists(%s) = false after PushSecret", key)
+		}
+		if s.Capabilities == esv1beta1.SecretStoreWriteOnly {
+			continue
+		}
+		got, err := s.Client.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: key})
+		if err != nil {
+			t.Fatalf("GetSecret(%s) error = %v", key, err)
+		}
+		if string(got) != value {
+			t.Errorf("GetSecret(%s) = %q after pushing %q", key, got, value)
+		}
+	}
+}
+
+func (s *Suite) checkDeleteSecret(ctx context.Context, t *testing.T, key string) {
+	ref := fake.PushSecretData{SecretKey: "key", RemoteKey: key}
+	if err := s.Client.PushSecret(ctx, pushSource("value"), ref); err != nil {
+		t.Fatalf("PushSecret(%s) error = %v", key, err)
+	}
+	if err := s.Client.DeleteSecret(ctx, ref); err != nil {
+		t.Fatalf("DeleteSecret(%s) error = %v", key, err)
+	}
+	exists, err := s.Client.SecretExists(ctx, ref)
+	if err != nil {
+		t.Fatalf("SecretExists(%s) error = %v", key, err)
+	}
+	if exists {
+		t.Errorf("SecretExists(%s) = true after DeleteSecret", key)
+	}
+	// the PushSecret controller deletes secrets that may already be gone
+	if err := s.Client.DeleteSecret(ctx, ref); err != nil {
+		t.Errorf("DeleteSecret(%s) of a deleted secret error = %v", key, err)
+	}
+}
+
+func pushSource(value string) *corev1.Secret {
+	return &corev1.Secret{Data: map[string][]byte{"key": []byte(value)}}
+}
+
+// slug converts the name of a check to a key that is valid for most providers.
+func slug(name string) string {
+	return strings.ToLower(name)
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/go-logr/logr"
 	vault "github.com/hashicorp/vault/api"
@@ -36,6 +37,8 @@ import (
 )
 
 var _ esv1beta1.SecretsClient = &client{}
+var _ esv1beta1.ProviderVersionReporter = &client{}
+var _ esv1beta1.SecretMetadataReporter = &client{}
 
 type client struct {
 	kube      kclient.Client
@@ -48,6 +51,11 @@ type client struct {
 	token     util.Token
 	namespace string
 	storeKind string
+
+	// metadata of the KV v2 secrets read by this client, by path,
+	// guarded by metadataMu as secrets are read in parallel by find
+	metadataMu sync.Mutex
+	metadata   map[string]esv1beta1.SecretMetadata
 }
 
 func (c *client) newConfig(ctx context.Context) (*vault.Config, error) {
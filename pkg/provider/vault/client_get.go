@@ -19,7 +19,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/tidwall/gjson"
 
@@ -45,6 +47,15 @@ const (
 //  2. get a key from the secret.
 //     Nested values are supported by specifying a gjson expression
 func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, err := c.readSecretValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return c.applyTransit(ctx, value)
+}
+
+// readSecretValue returns the value of the ref as stored in Vault.
+func (c *client) readSecretValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	var data map[string]any
 	var err error
 	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
@@ -77,7 +88,7 @@ func (c *client) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretData
 // 1. get the full secret from the vault data payload (by leaving .property empty).
 // 2. extract key/value pairs from a (nested) object.
 func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
-	data, err := c.GetSecret(ctx, ref)
+	data, err := c.readSecretValue(ctx, ref)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +104,11 @@ func (c *client) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretD
 		if err != nil {
 			return nil, err
 		}
+		// the transit engine is applied to every value, the map itself is never encrypted
+		byteMap[k], err = c.applyTransit(ctx, byteMap[k])
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return byteMap, nil
@@ -151,11 +167,59 @@ func (c *client) readSecret(ctx context.Context, path, version string) (map[stri
 		if !ok {
 			return nil, errors.New(errJSONUnmarshall)
 		}
+		c.recordMetadata(path, vaultSecret.Data["metadata"])
 	}
 
 	return secretData, nil
 }
 
+// recordMetadata keeps the version metadata of a KV v2 read, see
+// https://developer.hashicorp.com/vault/api-docs/secret/kv/kv-v2#read-secret-version.
+func (c *client) recordMetadata(path string, raw any) {
+	versionMetadata, ok := raw.(map[string]any)
+	if !ok {
+		return
+	}
+	metadata := esv1beta1.SecretMetadata{Key: path}
+	if version, ok := versionMetadata["version"]; ok && version != nil {
+		metadata.Version = fmt.Sprint(version)
+	}
+	if created, ok := versionMetadata["created_time"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, created); err == nil {
+			metadata.LastModified = t
+		}
+	}
+	if custom, ok := versionMetadata["custom_metadata"].(map[string]any); ok {
+		metadata.Tags = make(map[string]string, len(custom))
+		for k, v := range custom {
+			metadata.Tags[k] = fmt.Sprint(v)
+		}
+	}
+	c.metadataMu.Lock()
+	defer c.metadataMu.Unlock()
+	if c.metadata == nil {
+		c.metadata = make(map[string]esv1beta1.SecretMetadata)
+	}
+	c.metadata[path] = metadata
+}
+
+// SecretMetadata returns the version metadata of the KV v2 secrets read by the client.
+func (c *client) SecretMetadata() []esv1beta1.SecretMetadata {
+	c.metadataMu.Lock()
+	defer c.metadataMu.Unlock()
+	if len(c.metadata) == 0 {
+		return nil
+	}
+	metadata := make([]esv1beta1.SecretMetadata, 0, len(c.metadata))
+	for _, m := range c.metadata {
+		metadata = append(metadata, m)
+	}
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].Key < metadata[j].Key
+	})
+	return metadata
+}
+
 func getSecretValue(data map[string]any, property string) ([]byte, error) {
 	if data == nil {
 		return nil, esv1beta1.NoSecretError{}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/constants"
@@ -28,6 +29,8 @@ import (
 
 const (
 	errUnsupportedKvVersion = "cannot perform find operations with kv version v1"
+
+	defaultFindConcurrency = 1
 )
 
 // GetAllSecrets gets multiple secrets from the provider and loads into a kubernetes secret.
@@ -52,60 +55,110 @@ func (c *client) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecret
 }
 
 func (c *client) findSecretsFromTags(ctx context.Context, candidates []string, tags map[string]string) (map[string][]byte, error) {
-	secrets := make(map[string][]byte)
-	for _, name := range candidates {
-		match := true
+	// custom_metadata is not part of the list response, the metadata of every candidate is read
+	return c.readSecrets(ctx, candidates, func(ctx context.Context, name string) (bool, error) {
 		metadata, err := c.readSecretMetadata(ctx, name)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 		for tk, tv := range tags {
 			p, ok := metadata[tk]
 			if !ok || p != tv {
-				match = false
-				break
+				return false, nil
 			}
 		}
-		if match {
-			secret, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: name})
-			if errors.Is(err, esv1beta1.NoSecretError{}) {
-				continue
-			}
-			if err != nil {
-				return nil, err
-			}
-			if secret != nil {
-				secrets[name] = secret
-			}
-		}
-	}
-	return secrets, nil
+		return true, nil
+	})
 }
 
 func (c *client) findSecretsFromName(ctx context.Context, candidates []string, ref esv1beta1.FindName) (map[string][]byte, error) {
-	secrets := make(map[string][]byte)
 	matcher, err := find.New(ref)
 	if err != nil {
 		return nil, err
 	}
+	matching := make([]string, 0, len(candidates))
 	for _, name := range candidates {
-		ok := matcher.MatchName(name)
-		if ok {
-			secret, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: name})
-			if errors.Is(err, esv1beta1.NoSecretError{}) {
-				continue
-			}
+		if matcher.MatchName(name) {
+			matching = append(matching, name)
+		}
+	}
+	return c.readSecrets(ctx, matching, nil)
+}
+
+// readSecrets reads the candidates that match, at most findConcurrency at a time.
+// Candidates listed more than once are read once, deleted secrets are skipped.
+// The first error cancels the remaining reads.
+func (c *client) readSecrets(ctx context.Context, candidates []string, match func(ctx context.Context, name string) (bool, error)) (map[string][]byte, error) {
+	names := make([]string, 0, len(candidates))
+	seen := make(map[string]struct{}, len(candidates))
+	for _, name := range candidates {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	concurrency := c.store.FindConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultFindConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	secrets := make(map[string][]byte)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, name := range names {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			secret, err := c.readCandidate(ctx, name, match)
+			mu.Lock()
+			defer mu.Unlock()
 			if err != nil {
-				return nil, err
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
 			}
 			if secret != nil {
 				secrets[name] = secret
 			}
-		}
+		}(name)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
 	}
 	return secrets, nil
 }
 
+// readCandidate returns the secret of the candidate, or nil if it does not match or is deleted.
+func (c *client) readCandidate(ctx context.Context, name string, match func(ctx context.Context, name string) (bool, error)) ([]byte, error) {
+	if match != nil {
+		ok, err := match(ctx, name)
+		if err != nil || !ok {
+			return nil, err
+		}
+	}
+	secret, err := c.GetSecret(ctx, esv1beta1.ExternalSecretDataRemoteRef{Key: name})
+	if errors.Is(err, esv1beta1.NoSecretError{}) {
+		return nil, nil
+	}
+	return secret, err
+}
+
 func (c *client) listSecrets(ctx context.Context, path string) ([]string, error) {
 	secrets := make([]string, 0)
 	url, err := c.buildMetadataPath(path)
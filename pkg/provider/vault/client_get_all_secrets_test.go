@@ -17,7 +17,9 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	vault "github.com/hashicorp/vault/api"
@@ -201,6 +203,28 @@ func TestGetAllSecrets(t *testing.T) {
 				},
 			},
 		},
+		"FindByTagConcurrently": {
+			reason: "should map multiple secrets matching tags when reading in parallel",
+			args: args{
+				store: withFindConcurrency(makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault, 3),
+				vLogical: &fake.Logical{
+					ListWithContextFn:         newListWithContextFn(secret),
+					ReadWithDataWithContextFn: newReadtWithContextFn(secret),
+				},
+				data: esv1beta1.ExternalSecretFind{
+					Tags: map[string]string{
+						"foo": "baz",
+					},
+				},
+			},
+			want: want{
+				err: nil,
+				val: map[string][]byte{
+					"tag":     tagBytes,
+					"secret2": secret2Bytes,
+				},
+			},
+		},
 		"FailIfKv1": {
 			reason: "should not work if using kv1 store",
 			args: args{
@@ -260,6 +284,58 @@ func TestGetAllSecrets(t *testing.T) {
 	}
 }
 
+func TestReadSecretsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	reads := make(map[string]int)
+	vStore := &client{
+		store: withFindConcurrency(makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault, 2),
+		logical: &fake.Logical{
+			ReadWithDataWithContextFn: func(ctx context.Context, path string, d map[string][]string) (*vault.Secret, error) {
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				reads[path]++
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				if path == "secret/data/failing" {
+					return nil, errors.New("permission denied")
+				}
+				return &vault.Secret{Data: map[string]any{"data": map[string]any{"key": path}}}, nil
+			},
+		},
+	}
+
+	val, err := vStore.readSecrets(context.Background(), []string{"a", "b", "a", "c", "d", "b"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(val) != 4 {
+		t.Errorf("expected 4 secrets, got %d", len(val))
+	}
+	for path, n := range reads {
+		if n != 1 {
+			t.Errorf("expected %s to be read once, got %d", path, n)
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 parallel reads, got %d", maxInFlight)
+	}
+
+	_, err = vStore.readSecrets(context.Background(), []string{"a", "failing", "b"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("expected permission denied error, got %v", err)
+	}
+}
+
+func withFindConcurrency(store *esv1beta1.VaultProvider, concurrency int) *esv1beta1.VaultProvider {
+	store.FindConcurrency = concurrency
+	return store
+}
+
 func newListWithContextFn(secrets map[string]any) func(ctx context.Context, path string) (*vault.Secret, error) {
 	return func(ctx context.Context, path string) (*vault.Secret, error) {
 		path = strings.TrimPrefix(path, "secret/metadata/")
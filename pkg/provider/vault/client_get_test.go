@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	vault "github.com/hashicorp/vault/api"
@@ -348,6 +349,35 @@ func TestGetSecret(t *testing.T) {
 	}
 }
 
+func TestSecretMetadata(t *testing.T) {
+	vStore := &client{
+		store: makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault,
+		logical: &fake.Logical{
+			ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{
+				"data": map[string]any{"password": "secret"},
+				"metadata": map[string]any{
+					"created_time":    "2024-03-01T12:00:00.123456Z",
+					"custom_metadata": map[string]any{"owner": "team-a"},
+					"version":         json.Number("42"),
+				},
+			}, nil),
+		},
+	}
+	_, err := vStore.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "db/creds", Property: "password"})
+	if err != nil {
+		t.Fatalf("vault.GetSecret(...): unexpected error: %v", err)
+	}
+	want := []esv1beta1.SecretMetadata{{
+		Key:          "db/creds",
+		Version:      "42",
+		LastModified: time.Date(2024, 3, 1, 12, 0, 0, 123456000, time.UTC),
+		Tags:         map[string]string{"owner": "team-a"},
+	}}
+	if diff := cmp.Diff(want, vStore.SecretMetadata()); diff != "" {
+		t.Errorf("vault.SecretMetadata(): -want, +got:\n%s", diff)
+	}
+}
+
 func TestGetSecretMap(t *testing.T) {
 	errBoom := errors.New("boom")
 	secret := map[string]any{
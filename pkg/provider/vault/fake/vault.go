@@ -150,6 +150,24 @@ func (f Token) LookupSelfWithContext(ctx context.Context) (*vault.Secret, error)
 	return f.LookupSelfWithContextFn(ctx)
 }
 
+type SealStatusWithContextFn func(ctx context.Context) (*vault.SealStatusResponse, error)
+type Sys struct {
+	SealStatusWithContextFn SealStatusWithContextFn
+}
+
+func (f Sys) SealStatusWithContext(ctx context.Context) (*vault.SealStatusResponse, error) {
+	return f.SealStatusWithContextFn(ctx)
+}
+
+func NewSealStatusWithContextFn(version string, err error) SealStatusWithContextFn {
+	return func(ctx context.Context) (*vault.SealStatusResponse, error) {
+		if err != nil {
+			return nil, err
+		}
+		return &vault.SealStatusResponse{Version: version}, nil
+	}
+}
+
 type MockSetTokenFn func(v string)
 
 type MockTokenFn func() string
@@ -199,6 +217,7 @@ type VaultClient struct {
 	MockLogical      Logical
 	MockAuth         Auth
 	MockAuthToken    Token
+	MockSys          Sys
 	MockSetToken     MockSetTokenFn
 	MockToken        MockTokenFn
 	MockClearToken   MockClearTokenFn
@@ -244,6 +263,10 @@ func (c *VaultClient) AuthToken() Token {
 	return c.MockAuthToken
 }
 
+func (c *VaultClient) Sys() Sys {
+	return c.MockSys
+}
+
 func (c *VaultClient) SetToken(v string) {
 	c.MockSetToken(v)
 }
@@ -289,6 +312,7 @@ func ClientWithLoginMock(_ *vault.Config) (util.Client, error) {
 		AuthField:        cl.Auth(),
 		AuthTokenField:   cl.AuthToken(),
 		LogicalField:     cl.Logical(),
+		SysField:         cl.Sys(),
 		NamespaceFunc:    cl.Namespace,
 		SetNamespaceFunc: cl.SetNamespace,
 		AddHeaderFunc:    cl.AddHeader,
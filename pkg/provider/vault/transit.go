@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	defaultTransitPath = "transit"
+
+	errTransitEncrypt   = "cannot encrypt value with transit key %s: %w"
+	errTransitDecrypt   = "cannot decrypt value with transit key %s: %w"
+	errTransitNoField   = "transit response has no %s"
+	errTransitPlaintext = "cannot decode transit plaintext: %w"
+)
+
+// applyTransit passes a fetched value through the transit secrets engine configured
+// on the store. Encrypt returns the ciphertext, e.g. "vault:v1:...", which consumers
+// decrypt with the same key, Decrypt expects the value to be such a ciphertext.
+func (c *client) applyTransit(ctx context.Context, value []byte) ([]byte, error) {
+	transit := c.store.Transit
+	if transit == nil || value == nil {
+		return value, nil
+	}
+	mount := strings.Trim(transit.Path, "/")
+	if mount == "" {
+		mount = defaultTransitPath
+	}
+	data := make(map[string]any)
+	if transit.Context != "" {
+		data["context"] = transit.Context
+	}
+
+	if transit.Operation == esv1beta1.VaultTransitDecrypt {
+		data["ciphertext"] = string(value)
+		secret, err := c.logical.WriteWithContext(ctx, fmt.Sprintf("%s/decrypt/%s", mount, transit.Key), data)
+		metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultTransitDecrypt, err)
+		if err != nil {
+			return nil, fmt.Errorf(errTransitDecrypt, transit.Key, err)
+		}
+		plaintext, err := transitField(secret, "plaintext")
+		if err != nil {
+			return nil, fmt.Errorf(errTransitDecrypt, transit.Key, err)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(plaintext)
+		if err != nil {
+			return nil, fmt.Errorf(errTransitPlaintext, err)
+		}
+		return decoded, nil
+	}
+
+	data["plaintext"] = base64.StdEncoding.EncodeToString(value)
+	secret, err := c.logical.WriteWithContext(ctx, fmt.Sprintf("%s/encrypt/%s", mount, transit.Key), data)
+	metrics.ObserveAPICall(constants.ProviderHCVault, constants.CallHCVaultTransitEncrypt, err)
+	if err != nil {
+		return nil, fmt.Errorf(errTransitEncrypt, transit.Key, err)
+	}
+	ciphertext, err := transitField(secret, "ciphertext")
+	if err != nil {
+		return nil, fmt.Errorf(errTransitEncrypt, transit.Key, err)
+	}
+	return []byte(ciphertext), nil
+}
+
+func transitField(secret *vault.Secret, field string) (string, error) {
+	if secret == nil {
+		return "", fmt.Errorf(errTransitNoField, field)
+	}
+	value, ok := secret.Data[field].(string)
+	if !ok {
+		return "", fmt.Errorf(errTransitNoField, field)
+	}
+	return value, nil
+}
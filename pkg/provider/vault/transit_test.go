@@ -0,0 +1,119 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	vault "github.com/hashicorp/vault/api"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+)
+
+const fakeCiphertextPrefix = "vault:v1:"
+
+// fakeTransit mimics the transit engine mounted at "transit" with a key "app",
+// the ciphertext is the prefixed plaintext.
+func fakeTransit(_ context.Context, path string, data map[string]any) (*vault.Secret, error) {
+	switch path {
+	case "transit/encrypt/app":
+		return &vault.Secret{Data: map[string]any{"ciphertext": fakeCiphertextPrefix + data["plaintext"].(string)}}, nil
+	case "transit/decrypt/app":
+		ciphertext := data["ciphertext"].(string)
+		if !strings.HasPrefix(ciphertext, fakeCiphertextPrefix) {
+			return nil, errors.New("invalid ciphertext")
+		}
+		return &vault.Secret{Data: map[string]any{"plaintext": strings.TrimPrefix(ciphertext, fakeCiphertextPrefix)}}, nil
+	}
+	return nil, errors.New("unexpected path " + path)
+}
+
+func TestTransit(t *testing.T) {
+	encrypted := fakeCiphertextPrefix + base64.StdEncoding.EncodeToString([]byte("secret"))
+	tests := []struct {
+		name    string
+		transit *esv1beta1.VaultTransit
+		data    map[string]any
+		want    map[string][]byte
+		wantErr string
+	}{
+		{
+			name:    "encrypt",
+			transit: &esv1beta1.VaultTransit{Path: "transit", Key: "app", Operation: esv1beta1.VaultTransitEncrypt},
+			data:    map[string]any{"password": "secret"},
+			want:    map[string][]byte{"password": []byte(encrypted)},
+		},
+		{
+			name:    "decrypt",
+			transit: &esv1beta1.VaultTransit{Key: "app", Operation: esv1beta1.VaultTransitDecrypt},
+			data:    map[string]any{"password": encrypted},
+			want:    map[string][]byte{"password": []byte("secret")},
+		},
+		{
+			name:    "decrypt plaintext",
+			transit: &esv1beta1.VaultTransit{Key: "app", Operation: esv1beta1.VaultTransitDecrypt},
+			data:    map[string]any{"password": "secret"},
+			wantErr: "cannot decrypt value with transit key app: invalid ciphertext",
+		},
+		{
+			name:    "unknown key",
+			transit: &esv1beta1.VaultTransit{Key: "db"},
+			data:    map[string]any{"password": "secret"},
+			wantErr: "cannot encrypt value with transit key db: unexpected path transit/encrypt/db",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := makeValidSecretStoreWithVersion(esv1beta1.VaultKVStoreV2).Spec.Provider.Vault
+			store.Transit = tc.transit
+			vStore := &client{
+				store: store,
+				logical: &fake.Logical{
+					ReadWithDataWithContextFn: fake.NewReadWithContextFn(map[string]any{"data": tc.data}, nil),
+					WriteWithContextFn:        fakeTransit,
+				},
+			}
+			ref := esv1beta1.ExternalSecretDataRemoteRef{Key: "app", Property: "password"}
+			value, err := vStore.GetSecret(context.Background(), ref)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("vault.GetSecret(...): error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("vault.GetSecret(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want["password"], value); diff != "" {
+				t.Errorf("vault.GetSecret(...): -want, +got:\n%s", diff)
+			}
+
+			ref.Property = ""
+			values, err := vStore.GetSecretMap(context.Background(), ref)
+			if err != nil {
+				t.Fatalf("vault.GetSecretMap(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, values); diff != "" {
+				t.Errorf("vault.GetSecretMap(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
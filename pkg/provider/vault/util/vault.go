@@ -39,6 +39,10 @@ type Logical interface {
 	DeleteWithContext(ctx context.Context, path string) (*vault.Secret, error)
 }
 
+type Sys interface {
+	SealStatusWithContext(ctx context.Context) (*vault.SealStatusResponse, error)
+}
+
 type Client interface {
 	SetToken(v string)
 	Token() string
@@ -46,6 +50,7 @@ type Client interface {
 	Auth() Auth
 	Logical() Logical
 	AuthToken() Token
+	Sys() Sys
 	Namespace() string
 	SetNamespace(namespace string)
 	AddHeader(key, value string)
@@ -58,6 +63,7 @@ type VaultClient struct {
 	AuthField        Auth
 	LogicalField     Logical
 	AuthTokenField   Token
+	SysField         Sys
 	NamespaceFunc    func() string
 	SetNamespaceFunc func(namespace string)
 	AddHeaderFunc    func(key, value string)
@@ -98,3 +104,7 @@ func (v VaultClient) AuthToken() Token {
 func (v VaultClient) Logical() Logical {
 	return v.LogicalField
 }
+
+func (v VaultClient) Sys() Sys {
+	return v.SysField
+}
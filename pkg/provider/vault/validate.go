@@ -45,6 +45,10 @@ const (
 	errInvalidClientTLSCert   = "invalid ClientTLS.ClientCert: %w"
 	errInvalidClientTLSSecret = "invalid ClientTLS.SecretRef: %w"
 	errInvalidClientTLS       = "when provided, both ClientTLS.ClientCert and ClientTLS.SecretRef should be provided"
+	errSealStatus             = "could not get vault seal status: %w"
+
+	warnDeprecatedAudiences  = "auth.jwt.kubernetesServiceAccountToken.audiences is deprecated, use auth.jwt.kubernetesServiceAccountToken.serviceAccountRef.audiences instead"
+	warnDeprecatedExpiration = "auth.jwt.kubernetesServiceAccountToken.expirationSeconds is deprecated and will be removed in a future release"
 )
 
 func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
@@ -160,7 +164,22 @@ func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnin
 	} else if vaultProvider.ClientTLS.CertSecretRef != nil || vaultProvider.ClientTLS.KeySecretRef != nil {
 		return nil, errors.New(errInvalidClientTLS)
 	}
-	return nil, nil
+	return deprecationWarnings(vaultProvider), nil
+}
+
+// deprecationWarnings returns a warning for every deprecated field set in the provider.
+func deprecationWarnings(vaultProvider *esv1beta1.VaultProvider) admission.Warnings {
+	var warnings admission.Warnings
+	if vaultProvider.Auth.Jwt != nil && vaultProvider.Auth.Jwt.KubernetesServiceAccountToken != nil {
+		saToken := vaultProvider.Auth.Jwt.KubernetesServiceAccountToken
+		if saToken.Audiences != nil {
+			warnings = append(warnings, warnDeprecatedAudiences)
+		}
+		if saToken.ExpirationSeconds != nil {
+			warnings = append(warnings, warnDeprecatedExpiration)
+		}
+	}
+	return warnings
 }
 
 func (c *client) Validate() (esv1beta1.ValidationResult, error) {
@@ -176,3 +195,13 @@ func (c *client) Validate() (esv1beta1.ValidationResult, error) {
 	}
 	return esv1beta1.ValidationResultReady, nil
 }
+
+// ProviderVersion returns the version of the Vault server.
+// The seal status endpoint does not require authentication.
+func (c *client) ProviderVersion(ctx context.Context) (string, error) {
+	status, err := c.client.Sys().SealStatusWithContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf(errSealStatus, err)
+	}
+	return status.Version, nil
+}
@@ -14,12 +14,17 @@ limitations under the License.
 package vault
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"testing"
 
 	pointer "k8s.io/utils/ptr"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/vault/util"
 )
 
 const fakeValidationValue = "fake-value"
@@ -31,14 +36,32 @@ func TestValidateStore(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
+		name         string
+		args         args
+		wantErr      bool
+		wantWarnings []string
 	}{
 		{
 			name: "empty auth",
 			args: args{},
 		},
+		{
+			name: "deprecated kubernetes service account token fields",
+			args: args{
+				auth: esv1beta1.VaultAuth{
+					Jwt: &esv1beta1.VaultJwtAuth{
+						KubernetesServiceAccountToken: &esv1beta1.VaultKubernetesServiceAccountTokenAuth{
+							ServiceAccountRef: esmeta.ServiceAccountSelector{
+								Name: fakeValidationValue,
+							},
+							Audiences:         &[]string{"vault"},
+							ExpirationSeconds: pointer.To(int64(600)),
+						},
+					},
+				},
+			},
+			wantWarnings: []string{warnDeprecatedAudiences, warnDeprecatedExpiration},
+		},
 
 		{
 			name: "invalid approle with namespace",
@@ -264,9 +287,47 @@ func TestValidateStore(t *testing.T) {
 					},
 				},
 			}
-			if _, err := c.ValidateStore(store); (err != nil) != tt.wantErr {
+			warnings, err := c.ValidateStore(store)
+			if (err != nil) != tt.wantErr {
 				t.Errorf("connector.ValidateStore() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if len(warnings) != len(tt.wantWarnings) || (len(warnings) > 0 && !reflect.DeepEqual([]string(warnings), tt.wantWarnings)) {
+				t.Errorf("connector.ValidateStore() warnings = %v, want %v", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestProviderVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		sys     fake.Sys
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "version from seal status",
+			sys:  fake.Sys{SealStatusWithContextFn: fake.NewSealStatusWithContextFn("1.15.4", nil)},
+			want: "1.15.4",
+		},
+		{
+			name:    "seal status error",
+			sys:     fake.Sys{SealStatusWithContextFn: fake.NewSealStatusWithContextFn("", errors.New("boom"))},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client{
+				client: &util.VaultClient{SysField: tt.sys},
+			}
+			got, err := c.ProviderVersion(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("client.ProviderVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("client.ProviderVersion() = %q, want %q", got, tt.want)
+			}
 		})
 	}
 }
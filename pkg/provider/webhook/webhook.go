@@ -15,10 +15,11 @@ limitations under the License.
 package webhook
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"io"
 	"time"
 
 	"github.com/PaesslerAG/jsonpath"
@@ -28,16 +29,20 @@ import (
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
 	"github.com/external-secrets/external-secrets/pkg/common/webhook"
+	"github.com/external-secrets/external-secrets/pkg/find"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 const (
 	errNotImplemented = "not implemented"
+	errFindTags       = "find by tags is not supported"
 )
 
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &WebHook{}
+var _ esv1beta1.SecretStreamer = &WebHook{}
 var _ esv1beta1.Provider = &Provider{}
+var _ esv1beta1.RemoteRefFeatureValidator = &Provider{}
 
 // Provider satisfies the provider interface.
 type Provider struct{}
@@ -60,6 +65,13 @@ func (p *Provider) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadOnly
 }
 
+// UnsupportedRemoteRefFeatures returns the remote ref features the webhook rejects.
+func (p *Provider) UnsupportedRemoteRefFeatures(_ esv1beta1.GenericStore) []esv1beta1.RemoteRefFeature {
+	return []esv1beta1.RemoteRefFeature{
+		esv1beta1.RemoteRefFeatureFindTags,
+	}
+}
+
 func (p *Provider) NewClient(_ context.Context, store esv1beta1.GenericStore, kube client.Client, namespace string) (esv1beta1.SecretsClient, error) {
 	wh := webhook.Webhook{
 		Kube:      kube,
@@ -73,6 +85,11 @@ func (p *Provider) NewClient(_ context.Context, store esv1beta1.GenericStore, ku
 	if whClient.storeKind == esv1beta1.ClusterSecretStoreKind {
 		whClient.wh.ClusterScoped = true
 	}
+	whClient.wh.Store = &webhook.StoreRef{
+		Kind:      whClient.storeKind,
+		Namespace: store.GetNamespace(),
+		Name:      store.GetName(),
+	}
 	provider, err := getProvider(store)
 	if err != nil {
 		return nil, err
@@ -86,7 +103,42 @@ func (p *Provider) NewClient(_ context.Context, store esv1beta1.GenericStore, ku
 	return whClient, nil
 }
 
-func (p *Provider) ValidateStore(_ esv1beta1.GenericStore) (admission.Warnings, error) {
+func (p *Provider) ValidateStore(store esv1beta1.GenericStore) (admission.Warnings, error) {
+	spc := store.GetSpec()
+	if spc == nil || spc.Provider == nil || spc.Provider.Webhook == nil {
+		return nil, nil
+	}
+	result := spc.Provider.Webhook.Result
+	if result.Format == esv1beta1.WebhookResultFormatRaw && result.JSONPath != "" {
+		return nil, fmt.Errorf("result.jsonPath can not be used with result.format raw")
+	}
+	if result.Format == esv1beta1.WebhookResultFormatRaw && len(result.Keys) > 0 {
+		return nil, fmt.Errorf("result.keys can not be used with result.format raw")
+	}
+	for key, path := range result.Keys {
+		if path == "" {
+			return nil, fmt.Errorf("result.keys.%s: jsonPath is required", key)
+		}
+	}
+	if spc.Provider.Webhook.Pagination != nil && result.Format == esv1beta1.WebhookResultFormatRaw {
+		return nil, fmt.Errorf("pagination can not be used with result.format raw")
+	}
+	if check := spc.Provider.Webhook.HealthCheck; check != nil && check.Path == "" {
+		return nil, fmt.Errorf("healthCheck.path is required")
+	}
+	auth := spc.Provider.Webhook.Auth
+	if auth == nil || auth.PrivateKeyJWT == nil {
+		return nil, nil
+	}
+	if err := utils.ValidateSecretSelector(store, auth.PrivateKeyJWT.PrivateKeySecretRef); err != nil {
+		return nil, fmt.Errorf("invalid privateKeySecretRef: %w", err)
+	}
+	if auth.PrivateKeyJWT.PrivateKeySecretRef.Name == "" || auth.PrivateKeyJWT.PrivateKeySecretRef.Key == "" {
+		return nil, fmt.Errorf("privateKeySecretRef.name and privateKeySecretRef.key are required")
+	}
+	if auth.PrivateKeyJWT.Issuer == "" || auth.PrivateKeyJWT.Audience == "" {
+		return nil, fmt.Errorf("privateKeyJWT requires issuer and audience")
+	}
 	return nil, nil
 }
 
@@ -117,10 +169,37 @@ func (w *WebHook) PushSecret(_ context.Context, _ *corev1.Secret, _ esv1beta1.Pu
 	return fmt.Errorf(errNotImplemented)
 }
 
-// Empty GetAllSecrets.
-func (w *WebHook) GetAllSecrets(_ context.Context, _ esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	// TO be implemented
-	return nil, fmt.Errorf(errNotImplemented)
+// GetAllSecrets calls the webhook with find.path as remoteRef.key and follows the pagination of the response.
+// Only the name operator is supported to filter the merged secret map.
+func (w *WebHook) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 {
+		return nil, fmt.Errorf(errFindTags)
+	}
+	provider, err := getProvider(w.store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store: %w", err)
+	}
+	remoteRef := esv1beta1.ExternalSecretDataRemoteRef{}
+	if ref.Path != nil {
+		remoteRef.Key = *ref.Path
+	}
+	secrets, err := w.wh.GetPaginatedSecretMap(ctx, provider, &remoteRef)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Name == nil {
+		return secrets, nil
+	}
+	matcher, err := find.New(*ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	for key := range secrets {
+		if !matcher.MatchName(key) {
+			delete(secrets, key)
+		}
+	}
+	return secrets, nil
 }
 
 func (w *WebHook) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
@@ -128,11 +207,15 @@ func (w *WebHook) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDat
 	if err != nil {
 		return nil, fmt.Errorf("failed to get store: %w", err)
 	}
-	result, err := w.wh.GetWebhookData(ctx, provider, &ref)
+	resp, err := w.wh.GetWebhookResponse(ctx, provider, &ref)
 	if err != nil {
 		return nil, err
 	}
-	// Only parse as json if we have a jsonpath set
+	format := resp.Format(provider.Result)
+	if format == webhook.ResultFormatRaw {
+		return resp.Body, nil
+	}
+	// Only parse the response if we have a jsonpath set
 	data, err := w.wh.GetTemplateData(ctx, &ref, provider.Secrets)
 	if err != nil {
 		return nil, err
@@ -142,53 +225,34 @@ func (w *WebHook) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDat
 		return nil, err
 	}
 	if resultJSONPath != "" {
-		jsondata := any(nil)
-		if err := json.Unmarshal(result, &jsondata); err != nil {
-			return nil, fmt.Errorf("failed to parse response json: %w", err)
+		jsondata, err := resp.Parse(format)
+		if err != nil {
+			return nil, err
 		}
 		jsondata, err = jsonpath.Get(resultJSONPath, jsondata)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get response path %s: %w", resultJSONPath, err)
 		}
-		return extractSecretData(jsondata)
-	}
-
-	return result, nil
-}
-
-// tries to extract data from an any
-// it is supposed to return a single value.
-func extractSecretData(jsondata any) ([]byte, error) {
-	switch val := jsondata.(type) {
-	case bool:
-		return []byte(strconv.FormatBool(val)), nil
-	case nil:
-		return []byte{}, nil
-	case int:
-		return []byte(strconv.Itoa(val)), nil
-	case float64:
-		return []byte(strconv.FormatFloat(val, 'f', 0, 64)), nil
-	case []byte:
-		return val, nil
-	case string:
-		return []byte(val), nil
-
-	// due to backwards compatibility we must keep this!
-	// in case we see a []something we pick the first element and return it
-	case []any:
-		if len(val) == 0 {
-			return nil, fmt.Errorf("filter worked but didn't get any result")
-		}
-		return extractSecretData(val[0])
+		return webhook.ExtractSecretData(jsondata)
+	}
 
-	// in case we encounter a map we serialize it instead of erroring out
-	// The user should use that data from within a template and figure
-	// out how to deal with it.
-	case map[string]any:
-		return json.Marshal(val)
-	default:
-		return nil, fmt.Errorf("failed to get response (wrong type: %T)", jsondata)
+	return resp.Body, nil
+}
+
+// GetSecretStream streams the response of the webhook, as long as no jsonPath selects a part of it.
+func (w *WebHook) GetSecretStream(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (io.ReadCloser, error) {
+	provider, err := getProvider(w.store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store: %w", err)
+	}
+	if provider.Result.Format != webhook.ResultFormatRaw && provider.Result.JSONPath != "" {
+		secret, err := w.GetSecret(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(secret)), nil
 	}
+	return w.wh.GetWebhookStream(ctx, provider, &ref)
 }
 
 func (w *WebHook) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
@@ -204,6 +268,17 @@ func (w *WebHook) Close(_ context.Context) error {
 }
 
 func (w *WebHook) Validate() (esv1beta1.ValidationResult, error) {
+	provider, err := getProvider(w.store)
+	if err != nil {
+		return esv1beta1.ValidationResultError, err
+	}
+	if provider.HealthCheck != nil {
+		if err := w.wh.CheckHealth(context.Background(), provider); err != nil {
+			return esv1beta1.ValidationResultError, err
+		}
+		return esv1beta1.ValidationResultReady, nil
+	}
+
 	timeout := 15 * time.Second
 	url := w.url
 
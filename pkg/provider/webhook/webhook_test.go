@@ -17,18 +17,29 @@ package webhook
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 )
 
 type testCase struct {
@@ -47,6 +58,12 @@ type args struct {
 	JSONPath   string `json:"jsonpath,omitempty"`
 	Response   string `json:"response,omitempty"`
 	StatusCode int    `json:"statuscode,omitempty"`
+	// ContentType of the response
+	ContentType      string `json:"contenttype,omitempty"`
+	Format           string `json:"format,omitempty"`
+	MaxResponseBytes int64  `json:"maxresponsebytes,omitempty"`
+	// Keys of the secret map and their json paths
+	Keys map[string]string `json:"keys,omitempty"`
 }
 
 type want struct {
@@ -193,6 +210,38 @@ want:
     thesecret: secret-value
     alsosecret: another-value
 ---
+case: good json map keys
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}&version={{ .remoteRef.version }}
+  key: testkey
+  version: 1
+  jsonpath: $.ignored
+  keys:
+    username: $.data.credentials.user
+    port: $.data.port
+    tls: $.data.tls
+  response: '{"data":{"credentials":{"user":"admin","password":"secret"},"port":5432,"tls":{"enabled":true}}}'
+want:
+  path: /api/getsecret?id=testkey&version=1
+  err: ''
+  resultmap:
+    username: admin
+    port: "5432"
+    tls: '{"enabled":true}'
+---
+case: error json map keys
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}&version={{ .remoteRef.version }}
+  key: testkey
+  version: 1
+  keys:
+    username: $.data.missing
+  response: '{"data":{"user":"admin"}}'
+want:
+  path: /api/getsecret?id=testkey&version=1
+  err: failed to get key username from response path $.data.missing
+  resultmap: {}
+---
 case: error json map string
 args:
   url: /api/getsecret?id={{ .remoteRef.key }}&version={{ .remoteRef.version }}
@@ -299,6 +348,74 @@ want:
   path: /api/getsecret?id=testkey&version=1
   err: ''
   result: "RE/DACTED=="
+---
+case: raw format returns the body as is
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}
+  key: testkey
+  format: raw
+  contenttype: application/pkix-cert
+  response: "0\x82\x01\n"
+want:
+  path: /api/getsecret?id=testkey
+  err: ''
+  result: "0\x82\x01\n"
+---
+case: error raw format with dataFrom
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}
+  key: testkey
+  format: raw
+  response: '{"key":"value"}'
+want:
+  path: /api/getsecret?id=testkey
+  err: result format raw can not be used to get a map of secret values
+  resultmap: {}
+---
+case: yaml format with jsonpath
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}
+  key: testkey
+  format: yaml
+  jsonpath: $.result.thesecret
+  response: "result:\n  thesecret: secret-value\n"
+want:
+  path: /api/getsecret?id=testkey
+  err: ''
+  result: secret-value
+---
+case: yaml content type with dataFrom
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}
+  key: testkey
+  contenttype: application/yaml; charset=utf-8
+  response: "key: value\n"
+want:
+  path: /api/getsecret?id=testkey
+  err: ''
+  resultmap:
+    key: value
+---
+case: error response exceeds maxResponseBytes
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}
+  key: testkey
+  maxresponsebytes: 4
+  response: secret-value
+want:
+  path: /api/getsecret?id=testkey
+  err: response exceeds maxResponseBytes of 4 bytes
+---
+case: response within maxResponseBytes
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}
+  key: testkey
+  maxresponsebytes: 12
+  response: secret-value
+want:
+  path: /api/getsecret?id=testkey
+  err: ''
+  result: secret-value
 `
 
 func TestWebhookGetSecret(t *testing.T) {
@@ -321,6 +438,9 @@ func testCaseServer(tc testCase, t *testing.T) *httptest.Server {
 		if tc.Want.Path != "" && req.URL.String() != tc.Want.Path {
 			t.Errorf("%s: unexpected api path: %s, expected %s", tc.Case, req.URL.String(), tc.Want.Path)
 		}
+		if tc.Args.ContentType != "" {
+			rw.Header().Set("Content-Type", tc.Args.ContentType)
+		}
 		if tc.Args.StatusCode != 0 {
 			rw.WriteHeader(tc.Args.StatusCode)
 		}
@@ -411,6 +531,87 @@ func testGetSecret(tc testCase, t *testing.T, client esv1beta1.SecretsClient) {
 	}
 }
 
+func TestWebhookValidateStoreResultFormat(t *testing.T) {
+	store := makeClusterSecretStore("http://example.com", args{JSONPath: "$.value", Format: "raw"})
+	if _, err := (&Provider{}).ValidateStore(store); err == nil {
+		t.Errorf("expected an error for jsonPath with raw format")
+	}
+	store.Spec.Provider.Webhook.Result.JSONPath = ""
+	if _, err := (&Provider{}).ValidateStore(store); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	store.Spec.Provider.Webhook.Result.Keys = map[string]string{"username": "$.user"}
+	if _, err := (&Provider{}).ValidateStore(store); err == nil {
+		t.Errorf("expected an error for keys with raw format")
+	}
+	store.Spec.Provider.Webhook.Result.Format = ""
+	store.Spec.Provider.Webhook.Result.Keys = map[string]string{"username": ""}
+	if _, err := (&Provider{}).ValidateStore(store); err == nil {
+		t.Errorf("expected an error for a key without jsonPath")
+	}
+}
+
+func TestWebhookPrivateKeyJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		bearer := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		claims := jwt.RegisteredClaims{}
+		token, err := jwt.ParseWithClaims(bearer, &claims, func(*jwt.Token) (any, error) {
+			return &key.PublicKey, nil
+		}, jwt.WithAudience("https://api.example.com"), jwt.WithIssuer("client-id"), jwt.WithExpirationRequired())
+		if err != nil {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if token.Header["kid"] != "key-1" || claims.Subject != "client-id" || claims.ID == "" {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		rw.Write([]byte(req.Header.Get("X-Assertion-Matches")))
+	}))
+	defer ts.Close()
+
+	namespace := "default"
+	store := makeClusterSecretStore(ts.URL, args{URL: "/api/getsecret"})
+	store.Spec.Provider.Webhook.Headers["X-Assertion-Matches"] = `{{ if .auth.jwt }}true{{ end }}`
+	store.Spec.Provider.Webhook.Auth = &esv1beta1.WebhookAuth{
+		PrivateKeyJWT: &esv1beta1.WebhookPrivateKeyJWT{
+			PrivateKeySecretRef: esmeta.SecretKeySelector{
+				Name:      "client-key",
+				Key:       "key.pem",
+				Namespace: &namespace,
+			},
+			Issuer:   "client-id",
+			Audience: "https://api.example.com",
+			KeyID:    "key-1",
+		},
+	}
+	if _, err := (&Provider{}).ValidateStore(store); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-key", Namespace: namespace},
+		Data:       map[string][]byte{"key.pem": keyPEM},
+	}).Build()
+	client, err := (&Provider{}).NewClient(context.Background(), store, kube, namespace)
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	secret, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(secret) != "true" {
+		t.Errorf("unexpected response: %q", secret)
+	}
+}
+
 func makeClusterSecretStore(url string, args args) *esv1beta1.ClusterSecretStore {
 	store := &esv1beta1.ClusterSecretStore{
 		TypeMeta: metav1.TypeMeta{
@@ -431,10 +632,305 @@ func makeClusterSecretStore(url string, args args) *esv1beta1.ClusterSecretStore
 					},
 					Result: esv1beta1.WebhookResult{
 						JSONPath: args.JSONPath,
+						Format:   esv1beta1.WebhookResultFormat(args.Format),
+						Keys:     args.Keys,
 					},
 				},
 			},
 		},
 	}
+	if args.MaxResponseBytes != 0 {
+		store.Spec.Provider.Webhook.MaxResponseBytes = &args.MaxResponseBytes
+	}
 	return store
 }
+
+func TestWebhookValidateHealthCheck(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/healthz" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	namespace := "default"
+	kube := clientfake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: namespace},
+		Data:       map[string][]byte{"token": []byte("token")},
+	}).Build()
+
+	tests := []struct {
+		name   string
+		check  esv1beta1.WebhookHealthCheck
+		header string
+		want   esv1beta1.ValidationResult
+		err    string
+	}{
+		{
+			name:   "healthy",
+			check:  esv1beta1.WebhookHealthCheck{Path: "/healthz"},
+			header: "Bearer {{ .creds.token }}",
+			want:   esv1beta1.ValidationResultReady,
+		},
+		{
+			name:   "unauthorized",
+			check:  esv1beta1.WebhookHealthCheck{Path: "/healthz"},
+			header: "Bearer invalid",
+			want:   esv1beta1.ValidationResultError,
+			err:    "health check failed with status 401 Unauthorized",
+		},
+		{
+			name:   "unexpected status",
+			check:  esv1beta1.WebhookHealthCheck{Path: "/healthz", ExpectedStatus: http.StatusOK},
+			header: "Bearer {{ .creds.token }}",
+			want:   esv1beta1.ValidationResultError,
+			err:    "health check failed with status 204 No Content",
+		},
+		{
+			name:   "expected status",
+			check:  esv1beta1.WebhookHealthCheck{Path: "/unknown", ExpectedStatus: http.StatusNotFound},
+			header: "Bearer {{ .creds.token }}",
+			want:   esv1beta1.ValidationResultReady,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := makeClusterSecretStore(ts.URL, args{URL: "/api/getsecret?id={{ .remoteRef.key }}"})
+			store.Spec.Provider.Webhook.Headers["Authorization"] = tt.header
+			store.Spec.Provider.Webhook.Secrets = []esv1beta1.WebhookSecret{{
+				Name:      "creds",
+				SecretRef: esmeta.SecretKeySelector{Name: "credentials", Namespace: &namespace},
+			}}
+			check := tt.check
+			store.Spec.Provider.Webhook.HealthCheck = &check
+			if _, err := (&Provider{}).ValidateStore(store); err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+			client, err := (&Provider{}).NewClient(context.Background(), store, kube, namespace)
+			if err != nil {
+				t.Fatalf("error creating client: %v", err)
+			}
+			got, err := client.Validate()
+			if got != tt.want {
+				t.Errorf("unexpected result: %v, err: %v", got, err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("expected error %q, got %v", tt.err, err)
+			}
+		})
+	}
+
+	store := makeClusterSecretStore(ts.URL, args{})
+	store.Spec.Provider.Webhook.HealthCheck = &esv1beta1.WebhookHealthCheck{}
+	if _, err := (&Provider{}).ValidateStore(store); err == nil {
+		t.Errorf("expected an error for a health check without path")
+	}
+}
+
+func TestWebhookGetAllSecretsPagination(t *testing.T) {
+	pages := map[string]string{
+		"":  `{"secrets": {"db-user": "admin", "db-password": "s3cr3t"}, "next": "b"}`,
+		"b": `{"secrets": {"db-host": "localhost", "api-token": "token"}, "next": "c"}`,
+		"c": `{"secrets": {"db-port": "5432"}}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("prefix") != "db" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		page := req.URL.Query().Get("page")
+		if next := map[string]string{"": "b", "b": "c"}[page]; next != "" {
+			rw.Header().Add("Link", `<https://other.example.com/prev>; rel="prev", </list?prefix=db&page=`+next+`>; rel="next"`)
+		}
+		rw.Write([]byte(pages[page]))
+	}))
+	defer ts.Close()
+
+	name := "^db-"
+	path := "db"
+	want := map[string]string{"db-user": "admin", "db-password": "s3cr3t", "db-host": "localhost", "db-port": "5432"}
+	tests := []struct {
+		name       string
+		url        string
+		pagination *esv1beta1.WebhookPagination
+		want       map[string]string
+		err        string
+	}{
+		{
+			name: "link header",
+			url:  "/list?prefix={{ .remoteRef.key }}",
+			want: want,
+		},
+		{
+			name:       "cursor",
+			url:        "/list?prefix={{ .remoteRef.key }}&page={{ .pagination.cursor }}",
+			pagination: &esv1beta1.WebhookPagination{CursorJSONPath: "$.next"},
+			want:       want,
+		},
+		{
+			name:       "max pages",
+			url:        "/list?prefix={{ .remoteRef.key }}",
+			pagination: &esv1beta1.WebhookPagination{MaxPages: 2},
+			err:        "response has more than maxPages of 2 pages",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := makeClusterSecretStore(ts.URL, args{URL: tt.url, JSONPath: "$.secrets"})
+			store.Spec.Provider.Webhook.Pagination = tt.pagination
+			client, err := (&Provider{}).NewClient(context.Background(), store, nil, "default")
+			if err != nil {
+				t.Fatalf("error creating client: %v", err)
+			}
+			secrets, err := client.GetAllSecrets(context.Background(), esv1beta1.ExternalSecretFind{
+				Path: &path,
+				Name: &esv1beta1.FindName{RegExp: name},
+			})
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make(map[string]string, len(secrets))
+			for k, v := range secrets {
+				got[k] = string(v)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unexpected secrets: %v (expected %v)", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookGetSecretStream(t *testing.T) {
+	blob := bytes.Repeat([]byte{0x00, 0xff}, 4096)
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/blob":
+			rw.Header().Set("Content-Type", "application/octet-stream")
+			rw.Write(blob)
+		case "/json":
+			rw.Write([]byte(`{"value": "s3cr3t"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name string
+		args args
+		want []byte
+		err  string
+	}{
+		{
+			name: "raw body",
+			args: args{URL: "/blob"},
+			want: blob,
+		},
+		{
+			name: "json path",
+			args: args{URL: "/json", JSONPath: "$.value"},
+			want: []byte("s3cr3t"),
+		},
+		{
+			name: "max response bytes",
+			args: args{URL: "/blob", MaxResponseBytes: 1024},
+			err:  "response exceeds maxResponseBytes of 1024 bytes",
+		},
+		{
+			name: "not found",
+			args: args{URL: "/missing"},
+			err:  esv1beta1.NoSecretErr.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := (&Provider{}).NewClient(context.Background(), makeClusterSecretStore(ts.URL, tt.args), nil, "default")
+			if err != nil {
+				t.Fatalf("error creating client: %v", err)
+			}
+			got, err := readStream(client.(esv1beta1.SecretStreamer), esv1beta1.ExternalSecretDataRemoteRef{Key: "blob"})
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("unexpected value of %d bytes, want %d bytes", len(got), len(tt.want))
+			}
+		})
+	}
+}
+
+func readStream(streamer esv1beta1.SecretStreamer, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	stream, err := streamer.GetSecretStream(context.Background(), ref)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	return io.ReadAll(stream)
+}
+
+func TestWebhookConnectionPool(t *testing.T) {
+	var conns atomic.Int32
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"value": "s3cr3t"}`))
+	}))
+	ts.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	store := makeClusterSecretStore(ts.URL, args{JSONPath: "$.value"})
+	store.Name = "pooled-store"
+	getSecret := func() {
+		t.Helper()
+		client, err := (&Provider{}).NewClient(context.Background(), store, nil, "default")
+		if err != nil {
+			t.Fatalf("error creating client: %v", err)
+		}
+		got, err := client.GetSecret(context.Background(), esv1beta1.ExternalSecretDataRemoteRef{Key: "key"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != "s3cr3t" {
+			t.Fatalf("unexpected value %q", got)
+		}
+	}
+
+	// the clients of a store reuse its connections
+	getSecret()
+	getSecret()
+	if n := conns.Load(); n != 1 {
+		t.Errorf("opened %d connections, want 1", n)
+	}
+
+	// changed settings replace the connection pool of the store
+	store.Spec.Provider.Webhook.ConnectionPool = &esv1beta1.WebhookConnectionPool{
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     &metav1.Duration{Duration: time.Minute},
+	}
+	getSecret()
+	getSecret()
+	if n := conns.Load(); n != 2 {
+		t.Errorf("opened %d connections, want 2", n)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package seal encrypts the values of target Secrets with a public key,
+// so they can only be read by workloads holding the private key.
+// Values are sealed as JWE compact serialization (RFC 7516), which can also be
+// decrypted with a KMS that holds the private key, e.g. an asymmetric decryption key.
+package seal
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+const (
+	// Format is the format of sealed values.
+	Format = "JWE"
+
+	// ContentEncryption is the content encryption of sealed values.
+	ContentEncryption = jose.A256GCM
+
+	errNoPEM          = "no PEM data found"
+	errUnsupportedKey = "unsupported key type %T, only RSA and EC keys are supported"
+	errParseKey       = "unable to parse key: %w"
+	errSeal           = "unable to seal value: %w"
+	errOpen           = "unable to open sealed value: %w"
+)
+
+// keyAlgorithms are the key management algorithms of sealed values.
+var keyAlgorithms = []jose.KeyAlgorithm{jose.RSA_OAEP_256, jose.ECDH_ES_A256KW}
+
+// Sealer encrypts values with a public key.
+type Sealer struct {
+	encrypter jose.Encrypter
+}
+
+// NewSealer returns a Sealer for the PEM encoded RSA or EC public key.
+// The key id is set as kid header of the sealed values.
+func NewSealer(publicKeyPEM []byte, keyID string) (*Sealer, error) {
+	key, err := ParsePublicKey(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	alg := jose.RSA_OAEP_256
+	if _, ok := key.(*ecdsa.PublicKey); ok {
+		alg = jose.ECDH_ES_A256KW
+	}
+	encrypter, err := jose.NewEncrypter(ContentEncryption, jose.Recipient{Algorithm: alg, Key: key, KeyID: keyID}, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errParseKey, err)
+	}
+	return &Sealer{encrypter: encrypter}, nil
+}
+
+// Seal encrypts the value and returns its compact serialization.
+func (s *Sealer) Seal(value []byte) ([]byte, error) {
+	jwe, err := s.encrypter.Encrypt(value)
+	if err != nil {
+		return nil, fmt.Errorf(errSeal, err)
+	}
+	sealed, err := jwe.CompactSerialize()
+	if err != nil {
+		return nil, fmt.Errorf(errSeal, err)
+	}
+	return []byte(sealed), nil
+}
+
+// SealMap encrypts all values of the map.
+func (s *Sealer) SealMap(data map[string][]byte) (map[string][]byte, error) {
+	sealed := make(map[string][]byte, len(data))
+	for k, v := range data {
+		value, err := s.Seal(v)
+		if err != nil {
+			return nil, err
+		}
+		sealed[k] = value
+	}
+	return sealed, nil
+}
+
+// Open decrypts a sealed value with the RSA or EC private key.
+func Open(sealed []byte, key crypto.PrivateKey) ([]byte, error) {
+	jwe, err := jose.ParseEncrypted(string(sealed), keyAlgorithms, []jose.ContentEncryption{ContentEncryption})
+	if err != nil {
+		return nil, fmt.Errorf(errOpen, err)
+	}
+	value, err := jwe.Decrypt(key)
+	if err != nil {
+		return nil, fmt.Errorf(errOpen, err)
+	}
+	return value, nil
+}
+
+// ParsePublicKey parses a PEM encoded PKIX RSA or EC public key.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(errNoPEM)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf(errParseKey, err)
+	}
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf(errUnsupportedKey, key)
+	}
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS#8, PKCS#1 or SEC 1 RSA or EC private key.
+func ParsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(errNoPEM)
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errParseKey, err)
+	}
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf(errUnsupportedKey, key)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seal
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeKeys(t *testing.T, priv crypto.Signer) ([]byte, []byte) {
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(priv.Public())
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+}
+
+func TestSealOpen(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	for name, key := range map[string]crypto.Signer{"rsa": rsaKey, "ec": ecKey} {
+		t.Run(name, func(t *testing.T) {
+			pubPEM, privPEM := encodeKeys(t, key)
+			sealer, err := NewSealer(pubPEM, "key-1")
+			require.NoError(t, err)
+			sealed, err := sealer.SealMap(map[string][]byte{"password": []byte("s3cr3t")})
+			require.NoError(t, err)
+			assert.NotContains(t, string(sealed["password"]), "s3cr3t")
+			assert.Len(t, strings.Split(string(sealed["password"]), "."), 5)
+
+			priv, err := ParsePrivateKey(privPEM)
+			require.NoError(t, err)
+			value, err := Open(sealed["password"], priv)
+			require.NoError(t, err)
+			assert.Equal(t, "s3cr3t", string(value))
+
+			other, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err)
+			_, err = Open(sealed["password"], other)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	_, err := ParsePublicKey([]byte("not a key"))
+	assert.EqualError(t, err, errNoPEM)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pubPEM, privPEM := encodeKeys(t, edKey)
+	_, err = ParsePublicKey(pubPEM)
+	assert.EqualError(t, err, "unsupported key type ed25519.PublicKey, only RSA and EC keys are supported")
+	_, err = ParsePrivateKey(privPEM)
+	assert.EqualError(t, err, "unsupported key type ed25519.PrivateKey, only RSA and EC keys are supported")
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+	require.NoError(t, err)
+	assert.True(t, rsaKey.Equal(key))
+}
@@ -17,7 +17,9 @@ package utils
 import (
 	"bytes"
 	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,6 +33,8 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/huandu/xstrings"
+	"github.com/jmespath/go-jmespath"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -82,6 +86,12 @@ func RewriteMap(operations []esv1beta1.ExternalSecretRewrite, in map[string][]by
 				return nil, fmt.Errorf("failed rewriting transform operation[%v]: %w", i, err)
 			}
 		}
+		if op.Operation != nil {
+			out, err = RewriteOperation(*op.Operation, out)
+			if err != nil {
+				return nil, fmt.Errorf("failed rewriting operation[%v]: %w", i, err)
+			}
+		}
 	}
 	return out, nil
 }
@@ -119,6 +129,50 @@ func RewriteTransform(operation esv1beta1.ExternalSecretRewriteTransform, in map
 	return out, nil
 }
 
+// RewriteOperation applies a predefined operation on each secret key name to rewrite.
+func RewriteOperation(operation esv1beta1.ExternalSecretRewriteOperation, in map[string][]byte) (map[string][]byte, error) {
+	out := make(map[string][]byte)
+	for key, value := range in {
+		newKey, err := rewriteKey(operation, key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key, err)
+		}
+		out[newKey] = value
+	}
+	return out, nil
+}
+
+func rewriteKey(operation esv1beta1.ExternalSecretRewriteOperation, key string) (string, error) {
+	switch operation.Type {
+	case esv1beta1.RewriteOperationToUpper:
+		return strings.ToUpper(key), nil
+	case esv1beta1.RewriteOperationToLower:
+		return strings.ToLower(key), nil
+	case esv1beta1.RewriteOperationKebabCase:
+		return xstrings.ToKebabCase(key), nil
+	case esv1beta1.RewriteOperationSnakeCase:
+		return xstrings.ToSnakeCase(key), nil
+	case esv1beta1.RewriteOperationTrimPrefix:
+		return strings.TrimPrefix(key, operation.Value), nil
+	case esv1beta1.RewriteOperationTrimSuffix:
+		return strings.TrimSuffix(key, operation.Value), nil
+	case esv1beta1.RewriteOperationBase64Encode:
+		return base64.RawURLEncoding.EncodeToString([]byte(key)), nil
+	case esv1beta1.RewriteOperationBase64Decode:
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+			if decoded, err := enc.DecodeString(key); err == nil {
+				return string(decoded), nil
+			}
+		}
+		return "", errors.New("key is not base64 encoded")
+	case esv1beta1.RewriteOperationSHA256:
+		sum := sha256.Sum256([]byte(key))
+		return hex.EncodeToString(sum[:]), nil
+	default:
+		return "", fmt.Errorf("unknown rewrite operation %q", operation.Type)
+	}
+}
+
 func transform(val string, data map[string][]byte) ([]byte, error) {
 	strValData := make(map[string]string, len(data))
 	for k := range data {
@@ -186,6 +240,96 @@ func Decode(strategy esv1beta1.ExternalSecretDecodingStrategy, in []byte) ([]byt
 	}
 }
 
+// FilterMap evaluates the JMESPath expression against the map and returns the resulting object.
+// Values holding a JSON object or array are decoded before, so their fields can be queried.
+// String values of the result are used as is, all other values are encoded as JSON. Null values are dropped.
+func FilterMap(expression string, in map[string][]byte) (map[string][]byte, error) {
+	if expression == "" {
+		return in, nil
+	}
+	doc := make(map[string]any, len(in))
+	for k, v := range in {
+		trimmed := bytes.TrimSpace(v)
+		if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+			// keep numbers as they are, large integers would lose precision as float64
+			dec := json.NewDecoder(bytes.NewReader(trimmed))
+			dec.UseNumber()
+			var val any
+			if dec.Decode(&val) == nil {
+				doc[k] = val
+				continue
+			}
+		}
+		doc[k] = string(v)
+	}
+	result, err := jmespath.Search(expression, doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate filter path %q: %w", expression, err)
+	}
+	obj, ok := result.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("filter path %q must evaluate to an object, got %T", expression, result)
+	}
+	out := make(map[string][]byte, len(obj))
+	for k, v := range obj {
+		switch val := v.(type) {
+		case nil:
+			continue
+		case string:
+			out[k] = []byte(val)
+		default:
+			raw, err := json.Marshal(val)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode key %v: %w", k, err)
+			}
+			out[k] = raw
+		}
+	}
+	return out, nil
+}
+
+// NormalizeMap normalizes all values of the map, see Normalize.
+func NormalizeMap(normalization *esv1beta1.ExternalSecretNormalization, in map[string][]byte) (map[string][]byte, error) {
+	if normalization == nil {
+		return in, nil
+	}
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		val, err := Normalize(normalization, v)
+		if err != nil {
+			return nil, fmt.Errorf("failure normalizing key %v: %w", k, err)
+		}
+		out[k] = val
+	}
+	return out, nil
+}
+
+// Normalize converts the line endings of the value and trims it afterwards.
+func Normalize(normalization *esv1beta1.ExternalSecretNormalization, in []byte) ([]byte, error) {
+	if normalization == nil {
+		return in, nil
+	}
+	out := in
+	switch normalization.LineEndings {
+	case esv1beta1.ExternalSecretLineEndingsLF:
+		out = bytes.ReplaceAll(out, []byte("\r\n"), []byte("\n"))
+		out = bytes.ReplaceAll(out, []byte("\r"), []byte("\n"))
+	case esv1beta1.ExternalSecretLineEndingsPreserve, "":
+	default:
+		return nil, fmt.Errorf("line endings %v are not supported", normalization.LineEndings)
+	}
+	switch normalization.Trim {
+	case esv1beta1.ExternalSecretTrimTrailingNewline:
+		out = bytes.TrimRight(out, "\r\n")
+	case esv1beta1.ExternalSecretTrimWhitespace:
+		out = bytes.TrimSpace(out)
+	case esv1beta1.ExternalSecretTrimNone, "":
+	default:
+		return nil, fmt.Errorf("trim policy %v is not supported", normalization.Trim)
+	}
+	return out, nil
+}
+
 func ValidateKeys(in map[string][]byte) bool {
 	for key := range in {
 		for _, v := range key {
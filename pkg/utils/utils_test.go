@@ -310,6 +310,136 @@ func TestReverseKeys(t *testing.T) {
 	}
 }
 
+func TestNormalize(t *testing.T) {
+	in := map[string][]byte{
+		"conn": []byte("  Server=db;\r\nDatabase=app\r\n"),
+		"mac":  []byte("line1\rline2\n\n"),
+	}
+	tests := []struct {
+		name          string
+		normalization *esv1beta1.ExternalSecretNormalization
+		want          map[string][]byte
+		wantErr       bool
+	}{
+		{
+			name:          "no normalization",
+			normalization: nil,
+			want:          in,
+		},
+		{
+			name: "trailing newline",
+			normalization: &esv1beta1.ExternalSecretNormalization{
+				Trim: esv1beta1.ExternalSecretTrimTrailingNewline,
+			},
+			want: map[string][]byte{
+				"conn": []byte("  Server=db;\r\nDatabase=app"),
+				"mac":  []byte("line1\rline2"),
+			},
+		},
+		{
+			name: "line endings",
+			normalization: &esv1beta1.ExternalSecretNormalization{
+				LineEndings: esv1beta1.ExternalSecretLineEndingsLF,
+			},
+			want: map[string][]byte{
+				"conn": []byte("  Server=db;\nDatabase=app\n"),
+				"mac":  []byte("line1\nline2\n\n"),
+			},
+		},
+		{
+			name: "line endings and whitespace",
+			normalization: &esv1beta1.ExternalSecretNormalization{
+				LineEndings: esv1beta1.ExternalSecretLineEndingsLF,
+				Trim:        esv1beta1.ExternalSecretTrimWhitespace,
+			},
+			want: map[string][]byte{
+				"conn": []byte("Server=db;\nDatabase=app"),
+				"mac":  []byte("line1\nline2"),
+			},
+		},
+		{
+			name: "unsupported trim policy",
+			normalization: &esv1beta1.ExternalSecretNormalization{
+				Trim: "Everything",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeMap(tt.normalization, in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NormalizeMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeMap() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	in := map[string][]byte{
+		"database": []byte(`{"user":"app","password":"secret","port":5432,"replicas":["a","b"]}`),
+		"id":       []byte(`12345678901234567890`),
+		"other":    []byte("plain"),
+	}
+	tests := []struct {
+		name       string
+		expression string
+		want       map[string][]byte
+		wantErr    bool
+	}{
+		{
+			name:       "no filter",
+			expression: "",
+			want:       in,
+		},
+		{
+			name:       "nested object",
+			expression: "database",
+			want: map[string][]byte{
+				"user":     []byte("app"),
+				"password": []byte("secret"),
+				"port":     []byte("5432"),
+				"replicas": []byte(`["a","b"]`),
+			},
+		},
+		{
+			name:       "multi select",
+			expression: "{user: database.user, other: other, id: id, missing: missing}",
+			want: map[string][]byte{
+				"user":  []byte("app"),
+				"other": []byte("plain"),
+				"id":    []byte("12345678901234567890"),
+			},
+		},
+		{
+			name:       "not an object",
+			expression: "database.user",
+			wantErr:    true,
+		},
+		{
+			name:       "invalid expression",
+			expression: "database.[",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterMap(tt.expression, in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterMap() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterMap() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDecode(t *testing.T) {
 	type args struct {
 		strategy esv1beta1.ExternalSecretDecodingStrategy
@@ -414,6 +544,46 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestRewriteOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		operation esv1beta1.ExternalSecretRewriteOperation
+		want      string
+		wantErr   bool
+	}{
+		{name: "to upper", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationToUpper}, want: "APP/DATABASEURL"},
+		{name: "to lower", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationToLower}, want: "app/databaseurl"},
+		{name: "kebab case", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationKebabCase}, want: "app/database-url"},
+		{name: "snake case", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationSnakeCase}, want: "app/database_url"},
+		{name: "trim prefix", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationTrimPrefix, Value: "app/"}, want: "DatabaseURL"},
+		{name: "trim suffix", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationTrimSuffix, Value: "URL"}, want: "app/Database"},
+		{name: "base64 encode", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationBase64Encode}, want: "YXBwL0RhdGFiYXNlVVJM"},
+		{name: "sha256", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationSHA256}, want: "4f9c861568dd29335c494e5d913fc64cb1b5c1988b1b36bc388993e71622907c"},
+		{name: "base64 decode", key: "ZGItcGFzc3dvcmQ=", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationBase64Decode}, want: "db-password"},
+		{name: "base64 decode invalid", key: "db-password!", operation: esv1beta1.ExternalSecretRewriteOperation{Type: esv1beta1.RewriteOperationBase64Decode}, wantErr: true},
+		{name: "unknown operation", operation: esv1beta1.ExternalSecretRewriteOperation{Type: "Reverse"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := "app/DatabaseURL"
+			if tt.key != "" {
+				key = tt.key
+			}
+			got, err := RewriteOperation(tt.operation, map[string][]byte{key: []byte("bar")})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RewriteOperation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if _, ok := got[tt.want]; !ok || len(got) != 1 {
+				t.Errorf("RewriteOperation() = %v, want key %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRewrite(t *testing.T) {
 	type args struct {
 		operations []esv1beta1.ExternalSecretRewrite